@@ -321,8 +321,16 @@ func (a *apmplusHandler) OnEnd(ctx context.Context, info *callbacks.RunInfo, out
 	switch info.Component {
 	case components.ComponentOfEmbedding:
 		if ecbo := embedding.ConvCallbackOutput(output); ecbo != nil {
+			responseModel := ""
 			if ecbo.Config != nil {
 				span.SetAttributes(attribute.String("gen_ai.response.model", ecbo.Config.Model))
+				responseModel = ecbo.Config.Model
+			}
+			if usage, estimated := extractEmbeddingUsage(ecbo); usage != nil {
+				span.SetAttributes(attribute.Int("gen_ai.usage.total_tokens", usage.TotalTokens))
+				span.SetAttributes(attribute.Int("gen_ai.usage.prompt_tokens", usage.PromptTokens))
+				span.SetAttributes(attribute.Bool("gen_ai.usage.estimated", estimated))
+				a.addEmbeddingTokenUsage(ctx, usage, responseModel, estimated)
 			}
 		}
 	case components.ComponentOfChatModel:
@@ -693,3 +701,20 @@ func (a *apmplusHandler) AddTokenUsage(ctx context.Context, usage *model.TokenUs
 		))
 	}
 }
+
+// addEmbeddingTokenUsage records embedding usage in the same histogram as chat usage,
+// distinguished by the gen_ai_operation_name attribute. Embeddings have no output tokens.
+func (a *apmplusHandler) addEmbeddingTokenUsage(ctx context.Context, usage *embedding.TokenUsage, responseModel string, estimated bool) {
+	a.tokenUsage.Record(ctx, int64(usage.TotalTokens), metric.WithAttributes(
+		attribute.String("gen_ai_response_model", responseModel),
+		attribute.String("gen_ai_operation_name", "embeddings"),
+		attribute.String("gen_ai_token_type", "total"),
+		attribute.Bool("estimated", estimated),
+	))
+	a.tokenUsage.Record(ctx, int64(usage.PromptTokens), metric.WithAttributes(
+		attribute.String("gen_ai_response_model", responseModel),
+		attribute.String("gen_ai_operation_name", "embeddings"),
+		attribute.String("gen_ai_token_type", "input"),
+		attribute.Bool("estimated", estimated),
+	))
+}
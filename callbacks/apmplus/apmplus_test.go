@@ -25,9 +25,12 @@ import (
 	"github.com/bytedance/mockey"
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
 	"github.com/cloudwego/eino/components/model"
 	"github.com/cloudwego/eino/compose"
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/libs/acl/tokenusage"
 )
 
 func TestApmplusCallback(t *testing.T) {
@@ -127,6 +130,24 @@ func TestApmplusCallback(t *testing.T) {
 		})
 	})
 
+	mockey.PatchConvey("test embedding", t, func() {
+		info := &callbacks.RunInfo{Component: components.ComponentOfEmbedding, Type: "Ollama"}
+		ctx1 := cbh.OnStart(ctx, info, &embedding.CallbackInput{
+			Texts:  []string{"hello"},
+			Config: &embedding.Config{Model: "model"},
+		})
+		_, ok := ctx1.Value(apmplusStateKey{}).(*apmplusState)
+		if !ok {
+			t.Fatal("except state exists")
+		}
+		cbh.OnEnd(ctx1, info, &embedding.CallbackOutput{
+			Embeddings: [][]float64{{0.1, 0.2}},
+			Config:     &embedding.Config{Model: "model"},
+			TokenUsage: &embedding.TokenUsage{PromptTokens: 2, TotalTokens: 2},
+			Extra:      map[string]any{tokenusage.ExtraKeyEstimated: true},
+		})
+	})
+
 	mockey.PatchConvey("test generation stream", t, func() {
 		insr, insw := schema.Pipe[callbacks.CallbackInput](3)
 		insw.Send(&model.CallbackInput{
@@ -2,11 +2,14 @@ module github.com/cloudwego/eino-ext/callbacks/apmplus
 
 go 1.23.0
 
+replace github.com/cloudwego/eino-ext/libs/acl/tokenusage => ../../libs/acl/tokenusage
+
 require (
 	github.com/bytedance/mockey v1.2.14
 	github.com/bytedance/sonic v1.13.2
 	github.com/cloudwego/eino v0.3.51
 	github.com/cloudwego/eino-ext/libs/acl/opentelemetry v0.0.0-20250225080340-5935633151d3
+	github.com/cloudwego/eino-ext/libs/acl/tokenusage v0.0.0-00010101000000-000000000000
 	github.com/smartystreets/goconvey v1.8.1
 	go.opentelemetry.io/contrib/instrumentation/runtime v0.59.0
 	go.opentelemetry.io/otel v1.34.0
@@ -22,8 +22,11 @@ import (
 	"strings"
 
 	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/embedding"
 	"github.com/cloudwego/eino/components/model"
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/libs/acl/tokenusage"
 )
 
 func getName(info *callbacks.RunInfo) string {
@@ -161,3 +164,11 @@ func convSchemaMessage(in []*schema.Message) []*model.CallbackInput {
 	}
 	return ret
 }
+
+func extractEmbeddingUsage(out *embedding.CallbackOutput) (usage *embedding.TokenUsage, estimated bool) {
+	if out == nil || out.TokenUsage == nil {
+		return nil, false
+	}
+	estimated, _ = out.Extra[tokenusage.ExtraKeyEstimated].(bool)
+	return out.TokenUsage, estimated
+}
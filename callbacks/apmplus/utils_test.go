@@ -23,9 +23,12 @@ import (
 	"github.com/bytedance/mockey"
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
 	"github.com/cloudwego/eino/components/model"
 	"github.com/cloudwego/eino/schema"
 	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/libs/acl/tokenusage"
 )
 
 func Test_getName(t *testing.T) {
@@ -272,3 +275,26 @@ func Test_convSchemaMessage(t *testing.T) {
 		convey.So(actual, convey.ShouldResemble, expected)
 	})
 }
+
+func Test_extractEmbeddingUsage(t *testing.T) {
+	mockey.PatchConvey("Test extractEmbeddingUsage without usage", t, func() {
+		usage, estimated := extractEmbeddingUsage(&embedding.CallbackOutput{})
+		convey.So(usage, convey.ShouldBeNil)
+		convey.So(estimated, convey.ShouldBeFalse)
+	})
+	mockey.PatchConvey("Test extractEmbeddingUsage with reported usage", t, func() {
+		usage, estimated := extractEmbeddingUsage(&embedding.CallbackOutput{
+			TokenUsage: &embedding.TokenUsage{PromptTokens: 3, TotalTokens: 3},
+		})
+		convey.So(usage, convey.ShouldResemble, &embedding.TokenUsage{PromptTokens: 3, TotalTokens: 3})
+		convey.So(estimated, convey.ShouldBeFalse)
+	})
+	mockey.PatchConvey("Test extractEmbeddingUsage with estimated usage", t, func() {
+		usage, estimated := extractEmbeddingUsage(&embedding.CallbackOutput{
+			TokenUsage: &embedding.TokenUsage{PromptTokens: 3, TotalTokens: 3},
+			Extra:      map[string]any{tokenusage.ExtraKeyEstimated: true},
+		})
+		convey.So(usage, convey.ShouldNotBeNil)
+		convey.So(estimated, convey.ShouldBeTrue)
+	})
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package langfuse
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/cloudwego/eino-ext/libs/acl/langfuse"
+	"github.com/cloudwego/eino-ext/libs/acl/tokenusage"
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+// embeddingOutput summarizes the embeddings instead of reporting the vectors.
+type embeddingOutput struct {
+	Count      int `json:"count"`
+	Dimensions int `json:"dimensions"`
+}
+
+// startEmbedding reports an embedding call as a generation, so that its usage is
+// aggregated by langfuse together with chat model usage.
+func (c *CallbackHandler) startEmbedding(ctx context.Context, info *callbacks.RunInfo, state *langfuseState, input callbacks.CallbackInput) context.Context {
+	ecbi := embedding.ConvCallbackInput(input)
+
+	body := &langfuse.GenerationEventBody{
+		BaseObservationEventBody: langfuse.BaseObservationEventBody{
+			BaseEventBody: langfuse.BaseEventBody{
+				Name: getName(info),
+			},
+			TraceID:             state.traceID,
+			ParentObservationID: state.observationID,
+			StartTime:           time.Now(),
+		},
+	}
+	if ecbi != nil {
+		body.MetaData = ecbi.Extra
+		if in, err := sonic.MarshalString(ecbi.Texts); err == nil {
+			body.Input = in
+		}
+		if ecbi.Config != nil {
+			body.Model = ecbi.Config.Model
+			body.ModelParameters = ecbi.Config
+		}
+	}
+
+	generationID, err := c.cli.CreateGeneration(body)
+	if err != nil {
+		log.Printf("create generation error: %v, runinfo: %+v", err, info)
+		return ctx
+	}
+	return context.WithValue(ctx, langfuseStateKey{}, &langfuseState{
+		traceID:       state.traceID,
+		observationID: generationID,
+	})
+}
+
+func (c *CallbackHandler) endEmbedding(info *callbacks.RunInfo, state *langfuseState, output callbacks.CallbackOutput) {
+	ecbo := embedding.ConvCallbackOutput(output)
+
+	body := &langfuse.GenerationEventBody{
+		BaseObservationEventBody: langfuse.BaseObservationEventBody{
+			BaseEventBody: langfuse.BaseEventBody{
+				ID: state.observationID,
+			},
+		},
+		EndTime: time.Now(),
+	}
+	if ecbo != nil {
+		out := &embeddingOutput{Count: len(ecbo.Embeddings)}
+		if len(ecbo.Embeddings) > 0 {
+			out.Dimensions = len(ecbo.Embeddings[0])
+		}
+		if s, err := sonic.MarshalString(out); err == nil {
+			body.Output = s
+		}
+		if ecbo.TokenUsage != nil {
+			body.Usage = &langfuse.Usage{
+				PromptTokens:     ecbo.TokenUsage.PromptTokens,
+				CompletionTokens: ecbo.TokenUsage.CompletionTokens,
+				TotalTokens:      ecbo.TokenUsage.TotalTokens,
+			}
+			if estimated, _ := ecbo.Extra[tokenusage.ExtraKeyEstimated].(bool); estimated {
+				body.MetaData = map[string]any{tokenusage.ExtraKeyEstimated: true}
+			}
+		}
+	}
+
+	if err := c.cli.EndGeneration(body); err != nil {
+		log.Printf("end generation error: %v, runinfo: %+v", err, info)
+	}
+}
+
+func (c *CallbackHandler) errorEmbedding(info *callbacks.RunInfo, state *langfuseState, err error) {
+	body := &langfuse.GenerationEventBody{
+		BaseObservationEventBody: langfuse.BaseObservationEventBody{
+			BaseEventBody: langfuse.BaseEventBody{
+				ID: state.observationID,
+			},
+			Output: err.Error(),
+			Level:  langfuse.LevelTypeERROR,
+		},
+		EndTime: time.Now(),
+	}
+
+	if reportErr := c.cli.EndGeneration(body); reportErr != nil {
+		log.Printf("end generation fail: %v, runinfo: %+v, execute error: %v", reportErr, info, err)
+	}
+}
@@ -195,6 +195,9 @@ func (c *CallbackHandler) OnStart(ctx context.Context, info *callbacks.RunInfo,
 	if state == nil {
 		return ctx
 	}
+	if info.Component == components.ComponentOfEmbedding {
+		return c.startEmbedding(ctx, info, state, input)
+	}
 	if info.Component == components.ComponentOfChatModel {
 		mcbi := model.ConvCallbackInput(input)
 
@@ -262,6 +265,10 @@ func (c *CallbackHandler) OnEnd(ctx context.Context, info *callbacks.RunInfo, ou
 		return ctx
 	}
 
+	if info.Component == components.ComponentOfEmbedding {
+		c.endEmbedding(info, state, output)
+		return ctx
+	}
 	if info.Component == components.ComponentOfChatModel {
 		mcbo := model.ConvCallbackOutput(output)
 
@@ -321,6 +328,10 @@ func (c *CallbackHandler) OnError(ctx context.Context, info *callbacks.RunInfo,
 		return ctx
 	}
 
+	if info.Component == components.ComponentOfEmbedding {
+		c.errorEmbedding(info, state, err)
+		return ctx
+	}
 	if info.Component == components.ComponentOfChatModel {
 		body := &langfuse.GenerationEventBody{
 			BaseObservationEventBody: langfuse.BaseObservationEventBody{
@@ -26,8 +26,10 @@ import (
 	"github.com/bytedance/mockey"
 	"github.com/cloudwego/eino-ext/libs/acl/langfuse"
 	"github.com/cloudwego/eino-ext/libs/acl/langfuse/mock"
+	"github.com/cloudwego/eino-ext/libs/acl/tokenusage"
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
 	"github.com/cloudwego/eino/components/model"
 	"github.com/cloudwego/eino/compose"
 	"github.com/cloudwego/eino/schema"
@@ -220,6 +222,33 @@ func TestLangfuseCallback(t *testing.T) {
 		})
 	})
 
+	mockey.PatchConvey("test embedding generation", t, func() {
+		mockLangfuse.EXPECT().CreateTrace(gomock.Any()).Return("trace id", nil).Times(1)
+		mockLangfuse.EXPECT().CreateGeneration(gomock.Any()).DoAndReturn(func(body *langfuse.GenerationEventBody) (string, error) {
+			assert.Equal(t, "embedding-model", body.Model)
+			assert.Equal(t, `["hello","world"]`, body.Input)
+			return "embedding generation id", nil
+		}).Times(1)
+		mockLangfuse.EXPECT().EndGeneration(gomock.Any()).DoAndReturn(func(body *langfuse.GenerationEventBody) error {
+			assert.Equal(t, "embedding generation id", body.ID)
+			assert.Equal(t, `{"count":2,"dimensions":3}`, body.Output)
+			assert.Equal(t, &langfuse.Usage{PromptTokens: 4, TotalTokens: 4}, body.Usage)
+			assert.Equal(t, map[string]any{tokenusage.ExtraKeyEstimated: true}, body.MetaData)
+			return nil
+		}).Times(1)
+
+		info := &callbacks.RunInfo{Component: components.ComponentOfEmbedding, Type: "Ollama"}
+		ctx1 := cbh.OnStart(ctx, info, &embedding.CallbackInput{
+			Texts:  []string{"hello", "world"},
+			Config: &embedding.Config{Model: "embedding-model"},
+		})
+		cbh.OnEnd(ctx1, info, &embedding.CallbackOutput{
+			Embeddings: [][]float64{{1, 2, 3}, {4, 5, 6}},
+			TokenUsage: &embedding.TokenUsage{PromptTokens: 4, TotalTokens: 4},
+			Extra:      map[string]any{tokenusage.ExtraKeyEstimated: true},
+		})
+	})
+
 	mockey.PatchConvey("test generation stream", t, func() {
 		mockLangfuse.EXPECT().CreateTrace(gomock.Any()).Return("trace id", nil).Times(1)
 		mockLangfuse.EXPECT().CreateGeneration(gomock.Any()).DoAndReturn(func(body *langfuse.GenerationEventBody) (string, error) {
@@ -0,0 +1,50 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+const typ = "GraphExtractor"
+
+func GetType() string {
+	return typ
+}
+
+const (
+	defaultConcurrency = 4
+)
+
+const (
+	// MetaKeyEntities and MetaKeyRelations are the metadata keys of the entities and relations extracted from a document,
+	// read by the graph indexer of github.com/cloudwego/eino-ext/components/indexer/graph.
+	// Their values are []map[string]any with the json fields of Entity and Relation, so they survive json round trips.
+	MetaKeyEntities  = "_kg_entities"
+	MetaKeyRelations = "_kg_relations"
+)
+
+// DefaultEntityTypes are the entity types suggested to the model by default.
+var DefaultEntityTypes = []string{"person", "organization", "location", "product", "technology", "event", "concept"}
+
+// DefaultPrompt asks for entities and relations as json, "{entity_types}" is replaced by the entity types,
+// and "{text}" by the content of a document.
+const DefaultPrompt = `Extract the entities and the relations between them from the text below, to build a knowledge graph.
+Entity types: {entity_types}.
+Describe each entity and relation in one short sentence based on the text. The source and target of a relation are entity names.
+Use the same name for every mention of an entity. Don't extract anything the text doesn't state.
+Output only a json object in the format below, without any other text:
+{"entities": [{"name": "...", "type": "...", "description": "..."}], "relations": [{"source": "...", "target": "...", "type": "...", "description": "..."}]}
+
+Text:
+{text}`
@@ -0,0 +1,80 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/extractor/graph"
+)
+
+func main() {
+	ctx := context.Background()
+
+	extractor, err := graph.NewExtractor(ctx, &graph.ExtractorConfig{
+		ChatModel:    &cannedChatModel{}, // replace it with real chat model component
+		EntityTypes:  []string{"person", "organization", "location"},
+		Concurrency:  2,
+		SkipFailures: true,
+	})
+	if err != nil {
+		log.Fatalf("NewExtractor failed, err=%v", err)
+	}
+
+	docs, err := extractor.Transform(ctx, []*schema.Document{
+		{ID: "1", Content: "Alice works at Acme Corp.", MetaData: map[string]any{"_source": "people.md"}},
+	})
+	if err != nil {
+		log.Fatalf("Transform failed, err=%v", err)
+	}
+	for _, doc := range docs {
+		log.Printf("id=%s, entities=%v, relations=%v", doc.ID,
+			doc.MetaData[graph.MetaKeyEntities], doc.MetaData[graph.MetaKeyRelations])
+	}
+
+	result, err := extractor.Extract(ctx, "Alice works at Acme Corp.")
+	if err != nil {
+		log.Fatalf("Extract failed, err=%v", err)
+	}
+	for _, r := range result.Relations {
+		log.Printf("%s -[%s]-> %s", r.Source, r.Type, r.Target)
+	}
+}
+
+// cannedChatModel replies a canned extraction, only for the example.
+type cannedChatModel struct{}
+
+func (c *cannedChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	return schema.AssistantMessage(`{"entities": [
+		{"name": "Alice", "type": "person", "description": "an employee of Acme Corp"},
+		{"name": "Acme Corp", "type": "organization", "description": "the employer of Alice"}
+	], "relations": [
+		{"source": "Alice", "target": "Acme Corp", "type": "works at", "description": "Alice works at Acme Corp"}
+	]}`, nil), nil
+}
+
+func (c *cannedChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	msg, err := c.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return schema.StreamReaderFromArray([]*schema.Message{msg}), nil
+}
@@ -0,0 +1,310 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Entity is a thing the text talks about, e.g. a person or a product.
+type Entity struct {
+	Name        string `json:"name"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Relation is a directed relation between two entities, Source and Target are entity names.
+type Relation struct {
+	Source      string `json:"source"`
+	Target      string `json:"target"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+}
+
+// Result is what Extract extracts from a text.
+type Result struct {
+	Entities  []*Entity   `json:"entities"`
+	Relations []*Relation `json:"relations"`
+}
+
+type ExtractorConfig struct {
+	// ChatModel extracts the entities and relations of each document.
+	// Required.
+	ChatModel model.BaseChatModel
+	// Prompt of the extraction, "{entity_types}" is replaced by EntityTypes and "{text}" by the content of a document.
+	// The reply should be a json object in the format of DefaultPrompt, optionally in a markdown code block.
+	// Default DefaultPrompt.
+	Prompt string
+	// EntityTypes are suggested to the model, entities of other types are kept.
+	// Default DefaultEntityTypes.
+	EntityTypes []string
+	// Concurrency max number of documents extracted at the same time.
+	// Default 4.
+	Concurrency int
+	// SkipFailures passes documents whose extraction fails through without graph metadata, instead of failing Transform.
+	// Default false.
+	SkipFailures bool
+}
+
+// Extractor is a document.Transformer which extracts entities and relations from the content of documents by a chat model,
+// and writes them to the MetaKeyEntities and MetaKeyRelations metadata of the returned copies, for the graph indexer.
+type Extractor struct {
+	config *ExtractorConfig
+}
+
+func NewExtractor(_ context.Context, config *ExtractorConfig) (*Extractor, error) {
+	if config.ChatModel == nil {
+		return nil, fmt.Errorf("[NewExtractor] chat model not provided")
+	}
+
+	if config.Prompt == "" {
+		config.Prompt = DefaultPrompt
+	}
+
+	if len(config.EntityTypes) == 0 {
+		config.EntityTypes = DefaultEntityTypes
+	}
+
+	if config.Concurrency == 0 {
+		config.Concurrency = defaultConcurrency
+	}
+
+	if config.Concurrency < 0 {
+		return nil, fmt.Errorf("[NewExtractor] invalid concurrency: %d", config.Concurrency)
+	}
+
+	return &Extractor{
+		config: config,
+	}, nil
+}
+
+// Transform returns copies of docs carrying the extracted entities and relations in metadata,
+// documents with empty content are copied without them.
+func (e *Extractor) Transform(ctx context.Context, docs []*schema.Document, opts ...document.TransformerOption) (output []*schema.Document, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, e.GetType(), components.ComponentOfTransformer)
+	ctx = callbacks.OnStart(ctx, &document.TransformerCallbackInput{Input: docs})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	results := make([]*Result, len(docs))
+	errs := make([]error, len(docs))
+	e.parallel(len(docs), func(i int) (err error) {
+		if docs[i] == nil || strings.TrimSpace(docs[i].Content) == "" {
+			return nil
+		}
+		results[i], err = e.Extract(ctx, docs[i].Content)
+		return err
+	}, errs)
+
+	output = make([]*schema.Document, 0, len(docs))
+	for i, doc := range docs {
+		if doc == nil {
+			continue
+		}
+		if errs[i] != nil && !e.config.SkipFailures {
+			return nil, fmt.Errorf("[GraphExtractor] extract document failed, id=%s, %w", doc.ID, errs[i])
+		}
+
+		metadata := make(map[string]any, len(doc.MetaData)+2)
+		for k, v := range doc.MetaData {
+			metadata[k] = v
+		}
+		if results[i] != nil {
+			metadata[MetaKeyEntities] = entityMaps(results[i].Entities)
+			metadata[MetaKeyRelations] = relationMaps(results[i].Relations)
+		}
+		output = append(output, &schema.Document{ID: doc.ID, Content: doc.Content, MetaData: metadata})
+	}
+
+	callbacks.OnEnd(ctx, &document.TransformerCallbackOutput{Output: output})
+
+	return output, nil
+}
+
+// Extract asks the chat model for the entities and relations of text. Entities of the same name are merged,
+// relations between an entity and itself are dropped, and relation endpoints missing from the entities are added.
+// It can also extract the entities of a query for the graph retriever.
+func (e *Extractor) Extract(ctx context.Context, text string) (*Result, error) {
+	prompt := strings.NewReplacer("{entity_types}", strings.Join(e.config.EntityTypes, ", "), "{text}", text).Replace(e.config.Prompt)
+
+	msg, err := e.config.ChatModel.Generate(e.makeChatModelCtx(ctx), []*schema.Message{schema.UserMessage(prompt)})
+	if err != nil {
+		return nil, fmt.Errorf("[Extract] generate failed, %w", err)
+	}
+
+	return parseResult(msg.Content)
+}
+
+func (e *Extractor) GetType() string {
+	return typ
+}
+
+func (e *Extractor) IsCallbacksEnabled() bool {
+	return true
+}
+
+// parallel runs fn for 0 to n-1 with at most Concurrency running, errs[i] is the error of fn(i) or its panic.
+func (e *Extractor) parallel(n int, fn func(i int) error, errs []error) {
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, e.config.Concurrency)
+	)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			defer func() {
+				if r := recover(); r != nil {
+					errs[i] = fmt.Errorf("panic: %v", r)
+				}
+			}()
+			errs[i] = fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func (e *Extractor) makeChatModelCtx(ctx context.Context) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfChatModel,
+	}
+
+	if modelType, ok := components.GetType(e.config.ChatModel); ok {
+		runInfo.Type = modelType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+// parseResult parses the json object of reply, which models tend to wrap in a markdown code block or some text.
+func parseResult(reply string) (*Result, error) {
+	start, end := strings.Index(reply, "{"), strings.LastIndex(reply, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("[parseResult] json object not found in reply: %s", reply)
+	}
+
+	raw := &Result{}
+	if err := json.Unmarshal([]byte(reply[start:end+1]), raw); err != nil {
+		return nil, fmt.Errorf("[parseResult] unmarshal reply failed, %w", err)
+	}
+
+	result := &Result{Entities: []*Entity{}, Relations: []*Relation{}}
+	entities := make(map[string]*Entity)
+	addEntity := func(entity *Entity) {
+		key := nameKey(entity.Name)
+		if key == "" {
+			return
+		}
+		if existing, found := entities[key]; found {
+			if existing.Type == "" {
+				existing.Type = entity.Type
+			}
+			if existing.Description == "" {
+				existing.Description = entity.Description
+			}
+			return
+		}
+		entities[key] = entity
+		result.Entities = append(result.Entities, entity)
+	}
+
+	for _, entity := range raw.Entities {
+		if entity != nil {
+			addEntity(&Entity{
+				Name:        strings.TrimSpace(entity.Name),
+				Type:        strings.TrimSpace(entity.Type),
+				Description: strings.TrimSpace(entity.Description),
+			})
+		}
+	}
+
+	seen := make(map[string]struct{})
+	for _, relation := range raw.Relations {
+		if relation == nil {
+			continue
+		}
+		r := &Relation{
+			Source:      strings.TrimSpace(relation.Source),
+			Target:      strings.TrimSpace(relation.Target),
+			Type:        strings.TrimSpace(relation.Type),
+			Description: strings.TrimSpace(relation.Description),
+		}
+		source, target := nameKey(r.Source), nameKey(r.Target)
+		if source == "" || target == "" || source == target {
+			continue
+		}
+		key := source + "\x00" + nameKey(r.Type) + "\x00" + target
+		if _, found := seen[key]; found {
+			continue
+		}
+		seen[key] = struct{}{}
+		addEntity(&Entity{Name: r.Source})
+		addEntity(&Entity{Name: r.Target})
+		result.Relations = append(result.Relations, r)
+	}
+
+	return result, nil
+}
+
+// nameKey normalizes names as NodeKey of the graph indexer does, lower case with spaces collapsed.
+func nameKey(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+func entityMaps(entities []*Entity) []map[string]any {
+	maps := make([]map[string]any, 0, len(entities))
+	for _, e := range entities {
+		m := map[string]any{"name": e.Name}
+		if e.Type != "" {
+			m["type"] = e.Type
+		}
+		if e.Description != "" {
+			m["description"] = e.Description
+		}
+		maps = append(maps, m)
+	}
+	return maps
+}
+
+func relationMaps(relations []*Relation) []map[string]any {
+	maps := make([]map[string]any, 0, len(relations))
+	for _, r := range relations {
+		m := map[string]any{"source": r.Source, "target": r.Target, "type": r.Type}
+		if r.Description != "" {
+			m["description"] = r.Description
+		}
+		maps = append(maps, m)
+	}
+	return maps
+}
@@ -0,0 +1,186 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+// mockChatModel replies the reply of the first key contained in the prompt.
+type mockChatModel struct {
+	mu      sync.Mutex
+	replies map[string]string
+	prompts []string
+}
+
+func (m *mockChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	prompt := input[len(input)-1].Content
+	m.mu.Lock()
+	m.prompts = append(m.prompts, prompt)
+	m.mu.Unlock()
+
+	for key, reply := range m.replies {
+		if strings.Contains(prompt, key) {
+			if reply == "error" {
+				return nil, errors.New("mock")
+			}
+			if reply == "panic" {
+				panic("mock")
+			}
+			return schema.AssistantMessage(reply, nil), nil
+		}
+	}
+	return schema.AssistantMessage(`{"entities": [], "relations": []}`, nil), nil
+}
+
+func (m *mockChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestExtractor(t *testing.T) {
+	convey.Convey("test graph extractor", t, func() {
+		ctx := context.Background()
+		cm := &mockChatModel{replies: map[string]string{
+			"Alice": "```json\n" + `{"entities": [
+				{"name": "Alice", "type": "person", "description": "an engineer"},
+				{"name": " alice ", "type": "", "description": "duplicate"},
+				{"name": "Acme Corp", "type": "organization"},
+				{"name": ""}
+			], "relations": [
+				{"source": "Alice", "target": "Acme Corp", "type": "works at", "description": "since 2020"},
+				{"source": "alice", "target": "ACME corp", "type": "Works At"},
+				{"source": "Acme Corp", "target": "Berlin", "type": "based in"},
+				{"source": "Alice", "target": "alice", "type": "is"},
+				{"source": "", "target": "Alice", "type": "knows"}
+			]}` + "\n```",
+			"broken":  "no json here",
+			"invalid": `{"entities": "Alice"}`,
+			"failure": "error",
+			"crash":   "panic",
+		}}
+
+		_, err := NewExtractor(ctx, &ExtractorConfig{})
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = NewExtractor(ctx, &ExtractorConfig{ChatModel: cm, Concurrency: -1})
+		convey.So(err, convey.ShouldNotBeNil)
+
+		e, err := NewExtractor(ctx, &ExtractorConfig{ChatModel: cm})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(e.config.Prompt, convey.ShouldEqual, DefaultPrompt)
+		convey.So(e.config.EntityTypes, convey.ShouldResemble, DefaultEntityTypes)
+		convey.So(e.config.Concurrency, convey.ShouldEqual, defaultConcurrency)
+		convey.So(e.GetType(), convey.ShouldEqual, typ)
+		convey.So(e.IsCallbacksEnabled(), convey.ShouldBeTrue)
+
+		convey.Convey("test extract", func() {
+			result, err := e.Extract(ctx, "Alice works at Acme Corp in Berlin.")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(result, convey.ShouldResemble, &Result{
+				Entities: []*Entity{
+					{Name: "Alice", Type: "person", Description: "an engineer"},
+					{Name: "Acme Corp", Type: "organization"},
+					{Name: "Berlin"},
+				},
+				Relations: []*Relation{
+					{Source: "Alice", Target: "Acme Corp", Type: "works at", Description: "since 2020"},
+					{Source: "Acme Corp", Target: "Berlin", Type: "based in"},
+				},
+			})
+			convey.So(cm.prompts[0], convey.ShouldContainSubstring, "Entity types: person, organization")
+			convey.So(cm.prompts[0], convey.ShouldEndWith, "Alice works at Acme Corp in Berlin.")
+
+			_, err = e.Extract(ctx, "broken")
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = e.Extract(ctx, "invalid")
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = e.Extract(ctx, "failure")
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test transform", func() {
+			var input, output int
+			ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, callbacks.NewHandlerBuilder().
+				OnStartFn(func(ctx context.Context, info *callbacks.RunInfo, in callbacks.CallbackInput) context.Context {
+					if info.Component == "DocumentTransformer" {
+						input = len(document.ConvTransformerCallbackInput(in).Input)
+					}
+					return ctx
+				}).
+				OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, out callbacks.CallbackOutput) context.Context {
+					if info.Component == "DocumentTransformer" {
+						output = len(document.ConvTransformerCallbackOutput(out).Output)
+					}
+					return ctx
+				}).Build())
+
+			docs := []*schema.Document{
+				{ID: "1", Content: "Alice works at Acme Corp.", MetaData: map[string]any{"_source": "a.md"}},
+				{ID: "2", Content: "  "},
+				nil,
+				{ID: "3", Content: "nothing to extract"},
+			}
+			out, err := e.Transform(ctx, docs)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(input, convey.ShouldEqual, 4)
+			convey.So(output, convey.ShouldEqual, 3)
+			convey.So(len(cm.prompts), convey.ShouldEqual, 2)
+
+			convey.So(out[0].MetaData["_source"], convey.ShouldEqual, "a.md")
+			convey.So(out[0].MetaData[MetaKeyEntities], convey.ShouldResemble, []map[string]any{
+				{"name": "Alice", "type": "person", "description": "an engineer"},
+				{"name": "Acme Corp", "type": "organization"},
+				{"name": "Berlin"},
+			})
+			convey.So(out[0].MetaData[MetaKeyRelations], convey.ShouldResemble, []map[string]any{
+				{"source": "Alice", "target": "Acme Corp", "type": "works at", "description": "since 2020"},
+				{"source": "Acme Corp", "target": "Berlin", "type": "based in"},
+			})
+			// the input isn't changed
+			convey.So(docs[0].MetaData, convey.ShouldResemble, map[string]any{"_source": "a.md"})
+
+			_, found := out[1].MetaData[MetaKeyEntities]
+			convey.So(found, convey.ShouldBeFalse)
+			convey.So(out[2].MetaData[MetaKeyEntities], convey.ShouldResemble, []map[string]any{})
+		})
+
+		convey.Convey("test failures", func() {
+			docs := []*schema.Document{{ID: "1", Content: "Alice"}, {ID: "2", Content: "failure"}, {ID: "3", Content: "crash"}}
+			_, err := e.Transform(ctx, docs)
+			convey.So(err, convey.ShouldNotBeNil)
+
+			e.config.SkipFailures = true
+			e.config.Concurrency = 1
+			out, err := e.Transform(ctx, docs)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(len(out), convey.ShouldEqual, 3)
+			_, found := out[1].MetaData[MetaKeyEntities]
+			convey.So(found, convey.ShouldBeFalse)
+			_, found = out[2].MetaData[MetaKeyEntities]
+			convey.So(found, convey.ShouldBeFalse)
+		})
+	})
+}
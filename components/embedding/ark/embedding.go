@@ -20,11 +20,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"sync"
 	"time"
 
-	"golang.org/x/sync/errgroup"
-
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components"
 	"github.com/cloudwego/eino/components/embedding"
@@ -202,41 +199,15 @@ func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...emb
 			embeddings[i] = toFloat64(d.Embedding)
 		}
 	} else {
-		mu := sync.Mutex{}
-		eg := errgroup.Group{}
-		eg.SetLimit(*e.conf.MaxConcurrentRequests)
-		usage = &embedding.TokenUsage{}
-		embeddings = make([][]float64, len(texts))
-
-		for i := 0; i < len(texts); i++ {
-			idx := i
-			text := texts[idx]
-
-			eg.Go(func() error {
-				res, err := e.client.CreateMultiModalEmbeddings(ctx, model.MultiModalEmbeddingRequest{
-					Input: []model.MultimodalEmbeddingInput{
-						{Type: model.MultiModalEmbeddingInputTypeText, Text: &text},
-					},
-					Model:          conf.Model,
-					EncodingFormat: &encodingFormat,
-				})
-				if err != nil {
-					return fmt.Errorf("[Ark] CreateMultiModalEmbeddings error: %w", err)
-				}
-
-				mu.Lock()
-				defer mu.Unlock()
-
-				usage.PromptTokens += res.Usage.PromptTokens
-				usage.CompletionTokens += res.Usage.TotalTokens - res.Usage.PromptTokens
-				usage.TotalTokens += res.Usage.TotalTokens
-				embeddings[idx] = toFloat64(res.Data.Embedding)
-
-				return nil
-			})
+		inputs := make([][]model.MultimodalEmbeddingInput, len(texts))
+		for i := range texts {
+			inputs[i] = []model.MultimodalEmbeddingInput{
+				{Type: model.MultiModalEmbeddingInputTypeText, Text: &texts[i]},
+			}
 		}
 
-		if err = eg.Wait(); err != nil {
+		embeddings, usage, err = e.createMultiModalEmbeddings(ctx, conf.Model, encodingFormat, inputs)
+		if err != nil {
 			return nil, err
 		}
 	}
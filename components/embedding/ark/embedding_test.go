@@ -27,6 +27,7 @@ import (
 	"github.com/volcengine/volcengine-go-sdk/service/arkruntime/model"
 
 	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
 )
 
 func Test_EmbedStrings(t *testing.T) {
@@ -133,5 +134,55 @@ func TestAPITypeMultiModal(t *testing.T) {
 				convey.So(res[1], convey.ShouldEqual, toFloat64(v))
 			})
 		})
+
+		PatchConvey("test EmbedMultiModal", func() {
+			at := APITypeMultiModal
+			mcr := 5
+			cfg := &EmbeddingConfig{APIType: &at, MaxConcurrentRequests: &mcr}
+			mockCli := &arkruntime.Client{}
+			emb := &Embedder{client: mockCli, conf: cfg}
+			inputs := [][]schema.ChatMessagePart{
+				{{Type: schema.ChatMessagePartTypeText, Text: "a cat"}},
+				{
+					{Type: schema.ChatMessagePartTypeText, Text: "a dog"},
+					{Type: schema.ChatMessagePartTypeImageURL, ImageURL: &schema.ChatMessageImageURL{URL: "data:image/png;base64,AA=="}},
+				},
+			}
+
+			PatchConvey("test text api type", func() {
+				textType := APITypeText
+				res, err := (&Embedder{client: mockCli, conf: &EmbeddingConfig{APIType: &textType}}).EmbedMultiModal(ctx, inputs)
+				convey.So(err, convey.ShouldNotBeNil)
+				convey.So(res, convey.ShouldBeNil)
+			})
+
+			PatchConvey("test unsupported part", func() {
+				res, err := emb.EmbedMultiModal(ctx, [][]schema.ChatMessagePart{{{Type: schema.ChatMessagePartTypeAudioURL}}})
+				convey.So(err, convey.ShouldNotBeNil)
+				convey.So(res, convey.ShouldBeNil)
+			})
+
+			PatchConvey("test success", func() {
+				v := []float32{0.1, 0.2, 0.3}
+				Mock(GetMethod(mockCli, "CreateMultiModalEmbeddings")).Return(model.MultimodalEmbeddingResponse{
+					Data:  model.MultimodalEmbedding{Embedding: v, Object: "embedding"},
+					Usage: model.MultimodalEmbeddingUsage{PromptTokens: 1, TotalTokens: 3},
+				}, nil).Build()
+
+				res, err := emb.EmbedMultiModal(ctx, inputs)
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(len(res), convey.ShouldEqual, 2)
+				convey.So(res[1], convey.ShouldEqual, toFloat64(v))
+			})
+
+			PatchConvey("test toArkInput", func() {
+				input, err := toArkInput(inputs[1])
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(len(input), convey.ShouldEqual, 2)
+				convey.So(*input[0].Text, convey.ShouldEqual, "a dog")
+				convey.So(input[1].Type, convey.ShouldEqual, model.MultiModalEmbeddingInputTypeImageURL)
+				convey.So(input[1].ImageURL.URL, convey.ShouldEqual, "data:image/png;base64,AA==")
+			})
+		})
 	})
 }
@@ -0,0 +1,164 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ark
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+	"github.com/volcengine/volcengine-go-sdk/service/arkruntime/model"
+	"golang.org/x/sync/errgroup"
+)
+
+// extraKeyMultiModalInputs matches multimodal.ExtraKeyInputs of github.com/cloudwego/eino-ext/components/embedding/multimodal.
+const extraKeyMultiModalInputs = "multimodal_inputs"
+
+// EmbedMultiModal embeds inputs consisting of text and image parts, each input is embedded into one vector.
+// Images can be a http(s) url or a base64 data url. Only available with APITypeMultiModal.
+func (e *Embedder) EmbedMultiModal(ctx context.Context, inputs [][]schema.ChatMessagePart, opts ...embedding.Option) (
+	embeddings [][]float64, err error) {
+
+	options := embedding.GetCommonOptions(&embedding.Options{
+		Model: &e.conf.Model,
+	}, opts...)
+	encodingFormat := model.EmbeddingEncodingFormatFloat
+	conf := &embedding.Config{
+		Model:          dereferenceOrZero(options.Model),
+		EncodingFormat: string(encodingFormat),
+	}
+
+	ctx = callbacks.EnsureRunInfo(ctx, e.GetType(), components.ComponentOfEmbedding)
+	ctx = callbacks.OnStart(ctx, &embedding.CallbackInput{
+		Texts:  iter(inputs, textOf),
+		Config: conf,
+		Extra:  map[string]any{extraKeyMultiModalInputs: inputs},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	if e.conf.APIType == nil || *e.conf.APIType != APITypeMultiModal {
+		return nil, fmt.Errorf("[Ark] EmbedMultiModal requires APITypeMultiModal")
+	}
+
+	arkInputs := make([][]model.MultimodalEmbeddingInput, len(inputs))
+	for i, input := range inputs {
+		if arkInputs[i], err = toArkInput(input); err != nil {
+			return nil, err
+		}
+	}
+
+	embeddings, usage, err := e.createMultiModalEmbeddings(ctx, conf.Model, encodingFormat, arkInputs)
+	if err != nil {
+		return nil, err
+	}
+
+	callbacks.OnEnd(ctx, &embedding.CallbackOutput{
+		Embeddings: embeddings,
+		Config:     conf,
+		TokenUsage: usage,
+	})
+
+	return embeddings, nil
+}
+
+// createMultiModalEmbeddings calls the multi-modal api concurrently, one request per input
+// since the api combines all parts of a request into a single embedding.
+func (e *Embedder) createMultiModalEmbeddings(ctx context.Context, modelName string, encodingFormat model.EmbeddingEncodingFormat,
+	inputs [][]model.MultimodalEmbeddingInput) ([][]float64, *embedding.TokenUsage, error) {
+
+	mu := sync.Mutex{}
+	eg := errgroup.Group{}
+	eg.SetLimit(*e.conf.MaxConcurrentRequests)
+	usage := &embedding.TokenUsage{}
+	embeddings := make([][]float64, len(inputs))
+
+	for i := 0; i < len(inputs); i++ {
+		idx := i
+
+		eg.Go(func() error {
+			res, err := e.client.CreateMultiModalEmbeddings(ctx, model.MultiModalEmbeddingRequest{
+				Input:          inputs[idx],
+				Model:          modelName,
+				EncodingFormat: &encodingFormat,
+			})
+			if err != nil {
+				return fmt.Errorf("[Ark] CreateMultiModalEmbeddings error: %w", err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			usage.PromptTokens += res.Usage.PromptTokens
+			usage.CompletionTokens += res.Usage.TotalTokens - res.Usage.PromptTokens
+			usage.TotalTokens += res.Usage.TotalTokens
+			embeddings[idx] = toFloat64(res.Data.Embedding)
+
+			return nil
+		})
+	}
+
+	if err := eg.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	return embeddings, usage, nil
+}
+
+func toArkInput(parts []schema.ChatMessagePart) ([]model.MultimodalEmbeddingInput, error) {
+	input := make([]model.MultimodalEmbeddingInput, 0, len(parts))
+	for i := range parts {
+		part := parts[i]
+		switch part.Type {
+		case schema.ChatMessagePartTypeText:
+			input = append(input, model.MultimodalEmbeddingInput{
+				Type: model.MultiModalEmbeddingInputTypeText,
+				Text: &part.Text,
+			})
+		case schema.ChatMessagePartTypeImageURL:
+			if part.ImageURL == nil {
+				return nil, fmt.Errorf("[Ark] image part without image url")
+			}
+			// data urls (RFC-2397) are accepted by the api as they are
+			input = append(input, model.MultimodalEmbeddingInput{
+				Type:     model.MultiModalEmbeddingInputTypeImageURL,
+				ImageURL: &model.MultimodalEmbeddingImageURL{URL: part.ImageURL.URL},
+			})
+		default:
+			return nil, fmt.Errorf("[Ark] unsupported multimodal part type: %s", part.Type)
+		}
+	}
+	return input, nil
+}
+
+func textOf(parts []schema.ChatMessagePart) string {
+	var texts []string
+	for _, part := range parts {
+		if part.Type == schema.ChatMessagePartTypeText && part.Text != "" {
+			texts = append(texts, part.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
@@ -30,3 +30,12 @@ func dereferenceOrZero[T any](v *T) T {
 
 	return *v
 }
+
+func iter[T, D any](src []T, fn func(T) D) []D {
+	resp := make([]D, len(src))
+	for i := range src {
+		resp[i] = fn(src[i])
+	}
+
+	return resp
+}
@@ -91,22 +91,44 @@ func NewEmbedder(embedder embedding.Embedder, opts ...Option) (*Embedder, error)
 }
 
 func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	generatorOpt := e.generatorOption(opts...)
+
+	return embedWithCache(ctx, e, texts,
+		func(text string) (string, error) {
+			return e.generator.Generate(ctx, text, generatorOpt), nil
+		},
+		func(uncached []string) ([][]float64, error) {
+			return e.embedder.EmbedStrings(ctx, uncached, opts...)
+		})
+}
+
+// generatorOption generates options for the generator
+func (e *Embedder) generatorOption(opts ...embedding.Option) GeneratorOption {
+	var generatorOpt GeneratorOption
+	if embeddingOpts := embedding.GetCommonOptions(nil, opts...); embeddingOpts.Model != nil {
+		generatorOpt.Model = *embeddingOpts.Model
+	}
+	return generatorOpt
+}
+
+// embedWithCache looks up the cached embedding of each input and only embeds the uncached ones.
+func embedWithCache[T any](ctx context.Context, e *Embedder, inputs []T,
+	keyOf func(T) (string, error), embed func([]T) ([][]float64, error)) ([][]float64, error) {
 	var (
 		embeddingsByKey = make(map[int][]float64)
-		embeddingOpts   = embedding.GetCommonOptions(nil, opts...)
+		keys            = make([]string, len(inputs))
 		uncached        []int
-		uncachedTexts   []string
+		uncachedInputs  []T
 	)
 
-	// generate options for the generator
-	var generatorOpt GeneratorOption
-	if embeddingOpts.Model != nil {
-		generatorOpt.Model = *embeddingOpts.Model
-	}
+	// Get cached embeddings and find uncached inputs
+	for idx, input := range inputs {
+		key, err := keyOf(input)
+		if err != nil {
+			return nil, err
+		}
+		keys[idx] = key
 
-	// Get cached embeddings and find uncached texts
-	for idx, text := range texts {
-		key := e.generator.Generate(ctx, text, generatorOpt)
 		emb, ok, err := e.cacher.Get(ctx, key)
 		if err != nil {
 			return nil, err
@@ -115,21 +137,20 @@ func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...emb
 		} else {
 			// If the key is not found, we consider it as uncached
 			uncached = append(uncached, idx)
-			uncachedTexts = append(uncachedTexts, text)
+			uncachedInputs = append(uncachedInputs, input)
 		}
 	}
 
-	// Embed the uncached texts
-	if len(uncachedTexts) > 0 {
-		uncachedEmbeddings, err := e.embedder.EmbedStrings(ctx, uncachedTexts, opts...)
+	// Embed the uncached inputs
+	if len(uncachedInputs) > 0 {
+		uncachedEmbeddings, err := embed(uncachedInputs)
 		if err != nil {
 			return nil, err
 		}
 
 		// Cache the uncachedEmbeddings
 		for i, idx := range uncached {
-			key := e.generator.Generate(ctx, texts[idx], generatorOpt)
-			if err := e.cacher.Set(ctx, key, uncachedEmbeddings[i], e.expiration); err != nil {
+			if err := e.cacher.Set(ctx, keys[idx], uncachedEmbeddings[i], e.expiration); err != nil {
 				_ = err // skip caching if there's an error
 			}
 			embeddingsByKey[idx] = uncachedEmbeddings[i]
@@ -137,8 +158,8 @@ func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...emb
 	}
 
 	// Convert the map to a slice
-	result := make([][]float64, len(texts))
-	for i := range texts {
+	result := make([][]float64, len(inputs))
+	for i := range inputs {
 		if emb, ok := embeddingsByKey[i]; ok {
 			result[i] = emb
 		} else {
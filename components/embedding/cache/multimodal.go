@@ -0,0 +1,112 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+)
+
+var ErrMultiModalNotSupported = errors.New("embedding/cache: embedder doesn't support multimodal inputs")
+
+// multiModalEmbedder matches multimodal.Embedder of github.com/cloudwego/eino-ext/components/embedding/multimodal.
+type multiModalEmbedder interface {
+	EmbedMultiModal(ctx context.Context, inputs [][]schema.ChatMessagePart, opts ...embedding.Option) ([][]float64, error)
+}
+
+// EmbedMultiModal caches the embeddings of multimodal inputs, the wrapped embedder must support multimodal inputs.
+//
+// The cache key of an input is generated from its parts: texts are used as they are, images given
+// as base64 data urls are identified by the sha256 of the decoded bytes, so the same image gets
+// the same key whatever its encoding, and other images are identified by their url.
+func (e *Embedder) EmbedMultiModal(ctx context.Context, inputs [][]schema.ChatMessagePart, opts ...embedding.Option) ([][]float64, error) {
+	mm, ok := e.embedder.(multiModalEmbedder)
+	if !ok {
+		return nil, ErrMultiModalNotSupported
+	}
+
+	generatorOpt := e.generatorOption(opts...)
+
+	return embedWithCache(ctx, e, inputs,
+		func(input []schema.ChatMessagePart) (string, error) {
+			text, err := multiModalKeyText(input)
+			if err != nil {
+				return "", err
+			}
+			return e.generator.Generate(ctx, text, generatorOpt), nil
+		},
+		func(uncached [][]schema.ChatMessagePart) ([][]float64, error) {
+			return mm.EmbedMultiModal(ctx, uncached, opts...)
+		})
+}
+
+// multiModalKeyText converts input into the text passed to the [Generator].
+func multiModalKeyText(input []schema.ChatMessagePart) (string, error) {
+	var sb strings.Builder
+	for _, part := range input {
+		switch part.Type {
+		case schema.ChatMessagePartTypeText:
+			sb.WriteString("text:")
+			sb.WriteString(part.Text)
+		case schema.ChatMessagePartTypeImageURL:
+			if part.ImageURL == nil {
+				return "", fmt.Errorf("embedding/cache: image part without image url")
+			}
+			digest, err := imageDigest(part.ImageURL)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString("image:")
+			sb.WriteString(digest)
+		default:
+			return "", fmt.Errorf("embedding/cache: unsupported multimodal part type: %s", part.Type)
+		}
+		// separates parts, so that different splits of the same text don't collide
+		sb.WriteByte(0)
+	}
+	return sb.String(), nil
+}
+
+func imageDigest(image *schema.ChatMessageImageURL) (string, error) {
+	url := image.URL
+	if url == "" {
+		url = image.URI
+	}
+
+	if !strings.HasPrefix(url, "data:") {
+		return "url:" + url, nil
+	}
+
+	meta, payload, found := strings.Cut(url, ",")
+	if !found || !strings.HasSuffix(meta, ";base64") {
+		return "", fmt.Errorf("embedding/cache: invalid base64 data url")
+	}
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", fmt.Errorf("embedding/cache: decode data url failed: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
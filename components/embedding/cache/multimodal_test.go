@@ -0,0 +1,115 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockMultiModalEmbedder struct {
+	mockEmbedder
+}
+
+func (m *mockMultiModalEmbedder) EmbedMultiModal(ctx context.Context, inputs [][]schema.ChatMessagePart, opts ...embedding.Option) ([][]float64, error) {
+	args := m.Called(ctx, inputs, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([][]float64), args.Error(1)
+}
+
+func imagePart(url string) schema.ChatMessagePart {
+	return schema.ChatMessagePart{Type: schema.ChatMessagePartTypeImageURL, ImageURL: &schema.ChatMessageImageURL{URL: url}}
+}
+
+func textPart(text string) schema.ChatMessagePart {
+	return schema.ChatMessagePart{Type: schema.ChatMessagePartTypeText, Text: text}
+}
+
+func TestMultiModalKeyText(t *testing.T) {
+	// the same bytes are keyed the same whatever the declared mime type
+	png, err := multiModalKeyText([]schema.ChatMessagePart{imagePart("data:image/png;base64,AAE=")})
+	require.NoError(t, err)
+	jpeg, err := multiModalKeyText([]schema.ChatMessagePart{imagePart("data:image/jpeg;base64,AAE=")})
+	require.NoError(t, err)
+	assert.Equal(t, png, jpeg)
+
+	other, err := multiModalKeyText([]schema.ChatMessagePart{imagePart("data:image/png;base64,AAI=")})
+	require.NoError(t, err)
+	assert.NotEqual(t, png, other)
+
+	url, err := multiModalKeyText([]schema.ChatMessagePart{textPart("cat"), imagePart("https://example.com/cat.png")})
+	require.NoError(t, err)
+	assert.Equal(t, "text:cat\x00image:url:https://example.com/cat.png\x00", url)
+
+	split1, _ := multiModalKeyText([]schema.ChatMessagePart{textPart("ab"), textPart("c")})
+	split2, _ := multiModalKeyText([]schema.ChatMessagePart{textPart("a"), textPart("bc")})
+	assert.NotEqual(t, split1, split2)
+
+	_, err = multiModalKeyText([]schema.ChatMessagePart{imagePart("data:image/png;base64,!!")})
+	assert.Error(t, err)
+	_, err = multiModalKeyText([]schema.ChatMessagePart{{Type: schema.ChatMessagePartTypeAudioURL}})
+	assert.Error(t, err)
+}
+
+func TestEmbedder_EmbedMultiModal(t *testing.T) {
+	ctx := context.Background()
+	expiration := time.Minute
+	inputs := [][]schema.ChatMessagePart{
+		{textPart("cat")},
+		{imagePart("data:image/png;base64,AAE=")},
+	}
+	embeddings := [][]float64{{1.1}, {2.2}}
+
+	t.Run("not supported", func(t *testing.T) {
+		e, err := NewEmbedder(new(mockEmbedder), WithCacher(new(mockCacher)), WithGenerator(NewSimpleGenerator()))
+		require.NoError(t, err)
+		_, err = e.EmbedMultiModal(ctx, inputs)
+		assert.ErrorIs(t, err, ErrMultiModalNotSupported)
+	})
+
+	t.Run("partial cache hit", func(t *testing.T) {
+		mc := new(mockCacher)
+		me := new(mockMultiModalEmbedder)
+		e, err := NewEmbedder(me, WithCacher(mc), WithGenerator(NewSimpleGenerator()), WithExpiration(expiration))
+		require.NoError(t, err)
+
+		text0, _ := multiModalKeyText(inputs[0])
+		text1, _ := multiModalKeyText(inputs[1])
+		key0 := e.generator.Generate(ctx, text0, GeneratorOption{})
+		key1 := e.generator.Generate(ctx, text1, GeneratorOption{})
+
+		mc.On("Get", mock.Anything, key0).Return(embeddings[0], true, nil)
+		mc.On("Get", mock.Anything, key1).Return(nil, false, nil)
+		me.On("EmbedMultiModal", mock.Anything, inputs[1:], mock.Anything).Return([][]float64{embeddings[1]}, nil)
+		mc.On("Set", mock.Anything, key1, embeddings[1], expiration).Return(nil)
+
+		result, err := e.EmbedMultiModal(ctx, inputs)
+		assert.NoError(t, err)
+		assert.Equal(t, embeddings, result)
+		mc.AssertExpectations(t)
+		me.AssertExpectations(t)
+	})
+}
@@ -55,6 +55,10 @@ type EmbeddingConfig struct {
 	// the max sequence length. If this option is set to false, oversized inputs
 	// will lead to an INVALID_ARGUMENT error, similar to other text APIs.
 	AutoTruncate bool `json:"autoTruncate,omitempty"`
+
+	// TokenCounter counts the tokens of a text, used to estimate TokenUsage when the API reports no statistics.
+	// Optional. Default: a character based approximation
+	TokenCounter func(text string) int `json:"-"`
 }
 
 type Embedder struct {
@@ -96,6 +100,25 @@ func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...emb
 		contents = append(contents, genai.NewContentFromText(text, genai.RoleUser))
 	}
 
+	embeddings, tokenUsage, err := e.embedContents(ctx, conf.Model, contents)
+	if err != nil {
+		return nil, err
+	}
+	tokenUsage, extra := e.estimateIfMissing(tokenUsage, texts)
+
+	callbacks.OnEnd(ctx, &embedding.CallbackOutput{
+		Embeddings: embeddings,
+		Config:     conf,
+		TokenUsage: tokenUsage,
+		Extra:      extra,
+	})
+
+	return embeddings, nil
+}
+
+func (e *Embedder) embedContents(ctx context.Context, model string, contents []*genai.Content) (
+	[][]float64, *embedding.TokenUsage, error) {
+
 	embedContentConfig := &genai.EmbedContentConfig{
 		TaskType:             e.conf.TaskType,
 		Title:                e.conf.Title,
@@ -105,16 +128,16 @@ func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...emb
 	}
 
 	resp, err := e.cli.Models.EmbedContent(ctx,
-		e.conf.Model,
+		model,
 		contents,
 		embedContentConfig,
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Convert [][]float32 to [][]float64
-	embeddings = make([][]float64, len(resp.Embeddings))
+	embeddings := make([][]float64, len(resp.Embeddings))
 	var tokenUsage *embedding.TokenUsage
 	for i, emb := range resp.Embeddings {
 		embeddings[i] = make([]float64, len(emb.Values))
@@ -123,22 +146,14 @@ func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...emb
 		}
 		if emb.Statistics != nil {
 			if tokenUsage == nil {
-				tokenUsage = &embedding.TokenUsage{
-					PromptTokens: int(emb.Statistics.TokenCount),
-				}
-			} else {
-				tokenUsage.PromptTokens += int(emb.Statistics.TokenCount)
+				tokenUsage = &embedding.TokenUsage{}
 			}
+			tokenUsage.PromptTokens += int(emb.Statistics.TokenCount)
+			tokenUsage.TotalTokens += int(emb.Statistics.TokenCount)
 		}
 	}
 
-	callbacks.OnEnd(ctx, &embedding.CallbackOutput{
-		Embeddings: embeddings,
-		Config:     conf,
-		TokenUsage: tokenUsage,
-	})
-
-	return embeddings, nil
+	return embeddings, tokenUsage, nil
 }
 
 func (e *Embedder) GetType() string {
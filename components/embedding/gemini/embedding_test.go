@@ -21,8 +21,12 @@ import (
 	"testing"
 
 	. "github.com/bytedance/mockey"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
 	"github.com/smartystreets/goconvey/convey"
 	"google.golang.org/genai"
+
+	"github.com/cloudwego/eino-ext/libs/acl/tokenusage"
 )
 
 func Test_EmbedStrings(t *testing.T) {
@@ -71,3 +75,77 @@ func Test_EmbedStrings(t *testing.T) {
 		})
 	})
 }
+
+func Test_estimateIfMissing(t *testing.T) {
+	convey.Convey("test estimateIfMissing", t, func() {
+		embedder, err := NewEmbedder(context.Background(), &EmbeddingConfig{
+			TokenCounter: func(text string) int { return len(text) },
+		})
+		convey.So(err, convey.ShouldBeNil)
+
+		reported := &embedding.TokenUsage{PromptTokens: 3, TotalTokens: 3}
+		usage, extra := embedder.estimateIfMissing(reported, []string{"hello"})
+		convey.So(usage, convey.ShouldEqual, reported)
+		convey.So(extra, convey.ShouldBeNil)
+
+		usage, extra = embedder.estimateIfMissing(nil, []string{"hello", "hi"})
+		convey.So(usage, convey.ShouldResemble, &embedding.TokenUsage{PromptTokens: 7, TotalTokens: 7})
+		convey.So(extra[tokenusage.ExtraKeyEstimated], convey.ShouldEqual, true)
+	})
+}
+
+func Test_EmbedMultiModal(t *testing.T) {
+	PatchConvey("test EmbedMultiModal", t, func() {
+		ctx := context.Background()
+		mockCli := &genai.Client{
+			Models: &genai.Models{},
+		}
+
+		embedder, err := NewEmbedder(ctx, &EmbeddingConfig{
+			Client: mockCli,
+			Model:  "multimodalembedding",
+		})
+		convey.So(err, convey.ShouldBeNil)
+
+		inputs := [][]schema.ChatMessagePart{
+			{{Type: schema.ChatMessagePartTypeText, Text: "a cat"}},
+			{{Type: schema.ChatMessagePartTypeImageURL, ImageURL: &schema.ChatMessageImageURL{URL: "data:image/png;base64,AAE="}}},
+		}
+
+		PatchConvey("test embedding success", func() {
+			Mock(GetMethod(mockCli.Models, "EmbedContent")).Return(&genai.EmbedContentResponse{
+				Embeddings: []*genai.ContentEmbedding{{Values: []float32{0.1}}, {Values: []float32{0.2}}},
+			}, nil).Build()
+
+			result, err := embedder.EmbedMultiModal(ctx, inputs)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(len(result), convey.ShouldEqual, 2)
+		})
+
+		PatchConvey("test invalid part", func() {
+			_, err := embedder.EmbedMultiModal(ctx, [][]schema.ChatMessagePart{
+				{{Type: schema.ChatMessagePartTypeImageURL, ImageURL: &schema.ChatMessageImageURL{URL: "gs://bucket/cat.png"}}},
+			})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func Test_toGenAIParts(t *testing.T) {
+	PatchConvey("test toGenAIParts", t, func() {
+		parts, err := toGenAIParts([]schema.ChatMessagePart{
+			{Type: schema.ChatMessagePartTypeText, Text: "a cat"},
+			{Type: schema.ChatMessagePartTypeImageURL, ImageURL: &schema.ChatMessageImageURL{URL: "data:image/png;base64,AAE="}},
+			{Type: schema.ChatMessagePartTypeImageURL, ImageURL: &schema.ChatMessageImageURL{URI: "gs://bucket/cat.png", MIMEType: "image/png"}},
+		})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(len(parts), convey.ShouldEqual, 3)
+		convey.So(parts[0].Text, convey.ShouldEqual, "a cat")
+		convey.So(parts[1].InlineData.MIMEType, convey.ShouldEqual, "image/png")
+		convey.So(parts[1].InlineData.Data, convey.ShouldResemble, []byte{0, 1})
+		convey.So(parts[2].FileData.FileURI, convey.ShouldEqual, "gs://bucket/cat.png")
+
+		_, err = toGenAIParts([]schema.ChatMessagePart{{Type: schema.ChatMessagePartTypeImageURL, ImageURL: &schema.ChatMessageImageURL{URL: "data:image/png,raw"}}})
+		convey.So(err, convey.ShouldNotBeNil)
+	})
+}
@@ -2,9 +2,12 @@ module github.com/cloudwego/eino-ext/components/embedding/gemini
 
 go 1.23.0
 
+replace github.com/cloudwego/eino-ext/libs/acl/tokenusage => ../../../libs/acl/tokenusage
+
 require (
 	github.com/bytedance/mockey v1.2.12
 	github.com/cloudwego/eino v0.3.27
+	github.com/cloudwego/eino-ext/libs/acl/tokenusage v0.0.0-00010101000000-000000000000
 	google.golang.org/genai v1.18.0
 )
 
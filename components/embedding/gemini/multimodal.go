@@ -0,0 +1,145 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gemini
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+	"google.golang.org/genai"
+)
+
+// extraKeyMultiModalInputs matches multimodal.ExtraKeyInputs of github.com/cloudwego/eino-ext/components/embedding/multimodal.
+const extraKeyMultiModalInputs = "multimodal_inputs"
+
+// EmbedMultiModal embeds inputs consisting of text and image parts, each input is embedded into one vector.
+// Images are either base64 data urls, sent inline, or file uris (e.g. gs:// or Files API uris) with ImageURL.MIMEType set.
+func (e *Embedder) EmbedMultiModal(ctx context.Context, inputs [][]schema.ChatMessagePart, opts ...embedding.Option) (
+	embeddings [][]float64, err error) {
+
+	options := embedding.GetCommonOptions(&embedding.Options{
+		Model: &e.conf.Model,
+	}, opts...)
+
+	conf := &embedding.Config{
+		Model: *options.Model,
+	}
+
+	texts := make([]string, len(inputs))
+	for i, input := range inputs {
+		texts[i] = textOf(input)
+	}
+
+	ctx = callbacks.EnsureRunInfo(ctx, e.GetType(), components.ComponentOfEmbedding)
+	ctx = callbacks.OnStart(ctx, &embedding.CallbackInput{
+		Texts:  texts,
+		Config: conf,
+		Extra:  map[string]any{extraKeyMultiModalInputs: inputs},
+	})
+
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	contents := make([]*genai.Content, 0, len(inputs))
+	for _, input := range inputs {
+		parts, err := toGenAIParts(input)
+		if err != nil {
+			return nil, err
+		}
+		contents = append(contents, genai.NewContentFromParts(parts, genai.RoleUser))
+	}
+
+	embeddings, tokenUsage, err := e.embedContents(ctx, conf.Model, contents)
+	if err != nil {
+		return nil, err
+	}
+	// images are not counted by the estimate
+	tokenUsage, extra := e.estimateIfMissing(tokenUsage, texts)
+
+	callbacks.OnEnd(ctx, &embedding.CallbackOutput{
+		Embeddings: embeddings,
+		Config:     conf,
+		TokenUsage: tokenUsage,
+		Extra:      extra,
+	})
+
+	return embeddings, nil
+}
+
+func toGenAIParts(input []schema.ChatMessagePart) ([]*genai.Part, error) {
+	parts := make([]*genai.Part, 0, len(input))
+	for _, part := range input {
+		switch part.Type {
+		case schema.ChatMessagePartTypeText:
+			parts = append(parts, genai.NewPartFromText(part.Text))
+		case schema.ChatMessagePartTypeImageURL:
+			if part.ImageURL == nil {
+				return nil, fmt.Errorf("[Gemini] image part without image url")
+			}
+			if strings.HasPrefix(part.ImageURL.URL, "data:") {
+				mimeType, data, err := parseDataURL(part.ImageURL.URL)
+				if err != nil {
+					return nil, err
+				}
+				parts = append(parts, genai.NewPartFromBytes(data, mimeType))
+				continue
+			}
+			uri := part.ImageURL.URI
+			if uri == "" {
+				uri = part.ImageURL.URL
+			}
+			if part.ImageURL.MIMEType == "" {
+				return nil, fmt.Errorf("[Gemini] mime type is required for image uri: %s", uri)
+			}
+			parts = append(parts, genai.NewPartFromURI(uri, part.ImageURL.MIMEType))
+		default:
+			return nil, fmt.Errorf("[Gemini] unsupported multimodal part type: %s", part.Type)
+		}
+	}
+	return parts, nil
+}
+
+func parseDataURL(url string) (mimeType string, data []byte, err error) {
+	meta, payload, found := strings.Cut(strings.TrimPrefix(url, "data:"), ",")
+	if !found || !strings.HasSuffix(meta, ";base64") {
+		return "", nil, fmt.Errorf("[Gemini] invalid base64 data url")
+	}
+	data, err = base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", nil, fmt.Errorf("[Gemini] decode data url failed: %w", err)
+	}
+	return strings.TrimSuffix(meta, ";base64"), data, nil
+}
+
+func textOf(input []schema.ChatMessagePart) string {
+	var texts []string
+	for _, part := range input {
+		if part.Type == schema.ChatMessagePartTypeText && part.Text != "" {
+			texts = append(texts, part.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
@@ -0,0 +1,32 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gemini
+
+import (
+	"github.com/cloudwego/eino/components/embedding"
+
+	"github.com/cloudwego/eino-ext/libs/acl/tokenusage"
+)
+
+// estimateIfMissing counts usage locally when the api reports no statistics, which the Gemini API doesn't,
+// only Vertex AI does.
+func (e *Embedder) estimateIfMissing(usage *embedding.TokenUsage, texts []string) (*embedding.TokenUsage, map[string]any) {
+	if usage != nil || len(texts) == 0 {
+		return usage, nil
+	}
+	return tokenusage.Count(e.conf.TokenCounter, texts), map[string]any{tokenusage.ExtraKeyEstimated: true}
+}
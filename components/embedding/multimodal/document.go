@@ -0,0 +1,86 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package multimodal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+)
+
+// MetaKeyImageURL is the document metadata key of an image attached to the document.
+// Indexers in this repository embed the image together with the document content
+// when the embedder implements EmbedMultiModal.
+const MetaKeyImageURL = "_image_url"
+
+// WithImageURL attaches an image, a http(s) url or a data url, to doc.
+func WithImageURL(doc *schema.Document, url string) *schema.Document {
+	if doc.MetaData == nil {
+		doc.MetaData = make(map[string]any)
+	}
+	doc.MetaData[MetaKeyImageURL] = url
+	return doc
+}
+
+// GetImageURL returns the image attached to doc.
+func GetImageURL(doc *schema.Document) (string, bool) {
+	if doc == nil || doc.MetaData == nil {
+		return "", false
+	}
+	url, ok := doc.MetaData[MetaKeyImageURL].(string)
+	return url, ok && url != ""
+}
+
+// DocumentInput builds the multimodal input of doc from its content and attached image.
+func DocumentInput(doc *schema.Document) []schema.ChatMessagePart {
+	var input []schema.ChatMessagePart
+	if doc.Content != "" {
+		input = append(input, NewTextPart(doc.Content))
+	}
+	if url, ok := GetImageURL(doc); ok {
+		input = append(input, NewImageURLPart(url))
+	}
+	return input
+}
+
+// EmbedDocuments embeds docs with emb. Documents are embedded with EmbedMultiModal when any of them
+// carries an image, otherwise with EmbedStrings. An error is returned if images are present and
+// emb doesn't implement [Embedder].
+func EmbedDocuments(ctx context.Context, emb embedding.Embedder, docs []*schema.Document, opts ...embedding.Option) ([][]float64, error) {
+	inputs := make([][]schema.ChatMessagePart, len(docs))
+	withImage := false
+	for i, doc := range docs {
+		inputs[i] = DocumentInput(doc)
+		withImage = withImage || HasImage(inputs[i])
+	}
+
+	if !withImage {
+		texts := make([]string, len(docs))
+		for i, doc := range docs {
+			texts[i] = doc.Content
+		}
+		return emb.EmbedStrings(ctx, texts, opts...)
+	}
+
+	mm, ok := emb.(Embedder)
+	if !ok {
+		return nil, fmt.Errorf("[EmbedDocuments] documents carry images but embedder %T doesn't support multimodal inputs", emb)
+	}
+	return mm.EmbedMultiModal(ctx, inputs, opts...)
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package multimodal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type textEmbedder struct{}
+
+func (textEmbedder) EmbedStrings(_ context.Context, texts []string, _ ...embedding.Option) ([][]float64, error) {
+	out := make([][]float64, len(texts))
+	for i, text := range texts {
+		out[i] = []float64{float64(len(text))}
+	}
+	return out, nil
+}
+
+type mmEmbedder struct {
+	textEmbedder
+	inputs [][]schema.ChatMessagePart
+}
+
+func (m *mmEmbedder) EmbedMultiModal(_ context.Context, inputs [][]schema.ChatMessagePart, _ ...embedding.Option) ([][]float64, error) {
+	m.inputs = inputs
+	out := make([][]float64, len(inputs))
+	for i, input := range inputs {
+		out[i] = []float64{float64(len(input))}
+	}
+	return out, nil
+}
+
+var _ Embedder = (*mmEmbedder)(nil)
+
+func TestDocumentImage(t *testing.T) {
+	doc := &schema.Document{ID: "1", Content: "a cat"}
+	_, ok := GetImageURL(doc)
+	assert.False(t, ok)
+
+	WithImageURL(doc, "https://example.com/cat.png")
+	url, ok := GetImageURL(doc)
+	assert.True(t, ok)
+	assert.Equal(t, "https://example.com/cat.png", url)
+	assert.Equal(t, []schema.ChatMessagePart{
+		NewTextPart("a cat"),
+		NewImageURLPart("https://example.com/cat.png"),
+	}, DocumentInput(doc))
+}
+
+func TestEmbedDocuments(t *testing.T) {
+	ctx := context.Background()
+	plain := []*schema.Document{{ID: "1", Content: "abc"}}
+	withImage := []*schema.Document{{ID: "1", Content: "abc"}, WithImageURL(&schema.Document{ID: "2"}, "data:image/png;base64,AA==")}
+
+	t.Run("text only uses EmbedStrings", func(t *testing.T) {
+		emb := &mmEmbedder{}
+		got, err := EmbedDocuments(ctx, emb, plain)
+		require.NoError(t, err)
+		assert.Equal(t, [][]float64{{3}}, got)
+		assert.Nil(t, emb.inputs)
+	})
+
+	t.Run("images use EmbedMultiModal", func(t *testing.T) {
+		emb := &mmEmbedder{}
+		got, err := EmbedDocuments(ctx, emb, withImage)
+		require.NoError(t, err)
+		assert.Equal(t, [][]float64{{1}, {1}}, got)
+		assert.Len(t, emb.inputs, 2)
+	})
+
+	t.Run("images without multimodal support", func(t *testing.T) {
+		_, err := EmbedDocuments(ctx, textEmbedder{}, withImage)
+		assert.ErrorContains(t, err, "doesn't support multimodal inputs")
+	})
+}
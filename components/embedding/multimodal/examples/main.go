@@ -0,0 +1,76 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/embedding/multimodal"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// replace with a multimodal embedder, e.g. embedding/ark with APITypeMultiModal or embedding/gemini
+	var embedder multimodal.Embedder = &mockEmbedding{}
+
+	image := []byte("\x89PNG...") // replace with real image bytes
+
+	vectors, err := embedder.EmbedMultiModal(ctx, [][]schema.ChatMessagePart{
+		{multimodal.NewTextPart("the eino logo")},
+		{multimodal.NewImageBase64Part(image, "image/png")},
+		{multimodal.NewTextPart("a cat"), multimodal.NewImageURLPart("https://example.com/cat.png")},
+	})
+	if err != nil {
+		log.Fatalf("EmbedMultiModal failed, err=%v", err)
+	}
+	log.Printf("vectors: %v", vectors)
+
+	// documents carrying an image are embedded together with it by the indexers of this repository
+	doc := multimodal.WithImageURL(&schema.Document{ID: "1", Content: "a cat"}, "https://example.com/cat.png")
+	vectors, err = multimodal.EmbedDocuments(ctx, embedder, []*schema.Document{doc})
+	if err != nil {
+		log.Fatalf("EmbedDocuments failed, err=%v", err)
+	}
+	log.Printf("document vector: %v", vectors[0])
+}
+
+type mockEmbedding struct{}
+
+func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	out := make([][]float64, len(texts))
+	for i := range texts {
+		out[i] = []float64{1, 0}
+	}
+	return out, nil
+}
+
+func (m *mockEmbedding) EmbedMultiModal(ctx context.Context, inputs [][]schema.ChatMessagePart, opts ...embedding.Option) ([][]float64, error) {
+	out := make([][]float64, len(inputs))
+	for i, input := range inputs {
+		if multimodal.HasImage(input) {
+			out[i] = []float64{0, 1}
+		} else {
+			out[i] = []float64{1, 0}
+		}
+	}
+	return out, nil
+}
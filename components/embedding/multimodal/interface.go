@@ -0,0 +1,44 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package multimodal
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Embedder is an [embedding.Embedder] which also accepts images.
+//
+// Implementations in this repository satisfy it structurally, so components only need to
+// assert the EmbedMultiModal method and don't have to depend on this module.
+type Embedder interface {
+	embedding.Embedder
+
+	// EmbedMultiModal embeds each input into one vector. An input is a list of parts,
+	// which are combined into a single embedding when the model supports it.
+	// Supported part types are schema.ChatMessagePartTypeText and schema.ChatMessagePartTypeImageURL,
+	// where the image url is either a http(s) url or a base64 data url (RFC-2397).
+	EmbedMultiModal(ctx context.Context, inputs [][]schema.ChatMessagePart, opts ...embedding.Option) ([][]float64, error)
+}
+
+const (
+	// ExtraKeyInputs is the key of the multimodal inputs ([][]schema.ChatMessagePart) in [embedding.CallbackInput].Extra.
+	// CallbackInput.Texts only holds the text of each input.
+	ExtraKeyInputs = "multimodal_inputs"
+)
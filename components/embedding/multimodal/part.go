@@ -0,0 +1,101 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package multimodal
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+var ErrInvalidDataURL = errors.New("embedding/multimodal: invalid data url")
+
+// NewTextPart creates a text part.
+func NewTextPart(text string) schema.ChatMessagePart {
+	return schema.ChatMessagePart{
+		Type: schema.ChatMessagePartTypeText,
+		Text: text,
+	}
+}
+
+// NewImageURLPart creates an image part from a http(s) url or a data url.
+func NewImageURLPart(url string) schema.ChatMessagePart {
+	return schema.ChatMessagePart{
+		Type:     schema.ChatMessagePartTypeImageURL,
+		ImageURL: &schema.ChatMessageImageURL{URL: url},
+	}
+}
+
+// NewImageBase64Part creates an image part carrying data as a base64 data url.
+func NewImageBase64Part(data []byte, mimeType string) schema.ChatMessagePart {
+	return schema.ChatMessagePart{
+		Type: schema.ChatMessagePartTypeImageURL,
+		ImageURL: &schema.ChatMessageImageURL{
+			URL:      EncodeDataURL(data, mimeType),
+			MIMEType: mimeType,
+		},
+	}
+}
+
+// EncodeDataURL encodes data as a base64 data url, e.g. "data:image/png;base64,iVBORw0KGgo...".
+func EncodeDataURL(data []byte, mimeType string) string {
+	return "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
+
+// ParseDataURL decodes a base64 data url.
+// ok is false if url is not a data url, e.g. a http(s) url.
+func ParseDataURL(url string) (mimeType string, data []byte, ok bool, err error) {
+	if !strings.HasPrefix(url, "data:") {
+		return "", nil, false, nil
+	}
+
+	meta, payload, found := strings.Cut(strings.TrimPrefix(url, "data:"), ",")
+	if !found || !strings.HasSuffix(meta, ";base64") {
+		return "", nil, true, ErrInvalidDataURL
+	}
+
+	data, err = base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", nil, true, fmt.Errorf("%w: %v", ErrInvalidDataURL, err)
+	}
+
+	return strings.TrimSuffix(meta, ";base64"), data, true, nil
+}
+
+// TextOf joins the text parts of input, it's used to fill CallbackInput.Texts.
+func TextOf(input []schema.ChatMessagePart) string {
+	var texts []string
+	for _, part := range input {
+		if part.Type == schema.ChatMessagePartTypeText && part.Text != "" {
+			texts = append(texts, part.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// HasImage reports whether any part of input is an image.
+func HasImage(input []schema.ChatMessagePart) bool {
+	for _, part := range input {
+		if part.Type == schema.ChatMessagePartTypeImageURL && part.ImageURL != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package multimodal
+
+import (
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataURL(t *testing.T) {
+	part := NewImageBase64Part([]byte("png-bytes"), "image/png")
+	assert.Equal(t, schema.ChatMessagePartTypeImageURL, part.Type)
+	assert.Equal(t, "data:image/png;base64,cG5nLWJ5dGVz", part.ImageURL.URL)
+
+	mimeType, data, ok, err := ParseDataURL(part.ImageURL.URL)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "image/png", mimeType)
+	assert.Equal(t, []byte("png-bytes"), data)
+
+	_, _, ok, err = ParseDataURL("https://example.com/cat.png")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, ok, err = ParseDataURL("data:text/plain,hello")
+	assert.True(t, ok)
+	assert.ErrorIs(t, err, ErrInvalidDataURL)
+
+	_, _, _, err = ParseDataURL("data:image/png;base64,!!!")
+	assert.ErrorIs(t, err, ErrInvalidDataURL)
+}
+
+func TestTextOfAndHasImage(t *testing.T) {
+	input := []schema.ChatMessagePart{NewTextPart("a cat"), NewImageURLPart("https://example.com/cat.png"), NewTextPart("on a mat")}
+	assert.Equal(t, "a cat\non a mat", TextOf(input))
+	assert.True(t, HasImage(input))
+	assert.False(t, HasImage(input[:1]))
+}
@@ -27,6 +27,8 @@ import (
 	"github.com/cloudwego/eino/components"
 	"github.com/cloudwego/eino/components/embedding"
 	"github.com/ollama/ollama/api"
+
+	"github.com/cloudwego/eino-ext/libs/acl/tokenusage"
 )
 
 var (
@@ -72,6 +74,10 @@ type EmbeddingConfig struct {
 	// Options lists model-specific options.
 	// Optional
 	Options map[string]any `json:"options,omitempty"`
+
+	// TokenCounter counts the tokens of a text, used to estimate TokenUsage when the server reports no prompt_eval_count.
+	// Optional. Default: a character based approximation
+	TokenCounter func(text string) int `json:"-"`
 }
 
 var _ embedding.Embedder = (*Embedder)(nil)
@@ -160,9 +166,19 @@ func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...emb
 		PromptEvalCount: resp.PromptEvalCount,
 	}
 
+	usage := &embedding.TokenUsage{
+		PromptTokens: resp.PromptEvalCount,
+		TotalTokens:  resp.PromptEvalCount,
+	}
+	if resp.PromptEvalCount == 0 && len(texts) > 0 {
+		usage = tokenusage.Count(e.conf.TokenCounter, texts)
+		extra[tokenusage.ExtraKeyEstimated] = true
+	}
+
 	callbacks.OnEnd(ctx, &embedding.CallbackOutput{
 		Embeddings: result,
 		Config:     conf,
+		TokenUsage: usage,
 		Extra:      extra,
 	})
 
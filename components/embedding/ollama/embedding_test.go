@@ -127,6 +127,7 @@ func TestEmbedding(t *testing.T) {
 				}) {
 					t.Fatal("Ollama embedding response is unexpected")
 				}
+				assert.Equal(t, &embedding.TokenUsage{PromptTokens: 2, TotalTokens: 2}, output.TokenUsage)
 				return ctx
 			},
 			OnError: func(ctx context.Context, runInfo *callbacks.RunInfo, err error) context.Context {
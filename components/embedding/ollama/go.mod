@@ -4,9 +4,12 @@ go 1.24.0
 
 toolchain go1.24.2
 
+replace github.com/cloudwego/eino-ext/libs/acl/tokenusage => ../../../libs/acl/tokenusage
+
 require (
 	github.com/bytedance/mockey v1.2.14
 	github.com/cloudwego/eino v0.3.55
+	github.com/cloudwego/eino-ext/libs/acl/tokenusage v0.0.0-00010101000000-000000000000
 	github.com/ollama/ollama v0.9.6
 	github.com/stretchr/testify v1.10.0
 )
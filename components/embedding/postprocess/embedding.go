@@ -0,0 +1,174 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postprocess
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+// ExtraKeyTransform is the key of the applied [Transform] in the Extra of [embedding.CallbackInput] and [embedding.CallbackOutput].
+// Retrievers can compare it with the transform recorded at indexing time to make sure both sides use the same one.
+const ExtraKeyTransform = "postprocess_transform"
+
+var ErrEmbedderRequired = errors.New("embedding/postprocess: embedder is required")
+
+// Embedder post-processes the embeddings returned by the wrapped embedder.
+//
+// When composing with embedding/cache.Embedder, wrap the cache embedder with this one,
+// so raw embeddings are cached and every cache hit goes through the same transform as a miss.
+// Changing the transform later doesn't invalidate the cache.
+type Embedder struct {
+	embedder  embedding.Embedder
+	transform Transform
+}
+
+type Option interface {
+	apply(*Embedder)
+}
+
+type optionFunc func(*Embedder)
+
+func (f optionFunc) apply(e *Embedder) {
+	f(e)
+}
+
+// WithTransform returns an [Option] that replaces the whole [Transform] of the [Embedder].
+func WithTransform(transform Transform) Option {
+	return optionFunc(func(e *Embedder) {
+		e.transform = transform
+	})
+}
+
+// WithDimensions returns an [Option] that truncates embeddings to the first dimensions values.
+func WithDimensions(dimensions int) Option {
+	return optionFunc(func(e *Embedder) {
+		e.transform.Dimensions = dimensions
+	})
+}
+
+// WithNormalize returns an [Option] that L2-normalizes embeddings.
+func WithNormalize() Option {
+	return optionFunc(func(e *Embedder) {
+		e.transform.Normalize = true
+	})
+}
+
+// WithQuantization returns an [Option] that quantizes embedding values.
+func WithQuantization(quantization Quantization) Option {
+	return optionFunc(func(e *Embedder) {
+		e.transform.Quantization = quantization
+	})
+}
+
+var _ embedding.Embedder = (*Embedder)(nil)
+
+// NewEmbedder creates a new [Embedder] wrapping embedder.
+func NewEmbedder(embedder embedding.Embedder, opts ...Option) (*Embedder, error) {
+	if embedder == nil {
+		return nil, ErrEmbedderRequired
+	}
+
+	e := &Embedder{embedder: embedder}
+	for _, opt := range opts {
+		opt.apply(e)
+	}
+
+	if err := e.transform.Validate(); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// Transform returns the transform applied by the [Embedder].
+func (e *Embedder) Transform() Transform {
+	return e.transform
+}
+
+func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) (
+	embeddings [][]float64, err error) {
+
+	options := embedding.GetCommonOptions(&embedding.Options{}, opts...)
+	conf := &embedding.Config{}
+	if options.Model != nil {
+		conf.Model = *options.Model
+	}
+
+	ctx = callbacks.EnsureRunInfo(ctx, e.GetType(), components.ComponentOfEmbedding)
+	ctx = callbacks.OnStart(ctx, &embedding.CallbackInput{
+		Texts:  texts,
+		Config: conf,
+		Extra:  map[string]any{ExtraKeyTransform: e.transform},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	raw, err := e.embedder.EmbedStrings(e.innerContext(ctx), texts, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	embeddings = make([][]float64, len(raw))
+	for i, vec := range raw {
+		if embeddings[i], err = e.transform.Apply(vec); err != nil {
+			return nil, fmt.Errorf("[PostProcess] apply transform to embedding %d failed: %w", i, err)
+		}
+	}
+
+	callbacks.OnEnd(ctx, &embedding.CallbackOutput{
+		Embeddings: embeddings,
+		Config:     conf,
+		Extra:      map[string]any{ExtraKeyTransform: e.transform},
+	})
+
+	return embeddings, nil
+}
+
+// innerContext gives the wrapped embedder its own run info, so that its callbacks
+// are not reported as the ones of this wrapper.
+func (e *Embedder) innerContext(ctx context.Context) context.Context {
+	typ, _ := components.GetType(e.embedder)
+	return callbacks.ReuseHandlers(ctx, &callbacks.RunInfo{
+		Type:      typ,
+		Component: components.ComponentOfEmbedding,
+	})
+}
+
+// TransformFromExtra reads the [Transform] recorded in callback extra.
+func TransformFromExtra(extra map[string]any) (Transform, bool) {
+	t, ok := extra[ExtraKeyTransform].(Transform)
+	return t, ok
+}
+
+const typ = "PostProcess"
+
+func (e *Embedder) GetType() string {
+	return typ
+}
+
+func (e *Embedder) IsCallbacksEnabled() bool {
+	return true
+}
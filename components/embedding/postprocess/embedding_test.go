@@ -0,0 +1,149 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postprocess
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudwego/eino-ext/components/embedding/cache"
+)
+
+type fakeEmbedder struct {
+	calls int
+	err   error
+}
+
+func (f *fakeEmbedder) EmbedStrings(_ context.Context, texts []string, _ ...embedding.Option) ([][]float64, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	out := make([][]float64, len(texts))
+	for i := range texts {
+		out[i] = []float64{3, 4, float64(len(texts[i]))}
+	}
+	return out, nil
+}
+
+type mapCacher struct {
+	mu sync.Mutex
+	m  map[string][]float64
+}
+
+func (c *mapCacher) Set(_ context.Context, key string, value []float64, _ time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.m[key] = value
+	return nil
+}
+
+func (c *mapCacher) Get(_ context.Context, key string) ([]float64, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.m[key]
+	return v, ok, nil
+}
+
+func TestNewEmbedder(t *testing.T) {
+	_, err := NewEmbedder(nil)
+	assert.ErrorIs(t, err, ErrEmbedderRequired)
+
+	_, err = NewEmbedder(&fakeEmbedder{}, WithQuantization("fp4"))
+	assert.ErrorIs(t, err, ErrInvalidQuantization)
+
+	e, err := NewEmbedder(&fakeEmbedder{}, WithTransform(Transform{Dimensions: 4}), WithNormalize())
+	require.NoError(t, err)
+	assert.Equal(t, Transform{Dimensions: 4, Normalize: true}, e.Transform())
+}
+
+func TestEmbedder_EmbedStrings(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("transform and callback extra", func(t *testing.T) {
+		var (
+			inTransform  Transform
+			outTransform Transform
+		)
+		handler := callbacks.NewHandlerBuilder().
+			OnStartFn(func(ctx context.Context, info *callbacks.RunInfo, input callbacks.CallbackInput) context.Context {
+				if info.Type == typ {
+					inTransform, _ = TransformFromExtra(embedding.ConvCallbackInput(input).Extra)
+				}
+				return ctx
+			}).
+			OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+				if info.Type == typ {
+					outTransform, _ = TransformFromExtra(embedding.ConvCallbackOutput(output).Extra)
+				}
+				return ctx
+			}).Build()
+		cbCtx := callbacks.InitCallbacks(ctx, nil, handler)
+
+		e, err := NewEmbedder(&fakeEmbedder{}, WithDimensions(2), WithNormalize())
+		require.NoError(t, err)
+
+		got, err := e.EmbedStrings(cbCtx, []string{"a", "bb"})
+		require.NoError(t, err)
+		assert.InDeltaSlice(t, []float64{0.6, 0.8}, got[0], 1e-9)
+		assert.InDeltaSlice(t, []float64{0.6, 0.8}, got[1], 1e-9)
+		assert.Equal(t, e.Transform(), inTransform)
+		assert.Equal(t, e.Transform(), outTransform)
+	})
+
+	t.Run("inner error", func(t *testing.T) {
+		e, err := NewEmbedder(&fakeEmbedder{err: errors.New("boom")}, WithNormalize())
+		require.NoError(t, err)
+		_, err = e.EmbedStrings(ctx, []string{"a"})
+		assert.EqualError(t, err, "boom")
+	})
+
+	t.Run("transform error", func(t *testing.T) {
+		e, err := NewEmbedder(&fakeEmbedder{}, WithDimensions(8))
+		require.NoError(t, err)
+		_, err = e.EmbedStrings(ctx, []string{"a"})
+		assert.ErrorIs(t, err, ErrDimensionsTooLarge)
+	})
+
+	t.Run("compose with cache embedder", func(t *testing.T) {
+		inner := &fakeEmbedder{}
+		cached, err := cache.NewEmbedder(inner,
+			cache.WithCacher(&mapCacher{m: map[string][]float64{}}),
+			cache.WithGenerator(cache.NewSimpleGenerator()))
+		require.NoError(t, err)
+
+		e, err := NewEmbedder(cached, WithQuantization(QuantizationInt8), WithNormalize())
+		require.NoError(t, err)
+
+		miss, err := e.EmbedStrings(ctx, []string{"hello"})
+		require.NoError(t, err)
+		hit, err := e.EmbedStrings(ctx, []string{"hello"})
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, inner.calls)
+		assert.Equal(t, miss, hit)
+		assert.Equal(t, []float64{54, 72, 90}, hit[0])
+	})
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/components/embedding"
+
+	"github.com/cloudwego/eino-ext/components/embedding/cache"
+	"github.com/cloudwego/eino-ext/components/embedding/postprocess"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// cache the raw embeddings, then post-process both cache hits and misses the same way
+	cached, err := cache.NewEmbedder(&mockEmbedding{}, // replace with real embedding
+		cache.WithCacher(&memoryCacher{m: map[string][]float64{}}),
+		cache.WithGenerator(cache.NewSimpleGenerator()),
+	)
+	if err != nil {
+		log.Fatalf("NewEmbedder of cache failed, err=%v", err)
+	}
+
+	// keep the first 4 dimensions of the matryoshka embedding,
+	// renormalize them for the IP metric and store them as int8
+	embedder, err := postprocess.NewEmbedder(cached,
+		postprocess.WithDimensions(4),
+		postprocess.WithNormalize(),
+		postprocess.WithQuantization(postprocess.QuantizationInt8),
+	)
+	if err != nil {
+		log.Fatalf("NewEmbedder of postprocess failed, err=%v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		vectors, err := embedder.EmbedStrings(ctx, []string{"hello", "how are you"})
+		if err != nil {
+			log.Fatalf("EmbedStrings failed, err=%v", err)
+		}
+		log.Printf("transform: %s, vectors: %v", embedder.Transform(), vectors)
+	}
+}
+
+// mockEmbedding returns embeddings with 8 dimensions
+type mockEmbedding struct{}
+
+func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	out := make([][]float64, len(texts))
+	for i, text := range texts {
+		out[i] = make([]float64, 8)
+		for j := range out[i] {
+			out[i][j] = float64((len(text)+j)%5) - 2
+		}
+	}
+	return out, nil
+}
+
+type memoryCacher struct {
+	mu sync.Mutex
+	m  map[string][]float64
+}
+
+func (c *memoryCacher) Set(_ context.Context, key string, value []float64, _ time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.m[key] = value
+	return nil
+}
+
+func (c *memoryCacher) Get(_ context.Context, key string) ([]float64, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.m[key]
+	return v, ok, nil
+}
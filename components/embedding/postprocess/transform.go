@@ -0,0 +1,134 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postprocess
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Quantization is the quantization applied to the output vectors.
+type Quantization string
+
+const (
+	// QuantizationNone keeps float values.
+	QuantizationNone Quantization = ""
+	// QuantizationBinary maps every positive value to 1 and others to 0,
+	// which is the bit layout expected by binary vector fields, e.g. Milvus BINARY_VECTOR.
+	QuantizationBinary Quantization = "binary"
+	// QuantizationInt8 maps values in [-1, 1] to integers in [-127, 127], out of range values are clamped.
+	// It is usually combined with Normalize so that all values are in range.
+	QuantizationInt8 Quantization = "int8"
+)
+
+var (
+	ErrInvalidDimensions   = errors.New("embedding/postprocess: dimensions must not be negative")
+	ErrInvalidQuantization = errors.New("embedding/postprocess: unknown quantization")
+	ErrDimensionsTooLarge  = errors.New("embedding/postprocess: vector is shorter than the truncate dimensions")
+)
+
+// Transform describes the post-processing applied to embeddings.
+// Steps are applied in the order truncate, normalize, quantize, so that truncated
+// Matryoshka embeddings are renormalized before being quantized.
+type Transform struct {
+	// Dimensions truncates vectors to the first Dimensions values.
+	// Optional. Default: 0, vectors are not truncated
+	Dimensions int `json:"dimensions,omitempty"`
+	// Normalize scales vectors to unit L2 norm, required by IP metrics to behave like cosine.
+	// Optional. Default: false
+	Normalize bool `json:"normalize,omitempty"`
+	// Quantization quantizes the values of vectors.
+	// Optional. Default: QuantizationNone
+	Quantization Quantization `json:"quantization,omitempty"`
+}
+
+// Validate checks that the transform is well-formed.
+func (t Transform) Validate() error {
+	if t.Dimensions < 0 {
+		return ErrInvalidDimensions
+	}
+	switch t.Quantization {
+	case QuantizationNone, QuantizationBinary, QuantizationInt8:
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidQuantization, t.Quantization)
+	}
+	return nil
+}
+
+// String returns a stable description of the transform, e.g. "truncate=256,normalize,int8",
+// which can be stored alongside an index and compared at query time.
+func (t Transform) String() string {
+	var parts []string
+	if t.Dimensions > 0 {
+		parts = append(parts, fmt.Sprintf("truncate=%d", t.Dimensions))
+	}
+	if t.Normalize {
+		parts = append(parts, "normalize")
+	}
+	if t.Quantization != QuantizationNone {
+		parts = append(parts, string(t.Quantization))
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ",")
+}
+
+// Apply returns the post-processed copy of vector, the input is left untouched.
+func (t Transform) Apply(vector []float64) ([]float64, error) {
+	if t.Dimensions > 0 {
+		if len(vector) < t.Dimensions {
+			return nil, fmt.Errorf("%w: got %d, want at least %d", ErrDimensionsTooLarge, len(vector), t.Dimensions)
+		}
+		vector = vector[:t.Dimensions]
+	}
+
+	out := make([]float64, len(vector))
+	copy(out, vector)
+
+	if t.Normalize {
+		var sum float64
+		for _, v := range out {
+			sum += v * v
+		}
+		if sum > 0 {
+			norm := math.Sqrt(sum)
+			for i := range out {
+				out[i] /= norm
+			}
+		}
+	}
+
+	switch t.Quantization {
+	case QuantizationBinary:
+		for i, v := range out {
+			if v > 0 {
+				out[i] = 1
+			} else {
+				out[i] = 0
+			}
+		}
+	case QuantizationInt8:
+		for i, v := range out {
+			out[i] = math.Max(-127, math.Min(127, math.Round(v*127)))
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postprocess
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransform_Apply(t *testing.T) {
+	vec := []float64{3, -4, 12, 0.5}
+
+	t.Run("none", func(t *testing.T) {
+		got, err := Transform{}.Apply(vec)
+		require.NoError(t, err)
+		assert.Equal(t, vec, got)
+		got[0] = 100
+		assert.Equal(t, 3.0, vec[0])
+	})
+
+	t.Run("truncate and normalize", func(t *testing.T) {
+		got, err := Transform{Dimensions: 2, Normalize: true}.Apply(vec)
+		require.NoError(t, err)
+		assert.InDeltaSlice(t, []float64{0.6, -0.8}, got, 1e-9)
+	})
+
+	t.Run("truncate too large", func(t *testing.T) {
+		_, err := Transform{Dimensions: 5}.Apply(vec)
+		assert.ErrorIs(t, err, ErrDimensionsTooLarge)
+	})
+
+	t.Run("binary", func(t *testing.T) {
+		got, err := Transform{Quantization: QuantizationBinary}.Apply(vec)
+		require.NoError(t, err)
+		assert.Equal(t, []float64{1, 0, 1, 1}, got)
+	})
+
+	t.Run("int8", func(t *testing.T) {
+		got, err := Transform{Dimensions: 2, Normalize: true, Quantization: QuantizationInt8}.Apply(vec)
+		require.NoError(t, err)
+		assert.Equal(t, []float64{76, -102}, got)
+
+		got, err = Transform{Quantization: QuantizationInt8}.Apply([]float64{2, -0.5})
+		require.NoError(t, err)
+		assert.Equal(t, []float64{127, -64}, got)
+	})
+
+	t.Run("zero vector normalize", func(t *testing.T) {
+		got, err := Transform{Normalize: true}.Apply([]float64{0, 0})
+		require.NoError(t, err)
+		assert.Equal(t, []float64{0, 0}, got)
+	})
+}
+
+func TestTransform_Validate(t *testing.T) {
+	assert.NoError(t, Transform{Dimensions: 8, Quantization: QuantizationInt8}.Validate())
+	assert.ErrorIs(t, Transform{Dimensions: -1}.Validate(), ErrInvalidDimensions)
+	assert.ErrorIs(t, Transform{Quantization: "int4"}.Validate(), ErrInvalidQuantization)
+}
+
+func TestTransform_String(t *testing.T) {
+	assert.Equal(t, "none", Transform{}.String())
+	assert.Equal(t, "truncate=256,normalize,binary",
+		Transform{Dimensions: 256, Normalize: true, Quantization: QuantizationBinary}.String())
+}
@@ -0,0 +1,188 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sparse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sync"
+)
+
+const typeBM25 = "BM25"
+
+type BM25Config struct {
+	// Tokenizer splits texts into terms.
+	// Optional. Default: NewWhitespaceTokenizer()
+	Tokenizer Tokenizer `json:"-"`
+	// K1 controls term frequency saturation, must not be negative.
+	// Optional. Default: 1.2
+	K1 *float64 `json:"k1"`
+	// B controls document length normalization, in range [0, 1].
+	// 0 disables length normalization.
+	// Optional. Default: 0.75
+	B *float64 `json:"b"`
+}
+
+// BM25 encodes documents as saturated, length normalized term frequencies and queries as term IDFs,
+// so that the dot product of a query vector and a document vector is the Okapi BM25 score.
+type BM25 struct {
+	conf *BM25Config
+
+	mu    sync.RWMutex
+	stats *corpusStats
+}
+
+var _ Encoder = (*BM25)(nil)
+
+// NewBM25 creates an unfitted [BM25] encoder.
+func NewBM25(_ context.Context, config *BM25Config) (*BM25, error) {
+	conf := &BM25Config{}
+	if config != nil {
+		*conf = *config
+	}
+	if conf.Tokenizer == nil {
+		conf.Tokenizer = NewWhitespaceTokenizer()
+	}
+	if conf.K1 == nil {
+		conf.K1 = floatPtr(1.2)
+	}
+	if conf.B == nil {
+		conf.B = floatPtr(0.75)
+	}
+	if err := conf.validate(); err != nil {
+		return nil, fmt.Errorf("[NewBM25] invalid config: %w", err)
+	}
+	return &BM25{conf: conf}, nil
+}
+
+func (c *BM25Config) validate() error {
+	if c.K1 == nil || *c.K1 < 0 {
+		return errors.New("k1 must not be negative")
+	}
+	if c.B == nil || *c.B < 0 || *c.B > 1 {
+		return errors.New("b must be in range [0, 1]")
+	}
+	return nil
+}
+
+// LoadBM25 restores a [BM25] encoder saved by [BM25.Save].
+// K1 and B are taken from the serialized state, only Tokenizer of config is used.
+func LoadBM25(ctx context.Context, r io.Reader, config *BM25Config) (*BM25, error) {
+	enc, err := NewBM25(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := load(r, typeBM25, enc.conf)
+	if err != nil {
+		return nil, err
+	}
+	if err = enc.conf.validate(); err != nil {
+		return nil, fmt.Errorf("[LoadBM25] invalid params: %w", err)
+	}
+	enc.stats = stats
+	return enc, nil
+}
+
+// LoadBM25File restores a [BM25] encoder from the file written by [SaveFile].
+func LoadBM25File(ctx context.Context, path string, config *BM25Config) (*BM25, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadBM25(ctx, f, config)
+}
+
+func (e *BM25) Fit(_ context.Context, corpus []string) error {
+	stats, err := collectStats(e.conf.Tokenizer, corpus)
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	e.stats = stats
+	e.mu.Unlock()
+	return nil
+}
+
+func (e *BM25) EncodeDocuments(ctx context.Context, texts []string) ([]map[string]float64, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return encodeWithCallbacks(ctx, e.GetType(), encodeTargetDocument, texts, e.stats, func(text string) map[string]float64 {
+		k1, b := *e.conf.K1, *e.conf.B
+		terms := e.conf.Tokenizer.Tokenize(text)
+		norm := k1 * (1 - b + b*float64(len(terms))/math.Max(e.stats.AvgDocLen, 1))
+
+		vec := make(map[string]float64)
+		for term, tf := range termFreq(terms) {
+			f := float64(tf)
+			vec[term] = f * (k1 + 1) / (f + norm)
+		}
+		return vec
+	})
+}
+
+func (e *BM25) EncodeQueries(ctx context.Context, texts []string) ([]map[string]float64, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return encodeWithCallbacks(ctx, e.GetType(), encodeTargetQuery, texts, e.stats, func(text string) map[string]float64 {
+		vec := make(map[string]float64)
+		for term := range termFreq(e.conf.Tokenizer.Tokenize(text)) {
+			df, ok := e.stats.DocFreq[term]
+			if !ok {
+				continue
+			}
+			vec[term] = e.idf(df)
+		}
+		return vec
+	})
+}
+
+// idf uses the Lucene variant which is always positive.
+func (e *BM25) idf(df int) float64 {
+	n := float64(e.stats.DocCount)
+	return math.Log(1 + (n-float64(df)+0.5)/(float64(df)+0.5))
+}
+
+func (e *BM25) TokenIDs(vector map[string]float64) map[int]float64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.stats.tokenIDs(vector)
+}
+
+func (e *BM25) Save(w io.Writer) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return save(w, typeBM25, e.conf, e.stats)
+}
+
+func (e *BM25) GetType() string {
+	return typeBM25
+}
+
+func (e *BM25) IsCallbacksEnabled() bool {
+	return true
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
@@ -0,0 +1,231 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sparse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+var (
+	ErrNotFitted     = errors.New("embedding/sparse: encoder is not fitted")
+	ErrEmptyCorpus   = errors.New("embedding/sparse: corpus is empty")
+	ErrTypeMismatch  = errors.New("embedding/sparse: serialized encoder type mismatch")
+	ErrUnknownFormat = errors.New("embedding/sparse: unknown serialized format version")
+)
+
+const (
+	// ExtraKeySparseVectors is the key of the encoded sparse vectors ([]map[string]float64) in [embedding.CallbackOutput].Extra.
+	ExtraKeySparseVectors = "sparse_vectors"
+	// ExtraKeyEncodeTarget is the key of the encode target ("document" or "query") in [embedding.CallbackInput].Extra.
+	ExtraKeyEncodeTarget = "encode_target"
+
+	encodeTargetDocument = "document"
+	encodeTargetQuery    = "query"
+
+	formatVersion = 1
+)
+
+// Encoder produces sparse vectors from texts with corpus statistics collected by Fit.
+// Documents and queries are encoded asymmetrically, so that the dot product of a query vector
+// and a document vector is the relevance score of the algorithm.
+type Encoder interface {
+	// Fit collects corpus statistics, replacing any previously fitted state.
+	Fit(ctx context.Context, corpus []string) error
+	// EncodeDocuments encodes texts to be stored, keyed by term.
+	EncodeDocuments(ctx context.Context, texts []string) ([]map[string]float64, error)
+	// EncodeQueries encodes texts to be searched with, keyed by term.
+	// Terms unseen during Fit are dropped.
+	EncodeQueries(ctx context.Context, texts []string) ([]map[string]float64, error)
+	// TokenIDs converts a term-keyed vector to the token-id keyed form used by schema.Document.WithSparseVector.
+	// Terms absent from the fitted vocabulary are dropped.
+	TokenIDs(vector map[string]float64) map[int]float64
+	// Save serializes the fitted state, the tokenizer is not serialized.
+	Save(w io.Writer) error
+}
+
+// corpusStats holds the statistics shared by all encoders.
+type corpusStats struct {
+	DocCount  int            `json:"doc_count"`
+	AvgDocLen float64        `json:"avg_doc_len"`
+	DocFreq   map[string]int `json:"doc_freq"`
+	Vocab     map[string]int `json:"vocab"`
+}
+
+func collectStats(tokenizer Tokenizer, corpus []string) (*corpusStats, error) {
+	if len(corpus) == 0 {
+		return nil, ErrEmptyCorpus
+	}
+
+	st := &corpusStats{
+		DocCount: len(corpus),
+		DocFreq:  make(map[string]int),
+		Vocab:    make(map[string]int),
+	}
+
+	totalLen := 0
+	for _, text := range corpus {
+		terms := tokenizer.Tokenize(text)
+		totalLen += len(terms)
+		for term := range termFreq(terms) {
+			st.DocFreq[term]++
+		}
+	}
+	st.AvgDocLen = float64(totalLen) / float64(len(corpus))
+
+	// assign ids in lexical order so that fitting the same corpus always yields the same vocabulary
+	terms := make([]string, 0, len(st.DocFreq))
+	for term := range st.DocFreq {
+		terms = append(terms, term)
+	}
+	sort.Strings(terms)
+	for id, term := range terms {
+		st.Vocab[term] = id
+	}
+
+	return st, nil
+}
+
+func (s *corpusStats) tokenIDs(vector map[string]float64) map[int]float64 {
+	if s == nil {
+		return nil
+	}
+	ids := make(map[int]float64, len(vector))
+	for term, weight := range vector {
+		if id, ok := s.Vocab[term]; ok {
+			ids[id] = weight
+		}
+	}
+	return ids
+}
+
+func termFreq(terms []string) map[string]int {
+	tf := make(map[string]int, len(terms))
+	for _, term := range terms {
+		tf[term]++
+	}
+	return tf
+}
+
+// serialized is the on-disk format of an encoder.
+type serialized struct {
+	Version int             `json:"version"`
+	Type    string          `json:"type"`
+	Params  json.RawMessage `json:"params"`
+	Stats   *corpusStats    `json:"stats"`
+}
+
+func save(w io.Writer, typ string, params any, stats *corpusStats) error {
+	if stats == nil {
+		return ErrNotFitted
+	}
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("[sparse] marshal params failed: %w", err)
+	}
+	return json.NewEncoder(w).Encode(&serialized{
+		Version: formatVersion,
+		Type:    typ,
+		Params:  raw,
+		Stats:   stats,
+	})
+}
+
+func load(r io.Reader, typ string, params any) (*corpusStats, error) {
+	var s serialized
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, fmt.Errorf("[sparse] decode encoder failed: %w", err)
+	}
+	if s.Version != formatVersion {
+		return nil, ErrUnknownFormat
+	}
+	if s.Type != typ {
+		return nil, fmt.Errorf("%w: expected %s, got %s", ErrTypeMismatch, typ, s.Type)
+	}
+	if s.Stats == nil {
+		return nil, ErrNotFitted
+	}
+	if err := json.Unmarshal(s.Params, params); err != nil {
+		return nil, fmt.Errorf("[sparse] unmarshal params failed: %w", err)
+	}
+	return s.Stats, nil
+}
+
+// SaveFile writes the fitted state of the encoder to the file at path.
+func SaveFile(enc Encoder, path string) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cErr := f.Close(); err == nil {
+			err = cErr
+		}
+	}()
+	return enc.Save(f)
+}
+
+// ToFloat32 converts a sparse vector to the map[string]float32 form accepted by es8.WithSparseVector.
+func ToFloat32(vector map[string]float64) map[string]float32 {
+	out := make(map[string]float32, len(vector))
+	for k, v := range vector {
+		out[k] = float32(v)
+	}
+	return out
+}
+
+// encodeWithCallbacks runs fn inside the embedding component callbacks of the encoder.
+func encodeWithCallbacks(ctx context.Context, typ, target string, texts []string, stats *corpusStats,
+	fn func(text string) map[string]float64) (vectors []map[string]float64, err error) {
+
+	ctx = callbacks.EnsureRunInfo(ctx, typ, components.ComponentOfEmbedding)
+	ctx = callbacks.OnStart(ctx, &embedding.CallbackInput{
+		Texts:  texts,
+		Config: &embedding.Config{Model: typ},
+		Extra:  map[string]any{ExtraKeyEncodeTarget: target},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	if stats == nil {
+		return nil, ErrNotFitted
+	}
+
+	vectors = make([]map[string]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = fn(text)
+	}
+
+	callbacks.OnEnd(ctx, &embedding.CallbackOutput{
+		Config: &embedding.Config{Model: typ},
+		Extra:  map[string]any{ExtraKeySparseVectors: vectors},
+	})
+
+	return vectors, nil
+}
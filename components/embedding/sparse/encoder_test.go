@@ -0,0 +1,145 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sparse
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var corpus = []string{
+	"the cat sat on the mat",
+	"the dog sat on the log",
+	"cats and dogs are pets",
+}
+
+func dot(a, b map[string]float64) float64 {
+	var s float64
+	for k, v := range a {
+		s += v * b[k]
+	}
+	return s
+}
+
+func TestBM25(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("not fitted", func(t *testing.T) {
+		enc, err := NewBM25(ctx, nil)
+		require.NoError(t, err)
+		_, err = enc.EncodeQueries(ctx, []string{"cat"})
+		assert.ErrorIs(t, err, ErrNotFitted)
+		assert.ErrorIs(t, enc.Save(&bytes.Buffer{}), ErrNotFitted)
+		assert.ErrorIs(t, enc.Fit(ctx, nil), ErrEmptyCorpus)
+	})
+
+	enc, err := NewBM25(ctx, nil)
+	require.NoError(t, err)
+	require.NoError(t, enc.Fit(ctx, corpus))
+
+	docs, err := enc.EncodeDocuments(ctx, corpus)
+	require.NoError(t, err)
+	require.Len(t, docs, 3)
+
+	queries, err := enc.EncodeQueries(ctx, []string{"cat mat unicorn"})
+	require.NoError(t, err)
+	assert.NotContains(t, queries[0], "unicorn")
+
+	t.Run("score matches okapi bm25", func(t *testing.T) {
+		// doc 0 has 6 terms, avg len is 17/3, both query terms appear once
+		idf := math.Log(1 + (3-1+0.5)/(1+0.5))
+		tfw := 1 * 2.2 / (1 + 1.2*(1-0.75+0.75*6/(17.0/3)))
+		assert.InDelta(t, 2*idf*tfw, dot(queries[0], docs[0]), 1e-9)
+		assert.Zero(t, dot(queries[0], docs[1]))
+	})
+
+	t.Run("token ids", func(t *testing.T) {
+		ids := enc.TokenIDs(queries[0])
+		assert.Len(t, ids, 2)
+		assert.Equal(t, queries[0]["cat"], ids[enc.stats.Vocab["cat"]])
+	})
+
+	t.Run("save and load", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "bm25.json")
+		require.NoError(t, SaveFile(enc, path))
+
+		loaded, err := LoadBM25File(ctx, path, nil)
+		require.NoError(t, err)
+		got, err := loaded.EncodeDocuments(ctx, corpus)
+		require.NoError(t, err)
+		assert.Equal(t, docs, got)
+
+		_, err = LoadTFIDFFile(ctx, path, nil)
+		assert.ErrorIs(t, err, ErrTypeMismatch)
+	})
+
+	t.Run("explicit zero b", func(t *testing.T) {
+		b := 0.0
+		noNorm, err := NewBM25(ctx, &BM25Config{B: &b})
+		require.NoError(t, err)
+		require.NoError(t, noNorm.Fit(ctx, corpus))
+		got, err := noNorm.EncodeDocuments(ctx, corpus[:1])
+		require.NoError(t, err)
+		// without length normalization a term appearing once weighs (k1+1)/(1+k1) = 1
+		assert.InDelta(t, 1.0, got[0]["cat"], 1e-9)
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		k1, b := -1.0, 1.5
+		_, err := NewBM25(ctx, &BM25Config{K1: &k1})
+		assert.Error(t, err)
+		_, err = NewBM25(ctx, &BM25Config{B: &b})
+		assert.Error(t, err)
+	})
+}
+
+func TestTFIDF(t *testing.T) {
+	ctx := context.Background()
+
+	enc, err := NewTFIDF(ctx, &TFIDFConfig{SublinearTF: true})
+	require.NoError(t, err)
+	require.NoError(t, enc.Fit(ctx, corpus))
+
+	docs, err := enc.EncodeDocuments(ctx, corpus)
+	require.NoError(t, err)
+	for _, doc := range docs {
+		assert.InDelta(t, 1, dot(doc, doc), 1e-9)
+	}
+
+	queries, err := enc.EncodeQueries(ctx, []string{"dog log"})
+	require.NoError(t, err)
+	assert.Greater(t, dot(queries[0], docs[1]), dot(queries[0], docs[0]))
+
+	var buf bytes.Buffer
+	require.NoError(t, enc.Save(&buf))
+	loaded, err := LoadTFIDF(ctx, &buf, nil)
+	require.NoError(t, err)
+	assert.True(t, loaded.conf.SublinearTF)
+	got, err := loaded.EncodeQueries(ctx, []string{"dog log"})
+	require.NoError(t, err)
+	assert.Equal(t, queries, got)
+}
+
+func TestToFloat32(t *testing.T) {
+	assert.Equal(t, map[string]float32{"a": 0.5}, ToFloat32(map[string]float64{"a": 0.5}))
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/cloudwego/eino-ext/components/embedding/sparse"
+	"github.com/cloudwego/eino/schema"
+)
+
+func main() {
+	ctx := context.Background()
+
+	corpus := []string{
+		"Eino 是一个 Go 语言的大模型应用开发框架",
+		"BM25 is a bag-of-words ranking function",
+		"稀疏向量可以与稠密向量一起用于混合检索",
+	}
+
+	enc, err := sparse.NewBM25(ctx, &sparse.BM25Config{
+		Tokenizer: sparse.NewCJKBigramTokenizer(),
+	})
+	if err != nil {
+		log.Fatalf("NewBM25 failed, err=%v", err)
+	}
+
+	if err = enc.Fit(ctx, corpus); err != nil {
+		log.Fatalf("Fit failed, err=%v", err)
+	}
+
+	vectors, err := enc.EncodeDocuments(ctx, corpus)
+	if err != nil {
+		log.Fatalf("EncodeDocuments failed, err=%v", err)
+	}
+
+	docs := make([]*schema.Document, len(corpus))
+	for i, text := range corpus {
+		docs[i] = (&schema.Document{ID: string(rune('a' + i)), Content: text}).
+			WithSparseVector(enc.TokenIDs(vectors[i]))
+	}
+	log.Printf("doc sparse vector: %v", docs[2].SparseVector())
+
+	query, err := enc.EncodeQueries(ctx, []string{"混合检索"})
+	if err != nil {
+		log.Fatalf("EncodeQueries failed, err=%v", err)
+	}
+	log.Printf("query sparse vector: %v", query[0])
+
+	if err = sparse.SaveFile(enc, "bm25.json"); err != nil {
+		log.Fatalf("SaveFile failed, err=%v", err)
+	}
+
+	restored, err := sparse.LoadBM25File(ctx, "bm25.json", &sparse.BM25Config{
+		Tokenizer: sparse.NewCJKBigramTokenizer(),
+	})
+	if err != nil {
+		log.Fatalf("LoadBM25File failed, err=%v", err)
+	}
+	query, _ = restored.EncodeQueries(ctx, []string{"混合检索"})
+	log.Printf("query sparse vector from restored encoder: %v", query[0])
+}
@@ -0,0 +1,161 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sparse
+
+import (
+	"context"
+	"io"
+	"math"
+	"os"
+	"sync"
+)
+
+const typeTFIDF = "TFIDF"
+
+type TFIDFConfig struct {
+	// Tokenizer splits texts into terms.
+	// Optional. Default: NewWhitespaceTokenizer()
+	Tokenizer Tokenizer `json:"-"`
+	// SublinearTF replaces tf with 1 + log(tf).
+	// Optional. Default: false
+	SublinearTF bool `json:"sublinear_tf"`
+	// DisableL2Norm disables L2 normalization of the encoded vectors.
+	// With normalization the dot product of two vectors is their cosine similarity.
+	// Optional. Default: false
+	DisableL2Norm bool `json:"disable_l2_norm"`
+}
+
+// TFIDF encodes documents and queries symmetrically as tf * smoothed idf weights.
+// Terms unseen during Fit are dropped for both documents and queries.
+type TFIDF struct {
+	conf *TFIDFConfig
+
+	mu    sync.RWMutex
+	stats *corpusStats
+}
+
+var _ Encoder = (*TFIDF)(nil)
+
+// NewTFIDF creates an unfitted [TFIDF] encoder.
+func NewTFIDF(_ context.Context, config *TFIDFConfig) (*TFIDF, error) {
+	conf := &TFIDFConfig{}
+	if config != nil {
+		*conf = *config
+	}
+	if conf.Tokenizer == nil {
+		conf.Tokenizer = NewWhitespaceTokenizer()
+	}
+	return &TFIDF{conf: conf}, nil
+}
+
+// LoadTFIDF restores a [TFIDF] encoder saved by [TFIDF.Save].
+// Weighting params are taken from the serialized state, only Tokenizer of config is used.
+func LoadTFIDF(ctx context.Context, r io.Reader, config *TFIDFConfig) (*TFIDF, error) {
+	enc, err := NewTFIDF(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := load(r, typeTFIDF, enc.conf)
+	if err != nil {
+		return nil, err
+	}
+	enc.stats = stats
+	return enc, nil
+}
+
+// LoadTFIDFFile restores a [TFIDF] encoder from the file written by [SaveFile].
+func LoadTFIDFFile(ctx context.Context, path string, config *TFIDFConfig) (*TFIDF, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadTFIDF(ctx, f, config)
+}
+
+func (e *TFIDF) Fit(_ context.Context, corpus []string) error {
+	stats, err := collectStats(e.conf.Tokenizer, corpus)
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	e.stats = stats
+	e.mu.Unlock()
+	return nil
+}
+
+func (e *TFIDF) EncodeDocuments(ctx context.Context, texts []string) ([]map[string]float64, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return encodeWithCallbacks(ctx, e.GetType(), encodeTargetDocument, texts, e.stats, e.encode)
+}
+
+func (e *TFIDF) EncodeQueries(ctx context.Context, texts []string) ([]map[string]float64, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return encodeWithCallbacks(ctx, e.GetType(), encodeTargetQuery, texts, e.stats, e.encode)
+}
+
+func (e *TFIDF) encode(text string) map[string]float64 {
+	vec := make(map[string]float64)
+	n := float64(e.stats.DocCount)
+
+	var sumSquares float64
+	for term, tf := range termFreq(e.conf.Tokenizer.Tokenize(text)) {
+		df, ok := e.stats.DocFreq[term]
+		if !ok {
+			continue
+		}
+		w := float64(tf)
+		if e.conf.SublinearTF {
+			w = 1 + math.Log(w)
+		}
+		// smoothed idf, as if an extra document containing every term was seen once
+		w *= math.Log((1+n)/(1+float64(df))) + 1
+		vec[term] = w
+		sumSquares += w * w
+	}
+
+	if !e.conf.DisableL2Norm && sumSquares > 0 {
+		norm := math.Sqrt(sumSquares)
+		for term := range vec {
+			vec[term] /= norm
+		}
+	}
+
+	return vec
+}
+
+func (e *TFIDF) TokenIDs(vector map[string]float64) map[int]float64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.stats.tokenIDs(vector)
+}
+
+func (e *TFIDF) Save(w io.Writer) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return save(w, typeTFIDF, e.conf, e.stats)
+}
+
+func (e *TFIDF) GetType() string {
+	return typeTFIDF
+}
+
+func (e *TFIDF) IsCallbacksEnabled() bool {
+	return true
+}
@@ -0,0 +1,144 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sparse
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Tokenizer splits a text into terms.
+// The same tokenizer must be used when fitting, encoding documents and encoding queries.
+type Tokenizer interface {
+	Tokenize(text string) []string
+}
+
+// TokenizerFunc is an adapter to allow the use of ordinary functions as [Tokenizer].
+type TokenizerFunc func(text string) []string
+
+func (f TokenizerFunc) Tokenize(text string) []string {
+	return f(text)
+}
+
+// WhitespaceTokenizer lower-cases the text and splits it on any rune that is neither a letter nor a digit.
+// Terms found in StopWords are dropped.
+type WhitespaceTokenizer struct {
+	StopWords map[string]struct{}
+}
+
+var _ Tokenizer = (*WhitespaceTokenizer)(nil)
+
+// NewWhitespaceTokenizer creates a new [WhitespaceTokenizer] with optional stop words.
+func NewWhitespaceTokenizer(stopWords ...string) *WhitespaceTokenizer {
+	return &WhitespaceTokenizer{StopWords: toStopWordSet(stopWords)}
+}
+
+func (t *WhitespaceTokenizer) Tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if _, ok := t.StopWords[f]; ok {
+			continue
+		}
+		terms = append(terms, f)
+	}
+	return terms
+}
+
+// CJKBigramTokenizer tokenizes mixed CJK and latin text.
+// Runs of Han, Hiragana, Katakana and Hangul characters are emitted as overlapping bigrams
+// (a single character run is emitted as a unigram), other letters and digits are grouped
+// into lower-cased words the same way as [WhitespaceTokenizer].
+type CJKBigramTokenizer struct {
+	StopWords map[string]struct{}
+}
+
+var _ Tokenizer = (*CJKBigramTokenizer)(nil)
+
+// NewCJKBigramTokenizer creates a new [CJKBigramTokenizer] with optional stop words.
+func NewCJKBigramTokenizer(stopWords ...string) *CJKBigramTokenizer {
+	return &CJKBigramTokenizer{StopWords: toStopWordSet(stopWords)}
+}
+
+func (t *CJKBigramTokenizer) Tokenize(text string) []string {
+	var (
+		terms []string
+		word  []rune
+		cjk   []rune
+	)
+
+	emit := func(term string) {
+		if _, ok := t.StopWords[term]; ok {
+			return
+		}
+		terms = append(terms, term)
+	}
+	flushWord := func() {
+		if len(word) > 0 {
+			emit(strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+	flushCJK := func() {
+		switch len(cjk) {
+		case 0:
+		case 1:
+			emit(string(cjk))
+		default:
+			for i := 0; i+1 < len(cjk); i++ {
+				emit(string(cjk[i : i+2]))
+			}
+		}
+		cjk = cjk[:0]
+	}
+
+	for _, r := range text {
+		switch {
+		case isCJK(r):
+			flushWord()
+			cjk = append(cjk, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushCJK()
+			word = append(word, r)
+		default:
+			flushWord()
+			flushCJK()
+		}
+	}
+	flushWord()
+	flushCJK()
+
+	return terms
+}
+
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) ||
+		unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) ||
+		unicode.Is(unicode.Hangul, r)
+}
+
+func toStopWordSet(words []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(words))
+	for _, w := range words {
+		set[strings.ToLower(w)] = struct{}{}
+	}
+	return set
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sparse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWhitespaceTokenizer(t *testing.T) {
+	tk := NewWhitespaceTokenizer("the")
+	assert.Equal(t, []string{"quick", "brown", "fox", "42"}, tk.Tokenize("The quick, Brown fox: 42!"))
+	assert.Empty(t, tk.Tokenize("  ,,  "))
+}
+
+func TestCJKBigramTokenizer(t *testing.T) {
+	tk := NewCJKBigramTokenizer()
+
+	t.Run("pure cjk", func(t *testing.T) {
+		assert.Equal(t, []string{"向量", "量检", "检索"}, tk.Tokenize("向量检索"))
+	})
+
+	t.Run("single cjk char", func(t *testing.T) {
+		assert.Equal(t, []string{"猫"}, tk.Tokenize("猫"))
+	})
+
+	t.Run("mixed", func(t *testing.T) {
+		assert.Equal(t, []string{"使用", "bm25", "检索", "文档"}, tk.Tokenize("使用BM25检索，文档"))
+	})
+
+	t.Run("stop words", func(t *testing.T) {
+		tk := NewCJKBigramTokenizer("Is", "检索")
+		assert.Equal(t, []string{"this", "向量", "量检"}, tk.Tokenize("this is 向量检索"))
+	})
+}
+
+func TestTokenizerFunc(t *testing.T) {
+	var tk Tokenizer = TokenizerFunc(func(text string) []string { return []string{text} })
+	assert.Equal(t, []string{"a b"}, tk.Tokenize("a b"))
+}
@@ -0,0 +1,187 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tei
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/bytedance/sonic"
+)
+
+type teiRequest struct {
+	Inputs              []string `json:"inputs"`
+	Truncate            *bool    `json:"truncate,omitempty"`
+	TruncationDirection string   `json:"truncation_direction,omitempty"`
+	Normalize           *bool    `json:"normalize,omitempty"`
+	PromptName          *string  `json:"prompt_name,omitempty"`
+}
+
+type teiErrorResponse struct {
+	Error     string `json:"error"`
+	ErrorType string `json:"error_type"`
+}
+
+type openAIRequest struct {
+	Model          string   `json:"model,omitempty"`
+	Input          []string `json:"input"`
+	EncodingFormat string   `json:"encoding_format,omitempty"`
+	Dimensions     *int     `json:"dimensions,omitempty"`
+}
+
+type openAIEmbedding struct {
+	Index     int       `json:"index"`
+	Embedding []float64 `json:"embedding"`
+}
+
+type openAIUsage struct {
+	PromptTokens int `json:"prompt_tokens"`
+	TotalTokens  int `json:"total_tokens"`
+}
+
+type openAIResponse struct {
+	Model string            `json:"model"`
+	Data  []openAIEmbedding `json:"data"`
+	Usage *openAIUsage      `json:"usage"`
+}
+
+type openAIErrorResponse struct {
+	Error *struct {
+		Message string `json:"message"`
+		Type    string `json:"type"`
+	} `json:"error"`
+}
+
+// batchResult is the result of embedding one batch of texts.
+type batchResult struct {
+	embeddings   [][]float64
+	promptTokens int
+	totalTokens  int
+	hasUsage     bool
+}
+
+func (e *Embedder) getURL() string {
+	return strings.TrimRight(e.conf.BaseURL, "/") + e.conf.Path
+}
+
+func (e *Embedder) embedTEI(ctx context.Context, texts []string, promptName *string) (*batchResult, error) {
+	req := &teiRequest{
+		Inputs:              texts,
+		Truncate:            e.conf.Truncate,
+		TruncationDirection: e.conf.TruncationDirection,
+		Normalize:           e.conf.Normalize,
+		PromptName:          promptName,
+	}
+
+	body, header, err := e.doPost(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &batchResult{}
+	if err = sonic.Unmarshal(body, &res.embeddings); err != nil {
+		return nil, fmt.Errorf("decode response failed: %w", err)
+	}
+	if tokens, err := strconv.Atoi(header.Get(teiComputeTokensHeader)); err == nil {
+		res.promptTokens, res.totalTokens, res.hasUsage = tokens, tokens, true
+	}
+	return res, nil
+}
+
+func (e *Embedder) embedOpenAI(ctx context.Context, model string, texts []string) (*batchResult, error) {
+	req := &openAIRequest{
+		Model:          model,
+		Input:          texts,
+		EncodingFormat: "float",
+		Dimensions:     e.conf.Dimensions,
+	}
+
+	body, _, err := e.doPost(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &openAIResponse{}
+	if err = sonic.Unmarshal(body, resp); err != nil {
+		return nil, fmt.Errorf("decode response failed: %w", err)
+	}
+	if len(resp.Data) != len(texts) {
+		return nil, fmt.Errorf("unexpected number of embeddings: got %d, want %d", len(resp.Data), len(texts))
+	}
+
+	res := &batchResult{embeddings: make([][]float64, len(texts))}
+	for _, d := range resp.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("embedding index out of range: %d", d.Index)
+		}
+		res.embeddings[d.Index] = d.Embedding
+	}
+	if resp.Usage != nil {
+		res.promptTokens, res.totalTokens, res.hasUsage = resp.Usage.PromptTokens, resp.Usage.TotalTokens, true
+	}
+	return res, nil
+}
+
+func (e *Embedder) doPost(ctx context.Context, payload any) ([]byte, http.Header, error) {
+	reqData, err := sonic.Marshal(payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error marshaling data: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.getURL(), bytes.NewReader(reqData))
+	if err != nil {
+		return nil, nil, fmt.Errorf("create request failed: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.conf.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.conf.APIKey)
+	}
+
+	resp, err := e.cli.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("do request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read response failed: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, parseError(resp.StatusCode, body)
+	}
+
+	return body, resp.Header, nil
+}
+
+func parseError(statusCode int, body []byte) error {
+	teiErr := &teiErrorResponse{}
+	if err := sonic.Unmarshal(body, teiErr); err == nil && teiErr.Error != "" {
+		return fmt.Errorf("request failed with status code %d: %s (%s)", statusCode, teiErr.Error, teiErr.ErrorType)
+	}
+	openAIErr := &openAIErrorResponse{}
+	if err := sonic.Unmarshal(body, openAIErr); err == nil && openAIErr.Error != nil && openAIErr.Error.Message != "" {
+		return fmt.Errorf("request failed with status code %d: %s", statusCode, openAIErr.Error.Message)
+	}
+	return fmt.Errorf("request failed with status code: %d", statusCode)
+}
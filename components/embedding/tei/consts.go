@@ -0,0 +1,46 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tei
+
+type APIType string
+
+const (
+	// APITypeTEI uses the native /embed route of Hugging Face Text Embeddings Inference.
+	APITypeTEI APIType = "tei"
+	// APITypeOpenAI uses the OpenAI compatible /v1/embeddings route,
+	// which is served by TEI, Infinity, llama.cpp server, vLLM and others.
+	APITypeOpenAI APIType = "openai"
+)
+
+type InputType string
+
+const (
+	// InputTypeDocument marks texts as documents to be stored, it is the default input type.
+	InputTypeDocument InputType = "document"
+	// InputTypeQuery marks texts as search queries.
+	InputTypeQuery InputType = "query"
+)
+
+const (
+	typ = "TEI"
+
+	defaultTEIPath    = "/embed"
+	defaultOpenAIPath = "/v1/embeddings"
+
+	// teiComputeTokensHeader is the response header in which TEI reports the number of tokens processed.
+	teiComputeTokensHeader = "x-compute-tokens"
+)
@@ -0,0 +1,288 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tei
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+
+	"github.com/cloudwego/eino-ext/libs/acl/tokenusage"
+)
+
+type EmbeddingConfig struct {
+	// Timeout specifies the maximum duration to wait for API responses
+	// If HTTPClient is set, Timeout will not be used.
+	// Optional. Default: no timeout
+	Timeout time.Duration `json:"timeout"`
+
+	// HTTPClient specifies the client to send HTTP requests.
+	// If HTTPClient is set, Timeout will not be used.
+	// Optional. Default &http.Client{Timeout: Timeout}
+	HTTPClient *http.Client `json:"http_client"`
+
+	// BaseURL specifies the inference server endpoint URL
+	// Format: http(s)://host:port
+	// Required
+	BaseURL string `json:"base_url"`
+
+	// APIKey is sent as a bearer token when set, e.g. TEI started with --api-key
+	// Optional
+	APIKey string `json:"api_key"`
+
+	// APIType selects the route and the request format
+	// Optional. Default: APITypeTEI
+	APIType APIType `json:"api_type"`
+
+	// Path overrides the route appended to BaseURL, e.g. "/embeddings" for Infinity
+	// Optional. Default: "/embed" for APITypeTEI, "/v1/embeddings" for APITypeOpenAI
+	Path string `json:"path"`
+
+	// Model specifies the model name sent with APITypeOpenAI requests.
+	// TEI and llama.cpp serve a single model and ignore it, Infinity requires it when serving several models.
+	// Optional
+	Model string `json:"model"`
+
+	// BatchSize limits the number of texts sent in one request, TEI rejects batches larger than --max-client-batch-size.
+	// Optional. Default: 0, all texts are sent in one request
+	BatchSize int `json:"batch_size"`
+
+	// QueryInstruction is prepended to texts embedded with WithInputType(InputTypeQuery),
+	// e.g. "Represent this sentence for searching relevant passages: " for BGE models.
+	// Optional
+	QueryInstruction string `json:"query_instruction"`
+
+	// DocumentInstruction is prepended to texts embedded as documents, e.g. "passage: " for E5 models.
+	// Optional
+	DocumentInstruction string `json:"document_instruction"`
+
+	// The following fields are only used with APITypeTEI
+	// Ref: https://huggingface.github.io/text-embeddings-inference/#/Text%20Embeddings%20Inference/embed
+
+	// Truncate specifies whether to truncate inputs longer than the model's maximum length
+	// Optional. Default: server default
+	Truncate *bool `json:"truncate,omitempty"`
+
+	// TruncationDirection is "Left" or "Right"
+	// Optional. Default: server default
+	TruncationDirection string `json:"truncation_direction,omitempty"`
+
+	// Normalize specifies whether the server L2-normalizes the embeddings
+	// Optional. Default: server default
+	Normalize *bool `json:"normalize,omitempty"`
+
+	// QueryPromptName is the name of a prompt from the model's sentence-transformers config used for queries.
+	// Optional
+	QueryPromptName *string `json:"query_prompt_name,omitempty"`
+
+	// DocumentPromptName is the name of a prompt from the model's sentence-transformers config used for documents.
+	// Optional
+	DocumentPromptName *string `json:"document_prompt_name,omitempty"`
+
+	// The following fields are only used with APITypeOpenAI
+
+	// Dimensions specifies the number of dimensions of the output embeddings, if the server supports it
+	// Optional
+	Dimensions *int `json:"dimensions,omitempty"`
+
+	// TokenCounter counts the tokens of a text, used to estimate TokenUsage when the server reports none,
+	// e.g. TEI behind a proxy dropping the x-compute-tokens header, or an OpenAI compatible server without usage.
+	// Optional. Default: a character based approximation
+	TokenCounter func(text string) int `json:"-"`
+}
+
+var _ embedding.Embedder = (*Embedder)(nil)
+
+type Embedder struct {
+	cli  *http.Client
+	conf *EmbeddingConfig
+}
+
+func NewEmbedder(ctx context.Context, config *EmbeddingConfig) (*Embedder, error) {
+	if config == nil {
+		return nil, fmt.Errorf("embedding config must not be nil")
+	}
+	if config.BaseURL == "" {
+		return nil, fmt.Errorf("base url is required")
+	}
+	if config.BatchSize < 0 {
+		return nil, fmt.Errorf("batch size must not be negative")
+	}
+
+	conf := *config
+	if conf.APIType == "" {
+		conf.APIType = APITypeTEI
+	}
+	if conf.Path == "" {
+		switch conf.APIType {
+		case APITypeTEI:
+			conf.Path = defaultTEIPath
+		case APITypeOpenAI:
+			conf.Path = defaultOpenAIPath
+		default:
+			return nil, fmt.Errorf("unknown api type: %s", conf.APIType)
+		}
+	}
+
+	var httpClient *http.Client
+	if conf.HTTPClient != nil {
+		httpClient = conf.HTTPClient
+	} else {
+		httpClient = &http.Client{Timeout: conf.Timeout}
+	}
+
+	return &Embedder{
+		cli:  httpClient,
+		conf: &conf,
+	}, nil
+}
+
+func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) (
+	embeddings [][]float64, err error) {
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	options := embedding.GetCommonOptions(&embedding.Options{
+		Model: &e.conf.Model,
+	}, opts...)
+	specOptions := embedding.GetImplSpecificOptions(&implOptions{
+		InputType: InputTypeDocument,
+	}, opts...)
+
+	conf := &embedding.Config{
+		Model: *options.Model,
+	}
+
+	ctx = callbacks.EnsureRunInfo(ctx, e.GetType(), components.ComponentOfEmbedding)
+	ctx = callbacks.OnStart(ctx, &embedding.CallbackInput{
+		Texts:  texts,
+		Config: conf,
+	})
+
+	instruction, promptName := e.conf.DocumentInstruction, e.conf.DocumentPromptName
+	if specOptions.InputType == InputTypeQuery {
+		instruction, promptName = e.conf.QueryInstruction, e.conf.QueryPromptName
+	}
+
+	inputs := texts
+	if instruction != "" {
+		inputs = make([]string, len(texts))
+		for i, text := range texts {
+			inputs[i] = instruction + text
+		}
+	}
+
+	var (
+		usage     embedding.TokenUsage
+		estimated bool
+	)
+	embeddings = make([][]float64, 0, len(texts))
+	for _, batch := range e.batches(inputs) {
+		var res *batchResult
+		switch e.conf.APIType {
+		case APITypeOpenAI:
+			res, err = e.embedOpenAI(ctx, conf.Model, batch)
+		default:
+			res, err = e.embedTEI(ctx, batch, promptName)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("[TEI] EmbedStrings error: %w", err)
+		}
+		if len(res.embeddings) != len(batch) {
+			return nil, fmt.Errorf("[TEI] EmbedStrings error: unexpected number of embeddings: got %d, want %d",
+				len(res.embeddings), len(batch))
+		}
+
+		embeddings = append(embeddings, res.embeddings...)
+		if res.hasUsage {
+			usage.PromptTokens += res.promptTokens
+			usage.TotalTokens += res.totalTokens
+		} else {
+			counted := tokenusage.Count(e.conf.TokenCounter, batch)
+			estimated = true
+			usage.PromptTokens += counted.PromptTokens
+			usage.TotalTokens += counted.TotalTokens
+		}
+	}
+
+	var extra map[string]any
+	if estimated {
+		extra = map[string]any{tokenusage.ExtraKeyEstimated: true}
+	}
+
+	callbacks.OnEnd(ctx, &embedding.CallbackOutput{
+		Embeddings: embeddings,
+		Config:     conf,
+		TokenUsage: &usage,
+		Extra:      extra,
+	})
+
+	return embeddings, nil
+}
+
+func (e *Embedder) batches(texts []string) [][]string {
+	if e.conf.BatchSize <= 0 || len(texts) <= e.conf.BatchSize {
+		return [][]string{texts}
+	}
+	batches := make([][]string, 0, (len(texts)+e.conf.BatchSize-1)/e.conf.BatchSize)
+	for start := 0; start < len(texts); start += e.conf.BatchSize {
+		end := start + e.conf.BatchSize
+		if end > len(texts) {
+			end = len(texts)
+		}
+		batches = append(batches, texts[start:end])
+	}
+	return batches
+}
+
+// ForQueries returns an embedder that always embeds texts as queries.
+// Retrievers don't pass embedding options, so pass it as their Embedding to get the query instruction applied.
+func (e *Embedder) ForQueries() embedding.Embedder {
+	return &queryEmbedder{e: e}
+}
+
+type queryEmbedder struct {
+	e *Embedder
+}
+
+func (q *queryEmbedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	return q.e.EmbedStrings(ctx, texts, append([]embedding.Option{WithInputType(InputTypeQuery)}, opts...)...)
+}
+
+func (q *queryEmbedder) GetType() string {
+	return q.e.GetType()
+}
+
+func (q *queryEmbedder) IsCallbacksEnabled() bool {
+	return q.e.IsCallbacksEnabled()
+}
+
+func (e *Embedder) GetType() string {
+	return typ
+}
+
+func (e *Embedder) IsCallbacksEnabled() bool {
+	return true
+}
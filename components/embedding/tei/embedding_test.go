@@ -0,0 +1,218 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tei
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudwego/eino-ext/libs/acl/tokenusage"
+)
+
+func newTEIServer(t *testing.T, requests *[]teiRequest) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/embed", r.URL.Path)
+		var req teiRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		*requests = append(*requests, req)
+
+		if len(req.Inputs) == 0 {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"error":"Input validation error: inputs cannot be empty","error_type":"Validation"}`))
+			return
+		}
+
+		out := make([][]float64, len(req.Inputs))
+		for i, in := range req.Inputs {
+			out[i] = []float64{float64(len(in)), 1}
+		}
+		w.Header().Set(teiComputeTokensHeader, strconv.Itoa(len(req.Inputs)*3))
+		_ = json.NewEncoder(w).Encode(out)
+	}))
+}
+
+func TestNewEmbedder(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewEmbedder(ctx, nil)
+	assert.Error(t, err)
+	_, err = NewEmbedder(ctx, &EmbeddingConfig{})
+	assert.Error(t, err)
+	_, err = NewEmbedder(ctx, &EmbeddingConfig{BaseURL: "http://localhost", APIType: "grpc"})
+	assert.Error(t, err)
+
+	e, err := NewEmbedder(ctx, &EmbeddingConfig{BaseURL: "http://localhost:8080/", APIType: APITypeOpenAI})
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080/v1/embeddings", e.getURL())
+}
+
+func TestEmbedStrings_TEI(t *testing.T) {
+	ctx := context.Background()
+	var requests []teiRequest
+	srv := newTEIServer(t, &requests)
+	defer srv.Close()
+
+	truncate := true
+	queryPrompt := "query"
+	e, err := NewEmbedder(ctx, &EmbeddingConfig{
+		BaseURL:          srv.URL,
+		BatchSize:        2,
+		Truncate:         &truncate,
+		QueryInstruction: "q: ",
+		QueryPromptName:  &queryPrompt,
+	})
+	require.NoError(t, err)
+
+	t.Run("documents in batches with usage", func(t *testing.T) {
+		requests = nil
+		var usage *embedding.TokenUsage
+		handler := callbacks.NewHandlerBuilder().
+			OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+				usage = embedding.ConvCallbackOutput(output).TokenUsage
+				return ctx
+			}).Build()
+
+		got, err := e.EmbedStrings(callbacks.InitCallbacks(ctx, nil, handler), []string{"a", "bb", "ccc"})
+		require.NoError(t, err)
+		assert.Equal(t, [][]float64{{1, 1}, {2, 1}, {3, 1}}, got)
+
+		require.Len(t, requests, 2)
+		assert.Equal(t, []string{"a", "bb"}, requests[0].Inputs)
+		assert.Equal(t, &truncate, requests[0].Truncate)
+		assert.Nil(t, requests[0].PromptName)
+		require.NotNil(t, usage)
+		assert.Equal(t, 9, usage.PromptTokens)
+		assert.Equal(t, 9, usage.TotalTokens)
+	})
+
+	t.Run("estimated usage", func(t *testing.T) {
+		noHeader := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`[[1, 1]]`))
+		}))
+		defer noHeader.Close()
+		ne, err := NewEmbedder(ctx, &EmbeddingConfig{
+			BaseURL:      noHeader.URL,
+			TokenCounter: func(text string) int { return len(text) },
+		})
+		require.NoError(t, err)
+
+		var output *embedding.CallbackOutput
+		handler := callbacks.NewHandlerBuilder().
+			OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, out callbacks.CallbackOutput) context.Context {
+				output = embedding.ConvCallbackOutput(out)
+				return ctx
+			}).Build()
+
+		_, err = ne.EmbedStrings(callbacks.InitCallbacks(ctx, nil, handler), []string{"hello"})
+		require.NoError(t, err)
+		require.NotNil(t, output)
+		assert.Equal(t, &embedding.TokenUsage{PromptTokens: 5, TotalTokens: 5}, output.TokenUsage)
+		assert.Equal(t, true, output.Extra[tokenusage.ExtraKeyEstimated])
+	})
+
+	t.Run("queries", func(t *testing.T) {
+		requests = nil
+		got, err := e.ForQueries().EmbedStrings(ctx, []string{"a"})
+		require.NoError(t, err)
+		assert.Equal(t, [][]float64{{4, 1}}, got)
+		require.Len(t, requests, 1)
+		assert.Equal(t, []string{"q: a"}, requests[0].Inputs)
+		assert.Equal(t, &queryPrompt, requests[0].PromptName)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := e.EmbedStrings(ctx, []string{})
+		assert.ErrorContains(t, err, "inputs cannot be empty")
+	})
+}
+
+func TestEmbedStrings_OpenAI(t *testing.T) {
+	ctx := context.Background()
+	var got openAIRequest
+	withUsage := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/embeddings", r.URL.Path)
+		require.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+
+		if got.Model == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"message":"model missing not found","type":"invalid_request_error"}}`))
+			return
+		}
+
+		resp := openAIResponse{Model: got.Model}
+		// return data out of order, clients must sort by index
+		for i := len(got.Input) - 1; i >= 0; i-- {
+			resp.Data = append(resp.Data, openAIEmbedding{Index: i, Embedding: []float64{float64(i)}})
+		}
+		if withUsage {
+			resp.Usage = &openAIUsage{PromptTokens: 5, TotalTokens: 5}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	e, err := NewEmbedder(ctx, &EmbeddingConfig{
+		BaseURL:             srv.URL,
+		APIType:             APITypeOpenAI,
+		Path:                "/embeddings",
+		APIKey:              "key",
+		Model:               "bge-m3",
+		DocumentInstruction: "passage: ",
+	})
+	require.NoError(t, err)
+
+	var (
+		usage *embedding.TokenUsage
+		extra map[string]any
+	)
+	handler := callbacks.NewHandlerBuilder().
+		OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+			usage = embedding.ConvCallbackOutput(output).TokenUsage
+			extra = embedding.ConvCallbackOutput(output).Extra
+			return ctx
+		}).Build()
+	cbCtx := callbacks.InitCallbacks(ctx, nil, handler)
+
+	embeddings, err := e.EmbedStrings(cbCtx, []string{"x", "y"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]float64{{0}, {1}}, embeddings)
+	assert.Equal(t, "bge-m3", got.Model)
+	assert.Equal(t, []string{"passage: x", "passage: y"}, got.Input)
+	assert.Equal(t, &embedding.TokenUsage{PromptTokens: 5, TotalTokens: 5}, usage)
+	assert.Nil(t, extra)
+
+	// estimated from "passage: x"
+	withUsage = false
+	_, err = e.EmbedStrings(cbCtx, []string{"x"})
+	require.NoError(t, err)
+	assert.Equal(t, &embedding.TokenUsage{PromptTokens: 4, TotalTokens: 4}, usage)
+	assert.Equal(t, true, extra[tokenusage.ExtraKeyEstimated])
+
+	_, err = e.EmbedStrings(ctx, []string{"x"}, embedding.WithModel("missing"))
+	assert.ErrorContains(t, err, "model missing not found")
+}
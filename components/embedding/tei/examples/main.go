@@ -0,0 +1,57 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino-ext/components/embedding/tei"
+)
+
+func main() {
+	ctx := context.Background()
+
+	baseURL := os.Getenv("TEI_BASE_URL")
+	if baseURL == "" {
+		baseURL = "http://localhost:8080"
+	}
+
+	embedder, err := tei.NewEmbedder(ctx, &tei.EmbeddingConfig{
+		BaseURL:          baseURL,
+		APIType:          tei.APITypeTEI,
+		BatchSize:        32,
+		QueryInstruction: "Represent this sentence for searching relevant passages: ",
+	})
+	if err != nil {
+		log.Fatalf("NewEmbedder of tei failed, err=%v", err)
+	}
+
+	docs, err := embedder.EmbedStrings(ctx, []string{"hello", "how are you"})
+	if err != nil {
+		log.Fatalf("EmbedStrings failed, err=%v", err)
+	}
+	log.Printf("document embeddings: %d x %d", len(docs), len(docs[0]))
+
+	// pass embedder.ForQueries() as the Embedding of a retriever to apply the query instruction
+	queries, err := embedder.EmbedStrings(ctx, []string{"greetings"}, tei.WithInputType(tei.InputTypeQuery))
+	if err != nil {
+		log.Fatalf("EmbedStrings failed, err=%v", err)
+	}
+	log.Printf("query embedding dims: %d", len(queries[0]))
+}
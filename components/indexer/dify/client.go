@@ -0,0 +1,222 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/bytedance/sonic"
+)
+
+// ProcessRule is how Dify cleans and segments a document.
+// see: https://docs.dify.ai/guides/knowledge-base/knowledge-and-documents-maintenance/maintain-dataset-via-api
+type ProcessRule struct {
+	Mode ProcessMode `json:"mode"`
+	// Rules are required by ProcessModeCustom and ProcessModeHierarchical.
+	Rules *Rules `json:"rules,omitempty"`
+}
+
+type Rules struct {
+	PreProcessingRules []*PreProcessingRule `json:"pre_processing_rules"`
+	Segmentation       *Segmentation        `json:"segmentation"`
+	// ParentMode of ProcessModeHierarchical, "full-doc" or "paragraph".
+	ParentMode string `json:"parent_mode,omitempty"`
+	// SubchunkSegmentation of ProcessModeHierarchical segments the child chunks.
+	SubchunkSegmentation *Segmentation `json:"subchunk_segmentation,omitempty"`
+}
+
+type PreProcessingRule struct {
+	// ID of the rule, "remove_extra_spaces" or "remove_urls_emails".
+	ID      string `json:"id"`
+	Enabled bool   `json:"enabled"`
+}
+
+type Segmentation struct {
+	// Separator of segments, e.g. "\n".
+	Separator string `json:"separator"`
+	// MaxTokens of a segment.
+	MaxTokens    int `json:"max_tokens"`
+	ChunkOverlap int `json:"chunk_overlap,omitempty"`
+}
+
+// createRequest is the body of the create and update document apis,
+// sent as the data field of the multipart form of file uploads.
+type createRequest struct {
+	Name              string            `json:"name,omitempty"`
+	Text              string            `json:"text,omitempty"`
+	IndexingTechnique IndexingTechnique `json:"indexing_technique,omitempty"`
+	DocForm           string            `json:"doc_form,omitempty"`
+	DocLanguage       string            `json:"doc_language,omitempty"`
+	ProcessRule       *ProcessRule      `json:"process_rule,omitempty"`
+}
+
+type documentResponse struct {
+	Document *struct {
+		ID             string `json:"id"`
+		Name           string `json:"name"`
+		IndexingStatus string `json:"indexing_status"`
+	} `json:"document"`
+	Batch string `json:"batch"`
+}
+
+type indexingStatusResponse struct {
+	Data []*indexingStatus `json:"data"`
+}
+
+type indexingStatus struct {
+	ID                string  `json:"id"`
+	IndexingStatus    string  `json:"indexing_status"`
+	Error             *string `json:"error"`
+	CompletedSegments int     `json:"completed_segments"`
+	TotalSegments     int     `json:"total_segments"`
+}
+
+type metadataField struct {
+	ID   string       `json:"id"`
+	Name string       `json:"name"`
+	Type MetadataType `json:"type"`
+}
+
+type metadataListResponse struct {
+	DocMetadata []*metadataField `json:"doc_metadata"`
+}
+
+type metadataValue struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Value any    `json:"value"`
+}
+
+type documentMetadata struct {
+	DocumentID   string           `json:"document_id"`
+	MetadataList []*metadataValue `json:"metadata_list"`
+}
+
+type errorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Status  int    `json:"status"`
+}
+
+// statusError is returned for responses of non 2xx status.
+type statusError struct {
+	statusCode int
+	code       string
+	message    string
+}
+
+func (e *statusError) Error() string {
+	if e.message != "" {
+		return fmt.Sprintf("request failed with status code %d: %s", e.statusCode, e.message)
+	}
+	return fmt.Sprintf("request failed with status code: %d", e.statusCode)
+}
+
+func (i *Indexer) datasetURL(path string) string {
+	return strings.TrimRight(i.config.Endpoint, "/") + "/datasets/" + url.PathEscape(i.config.DatasetID) + path
+}
+
+// doJSON sends body as json, and decodes the response to result if it's not nil.
+func (i *Indexer) doJSON(ctx context.Context, method, path string, body, result any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := sonic.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("error marshaling data: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, i.datasetURL(path), reader)
+	if err != nil {
+		return fmt.Errorf("create request failed: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return i.do(req, result)
+}
+
+// doMultipart uploads file with data as the json data field of the form, and decodes the response to result.
+func (i *Indexer) doMultipart(ctx context.Context, path string, data any, file *File, result any) error {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+
+	raw, err := sonic.MarshalString(data)
+	if err != nil {
+		return fmt.Errorf("error marshaling data: %w", err)
+	}
+	if err = w.WriteField("data", raw); err != nil {
+		return fmt.Errorf("write data field failed: %w", err)
+	}
+
+	part, err := w.CreateFormFile("file", file.Name)
+	if err != nil {
+		return fmt.Errorf("create file field failed: %w", err)
+	}
+	if _, err = io.Copy(part, file.Reader); err != nil {
+		return fmt.Errorf("read file %s failed: %w", file.Name, err)
+	}
+	if err = w.Close(); err != nil {
+		return fmt.Errorf("close multipart writer failed: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, i.datasetURL(path), &buf)
+	if err != nil {
+		return fmt.Errorf("create request failed: %w", err)
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	return i.do(req, result)
+}
+
+func (i *Indexer) do(req *http.Request, result any) error {
+	req.Header.Set("Authorization", "Bearer "+i.config.APIKey)
+
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("do request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response failed: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		errResp := &errorResponse{}
+		_ = sonic.Unmarshal(body, errResp)
+		return &statusError{statusCode: resp.StatusCode, code: errResp.Code, message: errResp.Message}
+	}
+
+	if result == nil {
+		return nil
+	}
+	if err = sonic.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("decode response failed: %w", err)
+	}
+	return nil
+}
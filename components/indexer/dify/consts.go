@@ -0,0 +1,83 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dify
+
+import "time"
+
+const (
+	defaultEndpoint = "https://api.dify.ai/v1"
+	typ             = "Dify"
+)
+
+func GetType() string {
+	return typ
+}
+
+const (
+	defaultIndexingTimeout = 5 * time.Minute
+	defaultPollInterval    = time.Second
+
+	// metaKeyFileName is the metadata key of the file name set by the file loader.
+	metaKeyFileName = "_file_name"
+	// metaKeySource is the metadata key of the source uri set by the file loader.
+	metaKeySource = "_source"
+)
+
+// IndexingTechnique is how Dify indexes the segments of documents.
+type IndexingTechnique string
+
+const (
+	IndexingTechniqueHighQuality IndexingTechnique = "high_quality" // embedding
+	IndexingTechniqueEconomy     IndexingTechnique = "economy"      // keyword index
+)
+
+// ProcessMode is how Dify cleans and segments documents.
+type ProcessMode string
+
+const (
+	ProcessModeAutomatic    ProcessMode = "automatic"
+	ProcessModeCustom       ProcessMode = "custom"
+	ProcessModeHierarchical ProcessMode = "hierarchical" // parent - child segments
+)
+
+// MetadataType is the type of a metadata field of a dataset.
+type MetadataType string
+
+const (
+	MetadataTypeString MetadataType = "string"
+	MetadataTypeNumber MetadataType = "number"
+	MetadataTypeTime   MetadataType = "time" // unix seconds
+)
+
+const (
+	indexingStatusCompleted = "completed"
+	indexingStatusError     = "error"
+	indexingStatusPaused    = "paused"
+)
+
+// callback extra keys and operations, same as the ones of github.com/cloudwego/eino-ext/components/indexer/mutation,
+// checked by TestMutationConsts.
+const (
+	extraKeyOperation = "operation"
+	extraKeyIDs       = "ids"
+	extraKeyDeleted   = "deleted"
+
+	operationDelete = "delete"
+)
+
+// operationUpdate is the operation of Update, which isn't one of mutation.
+const operationUpdate = "update"
@@ -0,0 +1,82 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/indexer/dify"
+)
+
+func main() {
+	ctx := context.Background()
+
+	idx, err := dify.NewIndexer(ctx, &dify.IndexerConfig{
+		APIKey:            os.Getenv("DIFY_DATASET_API_KEY"),
+		Endpoint:          os.Getenv("DIFY_ENDPOINT"),
+		DatasetID:         os.Getenv("DIFY_DATASET_ID"),
+		IndexingTechnique: dify.IndexingTechniqueHighQuality,
+		ProcessRule: &dify.ProcessRule{
+			Mode: dify.ProcessModeCustom,
+			Rules: &dify.Rules{
+				PreProcessingRules: []*dify.PreProcessingRule{
+					{ID: "remove_extra_spaces", Enabled: true},
+					{ID: "remove_urls_emails", Enabled: false},
+				},
+				Segmentation: &dify.Segmentation{Separator: "\n\n", MaxTokens: 500, ChunkOverlap: 50},
+			},
+		},
+		// documents with a local "_source" file are uploaded as files, others are created by content
+		DocumentToFile:  dify.SourceFile,
+		MetadataKeys:    []string{"category", "updated_at"},
+		IndexingTimeout: 10 * time.Minute,
+	})
+	if err != nil {
+		log.Fatalf("NewIndexer failed, err=%v", err)
+	}
+
+	docs := []*schema.Document{
+		{ID: "intro", Content: "eino is a llm application framework", MetaData: map[string]any{"category": "guide", "updated_at": time.Now()}},
+		{ID: "faq", Content: "how to install eino", MetaData: map[string]any{"category": "faq", "updated_at": time.Now()}},
+	}
+
+	// ids[i] is the id of the Dify document of docs[i]
+	ids, err := idx.Store(ctx, docs)
+	if err != nil {
+		log.Fatalf("Store failed, err=%v", err)
+	}
+	for n, id := range ids {
+		log.Printf("%s -> dify document %s", docs[n].ID, id)
+	}
+
+	// keep the dataset in sync with the source documents
+	err = idx.Update(ctx, ids[0], &schema.Document{ID: "intro", Content: "eino is a go llm application framework"})
+	if err != nil {
+		log.Fatalf("Update failed, err=%v", err)
+	}
+
+	deleted, err := idx.Delete(ctx, ids[1:])
+	if err != nil {
+		log.Fatalf("Delete failed, err=%v", err)
+	}
+	log.Printf("deleted: %d", deleted)
+}
@@ -0,0 +1,191 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dify
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type fakeDocument struct {
+	name     string
+	text     string
+	fileName string
+	file     string
+	request  map[string]any
+}
+
+// fakeDify is an in-memory Dify knowledge api of the endpoints the indexer uses.
+type fakeDify struct {
+	mu        sync.Mutex
+	docs      map[string]*fakeDocument
+	batches   map[string]string         // batch -> document id
+	polls     map[string]int            // batch -> polls before completed
+	fields    []map[string]any          // dataset metadata fields
+	metadata  map[string]map[string]any // document id -> metadata name -> value
+	requests  []string                  // method path
+	auth      string
+	pollsLeft int    // polls of each batch before completed
+	status    string // final indexing status
+	failPath  string
+	nextID    int
+}
+
+func newFakeDify(t *testing.T) (*fakeDify, string) {
+	f := &fakeDify{
+		docs:     map[string]*fakeDocument{},
+		batches:  map[string]string{},
+		polls:    map[string]int{},
+		metadata: map[string]map[string]any{},
+		status:   indexingStatusCompleted,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(srv.Close)
+	return f, srv.URL + "/v1"
+}
+
+func (f *fakeDify) serve(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+	f.auth = r.Header.Get("Authorization")
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/datasets/ds/")
+	if f.failPath != "" && strings.HasSuffix(path, f.failPath) {
+		reply(w, http.StatusBadRequest, map[string]any{"code": "invalid_param", "message": "mock failure", "status": 400})
+		return
+	}
+
+	parts := strings.Split(path, "/")
+	switch {
+	case path == "document/create-by-text" || strings.HasSuffix(path, "/update-by-text"):
+		var req map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		f.saveDocument(w, parts, &fakeDocument{name: str(req["name"]), text: str(req["text"]), request: req})
+	case path == "document/create-by-file" || strings.HasSuffix(path, "/update-by-file"):
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			reply(w, http.StatusBadRequest, map[string]any{"message": err.Error()})
+			return
+		}
+		var req map[string]any
+		_ = json.Unmarshal([]byte(r.FormValue("data")), &req)
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			reply(w, http.StatusBadRequest, map[string]any{"message": err.Error()})
+			return
+		}
+		b, _ := io.ReadAll(file)
+		f.saveDocument(w, parts, &fakeDocument{name: header.Filename, fileName: header.Filename, file: string(b), request: req})
+	case len(parts) == 3 && parts[2] == "indexing-status":
+		batch := parts[1]
+		id, ok := f.batches[batch]
+		if !ok {
+			reply(w, http.StatusNotFound, map[string]any{"code": "not_found", "message": "batch not found"})
+			return
+		}
+		status := "indexing"
+		if f.polls[batch] <= 0 {
+			status = f.status
+		}
+		f.polls[batch]--
+		s := map[string]any{"id": id, "indexing_status": status, "error": nil}
+		if status == indexingStatusError {
+			s["error"] = "embedding model unavailable"
+		}
+		reply(w, http.StatusOK, map[string]any{"data": []any{s}})
+	case path == "metadata" && r.Method == http.MethodGet:
+		reply(w, http.StatusOK, map[string]any{"doc_metadata": f.fields, "built_in_field_enabled": false})
+	case path == "metadata" && r.Method == http.MethodPost:
+		var req map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		req["id"] = fmt.Sprintf("field-%d", len(f.fields)+1)
+		f.fields = append(f.fields, req)
+		reply(w, http.StatusOK, req)
+	case path == "documents/metadata":
+		var req struct {
+			OperationData []struct {
+				DocumentID   string `json:"document_id"`
+				MetadataList []struct {
+					ID    string `json:"id"`
+					Name  string `json:"name"`
+					Value any    `json:"value"`
+				} `json:"metadata_list"`
+			} `json:"operation_data"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		for _, op := range req.OperationData {
+			m := map[string]any{}
+			for _, v := range op.MetadataList {
+				m[v.Name] = v.Value
+			}
+			f.metadata[op.DocumentID] = m
+		}
+		reply(w, http.StatusOK, map[string]any{"result": "success"})
+	case len(parts) == 2 && parts[0] == "documents" && r.Method == http.MethodDelete:
+		if _, ok := f.docs[parts[1]]; !ok {
+			reply(w, http.StatusNotFound, map[string]any{"code": "not_found", "message": "Document Not Exists."})
+			return
+		}
+		delete(f.docs, parts[1])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		reply(w, http.StatusNotFound, map[string]any{"code": "not_found", "message": "not found"})
+	}
+}
+
+// saveDocument creates a document, or updates the document of path documents/{id}/update-by-*.
+func (f *fakeDify) saveDocument(w http.ResponseWriter, parts []string, doc *fakeDocument) {
+	var id string
+	if parts[0] == "documents" {
+		id = parts[1]
+		if _, ok := f.docs[id]; !ok {
+			reply(w, http.StatusNotFound, map[string]any{"code": "not_found", "message": "Document not found."})
+			return
+		}
+	} else {
+		f.nextID++
+		id = fmt.Sprintf("dify-%d", f.nextID)
+	}
+	f.docs[id] = doc
+
+	batch := "batch-" + id + fmt.Sprint(len(f.requests))
+	f.batches[batch] = id
+	f.polls[batch] = f.pollsLeft
+
+	reply(w, http.StatusOK, map[string]any{
+		"document": map[string]any{"id": id, "name": doc.name, "indexing_status": "waiting"},
+		"batch":    batch,
+	})
+}
+
+func reply(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func str(v any) string {
+	s, _ := v.(string)
+	return s
+}
@@ -0,0 +1,388 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+)
+
+type IndexerConfig struct {
+	// APIKey of the Dify knowledge api.
+	// Required.
+	APIKey string
+	// Endpoint of the Dify api.
+	// Default "https://api.dify.ai/v1".
+	Endpoint string
+	// DatasetID of the dataset to create documents in.
+	// Required.
+	DatasetID string
+	// IndexingTechnique of the documents, required if the dataset has no document yet.
+	// Default "", the indexing technique of the dataset.
+	IndexingTechnique IndexingTechnique
+	// DocForm of the documents, "text_model", "hierarchical_model" or "qa_model".
+	// Default "", the document form of the dataset.
+	DocForm string
+	// DocLanguage of the documents, used by "qa_model", e.g. "English".
+	// Optional.
+	DocLanguage string
+	// ProcessRule cleans and segments the documents.
+	// Default ProcessModeAutomatic.
+	ProcessRule *ProcessRule
+	// DocumentName returns the name of the Dify document of doc, by which the document of a retrieved segment is known,
+	// see GetOrgDocName of github.com/cloudwego/eino-ext/components/retriever/dify.
+	// Default is the id of doc, or its "_file_name" metadata if the id is empty.
+	DocumentName func(ctx context.Context, doc *schema.Document) (string, error)
+	// DocumentToFile returns the file to upload for doc, or nil to create the document by its content.
+	// A file is closed after uploaded if it implements io.Closer. See SourceFile.
+	// Default nil, documents are created by content.
+	DocumentToFile func(ctx context.Context, doc *schema.Document) (*File, error)
+	// MetadataKeys are the keys of the metadata of documents saved as Dify document metadata,
+	// fields missing from the dataset are created with the type of the first value: string, number or time.
+	// Default nil, no metadata is saved.
+	MetadataKeys []string
+	// Async returns once the documents are created, without waiting for them to be indexed,
+	// so they may not be retrievable right after Store returns.
+	// Default false, waits for the documents to be indexed.
+	Async bool
+	// IndexingTimeout is the max time to wait for the documents to be indexed.
+	// Default 5 minutes.
+	IndexingTimeout time.Duration
+	// PollInterval is the interval of polling the indexing status.
+	// Default 1 second.
+	PollInterval time.Duration
+	// Timeout of each http request.
+	// Default 0, no timeout.
+	Timeout time.Duration
+}
+
+// File is a file uploaded as a Dify document.
+type File struct {
+	// Name of the file, its extension decides how Dify parses it, e.g. "intro.md".
+	Name   string
+	Reader io.Reader
+}
+
+// SourceFile is a DocumentToFile uploading the local file of the "_source" metadata set by the file loader,
+// documents without "_source" are created by content.
+func SourceFile(_ context.Context, doc *schema.Document) (*File, error) {
+	source, _ := doc.MetaData[metaKeySource].(string)
+	if source == "" {
+		return nil, nil
+	}
+
+	f, err := os.Open(source)
+	if err != nil {
+		return nil, err
+	}
+
+	return &File{Name: filepath.Base(source), Reader: f}, nil
+}
+
+type Indexer struct {
+	config *IndexerConfig
+	client *http.Client
+}
+
+func NewIndexer(_ context.Context, config *IndexerConfig) (*Indexer, error) {
+	if config.APIKey == "" {
+		return nil, fmt.Errorf("[NewIndexer] api key not provided")
+	}
+
+	if config.DatasetID == "" {
+		return nil, fmt.Errorf("[NewIndexer] dataset id not provided")
+	}
+
+	if config.Endpoint == "" {
+		config.Endpoint = defaultEndpoint
+	}
+
+	if config.ProcessRule == nil {
+		config.ProcessRule = &ProcessRule{Mode: ProcessModeAutomatic}
+	}
+
+	if config.DocumentName == nil {
+		config.DocumentName = defaultDocumentName
+	}
+
+	if config.IndexingTimeout == 0 {
+		config.IndexingTimeout = defaultIndexingTimeout
+	}
+
+	if config.PollInterval == 0 {
+		config.PollInterval = defaultPollInterval
+	}
+
+	return &Indexer{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+	}, nil
+}
+
+func defaultDocumentName(_ context.Context, doc *schema.Document) (string, error) {
+	if doc.ID != "" {
+		return doc.ID, nil
+	}
+	if name, ok := doc.MetaData[metaKeyFileName].(string); ok && name != "" {
+		return name, nil
+	}
+	return "", fmt.Errorf("document id not provided")
+}
+
+// Store creates a Dify document of each doc, and returns the ids of the Dify documents in the order of docs.
+// Unless Async is set, it returns once all documents are indexed, and fails if any of them fails or isn't indexed in IndexingTimeout.
+func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{Docs: docs})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	ids = make([]string, len(docs))
+	batches := make([]string, len(docs))
+	for idx, doc := range docs {
+		if ids[idx], batches[idx], err = i.createDocument(ctx, "", doc); err != nil {
+			return nil, fmt.Errorf("[Store] create document failed, index=%d, created=%v, %w", idx, ids[:idx], err)
+		}
+	}
+
+	if err = i.saveMetadata(ctx, ids, docs); err != nil {
+		return nil, fmt.Errorf("[Store] save metadata failed, created=%v, %w", ids, err)
+	}
+
+	if err = i.waitIndexing(ctx, batches); err != nil {
+		return nil, fmt.Errorf("[Store] created=%v, %w", ids, err)
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{IDs: ids})
+
+	return ids, nil
+}
+
+// Update replaces the content, name and metadata of the Dify document of id with doc, and re-indexes it.
+// Unless Async is set, it returns once the document is indexed.
+func (i *Indexer) Update(ctx context.Context, id string, doc *schema.Document, opts ...indexer.Option) (err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{
+		Docs:  []*schema.Document{doc},
+		Extra: map[string]any{extraKeyOperation: operationUpdate, extraKeyIDs: []string{id}},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	if id == "" {
+		return fmt.Errorf("[Update] document id not provided")
+	}
+
+	_, batch, err := i.createDocument(ctx, id, doc)
+	if err != nil {
+		return fmt.Errorf("[Update] update document failed, id=%s, %w", id, err)
+	}
+
+	if err = i.saveMetadata(ctx, []string{id}, []*schema.Document{doc}); err != nil {
+		return fmt.Errorf("[Update] save metadata failed, id=%s, %w", id, err)
+	}
+
+	if err = i.waitIndexing(ctx, []string{batch}); err != nil {
+		return fmt.Errorf("[Update] id=%s, %w", id, err)
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{IDs: []string{id}})
+
+	return nil
+}
+
+// Delete deletes the Dify documents of ids, ids which don't exist are ignored, and returns the number of deleted documents.
+// It has the signature of Delete of mutation.Deleter of github.com/cloudwego/eino-ext/components/indexer/mutation,
+// but Indexer doesn't implement mutation.Deleter, which requires DeleteByFilter too.
+func (i *Indexer) Delete(ctx context.Context, ids []string, opts ...indexer.Option) (deleted int64, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{
+		Extra: map[string]any{extraKeyOperation: operationDelete, extraKeyIDs: ids},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	for _, id := range ids {
+		err = i.doJSON(ctx, http.MethodDelete, "/documents/"+url.PathEscape(id), nil, nil)
+		var se *statusError
+		if errors.As(err, &se) && se.statusCode == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return deleted, fmt.Errorf("[Delete] delete document failed, id=%s, %w", id, err)
+		}
+		deleted++
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{Extra: map[string]any{extraKeyDeleted: deleted}})
+
+	return deleted, nil
+}
+
+// createDocument creates a document of doc, or updates the document of id if it's not empty,
+// by the file of DocumentToFile or by the content of doc, and returns the document id and the indexing batch.
+func (i *Indexer) createDocument(ctx context.Context, id string, doc *schema.Document) (docID, batch string, err error) {
+	req := &createRequest{ProcessRule: i.config.ProcessRule}
+	if id == "" {
+		req.IndexingTechnique = i.config.IndexingTechnique
+		req.DocForm = i.config.DocForm
+		req.DocLanguage = i.config.DocLanguage
+	}
+
+	var file *File
+	if i.config.DocumentToFile != nil {
+		if file, err = i.config.DocumentToFile(ctx, doc); err != nil {
+			return "", "", fmt.Errorf("DocumentToFile failed: %w", err)
+		}
+	}
+
+	resp := &documentResponse{}
+	if file != nil {
+		if closer, ok := file.Reader.(io.Closer); ok {
+			defer closer.Close()
+		}
+
+		path := "/document/create-by-file"
+		if id != "" {
+			path = "/documents/" + url.PathEscape(id) + "/update-by-file"
+		}
+		err = i.doMultipart(ctx, path, req, file, resp)
+	} else {
+		if req.Name, err = i.config.DocumentName(ctx, doc); err != nil {
+			return "", "", fmt.Errorf("DocumentName failed: %w", err)
+		}
+		req.Text = doc.Content
+
+		path := "/document/create-by-text"
+		if id != "" {
+			path = "/documents/" + url.PathEscape(id) + "/update-by-text"
+		}
+		err = i.doJSON(ctx, http.MethodPost, path, req, resp)
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	if resp.Document == nil || resp.Document.ID == "" {
+		return "", "", fmt.Errorf("document not found in response")
+	}
+
+	return resp.Document.ID, resp.Batch, nil
+}
+
+// waitIndexing polls the indexing status of batches until all documents are completed,
+// any fails, or IndexingTimeout passes. It returns at once if Async is set.
+func (i *Indexer) waitIndexing(ctx context.Context, batches []string) error {
+	if i.config.Async {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, i.config.IndexingTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(i.config.PollInterval)
+	defer ticker.Stop()
+
+	pending := make([]string, 0, len(batches))
+	for _, batch := range batches {
+		if batch != "" {
+			pending = append(pending, batch)
+		}
+	}
+
+	for {
+		remaining := pending[:0]
+		for _, batch := range pending {
+			done, err := i.batchIndexed(ctx, batch)
+			if err != nil {
+				if ctx.Err() != nil {
+					return fmt.Errorf("wait indexing timeout after %v, %w", i.config.IndexingTimeout, err)
+				}
+				return err
+			}
+			if !done {
+				remaining = append(remaining, batch)
+			}
+		}
+		pending = remaining
+
+		if len(pending) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("wait indexing timeout after %v, pending batches=%v, %w", i.config.IndexingTimeout, pending, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// batchIndexed reports whether the documents of batch are indexed, and fails if any fails to index.
+func (i *Indexer) batchIndexed(ctx context.Context, batch string) (bool, error) {
+	resp := &indexingStatusResponse{}
+	if err := i.doJSON(ctx, http.MethodGet, "/documents/"+url.PathEscape(batch)+"/indexing-status", nil, resp); err != nil {
+		return false, fmt.Errorf("get indexing status failed, batch=%s, %w", batch, err)
+	}
+
+	done := true
+	for _, s := range resp.Data {
+		switch s.IndexingStatus {
+		case indexingStatusCompleted:
+		case indexingStatusError, indexingStatusPaused:
+			msg := s.IndexingStatus
+			if s.Error != nil && *s.Error != "" {
+				msg += ": " + strings.TrimSpace(*s.Error)
+			}
+			return false, fmt.Errorf("indexing failed, id=%s, %s", s.ID, msg)
+		default:
+			done = false
+		}
+	}
+
+	return done, nil
+}
+
+func (i *Indexer) GetType() string {
+	return typ
+}
+
+func (i *Indexer) IsCallbacksEnabled() bool {
+	return true
+}
@@ -0,0 +1,315 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dify
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/indexer/mutation"
+)
+
+func newTestIndexer(t *testing.T, endpoint string, modify func(conf *IndexerConfig)) *Indexer {
+	conf := &IndexerConfig{
+		APIKey:       "key",
+		Endpoint:     endpoint,
+		DatasetID:    "ds",
+		PollInterval: time.Millisecond,
+	}
+	if modify != nil {
+		modify(conf)
+	}
+	i, err := NewIndexer(context.Background(), conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return i
+}
+
+func TestNewIndexer(t *testing.T) {
+	convey.Convey("test NewIndexer", t, func() {
+		ctx := context.Background()
+
+		_, err := NewIndexer(ctx, &IndexerConfig{})
+		convey.So(err, convey.ShouldNotBeNil)
+
+		_, err = NewIndexer(ctx, &IndexerConfig{APIKey: "key"})
+		convey.So(err, convey.ShouldNotBeNil)
+
+		i, err := NewIndexer(ctx, &IndexerConfig{APIKey: "key", DatasetID: "ds", Timeout: time.Second})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(i.config.Endpoint, convey.ShouldEqual, defaultEndpoint)
+		convey.So(i.config.ProcessRule, convey.ShouldResemble, &ProcessRule{Mode: ProcessModeAutomatic})
+		convey.So(i.config.IndexingTimeout, convey.ShouldEqual, defaultIndexingTimeout)
+		convey.So(i.config.PollInterval, convey.ShouldEqual, defaultPollInterval)
+		convey.So(i.client.Timeout, convey.ShouldEqual, time.Second)
+		convey.So(i.GetType(), convey.ShouldEqual, GetType())
+		convey.So(i.IsCallbacksEnabled(), convey.ShouldBeTrue)
+
+		name, err := i.config.DocumentName(ctx, &schema.Document{MetaData: map[string]any{metaKeyFileName: "a.md"}})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(name, convey.ShouldEqual, "a.md")
+		_, err = i.config.DocumentName(ctx, &schema.Document{})
+		convey.So(err, convey.ShouldNotBeNil)
+	})
+}
+
+func TestStore(t *testing.T) {
+	convey.Convey("test Store", t, func() {
+		ctx := context.Background()
+		fake, endpoint := newFakeDify(t)
+
+		convey.Convey("test create by text and wait", func() {
+			fake.pollsLeft = 2
+			i := newTestIndexer(t, endpoint, func(conf *IndexerConfig) {
+				conf.IndexingTechnique = IndexingTechniqueHighQuality
+				conf.DocForm = "text_model"
+				conf.ProcessRule = &ProcessRule{Mode: ProcessModeCustom, Rules: &Rules{
+					PreProcessingRules: []*PreProcessingRule{{ID: "remove_extra_spaces", Enabled: true}},
+					Segmentation:       &Segmentation{Separator: "\n", MaxTokens: 500, ChunkOverlap: 50},
+				}}
+			})
+
+			var started, ended bool
+			handler := callbacks.NewHandlerBuilder().
+				OnStartFn(func(ctx context.Context, info *callbacks.RunInfo, input callbacks.CallbackInput) context.Context {
+					started = true
+					return ctx
+				}).
+				OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+					ended = true
+					convey.So(indexer.ConvCallbackOutput(output).IDs, convey.ShouldResemble, []string{"dify-1", "dify-2"})
+					return ctx
+				}).Build()
+			ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, handler)
+
+			ids, err := i.Store(ctx, []*schema.Document{
+				{ID: "intro", Content: "eino is a llm application framework"},
+				{ID: "faq", Content: "how to install eino"},
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids, convey.ShouldResemble, []string{"dify-1", "dify-2"})
+			convey.So(started && ended, convey.ShouldBeTrue)
+			convey.So(fake.auth, convey.ShouldEqual, "Bearer key")
+			convey.So(fake.docs["dify-1"].name, convey.ShouldEqual, "intro")
+			convey.So(fake.docs["dify-1"].text, convey.ShouldEqual, "eino is a llm application framework")
+			convey.So(fake.docs["dify-1"].request, convey.ShouldResemble, map[string]any{
+				"name":               "intro",
+				"text":               "eino is a llm application framework",
+				"indexing_technique": "high_quality",
+				"doc_form":           "text_model",
+				"process_rule": map[string]any{"mode": "custom", "rules": map[string]any{
+					"pre_processing_rules": []any{map[string]any{"id": "remove_extra_spaces", "enabled": true}},
+					"segmentation":         map[string]any{"separator": "\n", "max_tokens": 500.0, "chunk_overlap": 50.0},
+				}},
+			})
+
+			polls := 0
+			for _, req := range fake.requests {
+				if strings.HasSuffix(req, "/indexing-status") {
+					polls++
+				}
+			}
+			convey.So(polls, convey.ShouldEqual, 6)
+		})
+
+		convey.Convey("test async", func() {
+			i := newTestIndexer(t, endpoint, func(conf *IndexerConfig) { conf.Async = true })
+			ids, err := i.Store(ctx, []*schema.Document{{ID: "intro", Content: "eino"}})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids, convey.ShouldResemble, []string{"dify-1"})
+			convey.So(fake.requests, convey.ShouldResemble, []string{"POST /v1/datasets/ds/document/create-by-text"})
+		})
+
+		convey.Convey("test create by file", func() {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "intro.md")
+			convey.So(os.WriteFile(path, []byte("# eino"), 0o644), convey.ShouldBeNil)
+
+			i := newTestIndexer(t, endpoint, func(conf *IndexerConfig) { conf.DocumentToFile = SourceFile })
+			ids, err := i.Store(ctx, []*schema.Document{
+				{ID: "intro", MetaData: map[string]any{metaKeySource: path}},
+				{ID: "faq", Content: "how to install eino"},
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids, convey.ShouldHaveLength, 2)
+			convey.So(fake.docs[ids[0]].fileName, convey.ShouldEqual, "intro.md")
+			convey.So(fake.docs[ids[0]].file, convey.ShouldEqual, "# eino")
+			convey.So(fake.docs[ids[0]].request["process_rule"], convey.ShouldResemble, map[string]any{"mode": "automatic"})
+			convey.So(fake.docs[ids[1]].text, convey.ShouldEqual, "how to install eino")
+
+			_, err = i.Store(ctx, []*schema.Document{{ID: "x", MetaData: map[string]any{metaKeySource: filepath.Join(dir, "missing.md")}}})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test metadata", func() {
+			fake.fields = []map[string]any{{"id": "field-source", "name": "source", "type": "string"}}
+			created := time.Unix(1700000000, 0)
+			i := newTestIndexer(t, endpoint, func(conf *IndexerConfig) {
+				conf.MetadataKeys = []string{"source", "page", "created_at", "missing"}
+			})
+			ids, err := i.Store(ctx, []*schema.Document{
+				{ID: "intro", Content: "eino", MetaData: map[string]any{"source": "intro.md", "page": 1, "created_at": created}},
+				{ID: "faq", Content: "install", MetaData: map[string]any{"source": "faq.md"}},
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(fake.fields, convey.ShouldHaveLength, 4)
+			convey.So(fake.fields[1], convey.ShouldResemble, map[string]any{"id": "field-2", "name": "page", "type": "number"})
+			convey.So(fake.fields[2], convey.ShouldResemble, map[string]any{"id": "field-3", "name": "created_at", "type": "time"})
+			convey.So(fake.fields[3], convey.ShouldResemble, map[string]any{"id": "field-4", "name": "missing", "type": "string"})
+			convey.So(fake.metadata[ids[0]], convey.ShouldResemble, map[string]any{"source": "intro.md", "page": 1.0, "created_at": 1700000000.0})
+			convey.So(fake.metadata[ids[1]], convey.ShouldResemble, map[string]any{"source": "faq.md"})
+		})
+
+		convey.Convey("test errors", func() {
+			i := newTestIndexer(t, endpoint, nil)
+			docs := []*schema.Document{{ID: "intro", Content: "eino"}}
+
+			fake.status = indexingStatusError
+			_, err := i.Store(ctx, docs)
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "indexing failed")
+			convey.So(err.Error(), convey.ShouldContainSubstring, "embedding model unavailable")
+			fake.status = indexingStatusCompleted
+
+			fake.failPath = "create-by-text"
+			_, err = i.Store(ctx, docs)
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "mock failure")
+
+			fake.failPath = "indexing-status"
+			_, err = i.Store(ctx, docs)
+			convey.So(err, convey.ShouldNotBeNil)
+			fake.failPath = ""
+
+			_, err = i.Store(ctx, []*schema.Document{{Content: "no name"}})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			i.config.DocumentToFile = func(ctx context.Context, doc *schema.Document) (*File, error) {
+				return nil, fmt.Errorf("mock err")
+			}
+			_, err = i.Store(ctx, docs)
+			convey.So(err, convey.ShouldNotBeNil)
+			i.config.DocumentToFile = nil
+
+			i.config.MetadataKeys = []string{"source"}
+			fake.failPath = "metadata"
+			_, err = i.Store(ctx, []*schema.Document{{ID: "intro", Content: "eino", MetaData: map[string]any{"source": "a"}}})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test timeout", func() {
+			fake.pollsLeft = 1 << 30
+			i := newTestIndexer(t, endpoint, func(conf *IndexerConfig) { conf.IndexingTimeout = 20 * time.Millisecond })
+			_, err := i.Store(ctx, []*schema.Document{{ID: "intro", Content: "eino"}})
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "wait indexing timeout")
+			convey.So(err.Error(), convey.ShouldContainSubstring, "created=[dify-1]")
+		})
+	})
+}
+
+func TestUpdateAndDelete(t *testing.T) {
+	convey.Convey("test Update and Delete", t, func() {
+		ctx := context.Background()
+		fake, endpoint := newFakeDify(t)
+		i := newTestIndexer(t, endpoint, func(conf *IndexerConfig) { conf.IndexingTechnique = IndexingTechniqueEconomy })
+
+		ids, err := i.Store(ctx, []*schema.Document{{ID: "intro", Content: "eino"}, {ID: "faq", Content: "install"}})
+		convey.So(err, convey.ShouldBeNil)
+
+		convey.Convey("test update by text", func() {
+			err = i.Update(ctx, ids[0], &schema.Document{ID: "intro", Content: "eino is a framework"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(fake.docs[ids[0]].text, convey.ShouldEqual, "eino is a framework")
+			convey.So(fake.docs[ids[0]].request["indexing_technique"], convey.ShouldBeNil)
+			convey.So(fake.requests, convey.ShouldContain, "POST /v1/datasets/ds/documents/dify-1/update-by-text")
+		})
+
+		convey.Convey("test update by file", func() {
+			i.config.DocumentToFile = func(ctx context.Context, doc *schema.Document) (*File, error) {
+				return &File{Name: "intro.txt", Reader: strings.NewReader(doc.Content)}, nil
+			}
+			err = i.Update(ctx, ids[0], &schema.Document{ID: "intro", Content: "new"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(fake.docs[ids[0]].file, convey.ShouldEqual, "new")
+			convey.So(fake.requests, convey.ShouldContain, "POST /v1/datasets/ds/documents/dify-1/update-by-file")
+		})
+
+		convey.Convey("test update errors", func() {
+			convey.So(i.Update(ctx, "", &schema.Document{ID: "intro"}), convey.ShouldNotBeNil)
+			convey.So(i.Update(ctx, "unknown", &schema.Document{ID: "intro"}), convey.ShouldNotBeNil)
+
+			fake.status = indexingStatusPaused
+			convey.So(i.Update(ctx, ids[0], &schema.Document{ID: "intro"}), convey.ShouldNotBeNil)
+			fake.status = indexingStatusCompleted
+
+			i.config.MetadataKeys = []string{"source"}
+			fake.failPath = "metadata"
+			convey.So(i.Update(ctx, ids[0], &schema.Document{ID: "intro", MetaData: map[string]any{"source": "a"}}), convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test delete", func() {
+			var deletedInCallback any
+			handler := callbacks.NewHandlerBuilder().
+				OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+					deletedInCallback = indexer.ConvCallbackOutput(output).Extra[extraKeyDeleted]
+					return ctx
+				}).Build()
+			ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, handler)
+
+			deleted, err := i.Delete(ctx, []string{ids[0], "unknown", ids[1]})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(deleted, convey.ShouldEqual, 2)
+			convey.So(deletedInCallback, convey.ShouldEqual, int64(2))
+			convey.So(fake.docs, convey.ShouldBeEmpty)
+
+			fake.failPath = "/unknown"
+			_, err = i.Delete(ctx, []string{"unknown"})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+// deleter is the Delete of mutation.Deleter, which Indexer has without DeleteByFilter.
+type deleter interface {
+	Delete(ctx context.Context, ids []string, opts ...indexer.Option) (int64, error)
+}
+
+var (
+	_ deleter = mutation.Deleter(nil)
+	_ deleter = (*Indexer)(nil)
+)
+
+func TestMutationConsts(t *testing.T) {
+	convey.Convey("test callback extra keys and operations same as mutation", t, func() {
+		convey.So(extraKeyOperation, convey.ShouldEqual, mutation.ExtraKeyOperation)
+		convey.So(extraKeyIDs, convey.ShouldEqual, mutation.ExtraKeyIDs)
+		convey.So(extraKeyDeleted, convey.ShouldEqual, mutation.ExtraKeyDeleted)
+		convey.So(operationDelete, convey.ShouldEqual, mutation.OperationDelete)
+	})
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// saveMetadata sets the MetadataKeys metadata of docs to the Dify documents of ids,
+// creating the metadata fields missing from the dataset.
+func (i *Indexer) saveMetadata(ctx context.Context, ids []string, docs []*schema.Document) error {
+	if len(i.config.MetadataKeys) == 0 || len(docs) == 0 {
+		return nil
+	}
+
+	fields, err := i.metadataFields(ctx, docs)
+	if err != nil {
+		return err
+	}
+
+	data := make([]*documentMetadata, 0, len(docs))
+	for idx, doc := range docs {
+		dm := &documentMetadata{DocumentID: ids[idx], MetadataList: []*metadataValue{}}
+		for _, key := range i.config.MetadataKeys {
+			v, ok := doc.MetaData[key]
+			if !ok || v == nil {
+				continue
+			}
+			field := fields[key]
+			value, err := metadataValueOf(field.Type, v)
+			if err != nil {
+				return fmt.Errorf("invalid metadata value, key=%s, %w", key, err)
+			}
+			dm.MetadataList = append(dm.MetadataList, &metadataValue{ID: field.ID, Name: field.Name, Value: value})
+		}
+		data = append(data, dm)
+	}
+
+	body := map[string]any{"operation_data": data}
+	if err = i.doJSON(ctx, http.MethodPost, "/documents/metadata", body, nil); err != nil {
+		return fmt.Errorf("update document metadata failed, %w", err)
+	}
+
+	return nil
+}
+
+// metadataFields returns the dataset metadata field of each of MetadataKeys,
+// fields missing from the dataset are created with the type of their first value in docs.
+func (i *Indexer) metadataFields(ctx context.Context, docs []*schema.Document) (map[string]*metadataField, error) {
+	list := &metadataListResponse{}
+	if err := i.doJSON(ctx, http.MethodGet, "/metadata", nil, list); err != nil {
+		return nil, fmt.Errorf("list dataset metadata failed, %w", err)
+	}
+
+	existing := make(map[string]*metadataField, len(list.DocMetadata))
+	for _, f := range list.DocMetadata {
+		existing[f.Name] = f
+	}
+
+	fields := make(map[string]*metadataField, len(i.config.MetadataKeys))
+	for _, key := range i.config.MetadataKeys {
+		if f, ok := existing[key]; ok {
+			fields[key] = f
+			continue
+		}
+
+		typ := MetadataTypeString
+		for _, doc := range docs {
+			if v, ok := doc.MetaData[key]; ok && v != nil {
+				typ = metadataTypeOf(v)
+				break
+			}
+		}
+
+		f := &metadataField{}
+		if err := i.doJSON(ctx, http.MethodPost, "/metadata", &metadataField{Name: key, Type: typ}, f); err != nil {
+			return nil, fmt.Errorf("create dataset metadata failed, name=%s, %w", key, err)
+		}
+		fields[key] = f
+	}
+
+	return fields, nil
+}
+
+func metadataTypeOf(v any) MetadataType {
+	switch v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return MetadataTypeNumber
+	case time.Time, *time.Time:
+		return MetadataTypeTime
+	default:
+		return MetadataTypeString
+	}
+}
+
+// metadataValueOf converts v to the value of a metadata field of typ, times are unix seconds.
+func metadataValueOf(typ MetadataType, v any) (any, error) {
+	if t, ok := v.(*time.Time); ok {
+		v = *t
+	}
+
+	switch typ {
+	case MetadataTypeNumber:
+		if metadataTypeOf(v) != MetadataTypeNumber {
+			return nil, fmt.Errorf("number expected, got %T", v)
+		}
+		return v, nil
+	case MetadataTypeTime:
+		switch t := v.(type) {
+		case time.Time:
+			return t.Unix(), nil
+		case int, int64:
+			return t, nil
+		default:
+			return nil, fmt.Errorf("time expected, got %T", v)
+		}
+	default:
+		if t, ok := v.(time.Time); ok {
+			return t.Format(time.RFC3339), nil
+		}
+		return fmt.Sprint(v), nil
+	}
+}
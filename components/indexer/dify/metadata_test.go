@@ -0,0 +1,59 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestMetadataValue(t *testing.T) {
+	convey.Convey("test metadata types and values", t, func() {
+		now := time.Unix(1700000000, 0).UTC()
+
+		convey.So(metadataTypeOf(1), convey.ShouldEqual, MetadataTypeNumber)
+		convey.So(metadataTypeOf(1.5), convey.ShouldEqual, MetadataTypeNumber)
+		convey.So(metadataTypeOf(now), convey.ShouldEqual, MetadataTypeTime)
+		convey.So(metadataTypeOf(&now), convey.ShouldEqual, MetadataTypeTime)
+		convey.So(metadataTypeOf("a"), convey.ShouldEqual, MetadataTypeString)
+		convey.So(metadataTypeOf(true), convey.ShouldEqual, MetadataTypeString)
+
+		for _, c := range []struct {
+			typ      MetadataType
+			value    any
+			expected any
+		}{
+			{MetadataTypeNumber, 2, 2},
+			{MetadataTypeTime, now, int64(1700000000)},
+			{MetadataTypeTime, &now, int64(1700000000)},
+			{MetadataTypeTime, int64(1), int64(1)},
+			{MetadataTypeString, true, "true"},
+			{MetadataTypeString, now, "2023-11-14T22:13:20Z"},
+		} {
+			v, err := metadataValueOf(c.typ, c.value)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(v, convey.ShouldEqual, c.expected)
+		}
+
+		_, err := metadataValueOf(MetadataTypeNumber, "a")
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = metadataValueOf(MetadataTypeTime, "a")
+		convey.So(err, convey.ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,250 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package es8
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/esutil"
+)
+
+const (
+	// ExtraKeyBulkStats is the key of *BulkStats in indexer.CallbackOutput.Extra.
+	ExtraKeyBulkStats = "bulk_stats"
+	// ExtraKeyBulkFailures is the key of []*BulkFailure in indexer.CallbackOutput.Extra, set on partial failure.
+	ExtraKeyBulkFailures = "bulk_failures"
+)
+
+// BulkStats summarizes the bulk requests sent by one Store call.
+type BulkStats struct {
+	// Indexed is the number of documents stored.
+	Indexed int `json:"indexed"`
+	// Failed is the number of documents rejected after all retries.
+	Failed int `json:"failed"`
+	// Retried is the number of documents resubmitted after 429 Too Many Requests.
+	Retried int `json:"retried"`
+	// Bytes is the size of the documents sent, including retries.
+	Bytes int64 `json:"bytes"`
+}
+
+// BulkFailure describes a document rejected by elasticsearch.
+type BulkFailure struct {
+	// Index is the position of the document in the docs passed to Store.
+	Index int
+	// ID is the document id, empty if it was left to elasticsearch to generate.
+	ID string
+	// Status is the http status of the bulk item, 0 if the whole bulk request failed.
+	Status int
+	// Type and Reason are the es error, e.g. "mapper_parsing_exception".
+	Type   string
+	Reason string
+	// Err is the transport or encoding error if the bulk request failed.
+	Err error
+}
+
+func (f *BulkFailure) String() string {
+	if f.Err != nil {
+		return fmt.Sprintf("id=%s: %v", f.ID, f.Err)
+	}
+	return fmt.Sprintf("id=%s: status=%d, type=%s, reason=%s", f.ID, f.Status, f.Type, f.Reason)
+}
+
+// BulkError is returned by Store when some documents were not stored.
+// Store returns the IDs of the documents stored successfully together with it,
+// use errors.As to get the failures.
+type BulkError struct {
+	Failures []*BulkFailure
+	Stats    *BulkStats
+}
+
+func (e *BulkError) Error() string {
+	const maxListed = 3
+	msgs := make([]string, 0, maxListed)
+	for _, f := range e.Failures {
+		if len(msgs) == maxListed {
+			msgs = append(msgs, "...")
+			break
+		}
+		msgs = append(msgs, f.String())
+	}
+	return fmt.Sprintf("[Indexer.Store] %d documents failed: %s", len(e.Failures), strings.Join(msgs, "; "))
+}
+
+// FailedIDs returns the ids of the failed documents.
+func (e *BulkError) FailedIDs() []string {
+	return iter(e.Failures, func(f *BulkFailure) string { return f.ID })
+}
+
+func defaultRetryBackoff(attempt int) time.Duration {
+	d := 100 * time.Millisecond << (attempt - 1)
+	if d <= 0 || d > 5*time.Second {
+		return 5 * time.Second
+	}
+	return d
+}
+
+type bulkItem struct {
+	pos  int
+	id   string
+	body []byte
+}
+
+// bulkRecorder collects the results of the bulk items, it's called from the workers of esutil.BulkIndexer.
+type bulkRecorder struct {
+	mu sync.Mutex
+
+	ids      []string
+	pending  map[int]bool
+	failures map[int]*BulkFailure
+	stats    BulkStats
+	// flushErr is the last error of a failed bulk request, whose items are not reported individually.
+	flushErr error
+
+	// canRetry is set while retries are left, 429 failures are then queued in retries.
+	canRetry bool
+	retries  []bulkItem
+}
+
+func newBulkRecorder(ids []string) *bulkRecorder {
+	return &bulkRecorder{
+		ids:      ids,
+		pending:  make(map[int]bool),
+		failures: make(map[int]*BulkFailure),
+	}
+}
+
+func (r *bulkRecorder) add(ctx context.Context, bi esutil.BulkIndexer, index string, item bulkItem) error {
+	r.mu.Lock()
+	r.stats.Bytes += int64(len(item.body))
+	r.pending[item.pos] = true
+	r.mu.Unlock()
+
+	return bi.Add(ctx, esutil.BulkIndexerItem{
+		Index:      index,
+		Action:     "index",
+		DocumentID: item.id,
+		Body:       bytes.NewReader(item.body),
+		OnSuccess: func(_ context.Context, _ esutil.BulkIndexerItem, res esutil.BulkIndexerResponseItem) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.stats.Indexed++
+			delete(r.pending, item.pos)
+			delete(r.failures, item.pos)
+			if item.id == "" {
+				r.ids[item.pos] = res.DocumentID
+			}
+		},
+		OnFailure: func(_ context.Context, _ esutil.BulkIndexerItem, res esutil.BulkIndexerResponseItem, err error) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			delete(r.pending, item.pos)
+			if err == nil && res.Status == http.StatusTooManyRequests && r.canRetry {
+				r.retries = append(r.retries, item)
+				return
+			}
+			r.failures[item.pos] = &BulkFailure{
+				Index:  item.pos,
+				ID:     item.id,
+				Status: res.Status,
+				Type:   res.Error.Type,
+				Reason: res.Error.Reason,
+				Err:    err,
+			}
+		},
+	})
+}
+
+func (r *bulkRecorder) onError(_ context.Context, err error) {
+	r.mu.Lock()
+	r.flushErr = err
+	r.mu.Unlock()
+}
+
+// takeRetries returns the items queued for retry and resets the queue.
+func (r *bulkRecorder) takeRetries() []bulkItem {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	items := r.retries
+	r.retries = nil
+	r.stats.Retried += len(items)
+	return items
+}
+
+// fail records items which won't be sent, e.g. retries abandoned when ctx is done.
+func (r *bulkRecorder) fail(items []bulkItem, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, item := range items {
+		r.failures[item.pos] = &BulkFailure{Index: item.pos, ID: item.id, Err: err}
+	}
+}
+
+func (r *bulkRecorder) setCanRetry(canRetry bool) {
+	r.mu.Lock()
+	r.canRetry = canRetry
+	r.mu.Unlock()
+}
+
+// result returns the ids of the stored documents and the error describing the failed ones.
+func (r *bulkRecorder) result() ([]string, *BulkStats, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// items of a failed bulk request get neither OnSuccess nor OnFailure
+	for pos := range r.pending {
+		err := r.flushErr
+		if err == nil {
+			err = fmt.Errorf("no bulk response for document")
+		}
+		r.failures[pos] = &BulkFailure{Index: pos, ID: r.ids[pos], Err: err}
+	}
+	r.pending = make(map[int]bool)
+
+	stats := r.stats
+	stats.Failed = len(r.failures)
+	if len(r.failures) == 0 {
+		return r.ids, &stats, nil
+	}
+
+	ids := make([]string, 0, len(r.ids)-len(r.failures))
+	failures := make([]*BulkFailure, 0, len(r.failures))
+	for pos, id := range r.ids {
+		if f, failed := r.failures[pos]; failed {
+			failures = append(failures, f)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids, &stats, &BulkError{Failures: failures, Stats: &stats}
+}
+
+// bulkExtra returns the callback output extra of the stats, and of the failures of err if it's a *BulkError.
+func bulkExtra(stats *BulkStats, err error) map[string]any {
+	extra := map[string]any{ExtraKeyBulkStats: stats}
+	var bulkErr *BulkError
+	if errors.As(err, &bulkErr) {
+		extra[ExtraKeyBulkFailures] = bulkErr.Failures
+	}
+	return extra
+}
@@ -0,0 +1,245 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package es8
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+// bulkServer emulates the _bulk endpoint, respond decides the status of each document by id and attempt.
+type bulkServer struct {
+	mu       sync.Mutex
+	attempts map[string]int
+	respond  func(id string, attempt int) (status int, errType string)
+	genID    int
+}
+
+func (b *bulkServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Elastic-Product", "Elasticsearch")
+	w.Header().Set("Content-Type", "application/json")
+	if !strings.HasSuffix(r.URL.Path, "/_bulk") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var items []map[string]any
+	hasErrors := false
+	sc := bufio.NewScanner(r.Body)
+	sc.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for sc.Scan() {
+		var action map[string]map[string]any
+		if err := json.Unmarshal(sc.Bytes(), &action); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		sc.Scan() // document line
+
+		id, _ := action["index"]["_id"].(string)
+		if id == "" {
+			b.genID++
+			id = fmt.Sprintf("generated-%d", b.genID)
+		}
+		b.attempts[id]++
+		status, errType := b.respond(id, b.attempts[id])
+
+		item := map[string]any{"_index": action["index"]["_index"], "_id": id, "status": status}
+		if status > 299 {
+			hasErrors = true
+			item["error"] = map[string]any{"type": errType, "reason": "rejected " + id}
+		} else {
+			item["result"] = "created"
+		}
+		items = append(items, map[string]any{"index": item})
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]any{"took": 1, "errors": hasErrors, "items": items})
+}
+
+func newBulkTestIndexer(t *testing.T, srv http.Handler, maxRetries int) *Indexer {
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+
+	client, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{ts.URL}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	i, err := NewIndexer(context.Background(), &IndexerConfig{
+		Client: client,
+		Index:  "eino_test",
+		DocumentToFields: func(ctx context.Context, doc *schema.Document) (map[string]FieldValue, error) {
+			return map[string]FieldValue{"content": {Value: doc.Content}}, nil
+		},
+		MaxRetries:   maxRetries,
+		RetryBackoff: func(attempt int) time.Duration { return time.Millisecond },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return i
+}
+
+func TestStoreBulkResults(t *testing.T) {
+	convey.Convey("test Store bulk results", t, func() {
+		ctx := context.Background()
+		docs := []*schema.Document{
+			{ID: "1", Content: "first"},
+			{ID: "bad", Content: "second"},
+			{ID: "busy", Content: "third"},
+			{Content: "no id"},
+		}
+
+		var output *indexer.CallbackOutput
+		handler := callbacks.NewHandlerBuilder().
+			OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, out callbacks.CallbackOutput) context.Context {
+				output = indexer.ConvCallbackOutput(out)
+				return ctx
+			}).Build()
+		ctx = callbacks.InitCallbacks(ctx, nil, handler)
+
+		convey.Convey("test partial failure", func() {
+			srv := &bulkServer{attempts: map[string]int{}, respond: func(id string, attempt int) (int, string) {
+				if id == "bad" {
+					return http.StatusBadRequest, "mapper_parsing_exception"
+				}
+				return http.StatusCreated, ""
+			}}
+			i := newBulkTestIndexer(t, srv, 0)
+
+			ids, err := i.Store(ctx, docs)
+			convey.So(ids, convey.ShouldResemble, []string{"1", "busy", "generated-1"})
+
+			var bulkErr *BulkError
+			convey.So(errors.As(err, &bulkErr), convey.ShouldBeTrue)
+			convey.So(bulkErr.FailedIDs(), convey.ShouldResemble, []string{"bad"})
+			convey.So(bulkErr.Failures[0].Index, convey.ShouldEqual, 1)
+			convey.So(bulkErr.Failures[0].Status, convey.ShouldEqual, http.StatusBadRequest)
+			convey.So(bulkErr.Failures[0].Type, convey.ShouldEqual, "mapper_parsing_exception")
+			convey.So(bulkErr.Failures[0].Reason, convey.ShouldEqual, "rejected bad")
+			convey.So(bulkErr.Stats.Indexed, convey.ShouldEqual, 3)
+			convey.So(bulkErr.Stats.Failed, convey.ShouldEqual, 1)
+
+			// the stored ids, stats and failures reach the callbacks too
+			convey.So(output, convey.ShouldNotBeNil)
+			convey.So(output.IDs, convey.ShouldResemble, ids)
+			convey.So(output.Extra[ExtraKeyBulkStats], convey.ShouldEqual, bulkErr.Stats)
+			convey.So(output.Extra[ExtraKeyBulkFailures], convey.ShouldResemble, bulkErr.Failures)
+		})
+
+		convey.Convey("test cancelled while backing off", func() {
+			srv := &bulkServer{attempts: map[string]int{}, respond: func(id string, attempt int) (int, string) {
+				if id == "busy" {
+					return http.StatusTooManyRequests, "es_rejected_execution_exception"
+				}
+				return http.StatusCreated, ""
+			}}
+			i := newBulkTestIndexer(t, srv, 2)
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			i.config.RetryBackoff = func(attempt int) time.Duration {
+				cancel()
+				return time.Hour
+			}
+
+			ids, err := i.Store(ctx, docs)
+			convey.So(errors.Is(err, context.Canceled), convey.ShouldBeTrue)
+			convey.So(ids, convey.ShouldResemble, []string{"1", "bad", "generated-1"})
+			convey.So(srv.attempts["busy"], convey.ShouldEqual, 1)
+
+			convey.So(output, convey.ShouldNotBeNil)
+			failures := output.Extra[ExtraKeyBulkFailures].([]*BulkFailure)
+			convey.So(len(failures), convey.ShouldEqual, 1)
+			convey.So(failures[0].ID, convey.ShouldEqual, "busy")
+			convey.So(errors.Is(failures[0].Err, context.Canceled), convey.ShouldBeTrue)
+		})
+
+		convey.Convey("test retry 429", func() {
+			srv := &bulkServer{attempts: map[string]int{}, respond: func(id string, attempt int) (int, string) {
+				if id == "busy" && attempt < 3 {
+					return http.StatusTooManyRequests, "es_rejected_execution_exception"
+				}
+				return http.StatusCreated, ""
+			}}
+			i := newBulkTestIndexer(t, srv, 2)
+
+			ids, err := i.Store(ctx, docs)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids, convey.ShouldResemble, []string{"1", "bad", "busy", "generated-1"})
+			convey.So(srv.attempts["busy"], convey.ShouldEqual, 3)
+
+			convey.So(output, convey.ShouldNotBeNil)
+			stats := output.Extra[ExtraKeyBulkStats].(*BulkStats)
+			convey.So(stats.Indexed, convey.ShouldEqual, 4)
+			convey.So(stats.Failed, convey.ShouldEqual, 0)
+			convey.So(stats.Retried, convey.ShouldEqual, 2)
+			convey.So(stats.Bytes, convey.ShouldBeGreaterThan, 0)
+		})
+
+		convey.Convey("test retries exhausted", func() {
+			srv := &bulkServer{attempts: map[string]int{}, respond: func(id string, attempt int) (int, string) {
+				if id == "busy" {
+					return http.StatusTooManyRequests, "es_rejected_execution_exception"
+				}
+				return http.StatusCreated, ""
+			}}
+			i := newBulkTestIndexer(t, srv, 1)
+
+			ids, err := i.Store(ctx, docs)
+			convey.So(ids, convey.ShouldResemble, []string{"1", "bad", "generated-1"})
+
+			var bulkErr *BulkError
+			convey.So(errors.As(err, &bulkErr), convey.ShouldBeTrue)
+			convey.So(bulkErr.FailedIDs(), convey.ShouldResemble, []string{"busy"})
+			convey.So(bulkErr.Failures[0].Status, convey.ShouldEqual, http.StatusTooManyRequests)
+			convey.So(srv.attempts["busy"], convey.ShouldEqual, 2)
+		})
+
+		convey.Convey("test bulk request failure", func() {
+			i := newBulkTestIndexer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Elastic-Product", "Elasticsearch")
+				w.WriteHeader(http.StatusBadRequest)
+			}), 0)
+
+			ids, err := i.Store(ctx, docs[:2])
+			convey.So(ids, convey.ShouldBeEmpty)
+
+			var bulkErr *BulkError
+			convey.So(errors.As(err, &bulkErr), convey.ShouldBeTrue)
+			convey.So(bulkErr.FailedIDs(), convey.ShouldResemble, []string{"1", "bad"})
+			convey.So(bulkErr.Failures[0].Err, convey.ShouldNotBeNil)
+		})
+	})
+}
@@ -21,3 +21,20 @@ const typ = "ElasticSearch8"
 const (
 	defaultBatchSize = 5
 )
+
+const (
+	// defaultParentKey matches mutation.MetaKeyParent of github.com/cloudwego/eino-ext/components/indexer/mutation.
+	defaultParentKey = "_source"
+)
+
+// callback extra keys and operations, same as the ones of github.com/cloudwego/eino-ext/components/indexer/mutation,
+// checked by TestMutationConsts.
+const (
+	extraKeyOperation = "operation"
+	extraKeyIDs       = "ids"
+	extraKeyFilter    = "filter"
+	extraKeyDeleted   = "deleted"
+
+	operationDelete = "delete"
+	operationUpsert = "upsert"
+)
@@ -0,0 +1,98 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+	"github.com/elastic/go-elasticsearch/v8"
+
+	"github.com/cloudwego/eino-ext/components/indexer/es8"
+	"github.com/cloudwego/eino-ext/libs/acl/esindex"
+)
+
+const alias = "eino_example_schema"
+
+// fields of the index, shared with the es8 retriever, e.g. by search_mode.ApproximateConfigFromSchema
+var indexSchema = &esindex.Schema{Fields: []*esindex.Field{
+	{Name: "content", Type: esindex.FieldTypeText, Content: true},
+	{Name: "content_vector", Type: esindex.FieldTypeDenseVector, VectorOf: "content", Similarity: esindex.SimilarityCosine},
+	{Name: "location", Type: esindex.FieldTypeKeyword, MetadataKey: "location"},
+}}
+
+func main() {
+	ctx := context.Background()
+
+	client, err := elasticsearch.NewClient(elasticsearch.Config{
+		Addresses: []string{"http://localhost:9200"},
+		Username:  os.Getenv("ES_USERNAME"),
+		Password:  os.Getenv("ES_PASSWORD"),
+	})
+	if err != nil {
+		log.Fatalf("NewClient of es8 failed, err=%v", err)
+	}
+
+	emb := &fakeEmbedding{dims: 4} // replace it with real embedding component
+
+	// read and write through an alias, so that the mapping can be migrated without downtime.
+	// the first run creates the index behind the alias, each later run copies the documents to a new index
+	// created from the schema and swaps the alias to it.
+	index, err := esindex.ReindexAndSwap(ctx, client, alias, indexSchema.WithDims(emb.dims), &esindex.ReindexConfig{})
+	if err != nil {
+		log.Fatalf("ReindexAndSwap failed, err=%v", err)
+	}
+	log.Printf("alias %s points to %s", alias, index)
+
+	// check the mapping behind the alias, and derive DocumentToFields from the schema
+	indexer, err := es8.NewIndexer(ctx, &es8.IndexerConfig{
+		Client:    client,
+		Index:     alias,
+		Schema:    indexSchema,
+		Bootstrap: es8.BootstrapCheck,
+		Embedding: emb,
+	})
+	if err != nil {
+		log.Fatalf("NewIndexer of es8 failed, err=%v", err)
+	}
+
+	ids, err := indexer.Store(ctx, []*schema.Document{
+		{ID: "1", Content: "The Great Wall of China", MetaData: map[string]any{"location": "China"}},
+		{ID: "2", Content: "The Eiffel Tower", MetaData: map[string]any{"location": "France"}},
+	})
+	if err != nil {
+		log.Fatalf("Store of es8 failed, err=%v", err)
+	}
+	log.Printf("stored ids=%v", ids)
+}
+
+// fakeEmbedding returns constant vectors, only for the example.
+type fakeEmbedding struct {
+	dims int
+}
+
+func (f *fakeEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	vectors := make([][]float64, len(texts))
+	for i := range texts {
+		vectors[i] = make([]float64, f.dims)
+		vectors[i][i%f.dims] = 1
+	}
+	return vectors, nil
+}
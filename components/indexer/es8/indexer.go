@@ -17,10 +17,10 @@
 package es8
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components"
@@ -29,6 +29,8 @@ import (
 	"github.com/cloudwego/eino/schema"
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/elastic/go-elasticsearch/v8/esutil"
+
+	"github.com/cloudwego/eino-ext/libs/acl/esindex"
 )
 
 type IndexerConfig struct {
@@ -46,6 +48,29 @@ type IndexerConfig struct {
 	// 1. VectorFields contains fields except doc Content
 	// 2. VectorFields contains doc Content and vector not provided in doc extra (see Document.Vector method)
 	Embedding embedding.Embedder
+	// MaxRetries is the number of times documents rejected with 429 Too Many Requests are resubmitted.
+	// A whole bulk request rejected with 429 is retried by the client, see elasticsearch.Config.RetryOnStatus.
+	// Default is 0, no retry.
+	MaxRetries int
+	// RetryBackoff returns the time to wait before the attempt-th retry, attempt starts from 1.
+	// Default is exponential backoff from 100ms, capped at 5s.
+	RetryBackoff func(attempt int) time.Duration
+	// ParentKey is the metadata key of the parent document of a chunk, which Upsert replaces the chunks of.
+	// Default is "_source", the source uri set by the file loader.
+	ParentKey string
+	// MetadataKeyToField maps a metadata key of DeleteByFilter and ParentKey to the es field storing it,
+	// which DocumentToFields should fill. Fields are matched with term queries, so use keyword fields.
+	// Default is the field of Schema declared for the key, or trims the leading underscores of the key,
+	// es reserves them for metadata fields, e.g. "_source" is stored in field "source".
+	MetadataKeyToField func(key string) string
+	// Schema declares the fields of the index, see github.com/cloudwego/eino-ext/libs/acl/esindex.
+	// If set, DocumentToFields defaults to SchemaDocumentToFields(Schema), and the es8 retriever search modes
+	// can read their field names from the same Schema.
+	Schema *esindex.Schema
+	// Bootstrap creates or checks the index from Schema in NewIndexer,
+	// dense_vector fields without dims get the dimension of Embedding.
+	// Default is BootstrapNone.
+	Bootstrap BootstrapMode
 }
 
 type FieldValue struct {
@@ -64,11 +89,27 @@ type Indexer struct {
 	config *IndexerConfig
 }
 
-func NewIndexer(_ context.Context, conf *IndexerConfig) (*Indexer, error) {
+func NewIndexer(ctx context.Context, conf *IndexerConfig) (*Indexer, error) {
 	if conf.Client == nil {
 		return nil, fmt.Errorf("[NewIndexer] es client not provided")
 	}
 
+	if conf.Schema != nil {
+		if err := conf.Schema.Validate(); err != nil {
+			return nil, fmt.Errorf("[NewIndexer] invalid schema, %w", err)
+		}
+
+		if conf.DocumentToFields == nil {
+			conf.DocumentToFields = SchemaDocumentToFields(conf.Schema)
+		}
+
+		if conf.MetadataKeyToField == nil {
+			conf.MetadataKeyToField = schemaMetadataKeyToField(conf.Schema)
+		}
+	} else if conf.Bootstrap != BootstrapNone {
+		return nil, fmt.Errorf("[NewIndexer] schema not provided for bootstrap")
+	}
+
 	if conf.DocumentToFields == nil {
 		return nil, fmt.Errorf("[NewIndexer] DocumentToFields method not provided")
 	}
@@ -77,10 +118,30 @@ func NewIndexer(_ context.Context, conf *IndexerConfig) (*Indexer, error) {
 		conf.BatchSize = defaultBatchSize
 	}
 
-	return &Indexer{
+	if conf.RetryBackoff == nil {
+		conf.RetryBackoff = defaultRetryBackoff
+	}
+
+	if conf.ParentKey == "" {
+		conf.ParentKey = defaultParentKey
+	}
+
+	if conf.MetadataKeyToField == nil {
+		conf.MetadataKeyToField = defaultMetadataKeyToField
+	}
+
+	i := &Indexer{
 		client: conf.Client,
 		config: conf,
-	}, nil
+	}
+
+	if conf.Bootstrap != BootstrapNone {
+		if err := i.bootstrap(ctx); err != nil {
+			return nil, fmt.Errorf("[NewIndexer] %w", err)
+		}
+	}
+
+	return i, nil
 }
 
 func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
@@ -96,23 +157,75 @@ func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...in
 		Embedding: i.config.Embedding,
 	}, opts...)
 
-	if err = i.bulkAdd(ctx, docs, options); err != nil {
+	rec, err := i.bulkAdd(ctx, docs, options)
+	if rec == nil {
+		return nil, err
+	}
+
+	// on partial failure or cancellation, the stored ids and the failures are reported and returned along with the error
+	ids, stats, resultErr := rec.result()
+	if err == nil {
+		err = resultErr
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{
+		IDs:   ids,
+		Extra: bulkExtra(stats, resultErr),
+	})
+
+	return ids, err
+}
+
+func (i *Indexer) newBulkIndexer(rec *bulkRecorder) (esutil.BulkIndexer, error) {
+	return esutil.NewBulkIndexer(esutil.BulkIndexerConfig{
+		Index:   i.config.Index,
+		Client:  i.client,
+		OnError: rec.onError,
+	})
+}
+
+func (i *Indexer) bulkAdd(ctx context.Context, docs []*schema.Document, options *indexer.Options) (*bulkRecorder, error) {
+	rec := newBulkRecorder(iter(docs, func(t *schema.Document) string { return t.ID }))
+	rec.setCanRetry(i.config.MaxRetries > 0)
+
+	if err := i.addDocuments(ctx, docs, options, rec); err != nil {
 		return nil, err
 	}
 
-	ids = iter(docs, func(t *schema.Document) string { return t.ID })
+	for attempt := 1; attempt <= i.config.MaxRetries; attempt++ {
+		items := rec.takeRetries()
+		if len(items) == 0 {
+			break
+		}
+		rec.setCanRetry(attempt < i.config.MaxRetries)
+
+		select {
+		case <-ctx.Done():
+			rec.fail(items, ctx.Err())
+			return rec, ctx.Err()
+		case <-time.After(i.config.RetryBackoff(attempt)):
+		}
 
-	callbacks.OnEnd(ctx, &indexer.CallbackOutput{IDs: ids})
+		bi, err := i.newBulkIndexer(rec)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			if err = rec.add(ctx, bi, i.config.Index, item); err != nil {
+				return nil, err
+			}
+		}
+		if err = bi.Close(ctx); err != nil {
+			return nil, err
+		}
+	}
 
-	return ids, nil
+	return rec, nil
 }
 
-func (i *Indexer) bulkAdd(ctx context.Context, docs []*schema.Document, options *indexer.Options) error {
+func (i *Indexer) addDocuments(ctx context.Context, docs []*schema.Document, options *indexer.Options, rec *bulkRecorder) error {
 	emb := options.Embedding
-	bi, err := esutil.NewBulkIndexer(esutil.BulkIndexerConfig{
-		Index:  i.config.Index,
-		Client: i.client,
-	})
+	bi, err := i.newBulkIndexer(rec)
 	if err != nil {
 		return err
 	}
@@ -151,12 +264,7 @@ func (i *Indexer) bulkAdd(ctx context.Context, docs []*schema.Document, options
 				return fmt.Errorf("[bulkAdd] marshal bulk item failed, %w", err)
 			}
 
-			if err = bi.Add(ctx, esutil.BulkIndexerItem{
-				Index:      i.config.Index,
-				Action:     "index",
-				DocumentID: t.id,
-				Body:       bytes.NewReader(b),
-			}); err != nil {
+			if err = rec.add(ctx, bi, i.config.Index, bulkItem{pos: t.pos, id: t.id, body: b}); err != nil {
 				return err
 			}
 		}
@@ -225,6 +333,7 @@ func (i *Indexer) bulkAdd(ctx context.Context, docs []*schema.Document, options
 		}
 
 		tuples = append(tuples, tuple{
+			pos:     idx,
 			id:      doc.ID,
 			fields:  rawFields,
 			key2Idx: key2Idx,
@@ -263,6 +372,7 @@ func (i *Indexer) IsCallbacksEnabled() bool {
 }
 
 type tuple struct {
+	pos     int
 	id      string
 	fields  map[string]any
 	key2Idx map[string]int
@@ -53,7 +53,7 @@ func TestBulkAdd(t *testing.T) {
 					},
 				},
 			}
-			err := i.bulkAdd(ctx, docs, &indexer.Options{
+			_, err := i.bulkAdd(ctx, docs, &indexer.Options{
 				Embedding: &mockEmbedding{size: []int{1}, mockVector: []float64{2.1}},
 			})
 			convey.So(err, convey.ShouldBeError, mockErr)
@@ -70,7 +70,7 @@ func TestBulkAdd(t *testing.T) {
 					},
 				},
 			}
-			err := i.bulkAdd(ctx, docs, &indexer.Options{
+			_, err := i.bulkAdd(ctx, docs, &indexer.Options{
 				Embedding: &mockEmbedding{size: []int{1}, mockVector: []float64{2.1}},
 			})
 			convey.So(err, convey.ShouldBeError, fmt.Errorf("[bulkAdd] FieldMapping failed, %w", mockErr))
@@ -90,7 +90,7 @@ func TestBulkAdd(t *testing.T) {
 					},
 				},
 			}
-			err := i.bulkAdd(ctx, docs, &indexer.Options{
+			_, err := i.bulkAdd(ctx, docs, &indexer.Options{
 				Embedding: &mockEmbedding{size: []int{1}, mockVector: []float64{2.1}},
 			})
 			convey.So(err, convey.ShouldBeError, fmt.Errorf("[bulkAdd] needEmbeddingFields length over batch size, batch size=%d, got size=%d", i.config.BatchSize, 2))
@@ -114,7 +114,7 @@ func TestBulkAdd(t *testing.T) {
 					},
 				},
 			}
-			err := i.bulkAdd(ctx, docs, &indexer.Options{
+			_, err := i.bulkAdd(ctx, docs, &indexer.Options{
 				Embedding: nil,
 			})
 			convey.So(err, convey.ShouldBeError, fmt.Errorf("[bulkAdd] embedding method not provided"))
@@ -139,7 +139,7 @@ func TestBulkAdd(t *testing.T) {
 					},
 				},
 			}
-			err := i.bulkAdd(ctx, docs, &indexer.Options{
+			_, err := i.bulkAdd(ctx, docs, &indexer.Options{
 				Embedding: &mockEmbedding{err: mockErr},
 			})
 			convey.So(err, convey.ShouldBeError, fmt.Errorf("[bulkAdd] embedding failed, %w", mockErr))
@@ -163,7 +163,7 @@ func TestBulkAdd(t *testing.T) {
 					},
 				},
 			}
-			err := i.bulkAdd(ctx, docs, &indexer.Options{
+			_, err := i.bulkAdd(ctx, docs, &indexer.Options{
 				Embedding: &mockEmbedding{size: []int{1}, mockVector: []float64{2.1}},
 			})
 			convey.So(err, convey.ShouldBeError, fmt.Errorf("[bulkAdd] invalid vector length, expected=%d, got=%d", 2, 1))
@@ -192,7 +192,7 @@ func TestBulkAdd(t *testing.T) {
 					},
 				},
 			}
-			err := i.bulkAdd(ctx, docs, &indexer.Options{
+			_, err := i.bulkAdd(ctx, docs, &indexer.Options{
 				Embedding: &mockEmbedding{size: []int{2, 2}, mockVector: []float64{2.1}},
 			})
 			convey.So(err, convey.ShouldBeNil)
@@ -0,0 +1,214 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package es8
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Delete deletes the documents of ids.
+// It implements mutation.Deleter of github.com/cloudwego/eino-ext/components/indexer/mutation.
+func (i *Indexer) Delete(ctx context.Context, ids []string, opts ...indexer.Option) (deleted int64, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{
+		Extra: map[string]any{extraKeyOperation: operationDelete, extraKeyIDs: ids},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	if len(ids) > 0 {
+		query := map[string]any{"ids": map[string]any{"values": ids}}
+		if deleted, err = i.deleteByQuery(ctx, query); err != nil {
+			return 0, err
+		}
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{Extra: map[string]any{extraKeyDeleted: deleted}})
+
+	return deleted, nil
+}
+
+// DeleteByFilter deletes the documents whose fields equal every key - value pair of filter,
+// keys are mapped to es fields by MetadataKeyToField.
+func (i *Indexer) DeleteByFilter(ctx context.Context, filter map[string]any, opts ...indexer.Option) (deleted int64, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{
+		Extra: map[string]any{extraKeyOperation: operationDelete, extraKeyFilter: filter},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	if len(filter) == 0 {
+		return 0, fmt.Errorf("[DeleteByFilter] empty filter")
+	}
+
+	keys := make([]string, 0, len(filter))
+	for k := range filter {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	terms := make([]any, 0, len(keys))
+	for _, k := range keys {
+		terms = append(terms, map[string]any{"term": map[string]any{i.config.MetadataKeyToField(k): filter[k]}})
+	}
+
+	query := map[string]any{"bool": map[string]any{"filter": terms}}
+	if deleted, err = i.deleteByQuery(ctx, query); err != nil {
+		return 0, err
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{Extra: map[string]any{extraKeyDeleted: deleted}})
+
+	return deleted, nil
+}
+
+// Upsert indexes docs, which overwrites the documents of the same ids, then deletes the other documents of their parents,
+// the parent of a doc is its ParentKey metadata, stored in field MetadataKeyToField(ParentKey).
+// Es has no transaction, the stale documents are searchable until they are deleted after the bulk request.
+// Nothing is deleted if any document fails to index.
+// It implements mutation.Upserter of github.com/cloudwego/eino-ext/components/indexer/mutation.
+func (i *Indexer) Upsert(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{
+		Docs:  docs,
+		Extra: map[string]any{extraKeyOperation: operationUpsert},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	options := indexer.GetCommonOptions(&indexer.Options{
+		Embedding: i.config.Embedding,
+	}, opts...)
+
+	parents, err := getParents(docs, i.config.ParentKey)
+	if err != nil {
+		return nil, fmt.Errorf("[Upsert] %w", err)
+	}
+
+	rec, err := i.bulkAdd(ctx, docs, options)
+	if rec == nil {
+		return nil, err
+	}
+
+	ids, stats, resultErr := rec.result()
+	if err == nil {
+		err = resultErr
+	}
+	if err != nil {
+		// the other chunks of the parents are kept, the stored ids and the failures are reported
+		callbacks.OnEnd(ctx, &indexer.CallbackOutput{
+			IDs:   ids,
+			Extra: bulkExtra(stats, resultErr),
+		})
+		return ids, err
+	}
+
+	query := map[string]any{"bool": map[string]any{
+		"filter":   []any{map[string]any{"terms": map[string]any{i.config.MetadataKeyToField(i.config.ParentKey): parents}}},
+		"must_not": []any{map[string]any{"ids": map[string]any{"values": ids}}},
+	}}
+	deleted, err := i.deleteByQuery(ctx, query)
+	if err != nil {
+		return ids, err
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{
+		IDs:   ids,
+		Extra: map[string]any{ExtraKeyBulkStats: stats, extraKeyDeleted: deleted},
+	})
+
+	return ids, nil
+}
+
+// deleteByQuery deletes the documents matching query and refreshes the index, returns the number of deleted documents.
+func (i *Indexer) deleteByQuery(ctx context.Context, query map[string]any) (int64, error) {
+	body, err := json.Marshal(map[string]any{"query": query})
+	if err != nil {
+		return 0, fmt.Errorf("[deleteByQuery] marshal query failed, %w", err)
+	}
+
+	dbq := i.client.DeleteByQuery
+	resp, err := dbq([]string{i.config.Index}, bytes.NewReader(body),
+		dbq.WithContext(ctx),
+		dbq.WithRefresh(true),
+		dbq.WithConflicts("proceed"),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("[deleteByQuery] request failed, %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		return 0, fmt.Errorf("[deleteByQuery] request failed, %s", resp.String())
+	}
+
+	var result struct {
+		Deleted  int64             `json:"deleted"`
+		Failures []json.RawMessage `json:"failures"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("[deleteByQuery] decode response failed, %w", err)
+	}
+
+	if len(result.Failures) > 0 {
+		return result.Deleted, fmt.Errorf("[deleteByQuery] %d failures, deleted=%d, first=%s",
+			len(result.Failures), result.Deleted, result.Failures[0])
+	}
+
+	return result.Deleted, nil
+}
+
+// getParents returns the distinct parents of docs in order.
+func getParents(docs []*schema.Document, parentKey string) ([]any, error) {
+	var parents []any
+	seen := make(map[any]struct{})
+	for _, doc := range docs {
+		parent, ok := doc.MetaData[parentKey]
+		if !ok || parent == nil {
+			return nil, fmt.Errorf("parent metadata %q not found in document, id=%s", parentKey, doc.ID)
+		}
+		if _, found := seen[parent]; !found {
+			seen[parent] = struct{}{}
+			parents = append(parents, parent)
+		}
+	}
+	return parents, nil
+}
+
+func defaultMetadataKeyToField(key string) string {
+	return strings.TrimLeft(key, "_")
+}
@@ -0,0 +1,139 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package es8
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/indexer/mutation"
+)
+
+// deleteServer emulates the _delete_by_query endpoint, and delegates _bulk to bulk.
+type deleteServer struct {
+	bulk    *bulkServer
+	deleted int64
+	queries []string
+	params  []string
+}
+
+func (d *deleteServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasSuffix(r.URL.Path, "/_delete_by_query") {
+		d.bulk.ServeHTTP(w, r)
+		return
+	}
+
+	var body map[string]any
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	query, _ := json.Marshal(body["query"])
+	d.queries = append(d.queries, string(query))
+	d.params = append(d.params, r.URL.RawQuery)
+
+	w.Header().Set("X-Elastic-Product", "Elasticsearch")
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"deleted": d.deleted, "failures": []any{}})
+}
+
+func TestMutation(t *testing.T) {
+	convey.Convey("test delete and upsert", t, func() {
+		ctx := context.Background()
+		srv := &deleteServer{
+			bulk:    &bulkServer{attempts: map[string]int{}, respond: func(id string, attempt int) (int, string) { return http.StatusCreated, "" }},
+			deleted: 2,
+		}
+		i := newBulkTestIndexer(t, srv, 0)
+
+		var output *indexer.CallbackOutput
+		ctx = callbacks.InitCallbacks(ctx, nil, callbacks.NewHandlerBuilder().
+			OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, out callbacks.CallbackOutput) context.Context {
+				output = indexer.ConvCallbackOutput(out)
+				return ctx
+			}).Build())
+
+		convey.Convey("test delete by ids", func() {
+			n, err := i.Delete(ctx, []string{"1", "2"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(n, convey.ShouldEqual, 2)
+			convey.So(srv.queries, convey.ShouldResemble, []string{`{"ids":{"values":["1","2"]}}`})
+			convey.So(srv.params[0], convey.ShouldContainSubstring, "refresh=true")
+			convey.So(output.Extra[extraKeyDeleted], convey.ShouldEqual, int64(2))
+		})
+
+		convey.Convey("test delete by filter", func() {
+			n, err := i.DeleteByFilter(ctx, map[string]any{"_source": "a.md", "page": 1})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(n, convey.ShouldEqual, 2)
+			convey.So(srv.queries, convey.ShouldResemble, []string{
+				`{"bool":{"filter":[{"term":{"source":"a.md"}},{"term":{"page":1}}]}}`,
+			})
+
+			_, err = i.DeleteByFilter(ctx, nil)
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test upsert", func() {
+			srv.deleted = 1
+			ids, err := i.Upsert(ctx, []*schema.Document{
+				{ID: "a1", Content: "a1", MetaData: map[string]any{"_source": "a.md"}},
+				{ID: "b1", Content: "b1", MetaData: map[string]any{"_source": "b.md"}},
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids, convey.ShouldResemble, []string{"a1", "b1"})
+			convey.So(srv.bulk.attempts, convey.ShouldResemble, map[string]int{"a1": 1, "b1": 1})
+			convey.So(srv.queries, convey.ShouldResemble, []string{
+				`{"bool":{"filter":[{"terms":{"source":["a.md","b.md"]}}],"must_not":[{"ids":{"values":["a1","b1"]}}]}}`,
+			})
+			convey.So(output.IDs, convey.ShouldResemble, ids)
+			convey.So(output.Extra[extraKeyDeleted], convey.ShouldEqual, int64(1))
+
+			_, err = i.Upsert(ctx, []*schema.Document{{ID: "c1", Content: "c1"}})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test upsert keeps stale documents on bulk failure", func() {
+			srv.bulk.respond = func(id string, attempt int) (int, string) { return http.StatusBadRequest, "mapper_parsing_exception" }
+			_, err := i.Upsert(ctx, []*schema.Document{{ID: "a1", Content: "a1", MetaData: map[string]any{"_source": "a.md"}}})
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(srv.queries, convey.ShouldBeEmpty)
+		})
+	})
+}
+
+var (
+	_ mutation.Deleter  = (*Indexer)(nil)
+	_ mutation.Upserter = (*Indexer)(nil)
+)
+
+func TestMutationConsts(t *testing.T) {
+	convey.Convey("test callback extra keys and operations same as mutation", t, func() {
+		convey.So(extraKeyOperation, convey.ShouldEqual, mutation.ExtraKeyOperation)
+		convey.So(extraKeyIDs, convey.ShouldEqual, mutation.ExtraKeyIDs)
+		convey.So(extraKeyFilter, convey.ShouldEqual, mutation.ExtraKeyFilter)
+		convey.So(extraKeyDeleted, convey.ShouldEqual, mutation.ExtraKeyDeleted)
+		convey.So(operationDelete, convey.ShouldEqual, mutation.OperationDelete)
+		convey.So(operationUpsert, convey.ShouldEqual, mutation.OperationUpsert)
+		convey.So(defaultParentKey, convey.ShouldEqual, mutation.MetaKeyParent)
+	})
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package es8
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/libs/acl/esindex"
+)
+
+// BootstrapMode is how NewIndexer prepares the index from IndexerConfig.Schema.
+type BootstrapMode string
+
+const (
+	// BootstrapNone leaves the index as is, it should be created beforehand.
+	BootstrapNone BootstrapMode = ""
+	// BootstrapCreate creates the index if it doesn't exist, and checks its mapping otherwise.
+	BootstrapCreate BootstrapMode = "create"
+	// BootstrapCheck checks the mapping of the index, which should exist.
+	BootstrapCheck BootstrapMode = "check"
+)
+
+// SchemaDocumentToFields returns a DocumentToFields storing the fields declared by s:
+// the content field stores doc.Content, metadata fields store the doc.MetaData values of their keys if present,
+// dense_vector fields store the embeddings of their VectorOf fields,
+// and the sparse field of VectorOf stores doc.SparseVector with the indices as token keys.
+func SchemaDocumentToFields(s *esindex.Schema) func(ctx context.Context, doc *schema.Document) (map[string]FieldValue, error) {
+	return func(ctx context.Context, doc *schema.Document) (map[string]FieldValue, error) {
+		fields := make(map[string]FieldValue, len(s.Fields))
+		for _, f := range s.Fields {
+			switch {
+			case f.Content:
+				fields[f.Name] = FieldValue{Value: doc.Content}
+			case f.MetadataKey != "":
+				if v, found := doc.MetaData[f.MetadataKey]; found {
+					fields[f.Name] = FieldValue{Value: v}
+				}
+			case f.Type != esindex.FieldTypeDenseVector && f.VectorOf != "":
+				if sv := doc.SparseVector(); len(sv) > 0 {
+					tokens := make(map[string]float64, len(sv))
+					for idx, weight := range sv {
+						tokens[strconv.Itoa(idx)] = weight
+					}
+					fields[f.Name] = FieldValue{Value: tokens}
+				}
+			}
+		}
+
+		for _, f := range s.Fields {
+			if f.Type != esindex.FieldTypeDenseVector || f.VectorOf == "" {
+				continue
+			}
+			source, found := fields[f.VectorOf]
+			if !found {
+				continue
+			}
+			if _, ok := source.Value.(string); !ok {
+				return nil, fmt.Errorf("[SchemaDocumentToFields] value of field %s to embed isn't string, doc id=%s", f.VectorOf, doc.ID)
+			}
+			source.EmbedKey = f.Name
+			fields[f.VectorOf] = source
+		}
+
+		return fields, nil
+	}
+}
+
+// schemaMetadataKeyToField maps a metadata key to the field declared for it by s, or to defaultMetadataKeyToField.
+func schemaMetadataKeyToField(s *esindex.Schema) func(key string) string {
+	return func(key string) string {
+		for _, f := range s.Fields {
+			if f.MetadataKey == key {
+				return f.Name
+			}
+		}
+		return defaultMetadataKeyToField(key)
+	}
+}
+
+// bootstrap creates or checks the index from the schema, dense_vector fields without dims get the dimension of the embedding.
+func (i *Indexer) bootstrap(ctx context.Context) error {
+	s := i.config.Schema
+	if s.NeedsDims() {
+		emb := i.config.Embedding
+		if emb == nil {
+			return fmt.Errorf("[bootstrap] embedding not provided for the dims of dense vector fields")
+		}
+
+		vectors, err := emb.EmbedStrings(i.makeEmbeddingCtx(ctx, emb), []string{"dimension probe"})
+		if err != nil {
+			return fmt.Errorf("[bootstrap] embedding failed, %w", err)
+		}
+		if len(vectors) != 1 || len(vectors[0]) == 0 {
+			return fmt.Errorf("[bootstrap] invalid vectors of embedding, got %d vectors", len(vectors))
+		}
+		s = s.WithDims(len(vectors[0]))
+	}
+
+	switch i.config.Bootstrap {
+	case BootstrapCreate:
+		return esindex.EnsureIndex(ctx, i.client, i.config.Index, s)
+	case BootstrapCheck:
+		return esindex.CheckIndex(ctx, i.client, i.config.Index, s)
+	default:
+		return fmt.Errorf("[bootstrap] unknown bootstrap mode: %q", i.config.Bootstrap)
+	}
+}
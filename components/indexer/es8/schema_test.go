@@ -0,0 +1,181 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package es8
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/libs/acl/esindex"
+)
+
+// indexServer emulates the index exists, create and get mapping endpoints of a single index.
+type indexServer struct {
+	mapping json.RawMessage
+}
+
+func (s *indexServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Elastic-Product", "Elasticsearch")
+	w.Header().Set("Content-Type", "application/json")
+	index := strings.Split(strings.Trim(r.URL.Path, "/"), "/")[0]
+
+	switch {
+	case r.Method == http.MethodHead:
+		if s.mapping == nil {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case r.Method == http.MethodPut:
+		var req struct {
+			Mappings json.RawMessage `json:"mappings"`
+		}
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &req)
+		s.mapping = req.Mappings
+		_, _ = fmt.Fprintf(w, `{"acknowledged": true, "index": %q}`, index)
+	case r.Method == http.MethodGet && s.mapping != nil:
+		_, _ = fmt.Fprintf(w, `{%q: {"mappings": %s}}`, index, s.mapping)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error": {"type": "index_not_found_exception"}, "status": 404}`))
+	}
+}
+
+func testSchema() *esindex.Schema {
+	return &esindex.Schema{Fields: []*esindex.Field{
+		{Name: "content", Type: esindex.FieldTypeText, Content: true},
+		{Name: "content_vector", Type: esindex.FieldTypeDenseVector, VectorOf: "content"},
+		{Name: "content_sparse", Type: esindex.FieldTypeSparseVector, VectorOf: "content"},
+		{Name: "title", Type: esindex.FieldTypeText, MetadataKey: "title"},
+		{Name: "title_vector", Type: esindex.FieldTypeDenseVector, VectorOf: "title", Dims: 2},
+		{Name: "source", Type: esindex.FieldTypeKeyword, MetadataKey: "_source"},
+	}}
+}
+
+func TestSchemaDocumentToFields(t *testing.T) {
+	convey.Convey("test SchemaDocumentToFields", t, func() {
+		ctx := context.Background()
+		toFields := SchemaDocumentToFields(testSchema())
+
+		doc := (&schema.Document{
+			ID:       "1",
+			Content:  "eino",
+			MetaData: map[string]any{"title": "intro", "_source": "docs/intro.md"},
+		}).WithSparseVector(map[int]float64{7: 0.5})
+		fields, err := toFields(ctx, doc)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(len(fields), convey.ShouldEqual, 4)
+		convey.So(fields["content"].Value, convey.ShouldEqual, "eino")
+		convey.So(fields["content"].EmbedKey, convey.ShouldEqual, "content_vector")
+		convey.So(fields["content_sparse"].Value, convey.ShouldResemble, map[string]float64{"7": 0.5})
+		convey.So(fields["title"].EmbedKey, convey.ShouldEqual, "title_vector")
+		convey.So(fields["source"].Value, convey.ShouldEqual, "docs/intro.md")
+		convey.So(fields["source"].EmbedKey, convey.ShouldEqual, "")
+
+		fields, err = toFields(ctx, &schema.Document{ID: "2", Content: "graph"})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(len(fields), convey.ShouldEqual, 1)
+
+		_, err = toFields(ctx, &schema.Document{ID: "3", MetaData: map[string]any{"title": 1}})
+		convey.So(err, convey.ShouldNotBeNil)
+
+		keyToField := schemaMetadataKeyToField(testSchema())
+		convey.So(keyToField("_source"), convey.ShouldEqual, "source")
+		convey.So(keyToField("_author"), convey.ShouldEqual, "author")
+	})
+}
+
+func TestBootstrap(t *testing.T) {
+	convey.Convey("test NewIndexer bootstrap", t, func() {
+		ctx := context.Background()
+		srv := &indexServer{}
+		ts := httptest.NewServer(srv)
+		defer ts.Close()
+		client, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{ts.URL}})
+		convey.So(err, convey.ShouldBeNil)
+
+		newIndexer := func(mode BootstrapMode, s *esindex.Schema, emb *mockEmbedding) (*Indexer, error) {
+			conf := &IndexerConfig{Client: client, Index: "eino_test", Schema: s, Bootstrap: mode}
+			if emb != nil {
+				conf.Embedding = emb
+			}
+			return NewIndexer(ctx, conf)
+		}
+
+		convey.Convey("test invalid config", func() {
+			_, err := NewIndexer(ctx, &IndexerConfig{Client: client, Bootstrap: BootstrapCreate})
+			convey.So(err, convey.ShouldBeError, fmt.Errorf("[NewIndexer] schema not provided for bootstrap"))
+
+			_, err = newIndexer(BootstrapNone, &esindex.Schema{Fields: []*esindex.Field{{Name: "a"}}}, nil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "[NewIndexer] invalid schema")
+
+			_, err = newIndexer("recreate", &esindex.Schema{}, nil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "unknown bootstrap mode")
+
+			_, err = newIndexer(BootstrapCreate, testSchema(), nil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "embedding not provided")
+
+			_, err = newIndexer(BootstrapCreate, testSchema(), &mockEmbedding{err: fmt.Errorf("mock err")})
+			convey.So(err.Error(), convey.ShouldContainSubstring, "embedding failed")
+
+			_, err = newIndexer(BootstrapCreate, testSchema(), &mockEmbedding{size: []int{1}})
+			convey.So(err.Error(), convey.ShouldContainSubstring, "invalid vectors of embedding")
+		})
+
+		convey.Convey("test schema without bootstrap", func() {
+			i, err := newIndexer(BootstrapNone, testSchema(), nil)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(i.config.DocumentToFields, convey.ShouldNotBeNil)
+			convey.So(i.config.MetadataKeyToField("_source"), convey.ShouldEqual, "source")
+			convey.So(srv.mapping, convey.ShouldBeNil)
+		})
+
+		convey.Convey("test create then check", func() {
+			_, err := newIndexer(BootstrapCheck, testSchema(), &mockEmbedding{size: []int{1}, mockVector: []float64{0.1, 0.2, 0.3}})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			_, err = newIndexer(BootstrapCreate, testSchema(), &mockEmbedding{size: []int{1}, mockVector: []float64{0.1, 0.2, 0.3}})
+			convey.So(err, convey.ShouldBeNil)
+
+			var mapping struct {
+				Properties map[string]struct {
+					Type string `json:"type"`
+					Dims int    `json:"dims"`
+				} `json:"properties"`
+			}
+			convey.So(json.Unmarshal(srv.mapping, &mapping), convey.ShouldBeNil)
+			convey.So(mapping.Properties["content_vector"].Dims, convey.ShouldEqual, 3)
+			convey.So(mapping.Properties["title_vector"].Dims, convey.ShouldEqual, 2)
+			convey.So(mapping.Properties["content_sparse"].Type, convey.ShouldEqual, "sparse_vector")
+
+			_, err = newIndexer(BootstrapCheck, testSchema(), &mockEmbedding{size: []int{1}, mockVector: []float64{0.1, 0.2, 0.3}})
+			convey.So(err, convey.ShouldBeNil)
+
+			_, err = newIndexer(BootstrapCreate, testSchema(), &mockEmbedding{size: []int{1}, mockVector: []float64{0.1, 0.2}})
+			convey.So(err.Error(), convey.ShouldContainSubstring, "field content_vector has 3 dims, expected 2")
+		})
+	})
+}
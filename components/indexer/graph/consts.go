@@ -0,0 +1,51 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+const typ = "Graph"
+
+func GetType() string {
+	return typ
+}
+
+const (
+	// MetaKeyEntities and MetaKeyRelations are the metadata keys of the entities and relations of a chunk,
+	// written by the graph extractor of github.com/cloudwego/eino-ext/components/document/transformer/extractor/graph.
+	// Their values are lists of objects with the json fields of Entity and Relation.
+	MetaKeyEntities  = "_kg_entities"
+	MetaKeyRelations = "_kg_relations"
+)
+
+const (
+	// defaultParentKey matches mutation.MetaKeyParent of github.com/cloudwego/eino-ext/components/indexer/mutation.
+	defaultParentKey = "_source"
+
+	// snapshotVersion is the version of the snapshot format.
+	snapshotVersion = 1
+)
+
+// callback extra keys and operations, same as the ones of github.com/cloudwego/eino-ext/components/indexer/mutation,
+// checked by TestMutationConsts.
+const (
+	extraKeyOperation = "operation"
+	extraKeyIDs       = "ids"
+	extraKeyFilter    = "filter"
+	extraKeyDeleted   = "deleted"
+
+	operationDelete = "delete"
+	operationUpsert = "upsert"
+)
@@ -0,0 +1,87 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/indexer/graph"
+)
+
+func main() {
+	ctx := context.Background()
+
+	path := os.Getenv("GRAPH_PATH")
+	if path == "" {
+		path = filepath.Join(os.TempDir(), "eino_graph.json")
+	}
+
+	// the store loads the file if it exists, and saves it after every change
+	store, err := graph.NewMemoryStore(&graph.MemoryStoreConfig{Path: path})
+	if err != nil {
+		log.Fatalf("NewMemoryStore failed, err=%v", err)
+	}
+
+	idx, err := graph.NewIndexer(ctx, &graph.IndexerConfig{Store: store})
+	if err != nil {
+		log.Fatalf("NewIndexer failed, err=%v", err)
+	}
+
+	// the metadata is usually written by the graph extractor,
+	// github.com/cloudwego/eino-ext/components/document/transformer/extractor/graph
+	ids, err := idx.Store(ctx, []*schema.Document{
+		{
+			ID:      "people_1",
+			Content: "Alice works at Acme Corp.",
+			MetaData: map[string]any{
+				"_source": "people.md",
+				graph.MetaKeyEntities: []map[string]any{
+					{"name": "Alice", "type": "person"},
+					{"name": "Acme Corp", "type": "organization"},
+				},
+				graph.MetaKeyRelations: []map[string]any{
+					{"source": "Alice", "target": "Acme Corp", "type": "works at"},
+				},
+			},
+		},
+		{
+			ID:      "companies_1",
+			Content: "Acme Corp is based in Berlin.",
+			MetaData: map[string]any{
+				"_source": "companies.md",
+				graph.MetaKeyRelations: []map[string]any{
+					{"source": "Acme Corp", "target": "Berlin", "type": "based in"},
+				},
+			},
+		},
+	})
+	if err != nil {
+		log.Fatalf("Store failed, err=%v", err)
+	}
+	log.Printf("stored: ids=%v, chunks=%d, nodes=%d, edges=%d", ids, store.Len(), store.NodeCount(), store.EdgeCount())
+
+	deleted, err := idx.DeleteByFilter(ctx, map[string]any{"_source": "companies.md"})
+	if err != nil {
+		log.Fatalf("DeleteByFilter failed, err=%v", err)
+	}
+	log.Printf("deleted: %d, chunks=%d, nodes=%d, edges=%d", deleted, store.Len(), store.NodeCount(), store.EdgeCount())
+}
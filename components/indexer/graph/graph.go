@@ -0,0 +1,149 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"context"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// Entity is a node of the knowledge graph extracted from a chunk.
+type Entity struct {
+	Name        string `json:"name"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Relation is a directed edge between two entities extracted from a chunk, Source and Target are entity names.
+type Relation struct {
+	Source      string `json:"source"`
+	Target      string `json:"target"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+}
+
+// Chunk is a document with the entities and relations extracted from it.
+// Every Source and Target of Relations is the name of one of Entities.
+type Chunk struct {
+	Doc       *schema.Document
+	Entities  []*Entity
+	Relations []*Relation
+}
+
+// Node is an entity merged from the chunks mentioning it.
+type Node struct {
+	// Key identifies the node, see NodeKey.
+	Key  string
+	Name string
+	Type string
+	// Descriptions are the distinct descriptions of the entity in its chunks.
+	Descriptions []string
+	// ChunkIDs are the ids of the chunks mentioning the entity, sorted.
+	ChunkIDs []string
+}
+
+// Edge is a relation merged from the chunks stating it, Source and Target are node keys.
+type Edge struct {
+	Source string
+	Target string
+	Type   string
+	// Descriptions are the distinct descriptions of the relation in its chunks.
+	Descriptions []string
+	// ChunkIDs are the ids of the chunks stating the relation, sorted.
+	ChunkIDs []string
+}
+
+// Store is the graph storage of the graph indexer and retriever. MemoryStore implements it in memory,
+// implement it to keep the graph in an external graph database.
+type Store interface {
+	// AddChunks stores chunks with their entities and relations, replacing the chunks of the same ids,
+	// together with what the replaced chunks contributed to nodes and edges.
+	AddChunks(ctx context.Context, chunks []*Chunk) error
+	// DeleteChunks deletes the chunks of ids, and nodes and edges which no other chunk contributes to.
+	// It returns the number of deleted chunks.
+	DeleteChunks(ctx context.Context, ids []string) (int64, error)
+	// ChunkIDs returns the ids of the chunks whose metadata equals every key - value pair of filter.
+	ChunkIDs(ctx context.Context, filter map[string]any) ([]string, error)
+	// GetChunks returns the chunks of ids in order, ids not stored are skipped.
+	GetChunks(ctx context.Context, ids []string) ([]*schema.Document, error)
+	// GetNodes returns the nodes of keys in order, keys not stored are skipped.
+	GetNodes(ctx context.Context, keys []string) ([]*Node, error)
+	// MatchNodes returns at most limit nodes whose names occur in text, longer names first.
+	MatchNodes(ctx context.Context, text string, limit int) ([]*Node, error)
+	// GetEdges returns the edges with any of keys as source or target.
+	GetEdges(ctx context.Context, keys []string) ([]*Edge, error)
+}
+
+// NodeKey returns the key of the node of an entity name, which is the lower case name with spaces collapsed,
+// so that "Eino " and "eino" are the same node.
+func NodeKey(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// containsName reports whether text contains name at word boundaries, both normalized by NodeKey.
+// Scripts written without spaces, e.g. Chinese, match anywhere.
+func containsName(text, name string) bool {
+	if name == "" {
+		return false
+	}
+	for start := 0; ; {
+		idx := strings.Index(text[start:], name)
+		if idx < 0 {
+			return false
+		}
+		idx += start
+		end := idx + len(name)
+		if boundary(lastRune(text[:idx]), firstRune(name)) && boundary(firstRune(text[end:]), lastRune(name)) {
+			return true
+		}
+		_, size := utf8.DecodeRuneInString(text[idx:])
+		start = idx + size
+	}
+}
+
+// boundary reports whether a name edge c next to the text rune outer is a word boundary.
+func boundary(outer, c rune) bool {
+	return outer == 0 || !isWordRune(outer) || !isWordRune(c)
+}
+
+// isWordRune is a letter or digit of a script written with spaces between words.
+func isWordRune(c rune) bool {
+	if !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+		return false
+	}
+	return !unicode.In(c, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul, unicode.Thai)
+}
+
+func firstRune(s string) rune {
+	if s == "" {
+		return 0
+	}
+	c, _ := utf8.DecodeRuneInString(s)
+	return c
+}
+
+func lastRune(s string) rune {
+	if s == "" {
+		return 0
+	}
+	c, _ := utf8.DecodeLastRuneInString(s)
+	return c
+}
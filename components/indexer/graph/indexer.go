@@ -0,0 +1,161 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+)
+
+type IndexerConfig struct {
+	// Store keeps the graph, share it with the graph retriever, e.g. a MemoryStore.
+	// Required.
+	Store Store
+	// ParentKey is the metadata key of the parent document of a chunk, which Upsert replaces the chunks of.
+	// Default "_source", the source uri set by the file loader.
+	ParentKey string
+}
+
+// Indexer stores chunks with the entities and relations in their MetaKeyEntities and MetaKeyRelations metadata,
+// which the graph extractor writes, as nodes and edges of a knowledge graph.
+type Indexer struct {
+	config *IndexerConfig
+}
+
+func NewIndexer(_ context.Context, config *IndexerConfig) (*Indexer, error) {
+	if config.Store == nil {
+		return nil, fmt.Errorf("[NewIndexer] store not provided")
+	}
+
+	if config.ParentKey == "" {
+		config.ParentKey = defaultParentKey
+	}
+
+	return &Indexer{
+		config: config,
+	}, nil
+}
+
+// Store stores docs and their entities and relations, a doc without them is stored but can't be reached by the graph.
+// Storing a doc of an existing id replaces it and what it contributed to the graph.
+func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{Docs: docs})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	chunks, err := toChunks(docs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = i.config.Store.AddChunks(ctx, chunks); err != nil {
+		return nil, fmt.Errorf("[Store] add chunks failed, %w", err)
+	}
+
+	ids = docIDs(docs)
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{IDs: ids})
+
+	return ids, nil
+}
+
+func (i *Indexer) GetType() string {
+	return typ
+}
+
+func (i *Indexer) IsCallbacksEnabled() bool {
+	return true
+}
+
+// toChunks reads the entities and relations of docs from their metadata, relation endpoints which aren't
+// among the entities are added as entities with only a name. The stored documents don't carry them in metadata.
+func toChunks(docs []*schema.Document) ([]*Chunk, error) {
+	chunks := make([]*Chunk, 0, len(docs))
+	for idx, doc := range docs {
+		if doc == nil || doc.ID == "" {
+			return nil, fmt.Errorf("[toChunks] document id not provided, index=%d", idx)
+		}
+
+		c := &Chunk{Doc: &schema.Document{ID: doc.ID, Content: doc.Content, MetaData: make(map[string]any, len(doc.MetaData))}}
+		for k, v := range doc.MetaData {
+			if k != MetaKeyEntities && k != MetaKeyRelations {
+				c.Doc.MetaData[k] = v
+			}
+		}
+
+		if err := decodeMetadata(doc, MetaKeyEntities, &c.Entities); err != nil {
+			return nil, err
+		}
+		if err := decodeMetadata(doc, MetaKeyRelations, &c.Relations); err != nil {
+			return nil, err
+		}
+
+		names := make(map[string]struct{}, len(c.Entities))
+		for _, e := range c.Entities {
+			names[NodeKey(e.Name)] = struct{}{}
+		}
+		for _, r := range c.Relations {
+			for _, name := range []string{r.Source, r.Target} {
+				if _, found := names[NodeKey(name)]; !found {
+					names[NodeKey(name)] = struct{}{}
+					c.Entities = append(c.Entities, &Entity{Name: name})
+				}
+			}
+		}
+
+		chunks = append(chunks, c)
+	}
+
+	return chunks, nil
+}
+
+// decodeMetadata decodes the metadata value of key to target through json, so that typed values and
+// values restored from json, e.g. []any of map[string]any, are both accepted.
+func decodeMetadata(doc *schema.Document, key string, target any) error {
+	v, ok := doc.MetaData[key]
+	if !ok || v == nil {
+		return nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("[toChunks] marshal metadata %s failed, id=%s, %w", key, doc.ID, err)
+	}
+	if err = json.Unmarshal(b, target); err != nil {
+		return fmt.Errorf("[toChunks] invalid metadata %s, id=%s, %w", key, doc.ID, err)
+	}
+
+	return nil
+}
+
+func docIDs(docs []*schema.Document) []string {
+	ids := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+	return ids
+}
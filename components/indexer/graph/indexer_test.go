@@ -0,0 +1,186 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/indexer/mutation"
+)
+
+// extracted returns a doc carrying entities and relations in metadata as the graph extractor writes them.
+func extracted(id, source string, entities []map[string]any, relations []map[string]any) *schema.Document {
+	return &schema.Document{ID: id, Content: "content of " + id, MetaData: map[string]any{
+		"_source":        source,
+		MetaKeyEntities:  entities,
+		MetaKeyRelations: relations,
+	}}
+}
+
+// failingStore fails every call.
+type failingStore struct{ Store }
+
+func (f *failingStore) AddChunks(context.Context, []*Chunk) error { return errors.New("mock") }
+func (f *failingStore) DeleteChunks(context.Context, []string) (int64, error) {
+	return 0, errors.New("mock")
+}
+func (f *failingStore) ChunkIDs(context.Context, map[string]any) ([]string, error) {
+	return nil, errors.New("mock")
+}
+
+func TestIndexer(t *testing.T) {
+	convey.Convey("test graph indexer", t, func() {
+		ctx := context.Background()
+		s, _ := NewMemoryStore(nil)
+
+		_, err := NewIndexer(ctx, &IndexerConfig{})
+		convey.So(err, convey.ShouldNotBeNil)
+
+		i, err := NewIndexer(ctx, &IndexerConfig{Store: s})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(i.config.ParentKey, convey.ShouldEqual, defaultParentKey)
+		convey.So(i.GetType(), convey.ShouldEqual, typ)
+		convey.So(i.IsCallbacksEnabled(), convey.ShouldBeTrue)
+
+		docs := []*schema.Document{
+			extracted("a1", "a.md",
+				[]map[string]any{{"name": "Alice", "type": "person"}},
+				[]map[string]any{{"source": "Alice", "target": "Acme", "type": "works at"}}),
+			extracted("a2", "a.md", []map[string]any{{"name": "Acme", "type": "organization"}}, nil),
+			{ID: "b1", Content: "no graph", MetaData: map[string]any{"_source": "b.md"}},
+		}
+
+		var operation any
+		ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, callbacks.NewHandlerBuilder().
+			OnStartFn(func(ctx context.Context, info *callbacks.RunInfo, input callbacks.CallbackInput) context.Context {
+				operation = indexer.ConvCallbackInput(input).Extra[extraKeyOperation]
+				return ctx
+			}).Build())
+
+		ids, err := i.Store(ctx, docs)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(ids, convey.ShouldResemble, []string{"a1", "a2", "b1"})
+		convey.So(operation, convey.ShouldBeNil)
+		convey.So(s.Len(), convey.ShouldEqual, 3)
+		convey.So(s.NodeCount(), convey.ShouldEqual, 2)
+		convey.So(s.EdgeCount(), convey.ShouldEqual, 1)
+
+		// the relation endpoint acme is an entity of a1 as well
+		nodes, _ := s.GetNodes(ctx, []string{"acme"})
+		convey.So(nodes[0].ChunkIDs, convey.ShouldResemble, []string{"a1", "a2"})
+		convey.So(nodes[0].Type, convey.ShouldEqual, "organization")
+
+		// graph metadata isn't stored with the chunk
+		stored, _ := s.GetChunks(ctx, []string{"a1"})
+		convey.So(stored[0].MetaData, convey.ShouldResemble, map[string]any{"_source": "a.md"})
+
+		convey.Convey("test typed and json metadata", func() {
+			var restored []any
+			b, _ := json.Marshal([]*Entity{{Name: "Bob"}})
+			_ = json.Unmarshal(b, &restored)
+			_, err = i.Store(ctx, []*schema.Document{
+				{ID: "c1", MetaData: map[string]any{MetaKeyEntities: []*Entity{{Name: "Carol"}}}},
+				{ID: "c2", MetaData: map[string]any{MetaKeyEntities: restored}},
+			})
+			convey.So(err, convey.ShouldBeNil)
+			nodes, _ = s.GetNodes(ctx, []string{"carol", "bob"})
+			convey.So(len(nodes), convey.ShouldEqual, 2)
+
+			_, err = i.Store(ctx, []*schema.Document{{ID: "c3", MetaData: map[string]any{MetaKeyEntities: "Dave"}}})
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = i.Store(ctx, []*schema.Document{{ID: "c3", MetaData: map[string]any{MetaKeyRelations: func() {}}}})
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = i.Store(ctx, []*schema.Document{{Content: "no id"}})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test delete", func() {
+			deleted, err := i.Delete(ctx, []string{"a1", "missing"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(deleted, convey.ShouldEqual, 1)
+			convey.So(operation, convey.ShouldEqual, operationDelete)
+			convey.So(s.EdgeCount(), convey.ShouldEqual, 0)
+
+			deleted, err = i.DeleteByFilter(ctx, map[string]any{"_source": "a.md"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(deleted, convey.ShouldEqual, 1)
+			convey.So(s.NodeCount(), convey.ShouldEqual, 0)
+
+			deleted, err = i.DeleteByFilter(ctx, map[string]any{"_source": "a.md"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(deleted, convey.ShouldEqual, 0)
+
+			_, err = i.DeleteByFilter(ctx, nil)
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test upsert", func() {
+			ids, err := i.Upsert(ctx, []*schema.Document{
+				extracted("a1", "a.md", []map[string]any{{"name": "Alice"}, {"name": "Bob"}}, nil),
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids, convey.ShouldResemble, []string{"a1"})
+			convey.So(operation, convey.ShouldEqual, operationUpsert)
+			convey.So(s.Len(), convey.ShouldEqual, 2)
+			nodes, _ := s.GetNodes(ctx, []string{"alice", "bob", "acme"})
+			convey.So(len(nodes), convey.ShouldEqual, 2)
+			convey.So(s.EdgeCount(), convey.ShouldEqual, 0)
+
+			_, err = i.Upsert(ctx, []*schema.Document{{ID: "x"}})
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = i.Upsert(ctx, []*schema.Document{nil})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test store errors", func() {
+			i, _ = NewIndexer(ctx, &IndexerConfig{Store: &failingStore{Store: s}})
+			_, err = i.Store(ctx, docs)
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = i.Delete(ctx, []string{"a1"})
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = i.DeleteByFilter(ctx, map[string]any{"_source": "a.md"})
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = i.Upsert(ctx, docs)
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+var (
+	_ mutation.Deleter  = (*Indexer)(nil)
+	_ mutation.Upserter = (*Indexer)(nil)
+)
+
+func TestMutationConsts(t *testing.T) {
+	convey.Convey("test callback extra keys and operations same as mutation", t, func() {
+		convey.So(extraKeyOperation, convey.ShouldEqual, mutation.ExtraKeyOperation)
+		convey.So(extraKeyIDs, convey.ShouldEqual, mutation.ExtraKeyIDs)
+		convey.So(extraKeyFilter, convey.ShouldEqual, mutation.ExtraKeyFilter)
+		convey.So(extraKeyDeleted, convey.ShouldEqual, mutation.ExtraKeyDeleted)
+		convey.So(operationDelete, convey.ShouldEqual, mutation.OperationDelete)
+		convey.So(operationUpsert, convey.ShouldEqual, mutation.OperationUpsert)
+		convey.So(defaultParentKey, convey.ShouldEqual, mutation.MetaKeyParent)
+	})
+}
@@ -0,0 +1,459 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+type MemoryStoreConfig struct {
+	// Path of the snapshot file. If set, NewMemoryStore loads the file if it exists,
+	// and every change is saved to it before AddChunks and DeleteChunks return.
+	// Default empty, the graph is only kept in memory, call Save and Load to persist it.
+	Path string
+}
+
+// MemoryStore is an in-memory Store. It's safe for concurrent use.
+type MemoryStore struct {
+	path string
+
+	mu     sync.RWMutex
+	chunks map[string]*memChunk
+	nodes  map[string]*memNode
+	edges  map[string]*memEdge
+	// adjacency maps a node key to the keys of its edges
+	adjacency map[string]map[string]struct{}
+}
+
+type memChunk struct {
+	doc       *schema.Document
+	entities  []*Entity
+	relations []*Relation
+	nodeKeys  []string
+	edgeKeys  []string
+}
+
+// memNode keeps the entity contributed by each chunk, so deleting a chunk removes exactly its contribution.
+type memNode struct {
+	key      string
+	contribs map[string]*Entity
+}
+
+type memEdge struct {
+	source, target string
+	contribs       map[string]*Relation
+}
+
+func NewMemoryStore(config *MemoryStoreConfig) (*MemoryStore, error) {
+	if config == nil {
+		config = &MemoryStoreConfig{}
+	}
+
+	s := &MemoryStore{path: config.Path}
+	s.reset()
+
+	if config.Path != "" {
+		if err := s.Load(config.Path); err != nil && !isNotExist(err) {
+			return nil, fmt.Errorf("[NewMemoryStore] %w", err)
+		}
+	}
+
+	return s, nil
+}
+
+// reset empties the store, requires the write lock.
+func (s *MemoryStore) reset() {
+	s.chunks = make(map[string]*memChunk)
+	s.nodes = make(map[string]*memNode)
+	s.edges = make(map[string]*memEdge)
+	s.adjacency = make(map[string]map[string]struct{})
+}
+
+// Len returns the number of stored chunks.
+func (s *MemoryStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.chunks)
+}
+
+// NodeCount returns the number of nodes.
+func (s *MemoryStore) NodeCount() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.nodes)
+}
+
+// EdgeCount returns the number of edges.
+func (s *MemoryStore) EdgeCount() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.edges)
+}
+
+func (s *MemoryStore) AddChunks(_ context.Context, chunks []*Chunk) error {
+	for i, c := range chunks {
+		if c == nil || c.Doc == nil || c.Doc.ID == "" {
+			return fmt.Errorf("[MemoryStore] chunk without document id, index=%d", i)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, c := range chunks {
+		s.remove(c.Doc.ID)
+		s.add(c)
+	}
+
+	return s.persist()
+}
+
+func (s *MemoryStore) DeleteChunks(_ context.Context, ids []string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deleted int64
+	for _, id := range ids {
+		if s.remove(id) {
+			deleted++
+		}
+	}
+
+	if deleted == 0 {
+		return 0, nil
+	}
+	return deleted, s.persist()
+}
+
+// ChunkIDs returns the sorted ids of the chunks whose metadata equals every pair of filter,
+// numbers are compared by value regardless of their type.
+func (s *MemoryStore) ChunkIDs(_ context.Context, filter map[string]any) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var ids []string
+	for id, c := range s.chunks {
+		if matchMetadata(c.doc, filter) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+// GetChunks returns copies of the chunks of ids, their metadata is copied as well.
+func (s *MemoryStore) GetChunks(_ context.Context, ids []string) ([]*schema.Document, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	docs := make([]*schema.Document, 0, len(ids))
+	for _, id := range ids {
+		if c, ok := s.chunks[id]; ok {
+			docs = append(docs, copyDocument(c.doc))
+		}
+	}
+
+	return docs, nil
+}
+
+// GetNodes returns the nodes of keys, which may also be entity names, as they are normalized by NodeKey.
+func (s *MemoryStore) GetNodes(_ context.Context, keys []string) ([]*Node, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	nodes := make([]*Node, 0, len(keys))
+	for _, key := range keys {
+		if n, ok := s.nodes[NodeKey(key)]; ok {
+			nodes = append(nodes, n.node())
+		}
+	}
+
+	return nodes, nil
+}
+
+// MatchNodes scans the names of all nodes, text and names are compared by NodeKey at word boundaries,
+// e.g. "Who founded Acme Corp?" matches the node "acme corp" and "acme", but not "acm". limit <= 0 returns all matches.
+func (s *MemoryStore) MatchNodes(_ context.Context, text string, limit int) ([]*Node, error) {
+	text = NodeKey(text)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var keys []string
+	for key := range s.nodes {
+		if containsName(text, key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	if limit > 0 && len(keys) > limit {
+		keys = keys[:limit]
+	}
+
+	nodes := make([]*Node, 0, len(keys))
+	for _, key := range keys {
+		nodes = append(nodes, s.nodes[key].node())
+	}
+
+	return nodes, nil
+}
+
+// GetEdges returns the edges of keys sorted by source, type and target.
+func (s *MemoryStore) GetEdges(_ context.Context, keys []string) ([]*Edge, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	seen := make(map[string]struct{})
+	var edgeKeys []string
+	for _, key := range keys {
+		for ek := range s.adjacency[NodeKey(key)] {
+			if _, found := seen[ek]; !found {
+				seen[ek] = struct{}{}
+				edgeKeys = append(edgeKeys, ek)
+			}
+		}
+	}
+	sort.Strings(edgeKeys)
+
+	edges := make([]*Edge, 0, len(edgeKeys))
+	for _, ek := range edgeKeys {
+		edges = append(edges, s.edges[ek].edge())
+	}
+
+	return edges, nil
+}
+
+// add stores chunk c, requires the write lock and c not stored.
+func (s *MemoryStore) add(c *Chunk) {
+	id := c.Doc.ID
+	mc := &memChunk{doc: copyDocument(c.Doc), entities: c.Entities, relations: c.Relations}
+	s.chunks[id] = mc
+
+	addNode := func(e *Entity) string {
+		key := NodeKey(e.Name)
+		if key == "" {
+			return ""
+		}
+		n, ok := s.nodes[key]
+		if !ok {
+			n = &memNode{key: key, contribs: make(map[string]*Entity)}
+			s.nodes[key] = n
+		}
+		if _, found := n.contribs[id]; !found {
+			n.contribs[id] = e
+			mc.nodeKeys = append(mc.nodeKeys, key)
+		}
+		return key
+	}
+
+	for _, e := range c.Entities {
+		if e != nil {
+			addNode(e)
+		}
+	}
+
+	for _, r := range c.Relations {
+		if r == nil {
+			continue
+		}
+		source, target := addNode(&Entity{Name: r.Source}), addNode(&Entity{Name: r.Target})
+		if source == "" || target == "" || source == target {
+			continue
+		}
+		ek := edgeKey(source, r.Type, target)
+		e, ok := s.edges[ek]
+		if !ok {
+			e = &memEdge{source: source, target: target, contribs: make(map[string]*Relation)}
+			s.edges[ek] = e
+			s.link(source, ek)
+			s.link(target, ek)
+		}
+		if _, found := e.contribs[id]; !found {
+			e.contribs[id] = r
+			mc.edgeKeys = append(mc.edgeKeys, ek)
+		}
+	}
+}
+
+// remove deletes the chunk of id and its contributions, requires the write lock.
+func (s *MemoryStore) remove(id string) bool {
+	mc, ok := s.chunks[id]
+	if !ok {
+		return false
+	}
+	delete(s.chunks, id)
+
+	for _, ek := range mc.edgeKeys {
+		e := s.edges[ek]
+		delete(e.contribs, id)
+		if len(e.contribs) == 0 {
+			delete(s.edges, ek)
+			s.unlink(e.source, ek)
+			s.unlink(e.target, ek)
+		}
+	}
+
+	for _, key := range mc.nodeKeys {
+		n := s.nodes[key]
+		delete(n.contribs, id)
+		if len(n.contribs) == 0 {
+			delete(s.nodes, key)
+		}
+	}
+
+	return true
+}
+
+func (s *MemoryStore) link(key, ek string) {
+	edges, ok := s.adjacency[key]
+	if !ok {
+		edges = make(map[string]struct{})
+		s.adjacency[key] = edges
+	}
+	edges[ek] = struct{}{}
+}
+
+func (s *MemoryStore) unlink(key, ek string) {
+	delete(s.adjacency[key], ek)
+	if len(s.adjacency[key]) == 0 {
+		delete(s.adjacency, key)
+	}
+}
+
+// persist saves a snapshot to path if set, requires the lock.
+func (s *MemoryStore) persist() error {
+	if s.path == "" {
+		return nil
+	}
+
+	b, err := s.marshal()
+	if err != nil {
+		return err
+	}
+	return writeFile(s.path, b)
+}
+
+// node merges the contributions of the chunks in order of their ids, the first name and type win.
+func (n *memNode) node() *Node {
+	node := &Node{Key: n.key, ChunkIDs: sortedKeys(n.contribs)}
+	for _, id := range node.ChunkIDs {
+		e := n.contribs[id]
+		if node.Name == "" {
+			node.Name = strings.TrimSpace(e.Name)
+		}
+		if node.Type == "" {
+			node.Type = strings.TrimSpace(e.Type)
+		}
+		node.Descriptions = appendDistinct(node.Descriptions, e.Description)
+	}
+	return node
+}
+
+func (e *memEdge) edge() *Edge {
+	edge := &Edge{Source: e.source, Target: e.target, ChunkIDs: sortedKeys(e.contribs)}
+	for _, id := range edge.ChunkIDs {
+		r := e.contribs[id]
+		if edge.Type == "" {
+			edge.Type = strings.TrimSpace(r.Type)
+		}
+		edge.Descriptions = appendDistinct(edge.Descriptions, r.Description)
+	}
+	return edge
+}
+
+// edgeKey identifies an edge by its endpoints and normalized type, e.g. "alice\x00works at\x00acme".
+func edgeKey(source, typ, target string) string {
+	return source + "\x00" + NodeKey(typ) + "\x00" + target
+}
+
+func appendDistinct(list []string, s string) []string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return list
+	}
+	for _, v := range list {
+		if v == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// copyDocument copies doc and its metadata, so that callers can't change the stored document.
+func copyDocument(doc *schema.Document) *schema.Document {
+	metadata := make(map[string]any, len(doc.MetaData))
+	for k, v := range doc.MetaData {
+		metadata[k] = v
+	}
+	return &schema.Document{ID: doc.ID, Content: doc.Content, MetaData: metadata}
+}
+
+// matchMetadata reports whether the metadata of doc equals every key - value pair of filter.
+func matchMetadata(doc *schema.Document, filter map[string]any) bool {
+	for k, v := range filter {
+		actual, ok := doc.MetaData[k]
+		if !ok || !valueEqual(actual, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// valueEqual compares numbers by value, as metadata restored from a snapshot holds float64 numbers.
+func valueEqual(a, b any) bool {
+	if fa, ok := toFloat(a); ok {
+		fb, ok := toFloat(b)
+		return ok && fa == fb
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func toFloat(v any) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,193 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func testChunks() []*Chunk {
+	return []*Chunk{
+		{
+			Doc: &schema.Document{ID: "c1", Content: "Alice works at Acme Corp.", MetaData: map[string]any{"_source": "a.md"}},
+			Entities: []*Entity{
+				{Name: "Alice", Type: "person", Description: "an engineer"},
+				{Name: "Acme Corp", Type: "organization"},
+			},
+			Relations: []*Relation{{Source: "Alice", Target: "Acme Corp", Type: "works at", Description: "since 2020"}},
+		},
+		{
+			Doc: &schema.Document{ID: "c2", Content: "Acme Corp is based in Berlin.", MetaData: map[string]any{"_source": "b.md", "page": 1}},
+			Entities: []*Entity{
+				{Name: "acme  corp", Type: "company", Description: "a robot maker"},
+				{Name: "Berlin", Type: "location"},
+			},
+			Relations: []*Relation{{Source: "Acme Corp", Target: "Berlin", Type: "based in"}},
+		},
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	convey.Convey("test MemoryStore", t, func() {
+		ctx := context.Background()
+		s, err := NewMemoryStore(nil)
+		convey.So(err, convey.ShouldBeNil)
+
+		convey.So(s.AddChunks(ctx, []*Chunk{{}}), convey.ShouldNotBeNil)
+		convey.So(s.AddChunks(ctx, testChunks()), convey.ShouldBeNil)
+		convey.So(s.Len(), convey.ShouldEqual, 2)
+		convey.So(s.NodeCount(), convey.ShouldEqual, 3)
+		convey.So(s.EdgeCount(), convey.ShouldEqual, 2)
+
+		convey.Convey("test nodes are merged", func() {
+			nodes, err := s.GetNodes(ctx, []string{"ACME Corp", "missing", "alice"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(nodes, convey.ShouldResemble, []*Node{
+				{Key: "acme corp", Name: "Acme Corp", Type: "organization", Descriptions: []string{"a robot maker"}, ChunkIDs: []string{"c1", "c2"}},
+				{Key: "alice", Name: "Alice", Type: "person", Descriptions: []string{"an engineer"}, ChunkIDs: []string{"c1"}},
+			})
+
+			edges, err := s.GetEdges(ctx, []string{"acme corp"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(edges, convey.ShouldResemble, []*Edge{
+				{Source: "acme corp", Target: "berlin", Type: "based in", ChunkIDs: []string{"c2"}},
+				{Source: "alice", Target: "acme corp", Type: "works at", Descriptions: []string{"since 2020"}, ChunkIDs: []string{"c1"}},
+			})
+
+			docs, err := s.GetChunks(ctx, []string{"c2", "missing", "c1"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(len(docs), convey.ShouldEqual, 2)
+			convey.So(docs[0].ID, convey.ShouldEqual, "c2")
+			docs[0].MetaData["page"] = 2
+			docs, _ = s.GetChunks(ctx, []string{"c2"})
+			convey.So(docs[0].MetaData["page"], convey.ShouldEqual, 1)
+
+			ids, err := s.ChunkIDs(ctx, map[string]any{"page": 1.0})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids, convey.ShouldResemble, []string{"c2"})
+			ids, _ = s.ChunkIDs(ctx, map[string]any{"_source": "a.md", "page": 1})
+			convey.So(ids, convey.ShouldBeEmpty)
+		})
+
+		convey.Convey("test match nodes", func() {
+			nodes, err := s.MatchNodes(ctx, "Where is ACME corp located?", 0)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(len(nodes), convey.ShouldEqual, 1)
+			convey.So(nodes[0].Key, convey.ShouldEqual, "acme corp")
+
+			nodes, _ = s.MatchNodes(ctx, "alice, berlin and acme corp", 2)
+			convey.So(len(nodes), convey.ShouldEqual, 2)
+			convey.So(nodes[0].Key, convey.ShouldEqual, "acme corp")
+			convey.So(nodes[1].Key, convey.ShouldEqual, "berlin")
+
+			// not at word boundaries
+			nodes, _ = s.MatchNodes(ctx, "malice in berliner acme corporation", 0)
+			convey.So(nodes, convey.ShouldBeEmpty)
+
+			convey.So(s.AddChunks(ctx, []*Chunk{{Doc: &schema.Document{ID: "c3"}, Entities: []*Entity{{Name: "北京"}, {Name: " "}}}}), convey.ShouldBeNil)
+			nodes, _ = s.MatchNodes(ctx, "北京的天气", 0)
+			convey.So(len(nodes), convey.ShouldEqual, 1)
+		})
+
+		convey.Convey("test replace and delete", func() {
+			// c2 no longer mentions berlin
+			convey.So(s.AddChunks(ctx, []*Chunk{{Doc: &schema.Document{ID: "c2"}, Entities: []*Entity{{Name: "Acme Corp"}}}}), convey.ShouldBeNil)
+			convey.So(s.NodeCount(), convey.ShouldEqual, 2)
+			convey.So(s.EdgeCount(), convey.ShouldEqual, 1)
+
+			deleted, err := s.DeleteChunks(ctx, []string{"c1", "missing"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(deleted, convey.ShouldEqual, 1)
+			convey.So(s.NodeCount(), convey.ShouldEqual, 1)
+			convey.So(s.EdgeCount(), convey.ShouldEqual, 0)
+			edges, _ := s.GetEdges(ctx, []string{"acme corp", "alice"})
+			convey.So(edges, convey.ShouldBeEmpty)
+
+			deleted, err = s.DeleteChunks(ctx, []string{"c1"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(deleted, convey.ShouldEqual, 0)
+		})
+
+		convey.Convey("test self relations and empty names are skipped", func() {
+			convey.So(s.AddChunks(ctx, []*Chunk{{
+				Doc:       &schema.Document{ID: "c3"},
+				Relations: []*Relation{{Source: "Bob", Target: "bob", Type: "is"}, {Source: "", Target: "Bob", Type: "knows"}},
+			}}), convey.ShouldBeNil)
+			convey.So(s.NodeCount(), convey.ShouldEqual, 4)
+			convey.So(s.EdgeCount(), convey.ShouldEqual, 2)
+		})
+	})
+}
+
+func TestMemoryStoreSnapshot(t *testing.T) {
+	convey.Convey("test MemoryStore snapshot", t, func() {
+		ctx := context.Background()
+		path := filepath.Join(t.TempDir(), "graph.json")
+
+		s, err := NewMemoryStore(&MemoryStoreConfig{Path: path})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(s.AddChunks(ctx, testChunks()), convey.ShouldBeNil)
+
+		// saved by AddChunks, loaded by NewMemoryStore
+		loaded, err := NewMemoryStore(&MemoryStoreConfig{Path: path})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(loaded.Len(), convey.ShouldEqual, 2)
+		convey.So(loaded.NodeCount(), convey.ShouldEqual, 3)
+		convey.So(loaded.EdgeCount(), convey.ShouldEqual, 2)
+		nodes, _ := loaded.GetNodes(ctx, []string{"acme corp"})
+		convey.So(nodes[0].ChunkIDs, convey.ShouldResemble, []string{"c1", "c2"})
+
+		_, err = s.DeleteChunks(ctx, []string{"c1"})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(loaded.Load(path), convey.ShouldBeNil)
+		convey.So(loaded.Len(), convey.ShouldEqual, 1)
+
+		var buf bytes.Buffer
+		_, err = s.WriteTo(&buf)
+		convey.So(err, convey.ShouldBeNil)
+		other, _ := NewMemoryStore(nil)
+		_, err = other.ReadFrom(&buf)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(other.EdgeCount(), convey.ShouldEqual, 1)
+
+		other2 := filepath.Join(t.TempDir(), "other.json")
+		convey.So(other.Save(other2), convey.ShouldBeNil)
+		convey.So(s.Load(other2), convey.ShouldBeNil)
+
+		_, err = other.ReadFrom(bytes.NewBufferString(`{"version": 2}`))
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = other.ReadFrom(bytes.NewBufferString(`{`))
+		convey.So(err, convey.ShouldNotBeNil)
+		convey.So(other.Load(filepath.Join(t.TempDir(), "missing.json")), convey.ShouldNotBeNil)
+
+		convey.So(os.WriteFile(path, []byte("{"), 0o600), convey.ShouldBeNil)
+		_, err = NewMemoryStore(&MemoryStoreConfig{Path: path})
+		convey.So(err, convey.ShouldNotBeNil)
+
+		// the directory of the file doesn't exist
+		s, err = NewMemoryStore(&MemoryStoreConfig{Path: filepath.Join(t.TempDir(), "missing", "graph.json")})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(s.AddChunks(ctx, testChunks()), convey.ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,160 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Delete deletes the chunks of ids, and the nodes and edges only they contributed to.
+// It implements mutation.Deleter of github.com/cloudwego/eino-ext/components/indexer/mutation.
+func (i *Indexer) Delete(ctx context.Context, ids []string, opts ...indexer.Option) (deleted int64, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{
+		Extra: map[string]any{extraKeyOperation: operationDelete, extraKeyIDs: ids},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	deleted, err = i.config.Store.DeleteChunks(ctx, ids)
+	if err != nil {
+		return 0, fmt.Errorf("[Delete] delete chunks failed, %w", err)
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{Extra: map[string]any{extraKeyDeleted: deleted}})
+
+	return deleted, nil
+}
+
+// DeleteByFilter deletes the chunks whose metadata equals every key - value pair of filter, as matched by Store.ChunkIDs.
+func (i *Indexer) DeleteByFilter(ctx context.Context, filter map[string]any, opts ...indexer.Option) (deleted int64, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{
+		Extra: map[string]any{extraKeyOperation: operationDelete, extraKeyFilter: filter},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	if len(filter) == 0 {
+		return 0, fmt.Errorf("[DeleteByFilter] empty filter")
+	}
+
+	ids, err := i.config.Store.ChunkIDs(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("[DeleteByFilter] find chunks failed, %w", err)
+	}
+
+	if len(ids) > 0 {
+		if deleted, err = i.config.Store.DeleteChunks(ctx, ids); err != nil {
+			return 0, fmt.Errorf("[DeleteByFilter] delete chunks failed, %w", err)
+		}
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{Extra: map[string]any{extraKeyDeleted: deleted}})
+
+	return deleted, nil
+}
+
+// Upsert stores docs, then deletes the other chunks of their parents, the parent of a doc is its ParentKey metadata.
+// Store has no transactions, so the new chunks are written before the stale ones are deleted.
+// It implements mutation.Upserter of github.com/cloudwego/eino-ext/components/indexer/mutation.
+func (i *Indexer) Upsert(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{
+		Docs:  docs,
+		Extra: map[string]any{extraKeyOperation: operationUpsert},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	var parents []any
+	for _, doc := range docs {
+		if doc == nil {
+			continue
+		}
+		parent, ok := doc.MetaData[i.config.ParentKey]
+		if !ok || parent == nil {
+			return nil, fmt.Errorf("[Upsert] parent metadata %q not found in document, id=%s", i.config.ParentKey, doc.ID)
+		}
+		if !containsValue(parents, parent) {
+			parents = append(parents, parent)
+		}
+	}
+
+	chunks, err := toChunks(docs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = i.config.Store.AddChunks(ctx, chunks); err != nil {
+		return nil, fmt.Errorf("[Upsert] add chunks failed, %w", err)
+	}
+
+	ids = docIDs(docs)
+	keep := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		keep[id] = struct{}{}
+	}
+
+	var stale []string
+	for _, parent := range parents {
+		parentIDs, err := i.config.Store.ChunkIDs(ctx, map[string]any{i.config.ParentKey: parent})
+		if err != nil {
+			return nil, fmt.Errorf("[Upsert] find chunks of parent %v failed, %w", parent, err)
+		}
+		for _, id := range parentIDs {
+			if _, found := keep[id]; !found {
+				stale = append(stale, id)
+			}
+		}
+	}
+
+	var deleted int64
+	if len(stale) > 0 {
+		if deleted, err = i.config.Store.DeleteChunks(ctx, stale); err != nil {
+			return nil, fmt.Errorf("[Upsert] delete stale chunks failed, %w", err)
+		}
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{IDs: ids, Extra: map[string]any{extraKeyDeleted: deleted}})
+
+	return ids, nil
+}
+
+func containsValue(values []any, v any) bool {
+	for _, value := range values {
+		if valueEqual(value, v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,161 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+type snapshot struct {
+	Version int              `json:"version"`
+	Chunks  []*snapshotChunk `json:"chunks"`
+}
+
+type snapshotChunk struct {
+	ID        string         `json:"id"`
+	Content   string         `json:"content"`
+	MetaData  map[string]any `json:"metadata,omitempty"`
+	Entities  []*Entity      `json:"entities,omitempty"`
+	Relations []*Relation    `json:"relations,omitempty"`
+}
+
+// marshal returns a json snapshot of the chunks with their entities and relations, requires the lock.
+func (s *MemoryStore) marshal() ([]byte, error) {
+	snap := &snapshot{Version: snapshotVersion, Chunks: make([]*snapshotChunk, 0, len(s.chunks))}
+	for _, id := range sortedKeys(s.chunks) {
+		c := s.chunks[id]
+		snap.Chunks = append(snap.Chunks, &snapshotChunk{
+			ID:        id,
+			Content:   c.doc.Content,
+			MetaData:  c.doc.MetaData,
+			Entities:  c.entities,
+			Relations: c.relations,
+		})
+	}
+
+	b, err := json.Marshal(snap)
+	if err != nil {
+		return nil, fmt.Errorf("[MemoryStore] marshal snapshot failed, %w", err)
+	}
+	return b, nil
+}
+
+// WriteTo writes a json snapshot of the chunks and their entities and relations to w, the graph is rebuilt from them on read.
+// Metadata values must be json serializable, and are restored as json values, e.g. numbers as float64.
+func (s *MemoryStore) WriteTo(w io.Writer) (int64, error) {
+	s.mu.RLock()
+	b, err := s.marshal()
+	s.mu.RUnlock()
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// ReadFrom replaces the graph of the store with the snapshot read from r.
+func (s *MemoryStore) ReadFrom(r io.Reader) (int64, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return int64(len(b)), err
+	}
+	snap := &snapshot{}
+	if err = json.Unmarshal(b, snap); err != nil {
+		return int64(len(b)), fmt.Errorf("[MemoryStore] unmarshal snapshot failed, %w", err)
+	}
+	if snap.Version != snapshotVersion {
+		return int64(len(b)), fmt.Errorf("[MemoryStore] unsupported snapshot version: %d", snap.Version)
+	}
+
+	restored := &MemoryStore{}
+	restored.reset()
+	for _, c := range snap.Chunks {
+		restored.add(&Chunk{
+			Doc:       &schema.Document{ID: c.ID, Content: c.Content, MetaData: c.MetaData},
+			Entities:  c.Entities,
+			Relations: c.Relations,
+		})
+	}
+
+	s.mu.Lock()
+	s.chunks, s.nodes, s.edges, s.adjacency = restored.chunks, restored.nodes, restored.edges, restored.adjacency
+	s.mu.Unlock()
+
+	return int64(len(b)), nil
+}
+
+// Save writes a snapshot to the file of path, through a temporary file renamed on success,
+// so that a failed save doesn't corrupt the previous snapshot.
+func (s *MemoryStore) Save(path string) error {
+	s.mu.RLock()
+	b, err := s.marshal()
+	s.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	return writeFile(path, b)
+}
+
+// Load replaces the graph of the store with the snapshot in the file of path.
+func (s *MemoryStore) Load(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("[MemoryStore] open snapshot file failed, %w", err)
+	}
+	defer f.Close()
+
+	_, err = s.ReadFrom(f)
+	return err
+}
+
+func writeFile(path string, b []byte) (err error) {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("[MemoryStore] create snapshot file failed, %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+		}
+	}()
+
+	if _, err = f.Write(b); err != nil {
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+func isNotExist(err error) bool {
+	return errors.Is(err, fs.ErrNotExist)
+}
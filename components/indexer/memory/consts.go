@@ -0,0 +1,53 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+const typ = "Memory"
+
+func GetType() string {
+	return typ
+}
+
+const (
+	defaultHNSWM              = 16
+	defaultHNSWEfConstruction = 200
+	defaultHNSWEfSearch       = 64
+	defaultHNSWSeed           = 1
+
+	// minCompactDeleted is the least number of removed nodes to rebuild the hnsw graph.
+	minCompactDeleted = 64
+
+	defaultBatchSize = 10
+
+	// defaultParentKey matches mutation.MetaKeyParent of github.com/cloudwego/eino-ext/components/indexer/mutation.
+	defaultParentKey = "_source"
+
+	// snapshotVersion is the version of the snapshot format.
+	snapshotVersion = 1
+)
+
+// callback extra keys and operations, same as the ones of github.com/cloudwego/eino-ext/components/indexer/mutation,
+// checked by TestMutationConsts.
+const (
+	extraKeyOperation = "operation"
+	extraKeyIDs       = "ids"
+	extraKeyFilter    = "filter"
+	extraKeyDeleted   = "deleted"
+
+	operationDelete = "delete"
+	operationUpsert = "upsert"
+)
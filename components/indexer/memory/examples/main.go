@@ -0,0 +1,101 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/indexer/memory"
+)
+
+func main() {
+	ctx := context.Background()
+
+	store, err := memory.NewStore(&memory.StoreConfig{
+		Metric: memory.MetricCosine,
+		Index:  memory.IndexHNSW,
+	})
+	if err != nil {
+		log.Fatalf("NewStore failed, err=%v", err)
+	}
+
+	idx, err := memory.NewIndexer(ctx, &memory.IndexerConfig{
+		Store:     store,
+		Embedding: &letterEmbedding{}, // replace with a real embedder, e.g. ark or openai
+	})
+	if err != nil {
+		log.Fatalf("NewIndexer failed, err=%v", err)
+	}
+
+	ids, err := idx.Store(ctx, []*schema.Document{
+		{ID: "intro_1", Content: "eino is a llm application framework", MetaData: map[string]any{"_source": "intro.md"}},
+		{ID: "intro_2", Content: "eino components are composed by graphs", MetaData: map[string]any{"_source": "intro.md"}},
+		{ID: "faq_1", Content: "how to install eino", MetaData: map[string]any{"_source": "faq.md"}},
+	})
+	if err != nil {
+		log.Fatalf("Store failed, err=%v", err)
+	}
+	log.Printf("stored: %v, len=%d", ids, store.Len())
+
+	// replace every chunk of intro.md
+	ids, err = idx.Upsert(ctx, []*schema.Document{
+		{ID: "intro_1", Content: "eino is a go llm application framework", MetaData: map[string]any{"_source": "intro.md"}},
+	})
+	if err != nil {
+		log.Fatalf("Upsert failed, err=%v", err)
+	}
+	log.Printf("upserted: %v, len=%d", ids, store.Len())
+
+	// persist the store, and restore it in another process with Load
+	path := filepath.Join(os.TempDir(), "eino_memory_store.json")
+	if err = store.Save(path); err != nil {
+		log.Fatalf("Save failed, err=%v", err)
+	}
+	defer os.Remove(path)
+
+	restored, err := memory.NewStore(&memory.StoreConfig{Metric: memory.MetricCosine, Index: memory.IndexHNSW})
+	if err != nil {
+		log.Fatalf("NewStore failed, err=%v", err)
+	}
+	if err = restored.Load(path); err != nil {
+		log.Fatalf("Load failed, err=%v", err)
+	}
+	log.Printf("restored: len=%d", restored.Len())
+}
+
+// letterEmbedding embeds a text to its letter frequencies, only for the example.
+type letterEmbedding struct{}
+
+func (l *letterEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = make([]float64, 26)
+		for _, c := range strings.ToLower(text) {
+			if c >= 'a' && c <= 'z' {
+				vectors[i][c-'a']++
+			}
+		}
+	}
+	return vectors, nil
+}
@@ -0,0 +1,298 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"container/heap"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// hnsw is a hierarchical navigable small world graph, see https://arxiv.org/abs/1603.09320.
+// Removed nodes are kept for navigation and skipped in results, Store rebuilds the graph when they outnumber live ones.
+type hnsw struct {
+	m, m0          int
+	efConstruction int
+	levelMult      float64
+	rnd            *rand.Rand
+	distance       func(a, b *entry) float64
+
+	nodes      []*hnswNode
+	index      map[*entry]int
+	entryPoint int
+	maxLevel   int
+	deleted    int
+}
+
+type hnswNode struct {
+	entry *entry
+	// links of the node on each level, from 0 to the level of the node
+	links   [][]int
+	deleted bool
+}
+
+func newHNSW(config *HNSWConfig, distance func(a, b *entry) float64) *hnsw {
+	return &hnsw{
+		m:              config.M,
+		m0:             2 * config.M,
+		efConstruction: config.EfConstruction,
+		levelMult:      1 / math.Log(float64(config.M)),
+		rnd:            rand.New(rand.NewSource(config.Seed)),
+		distance:       distance,
+		index:          make(map[*entry]int),
+		entryPoint:     -1,
+	}
+}
+
+func (h *hnsw) insert(e *entry) {
+	level := int(math.Floor(-math.Log(1-h.rnd.Float64()) * h.levelMult))
+	id := len(h.nodes)
+	node := &hnswNode{entry: e, links: make([][]int, level+1)}
+	h.nodes = append(h.nodes, node)
+	h.index[e] = id
+
+	if h.entryPoint < 0 {
+		h.entryPoint, h.maxLevel = id, level
+		return
+	}
+
+	ep := candidate{entry: h.nodes[h.entryPoint].entry, id: h.entryPoint}
+	ep.dist = h.distance(e, ep.entry)
+	for l := h.maxLevel; l > level; l-- {
+		ep = h.greedy(e, ep, l)
+	}
+
+	eps := []candidate{ep}
+	for l := min(level, h.maxLevel); l >= 0; l-- {
+		found := h.searchLayer(e, eps, h.efConstruction, l, nil)
+		neighbors := h.selectNeighbors(found, h.m)
+		for _, nb := range neighbors {
+			node.links[l] = append(node.links[l], nb.id)
+			h.link(nb.id, id, l)
+		}
+		eps = found
+	}
+
+	if level > h.maxLevel {
+		h.entryPoint, h.maxLevel = id, level
+	}
+}
+
+// link adds a link from node from to node to on level l, pruning the links of from if it has too many.
+func (h *hnsw) link(from, to, l int) {
+	node := h.nodes[from]
+	node.links[l] = append(node.links[l], to)
+
+	limit := h.m
+	if l == 0 {
+		limit = h.m0
+	}
+	if len(node.links[l]) <= limit {
+		return
+	}
+
+	candidates := make([]candidate, 0, len(node.links[l]))
+	for _, id := range node.links[l] {
+		nb := h.nodes[id]
+		candidates = append(candidates, candidate{entry: nb.entry, id: id, dist: h.distance(node.entry, nb.entry)})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].less(candidates[j])
+	})
+	kept := h.selectNeighbors(candidates, limit)
+	node.links[l] = node.links[l][:0]
+	for _, c := range kept {
+		node.links[l] = append(node.links[l], c.id)
+	}
+}
+
+// selectNeighbors picks up to m of candidates sorted by distance, preferring ones which aren't closer to
+// an already picked neighbor than to the base node, so that links spread in different directions.
+func (h *hnsw) selectNeighbors(candidates []candidate, m int) []candidate {
+	if len(candidates) <= m {
+		return candidates
+	}
+	selected := make([]candidate, 0, m)
+	var pruned []candidate
+	for _, c := range candidates {
+		if len(selected) == m {
+			break
+		}
+		good := true
+		for _, s := range selected {
+			if h.distance(c.entry, s.entry) < c.dist {
+				good = false
+				break
+			}
+		}
+		if good {
+			selected = append(selected, c)
+		} else {
+			pruned = append(pruned, c)
+		}
+	}
+	for _, c := range pruned {
+		if len(selected) == m {
+			break
+		}
+		selected = append(selected, c)
+	}
+	return selected
+}
+
+// greedy moves from ep to the closest node to q on level l.
+func (h *hnsw) greedy(q *entry, ep candidate, l int) candidate {
+	for changed := true; changed; {
+		changed = false
+		for _, id := range h.nodes[ep.id].links[l] {
+			nb := h.nodes[id]
+			if d := h.distance(q, nb.entry); d < ep.dist {
+				ep, changed = candidate{entry: nb.entry, id: id, dist: d}, true
+			}
+		}
+	}
+	return ep
+}
+
+// searchLayer returns up to ef nodes closest to q on level l, sorted by distance.
+// With accept set, only live nodes accepted are returned, while all nodes are traversed.
+func (h *hnsw) searchLayer(q *entry, eps []candidate, ef, l int, accept func(*hnswNode) bool) []candidate {
+	visited := make(map[int]struct{}, ef*4)
+	candidates := &minHeap{}
+	results := &maxHeap{}
+	for _, ep := range eps {
+		visited[ep.id] = struct{}{}
+		heap.Push(candidates, ep)
+		if accept == nil || accept(h.nodes[ep.id]) {
+			results.pushBounded(ep, ef)
+		}
+	}
+
+	for candidates.Len() > 0 {
+		c := heap.Pop(candidates).(candidate)
+		if results.Len() >= ef && c.dist > (*results)[0].dist {
+			break
+		}
+		for _, id := range h.nodes[c.id].links[l] {
+			if _, ok := visited[id]; ok {
+				continue
+			}
+			visited[id] = struct{}{}
+			nb := h.nodes[id]
+			d := h.distance(q, nb.entry)
+			if results.Len() < ef || d < (*results)[0].dist {
+				next := candidate{entry: nb.entry, id: id, dist: d}
+				heap.Push(candidates, next)
+				if accept == nil || accept(nb) {
+					results.pushBounded(next, ef)
+				}
+			}
+		}
+	}
+	return results.sorted()
+}
+
+func (h *hnsw) search(q *entry, k, ef int, accept func(doc *schema.Document) bool) []candidate {
+	if h.entryPoint < 0 {
+		return nil
+	}
+	ep := candidate{entry: h.nodes[h.entryPoint].entry, id: h.entryPoint}
+	ep.dist = h.distance(q, ep.entry)
+	for l := h.maxLevel; l > 0; l-- {
+		ep = h.greedy(q, ep, l)
+	}
+
+	results := h.searchLayer(q, []candidate{ep}, ef, 0, func(node *hnswNode) bool {
+		return !node.deleted && (accept == nil || accept(node.entry.doc))
+	})
+	if len(results) > k {
+		results = results[:k]
+	}
+	return results
+}
+
+func (h *hnsw) remove(e *entry) {
+	id, ok := h.index[e]
+	if !ok {
+		return
+	}
+	delete(h.index, e)
+	h.nodes[id].deleted = true
+	h.deleted++
+}
+
+type candidate struct {
+	entry *entry
+	// id is the node id in hnsw, unused by flat search
+	id   int
+	dist float64
+}
+
+// less orders by distance, then by insertion order.
+func (c candidate) less(o candidate) bool {
+	if c.dist != o.dist {
+		return c.dist < o.dist
+	}
+	return c.entry.seq < o.entry.seq
+}
+
+type minHeap []candidate
+
+func (h minHeap) Len() int           { return len(h) }
+func (h minHeap) Less(i, j int) bool { return h[i].less(h[j]) }
+func (h minHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *minHeap) Push(x any)        { *h = append(*h, x.(candidate)) }
+func (h *minHeap) Pop() any {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
+
+// maxHeap keeps the farthest candidate on top, to bound the closest ones kept.
+type maxHeap []candidate
+
+func (h maxHeap) Len() int           { return len(h) }
+func (h maxHeap) Less(i, j int) bool { return h[j].less(h[i]) }
+func (h maxHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *maxHeap) Push(x any)        { *h = append(*h, x.(candidate)) }
+func (h *maxHeap) Pop() any {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
+
+// pushBounded pushes c, then pops the farthest if there are more than bound.
+func (h *maxHeap) pushBounded(c candidate, bound int) {
+	heap.Push(h, c)
+	if h.Len() > bound {
+		heap.Pop(h)
+	}
+}
+
+// sorted returns the candidates sorted by distance.
+func (h *maxHeap) sorted() []candidate {
+	out := append([]candidate(nil), *h...)
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].less(out[j])
+	})
+	return out
+}
@@ -0,0 +1,161 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+)
+
+type IndexerConfig struct {
+	// Store is the in-memory vector store to write, share it with the memory retriever.
+	// Required
+	Store *Store
+	// Embedding vectorization method for the content of documents without a dense vector.
+	// Documents carrying schema.Document.DenseVector() are stored with it, without embedding.
+	// Required unless every document carries a dense vector
+	Embedding embedding.Embedder
+	// BatchSize controls embedding texts size.
+	// Optional, and the default value is 10
+	BatchSize int
+	// ParentKey is the metadata key of the parent document of a chunk, which Upsert replaces the chunks of.
+	// Optional, and the default value is "_source", the source uri set by the file loader
+	ParentKey string
+}
+
+type Indexer struct {
+	config *IndexerConfig
+}
+
+func NewIndexer(_ context.Context, config *IndexerConfig) (*Indexer, error) {
+	if config.Store == nil {
+		return nil, fmt.Errorf("[NewIndexer] store not provided for memory indexer")
+	}
+
+	if config.BatchSize == 0 {
+		config.BatchSize = defaultBatchSize
+	}
+
+	if config.ParentKey == "" {
+		config.ParentKey = defaultParentKey
+	}
+
+	return &Indexer{
+		config: config,
+	}, nil
+}
+
+func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
+	options := indexer.GetCommonOptions(&indexer.Options{
+		Embedding: i.config.Embedding,
+	}, opts...)
+
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{Docs: docs})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	vectors, err := i.embed(ctx, docs, options.Embedding)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = i.config.Store.Add(docs, vectors); err != nil {
+		return nil, err
+	}
+
+	ids = make([]string, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{IDs: ids})
+
+	return ids, nil
+}
+
+// embed returns the vectors of docs, embedding the content of the ones without a dense vector in batches.
+func (i *Indexer) embed(ctx context.Context, docs []*schema.Document, emb embedding.Embedder) ([][]float64, error) {
+	vectors := make([][]float64, len(docs))
+	var pending []int
+	for idx, doc := range docs {
+		if vector := doc.DenseVector(); len(vector) > 0 {
+			vectors[idx] = vector
+		} else {
+			pending = append(pending, idx)
+		}
+	}
+	if len(pending) == 0 {
+		return vectors, nil
+	}
+
+	if emb == nil {
+		return nil, fmt.Errorf("[memory indexer] embedding method not provided")
+	}
+
+	for start := 0; start < len(pending); start += i.config.BatchSize {
+		batch := pending[start:min(start+i.config.BatchSize, len(pending))]
+		texts := make([]string, 0, len(batch))
+		for _, idx := range batch {
+			texts = append(texts, docs[idx].Content)
+		}
+
+		embedded, err := emb.EmbedStrings(i.makeEmbeddingCtx(ctx, emb), texts)
+		if err != nil {
+			return nil, fmt.Errorf("[memory indexer] embedding failed, %w", err)
+		}
+		if len(embedded) != len(texts) {
+			return nil, fmt.Errorf("[memory indexer] invalid vector length, expected=%d, got=%d", len(texts), len(embedded))
+		}
+		for j, idx := range batch {
+			vectors[idx] = embedded[j]
+		}
+	}
+
+	return vectors, nil
+}
+
+func (i *Indexer) makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfEmbedding,
+	}
+
+	if embType, ok := components.GetType(emb); ok {
+		runInfo.Type = embType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+func (i *Indexer) GetType() string {
+	return typ
+}
+
+func (i *Indexer) IsCallbacksEnabled() bool {
+	return true
+}
@@ -0,0 +1,155 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/indexer/mutation"
+)
+
+// mockEmbedding embeds a text to its length and its first byte.
+type mockEmbedding struct {
+	calls int
+}
+
+func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	m.calls++
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		if text == "" {
+			return nil, fmt.Errorf("empty text")
+		}
+		vectors[i] = []float64{float64(len(text)), float64(text[0])}
+	}
+	return vectors, nil
+}
+
+func TestIndexer(t *testing.T) {
+	convey.Convey("test memory indexer", t, func() {
+		ctx := context.Background()
+		store, _ := NewStore(nil)
+		emb := &mockEmbedding{}
+
+		_, err := NewIndexer(ctx, &IndexerConfig{})
+		convey.So(err, convey.ShouldNotBeNil)
+
+		i, err := NewIndexer(ctx, &IndexerConfig{Store: store, Embedding: emb, BatchSize: 2})
+		convey.So(err, convey.ShouldBeNil)
+
+		var deleted []int64
+		ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, callbacks.NewHandlerBuilder().
+			OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+				if n, ok := indexer.ConvCallbackOutput(output).Extra[extraKeyDeleted].(int64); ok {
+					deleted = append(deleted, n)
+				}
+				return ctx
+			}).Build())
+
+		convey.Convey("test store", func() {
+			ids, err := i.Store(ctx, []*schema.Document{
+				{ID: "1", Content: "a"},
+				{ID: "2", Content: "bb"},
+				(&schema.Document{ID: "3", Content: "preset"}).WithDenseVector([]float64{3, 3}),
+				{ID: "4", Content: "dddd"},
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids, convey.ShouldResemble, []string{"1", "2", "3", "4"})
+			convey.So(emb.calls, convey.ShouldEqual, 2)
+			convey.So(store.Len(), convey.ShouldEqual, 4)
+
+			_, err = i.Store(ctx, []*schema.Document{{ID: "5"}})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			noEmb, _ := NewIndexer(ctx, &IndexerConfig{Store: store})
+			_, err = noEmb.Store(ctx, []*schema.Document{{ID: "5", Content: "e"}})
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = noEmb.Store(ctx, []*schema.Document{(&schema.Document{ID: "5"}).WithDenseVector([]float64{1, 1})})
+			convey.So(err, convey.ShouldBeNil)
+		})
+
+		convey.Convey("test delete and upsert", func() {
+			_, err := i.Store(ctx, []*schema.Document{
+				{ID: "a1", Content: "a1", MetaData: map[string]any{"_source": "a.md", "page": 1}},
+				{ID: "a2", Content: "a2", MetaData: map[string]any{"_source": "a.md", "page": 2}},
+				{ID: "a3", Content: "a3", MetaData: map[string]any{"_source": "a.md", "page": 3}},
+				{ID: "b1", Content: "b1", MetaData: map[string]any{"_source": "b.md", "page": 1}},
+			})
+			convey.So(err, convey.ShouldBeNil)
+
+			ids, err := i.Upsert(ctx, []*schema.Document{
+				{ID: "a1", Content: "a1 new", MetaData: map[string]any{"_source": "a.md", "page": 1}},
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids, convey.ShouldResemble, []string{"a1"})
+			convey.So(store.Len(), convey.ShouldEqual, 2)
+			doc, _ := store.Get("a1")
+			convey.So(doc.Content, convey.ShouldEqual, "a1 new")
+
+			_, err = i.Upsert(ctx, []*schema.Document{{ID: "c1", Content: "c1"}})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			n, err := i.DeleteByFilter(ctx, map[string]any{"page": 1.0, "_source": "b.md"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(n, convey.ShouldEqual, 1)
+
+			_, err = i.DeleteByFilter(ctx, nil)
+			convey.So(err, convey.ShouldNotBeNil)
+
+			n, err = i.Delete(ctx, []string{"a1", "a2"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(n, convey.ShouldEqual, 1)
+			convey.So(store.Len(), convey.ShouldEqual, 0)
+			convey.So(deleted, convey.ShouldResemble, []int64{2, 1, 1})
+		})
+	})
+}
+
+func TestValueEqual(t *testing.T) {
+	convey.Convey("test valueEqual", t, func() {
+		convey.So(valueEqual(1, 1.0), convey.ShouldBeTrue)
+		convey.So(valueEqual(uint8(2), int64(2)), convey.ShouldBeTrue)
+		convey.So(valueEqual(1, "1"), convey.ShouldBeFalse)
+		convey.So(valueEqual("a", "a"), convey.ShouldBeTrue)
+		convey.So(valueEqual([]any{"a"}, []any{"a"}), convey.ShouldBeTrue)
+	})
+}
+
+var (
+	_ mutation.Deleter  = (*Indexer)(nil)
+	_ mutation.Upserter = (*Indexer)(nil)
+)
+
+func TestMutationConsts(t *testing.T) {
+	convey.Convey("test callback extra keys and operations same as mutation", t, func() {
+		convey.So(extraKeyOperation, convey.ShouldEqual, mutation.ExtraKeyOperation)
+		convey.So(extraKeyIDs, convey.ShouldEqual, mutation.ExtraKeyIDs)
+		convey.So(extraKeyFilter, convey.ShouldEqual, mutation.ExtraKeyFilter)
+		convey.So(extraKeyDeleted, convey.ShouldEqual, mutation.ExtraKeyDeleted)
+		convey.So(operationDelete, convey.ShouldEqual, mutation.OperationDelete)
+		convey.So(operationUpsert, convey.ShouldEqual, mutation.OperationUpsert)
+		convey.So(defaultParentKey, convey.ShouldEqual, mutation.MetaKeyParent)
+	})
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Delete deletes the documents of ids.
+// It implements mutation.Deleter of github.com/cloudwego/eino-ext/components/indexer/mutation.
+func (i *Indexer) Delete(ctx context.Context, ids []string, opts ...indexer.Option) (deleted int64, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{
+		Extra: map[string]any{extraKeyOperation: operationDelete, extraKeyIDs: ids},
+	})
+
+	deleted = int64(i.config.Store.Delete(ids...))
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{Extra: map[string]any{extraKeyDeleted: deleted}})
+
+	return deleted, nil
+}
+
+// DeleteByFilter deletes the documents whose metadata equals every key - value pair of filter,
+// numbers are compared by value regardless of their type.
+func (i *Indexer) DeleteByFilter(ctx context.Context, filter map[string]any, opts ...indexer.Option) (deleted int64, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{
+		Extra: map[string]any{extraKeyOperation: operationDelete, extraKeyFilter: filter},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	if len(filter) == 0 {
+		return 0, fmt.Errorf("[DeleteByFilter] empty filter")
+	}
+
+	deleted = int64(i.config.Store.DeleteFunc(func(doc *schema.Document) bool {
+		return matchMetadata(doc, filter)
+	}))
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{Extra: map[string]any{extraKeyDeleted: deleted}})
+
+	return deleted, nil
+}
+
+// Upsert stores docs and deletes the other documents of their parents atomically, the parent of a doc is its
+// ParentKey metadata. Searches see either the previous or the new chunks of a parent, never both.
+// It implements mutation.Upserter of github.com/cloudwego/eino-ext/components/indexer/mutation.
+func (i *Indexer) Upsert(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
+	options := indexer.GetCommonOptions(&indexer.Options{
+		Embedding: i.config.Embedding,
+	}, opts...)
+
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{
+		Docs:  docs,
+		Extra: map[string]any{extraKeyOperation: operationUpsert},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	parents := make([]any, 0, len(docs))
+	for _, doc := range docs {
+		parent, ok := doc.MetaData[i.config.ParentKey]
+		if !ok || parent == nil {
+			return nil, fmt.Errorf("[Upsert] parent metadata %q not found in document, id=%s", i.config.ParentKey, doc.ID)
+		}
+		parents = append(parents, parent)
+	}
+
+	vectors, err := i.embed(ctx, docs, options.Embedding)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := i.config.Store.upsert(docs, vectors, func(doc *schema.Document) bool {
+		parent, ok := doc.MetaData[i.config.ParentKey]
+		if !ok {
+			return false
+		}
+		for _, p := range parents {
+			if valueEqual(parent, p) {
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids = make([]string, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{IDs: ids, Extra: map[string]any{extraKeyDeleted: int64(deleted)}})
+
+	return ids, nil
+}
+
+// matchMetadata reports whether the metadata of doc equals every key - value pair of filter.
+func matchMetadata(doc *schema.Document, filter map[string]any) bool {
+	for k, v := range filter {
+		actual, ok := doc.MetaData[k]
+		if !ok || !valueEqual(actual, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// valueEqual compares numbers by value, as metadata restored from a snapshot holds float64 numbers.
+func valueEqual(a, b any) bool {
+	if fa, ok := toFloat(a); ok {
+		fb, ok := toFloat(b)
+		return ok && fa == fb
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func toFloat(v any) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,139 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+type snapshot struct {
+	Version int             `json:"version"`
+	Dim     int             `json:"dim"`
+	Docs    []*snapshotItem `json:"docs"`
+}
+
+type snapshotItem struct {
+	ID       string         `json:"id"`
+	Content  string         `json:"content"`
+	MetaData map[string]any `json:"metadata,omitempty"`
+	Vector   []float64      `json:"vector"`
+}
+
+// WriteTo writes a json snapshot of the documents and their vectors to w.
+// Metadata values must be json serializable, and are restored as json values, e.g. numbers as float64.
+func (s *Store) WriteTo(w io.Writer) (int64, error) {
+	s.mu.RLock()
+	snap := &snapshot{Version: snapshotVersion, Dim: s.dim}
+	for _, e := range s.sortedEntries() {
+		snap.Docs = append(snap.Docs, &snapshotItem{ID: e.doc.ID, Content: e.doc.Content, MetaData: e.doc.MetaData, Vector: e.vector})
+	}
+	b, err := json.Marshal(snap)
+	s.mu.RUnlock()
+	if err != nil {
+		return 0, fmt.Errorf("[Store] marshal snapshot failed, %w", err)
+	}
+
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// ReadFrom replaces the documents of the store with the snapshot read from r, the hnsw graph is rebuilt.
+// The metric and index type of the store are kept, as the snapshot only carries vectors.
+func (s *Store) ReadFrom(r io.Reader) (int64, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return int64(len(b)), err
+	}
+	snap := &snapshot{}
+	if err = json.Unmarshal(b, snap); err != nil {
+		return int64(len(b)), fmt.Errorf("[Store] unmarshal snapshot failed, %w", err)
+	}
+	if snap.Version != snapshotVersion {
+		return int64(len(b)), fmt.Errorf("[Store] unsupported snapshot version: %d", snap.Version)
+	}
+	if s.config.Dim != 0 && snap.Dim != 0 && snap.Dim != s.config.Dim {
+		return int64(len(b)), fmt.Errorf("[Store] %w, expected=%d, snapshot=%d", ErrDimensionMismatch, s.config.Dim, snap.Dim)
+	}
+
+	docs := make([]*schema.Document, 0, len(snap.Docs))
+	vectors := make([][]float64, 0, len(snap.Docs))
+	for _, item := range snap.Docs {
+		docs = append(docs, &schema.Document{ID: item.ID, Content: item.Content, MetaData: item.MetaData})
+		vectors = append(vectors, item.Vector)
+	}
+
+	restored, err := NewStore(&StoreConfig{Metric: s.config.Metric, Index: s.config.Index, HNSW: s.config.HNSW, Dim: snap.Dim})
+	if err != nil {
+		return int64(len(b)), err
+	}
+	if err = restored.Add(docs, vectors); err != nil {
+		return int64(len(b)), err
+	}
+
+	s.mu.Lock()
+	s.dim, s.seq, s.entries, s.graph = restored.dim, restored.seq, restored.entries, restored.graph
+	if s.graph != nil {
+		s.graph.distance = s.distance
+	}
+	s.mu.Unlock()
+
+	return int64(len(b)), nil
+}
+
+// Save writes a snapshot to the file of path, through a temporary file renamed on success,
+// so that a failed save doesn't corrupt the previous snapshot.
+func (s *Store) Save(path string) (err error) {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("[Store] create snapshot file failed, %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+		}
+	}()
+
+	if _, err = s.WriteTo(f); err != nil {
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// Load replaces the documents of the store with the snapshot in the file of path.
+func (s *Store) Load(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("[Store] open snapshot file failed, %w", err)
+	}
+	defer f.Close()
+
+	_, err = s.ReadFrom(f)
+	return err
+}
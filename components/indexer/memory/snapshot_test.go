@@ -0,0 +1,77 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestSnapshot(t *testing.T) {
+	convey.Convey("test snapshot", t, func() {
+		docs := []*schema.Document{
+			{ID: "1", Content: "a", MetaData: map[string]any{"page": 1, "source": "a.md"}},
+			{ID: "2", Content: "b"},
+		}
+		vectors := [][]float64{{1, 0, 0}, {0, 1, 0}}
+		s, _ := NewStore(&StoreConfig{Index: IndexHNSW})
+		convey.So(s.Add(docs, vectors), convey.ShouldBeNil)
+
+		path := filepath.Join(t.TempDir(), "store.json")
+		convey.So(s.Save(path), convey.ShouldBeNil)
+
+		restored, _ := NewStore(&StoreConfig{Index: IndexHNSW})
+		convey.So(restored.Add([]*schema.Document{{ID: "old"}}, [][]float64{{1, 1, 1}}), convey.ShouldBeNil)
+		convey.So(restored.Load(path), convey.ShouldBeNil)
+		convey.So(restored.Len(), convey.ShouldEqual, 2)
+		convey.So(restored.Dim(), convey.ShouldEqual, 3)
+
+		doc, ok := restored.Get("1")
+		convey.So(ok, convey.ShouldBeTrue)
+		convey.So(doc, convey.ShouldResemble, &schema.Document{ID: "1", Content: "a", MetaData: map[string]any{"page": float64(1), "source": "a.md"}})
+		_, ok = restored.Get("old")
+		convey.So(ok, convey.ShouldBeFalse)
+
+		hits, err := restored.Search([]float64{0, 1, 0}, 1, nil)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(hits[0].Doc.ID, convey.ShouldEqual, "2")
+
+		entries, _ := os.ReadDir(filepath.Dir(path))
+		convey.So(entries, convey.ShouldHaveLength, 1)
+
+		convey.Convey("test restore errors", func() {
+			fixed, _ := NewStore(&StoreConfig{Dim: 4})
+			_, err := fixed.ReadFrom(bytes.NewBufferString(`{"version":1,"dim":3,"docs":[]}`))
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = fixed.ReadFrom(bytes.NewBufferString(`{"version":2}`))
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(fixed.Load(filepath.Join(t.TempDir(), "missing.json")), convey.ShouldNotBeNil)
+
+			s.mu.Lock()
+			s.entries["1"].doc.MetaData["bad"] = make(chan int)
+			s.mu.Unlock()
+			convey.So(s.Save(path), convey.ShouldNotBeNil)
+			entries, _ := os.ReadDir(filepath.Dir(path))
+			convey.So(entries, convey.ShouldHaveLength, 1)
+		})
+	})
+}
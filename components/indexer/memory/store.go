@@ -0,0 +1,407 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// Metric is the similarity metric of a Store.
+type Metric string
+
+const (
+	// MetricCosine scores by cosine similarity, in [-1, 1].
+	MetricCosine Metric = "cosine"
+	// MetricIP scores by inner product.
+	MetricIP Metric = "ip"
+	// MetricL2 scores by 1 / (1 + euclidean distance), in (0, 1].
+	MetricL2 Metric = "l2"
+)
+
+// IndexType is the search algorithm of a Store.
+type IndexType string
+
+const (
+	// IndexFlat compares the query with every vector, exact and fine for up to tens of thousands of vectors.
+	IndexFlat IndexType = "flat"
+	// IndexHNSW searches a hierarchical navigable small world graph, approximate and sublinear.
+	IndexHNSW IndexType = "hnsw"
+)
+
+// ErrDimensionMismatch is returned when a vector's dimension differs from the store's.
+var ErrDimensionMismatch = errors.New("vector dimension mismatch")
+
+type StoreConfig struct {
+	// Metric is the similarity metric.
+	// Optional, and the default value is MetricCosine
+	Metric Metric
+	// Index is the search algorithm.
+	// Optional, and the default value is IndexFlat
+	Index IndexType
+	// HNSW is the graph parameters of IndexHNSW.
+	// Optional, and the default value is &HNSWConfig{M: 16, EfConstruction: 200, EfSearch: 64}
+	HNSW *HNSWConfig
+	// Dim is the dimension of vectors.
+	// Optional, and the default value is the dimension of the first stored vector
+	Dim int
+}
+
+type HNSWConfig struct {
+	// M is the number of links of a node per layer, 2*M on the bottom layer. Default 16.
+	M int
+	// EfConstruction is the candidate list size when inserting. Default 200.
+	EfConstruction int
+	// EfSearch is the candidate list size when searching, raised to TopK if lower. Default 64.
+	EfSearch int
+	// Seed of the random levels of nodes, so that graphs built from the same inserts are the same. Default 1.
+	Seed int64
+}
+
+// Store is an in-memory vector store shared by the memory indexer and retriever. It's safe for concurrent use.
+type Store struct {
+	config StoreConfig
+
+	mu      sync.RWMutex
+	dim     int
+	seq     uint64
+	entries map[string]*entry
+	// graph is nil for IndexFlat
+	graph *hnsw
+}
+
+type entry struct {
+	doc    *schema.Document
+	vector []float64
+	norm   float64
+	// seq is the order of insertion, to rebuild and to break ties
+	seq uint64
+}
+
+// Hit is a search result.
+type Hit struct {
+	// Doc is a copy of the stored document, its metadata is copied as well.
+	Doc   *schema.Document
+	Score float64
+}
+
+func NewStore(config *StoreConfig) (*Store, error) {
+	if config == nil {
+		config = &StoreConfig{}
+	}
+	conf := *config
+	if conf.Metric == "" {
+		conf.Metric = MetricCosine
+	}
+	switch conf.Metric {
+	case MetricCosine, MetricIP, MetricL2:
+	default:
+		return nil, fmt.Errorf("[NewStore] unknown metric: %s", conf.Metric)
+	}
+	if conf.Index == "" {
+		conf.Index = IndexFlat
+	}
+	switch conf.Index {
+	case IndexFlat:
+	case IndexHNSW:
+		hc := HNSWConfig{}
+		if conf.HNSW != nil {
+			hc = *conf.HNSW
+		}
+		if hc.M == 0 {
+			hc.M = defaultHNSWM
+		}
+		if hc.EfConstruction == 0 {
+			hc.EfConstruction = defaultHNSWEfConstruction
+		}
+		if hc.EfSearch == 0 {
+			hc.EfSearch = defaultHNSWEfSearch
+		}
+		if hc.Seed == 0 {
+			hc.Seed = defaultHNSWSeed
+		}
+		if hc.M < 2 || hc.EfConstruction < 1 || hc.EfSearch < 1 {
+			return nil, fmt.Errorf("[NewStore] invalid hnsw config, m=%d, ef_construction=%d, ef_search=%d",
+				hc.M, hc.EfConstruction, hc.EfSearch)
+		}
+		conf.HNSW = &hc
+	default:
+		return nil, fmt.Errorf("[NewStore] unknown index type: %s", conf.Index)
+	}
+	if conf.Dim < 0 {
+		return nil, fmt.Errorf("[NewStore] invalid dim: %d", conf.Dim)
+	}
+
+	s := &Store{config: conf}
+	s.reset(conf.Dim)
+	return s, nil
+}
+
+// reset empties the store, requires the write lock.
+func (s *Store) reset(dim int) {
+	s.dim = dim
+	s.seq = 0
+	s.entries = make(map[string]*entry)
+	s.graph = nil
+	if s.config.Index == IndexHNSW {
+		s.graph = newHNSW(s.config.HNSW, s.distance)
+	}
+}
+
+// Len returns the number of stored documents.
+func (s *Store) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.entries)
+}
+
+// Dim returns the dimension of vectors, 0 if not known yet.
+func (s *Store) Dim() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.dim
+}
+
+// Get returns a copy of the document of id.
+func (s *Store) Get(id string) (*schema.Document, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	e, ok := s.entries[id]
+	if !ok {
+		return nil, false
+	}
+	return copyDocument(e.doc), true
+}
+
+// Add stores docs with their vectors, replacing documents of the same ids.
+func (s *Store) Add(docs []*schema.Document, vectors [][]float64) error {
+	_, err := s.upsert(docs, vectors, nil)
+	return err
+}
+
+// Delete deletes the documents of ids, returns the number of deleted documents.
+func (s *Store) Delete(ids ...string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	deleted := 0
+	for _, id := range ids {
+		if s.remove(id) {
+			deleted++
+		}
+	}
+	s.compact()
+	return deleted
+}
+
+// DeleteFunc deletes the documents which match returns true for, returns the number of deleted documents.
+func (s *Store) DeleteFunc(match func(doc *schema.Document) bool) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	deleted := 0
+	for id, e := range s.entries {
+		if match(e.doc) && s.remove(id) {
+			deleted++
+		}
+	}
+	s.compact()
+	return deleted
+}
+
+// upsert stores docs, then deletes the other documents which drop returns true for, in one critical section.
+func (s *Store) upsert(docs []*schema.Document, vectors [][]float64, drop func(doc *schema.Document) bool) (int, error) {
+	if len(docs) != len(vectors) {
+		return 0, fmt.Errorf("[Store] invalid vector length, expected=%d, got=%d", len(docs), len(vectors))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dim := s.dim
+	for i, doc := range docs {
+		if doc.ID == "" {
+			return 0, fmt.Errorf("[Store] doc id not set")
+		}
+		if dim == 0 {
+			dim = len(vectors[i])
+		}
+		if len(vectors[i]) == 0 || len(vectors[i]) != dim {
+			return 0, fmt.Errorf("[Store] %w, id=%s, expected=%d, got=%d", ErrDimensionMismatch, doc.ID, dim, len(vectors[i]))
+		}
+	}
+	s.dim = dim
+
+	written := make(map[string]struct{}, len(docs))
+	for i, doc := range docs {
+		s.remove(doc.ID)
+		s.seq++
+		e := &entry{doc: copyDocument(doc), vector: append([]float64(nil), vectors[i]...), seq: s.seq}
+		e.norm = math.Sqrt(dot(e.vector, e.vector))
+		s.insert(e)
+		written[doc.ID] = struct{}{}
+	}
+
+	deleted := 0
+	if drop != nil {
+		for id, e := range s.entries {
+			if _, ok := written[id]; !ok && drop(e.doc) && s.remove(id) {
+				deleted++
+			}
+		}
+	}
+	s.compact()
+	return deleted, nil
+}
+
+// insert adds e to the entries and the graph, requires the write lock.
+func (s *Store) insert(e *entry) {
+	s.entries[e.doc.ID] = e
+	if s.graph != nil {
+		s.graph.insert(e)
+	}
+}
+
+// remove deletes the document of id, requires the write lock.
+func (s *Store) remove(id string) bool {
+	e, ok := s.entries[id]
+	if !ok {
+		return false
+	}
+	delete(s.entries, id)
+	if s.graph != nil {
+		s.graph.remove(e)
+	}
+	return true
+}
+
+// compact rebuilds the graph when deleted nodes outnumber live ones, requires the write lock.
+func (s *Store) compact() {
+	if s.graph == nil || s.graph.deleted <= len(s.entries) || s.graph.deleted < minCompactDeleted {
+		return
+	}
+	s.graph = newHNSW(s.config.HNSW, s.distance)
+	for _, e := range s.sortedEntries() {
+		s.graph.insert(e)
+	}
+}
+
+// sortedEntries returns the entries in insertion order, requires the read lock.
+func (s *Store) sortedEntries() []*entry {
+	entries := make([]*entry, 0, len(s.entries))
+	for _, e := range s.entries {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].seq < entries[j].seq
+	})
+	return entries
+}
+
+// Search returns the topK documents most similar to vector which accept returns true for, by descending score.
+// accept is optional.
+func (s *Store) Search(vector []float64, topK int, accept func(doc *schema.Document) bool) ([]*Hit, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if topK <= 0 || len(s.entries) == 0 {
+		return nil, nil
+	}
+	if len(vector) != s.dim {
+		return nil, fmt.Errorf("[Store] %w, expected=%d, got=%d", ErrDimensionMismatch, s.dim, len(vector))
+	}
+
+	q := &entry{vector: vector, norm: math.Sqrt(dot(vector, vector))}
+	var results []candidate
+	if s.graph != nil {
+		ef := s.config.HNSW.EfSearch
+		if ef < topK {
+			ef = topK
+		}
+		results = s.graph.search(q, topK, ef, accept)
+	} else {
+		h := &maxHeap{}
+		for _, e := range s.entries {
+			if accept != nil && !accept(e.doc) {
+				continue
+			}
+			h.pushBounded(candidate{entry: e, dist: s.distance(q, e)}, topK)
+		}
+		results = h.sorted()
+	}
+
+	hits := make([]*Hit, 0, len(results))
+	for _, c := range results {
+		hits = append(hits, &Hit{Doc: copyDocument(c.entry.doc), Score: s.score(c.dist)})
+	}
+	return hits, nil
+}
+
+// distance is lower for more similar vectors.
+func (s *Store) distance(a, b *entry) float64 {
+	switch s.config.Metric {
+	case MetricIP:
+		return -dot(a.vector, b.vector)
+	case MetricL2:
+		var sum float64
+		for i := range a.vector {
+			d := a.vector[i] - b.vector[i]
+			sum += d * d
+		}
+		return sum
+	default:
+		if a.norm == 0 || b.norm == 0 {
+			return 1
+		}
+		return 1 - dot(a.vector, b.vector)/(a.norm*b.norm)
+	}
+}
+
+// score converts a distance to a similarity score, higher is more similar.
+func (s *Store) score(distance float64) float64 {
+	switch s.config.Metric {
+	case MetricIP:
+		return -distance
+	case MetricL2:
+		return 1 / (1 + math.Sqrt(distance))
+	default:
+		return 1 - distance
+	}
+}
+
+func dot(a, b []float64) float64 {
+	var sum float64
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+// copyDocument copies doc and its metadata, so that callers can't change stored documents.
+func copyDocument(doc *schema.Document) *schema.Document {
+	cp := &schema.Document{ID: doc.ID, Content: doc.Content}
+	if doc.MetaData != nil {
+		cp.MetaData = make(map[string]any, len(doc.MetaData))
+		for k, v := range doc.MetaData {
+			cp.MetaData[k] = v
+		}
+	}
+	return cp
+}
@@ -0,0 +1,199 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func randomVectors(rnd *rand.Rand, n, dim int) [][]float64 {
+	vectors := make([][]float64, n)
+	for i := range vectors {
+		vectors[i] = make([]float64, dim)
+		for j := range vectors[i] {
+			vectors[i][j] = rnd.NormFloat64()
+		}
+	}
+	return vectors
+}
+
+func numberedDocs(n int) []*schema.Document {
+	docs := make([]*schema.Document, n)
+	for i := range docs {
+		docs[i] = &schema.Document{ID: fmt.Sprint(i), Content: fmt.Sprint("doc ", i), MetaData: map[string]any{"group": i % 4}}
+	}
+	return docs
+}
+
+func hitIDs(hits []*Hit) []string {
+	ids := make([]string, 0, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, hit.Doc.ID)
+	}
+	return ids
+}
+
+func TestStore(t *testing.T) {
+	convey.Convey("test store", t, func() {
+		docs := []*schema.Document{{ID: "x"}, {ID: "y"}, {ID: "xy", MetaData: map[string]any{"k": "v"}}}
+		vectors := [][]float64{{1, 0}, {0, 1}, {3, 3}}
+
+		convey.Convey("test metrics", func() {
+			for _, tc := range []struct {
+				metric Metric
+				ids    []string
+				scores []float64
+			}{
+				{MetricCosine, []string{"x", "xy", "y"}, []float64{1, 0.7071, 0}},
+				{MetricIP, []string{"xy", "x", "y"}, []float64{6, 2, 0}},
+				{MetricL2, []string{"x", "y", "xy"}, []float64{0.5, 1 / (1 + 2.2361), 1 / (1 + 3.1623)}},
+			} {
+				s, err := NewStore(&StoreConfig{Metric: tc.metric})
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(s.Add(docs, vectors), convey.ShouldBeNil)
+
+				hits, err := s.Search([]float64{2, 0}, 3, nil)
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(hitIDs(hits), convey.ShouldResemble, tc.ids)
+				for i, hit := range hits {
+					convey.So(hit.Score, convey.ShouldAlmostEqual, tc.scores[i], 0.001)
+				}
+			}
+		})
+
+		convey.Convey("test add, get and delete", func() {
+			s, err := NewStore(nil)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(s.Add(docs, vectors), convey.ShouldBeNil)
+			convey.So(s.Len(), convey.ShouldEqual, 3)
+			convey.So(s.Dim(), convey.ShouldEqual, 2)
+
+			doc, ok := s.Get("xy")
+			convey.So(ok, convey.ShouldBeTrue)
+			doc.MetaData["k"] = "changed"
+			doc, _ = s.Get("xy")
+			convey.So(doc.MetaData["k"], convey.ShouldEqual, "v")
+
+			convey.So(s.Add([]*schema.Document{{ID: "x", Content: "new"}}, [][]float64{{0, 2}}), convey.ShouldBeNil)
+			convey.So(s.Len(), convey.ShouldEqual, 3)
+			hits, err := s.Search([]float64{0, 1}, 1, nil)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(hits[0].Doc.ID, convey.ShouldEqual, "y")
+
+			convey.So(s.Delete("x", "missing"), convey.ShouldEqual, 1)
+			convey.So(s.DeleteFunc(func(doc *schema.Document) bool { return doc.MetaData["k"] == "v" }), convey.ShouldEqual, 1)
+			convey.So(s.Len(), convey.ShouldEqual, 1)
+
+			hits, err = s.Search([]float64{1, 1}, 5, func(doc *schema.Document) bool { return doc.ID != "y" })
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(hits, convey.ShouldBeEmpty)
+		})
+
+		convey.Convey("test dimension mismatch", func() {
+			s, err := NewStore(&StoreConfig{Dim: 3})
+			convey.So(err, convey.ShouldBeNil)
+			err = s.Add(docs, vectors)
+			convey.So(errors.Is(err, ErrDimensionMismatch), convey.ShouldBeTrue)
+			convey.So(s.Len(), convey.ShouldEqual, 0)
+
+			s, _ = NewStore(nil)
+			convey.So(s.Add(docs, vectors), convey.ShouldBeNil)
+			_, err = s.Search([]float64{1, 2, 3}, 1, nil)
+			convey.So(errors.Is(err, ErrDimensionMismatch), convey.ShouldBeTrue)
+			convey.So(s.Add([]*schema.Document{{}}, [][]float64{{1, 2}}), convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test invalid config", func() {
+			_, err := NewStore(&StoreConfig{Metric: "hamming"})
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = NewStore(&StoreConfig{Index: "ivf"})
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = NewStore(&StoreConfig{Index: IndexHNSW, HNSW: &HNSWConfig{M: 1}})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestHNSW(t *testing.T) {
+	convey.Convey("test hnsw against flat search", t, func() {
+		rnd := rand.New(rand.NewSource(42))
+		n, dim, k := 1000, 16, 10
+		docs, vectors := numberedDocs(n), randomVectors(rnd, n, dim)
+		queries := randomVectors(rnd, 50, dim)
+
+		for _, metric := range []Metric{MetricCosine, MetricIP, MetricL2} {
+			flat, _ := NewStore(&StoreConfig{Metric: metric})
+			graph, err := NewStore(&StoreConfig{Metric: metric, Index: IndexHNSW})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(flat.Add(docs, vectors), convey.ShouldBeNil)
+			convey.So(graph.Add(docs, vectors), convey.ShouldBeNil)
+
+			recall := func(accept func(doc *schema.Document) bool) float64 {
+				found := 0
+				for _, q := range queries {
+					exact, _ := flat.Search(q, k, accept)
+					approx, err := graph.Search(q, k, accept)
+					convey.So(err, convey.ShouldBeNil)
+					convey.So(approx, convey.ShouldHaveLength, len(exact))
+					truth := make(map[string]bool, k)
+					for _, hit := range exact {
+						truth[hit.Doc.ID] = true
+					}
+					for _, hit := range approx {
+						if truth[hit.Doc.ID] {
+							found++
+						}
+					}
+				}
+				return float64(found) / float64(len(queries)*k)
+			}
+
+			convey.So(recall(nil), convey.ShouldBeGreaterThan, 0.95)
+			convey.So(recall(func(doc *schema.Document) bool { return doc.MetaData["group"] == 1 }), convey.ShouldBeGreaterThan, 0.95)
+		}
+	})
+
+	convey.Convey("test hnsw delete and compact", t, func() {
+		rnd := rand.New(rand.NewSource(7))
+		n, dim := 500, 8
+		docs, vectors := numberedDocs(n), randomVectors(rnd, n, dim)
+		s, _ := NewStore(&StoreConfig{Index: IndexHNSW})
+		convey.So(s.Add(docs, vectors), convey.ShouldBeNil)
+
+		convey.So(s.DeleteFunc(func(doc *schema.Document) bool { return doc.MetaData["group"] != 0 }), convey.ShouldEqual, 375)
+		convey.So(s.Len(), convey.ShouldEqual, 125)
+		convey.So(s.graph.deleted, convey.ShouldEqual, 0)
+		convey.So(s.graph.nodes, convey.ShouldHaveLength, 125)
+
+		hits, err := s.Search(vectors[0], 10, nil)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(hits, convey.ShouldHaveLength, 10)
+		convey.So(hits[0].Doc.ID, convey.ShouldEqual, "0")
+
+		convey.So(s.Delete("0"), convey.ShouldEqual, 1)
+		convey.So(s.graph.deleted, convey.ShouldEqual, 1)
+		hits, _ = s.Search(vectors[0], 125, nil)
+		convey.So(hits, convey.ShouldHaveLength, 124)
+		convey.So(hitIDs(hits), convey.ShouldNotContain, "0")
+	})
+}
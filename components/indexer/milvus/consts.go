@@ -29,10 +29,35 @@ const (
 	defaultCollectionMetadata     = "metadata"
 	defaultCollectionMetadataDesc = "the metadata of the document"
 	
+	defaultCollectionSparseVectorDesc = "the sparse vector of the document"
+	
+	// docMetaDataKeySparseVector is the metadata key of schema.Document.WithSparseVector
+	docMetaDataKeySparseVector = "_sparse_vector"
+	
+	// dimProbeText is embedded to derive the dimension of the embedder when it is not configured
+	dimProbeText = "eino"
+	
 	defaultDim = 81920
 	
 	defaultIndexField = "vector"
 	
 	defaultConsistencyLevel = ConsistencyLevelBounded
 	defaultMetricType       = HAMMING
+	defaultFloatMetricType  = COSINE
+	defaultVectorType       = VectorTypeBinary
+	
+	// defaultParentKey matches mutation.MetaKeyParent of github.com/cloudwego/eino-ext/components/indexer/mutation
+	defaultParentKey = "_source"
+)
+
+// callback extra keys and operations, same as the ones of github.com/cloudwego/eino-ext/components/indexer/mutation,
+// checked by TestMutationConsts
+const (
+	extraKeyOperation = "operation"
+	extraKeyIDs       = "ids"
+	extraKeyFilter    = "filter"
+	extraKeyDeleted   = "deleted"
+	
+	operationDelete = "delete"
+	operationUpsert = "upsert"
 )
@@ -74,16 +74,41 @@ type IndexerConfig struct {
 	
 	// Index config to the vector column
 	// MetricType the metric type for vector
-	// Optional and default type is HAMMING
+	// Optional and default type is HAMMING, or COSINE with VectorTypeFloat / VectorTypeFloat16
 	MetricType MetricType
+	// VectorIndex is the index created on the vector column if the collection has none, e.g. entity.NewIndexHNSW(entity.COSINE, 16, 200)
+	// Optional, and the default value is AUTOINDEX with MetricType
+	VectorIndex entity.Index
+	
+	// VectorType is the data type of the vector column of default fields, it's ignored when Fields is set
+	// Optional, and the default value is VectorTypeBinary
+	VectorType VectorType
+	// Dim is the dimension of the float vector column of default fields
+	// Optional, and the default value is derived by embedding a probe text when the collection is created
+	Dim int64
+	// SparseVectorField adds a sparse vector column of this name to default fields, indexed by SPARSE_INVERTED_INDEX with IP
+	// Sparse vectors are read from schema.Document.SparseVector(), or produced by SparseEmbedding if absent
+	// Optional, and the default value is empty(no sparse vector column), it requires VectorTypeFloat / VectorTypeFloat16
+	SparseVectorField string
+	// SparseEmbedding produces sparse vectors of the documents content, e.g. BM25 or SPLADE
+	// Optional, documents without a sparse vector fail to store if it's not set
+	SparseEmbedding func(ctx context.Context, texts []string) ([]map[int]float64, error)
+	// ParentKey is the metadata key of the parent document of a chunk, which Upsert replaces the chunks of
+	// Optional, and the default value is "_source", the source uri set by the file loader
+	ParentKey string
 	
 	// Embedding vectorization method for values needs to be embedded from schema.Document's content.
 	// Required
 	Embedding embedding.Embedder
+	
+	// defaultFields is true if Fields is not set and filled by default fields
+	defaultFields bool
 }
 
 type Indexer struct {
 	config IndexerConfig
+	// schema is the collection schema, used by Upsert to convert rows to columns
+	schema *entity.Schema
 }
 
 // NewIndexer creates a new indexer.
@@ -105,6 +130,9 @@ func NewIndexer(ctx context.Context, conf *IndexerConfig) (*Indexer, error) {
 		return nil, fmt.Errorf("[NewIndexer] failed to check collection: %w", err)
 	}
 	if !ok {
+		if err := conf.resolveDim(ctx); err != nil {
+			return nil, err
+		}
 		// create the collection
 		if errToCreate := conf.Client.CreateCollection(
 			ctx,
@@ -156,6 +184,7 @@ func NewIndexer(ctx context.Context, conf *IndexerConfig) (*Indexer, error) {
 	// create indexer
 	return &Indexer{
 		config: *conf,
+		schema: collection.Schema,
 	}, nil
 }
 
@@ -182,33 +211,11 @@ func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...in
 		}
 	}()
 	
-	emb := co.Embedding
-	if emb == nil {
-		return nil, fmt.Errorf("[Indexer.Store] embedding not provided")
-	}
-	
-	// load documents content
-	texts := make([]string, 0, len(docs))
-	for _, doc := range docs {
-		texts = append(texts, doc.Content)
-	}
-	
-	// embedding
-	vectors, err := emb.EmbedStrings(makeEmbeddingCtx(ctx, emb), texts)
+	rows, err := i.convertDocuments(ctx, docs, co.Embedding)
 	if err != nil {
 		return nil, err
 	}
 	
-	if len(vectors) != len(docs) {
-		return nil, fmt.Errorf("[Indexer.Store] embedding result length not match need: %d, got: %d", len(docs), len(vectors))
-	}
-	
-	// load documents content
-	rows, err := i.config.DocumentConverter(ctx, docs, vectors)
-	if err != nil {
-		return nil, fmt.Errorf("[Indexer.Store] failed to convert documents: %w", err)
-	}
-	
 	// store documents into milvus
 	results, err := i.config.Client.InsertRows(ctx, i.config.Collection, io.Partition, rows)
 	if err != nil {
@@ -235,6 +242,67 @@ func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...in
 	return ids, nil
 }
 
+// convertDocuments embeds docs and converts them to rows.
+func (i *Indexer) convertDocuments(ctx context.Context, docs []*schema.Document, emb embedding.Embedder) ([]interface{}, error) {
+	if emb == nil {
+		return nil, fmt.Errorf("[Indexer.Store] embedding not provided")
+	}
+	
+	// embedding, documents carrying an image are embedded with multimodal inputs
+	vectors, err := embedDocuments(makeEmbeddingCtx(ctx, emb), emb, docs)
+	if err != nil {
+		return nil, err
+	}
+	
+	if len(vectors) != len(docs) {
+		return nil, fmt.Errorf("[Indexer.Store] embedding result length not match need: %d, got: %d", len(docs), len(vectors))
+	}
+	
+	if i.config.SparseVectorField != "" {
+		if err = i.fillSparseVectors(ctx, docs); err != nil {
+			return nil, err
+		}
+	}
+	
+	// load documents content
+	rows, err := i.config.DocumentConverter(ctx, docs, vectors)
+	if err != nil {
+		return nil, fmt.Errorf("[Indexer.Store] failed to convert documents: %w", err)
+	}
+	return rows, nil
+}
+
+// fillSparseVectors sets the sparse vector of documents without one by SparseEmbedding.
+func (i *Indexer) fillSparseVectors(ctx context.Context, docs []*schema.Document) error {
+	var (
+		texts   []string
+		missing []*schema.Document
+	)
+	for _, doc := range docs {
+		if doc.SparseVector() == nil {
+			texts = append(texts, doc.Content)
+			missing = append(missing, doc)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	if i.config.SparseEmbedding == nil {
+		return fmt.Errorf("[Indexer.Store] sparse embedding not provided for documents without sparse vector")
+	}
+	sparse, err := i.config.SparseEmbedding(ctx, texts)
+	if err != nil {
+		return fmt.Errorf("[Indexer.Store] failed to embed sparse vectors: %w", err)
+	}
+	if len(sparse) != len(missing) {
+		return fmt.Errorf("[Indexer.Store] sparse embedding result length not match need: %d, got: %d", len(missing), len(sparse))
+	}
+	for idx, doc := range missing {
+		doc.WithSparseVector(sparse[idx])
+	}
+	return nil
+}
+
 func (i *Indexer) GetType() string {
 	return typ
 }
@@ -255,6 +323,9 @@ func (i *IndexerConfig) getSchema(collection, description string, fields []*enti
 }
 
 func (i *IndexerConfig) getDefaultDocumentConvert() func(ctx context.Context, docs []*schema.Document, vectors [][]float64) ([]interface{}, error) {
+	if i.VectorType != VectorTypeBinary {
+		return i.getFloatDocumentConvert()
+	}
 	return func(ctx context.Context, docs []*schema.Document, vectors [][]float64) ([]interface{}, error) {
 		em := make([]defaultSchema, 0, len(docs))
 		texts := make([]string, 0, len(docs))
@@ -283,11 +354,78 @@ func (i *IndexerConfig) getDefaultDocumentConvert() func(ctx context.Context, do
 	}
 }
 
+// getFloatDocumentConvert returns the default converter for float / float16 vector columns,
+// rows are column name - value maps, with the sparse vector column if configured.
+func (i *IndexerConfig) getFloatDocumentConvert() func(ctx context.Context, docs []*schema.Document, vectors [][]float64) ([]interface{}, error) {
+	return func(ctx context.Context, docs []*schema.Document, vectors [][]float64) ([]interface{}, error) {
+		rows := make([]interface{}, 0, len(docs))
+		for idx, doc := range docs {
+			meta := doc.MetaData
+			if i.SparseVectorField != "" && meta != nil {
+				// the sparse vector has its own column
+				meta = make(map[string]any, len(doc.MetaData))
+				for k, v := range doc.MetaData {
+					if k != docMetaDataKeySparseVector {
+						meta[k] = v
+					}
+				}
+			}
+			metadata, err := sonic.Marshal(meta)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+			}
+			
+			row := map[string]interface{}{
+				defaultCollectionID:       doc.ID,
+				defaultCollectionContent:  doc.Content,
+				defaultCollectionMetadata: metadata,
+			}
+			if i.VectorType == VectorTypeFloat16 {
+				row[defaultCollectionVector] = vector2Float16Bytes(vectors[idx])
+			} else {
+				row[defaultCollectionVector] = vector2Float32(vectors[idx])
+			}
+			if i.SparseVectorField != "" {
+				sparse, err := sparse2Embedding(doc.SparseVector())
+				if err != nil {
+					return nil, fmt.Errorf("failed to convert sparse vector: %w", err)
+				}
+				row[i.SparseVectorField] = sparse
+			}
+			rows = append(rows, row)
+		}
+		return rows, nil
+	}
+}
+
+// resolveDim derives the dimension of default float vector column from the embedder if not configured.
+func (i *IndexerConfig) resolveDim(ctx context.Context) error {
+	if i.VectorType == VectorTypeBinary || !i.defaultFields {
+		return nil
+	}
+	if i.Dim <= 0 {
+		vectors, err := i.Embedding.EmbedStrings(makeEmbeddingCtx(ctx, i.Embedding), []string{dimProbeText})
+		if err != nil {
+			return fmt.Errorf("[NewIndexer] failed to derive dim from embedding: %w", err)
+		}
+		if len(vectors) != 1 || len(vectors[0]) == 0 {
+			return fmt.Errorf("[NewIndexer] failed to derive dim from embedding, got %d vectors", len(vectors))
+		}
+		i.Dim = int64(len(vectors[0]))
+	}
+	i.Fields = getDefaultFloatFields(i.VectorType, i.Dim, i.SparseVectorField)
+	return nil
+}
+
 // createdDefaultIndex creates the default index
 func (i *IndexerConfig) createdDefaultIndex(ctx context.Context, async bool) error {
-	index, err := entity.NewIndexAUTOINDEX(i.MetricType.getMetricType())
-	if err != nil {
-		return fmt.Errorf("[NewIndexer] failed to create index: %w", err)
+	index := i.VectorIndex
+	if index == nil {
+		var err error
+		index, err = entity.NewIndexAUTOINDEX(i.MetricType.getMetricType())
+		if err != nil {
+			return fmt.Errorf("[NewIndexer] failed to create index: %w", err)
+		}
 	}
 	if err := i.Client.CreateIndex(ctx, i.Collection, defaultIndexField, index, async); err != nil {
 		return fmt.Errorf("[NewIndexer] failed to create index: %w", err)
@@ -295,6 +433,18 @@ func (i *IndexerConfig) createdDefaultIndex(ctx context.Context, async bool) err
 	return nil
 }
 
+// createdSparseIndex creates the inverted index of the sparse vector column
+func (i *IndexerConfig) createdSparseIndex(ctx context.Context, async bool) error {
+	index, err := entity.NewIndexSparseInverted(entity.IP, 0)
+	if err != nil {
+		return fmt.Errorf("[NewIndexer] failed to create sparse index: %w", err)
+	}
+	if err := i.Client.CreateIndex(ctx, i.Collection, i.SparseVectorField, index, async); err != nil {
+		return fmt.Errorf("[NewIndexer] failed to create sparse index: %w", err)
+	}
+	return nil
+}
+
 // checkCollectionSchema checks the collection schema
 func (i *IndexerConfig) checkCollectionSchema(schema *entity.Schema, field []*entity.Field) bool {
 	var count int
@@ -333,6 +483,17 @@ func (i *IndexerConfig) loadCollection(ctx context.Context) error {
 				return err
 			}
 		}
+		if i.SparseVectorField != "" {
+			index, err = i.Client.DescribeIndex(ctx, i.Collection, i.SparseVectorField)
+			if errors.Is(err, client.ErrClientNotReady) {
+				return fmt.Errorf("[NewIndexer] milvus client not ready: %w", err)
+			}
+			if len(index) == 0 {
+				if err := i.createdSparseIndex(ctx, false); err != nil {
+					return err
+				}
+			}
+		}
 		if err := i.Client.LoadCollection(ctx, i.Collection, true); err != nil {
 			return err
 		}
@@ -379,17 +540,42 @@ func (i *IndexerConfig) check() error {
 	if i.SharedNum <= 0 {
 		i.SharedNum = 1
 	}
+	if i.ParentKey == "" {
+		i.ParentKey = defaultParentKey
+	}
 	if i.ConsistencyLevel <= 0 || i.ConsistencyLevel > 5 {
 		i.ConsistencyLevel = defaultConsistencyLevel
 	}
+	if i.VectorType == "" {
+		i.VectorType = defaultVectorType
+	}
+	switch i.VectorType {
+	case VectorTypeBinary:
+		if i.SparseVectorField != "" {
+			return fmt.Errorf("[NewIndexer] sparse vector field requires float or float16 vector type")
+		}
+	case VectorTypeFloat, VectorTypeFloat16:
+	default:
+		return fmt.Errorf("[NewIndexer] unknown vector type: %s", i.VectorType)
+	}
 	if i.MetricType == "" {
-		i.MetricType = defaultMetricType
+		if i.VectorType == VectorTypeBinary {
+			i.MetricType = defaultMetricType
+		} else {
+			i.MetricType = defaultFloatMetricType
+		}
 	}
 	if i.PartitionNum <= 1 {
 		i.PartitionNum = 0
 	}
 	if i.Fields == nil {
-		i.Fields = getDefaultFields()
+		i.defaultFields = true
+		if i.VectorType == VectorTypeBinary {
+			i.Fields = getDefaultFields()
+		} else {
+			// dim is resolved by resolveDim before the collection is created
+			i.Fields = getDefaultFloatFields(i.VectorType, i.Dim, i.SparseVectorField)
+		}
 	}
 	if i.DocumentConverter == nil {
 		i.DocumentConverter = i.getDefaultDocumentConvert()
@@ -27,6 +27,8 @@ import (
 	"github.com/milvus-io/milvus-sdk-go/v2/client"
 	"github.com/milvus-io/milvus-sdk-go/v2/entity"
 	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/embedding/multimodal"
 )
 
 // 模拟Embedding实现
@@ -409,3 +411,54 @@ func TestIndexer_Store(t *testing.T) {
 		})
 	})
 }
+
+type mockMultiModalEmbedding struct {
+	mockEmbedding
+	inputs [][]schema.ChatMessagePart
+}
+
+func (m *mockMultiModalEmbedding) EmbedMultiModal(ctx context.Context, inputs [][]schema.ChatMessagePart, opts ...embedding.Option) ([][]float64, error) {
+	m.inputs = inputs
+	result := make([][]float64, len(inputs))
+	for i := range inputs {
+		result[i] = []float64{0.4, 0.5, 0.6}
+	}
+	return result, nil
+}
+
+func TestEmbedDocuments(t *testing.T) {
+	ctx := context.Background()
+	textDocs := []*schema.Document{{ID: "doc1", Content: "text only"}}
+	imageDocs := []*schema.Document{
+		{ID: "doc1", Content: "text only"},
+		{ID: "doc2", Content: "with image", MetaData: map[string]any{multimodal.MetaKeyImageURL: "https://example.com/a.png"}},
+	}
+
+	convey.Convey("test embedDocuments", t, func() {
+		convey.Convey("test text only documents", func() {
+			emb := &mockMultiModalEmbedding{}
+			vectors, err := embedDocuments(ctx, emb, textDocs)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(vectors, convey.ShouldResemble, [][]float64{{0.1, 0.2, 0.3}})
+			convey.So(emb.inputs, convey.ShouldBeNil)
+		})
+
+		convey.Convey("test documents with image", func() {
+			emb := &mockMultiModalEmbedding{}
+			vectors, err := embedDocuments(ctx, emb, imageDocs)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(len(vectors), convey.ShouldEqual, 2)
+			convey.So(len(emb.inputs), convey.ShouldEqual, 2)
+			convey.So(len(emb.inputs[0]), convey.ShouldEqual, 1)
+			convey.So(emb.inputs[1][0].Text, convey.ShouldEqual, "with image")
+			convey.So(emb.inputs[1][1].ImageURL.URL, convey.ShouldEqual, "https://example.com/a.png")
+		})
+
+		convey.Convey("test documents with image and text only embedding", func() {
+			// images are ignored, so documents carrying the key still store with text only embedders
+			vectors, err := embedDocuments(ctx, &mockEmbedding{}, imageDocs)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(len(vectors), convey.ShouldEqual, 2)
+		})
+	})
+}
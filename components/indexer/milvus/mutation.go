@@ -0,0 +1,282 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package milvus
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// Delete deletes the documents of ids.
+// It implements mutation.Deleter of github.com/cloudwego/eino-ext/components/indexer/mutation.
+func (i *Indexer) Delete(ctx context.Context, ids []string, opts ...indexer.Option) (deleted int64, err error) {
+	io := indexer.GetImplSpecificOptions(&ImplOptions{}, opts...)
+	if io.Partition == "" {
+		io.Partition = i.config.PartitionName
+	}
+	
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{
+		Extra: map[string]any{extraKeyOperation: operationDelete, extraKeyIDs: ids},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+	
+	if len(ids) > 0 {
+		expr, err := i.idsExpr("in", ids)
+		if err != nil {
+			return 0, fmt.Errorf("[Indexer.Delete] %w", err)
+		}
+		if deleted, err = i.deleteByExpr(ctx, io.Partition, expr); err != nil {
+			return 0, fmt.Errorf("[Indexer.Delete] %w", err)
+		}
+	}
+	
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{Extra: map[string]any{extraKeyDeleted: deleted}})
+	return deleted, nil
+}
+
+// DeleteByFilter deletes the documents whose metadata equals every key - value pair of filter.
+// A key which is a field of the collection is compared with the field, other keys are looked up
+// in the json metadata field, e.g. metadata["_source"] == "a.md".
+// Values are strings, numbers or booleans.
+func (i *Indexer) DeleteByFilter(ctx context.Context, filter map[string]any, opts ...indexer.Option) (deleted int64, err error) {
+	io := indexer.GetImplSpecificOptions(&ImplOptions{}, opts...)
+	if io.Partition == "" {
+		io.Partition = i.config.PartitionName
+	}
+	
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{
+		Extra: map[string]any{extraKeyOperation: operationDelete, extraKeyFilter: filter},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+	
+	expr, err := i.filterExpr(filter)
+	if err != nil {
+		return 0, fmt.Errorf("[Indexer.DeleteByFilter] %w", err)
+	}
+	if deleted, err = i.deleteByExpr(ctx, io.Partition, expr); err != nil {
+		return 0, fmt.Errorf("[Indexer.DeleteByFilter] %w", err)
+	}
+	
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{Extra: map[string]any{extraKeyDeleted: deleted}})
+	return deleted, nil
+}
+
+// Upsert upserts docs by primary key, then deletes the other documents of their parents,
+// the parent of a doc is its ParentKey metadata.
+// Milvus has no transaction, the stale documents are visible until they are deleted after the upsert.
+// It implements mutation.Upserter of github.com/cloudwego/eino-ext/components/indexer/mutation.
+func (i *Indexer) Upsert(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
+	co := indexer.GetCommonOptions(&indexer.Options{
+		Embedding: i.config.Embedding,
+	}, opts...)
+	io := indexer.GetImplSpecificOptions(&ImplOptions{}, opts...)
+	if io.Partition == "" {
+		io.Partition = i.config.PartitionName
+	}
+	
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{
+		Docs:  docs,
+		Extra: map[string]any{extraKeyOperation: operationUpsert},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+	
+	parents, err := getParents(docs, i.config.ParentKey)
+	if err != nil {
+		return nil, fmt.Errorf("[Indexer.Upsert] %w", err)
+	}
+	
+	rows, err := i.convertDocuments(ctx, docs, co.Embedding)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := entity.AnyToColumns(rows, i.schema)
+	if err != nil {
+		return nil, fmt.Errorf("[Indexer.Upsert] failed to convert rows to columns: %w", err)
+	}
+	results, err := i.config.Client.Upsert(ctx, i.config.Collection, io.Partition, columns...)
+	if err != nil {
+		return nil, fmt.Errorf("[Indexer.Upsert] failed to upsert rows: %w", err)
+	}
+	ids = make([]string, results.Len())
+	for idx := 0; idx < results.Len(); idx++ {
+		ids[idx], err = results.GetAsString(idx)
+		if err != nil {
+			return nil, fmt.Errorf("[Indexer.Upsert] failed to get id: %w", err)
+		}
+	}
+	
+	// delete the documents of the parents which are not upserted
+	notIn, err := i.idsExpr("not in", ids)
+	if err != nil {
+		return nil, fmt.Errorf("[Indexer.Upsert] %w", err)
+	}
+	var deleted int64
+	for _, parent := range parents {
+		expr, err := i.filterExpr(map[string]any{i.config.ParentKey: parent})
+		if err != nil {
+			return nil, fmt.Errorf("[Indexer.Upsert] %w", err)
+		}
+		n, err := i.deleteByExpr(ctx, io.Partition, expr+" and "+notIn)
+		if err != nil {
+			return nil, fmt.Errorf("[Indexer.Upsert] %w", err)
+		}
+		deleted += n
+	}
+	
+	if err := i.config.Client.Flush(ctx, i.config.Collection, false); err != nil {
+		return nil, fmt.Errorf("[Indexer.Upsert] failed to flush collection: %w", err)
+	}
+	
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{
+		IDs:   ids,
+		Extra: map[string]any{extraKeyDeleted: deleted},
+	})
+	return ids, nil
+}
+
+// deleteByExpr queries the primary keys matching expr with strong consistency and deletes them,
+// returns the number of deleted documents.
+func (i *Indexer) deleteByExpr(ctx context.Context, partition string, expr string) (int64, error) {
+	var partitions []string
+	if partition != "" {
+		partitions = []string{partition}
+	}
+	pk := i.schema.PKFieldName()
+	rs, err := i.config.Client.Query(ctx, i.config.Collection, partitions, expr, []string{pk},
+		client.WithSearchQueryConsistencyLevel(entity.ClStrong))
+	if err != nil {
+		return 0, fmt.Errorf("failed to query documents: %w", err)
+	}
+	column := rs.GetColumn(pk)
+	if column == nil || column.Len() == 0 {
+		return 0, nil
+	}
+	if err := i.config.Client.DeleteByPks(ctx, i.config.Collection, partition, column); err != nil {
+		return 0, fmt.Errorf("failed to delete documents: %w", err)
+	}
+	return int64(column.Len()), nil
+}
+
+// idsExpr builds the expression of the primary key in / not in ids.
+func (i *Indexer) idsExpr(op string, ids []string) (string, error) {
+	pk := i.schema.PKField()
+	if pk == nil {
+		return "", fmt.Errorf("primary key not found in collection schema")
+	}
+	literals := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if pk.DataType == entity.FieldTypeVarChar {
+			literals = append(literals, strconv.Quote(id))
+			continue
+		}
+		if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+			return "", fmt.Errorf("invalid int64 primary key: %s", id)
+		}
+		literals = append(literals, id)
+	}
+	return fmt.Sprintf("%s %s [%s]", pk.Name, op, strings.Join(literals, ", ")), nil
+}
+
+// filterExpr builds the boolean expression of filter, keys are sorted for a stable expression.
+func (i *Indexer) filterExpr(filter map[string]any) (string, error) {
+	if len(filter) == 0 {
+		return "", fmt.Errorf("empty filter")
+	}
+	fields := make(map[string]struct{}, len(i.schema.Fields))
+	for _, field := range i.schema.Fields {
+		fields[field.Name] = struct{}{}
+	}
+	keys := make([]string, 0, len(filter))
+	for key := range filter {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	
+	conds := make([]string, 0, len(keys))
+	for _, key := range keys {
+		literal, err := exprLiteral(filter[key])
+		if err != nil {
+			return "", fmt.Errorf("invalid filter value of %s: %w", key, err)
+		}
+		if _, ok := fields[key]; ok {
+			conds = append(conds, fmt.Sprintf("%s == %s", key, literal))
+		} else {
+			conds = append(conds, fmt.Sprintf("%s[%s] == %s", defaultCollectionMetadata, strconv.Quote(key), literal))
+		}
+	}
+	return strings.Join(conds, " and "), nil
+}
+
+// exprLiteral formats v as a literal of milvus boolean expression.
+func exprLiteral(v any) (string, error) {
+	switch val := v.(type) {
+	case string:
+		return strconv.Quote(val), nil
+	case bool:
+		return strconv.FormatBool(val), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(val), nil
+	case float32:
+		return strconv.FormatFloat(float64(val), 'g', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(val, 'g', -1, 64), nil
+	default:
+		return "", fmt.Errorf("unsupported type %T", v)
+	}
+}
+
+// getParents returns the distinct parents of docs in order.
+func getParents(docs []*schema.Document, parentKey string) ([]any, error) {
+	var parents []any
+	seen := make(map[any]struct{})
+	for _, doc := range docs {
+		parent, ok := doc.MetaData[parentKey]
+		if !ok || parent == nil {
+			return nil, fmt.Errorf("parent metadata %q not found in document, id=%s", parentKey, doc.ID)
+		}
+		if _, found := seen[parent]; !found {
+			seen[parent] = struct{}{}
+			parents = append(parents, parent)
+		}
+	}
+	return parents, nil
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package milvus
+
+import (
+	"context"
+	"testing"
+	
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/indexer/mutation"
+)
+
+func TestMutation(t *testing.T) {
+	convey.Convey("test delete and upsert", t, func() {
+		ctx := context.Background()
+		cli := &fakeClient{indexes: map[string]entity.Index{}}
+		i, err := NewIndexer(ctx, &IndexerConfig{
+			Client:     cli,
+			Embedding:  &dimEmbedding{dim: 4},
+			VectorType: VectorTypeFloat,
+			Dim:        4,
+		})
+		convey.So(err, convey.ShouldBeNil)
+		
+		var deleted []int64
+		ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, callbacks.NewHandlerBuilder().
+			OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+				if n, ok := indexer.ConvCallbackOutput(output).Extra[extraKeyDeleted].(int64); ok {
+					deleted = append(deleted, n)
+				}
+				return ctx
+			}).Build())
+		
+		convey.Convey("test delete by ids", func() {
+			cli.matched = [][]string{{"1"}}
+			n, err := i.Delete(ctx, []string{"1", `2"`})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(n, convey.ShouldEqual, 1)
+			convey.So(cli.exprs, convey.ShouldResemble, []string{`id in ["1", "2\""]`})
+			convey.So(cli.deleted, convey.ShouldResemble, [][]string{{"1"}})
+			convey.So(deleted, convey.ShouldResemble, []int64{1})
+			
+			n, err = i.Delete(ctx, []string{"3"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(n, convey.ShouldEqual, 0)
+			convey.So(cli.deleted, convey.ShouldHaveLength, 1)
+		})
+		
+		convey.Convey("test delete by filter", func() {
+			cli.matched = [][]string{{"1", "2"}}
+			n, err := i.DeleteByFilter(ctx, map[string]any{"_source": "a.md", "page": 2, "content": "asd"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(n, convey.ShouldEqual, 2)
+			convey.So(cli.exprs, convey.ShouldResemble, []string{
+				`metadata["_source"] == "a.md" and content == "asd" and metadata["page"] == 2`,
+			})
+			convey.So(deleted, convey.ShouldResemble, []int64{2})
+			
+			_, err = i.DeleteByFilter(ctx, map[string]any{"page": []int{1}})
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = i.DeleteByFilter(ctx, nil)
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+		
+		convey.Convey("test upsert", func() {
+			cli.matched = [][]string{{"a2"}, nil}
+			ids, err := i.Upsert(ctx, []*schema.Document{
+				{ID: "a1", Content: "a1", MetaData: map[string]any{"_source": "a.md"}},
+				{ID: "b1", Content: "b1", MetaData: map[string]any{"_source": "b.md"}},
+				{ID: "a3", Content: "a3", MetaData: map[string]any{"_source": "a.md"}},
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids, convey.ShouldResemble, []string{"a1", "b1", "a3"})
+			convey.So(cli.columns, convey.ShouldHaveLength, 4)
+			convey.So(cli.exprs, convey.ShouldResemble, []string{
+				`metadata["_source"] == "a.md" and id not in ["a1", "b1", "a3"]`,
+				`metadata["_source"] == "b.md" and id not in ["a1", "b1", "a3"]`,
+			})
+			convey.So(cli.deleted, convey.ShouldResemble, [][]string{{"a2"}})
+			convey.So(deleted, convey.ShouldResemble, []int64{1})
+			
+			_, err = i.Upsert(ctx, []*schema.Document{{ID: "c1", Content: "c1"}})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+var (
+	_ mutation.Deleter  = (*Indexer)(nil)
+	_ mutation.Upserter = (*Indexer)(nil)
+)
+
+func TestMutationConsts(t *testing.T) {
+	convey.Convey("test callback extra keys and operations same as mutation", t, func() {
+		convey.So(extraKeyOperation, convey.ShouldEqual, mutation.ExtraKeyOperation)
+		convey.So(extraKeyIDs, convey.ShouldEqual, mutation.ExtraKeyIDs)
+		convey.So(extraKeyFilter, convey.ShouldEqual, mutation.ExtraKeyFilter)
+		convey.So(extraKeyDeleted, convey.ShouldEqual, mutation.ExtraKeyDeleted)
+		convey.So(operationDelete, convey.ShouldEqual, mutation.OperationDelete)
+		convey.So(operationUpsert, convey.ShouldEqual, mutation.OperationUpsert)
+		convey.So(defaultParentKey, convey.ShouldEqual, mutation.MetaKeyParent)
+	})
+}
@@ -40,6 +40,19 @@ const (
 	SUPERSTRUCTURE = MetricType(entity.SUPERSTRUCTURE)
 )
 
+// VectorType is the data type of the default vector field
+type VectorType string
+
+const (
+	// VectorTypeBinary stores the float32 bytes of the embedding in a binary vector, kept for compatibility.
+	// It only works with HAMMING / JACCARD metric types.
+	VectorTypeBinary VectorType = "binary"
+	// VectorTypeFloat stores the embedding in a float32 vector, works with COSINE / IP / L2 metric types.
+	VectorTypeFloat VectorType = "float"
+	// VectorTypeFloat16 stores the embedding in a half precision vector, which halves the storage of VectorTypeFloat.
+	VectorTypeFloat16 VectorType = "float16"
+)
+
 // defaultSchema is the default schema for milvus by eino
 type defaultSchema struct {
 	ID       string `json:"id" milvus:"name:id"`
@@ -76,6 +89,30 @@ func getDefaultFields() []*entity.Field {
 	}
 }
 
+// getDefaultFloatFields returns the default fields with a float or float16 vector of dim,
+// and a sparse vector field if sparseField is not empty.
+func getDefaultFloatFields(vectorType VectorType, dim int64, sparseField string) []*entity.Field {
+	dataType := entity.FieldTypeFloatVector
+	if vectorType == VectorTypeFloat16 {
+		dataType = entity.FieldTypeFloat16Vector
+	}
+	fields := getDefaultFields()
+	fields[1] = entity.NewField().
+		WithName(defaultCollectionVector).
+		WithDescription(defaultCollectionVectorDesc).
+		WithIsPrimaryKey(false).
+		WithDataType(dataType).
+		WithDim(dim)
+	if sparseField != "" {
+		fields = append(fields, entity.NewField().
+			WithName(sparseField).
+			WithDescription(defaultCollectionSparseVectorDesc).
+			WithIsPrimaryKey(false).
+			WithDataType(entity.FieldTypeSparseVector))
+	}
+	return fields
+}
+
 type ConsistencyLevel entity.ConsistencyLevel
 
 func (c *ConsistencyLevel) getConsistencyLevel() entity.ConsistencyLevel {
@@ -19,11 +19,17 @@ package milvus
 import (
 	"context"
 	"encoding/binary"
+	"fmt"
 	"math"
+	"sort"
 
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components"
 	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+
+	"github.com/cloudwego/eino-ext/components/embedding/multimodal"
 )
 
 // vector2Bytes converts vector to bytes
@@ -39,6 +45,77 @@ func vector2Bytes(vector []float64) []byte {
 	return bytes
 }
 
+// vector2Float32 converts vector to float32 vector
+func vector2Float32(vector []float64) []float32 {
+	float32Arr := make([]float32, len(vector))
+	for i, v := range vector {
+		float32Arr[i] = float32(v)
+	}
+	return float32Arr
+}
+
+// vector2Float16Bytes converts vector to little endian IEEE 754 half precision bytes
+func vector2Float16Bytes(vector []float64) []byte {
+	bytes := make([]byte, len(vector)*2)
+	for i, v := range vector {
+		binary.LittleEndian.PutUint16(bytes[i*2:], float32ToFloat16(float32(v)))
+	}
+	return bytes
+}
+
+// float32ToFloat16 converts f to half precision bits, rounding to nearest even
+func float32ToFloat16(f float32) uint16 {
+	bits := math.Float32bits(f)
+	sign := uint16(bits>>16) & 0x8000
+	exp := int32(bits>>23&0xff) - 127 + 15
+	mant := bits & 0x7fffff
+
+	switch {
+	case bits&0x7fffffff == 0:
+		return sign
+	case exp >= 0x1f:
+		if bits&0x7fffffff > 0x7f800000 {
+			return sign | 0x7e00 // NaN
+		}
+		return sign | 0x7c00 // overflow to infinity
+	case exp <= 0:
+		if exp < -10 {
+			return sign // underflow to zero
+		}
+		// subnormal
+		mant |= 0x800000
+		shift := uint32(14 - exp)
+		half := uint16(mant >> shift)
+		if rem := mant & (1<<shift - 1); rem > 1<<(shift-1) || (rem == 1<<(shift-1) && half&1 == 1) {
+			half++
+		}
+		return sign | half
+	default:
+		half := uint16(exp)<<10 | uint16(mant>>13)
+		if rem := mant & 0x1fff; rem > 0x1000 || (rem == 0x1000 && half&1 == 1) {
+			half++ // carry into exponent is the correct rounding
+		}
+		return sign | half
+	}
+}
+
+// sparse2Embedding converts the sparse vector of schema.Document to milvus sparse embedding
+func sparse2Embedding(sparse map[int]float64) (entity.SparseEmbedding, error) {
+	positions := make([]uint32, 0, len(sparse))
+	for pos := range sparse {
+		if pos < 0 {
+			return nil, fmt.Errorf("invalid sparse vector position: %d", pos)
+		}
+		positions = append(positions, uint32(pos))
+	}
+	sort.Slice(positions, func(i, j int) bool { return positions[i] < positions[j] })
+	values := make([]float32, 0, len(positions))
+	for _, pos := range positions {
+		values = append(values, float32(sparse[int(pos)]))
+	}
+	return entity.NewSliceSparseEmbedding(positions, values)
+}
+
 // MakeEmbeddingCtx makes the embedding context.
 func makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Context {
 	runInfo := &callbacks.RunInfo{
@@ -53,3 +130,17 @@ func makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Conte
 
 	return callbacks.ReuseHandlers(ctx, runInfo)
 }
+
+// embedDocuments embeds the documents content, together with the attached image if any doc carries one
+// and the embedder supports multimodal inputs. Text only embedders ignore attached images.
+func embedDocuments(ctx context.Context, emb embedding.Embedder, docs []*schema.Document) ([][]float64, error) {
+	if _, ok := emb.(multimodal.Embedder); ok {
+		return multimodal.EmbedDocuments(ctx, emb, docs)
+	}
+
+	texts := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		texts = append(texts, doc.Content)
+	}
+	return emb.EmbedStrings(ctx, texts)
+}
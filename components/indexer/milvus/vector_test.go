@@ -0,0 +1,245 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package milvus
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+// dimEmbedding returns vectors of dim
+type dimEmbedding struct {
+	dim   int
+	calls int
+}
+
+func (m *dimEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	m.calls++
+	result := make([][]float64, len(texts))
+	for i := range texts {
+		result[i] = make([]float64, m.dim)
+		for j := range result[i] {
+			result[i][j] = 0.5
+		}
+	}
+	return result, nil
+}
+
+// fakeClient keeps the collection in memory, unimplemented methods of client.Client panic
+type fakeClient struct {
+	client.Client
+	schema  *entity.Schema
+	indexes map[string]entity.Index
+	columns []entity.Column
+	
+	// exprs are the query expressions, each query returns the next ids of matched
+	exprs   []string
+	matched [][]string
+	deleted [][]string
+}
+
+func (f *fakeClient) HasCollection(ctx context.Context, collName string) (bool, error) {
+	return f.schema != nil, nil
+}
+
+func (f *fakeClient) CreateCollection(ctx context.Context, schema *entity.Schema, shardsNum int32, opts ...client.CreateCollectionOption) error {
+	f.schema = schema
+	return nil
+}
+
+func (f *fakeClient) DescribeCollection(ctx context.Context, collName string) (*entity.Collection, error) {
+	return &entity.Collection{Name: collName, Schema: f.schema}, nil
+}
+
+func (f *fakeClient) GetLoadState(ctx context.Context, collectionName string, partitionNames []string) (entity.LoadState, error) {
+	return entity.LoadStateNotLoad, nil
+}
+
+func (f *fakeClient) DescribeIndex(ctx context.Context, collName string, fieldName string, opts ...client.IndexOption) ([]entity.Index, error) {
+	if idx, ok := f.indexes[fieldName]; ok {
+		return []entity.Index{idx}, nil
+	}
+	return nil, nil
+}
+
+func (f *fakeClient) CreateIndex(ctx context.Context, collName string, fieldName string, idx entity.Index, async bool, opts ...client.IndexOption) error {
+	f.indexes[fieldName] = idx
+	return nil
+}
+
+func (f *fakeClient) LoadCollection(ctx context.Context, collName string, async bool, opts ...client.LoadCollectionOption) error {
+	return nil
+}
+
+func (f *fakeClient) InsertRows(ctx context.Context, collName string, partitionName string, rows []interface{}) (entity.Column, error) {
+	columns, err := entity.AnyToColumns(rows, f.schema)
+	if err != nil {
+		return nil, err
+	}
+	f.columns = columns
+	for _, col := range columns {
+		if col.Name() == defaultCollectionID {
+			return col, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeClient) Flush(ctx context.Context, collName string, async bool, opts ...client.FlushOption) error {
+	return nil
+}
+
+func TestFloat32ToFloat16(t *testing.T) {
+	convey.Convey("test float32ToFloat16", t, func() {
+		cases := map[float32]uint16{
+			0:                             0x0000,
+			1:                             0x3c00,
+			-2:                            0xc000,
+			0.5:                           0x3800,
+			0.1:                           0x2e66,
+			65504:                         0x7bff,
+			1e6:                           0x7c00,
+			float32(math.Pow(2, -24)):     0x0001,
+			float32(math.Pow(2, -14)):     0x0400,
+			float32(math.Inf(-1)):         0xfc00,
+			float32(math.Pow(2, -26)):     0x0000,
+			1 + float32(math.Pow(2, -11)): 0x3c00, // tie rounds to even
+		}
+		for f, exp := range cases {
+			convey.So(float32ToFloat16(f), convey.ShouldEqual, exp)
+		}
+		convey.So(float32ToFloat16(float32(math.NaN()))&0x7c00, convey.ShouldEqual, 0x7c00)
+	})
+}
+
+func TestFloatVectorIndexer(t *testing.T) {
+	convey.Convey("test float vector indexer", t, func() {
+		ctx := context.Background()
+
+		convey.Convey("test sparse vector requires float type", func() {
+			_, err := NewIndexer(ctx, &IndexerConfig{
+				Client:            &fakeClient{},
+				Embedding:         &dimEmbedding{dim: 4},
+				SparseVectorField: "sparse",
+			})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		for _, vt := range []VectorType{VectorTypeFloat, VectorTypeFloat16} {
+			convey.Convey("test "+string(vt), func() {
+				cli := &fakeClient{indexes: map[string]entity.Index{}}
+				emb := &dimEmbedding{dim: 4}
+				hnsw, err := entity.NewIndexHNSW(entity.IP, 16, 200)
+				convey.So(err, convey.ShouldBeNil)
+
+				i, err := NewIndexer(ctx, &IndexerConfig{
+					Client:            cli,
+					Embedding:         emb,
+					VectorType:        vt,
+					VectorIndex:       hnsw,
+					SparseVectorField: "sparse",
+					SparseEmbedding: func(ctx context.Context, texts []string) ([]map[int]float64, error) {
+						resp := make([]map[int]float64, len(texts))
+						for idx := range texts {
+							resp[idx] = map[int]float64{7: 0.3, 2: 0.1}
+						}
+						return resp, nil
+					},
+				})
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(emb.calls, convey.ShouldEqual, 1) // dim probe
+				convey.So(i.config.MetricType, convey.ShouldEqual, COSINE)
+				convey.So(cli.indexes[defaultIndexField], convey.ShouldEqual, hnsw)
+				convey.So(cli.indexes["sparse"].IndexType(), convey.ShouldEqual, entity.SparseInverted)
+
+				expType := entity.FieldTypeFloatVector
+				if vt == VectorTypeFloat16 {
+					expType = entity.FieldTypeFloat16Vector
+				}
+				var vectorField, sparseField *entity.Field
+				for _, f := range cli.schema.Fields {
+					switch f.Name {
+					case defaultCollectionVector:
+						vectorField = f
+					case "sparse":
+						sparseField = f
+					}
+				}
+				convey.So(vectorField.DataType, convey.ShouldEqual, expType)
+				convey.So(vectorField.TypeParams[entity.TypeParamDim], convey.ShouldEqual, "4")
+				convey.So(sparseField.DataType, convey.ShouldEqual, entity.FieldTypeSparseVector)
+
+				d2 := &schema.Document{ID: "2", Content: "qwe"}
+				d2.WithSparseVector(map[int]float64{1: 1})
+				ids, err := i.Store(ctx, []*schema.Document{{ID: "1", Content: "asd", MetaData: map[string]any{"a": 1}}, d2})
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(ids, convey.ShouldResemble, []string{"1", "2"})
+				convey.So(cli.columns, convey.ShouldHaveLength, 5)
+				for _, col := range cli.columns {
+					switch col.Name() {
+					case defaultCollectionVector:
+						convey.So(col.Type(), convey.ShouldEqual, expType)
+						convey.So(col.Len(), convey.ShouldEqual, 2)
+					case "sparse":
+						v, err := col.Get(1)
+						convey.So(err, convey.ShouldBeNil)
+						pos, val, ok := v.(entity.SparseEmbedding).Get(0)
+						convey.So(ok, convey.ShouldBeTrue)
+						convey.So(pos, convey.ShouldEqual, 1)
+						convey.So(val, convey.ShouldEqual, 1)
+					case defaultCollectionMetadata:
+						v, err := col.Get(1)
+						convey.So(err, convey.ShouldBeNil)
+						convey.So(string(v.([]byte)), convey.ShouldNotContainSubstring, docMetaDataKeySparseVector)
+					}
+				}
+			})
+		}
+	})
+}
+
+func (f *fakeClient) Upsert(ctx context.Context, collName string, partitionName string, columns ...entity.Column) (entity.Column, error) {
+	f.columns = columns
+	for _, col := range columns {
+		if col.Name() == defaultCollectionID {
+			return col, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeClient) Query(ctx context.Context, collectionName string, partitionNames []string, expr string, outputFields []string,
+	opts ...client.SearchQueryOptionFunc) (client.ResultSet, error) {
+	f.exprs = append(f.exprs, expr)
+	var ids []string
+	if len(f.matched) > 0 {
+		ids, f.matched = f.matched[0], f.matched[1:]
+	}
+	return client.ResultSet{entity.NewColumnVarChar(defaultCollectionID, ids)}, nil
+}
+
+func (f *fakeClient) DeleteByPks(ctx context.Context, collName string, partitionName string, ids entity.Column) error {
+	f.deleted = append(f.deleted, ids.(*entity.ColumnVarChar).Data())
+	return nil
+}
@@ -0,0 +1,156 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/indexer/mutation"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// replace with an indexer of this repository, e.g. indexer/redis, indexer/milvus, indexer/es8 or indexer/volc_vikingdb
+	var idx mutation.Indexer = &memoryIndexer{docs: map[string]*schema.Document{}}
+
+	ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, callbacks.NewHandlerBuilder().
+		OnStartFn(func(ctx context.Context, info *callbacks.RunInfo, input callbacks.CallbackInput) context.Context {
+			return context.WithValue(ctx, operationKey{}, mutation.GetOperation(indexer.ConvCallbackInput(input)))
+		}).
+		OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+			if deleted, ok := mutation.GetDeleted(indexer.ConvCallbackOutput(output)); ok {
+				log.Printf("%s deleted %d documents", ctx.Value(operationKey{}), deleted)
+			}
+			return ctx
+		}).Build())
+
+	// the first version of intro.md is split into 3 chunks
+	if _, err := idx.Store(ctx, []*schema.Document{
+		{ID: "intro_1", Content: "...", MetaData: map[string]any{mutation.MetaKeyParent: "docs/intro.md"}},
+		{ID: "intro_2", Content: "...", MetaData: map[string]any{mutation.MetaKeyParent: "docs/intro.md"}},
+		{ID: "intro_3", Content: "...", MetaData: map[string]any{mutation.MetaKeyParent: "docs/intro.md"}},
+	}); err != nil {
+		log.Fatalf("Store failed, err=%v", err)
+	}
+
+	// the new version only has 2 chunks, Upsert removes intro_3
+	ids, err := idx.Upsert(ctx, []*schema.Document{
+		{ID: "intro_1", Content: "...", MetaData: map[string]any{mutation.MetaKeyParent: "docs/intro.md"}},
+		{ID: "intro_2", Content: "...", MetaData: map[string]any{mutation.MetaKeyParent: "docs/intro.md"}},
+	})
+	if err != nil {
+		log.Fatalf("Upsert failed, err=%v", err)
+	}
+	log.Printf("upserted: %v", ids)
+
+	// intro.md was removed from the source
+	if _, err = idx.DeleteByFilter(ctx, map[string]any{mutation.MetaKeyParent: "docs/intro.md"}); err != nil {
+		log.Fatalf("DeleteByFilter failed, err=%v", err)
+	}
+}
+
+type operationKey struct{}
+
+// memoryIndexer is a minimal mutation.Indexer for the example.
+type memoryIndexer struct {
+	docs map[string]*schema.Document
+}
+
+func (m *memoryIndexer) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) ([]string, error) {
+	ids := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		m.docs[doc.ID] = doc
+		ids = append(ids, doc.ID)
+	}
+	return ids, nil
+}
+
+func (m *memoryIndexer) Delete(ctx context.Context, ids []string, opts ...indexer.Option) (int64, error) {
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{Extra: map[string]any{
+		mutation.ExtraKeyOperation: mutation.OperationDelete,
+		mutation.ExtraKeyIDs:       ids,
+	}})
+	var deleted int64
+	for _, id := range ids {
+		if _, ok := m.docs[id]; ok {
+			delete(m.docs, id)
+			deleted++
+		}
+	}
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{IDs: ids, Extra: map[string]any{mutation.ExtraKeyDeleted: deleted}})
+	return deleted, nil
+}
+
+func (m *memoryIndexer) DeleteByFilter(ctx context.Context, filter map[string]any, opts ...indexer.Option) (int64, error) {
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{Extra: map[string]any{
+		mutation.ExtraKeyOperation: mutation.OperationDelete,
+		mutation.ExtraKeyFilter:    filter,
+	}})
+	var deleted int64
+	for id, doc := range m.docs {
+		if matches(doc, filter) {
+			delete(m.docs, id)
+			deleted++
+		}
+	}
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{Extra: map[string]any{mutation.ExtraKeyDeleted: deleted}})
+	return deleted, nil
+}
+
+func (m *memoryIndexer) Upsert(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) ([]string, error) {
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{Docs: docs, Extra: map[string]any{
+		mutation.ExtraKeyOperation: mutation.OperationUpsert,
+	}})
+	groups, err := mutation.GroupByParent(docs, mutation.MetaKeyParent)
+	if err != nil {
+		callbacks.OnError(ctx, err)
+		return nil, err
+	}
+	written := make(map[string]bool, len(docs))
+	ids := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		m.docs[doc.ID] = doc
+		written[doc.ID] = true
+		ids = append(ids, doc.ID)
+	}
+	var deleted int64
+	for _, group := range groups {
+		for id, doc := range m.docs {
+			if !written[id] && matches(doc, map[string]any{mutation.MetaKeyParent: group.Parent}) {
+				delete(m.docs, id)
+				deleted++
+			}
+		}
+	}
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{IDs: ids, Extra: map[string]any{mutation.ExtraKeyDeleted: deleted}})
+	return ids, nil
+}
+
+func matches(doc *schema.Document, filter map[string]any) bool {
+	for k, v := range filter {
+		if doc.MetaData[k] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mutation
+
+import (
+	"fmt"
+
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+)
+
+// ParentGroup is the chunks of one parent document.
+type ParentGroup struct {
+	Parent any
+	Docs   []*schema.Document
+}
+
+// GroupByParent groups docs by the value of parentKey in their metadata, in order of first appearance.
+// It fails if a doc doesn't carry parentKey.
+func GroupByParent(docs []*schema.Document, parentKey string) ([]*ParentGroup, error) {
+	var groups []*ParentGroup
+	index := make(map[any]int)
+	for _, doc := range docs {
+		parent, ok := doc.MetaData[parentKey]
+		if !ok || parent == nil {
+			return nil, fmt.Errorf("parent metadata %q not found in document, id=%s", parentKey, doc.ID)
+		}
+		if idx, found := index[parent]; found {
+			groups[idx].Docs = append(groups[idx].Docs, doc)
+			continue
+		}
+		index[parent] = len(groups)
+		groups = append(groups, &ParentGroup{Parent: parent, Docs: []*schema.Document{doc}})
+	}
+	return groups, nil
+}
+
+// GetOperation returns the operation of an indexer callback, empty for Store.
+func GetOperation(input *indexer.CallbackInput) string {
+	if input == nil {
+		return ""
+	}
+	op, _ := input.Extra[ExtraKeyOperation].(string)
+	return op
+}
+
+// GetDeleted returns the number of documents deleted by Delete, DeleteByFilter or Upsert from the callback output.
+func GetDeleted(output *indexer.CallbackOutput) (int64, bool) {
+	if output == nil {
+		return 0, false
+	}
+	deleted, ok := output.Extra[ExtraKeyDeleted].(int64)
+	return deleted, ok
+}
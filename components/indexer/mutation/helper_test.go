@@ -0,0 +1,54 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mutation
+
+import (
+	"testing"
+
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupByParent(t *testing.T) {
+	docs := []*schema.Document{
+		{ID: "a1", MetaData: map[string]any{MetaKeyParent: "a.md"}},
+		{ID: "b1", MetaData: map[string]any{MetaKeyParent: "b.md"}},
+		{ID: "a2", MetaData: map[string]any{MetaKeyParent: "a.md"}},
+	}
+	groups, err := GroupByParent(docs, MetaKeyParent)
+	assert.NoError(t, err)
+	assert.Equal(t, []*ParentGroup{
+		{Parent: "a.md", Docs: []*schema.Document{docs[0], docs[2]}},
+		{Parent: "b.md", Docs: []*schema.Document{docs[1]}},
+	}, groups)
+
+	_, err = GroupByParent(append(docs, &schema.Document{ID: "c1"}), MetaKeyParent)
+	assert.ErrorContains(t, err, "id=c1")
+}
+
+func TestCallbackExtra(t *testing.T) {
+	assert.Equal(t, "", GetOperation(nil))
+	assert.Equal(t, "", GetOperation(&indexer.CallbackInput{}))
+	assert.Equal(t, OperationDelete, GetOperation(&indexer.CallbackInput{Extra: map[string]any{ExtraKeyOperation: OperationDelete}}))
+
+	_, ok := GetDeleted(&indexer.CallbackOutput{})
+	assert.False(t, ok)
+	deleted, ok := GetDeleted(&indexer.CallbackOutput{Extra: map[string]any{ExtraKeyDeleted: int64(3)}})
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), deleted)
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mutation
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Deleter removes documents from the store of an [indexer.Indexer].
+//
+// Implementations in this repository satisfy it structurally, so components don't have to depend on this module.
+type Deleter interface {
+	// Delete deletes the documents of ids, ids which are not stored are ignored.
+	// It returns the number of deleted documents, or the number of ids where the backend doesn't report it.
+	Delete(ctx context.Context, ids []string, opts ...indexer.Option) (int64, error)
+	// DeleteByFilter deletes the documents whose metadata equals every key - value pair of filter,
+	// e.g. map[string]any{MetaKeyParent: "docs/intro.md"} deletes all chunks of a source document.
+	// How a metadata key maps to a field of the store is documented by each indexer.
+	// It returns the number of deleted documents.
+	DeleteByFilter(ctx context.Context, filter map[string]any, opts ...indexer.Option) (int64, error)
+}
+
+// Upserter replaces the chunks of parent documents.
+type Upserter interface {
+	// Upsert stores docs, then deletes the other chunks stored for the same parents, so that
+	// a changed source document doesn't leave stale chunks behind.
+	// The parent of a chunk is the value of its parent metadata key, MetaKeyParent by default,
+	// and every doc must carry one. The replacement is atomic where the backend supports transactions,
+	// otherwise the new chunks are written before the stale ones are deleted.
+	Upsert(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error)
+}
+
+// Indexer is an [indexer.Indexer] which also deletes and upserts documents.
+type Indexer interface {
+	indexer.Indexer
+	Deleter
+	Upserter
+}
+
+const (
+	// MetaKeyParent is the default metadata key of the parent document of a chunk,
+	// which is the source uri set by the file loader.
+	MetaKeyParent = "_source"
+)
+
+const (
+	// ExtraKeyOperation is the key of the operation in [indexer.CallbackInput].Extra, OperationDelete or OperationUpsert.
+	// It's absent for Store.
+	ExtraKeyOperation = "operation"
+	// ExtraKeyIDs is the key of the ids ([]string) passed to Delete in [indexer.CallbackInput].Extra.
+	ExtraKeyIDs = "ids"
+	// ExtraKeyFilter is the key of the filter (map[string]any) passed to DeleteByFilter in [indexer.CallbackInput].Extra.
+	ExtraKeyFilter = "filter"
+	// ExtraKeyDeleted is the key of the number of deleted documents (int64) in [indexer.CallbackOutput].Extra.
+	ExtraKeyDeleted = "deleted"
+
+	OperationDelete = "delete"
+	OperationUpsert = "upsert"
+)
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/indexer/opensearch"
+	oscli "github.com/cloudwego/eino-ext/libs/acl/opensearch"
+)
+
+const (
+	indexName          = "eino_docs"
+	fieldContent       = "content"
+	fieldContentVector = "content_vector"
+	fieldSource        = "source"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// e.g. https://localhost:9200
+	cli, err := oscli.NewClient(&oscli.Config{
+		BaseURL:  os.Getenv("OPENSEARCH_URL"),
+		Username: os.Getenv("OPENSEARCH_USERNAME"),
+		Password: os.Getenv("OPENSEARCH_PASSWORD"),
+	})
+	if err != nil {
+		log.Fatalf("NewClient failed, err=%v", err)
+	}
+
+	idx, err := opensearch.NewIndexer(ctx, &opensearch.IndexerConfig{
+		Client: cli,
+		Index:  indexName,
+		CreateIndex: &opensearch.IndexConfig{
+			VectorFields: []string{fieldContentVector},
+			Dimension:    26,
+			SpaceType:    oscli.SpaceTypeCosine,
+			Properties: map[string]any{
+				fieldContent: map[string]any{"type": "text"},
+				fieldSource:  map[string]any{"type": "keyword"},
+			},
+		},
+		DocumentToFields: func(ctx context.Context, doc *schema.Document) (map[string]opensearch.FieldValue, error) {
+			return map[string]opensearch.FieldValue{
+				fieldContent: {Value: doc.Content, EmbedKey: fieldContentVector},
+				fieldSource:  {Value: doc.MetaData["_source"]},
+			}, nil
+		},
+		Embedding: &letterEmbedding{}, // replace it with real embedding component
+		Refresh:   oscli.RefreshWaitFor,
+	})
+	if err != nil {
+		log.Fatalf("NewIndexer failed, err=%v", err)
+	}
+
+	ids, err := idx.Store(ctx, []*schema.Document{
+		{ID: "intro_1", Content: "eino is a llm application framework", MetaData: map[string]any{"_source": "intro.md"}},
+		{ID: "intro_2", Content: "eino components are composed by graphs", MetaData: map[string]any{"_source": "intro.md"}},
+		{Content: "how to install eino", MetaData: map[string]any{"_source": "faq.md"}}, // id generated by opensearch
+	})
+	if err != nil {
+		log.Fatalf("Store failed, err=%v", err)
+	}
+	log.Printf("stored: %v", ids)
+}
+
+// letterEmbedding embeds a text to its letter frequencies, only for the example.
+type letterEmbedding struct{}
+
+func (l *letterEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = make([]float64, 26)
+		for _, c := range strings.ToLower(text) {
+			if c >= 'a' && c <= 'z' {
+				vectors[i][c-'a']++
+			}
+		}
+	}
+	return vectors, nil
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package opensearch
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cloudwego/eino-ext/libs/acl/opensearch"
+)
+
+// fakeOpenSearch is an in-memory opensearch REST api of the endpoints the indexer uses.
+type fakeOpenSearch struct {
+	mu       sync.Mutex
+	indices  map[string]map[string]any
+	docs     map[string]map[string]any // doc id -> source
+	requests []string                  // method path?query
+	bulks    int
+	reject   map[string]bool // doc ids rejected by bulk
+	fail     bool
+	nextID   int
+}
+
+func newFakeOpenSearch(t *testing.T) (*fakeOpenSearch, *opensearch.Client) {
+	f := &fakeOpenSearch{
+		indices: map[string]map[string]any{},
+		docs:    map[string]map[string]any{},
+		reject:  map[string]bool{},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(srv.Close)
+
+	cli, err := opensearch.NewClient(&opensearch.Config{BaseURL: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f, cli
+}
+
+func (f *fakeOpenSearch) serve(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	req := r.Method + " " + r.URL.Path
+	if r.URL.RawQuery != "" {
+		req += "?" + r.URL.RawQuery
+	}
+	f.requests = append(f.requests, req)
+
+	if f.fail {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"error":{"type":"exception","reason":"service error"},"status":500}`))
+		return
+	}
+
+	switch {
+	case r.URL.Path == "/_bulk":
+		f.bulk(w, r)
+	case r.Method == http.MethodHead:
+		if _, ok := f.indices[strings.TrimPrefix(r.URL.Path, "/")]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case r.Method == http.MethodPut:
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.indices[strings.TrimPrefix(r.URL.Path, "/")] = body
+		_, _ = w.Write([]byte(`{"acknowledged":true}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeOpenSearch) bulk(w http.ResponseWriter, r *http.Request) {
+	f.bulks++
+
+	var items []map[string]any
+	scanner := bufio.NewScanner(r.Body)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		var action struct {
+			Index struct {
+				ID string `json:"_id"`
+			} `json:"index"`
+		}
+		_ = json.Unmarshal(scanner.Bytes(), &action)
+		scanner.Scan()
+		var source map[string]any
+		_ = json.Unmarshal(scanner.Bytes(), &source)
+
+		id := action.Index.ID
+		if id == "" {
+			f.nextID++
+			id = fmt.Sprintf("gen-%d", f.nextID)
+		}
+		if f.reject[id] {
+			items = append(items, map[string]any{"index": map[string]any{"_id": id, "status": 400,
+				"error": map[string]any{"type": "mapper_parsing_exception", "reason": "failed to parse"}}})
+			continue
+		}
+		f.docs[id] = source
+		items = append(items, map[string]any{"index": map[string]any{"_id": id, "status": 201, "result": "created"}})
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]any{"took": 1, "errors": false, "items": items})
+}
@@ -0,0 +1,384 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package opensearch
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/libs/acl/opensearch"
+)
+
+type IndexerConfig struct {
+	// Client opensearch client, e.g. opensearch.NewClient of github.com/cloudwego/eino-ext/libs/acl/opensearch.
+	// Required.
+	Client *opensearch.Client
+	// Index to store documents.
+	// Required.
+	Index string
+	// BatchSize controls max texts size for embedding, and the documents of a bulk request are embedded in one batch.
+	// Default is 5.
+	BatchSize int
+	// DocumentToFields maps a document to the fields of the index.
+	// Each key - FieldValue.Value from field2Value will be saved, and
+	// vector of FieldValue.Value will be saved to field FieldValue.EmbedKey if it's not empty.
+	// Required.
+	DocumentToFields func(ctx context.Context, doc *schema.Document) (field2Value map[string]FieldValue, err error)
+	// Embedding vectorization method, must provide if DocumentToFields returns fields with EmbedKey.
+	Embedding embedding.Embedder
+	// Refresh controls when stored documents become searchable, e.g. opensearch.RefreshWaitFor waits for them.
+	// Default "", searchable after the refresh interval of the index.
+	Refresh opensearch.Refresh
+	// CreateIndex creates the index with knn enabled in NewIndexer if it doesn't exist.
+	// Default nil, the index should be created in advance.
+	CreateIndex *IndexConfig
+}
+
+type FieldValue struct {
+	// Value original Value
+	Value any
+	// EmbedKey if set, Value will be vectorized and saved to the knn_vector field of this name.
+	// If Stringify method is provided, Embedding input text will be Stringify(Value).
+	// If Stringify method not set, indexer will try to assert Value as string.
+	EmbedKey string
+	// Stringify converts Value to string
+	Stringify func(val any) (string, error)
+}
+
+// IndexConfig configures the index created by NewIndexer.
+type IndexConfig struct {
+	// VectorFields are the knn_vector fields, the EmbedKey of the FieldValue returned by DocumentToFields.
+	// Required.
+	VectorFields []string
+	// Dimension of the vectors of Embedding.
+	// Required.
+	Dimension int
+	// SpaceType of the vectors, which the retriever search modes should use as well.
+	// Default opensearch.SpaceTypeCosine.
+	SpaceType opensearch.SpaceType
+	// Engine of the approximate knn search.
+	// Default opensearch.EngineLucene, which supports efficient filtering.
+	Engine opensearch.Engine
+	// MethodParameters of the hnsw method, e.g. {"ef_construction": 128, "m": 16}.
+	// Optional.
+	MethodParameters map[string]any
+	// Properties are the mappings of the other fields, e.g. {"content": {"type": "text"}, "source": {"type": "keyword"}}.
+	// Optional, fields are mapped dynamically if absent.
+	Properties map[string]any
+	// Settings of the index, e.g. {"number_of_shards": 1}, "index.knn" is always enabled.
+	// Optional.
+	Settings map[string]any
+}
+
+type Indexer struct {
+	client *opensearch.Client
+	config *IndexerConfig
+}
+
+func NewIndexer(ctx context.Context, conf *IndexerConfig) (*Indexer, error) {
+	if conf.Client == nil {
+		return nil, fmt.Errorf("[NewIndexer] opensearch client not provided")
+	}
+
+	if conf.Index == "" {
+		return nil, fmt.Errorf("[NewIndexer] index not provided")
+	}
+
+	if conf.DocumentToFields == nil {
+		return nil, fmt.Errorf("[NewIndexer] DocumentToFields method not provided")
+	}
+
+	if conf.BatchSize == 0 {
+		conf.BatchSize = defaultBatchSize
+	}
+
+	if conf.CreateIndex != nil {
+		if err := conf.createIndex(ctx); err != nil {
+			return nil, fmt.Errorf("[NewIndexer] %w", err)
+		}
+	}
+
+	return &Indexer{
+		client: conf.Client,
+		config: conf,
+	}, nil
+}
+
+// createIndex creates the index of CreateIndex if it doesn't exist.
+func (c *IndexerConfig) createIndex(ctx context.Context) error {
+	ic := c.CreateIndex
+	if len(ic.VectorFields) == 0 {
+		return fmt.Errorf("vector fields not provided")
+	}
+	if ic.Dimension <= 0 {
+		return fmt.Errorf("invalid dimension: %d", ic.Dimension)
+	}
+
+	exists, err := c.Client.IndexExists(ctx, c.Index)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	method := &opensearch.KNNMethod{
+		Name:       defaultKNNMethod,
+		SpaceType:  ic.SpaceType,
+		Engine:     ic.Engine,
+		Parameters: ic.MethodParameters,
+	}
+	if method.SpaceType == "" {
+		method.SpaceType = opensearch.SpaceTypeCosine
+	}
+	if method.Engine == "" {
+		method.Engine = opensearch.EngineLucene
+	}
+
+	properties := make(map[string]any, len(ic.Properties)+len(ic.VectorFields))
+	for k, v := range ic.Properties {
+		properties[k] = v
+	}
+	for _, field := range ic.VectorFields {
+		properties[field] = opensearch.KNNVectorProperty(ic.Dimension, method)
+	}
+
+	settings := make(map[string]any, len(ic.Settings)+1)
+	for k, v := range ic.Settings {
+		settings[k] = v
+	}
+	settings["index.knn"] = true
+
+	return c.Client.CreateIndex(ctx, c.Index, &opensearch.CreateIndexRequest{
+		Settings: settings,
+		Mappings: &opensearch.Mappings{Properties: properties},
+	})
+}
+
+func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{Docs: docs})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	options := indexer.GetCommonOptions(&indexer.Options{
+		Embedding: i.config.Embedding,
+	}, opts...)
+
+	if ids, err = i.bulkAdd(ctx, docs, options); err != nil {
+		return nil, err
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{IDs: ids})
+
+	return ids, nil
+}
+
+type tuple struct {
+	pos     int
+	fields  map[string]any
+	key2Idx map[string]int
+}
+
+// bulkAdd sends the documents in bulk requests of up to BatchSize embedding texts,
+// and returns the ids of the documents, generated by opensearch for documents without id.
+func (i *Indexer) bulkAdd(ctx context.Context, docs []*schema.Document, options *indexer.Options) ([]string, error) {
+	var (
+		ids    = make([]string, len(docs))
+		tuples []tuple
+		texts  []string
+	)
+
+	embAndAdd := func() error {
+		var vectors [][]float64
+
+		if len(texts) > 0 {
+			emb := options.Embedding
+			if emb == nil {
+				return fmt.Errorf("[bulkAdd] embedding method not provided")
+			}
+
+			var err error
+			vectors, err = emb.EmbedStrings(i.makeEmbeddingCtx(ctx, emb), texts)
+			if err != nil {
+				return fmt.Errorf("[bulkAdd] embedding failed, %w", err)
+			}
+
+			if len(vectors) != len(texts) {
+				return fmt.Errorf("[bulkAdd] invalid vector length, expected=%d, got=%d", len(texts), len(vectors))
+			}
+		}
+
+		bulk := make([]*opensearch.BulkDocument, 0, len(tuples))
+		for _, t := range tuples {
+			for k, idx := range t.key2Idx {
+				t.fields[k] = vectors[idx]
+			}
+			bulk = append(bulk, &opensearch.BulkDocument{Index: i.config.Index, ID: docs[t.pos].ID, Source: t.fields})
+		}
+
+		resp, err := i.client.Bulk(ctx, bulk, i.config.Refresh)
+		if err != nil {
+			return fmt.Errorf("[bulkAdd] %w", err)
+		}
+
+		if err = collectIDs(resp, tuples, ids); err != nil {
+			return err
+		}
+
+		tuples = tuples[:0]
+		texts = texts[:0]
+
+		return nil
+	}
+
+	for idx, doc := range docs {
+		fields, err := i.config.DocumentToFields(ctx, doc)
+		if err != nil {
+			return nil, fmt.Errorf("[bulkAdd] FieldMapping failed, %w", err)
+		}
+
+		rawFields := make(map[string]any, len(fields))
+		embSize := 0
+		for k, v := range fields {
+			rawFields[k] = v.Value
+			if v.EmbedKey != "" {
+				embSize++
+			}
+		}
+
+		if embSize > i.config.BatchSize {
+			return nil, fmt.Errorf("[bulkAdd] needEmbeddingFields length over batch size, batch size=%d, got size=%d",
+				i.config.BatchSize, embSize)
+		}
+
+		if len(texts)+embSize > i.config.BatchSize {
+			if err = embAndAdd(); err != nil {
+				return nil, err
+			}
+		}
+
+		key2Idx := make(map[string]int, embSize)
+		for k, v := range fields {
+			if v.EmbedKey == "" {
+				continue
+			}
+
+			if _, found := fields[v.EmbedKey]; found {
+				return nil, fmt.Errorf("[bulkAdd] duplicate key for origin key, key=%s", k)
+			}
+
+			if _, found := key2Idx[v.EmbedKey]; found {
+				return nil, fmt.Errorf("[bulkAdd] duplicate key from embed_key, key=%s", v.EmbedKey)
+			}
+
+			var text string
+			if v.Stringify != nil {
+				if text, err = v.Stringify(v.Value); err != nil {
+					return nil, err
+				}
+			} else {
+				var ok bool
+				if text, ok = v.Value.(string); !ok {
+					return nil, fmt.Errorf("[bulkAdd] assert value as string failed, key=%s, emb_key=%s", k, v.EmbedKey)
+				}
+			}
+
+			key2Idx[v.EmbedKey] = len(texts)
+			texts = append(texts, text)
+		}
+
+		tuples = append(tuples, tuple{
+			pos:     idx,
+			fields:  rawFields,
+			key2Idx: key2Idx,
+		})
+	}
+
+	if len(tuples) > 0 {
+		if err := embAndAdd(); err != nil {
+			return nil, err
+		}
+	}
+
+	return ids, nil
+}
+
+// collectIDs fills ids of the documents of tuples from the items of resp,
+// and fails with the first rejected item if any.
+func collectIDs(resp *opensearch.BulkResponse, tuples []tuple, ids []string) error {
+	if len(resp.Items) != len(tuples) {
+		return fmt.Errorf("[bulkAdd] invalid bulk response items length, expected=%d, got=%d", len(tuples), len(resp.Items))
+	}
+
+	var (
+		failed   int
+		firstErr string
+	)
+	for n, t := range tuples {
+		for _, item := range resp.Items[n] {
+			if item.Error != nil || item.Status >= 300 {
+				failed++
+				if firstErr == "" {
+					firstErr = fmt.Sprintf("id=%s, status=%d", item.ID, item.Status)
+					if item.Error != nil {
+						firstErr += fmt.Sprintf(", %s: %s", item.Error.Type, item.Error.Reason)
+					}
+				}
+				continue
+			}
+			ids[t.pos] = item.ID
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("[bulkAdd] %d of %d documents failed to index, first failure: %s", failed, len(tuples), firstErr)
+	}
+
+	return nil
+}
+
+func (i *Indexer) makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfEmbedding,
+	}
+
+	if embType, ok := components.GetType(emb); ok {
+		runInfo.Type = embType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+func (i *Indexer) GetType() string {
+	return typ
+}
+
+func (i *Indexer) IsCallbacksEnabled() bool {
+	return true
+}
@@ -0,0 +1,263 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package opensearch
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/libs/acl/opensearch"
+)
+
+type mockEmbedding struct {
+	calls int
+	err   error
+	size  int
+}
+
+func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	n := len(texts)
+	if m.size > 0 {
+		n = m.size
+	}
+	vectors := make([][]float64, n)
+	for i := range vectors {
+		vectors[i] = []float64{float64(len(texts[i%len(texts)])), 1}
+	}
+	return vectors, nil
+}
+
+func contentFields(ctx context.Context, doc *schema.Document) (map[string]FieldValue, error) {
+	return map[string]FieldValue{
+		"content": {Value: doc.Content, EmbedKey: "content_vector"},
+		"source":  {Value: doc.MetaData["source"]},
+	}, nil
+}
+
+func TestNewIndexer(t *testing.T) {
+	convey.Convey("test NewIndexer", t, func() {
+		ctx := context.Background()
+		fake, cli := newFakeOpenSearch(t)
+
+		convey.Convey("test invalid config", func() {
+			_, err := NewIndexer(ctx, &IndexerConfig{})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			_, err = NewIndexer(ctx, &IndexerConfig{Client: cli})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			_, err = NewIndexer(ctx, &IndexerConfig{Client: cli, Index: "docs"})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			_, err = NewIndexer(ctx, &IndexerConfig{Client: cli, Index: "docs", DocumentToFields: contentFields,
+				CreateIndex: &IndexConfig{Dimension: 2}})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			_, err = NewIndexer(ctx, &IndexerConfig{Client: cli, Index: "docs", DocumentToFields: contentFields,
+				CreateIndex: &IndexConfig{VectorFields: []string{"content_vector"}}})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test defaults", func() {
+			i, err := NewIndexer(ctx, &IndexerConfig{Client: cli, Index: "docs", DocumentToFields: contentFields})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(i.config.BatchSize, convey.ShouldEqual, defaultBatchSize)
+			convey.So(i.GetType(), convey.ShouldEqual, GetType())
+			convey.So(i.IsCallbacksEnabled(), convey.ShouldBeTrue)
+			convey.So(fake.requests, convey.ShouldBeEmpty)
+		})
+
+		convey.Convey("test create index", func() {
+			_, err := NewIndexer(ctx, &IndexerConfig{
+				Client:           cli,
+				Index:            "docs",
+				DocumentToFields: contentFields,
+				CreateIndex: &IndexConfig{
+					VectorFields:     []string{"content_vector"},
+					Dimension:        2,
+					MethodParameters: map[string]any{"m": 16},
+					Properties:       map[string]any{"source": map[string]any{"type": "keyword"}},
+					Settings:         map[string]any{"number_of_shards": 1},
+				},
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(fake.indices["docs"], convey.ShouldResemble, map[string]any{
+				"settings": map[string]any{"index.knn": true, "number_of_shards": 1.0},
+				"mappings": map[string]any{"properties": map[string]any{
+					"source": map[string]any{"type": "keyword"},
+					"content_vector": map[string]any{"type": "knn_vector", "dimension": 2.0, "method": map[string]any{
+						"name": "hnsw", "space_type": "cosinesimil", "engine": "lucene", "parameters": map[string]any{"m": 16.0},
+					}},
+				}},
+			})
+
+			// existing indices are kept
+			fake.requests = nil
+			_, err = NewIndexer(ctx, &IndexerConfig{
+				Client:           cli,
+				Index:            "docs",
+				DocumentToFields: contentFields,
+				CreateIndex: &IndexConfig{VectorFields: []string{"v"}, Dimension: 8,
+					SpaceType: opensearch.SpaceTypeInnerProduct, Engine: opensearch.EngineFaiss},
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(fake.requests, convey.ShouldResemble, []string{"HEAD /docs"})
+		})
+
+		convey.Convey("test create index failed", func() {
+			fake.fail = true
+			_, err := NewIndexer(ctx, &IndexerConfig{Client: cli, Index: "docs", DocumentToFields: contentFields,
+				CreateIndex: &IndexConfig{VectorFields: []string{"v"}, Dimension: 2}})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestStore(t *testing.T) {
+	convey.Convey("test Store", t, func() {
+		ctx := context.Background()
+		fake, cli := newFakeOpenSearch(t)
+		emb := &mockEmbedding{}
+
+		i, err := NewIndexer(ctx, &IndexerConfig{
+			Client:           cli,
+			Index:            "docs",
+			BatchSize:        2,
+			DocumentToFields: contentFields,
+			Embedding:        emb,
+			Refresh:          opensearch.RefreshWaitFor,
+		})
+		convey.So(err, convey.ShouldBeNil)
+
+		convey.Convey("test store in batches", func() {
+			var started, ended bool
+			handler := callbacks.NewHandlerBuilder().
+				OnStartFn(func(ctx context.Context, info *callbacks.RunInfo, input callbacks.CallbackInput) context.Context {
+					started = true
+					return ctx
+				}).
+				OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+					ended = true
+					convey.So(indexer.ConvCallbackOutput(output).IDs, convey.ShouldHaveLength, 3)
+					return ctx
+				}).Build()
+			ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, handler)
+
+			ids, err := i.Store(ctx, []*schema.Document{
+				{ID: "1", Content: "a", MetaData: map[string]any{"source": "a.md"}},
+				{Content: "bb"},
+				{ID: "3", Content: "ccc"},
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids, convey.ShouldResemble, []string{"1", "gen-1", "3"})
+			convey.So(started && ended, convey.ShouldBeTrue)
+			convey.So(emb.calls, convey.ShouldEqual, 2)
+			convey.So(fake.bulks, convey.ShouldEqual, 2)
+			convey.So(fake.requests[0], convey.ShouldEqual, "POST /_bulk?refresh=wait_for")
+			convey.So(fake.docs["1"], convey.ShouldResemble, map[string]any{
+				"content": "a", "source": "a.md", "content_vector": []any{1.0, 1.0},
+			})
+			convey.So(fake.docs["3"]["content_vector"], convey.ShouldResemble, []any{3.0, 1.0})
+		})
+
+		convey.Convey("test stringify and embedding option", func() {
+			i.config.DocumentToFields = func(ctx context.Context, doc *schema.Document) (map[string]FieldValue, error) {
+				return map[string]FieldValue{
+					"title": {Value: 42, EmbedKey: "title_vector", Stringify: func(val any) (string, error) {
+						return fmt.Sprint(val), nil
+					}},
+				}, nil
+			}
+			other := &mockEmbedding{}
+			_, err := i.Store(ctx, []*schema.Document{{ID: "1"}}, indexer.WithEmbedding(other))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(other.calls, convey.ShouldEqual, 1)
+			convey.So(emb.calls, convey.ShouldEqual, 0)
+			convey.So(fake.docs["1"]["title_vector"], convey.ShouldResemble, []any{2.0, 1.0})
+		})
+
+		convey.Convey("test rejected documents", func() {
+			fake.reject["2"] = true
+			_, err := i.Store(ctx, []*schema.Document{{ID: "1", Content: "a"}, {ID: "2", Content: "b"}})
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "1 of 2 documents failed")
+			convey.So(err.Error(), convey.ShouldContainSubstring, "id=2, status=400, mapper_parsing_exception: failed to parse")
+		})
+
+		convey.Convey("test errors", func() {
+			docs := []*schema.Document{{ID: "1", Content: "a"}}
+
+			fake.fail = true
+			_, err := i.Store(ctx, docs)
+			convey.So(err, convey.ShouldNotBeNil)
+			fake.fail = false
+
+			emb.err = fmt.Errorf("mock err")
+			_, err = i.Store(ctx, docs)
+			convey.So(err, convey.ShouldNotBeNil)
+			emb.err = nil
+
+			emb.size = 2
+			_, err = i.Store(ctx, docs)
+			convey.So(err, convey.ShouldNotBeNil)
+			emb.size = 0
+
+			i.config.Embedding = nil
+			_, err = i.Store(ctx, docs)
+			convey.So(err, convey.ShouldNotBeNil)
+			i.config.Embedding = emb
+
+			for _, fields := range []map[string]FieldValue{
+				{"a": {Value: "x", EmbedKey: "v"}, "b": {Value: "y", EmbedKey: "w"}, "c": {Value: "z", EmbedKey: "u"}},
+				{"a": {Value: "x", EmbedKey: "b"}, "b": {Value: "y"}},
+				{"a": {Value: "x", EmbedKey: "v"}, "b": {Value: "y", EmbedKey: "v"}},
+				{"a": {Value: 1, EmbedKey: "v"}},
+				{"a": {Value: 1, EmbedKey: "v", Stringify: func(val any) (string, error) { return "", fmt.Errorf("mock err") }}},
+			} {
+				i.config.DocumentToFields = func(ctx context.Context, doc *schema.Document) (map[string]FieldValue, error) {
+					return fields, nil
+				}
+				_, err = i.Store(ctx, docs)
+				convey.So(err, convey.ShouldNotBeNil)
+			}
+
+			i.config.DocumentToFields = func(ctx context.Context, doc *schema.Document) (map[string]FieldValue, error) {
+				return nil, fmt.Errorf("mock err")
+			}
+			_, err = i.Store(ctx, docs)
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestCollectIDs(t *testing.T) {
+	convey.Convey("test collectIDs", t, func() {
+		err := collectIDs(&opensearch.BulkResponse{}, []tuple{{pos: 0}}, make([]string, 1))
+		convey.So(err, convey.ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,433 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pgvector
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+)
+
+// maxParams is the max number of bind parameters of a postgres statement.
+const maxParams = 65535
+
+type IndexerConfig struct {
+	// DB postgres database with the pgvector extension, e.g. opened by sql.Open("pgx", dsn)
+	// with driver github.com/jackc/pgx/v5/stdlib, or sql.Open("postgres", dsn) with github.com/lib/pq.
+	// Required.
+	DB *sql.DB
+	// Table to store documents, may be schema qualified, e.g. "public.docs".
+	// Required.
+	Table string
+	// CreateTable creates the vector extension, the table of the default DocumentToFields and its indexes if they don't exist.
+	// Default nil, the table should be created in advance.
+	CreateTable *TableConfig
+	// IDColumn primary key column, documents of existing ids are updated.
+	// Default "id".
+	IDColumn string
+	// MetadataColumn jsonb column storing document metadata, which DeleteByFilter and Upsert match.
+	// Default "metadata".
+	MetadataColumn string
+	// DocumentToFields supports customize columns from eino document.
+	// Each key - FieldValue.Value from field2Value will be saved to column key, and
+	// vector of FieldValue.Value will be saved to column FieldValue.EmbedKey if it's not empty.
+	// Maps, slices and structs are saved as json.
+	// Default saves ID, Content and MetaData to columns IDColumn, content and MetadataColumn, and the vector of Content to embedding.
+	DocumentToFields func(ctx context.Context, doc *schema.Document) (field2Value map[string]FieldValue, err error)
+	// Embedding vectorization method, must provide if any FieldValue.EmbedKey is set.
+	Embedding embedding.Embedder
+	// BatchSize controls max texts size for embedding.
+	// Default is 10.
+	BatchSize int
+	// ParentKey is the metadata key of the parent document of a chunk, which Upsert replaces the chunks of.
+	// Default is "_source", the source uri set by the file loader.
+	ParentKey string
+}
+
+type FieldValue struct {
+	// Value original Value
+	Value any
+	// EmbedKey if set, Value will be vectorized and saved to column EmbedKey.
+	// If Stringify method is provided, Embedding input text will be Stringify(Value).
+	// If Stringify method not set, indexer will try to assert Value as string.
+	EmbedKey string
+	// Stringify converts Value to string
+	Stringify func(val any) (string, error)
+}
+
+type Indexer struct {
+	config *IndexerConfig
+}
+
+func NewIndexer(ctx context.Context, conf *IndexerConfig) (*Indexer, error) {
+	if conf.DB == nil {
+		return nil, fmt.Errorf("[NewIndexer] db not provided")
+	}
+
+	if conf.Table == "" {
+		return nil, fmt.Errorf("[NewIndexer] table not provided")
+	}
+
+	if conf.IDColumn == "" {
+		conf.IDColumn = defaultIDColumn
+	}
+
+	if conf.MetadataColumn == "" {
+		conf.MetadataColumn = defaultMetadataColumn
+	}
+
+	if conf.DocumentToFields == nil {
+		conf.DocumentToFields = defaultDocumentToFields(conf.IDColumn, conf.MetadataColumn)
+	}
+
+	if conf.BatchSize == 0 {
+		conf.BatchSize = defaultBatchSize
+	}
+
+	if conf.ParentKey == "" {
+		conf.ParentKey = defaultParentKey
+	}
+
+	if conf.CreateTable != nil {
+		if err := conf.CreateTable.check(); err != nil {
+			return nil, fmt.Errorf("[NewIndexer] %w", err)
+		}
+
+		stmts := createTableStatements(conf.Table, conf.IDColumn, conf.MetadataColumn, conf.CreateTable)
+		if err := createTable(ctx, conf.DB, stmts); err != nil {
+			return nil, fmt.Errorf("[NewIndexer] %w", err)
+		}
+	}
+
+	return &Indexer{
+		config: conf,
+	}, nil
+}
+
+func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{Docs: docs})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	options := indexer.GetCommonOptions(&indexer.Options{
+		Embedding: i.config.Embedding,
+	}, opts...)
+
+	rows, err := i.makeRows(ctx, docs, options.Embedding)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.withTx(ctx, func(tx *sql.Tx) error {
+		return i.insertRows(ctx, tx, rows)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids = iter(docs, func(doc *schema.Document) string { return doc.ID })
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{IDs: ids})
+
+	return ids, nil
+}
+
+type row struct {
+	id     string
+	values map[string]any
+}
+
+// makeRows maps docs to the column values of their rows, with the vectors of embedded fields.
+func (i *Indexer) makeRows(ctx context.Context, docs []*schema.Document, emb embedding.Embedder) ([]*row, error) {
+	type target struct {
+		row    int
+		column string
+	}
+
+	var (
+		rows    = make([]*row, 0, len(docs))
+		texts   []string
+		targets []target
+	)
+
+	for _, doc := range docs {
+		fields, err := i.config.DocumentToFields(ctx, doc)
+		if err != nil {
+			return nil, fmt.Errorf("[makeRows] DocumentToFields failed, %w", err)
+		}
+
+		if _, found := fields[i.config.IDColumn]; !found {
+			return nil, fmt.Errorf("[makeRows] id column %q not provided by DocumentToFields, id=%s", i.config.IDColumn, doc.ID)
+		}
+
+		r := &row{id: doc.ID, values: make(map[string]any, len(fields))}
+		embedKeys := make(map[string]struct{})
+		for k, v := range fields {
+			if r.values[k], err = encodeValue(v.Value); err != nil {
+				return nil, fmt.Errorf("[makeRows] encode column %s failed, id=%s, %w", k, doc.ID, err)
+			}
+
+			if v.EmbedKey == "" {
+				continue
+			}
+
+			if _, found := fields[v.EmbedKey]; found {
+				return nil, fmt.Errorf("[makeRows] duplicate key for origin key, key=%s", k)
+			}
+
+			if _, found := embedKeys[v.EmbedKey]; found {
+				return nil, fmt.Errorf("[makeRows] duplicate key from embed_key, key=%s", v.EmbedKey)
+			}
+			embedKeys[v.EmbedKey] = struct{}{}
+
+			var text string
+			if v.Stringify != nil {
+				if text, err = v.Stringify(v.Value); err != nil {
+					return nil, err
+				}
+			} else {
+				var ok bool
+				if text, ok = v.Value.(string); !ok {
+					return nil, fmt.Errorf("[makeRows] assert value as string failed, key=%s, emb_key=%s", k, v.EmbedKey)
+				}
+			}
+
+			targets = append(targets, target{row: len(rows), column: v.EmbedKey})
+			texts = append(texts, text)
+		}
+
+		rows = append(rows, r)
+	}
+
+	vectors, err := i.embed(ctx, texts, emb)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx, t := range targets {
+		rows[t.row].values[t.column] = vectorLiteral(vectors[idx])
+	}
+
+	return rows, nil
+}
+
+// embed vectorizes texts in batches of BatchSize.
+func (i *Indexer) embed(ctx context.Context, texts []string, emb embedding.Embedder) ([][]float64, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+
+	if emb == nil {
+		return nil, fmt.Errorf("[embed] embedding method not provided")
+	}
+
+	vectors := make([][]float64, 0, len(texts))
+	for start := 0; start < len(texts); start += i.config.BatchSize {
+		end := start + i.config.BatchSize
+		if end > len(texts) {
+			end = len(texts)
+		}
+
+		batch, err := emb.EmbedStrings(i.makeEmbeddingCtx(ctx, emb), texts[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("[embed] embedding failed, %w", err)
+		}
+
+		if len(batch) != end-start {
+			return nil, fmt.Errorf("[embed] invalid vector length, expected=%d, got=%d", end-start, len(batch))
+		}
+
+		vectors = append(vectors, batch...)
+	}
+
+	return vectors, nil
+}
+
+// insertRows inserts rows, updating the rows of existing ids. Columns a row doesn't provide are set to their default,
+// and the last of rows of the same id wins.
+func (i *Indexer) insertRows(ctx context.Context, tx *sql.Tx, rows []*row) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	rows = dedupRows(rows)
+
+	columnSet := make(map[string]struct{})
+	for _, r := range rows {
+		for k := range r.values {
+			columnSet[k] = struct{}{}
+		}
+	}
+	columns := make([]string, 0, len(columnSet))
+	for k := range columnSet {
+		columns = append(columns, k)
+	}
+	sort.Strings(columns)
+
+	perStmt := maxParams / len(columns)
+	for start := 0; start < len(rows); start += perStmt {
+		end := start + perStmt
+		if end > len(rows) {
+			end = len(rows)
+		}
+
+		query, args := i.insertStatement(columns, rows[start:end])
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("[insertRows] exec failed, %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (i *Indexer) insertStatement(columns []string, rows []*row) (string, []any) {
+	var (
+		sb   strings.Builder
+		args []any
+		sets []string
+	)
+
+	quoted := make([]string, len(columns))
+	for idx, c := range columns {
+		quoted[idx] = quoteIdent(c)
+		if c != i.config.IDColumn {
+			sets = append(sets, fmt.Sprintf("%s = EXCLUDED.%s", quoted[idx], quoted[idx]))
+		}
+	}
+
+	fmt.Fprintf(&sb, "INSERT INTO %s (%s) VALUES ", quoteIdent(i.config.Table), strings.Join(quoted, ", "))
+	for ri, r := range rows {
+		if ri > 0 {
+			sb.WriteString(", ")
+		}
+		values := make([]string, len(columns))
+		for ci, c := range columns {
+			v, ok := r.values[c]
+			if !ok {
+				values[ci] = "DEFAULT"
+				continue
+			}
+			args = append(args, v)
+			values[ci] = fmt.Sprintf("$%d", len(args))
+		}
+		sb.WriteString("(" + strings.Join(values, ", ") + ")")
+	}
+
+	fmt.Fprintf(&sb, " ON CONFLICT (%s)", quoteIdent(i.config.IDColumn))
+	if len(sets) == 0 {
+		sb.WriteString(" DO NOTHING")
+	} else {
+		sb.WriteString(" DO UPDATE SET " + strings.Join(sets, ", "))
+	}
+
+	return sb.String(), args
+}
+
+// dedupRows keeps the last row of each id, at the position of the first one.
+func dedupRows(rows []*row) []*row {
+	pos := make(map[string]int, len(rows))
+	result := make([]*row, 0, len(rows))
+	for _, r := range rows {
+		if p, found := pos[r.id]; found {
+			result[p] = r
+			continue
+		}
+		pos[r.id] = len(result)
+		result = append(result, r)
+	}
+	return result
+}
+
+func (i *Indexer) withTx(ctx context.Context, fn func(tx *sql.Tx) error) (err error) {
+	tx, err := i.config.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("[withTx] begin failed, %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("[withTx] commit failed, %w", err)
+	}
+
+	return nil
+}
+
+func (i *Indexer) makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfEmbedding,
+	}
+
+	if embType, ok := components.GetType(emb); ok {
+		runInfo.Type = embType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+func (i *Indexer) GetType() string {
+	return typ
+}
+
+func (i *Indexer) IsCallbacksEnabled() bool {
+	return true
+}
+
+func defaultDocumentToFields(idColumn, metadataColumn string) func(ctx context.Context, doc *schema.Document) (map[string]FieldValue, error) {
+	return func(ctx context.Context, doc *schema.Document) (map[string]FieldValue, error) {
+		metadata := doc.MetaData
+		if metadata == nil {
+			metadata = map[string]any{}
+		}
+
+		return map[string]FieldValue{
+			idColumn:             {Value: doc.ID},
+			defaultContentColumn: {Value: doc.Content, EmbedKey: defaultVectorColumn},
+			metadataColumn:       {Value: metadata},
+		}, nil
+	}
+}
+
+func iter[T, D any](ss []T, fn func(T) D) []D {
+	r := make([]D, len(ss))
+	for i := range ss {
+		r[i] = fn(ss[i])
+	}
+	return r
+}
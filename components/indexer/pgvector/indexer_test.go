@@ -0,0 +1,244 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pgvector
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+// mockEmbedding embeds a text to its length and its first byte.
+type mockEmbedding struct {
+	calls int
+}
+
+func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	m.calls++
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		if text == "" {
+			return nil, fmt.Errorf("empty text")
+		}
+		vectors[i] = []float64{float64(len(text)), float64(text[0])}
+	}
+	return vectors, nil
+}
+
+func newMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, mock
+}
+
+func TestNewIndexer(t *testing.T) {
+	convey.Convey("test NewIndexer", t, func() {
+		ctx := context.Background()
+		db, mock := newMockDB(t)
+
+		convey.Convey("test invalid config", func() {
+			_, err := NewIndexer(ctx, &IndexerConfig{Table: "docs"})
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = NewIndexer(ctx, &IndexerConfig{DB: db})
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = NewIndexer(ctx, &IndexerConfig{DB: db, Table: "docs", CreateTable: &TableConfig{}})
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = NewIndexer(ctx, &IndexerConfig{DB: db, Table: "docs", CreateTable: &TableConfig{Dimension: 3, Index: "x"}})
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = NewIndexer(ctx, &IndexerConfig{DB: db, Table: "docs", CreateTable: &TableConfig{Dimension: 3, Metric: "x"}})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test create hnsw table", func() {
+			mock.ExpectExec(`CREATE EXTENSION IF NOT EXISTS vector`).WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(`CREATE TABLE IF NOT EXISTS "public"."docs" ("id" TEXT PRIMARY KEY, "content" TEXT NOT NULL, ` +
+				`"metadata" JSONB NOT NULL DEFAULT '{}', "embedding" vector(3), ` +
+				`"content_tsv" tsvector GENERATED ALWAYS AS (to_tsvector('english'::regconfig, "content")) STORED)`).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(`CREATE INDEX IF NOT EXISTS "docs_embedding_idx" ON "public"."docs" USING hnsw ("embedding" vector_cosine_ops) WITH (m = 16, ef_construction = 64)`).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(`CREATE INDEX IF NOT EXISTS "docs_metadata_idx" ON "public"."docs" USING gin ("metadata" jsonb_path_ops)`).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(`CREATE INDEX IF NOT EXISTS "docs_content_tsv_idx" ON "public"."docs" USING gin ("content_tsv")`).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+
+			_, err := NewIndexer(ctx, &IndexerConfig{
+				DB:          db,
+				Table:       "public.docs",
+				CreateTable: &TableConfig{Dimension: 3, TextSearchConfig: "english"},
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(mock.ExpectationsWereMet(), convey.ShouldBeNil)
+		})
+
+		convey.Convey("test create ivfflat table", func() {
+			mock.ExpectExec(`CREATE EXTENSION IF NOT EXISTS vector`).WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(`CREATE TABLE IF NOT EXISTS "docs" ("doc_id" TEXT PRIMARY KEY, "content" TEXT NOT NULL, ` +
+				`"meta" JSONB NOT NULL DEFAULT '{}', "embedding" vector(3))`).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(`CREATE INDEX IF NOT EXISTS "docs_embedding_idx" ON "docs" USING ivfflat ("embedding" vector_l2_ops) WITH (lists = 10)`).
+				WillReturnError(fmt.Errorf("mock err"))
+
+			_, err := NewIndexer(ctx, &IndexerConfig{
+				DB:             db,
+				Table:          "docs",
+				IDColumn:       "doc_id",
+				MetadataColumn: "meta",
+				CreateTable: &TableConfig{
+					Dimension:            3,
+					Index:                IndexIVFFlat,
+					Metric:               MetricL2,
+					IVFFlat:              &IVFFlatConfig{Lists: 10},
+					DisableMetadataIndex: true,
+				},
+			})
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(mock.ExpectationsWereMet(), convey.ShouldBeNil)
+		})
+	})
+}
+
+func TestStore(t *testing.T) {
+	convey.Convey("test Store", t, func() {
+		ctx := context.Background()
+		db, mock := newMockDB(t)
+		emb := &mockEmbedding{}
+
+		i, err := NewIndexer(ctx, &IndexerConfig{DB: db, Table: "docs", Embedding: emb, BatchSize: 2})
+		convey.So(err, convey.ShouldBeNil)
+
+		convey.Convey("test default fields", func() {
+			mock.ExpectBegin()
+			mock.ExpectExec(`INSERT INTO "docs" ("content", "embedding", "id", "metadata") VALUES ($1, $2, $3, $4), ($5, $6, $7, $8), ($9, $10, $11, $12) `+
+				`ON CONFLICT ("id") DO UPDATE SET "content" = EXCLUDED."content", "embedding" = EXCLUDED."embedding", "metadata" = EXCLUDED."metadata"`).
+				WithArgs(
+					"ccc", "[3,99]", "1", `{"page":2}`,
+					"b", "[1,98]", "2", `{}`,
+					"dddd", "[4,100]", "3", `{}`,
+				).
+				WillReturnResult(sqlmock.NewResult(0, 3))
+			mock.ExpectCommit()
+
+			ids, err := i.Store(ctx, []*schema.Document{
+				{ID: "1", Content: "a", MetaData: map[string]any{"page": 1}},
+				{ID: "2", Content: "b"},
+				{ID: "3", Content: "dddd"},
+				{ID: "1", Content: "ccc", MetaData: map[string]any{"page": 2}},
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids, convey.ShouldResemble, []string{"1", "2", "3", "1"})
+			convey.So(emb.calls, convey.ShouldEqual, 2)
+			convey.So(mock.ExpectationsWereMet(), convey.ShouldBeNil)
+		})
+
+		convey.Convey("test custom fields", func() {
+			i.config.DocumentToFields = func(ctx context.Context, doc *schema.Document) (map[string]FieldValue, error) {
+				fields := map[string]FieldValue{
+					"id":    {Value: doc.ID},
+					"title": {Value: doc.MetaData["title"], EmbedKey: "title_vector", Stringify: func(val any) (string, error) { return fmt.Sprint(val), nil }},
+				}
+				if tags, ok := doc.MetaData["tags"]; ok {
+					fields["tags"] = FieldValue{Value: tags}
+				}
+				return fields, nil
+			}
+
+			mock.ExpectBegin()
+			mock.ExpectExec(`INSERT INTO "docs" ("id", "tags", "title", "title_vector") VALUES ($1, $2, $3, $4), ($5, DEFAULT, $6, $7) `+
+				`ON CONFLICT ("id") DO UPDATE SET "tags" = EXCLUDED."tags", "title" = EXCLUDED."title", "title_vector" = EXCLUDED."title_vector"`).
+				WithArgs("1", `["x","y"]`, 10, "[2,49]", "2", "t", "[1,116]").
+				WillReturnResult(sqlmock.NewResult(0, 2))
+			mock.ExpectCommit()
+
+			_, err := i.Store(ctx, []*schema.Document{
+				{ID: "1", MetaData: map[string]any{"title": 10, "tags": []string{"x", "y"}}},
+				{ID: "2", MetaData: map[string]any{"title": "t"}},
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(mock.ExpectationsWereMet(), convey.ShouldBeNil)
+		})
+
+		convey.Convey("test rollback", func() {
+			mock.ExpectBegin()
+			mock.ExpectExec(`INSERT INTO "docs" ("content", "embedding", "id", "metadata") VALUES ($1, $2, $3, $4) ` +
+				`ON CONFLICT ("id") DO UPDATE SET "content" = EXCLUDED."content", "embedding" = EXCLUDED."embedding", "metadata" = EXCLUDED."metadata"`).
+				WillReturnError(fmt.Errorf("mock err"))
+			mock.ExpectRollback()
+
+			_, err := i.Store(ctx, []*schema.Document{{ID: "1", Content: "a"}})
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(mock.ExpectationsWereMet(), convey.ShouldBeNil)
+		})
+
+		convey.Convey("test invalid fields", func() {
+			_, err := i.Store(ctx, []*schema.Document{{ID: "1"}})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			i.config.DocumentToFields = func(ctx context.Context, doc *schema.Document) (map[string]FieldValue, error) {
+				return map[string]FieldValue{"content": {Value: doc.Content}}, nil
+			}
+			_, err = i.Store(ctx, []*schema.Document{{ID: "1", Content: "a"}})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			i.config.DocumentToFields = func(ctx context.Context, doc *schema.Document) (map[string]FieldValue, error) {
+				return map[string]FieldValue{"id": {Value: doc.ID}, "content": {Value: 1, EmbedKey: "embedding"}}, nil
+			}
+			_, err = i.Store(ctx, []*schema.Document{{ID: "1", Content: "a"}})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			i.config.DocumentToFields = func(ctx context.Context, doc *schema.Document) (map[string]FieldValue, error) {
+				return map[string]FieldValue{"id": {Value: doc.ID, EmbedKey: "content"}, "content": {Value: doc.Content}}, nil
+			}
+			_, err = i.Store(ctx, []*schema.Document{{ID: "1", Content: "a"}})
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(mock.ExpectationsWereMet(), convey.ShouldBeNil)
+		})
+	})
+}
+
+func TestUtils(t *testing.T) {
+	convey.Convey("test utils", t, func() {
+		convey.So(quoteIdent(`a."b`), convey.ShouldEqual, `"a"."""b"`)
+		convey.So(quoteLiteral(`it's`), convey.ShouldEqual, `'it''s'`)
+		convey.So(vectorLiteral([]float64{1, 0.5, -2e-8}), convey.ShouldEqual, "[1,0.5,-2e-08]")
+		convey.So(placeholders(3, 2), convey.ShouldEqual, "$3,$4")
+
+		for _, c := range []struct {
+			in, out any
+		}{
+			{nil, nil},
+			{"a", "a"},
+			{1.5, 1.5},
+			{[]byte("a"), []byte("a")},
+			{map[string]any{"a": 1}, `{"a":1}`},
+			{[]int{1, 2}, `[1,2]`},
+			{&struct{ A int }{1}, `{"A":1}`},
+		} {
+			v, err := encodeValue(c.in)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(v, convey.ShouldResemble, c.out)
+		}
+	})
+}
@@ -0,0 +1,219 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pgvector
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Delete deletes the documents of ids.
+// It implements mutation.Deleter of github.com/cloudwego/eino-ext/components/indexer/mutation.
+func (i *Indexer) Delete(ctx context.Context, ids []string, opts ...indexer.Option) (deleted int64, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{
+		Extra: map[string]any{extraKeyOperation: operationDelete, extraKeyIDs: ids},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	for start := 0; start < len(ids); start += maxParams {
+		end := start + maxParams
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		query := fmt.Sprintf("DELETE FROM %s WHERE %s IN (%s)",
+			quoteIdent(i.config.Table), quoteIdent(i.config.IDColumn), placeholders(1, end-start))
+		n, err := execAffected(ctx, i.config.DB, query, toArgs(ids[start:end])...)
+		if err != nil {
+			return deleted, fmt.Errorf("[Delete] %w", err)
+		}
+		deleted += n
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{Extra: map[string]any{extraKeyDeleted: deleted}})
+
+	return deleted, nil
+}
+
+// DeleteByFilter deletes the documents whose metadata contains every key - value pair of filter,
+// matched by the jsonb containment operator @> on MetadataColumn.
+func (i *Indexer) DeleteByFilter(ctx context.Context, filter map[string]any, opts ...indexer.Option) (deleted int64, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{
+		Extra: map[string]any{extraKeyOperation: operationDelete, extraKeyFilter: filter},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	if len(filter) == 0 {
+		return 0, fmt.Errorf("[DeleteByFilter] empty filter")
+	}
+
+	contains, err := json.Marshal(filter)
+	if err != nil {
+		return 0, fmt.Errorf("[DeleteByFilter] marshal filter failed, %w", err)
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE %s @> $1::jsonb", quoteIdent(i.config.Table), quoteIdent(i.config.MetadataColumn))
+	if deleted, err = execAffected(ctx, i.config.DB, query, string(contains)); err != nil {
+		return 0, fmt.Errorf("[DeleteByFilter] %w", err)
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{Extra: map[string]any{extraKeyDeleted: deleted}})
+
+	return deleted, nil
+}
+
+// Upsert stores docs, which overwrites the documents of the same ids, then deletes the other documents of their parents,
+// the parent of a doc is its ParentKey metadata, matched in MetadataColumn. Both run in one transaction.
+// It implements mutation.Upserter of github.com/cloudwego/eino-ext/components/indexer/mutation.
+func (i *Indexer) Upsert(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{
+		Docs:  docs,
+		Extra: map[string]any{extraKeyOperation: operationUpsert},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	options := indexer.GetCommonOptions(&indexer.Options{
+		Embedding: i.config.Embedding,
+	}, opts...)
+
+	parents, err := getParents(docs, i.config.ParentKey)
+	if err != nil {
+		return nil, fmt.Errorf("[Upsert] %w", err)
+	}
+
+	rows, err := i.makeRows(ctx, docs, options.Embedding)
+	if err != nil {
+		return nil, err
+	}
+
+	ids = iter(docs, func(doc *schema.Document) string { return doc.ID })
+
+	var deleted int64
+	err = i.withTx(ctx, func(tx *sql.Tx) error {
+		if err := i.insertRows(ctx, tx, rows); err != nil {
+			return err
+		}
+
+		if len(parents) == 0 {
+			return nil
+		}
+
+		query, args, err := i.deleteStaleStatement(parents, ids)
+		if err != nil {
+			return err
+		}
+
+		deleted, err = execAffected(ctx, tx, query, args...)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[Upsert] %w", err)
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{
+		IDs:   ids,
+		Extra: map[string]any{extraKeyDeleted: deleted},
+	})
+
+	return ids, nil
+}
+
+// deleteStaleStatement deletes the documents of parents except ids.
+func (i *Indexer) deleteStaleStatement(parents []any, ids []string) (string, []any, error) {
+	var (
+		args  []any
+		conds []string
+	)
+
+	metadata := quoteIdent(i.config.MetadataColumn)
+	for _, parent := range parents {
+		contains, err := json.Marshal(map[string]any{i.config.ParentKey: parent})
+		if err != nil {
+			return "", nil, fmt.Errorf("marshal parent failed, %w", err)
+		}
+		args = append(args, string(contains))
+		conds = append(conds, fmt.Sprintf("%s @> $%d::jsonb", metadata, len(args)))
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE (%s) AND %s NOT IN (%s)",
+		quoteIdent(i.config.Table), strings.Join(conds, " OR "), quoteIdent(i.config.IDColumn), placeholders(len(args)+1, len(ids)))
+
+	return query, append(args, toArgs(ids)...), nil
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// execAffected executes query and returns the number of affected rows.
+func execAffected(ctx context.Context, db execer, query string, args ...any) (int64, error) {
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("exec failed, %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("get affected rows failed, %w", err)
+	}
+
+	return n, nil
+}
+
+// getParents returns the distinct parents of docs in order.
+func getParents(docs []*schema.Document, parentKey string) ([]any, error) {
+	var parents []any
+	seen := make(map[any]struct{})
+	for _, doc := range docs {
+		parent, ok := doc.MetaData[parentKey]
+		if !ok || parent == nil {
+			return nil, fmt.Errorf("parent metadata %q not found in document, id=%s", parentKey, doc.ID)
+		}
+		if _, found := seen[parent]; !found {
+			seen[parent] = struct{}{}
+			parents = append(parents, parent)
+		}
+	}
+	return parents, nil
+}
+
+func toArgs(ids []string) []any {
+	return iter(ids, func(id string) any { return id })
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pgvector
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/indexer/mutation"
+)
+
+func TestMutation(t *testing.T) {
+	convey.Convey("test mutation", t, func() {
+		ctx := context.Background()
+		db, mock := newMockDB(t)
+
+		i, err := NewIndexer(ctx, &IndexerConfig{DB: db, Table: "docs", Embedding: &mockEmbedding{}})
+		convey.So(err, convey.ShouldBeNil)
+
+		var deleted []int64
+		ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, callbacks.NewHandlerBuilder().
+			OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+				if n, ok := indexer.ConvCallbackOutput(output).Extra[extraKeyDeleted].(int64); ok {
+					deleted = append(deleted, n)
+				}
+				return ctx
+			}).Build())
+
+		convey.Convey("test delete", func() {
+			mock.ExpectExec(`DELETE FROM "docs" WHERE "id" IN ($1,$2)`).
+				WithArgs("1", "2").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			n, err := i.Delete(ctx, []string{"1", "2"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(n, convey.ShouldEqual, 1)
+
+			n, err = i.Delete(ctx, nil)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(n, convey.ShouldEqual, 0)
+			convey.So(deleted, convey.ShouldResemble, []int64{1, 0})
+			convey.So(mock.ExpectationsWereMet(), convey.ShouldBeNil)
+		})
+
+		convey.Convey("test delete by filter", func() {
+			mock.ExpectExec(`DELETE FROM "docs" WHERE "metadata" @> $1::jsonb`).
+				WithArgs(`{"_source":"a.md","page":1}`).
+				WillReturnResult(sqlmock.NewResult(0, 3))
+
+			n, err := i.DeleteByFilter(ctx, map[string]any{"_source": "a.md", "page": 1})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(n, convey.ShouldEqual, 3)
+
+			_, err = i.DeleteByFilter(ctx, nil)
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(mock.ExpectationsWereMet(), convey.ShouldBeNil)
+		})
+
+		convey.Convey("test upsert", func() {
+			mock.ExpectBegin()
+			mock.ExpectExec(`INSERT INTO "docs" ("content", "embedding", "id", "metadata") VALUES ($1, $2, $3, $4), ($5, $6, $7, $8) `+
+				`ON CONFLICT ("id") DO UPDATE SET "content" = EXCLUDED."content", "embedding" = EXCLUDED."embedding", "metadata" = EXCLUDED."metadata"`).
+				WithArgs("a", "[1,97]", "a_1", `{"_source":"a.md"}`, "b", "[1,98]", "b_1", `{"_source":"b.md"}`).
+				WillReturnResult(sqlmock.NewResult(0, 2))
+			mock.ExpectExec(`DELETE FROM "docs" WHERE ("metadata" @> $1::jsonb OR "metadata" @> $2::jsonb) AND "id" NOT IN ($3,$4)`).
+				WithArgs(`{"_source":"a.md"}`, `{"_source":"b.md"}`, "a_1", "b_1").
+				WillReturnResult(sqlmock.NewResult(0, 4))
+			mock.ExpectCommit()
+
+			ids, err := i.Upsert(ctx, []*schema.Document{
+				{ID: "a_1", Content: "a", MetaData: map[string]any{"_source": "a.md"}},
+				{ID: "b_1", Content: "b", MetaData: map[string]any{"_source": "b.md"}},
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids, convey.ShouldResemble, []string{"a_1", "b_1"})
+			convey.So(deleted, convey.ShouldResemble, []int64{4})
+			convey.So(mock.ExpectationsWereMet(), convey.ShouldBeNil)
+		})
+
+		convey.Convey("test upsert rollback", func() {
+			mock.ExpectBegin()
+			mock.ExpectExec(`INSERT INTO "docs" ("content", "embedding", "id", "metadata") VALUES ($1, $2, $3, $4) ` +
+				`ON CONFLICT ("id") DO UPDATE SET "content" = EXCLUDED."content", "embedding" = EXCLUDED."embedding", "metadata" = EXCLUDED."metadata"`).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec(`DELETE FROM "docs" WHERE ("metadata" @> $1::jsonb) AND "id" NOT IN ($2)`).
+				WillReturnError(fmt.Errorf("mock err"))
+			mock.ExpectRollback()
+
+			_, err := i.Upsert(ctx, []*schema.Document{{ID: "a_1", Content: "a", MetaData: map[string]any{"_source": "a.md"}}})
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(mock.ExpectationsWereMet(), convey.ShouldBeNil)
+		})
+
+		convey.Convey("test upsert without parent", func() {
+			_, err := i.Upsert(ctx, []*schema.Document{{ID: "a_1", Content: "a"}})
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(mock.ExpectationsWereMet(), convey.ShouldBeNil)
+		})
+	})
+}
+
+var (
+	_ mutation.Deleter  = (*Indexer)(nil)
+	_ mutation.Upserter = (*Indexer)(nil)
+)
+
+func TestMutationConsts(t *testing.T) {
+	convey.Convey("test callback extra keys and operations same as mutation", t, func() {
+		convey.So(extraKeyOperation, convey.ShouldEqual, mutation.ExtraKeyOperation)
+		convey.So(extraKeyIDs, convey.ShouldEqual, mutation.ExtraKeyIDs)
+		convey.So(extraKeyFilter, convey.ShouldEqual, mutation.ExtraKeyFilter)
+		convey.So(extraKeyDeleted, convey.ShouldEqual, mutation.ExtraKeyDeleted)
+		convey.So(operationDelete, convey.ShouldEqual, mutation.OperationDelete)
+		convey.So(operationUpsert, convey.ShouldEqual, mutation.OperationUpsert)
+		convey.So(defaultParentKey, convey.ShouldEqual, mutation.MetaKeyParent)
+	})
+}
@@ -0,0 +1,198 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pgvector
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Metric is the distance of vectors, which the vector index is built for.
+// It must match the metric of the retriever, otherwise the index isn't used.
+type Metric string
+
+const (
+	// MetricCosine cosine distance, operator <=>.
+	MetricCosine Metric = "cosine"
+	// MetricL2 euclidean distance, operator <->.
+	MetricL2 Metric = "l2"
+	// MetricIP negative inner product, operator <#>.
+	MetricIP Metric = "ip"
+)
+
+func (m Metric) opClass() (string, error) {
+	switch m {
+	case MetricCosine:
+		return "vector_cosine_ops", nil
+	case MetricL2:
+		return "vector_l2_ops", nil
+	case MetricIP:
+		return "vector_ip_ops", nil
+	default:
+		return "", fmt.Errorf("unknown metric: %s", m)
+	}
+}
+
+// IndexType is the approximate nearest neighbor index of the vector column.
+// see: https://github.com/pgvector/pgvector#indexing
+type IndexType string
+
+const (
+	IndexHNSW    IndexType = "hnsw"
+	IndexIVFFlat IndexType = "ivfflat"
+	// IndexNone creates no vector index, searches are exact and scan the whole table.
+	IndexNone IndexType = "none"
+)
+
+// TableConfig creates the table of the default DocumentToFields, with columns
+// IDColumn TEXT PRIMARY KEY, content TEXT, MetadataColumn JSONB and embedding vector(Dimension),
+// and a tsvector column content_tsv generated from content if TextSearchConfig is set.
+type TableConfig struct {
+	// Dimension of the embedding column.
+	// Required.
+	Dimension int
+	// Index of the embedding column.
+	// Default IndexHNSW.
+	Index IndexType
+	// Metric the vector index is built for.
+	// Default MetricCosine.
+	Metric Metric
+	// HNSW parameters of IndexHNSW.
+	// Default &HNSWConfig{M: 16, EfConstruction: 64}.
+	HNSW *HNSWConfig
+	// IVFFlat parameters of IndexIVFFlat.
+	// Default &IVFFlatConfig{Lists: 100}.
+	IVFFlat *IVFFlatConfig
+	// TextSearchConfig text search configuration of the generated content_tsv column for hybrid search, e.g. "english" or "simple".
+	// Default empty, no tsvector column is created.
+	TextSearchConfig string
+	// DisableMetadataIndex skips the GIN index of MetadataColumn, which filters of DeleteByFilter, Upsert and the retriever use.
+	// Default false.
+	DisableMetadataIndex bool
+}
+
+type HNSWConfig struct {
+	// M max number of connections per layer.
+	M int
+	// EfConstruction size of the dynamic candidate list for constructing the graph.
+	EfConstruction int
+}
+
+type IVFFlatConfig struct {
+	// Lists number of inverted lists, rows/1000 is a good start for up to 1M rows.
+	// The index should be created after the table has data, so create the table in advance for IVFFlat if possible.
+	Lists int
+}
+
+func (t *TableConfig) check() error {
+	if t.Dimension <= 0 {
+		return fmt.Errorf("[TableConfig] invalid dimension: %d", t.Dimension)
+	}
+
+	if t.Index == "" {
+		t.Index = IndexHNSW
+	}
+
+	if t.Metric == "" {
+		t.Metric = MetricCosine
+	}
+
+	if _, err := t.Metric.opClass(); err != nil {
+		return fmt.Errorf("[TableConfig] %w", err)
+	}
+
+	switch t.Index {
+	case IndexHNSW:
+		if t.HNSW == nil {
+			t.HNSW = &HNSWConfig{}
+		}
+		if t.HNSW.M == 0 {
+			t.HNSW.M = 16
+		}
+		if t.HNSW.EfConstruction == 0 {
+			t.HNSW.EfConstruction = 64
+		}
+	case IndexIVFFlat:
+		if t.IVFFlat == nil {
+			t.IVFFlat = &IVFFlatConfig{}
+		}
+		if t.IVFFlat.Lists == 0 {
+			t.IVFFlat.Lists = 100
+		}
+	case IndexNone:
+	default:
+		return fmt.Errorf("[TableConfig] unknown index type: %s", t.Index)
+	}
+
+	return nil
+}
+
+// createTableStatements returns the statements creating the vector extension, table and its indexes if not exist.
+func createTableStatements(table, idColumn, metadataColumn string, t *TableConfig) []string {
+	name := quoteIdent(table)
+	// indexes are created in the schema of the table, their names must not be qualified
+	base := table[strings.LastIndex(table, ".")+1:]
+
+	columns := []string{
+		fmt.Sprintf("%s TEXT PRIMARY KEY", quoteIdent(idColumn)),
+		fmt.Sprintf("%s TEXT NOT NULL", quoteIdent(defaultContentColumn)),
+		fmt.Sprintf("%s JSONB NOT NULL DEFAULT '{}'", quoteIdent(metadataColumn)),
+		fmt.Sprintf("%s vector(%d)", quoteIdent(defaultVectorColumn), t.Dimension),
+	}
+	if t.TextSearchConfig != "" {
+		columns = append(columns, fmt.Sprintf("%s tsvector GENERATED ALWAYS AS (to_tsvector(%s::regconfig, %s)) STORED",
+			quoteIdent(defaultTSVColumn), quoteLiteral(t.TextSearchConfig), quoteIdent(defaultContentColumn)))
+	}
+
+	stmts := []string{
+		"CREATE EXTENSION IF NOT EXISTS vector",
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s)", name, strings.Join(columns, ", ")),
+	}
+
+	opClass, _ := t.Metric.opClass()
+	vectorIndex := fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s USING %s (%s %s)",
+		quoteIdent(base+"_"+defaultVectorColumn+"_idx"), name, t.Index, quoteIdent(defaultVectorColumn), opClass)
+	switch t.Index {
+	case IndexHNSW:
+		stmts = append(stmts, fmt.Sprintf("%s WITH (m = %d, ef_construction = %d)", vectorIndex, t.HNSW.M, t.HNSW.EfConstruction))
+	case IndexIVFFlat:
+		stmts = append(stmts, fmt.Sprintf("%s WITH (lists = %d)", vectorIndex, t.IVFFlat.Lists))
+	}
+
+	if !t.DisableMetadataIndex {
+		stmts = append(stmts, fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s USING gin (%s jsonb_path_ops)",
+			quoteIdent(base+"_"+metadataColumn+"_idx"), name, quoteIdent(metadataColumn)))
+	}
+
+	if t.TextSearchConfig != "" {
+		stmts = append(stmts, fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s USING gin (%s)",
+			quoteIdent(base+"_"+defaultTSVColumn+"_idx"), name, quoteIdent(defaultTSVColumn)))
+	}
+
+	return stmts
+}
+
+func createTable(ctx context.Context, db *sql.DB, stmts []string) error {
+	for _, stmt := range stmts {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("[createTable] exec failed, sql=%s, %w", stmt, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pgvector
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// quoteIdent quotes a possibly schema qualified identifier, e.g. public.docs to "public"."docs".
+func quoteIdent(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = `"` + strings.ReplaceAll(part, `"`, `""`) + `"`
+	}
+	return strings.Join(parts, ".")
+}
+
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// vectorLiteral formats v as the text input of the pgvector vector type, e.g. [1,2.5,3].
+func vectorLiteral(v []float64) string {
+	var sb strings.Builder
+	sb.WriteByte('[')
+	for i, f := range v {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(strconv.FormatFloat(f, 'g', -1, 32))
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+// encodeValue converts v to a value accepted by database/sql drivers,
+// maps, slices and structs are encoded to json text for json and jsonb columns.
+func encodeValue(v any) (any, error) {
+	switch v.(type) {
+	case nil:
+		return nil, nil
+	case driver.Valuer, time.Time, []byte:
+		return v, nil
+	}
+
+	switch reflect.Indirect(reflect.ValueOf(v)).Kind() {
+	case reflect.Map, reflect.Struct, reflect.Array, reflect.Slice:
+	default:
+		return v, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("marshal value failed, %w", err)
+	}
+	return string(b), nil
+}
+
+// placeholders returns n comma separated placeholders from $start, e.g. $3,$4.
+func placeholders(start, n int) string {
+	ps := make([]string, n)
+	for i := range ps {
+		ps[i] = "$" + strconv.Itoa(start+i)
+	}
+	return strings.Join(ps, ",")
+}
@@ -0,0 +1,56 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+const typ = "Qdrant"
+
+func GetType() string {
+	return typ
+}
+
+const (
+	defaultBatchSize       = 10
+	defaultUpsertBatchSize = 64
+
+	defaultContentKey  = "content"
+	defaultMetadataKey = "metadata"
+
+	// payloadKeyDocID is the payload key of the document id, as point ids are unsigned integers or UUIDs.
+	payloadKeyDocID = "doc_id"
+
+	// docMetaDataKeyDenseVector and docMetaDataKeySparseVector are the metadata keys of schema.Document.WithDenseVector
+	// and schema.Document.WithSparseVector, which are stored as vectors rather than payload.
+	docMetaDataKeyDenseVector  = "_dense_vector"
+	docMetaDataKeySparseVector = "_sparse_vector"
+)
+
+const (
+	// defaultParentKey matches mutation.MetaKeyParent of github.com/cloudwego/eino-ext/components/indexer/mutation.
+	defaultParentKey = "_source"
+)
+
+// callback extra keys and operations, same as the ones of github.com/cloudwego/eino-ext/components/indexer/mutation,
+// checked by TestMutationConsts.
+const (
+	extraKeyOperation = "operation"
+	extraKeyIDs       = "ids"
+	extraKeyFilter    = "filter"
+	extraKeyDeleted   = "deleted"
+
+	operationDelete = "delete"
+	operationUpsert = "upsert"
+)
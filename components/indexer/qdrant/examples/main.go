@@ -0,0 +1,117 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/indexer/qdrant"
+	qdrantcli "github.com/cloudwego/eino-ext/libs/acl/qdrant"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// e.g. http://localhost:6333
+	cli, err := qdrantcli.NewRESTClient(&qdrantcli.Config{
+		BaseURL: os.Getenv("QDRANT_URL"),
+		APIKey:  os.Getenv("QDRANT_API_KEY"),
+	})
+	if err != nil {
+		log.Fatalf("NewRESTClient failed, err=%v", err)
+	}
+
+	idx, err := qdrant.NewIndexer(ctx, &qdrant.IndexerConfig{
+		Client:           cli,
+		Collection:       "eino_docs",
+		VectorName:       "dense",
+		SparseVectorName: "sparse",
+		SparseEmbedding:  termFrequencies, // replace it with real sparse embedding, e.g. BM25
+		CreateCollection: &qdrant.CollectionConfig{
+			Dimension:      26,
+			Distance:       qdrantcli.DistanceCosine,
+			SparseModifier: "idf",
+		},
+		Embedding: &letterEmbedding{}, // replace it with real embedding component
+	})
+	if err != nil {
+		log.Fatalf("NewIndexer failed, err=%v", err)
+	}
+
+	ids, err := idx.Store(ctx, []*schema.Document{
+		{ID: "intro_1", Content: "eino is a llm application framework", MetaData: map[string]any{"_source": "intro.md", "page": 1}},
+		{ID: "intro_2", Content: "eino components are composed by graphs", MetaData: map[string]any{"_source": "intro.md", "page": 2}},
+		{ID: "faq_1", Content: "how to install eino", MetaData: map[string]any{"_source": "faq.md", "page": 1}},
+	})
+	if err != nil {
+		log.Fatalf("Store failed, err=%v", err)
+	}
+	log.Printf("stored: %v", ids)
+
+	// replace every chunk of intro.md
+	ids, err = idx.Upsert(ctx, []*schema.Document{
+		{ID: "intro_1", Content: "eino is a go llm application framework", MetaData: map[string]any{"_source": "intro.md", "page": 1}},
+	})
+	if err != nil {
+		log.Fatalf("Upsert failed, err=%v", err)
+	}
+	log.Printf("upserted: %v", ids)
+
+	deleted, err := idx.DeleteByFilter(ctx, map[string]any{"_source": "faq.md"})
+	if err != nil {
+		log.Fatalf("DeleteByFilter failed, err=%v", err)
+	}
+	log.Printf("deleted: %d", deleted)
+}
+
+// letterEmbedding embeds a text to its letter frequencies, only for the example.
+type letterEmbedding struct{}
+
+func (l *letterEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = make([]float64, 26)
+		for _, c := range strings.ToLower(text) {
+			if c >= 'a' && c <= 'z' {
+				vectors[i][c-'a']++
+			}
+		}
+	}
+	return vectors, nil
+}
+
+// termFrequencies maps words to hashed indices with their counts, only for the example.
+func termFrequencies(ctx context.Context, texts []string) ([]map[int]float64, error) {
+	result := make([]map[int]float64, len(texts))
+	for i, text := range texts {
+		result[i] = map[int]float64{}
+		for _, word := range strings.Fields(strings.ToLower(text)) {
+			h := 0
+			for _, c := range word {
+				h = (h*31 + int(c)) % 100003
+			}
+			result[i][h]++
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,216 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cloudwego/eino-ext/libs/acl/qdrant"
+)
+
+// fakeQdrant is an in-memory qdrant REST api of the endpoints the indexer uses.
+type fakeQdrant struct {
+	mu          sync.Mutex
+	collections map[string]map[string]any
+	points      map[string]map[string]any // point id -> point json
+	requests    []string                  // method path?query
+	failPath    string
+}
+
+func newFakeQdrant(t *testing.T) (*fakeQdrant, qdrant.Client) {
+	f := &fakeQdrant{
+		collections: map[string]map[string]any{},
+		points:      map[string]map[string]any{},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(srv.Close)
+
+	cli, err := qdrant.NewRESTClient(&qdrant.Config{BaseURL: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f, cli
+}
+
+func (f *fakeQdrant) serve(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	req := r.Method + " " + r.URL.Path
+	if r.URL.RawQuery != "" {
+		req += "?" + r.URL.RawQuery
+	}
+	f.requests = append(f.requests, req)
+
+	var body map[string]any
+	_ = json.NewDecoder(r.Body).Decode(&body)
+
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/collections/"), "/", 2)
+	name, sub := parts[0], ""
+	if len(parts) == 2 {
+		sub = parts[1]
+	}
+
+	if f.failPath != "" && strings.HasSuffix(r.URL.Path, f.failPath) {
+		reply(w, http.StatusInternalServerError, nil, "service error")
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && sub == "exists":
+		_, ok := f.collections[name]
+		reply(w, http.StatusOK, map[string]any{"exists": ok}, "")
+	case r.Method == http.MethodPut && sub == "":
+		f.collections[name] = body
+		reply(w, http.StatusOK, true, "")
+	case r.Method == http.MethodPut && sub == "points":
+		for _, p := range body["points"].([]any) {
+			point := p.(map[string]any)
+			f.points[fmt.Sprint(point["id"])] = point
+		}
+		reply(w, http.StatusOK, map[string]any{"status": "completed"}, "")
+	case sub == "points/count":
+		filter, _ := body["filter"].(map[string]any)
+		reply(w, http.StatusOK, map[string]any{"count": len(f.match(filter))}, "")
+	case sub == "points/delete":
+		for _, id := range f.match(body["filter"].(map[string]any)) {
+			delete(f.points, id)
+		}
+		reply(w, http.StatusOK, map[string]any{"status": "completed"}, "")
+	default:
+		reply(w, http.StatusNotFound, nil, "not found")
+	}
+}
+
+func reply(w http.ResponseWriter, code int, result any, errMsg string) {
+	w.WriteHeader(code)
+	if errMsg != "" {
+		_ = json.NewEncoder(w).Encode(map[string]any{"status": map[string]any{"error": errMsg}})
+		return
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{"result": result, "status": "ok"})
+}
+
+// ids returns the sorted doc ids of stored points.
+func (f *fakeQdrant) ids() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var ids []string
+	for _, p := range f.points {
+		ids = append(ids, p["payload"].(map[string]any)[payloadKeyDocID].(string))
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (f *fakeQdrant) match(filter map[string]any) []string {
+	var ids []string
+	for id, p := range f.points {
+		if filter == nil || matchFilter(id, p["payload"].(map[string]any), filter) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func matchFilter(id string, payload, filter map[string]any) bool {
+	for _, c := range list(filter["must"]) {
+		if !matchCondition(id, payload, c) {
+			return false
+		}
+	}
+	for _, c := range list(filter["must_not"]) {
+		if matchCondition(id, payload, c) {
+			return false
+		}
+	}
+	should := list(filter["should"])
+	for _, c := range should {
+		if matchCondition(id, payload, c) {
+			return true
+		}
+	}
+	return len(should) == 0
+}
+
+func matchCondition(id string, payload, cond map[string]any) bool {
+	if ids, ok := cond["has_id"]; ok {
+		for _, v := range ids.([]any) {
+			if fmt.Sprint(v) == id {
+				return true
+			}
+		}
+		return false
+	}
+	if _, ok := cond["must"]; ok {
+		return matchFilter(id, payload, cond)
+	}
+	if _, ok := cond["should"]; ok {
+		return matchFilter(id, payload, cond)
+	}
+	if _, ok := cond["must_not"]; ok {
+		return matchFilter(id, payload, cond)
+	}
+
+	value := lookup(payload, cond["key"].(string))
+	if m, ok := cond["match"].(map[string]any); ok {
+		return fmt.Sprint(m["value"]) == fmt.Sprint(value)
+	}
+	if r, ok := cond["range"].(map[string]any); ok {
+		v, ok := value.(float64)
+		if !ok {
+			return false
+		}
+		if gte, ok := r["gte"].(float64); ok && v < gte {
+			return false
+		}
+		if lte, ok := r["lte"].(float64); ok && v > lte {
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+func lookup(payload map[string]any, key string) any {
+	var cur any = payload
+	for _, k := range strings.Split(key, ".") {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil
+		}
+		cur = m[k]
+	}
+	return cur
+}
+
+func list(v any) []map[string]any {
+	items, _ := v.([]any)
+	result := make([]map[string]any, len(items))
+	for i, item := range items {
+		result[i] = item.(map[string]any)
+	}
+	return result
+}
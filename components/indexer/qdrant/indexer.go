@@ -0,0 +1,370 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/libs/acl/qdrant"
+)
+
+type IndexerConfig struct {
+	// Client qdrant client, e.g. qdrant.NewRESTClient of github.com/cloudwego/eino-ext/libs/acl/qdrant.
+	// Required.
+	Client qdrant.Client
+	// Collection to store documents.
+	// Required.
+	Collection string
+	// CreateCollection creates the collection if it doesn't exist.
+	// Default nil, the collection should be created in advance.
+	CreateCollection *CollectionConfig
+	// VectorName of the dense vector, the vector of document content.
+	// Default "", the unnamed vector of the collection.
+	VectorName string
+	// SparseVectorName adds a sparse vector of this name to points, read from schema.Document.SparseVector(),
+	// or produced by SparseEmbedding if absent.
+	// Default "", no sparse vector. VectorName must be set with it, as the unnamed vector can't be mixed with named ones.
+	SparseVectorName string
+	// SparseEmbedding produces sparse vectors of the documents content, e.g. BM25 or SPLADE.
+	// Optional, documents without a sparse vector fail to store if it's not set.
+	SparseEmbedding func(ctx context.Context, texts []string) ([]map[int]float64, error)
+	// Embedding vectorization method, documents with schema.Document.DenseVector() are stored with it and not embedded.
+	Embedding embedding.Embedder
+	// BatchSize controls max texts size for embedding.
+	// Default is 10.
+	BatchSize int
+	// UpsertBatchSize controls max points size of an upsert request.
+	// Default is 64.
+	UpsertBatchSize int
+	// Async returns once qdrant receives the points, without waiting for them to be applied,
+	// so they may not be searchable right after Store returns.
+	// Default false, waits for the points to be applied.
+	Async bool
+	// ContentKey payload key of document content.
+	// Default "content".
+	ContentKey string
+	// MetadataKey payload key of document metadata, which DeleteByFilter and Upsert match.
+	// Default "metadata".
+	MetadataKey string
+	// ParentKey is the metadata key of the parent document of a chunk, which Upsert replaces the chunks of.
+	// Default is "_source", the source uri set by the file loader.
+	ParentKey string
+}
+
+type CollectionConfig struct {
+	// Dimension of the dense vector.
+	// Required.
+	Dimension int
+	// Distance of the dense vector.
+	// Default qdrant.DistanceCosine.
+	Distance qdrant.Distance
+	// OnDisk stores the dense vectors on disk rather than in memory.
+	OnDisk bool
+	// SparseModifier of the sparse vector, "idf" weights it by inverse document frequency, e.g. for BM25 term frequencies.
+	// Default "", no modifier.
+	SparseModifier string
+}
+
+type Indexer struct {
+	config *IndexerConfig
+}
+
+func NewIndexer(ctx context.Context, conf *IndexerConfig) (*Indexer, error) {
+	if conf.Client == nil {
+		return nil, fmt.Errorf("[NewIndexer] qdrant client not provided")
+	}
+
+	if conf.Collection == "" {
+		return nil, fmt.Errorf("[NewIndexer] collection not provided")
+	}
+
+	if conf.SparseVectorName != "" && conf.VectorName == "" {
+		return nil, fmt.Errorf("[NewIndexer] vector name not provided with sparse vector name")
+	}
+
+	if conf.SparseVectorName != "" && conf.SparseVectorName == conf.VectorName {
+		return nil, fmt.Errorf("[NewIndexer] duplicate vector name: %s", conf.VectorName)
+	}
+
+	if conf.BatchSize == 0 {
+		conf.BatchSize = defaultBatchSize
+	}
+
+	if conf.UpsertBatchSize == 0 {
+		conf.UpsertBatchSize = defaultUpsertBatchSize
+	}
+
+	if conf.ContentKey == "" {
+		conf.ContentKey = defaultContentKey
+	}
+
+	if conf.MetadataKey == "" {
+		conf.MetadataKey = defaultMetadataKey
+	}
+
+	if conf.ParentKey == "" {
+		conf.ParentKey = defaultParentKey
+	}
+
+	if conf.CreateCollection != nil {
+		if err := conf.createCollection(ctx); err != nil {
+			return nil, fmt.Errorf("[NewIndexer] %w", err)
+		}
+	}
+
+	return &Indexer{
+		config: conf,
+	}, nil
+}
+
+// createCollection creates the collection of the vectors of config if it doesn't exist.
+func (c *IndexerConfig) createCollection(ctx context.Context) error {
+	cc := c.CreateCollection
+	if cc.Dimension <= 0 {
+		return fmt.Errorf("invalid dimension: %d", cc.Dimension)
+	}
+
+	exists, err := c.Client.CollectionExists(ctx, c.Collection)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	params := &qdrant.VectorParams{Size: cc.Dimension, Distance: cc.Distance}
+	if params.Distance == "" {
+		params.Distance = qdrant.DistanceCosine
+	}
+	if cc.OnDisk {
+		onDisk := true
+		params.OnDisk = &onDisk
+	}
+
+	req := &qdrant.CreateCollectionRequest{Vectors: qdrant.VectorsConfig{c.VectorName: params}}
+	if c.SparseVectorName != "" {
+		req.SparseVectors = map[string]*qdrant.SparseVectorParams{
+			c.SparseVectorName: {Modifier: cc.SparseModifier},
+		}
+	}
+
+	return c.Client.CreateCollection(ctx, c.Collection, req)
+}
+
+func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{Docs: docs})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	options := indexer.GetCommonOptions(&indexer.Options{
+		Embedding: i.config.Embedding,
+	}, opts...)
+
+	points, err := i.makePoints(ctx, docs, options.Embedding)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = i.upsertPoints(ctx, points); err != nil {
+		return nil, err
+	}
+
+	ids = iter(docs, func(doc *schema.Document) string { return doc.ID })
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{IDs: ids})
+
+	return ids, nil
+}
+
+// makePoints maps docs to points, embedding the content of docs without a dense vector,
+// and of docs without a sparse vector if SparseVectorName is set.
+func (i *Indexer) makePoints(ctx context.Context, docs []*schema.Document, emb embedding.Embedder) ([]*qdrant.Point, error) {
+	var (
+		points       = make([]*qdrant.Point, len(docs))
+		denseTexts   []string
+		denseIdx     []int
+		sparseTexts  []string
+		sparseIdx    []int
+		sparseByName = i.config.SparseVectorName != ""
+	)
+
+	for idx, doc := range docs {
+		if doc.ID == "" {
+			return nil, fmt.Errorf("[makePoints] document id not provided, index=%d", idx)
+		}
+
+		metadata := make(map[string]any, len(doc.MetaData))
+		for k, v := range doc.MetaData {
+			if k != docMetaDataKeyDenseVector && k != docMetaDataKeySparseVector {
+				metadata[k] = v
+			}
+		}
+
+		p := &qdrant.Point{
+			ID: qdrant.PointID(doc.ID),
+			Vector: qdrant.Vectors{
+				Dense: map[string][]float64{i.config.VectorName: doc.DenseVector()},
+			},
+			Payload: map[string]any{
+				payloadKeyDocID:      doc.ID,
+				i.config.ContentKey:  doc.Content,
+				i.config.MetadataKey: metadata,
+			},
+		}
+		if doc.DenseVector() == nil {
+			denseTexts = append(denseTexts, doc.Content)
+			denseIdx = append(denseIdx, idx)
+		}
+
+		if sparseByName {
+			if sparse := doc.SparseVector(); sparse != nil {
+				sv, err := qdrant.NewSparseVector(sparse)
+				if err != nil {
+					return nil, fmt.Errorf("[makePoints] invalid sparse vector, id=%s, %w", doc.ID, err)
+				}
+				p.Vector.Sparse = map[string]*qdrant.SparseVector{i.config.SparseVectorName: sv}
+			} else {
+				sparseTexts = append(sparseTexts, doc.Content)
+				sparseIdx = append(sparseIdx, idx)
+			}
+		}
+
+		points[idx] = p
+	}
+
+	vectors, err := i.embed(ctx, denseTexts, emb)
+	if err != nil {
+		return nil, err
+	}
+	for n, idx := range denseIdx {
+		points[idx].Vector.Dense[i.config.VectorName] = vectors[n]
+	}
+
+	if len(sparseTexts) == 0 {
+		return points, nil
+	}
+
+	if i.config.SparseEmbedding == nil {
+		return nil, fmt.Errorf("[makePoints] sparse embedding not provided for documents without sparse vector")
+	}
+
+	sparse, err := i.config.SparseEmbedding(ctx, sparseTexts)
+	if err != nil {
+		return nil, fmt.Errorf("[makePoints] sparse embedding failed, %w", err)
+	}
+	if len(sparse) != len(sparseTexts) {
+		return nil, fmt.Errorf("[makePoints] invalid sparse vector length, expected=%d, got=%d", len(sparseTexts), len(sparse))
+	}
+	for n, idx := range sparseIdx {
+		sv, err := qdrant.NewSparseVector(sparse[n])
+		if err != nil {
+			return nil, fmt.Errorf("[makePoints] invalid sparse vector, id=%s, %w", docs[idx].ID, err)
+		}
+		points[idx].Vector.Sparse = map[string]*qdrant.SparseVector{i.config.SparseVectorName: sv}
+	}
+
+	return points, nil
+}
+
+// embed vectorizes texts in batches of BatchSize.
+func (i *Indexer) embed(ctx context.Context, texts []string, emb embedding.Embedder) ([][]float64, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+
+	if emb == nil {
+		return nil, fmt.Errorf("[embed] embedding method not provided")
+	}
+
+	vectors := make([][]float64, 0, len(texts))
+	for start := 0; start < len(texts); start += i.config.BatchSize {
+		end := start + i.config.BatchSize
+		if end > len(texts) {
+			end = len(texts)
+		}
+
+		batch, err := emb.EmbedStrings(i.makeEmbeddingCtx(ctx, emb), texts[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("[embed] embedding failed, %w", err)
+		}
+
+		if len(batch) != end-start {
+			return nil, fmt.Errorf("[embed] invalid vector length, expected=%d, got=%d", end-start, len(batch))
+		}
+
+		vectors = append(vectors, batch...)
+	}
+
+	return vectors, nil
+}
+
+// upsertPoints upserts points in batches of UpsertBatchSize.
+func (i *Indexer) upsertPoints(ctx context.Context, points []*qdrant.Point) error {
+	for start := 0; start < len(points); start += i.config.UpsertBatchSize {
+		end := start + i.config.UpsertBatchSize
+		if end > len(points) {
+			end = len(points)
+		}
+
+		if err := i.config.Client.UpsertPoints(ctx, i.config.Collection, points[start:end], !i.config.Async); err != nil {
+			return fmt.Errorf("[upsertPoints] %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (i *Indexer) makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfEmbedding,
+	}
+
+	if embType, ok := components.GetType(emb); ok {
+		runInfo.Type = embType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+func (i *Indexer) GetType() string {
+	return typ
+}
+
+func (i *Indexer) IsCallbacksEnabled() bool {
+	return true
+}
+
+func iter[T, D any](ss []T, fn func(T) D) []D {
+	r := make([]D, len(ss))
+	for i := range ss {
+		r[i] = fn(ss[i])
+	}
+	return r
+}
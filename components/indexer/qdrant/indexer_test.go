@@ -0,0 +1,281 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+type mockEmbedding struct {
+	calls int
+	err   error
+	dims  int
+}
+
+func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = make([]float64, m.dims)
+		vectors[i][0] = float64(len(text))
+	}
+	return vectors, nil
+}
+
+func TestNewIndexer(t *testing.T) {
+	convey.Convey("test NewIndexer", t, func() {
+		ctx := context.Background()
+		fake, cli := newFakeQdrant(t)
+
+		convey.Convey("test invalid config", func() {
+			_, err := NewIndexer(ctx, &IndexerConfig{})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			_, err = NewIndexer(ctx, &IndexerConfig{Client: cli})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			_, err = NewIndexer(ctx, &IndexerConfig{Client: cli, Collection: "docs", SparseVectorName: "sparse"})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			_, err = NewIndexer(ctx, &IndexerConfig{Client: cli, Collection: "docs", VectorName: "v", SparseVectorName: "v"})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			_, err = NewIndexer(ctx, &IndexerConfig{Client: cli, Collection: "docs", CreateCollection: &CollectionConfig{}})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test defaults", func() {
+			i, err := NewIndexer(ctx, &IndexerConfig{Client: cli, Collection: "docs"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(i.config.BatchSize, convey.ShouldEqual, defaultBatchSize)
+			convey.So(i.config.UpsertBatchSize, convey.ShouldEqual, defaultUpsertBatchSize)
+			convey.So(i.config.ContentKey, convey.ShouldEqual, defaultContentKey)
+			convey.So(i.config.MetadataKey, convey.ShouldEqual, defaultMetadataKey)
+			convey.So(i.config.ParentKey, convey.ShouldEqual, defaultParentKey)
+			convey.So(i.GetType(), convey.ShouldEqual, GetType())
+			convey.So(i.IsCallbacksEnabled(), convey.ShouldBeTrue)
+			convey.So(fake.requests, convey.ShouldBeEmpty)
+		})
+
+		convey.Convey("test create collection", func() {
+			_, err := NewIndexer(ctx, &IndexerConfig{
+				Client:           cli,
+				Collection:       "docs",
+				VectorName:       "dense",
+				SparseVectorName: "sparse",
+				CreateCollection: &CollectionConfig{Dimension: 4, OnDisk: true, SparseModifier: "idf"},
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(fake.collections["docs"], convey.ShouldResemble, map[string]any{
+				"vectors":        map[string]any{"dense": map[string]any{"size": 4.0, "distance": "Cosine", "on_disk": true}},
+				"sparse_vectors": map[string]any{"sparse": map[string]any{"modifier": "idf"}},
+			})
+
+			// existing collections are kept
+			fake.requests = nil
+			_, err = NewIndexer(ctx, &IndexerConfig{
+				Client:           cli,
+				Collection:       "docs",
+				CreateCollection: &CollectionConfig{Dimension: 8, Distance: "Dot"},
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(fake.requests, convey.ShouldResemble, []string{"GET /collections/docs/exists"})
+		})
+
+		convey.Convey("test create unnamed vector collection", func() {
+			_, err := NewIndexer(ctx, &IndexerConfig{
+				Client:           cli,
+				Collection:       "plain",
+				CreateCollection: &CollectionConfig{Dimension: 2, Distance: "Dot"},
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(fake.collections["plain"], convey.ShouldResemble, map[string]any{
+				"vectors": map[string]any{"size": 2.0, "distance": "Dot"},
+			})
+		})
+
+		convey.Convey("test create collection failed", func() {
+			fake.failPath = "/exists"
+			_, err := NewIndexer(ctx, &IndexerConfig{Client: cli, Collection: "docs", CreateCollection: &CollectionConfig{Dimension: 2}})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestStore(t *testing.T) {
+	convey.Convey("test Store", t, func() {
+		ctx := context.Background()
+		fake, cli := newFakeQdrant(t)
+		emb := &mockEmbedding{dims: 2}
+
+		i, err := NewIndexer(ctx, &IndexerConfig{
+			Client:          cli,
+			Collection:      "docs",
+			Embedding:       emb,
+			BatchSize:       2,
+			UpsertBatchSize: 2,
+		})
+		convey.So(err, convey.ShouldBeNil)
+
+		convey.Convey("test store points", func() {
+			docs := []*schema.Document{
+				{ID: "1", Content: "a", MetaData: map[string]any{"page": 1}},
+				{ID: "doc-2", Content: "bb"},
+				(&schema.Document{ID: "doc-3", Content: "ccc"}).WithDenseVector([]float64{0.5, 0.5}),
+			}
+			ids, err := i.Store(ctx, docs)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids, convey.ShouldResemble, []string{"1", "doc-2", "doc-3"})
+			convey.So(emb.calls, convey.ShouldEqual, 1)
+			convey.So(fake.requests, convey.ShouldResemble, []string{
+				"PUT /collections/docs/points?wait=true",
+				"PUT /collections/docs/points?wait=true",
+			})
+
+			convey.So(fake.points["1"], convey.ShouldResemble, map[string]any{
+				"id":     1.0,
+				"vector": []any{1.0, 0.0},
+				"payload": map[string]any{
+					"doc_id":   "1",
+					"content":  "a",
+					"metadata": map[string]any{"page": 1.0},
+				},
+			})
+			p3 := fake.points[fmt.Sprint(pointID("doc-3"))]
+			convey.So(p3["vector"], convey.ShouldResemble, []any{0.5, 0.5})
+			convey.So(p3["payload"].(map[string]any)["metadata"], convey.ShouldResemble, map[string]any{})
+			// caller docs are not changed
+			convey.So(docs[2].MetaData, convey.ShouldContainKey, docMetaDataKeyDenseVector)
+		})
+
+		convey.Convey("test async", func() {
+			i.config.Async = true
+			_, err := i.Store(ctx, []*schema.Document{{ID: "1", Content: "a"}})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(fake.requests, convey.ShouldResemble, []string{"PUT /collections/docs/points?wait=false"})
+		})
+
+		convey.Convey("test embedding from options", func() {
+			other := &mockEmbedding{dims: 2}
+			_, err := i.Store(ctx, []*schema.Document{{ID: "1", Content: "a"}}, indexer.WithEmbedding(other))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(emb.calls, convey.ShouldEqual, 0)
+			convey.So(other.calls, convey.ShouldEqual, 1)
+		})
+
+		convey.Convey("test store failed", func() {
+			_, err := i.Store(ctx, []*schema.Document{{Content: "a"}})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			_, err = i.Store(ctx, []*schema.Document{{ID: "1", Content: "a"}}, indexer.WithEmbedding(&mockEmbedding{err: fmt.Errorf("mock err")}))
+			convey.So(err, convey.ShouldNotBeNil)
+
+			i.config.Embedding = nil
+			_, err = i.Store(ctx, []*schema.Document{{ID: "1", Content: "a"}})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			fake.failPath = "/points"
+			_, err = i.Store(ctx, []*schema.Document{(&schema.Document{ID: "1"}).WithDenseVector([]float64{1})})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestStoreSparse(t *testing.T) {
+	convey.Convey("test Store with sparse vectors", t, func() {
+		ctx := context.Background()
+		fake, cli := newFakeQdrant(t)
+
+		var sparseTexts []string
+		i, err := NewIndexer(ctx, &IndexerConfig{
+			Client:           cli,
+			Collection:       "docs",
+			Embedding:        &mockEmbedding{dims: 1},
+			VectorName:       "dense",
+			SparseVectorName: "sparse",
+			SparseEmbedding: func(ctx context.Context, texts []string) ([]map[int]float64, error) {
+				sparseTexts = append(sparseTexts, texts...)
+				result := make([]map[int]float64, len(texts))
+				for idx := range texts {
+					result[idx] = map[int]float64{idx: 1}
+				}
+				return result, nil
+			},
+		})
+		convey.So(err, convey.ShouldBeNil)
+
+		convey.Convey("test store", func() {
+			docs := []*schema.Document{
+				(&schema.Document{ID: "1", Content: "a"}).WithSparseVector(map[int]float64{9: 0.1, 3: 0.3}),
+				{ID: "2", Content: "b"},
+			}
+			_, err := i.Store(ctx, docs)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(sparseTexts, convey.ShouldResemble, []string{"b"})
+			convey.So(fake.points["1"]["vector"], convey.ShouldResemble, map[string]any{
+				"dense":  []any{1.0},
+				"sparse": map[string]any{"indices": []any{3.0, 9.0}, "values": []any{0.3, 0.1}},
+			})
+			convey.So(fake.points["1"]["payload"].(map[string]any)["metadata"], convey.ShouldResemble, map[string]any{})
+			convey.So(fake.points["2"]["vector"].(map[string]any)["sparse"], convey.ShouldResemble,
+				map[string]any{"indices": []any{0.0}, "values": []any{1.0}})
+		})
+
+		convey.Convey("test sparse embedding failed", func() {
+			docs := []*schema.Document{{ID: "1", Content: "a"}}
+
+			i.config.SparseEmbedding = func(ctx context.Context, texts []string) ([]map[int]float64, error) {
+				return nil, fmt.Errorf("mock err")
+			}
+			_, err := i.Store(ctx, docs)
+			convey.So(err, convey.ShouldNotBeNil)
+
+			i.config.SparseEmbedding = func(ctx context.Context, texts []string) ([]map[int]float64, error) {
+				return nil, nil
+			}
+			_, err = i.Store(ctx, docs)
+			convey.So(err, convey.ShouldNotBeNil)
+
+			i.config.SparseEmbedding = func(ctx context.Context, texts []string) ([]map[int]float64, error) {
+				return []map[int]float64{{-1: 1}}, nil
+			}
+			_, err = i.Store(ctx, docs)
+			convey.So(err, convey.ShouldNotBeNil)
+
+			i.config.SparseEmbedding = nil
+			_, err = i.Store(ctx, docs)
+			convey.So(err, convey.ShouldNotBeNil)
+
+			_, err = i.Store(ctx, []*schema.Document{(&schema.Document{ID: "1"}).WithSparseVector(map[int]float64{-1: 1})})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func pointID(id string) any {
+	return toPointIDs([]string{id})[0]
+}
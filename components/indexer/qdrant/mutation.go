@@ -0,0 +1,223 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/libs/acl/qdrant"
+)
+
+// Delete deletes the documents of ids, deleted is counted right before deleting.
+// It implements mutation.Deleter of github.com/cloudwego/eino-ext/components/indexer/mutation.
+func (i *Indexer) Delete(ctx context.Context, ids []string, opts ...indexer.Option) (deleted int64, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{
+		Extra: map[string]any{extraKeyOperation: operationDelete, extraKeyIDs: ids},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	if len(ids) > 0 {
+		filter := &qdrant.Filter{Must: []*qdrant.Condition{qdrant.NewHasID(toPointIDs(ids)...)}}
+		if deleted, err = i.deletePoints(ctx, filter); err != nil {
+			return 0, fmt.Errorf("[Delete] %w", err)
+		}
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{Extra: map[string]any{extraKeyDeleted: deleted}})
+
+	return deleted, nil
+}
+
+// DeleteByFilter deletes the documents whose metadata equals every key - value pair of filter,
+// matched on the MetadataKey payload, deleted is counted right before deleting.
+func (i *Indexer) DeleteByFilter(ctx context.Context, filter map[string]any, opts ...indexer.Option) (deleted int64, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{
+		Extra: map[string]any{extraKeyOperation: operationDelete, extraKeyFilter: filter},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	if len(filter) == 0 {
+		return 0, fmt.Errorf("[DeleteByFilter] empty filter")
+	}
+
+	keys := make([]string, 0, len(filter))
+	for k := range filter {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	f := &qdrant.Filter{}
+	for _, k := range keys {
+		cond, err := i.matchMetadata(k, filter[k])
+		if err != nil {
+			return 0, fmt.Errorf("[DeleteByFilter] %w", err)
+		}
+		f.Must = append(f.Must, cond)
+	}
+
+	if deleted, err = i.deletePoints(ctx, f); err != nil {
+		return 0, fmt.Errorf("[DeleteByFilter] %w", err)
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{Extra: map[string]any{extraKeyDeleted: deleted}})
+
+	return deleted, nil
+}
+
+// Upsert stores docs, which overwrites the documents of the same ids, then deletes the other documents of their parents,
+// the parent of a doc is its ParentKey metadata. The two steps aren't atomic, a failed delete leaves stale documents,
+// which the next Upsert of the parent deletes.
+// It implements mutation.Upserter of github.com/cloudwego/eino-ext/components/indexer/mutation.
+func (i *Indexer) Upsert(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{
+		Docs:  docs,
+		Extra: map[string]any{extraKeyOperation: operationUpsert},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	options := indexer.GetCommonOptions(&indexer.Options{
+		Embedding: i.config.Embedding,
+	}, opts...)
+
+	parents, err := getParents(docs, i.config.ParentKey)
+	if err != nil {
+		return nil, fmt.Errorf("[Upsert] %w", err)
+	}
+
+	stale := &qdrant.Filter{}
+	for _, parent := range parents {
+		cond, err := i.matchMetadata(i.config.ParentKey, parent)
+		if err != nil {
+			return nil, fmt.Errorf("[Upsert] %w", err)
+		}
+		stale.Should = append(stale.Should, cond)
+	}
+
+	points, err := i.makePoints(ctx, docs, options.Embedding)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = i.upsertPoints(ctx, points); err != nil {
+		return nil, fmt.Errorf("[Upsert] %w", err)
+	}
+
+	ids = iter(docs, func(doc *schema.Document) string { return doc.ID })
+
+	var deleted int64
+	if len(parents) > 0 {
+		stale.MustNot = []*qdrant.Condition{qdrant.NewHasID(toPointIDs(ids)...)}
+		if deleted, err = i.deletePoints(ctx, stale); err != nil {
+			return nil, fmt.Errorf("[Upsert] %w", err)
+		}
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{
+		IDs:   ids,
+		Extra: map[string]any{extraKeyDeleted: deleted},
+	})
+
+	return ids, nil
+}
+
+// deletePoints counts and deletes the points matching filter.
+func (i *Indexer) deletePoints(ctx context.Context, filter *qdrant.Filter) (int64, error) {
+	n, err := i.config.Client.CountPoints(ctx, i.config.Collection, filter)
+	if err != nil {
+		return 0, err
+	}
+	if n == 0 {
+		return 0, nil
+	}
+
+	if err = i.config.Client.DeletePoints(ctx, i.config.Collection, &qdrant.PointsSelector{Filter: filter}, !i.config.Async); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+// matchMetadata matches metadata key equal to value. Qdrant matches keywords, integers and bools,
+// so integral floats, e.g. numbers decoded from json, are matched as integers and others by a closed range.
+func (i *Indexer) matchMetadata(key string, value any) (*qdrant.Condition, error) {
+	field := i.config.MetadataKey + "." + key
+	switch v := value.(type) {
+	case string, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return qdrant.NewMatch(field, v), nil
+	case float32:
+		return matchFloat(field, float64(v)), nil
+	case float64:
+		return matchFloat(field, v), nil
+	default:
+		return nil, fmt.Errorf("unsupported value type %T of key %s", value, key)
+	}
+}
+
+func matchFloat(field string, v float64) *qdrant.Condition {
+	if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+		return qdrant.NewMatch(field, int64(v))
+	}
+	return qdrant.NewRange(field, &qdrant.Range{Gte: &v, Lte: &v})
+}
+
+// getParents returns the distinct parents of docs in order.
+func getParents(docs []*schema.Document, parentKey string) ([]any, error) {
+	var parents []any
+	seen := make(map[any]struct{})
+	for _, doc := range docs {
+		parent, ok := doc.MetaData[parentKey]
+		if !ok || parent == nil {
+			return nil, fmt.Errorf("parent metadata %q not found in document, id=%s", parentKey, doc.ID)
+		}
+		if !reflect.TypeOf(parent).Comparable() {
+			return nil, fmt.Errorf("unsupported parent type %T, id=%s", parent, doc.ID)
+		}
+		if _, found := seen[parent]; !found {
+			seen[parent] = struct{}{}
+			parents = append(parents, parent)
+		}
+	}
+	return parents, nil
+}
+
+func toPointIDs(ids []string) []any {
+	return iter(ids, func(id string) any { return qdrant.PointID(id) })
+}
@@ -0,0 +1,164 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/indexer/mutation"
+)
+
+func TestDelete(t *testing.T) {
+	convey.Convey("test Delete and DeleteByFilter", t, func() {
+		ctx := context.Background()
+		fake, cli := newFakeQdrant(t)
+
+		i, err := NewIndexer(ctx, &IndexerConfig{Client: cli, Collection: "docs", Embedding: &mockEmbedding{dims: 1}})
+		convey.So(err, convey.ShouldBeNil)
+
+		_, err = i.Store(ctx, []*schema.Document{
+			{ID: "a", Content: "a", MetaData: map[string]any{"lang": "en", "page": 1, "score": 0.5, "draft": false}},
+			{ID: "b", Content: "b", MetaData: map[string]any{"lang": "en", "page": 2, "score": 0.7, "draft": true}},
+			{ID: "c", Content: "c", MetaData: map[string]any{"lang": "fr", "page": 1}},
+		})
+		convey.So(err, convey.ShouldBeNil)
+		fake.requests = nil
+
+		convey.Convey("test delete by ids", func() {
+			deleted, err := i.Delete(ctx, []string{"a", "missing"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(deleted, convey.ShouldEqual, 1)
+			convey.So(fake.ids(), convey.ShouldResemble, []string{"b", "c"})
+
+			// nothing matched, nothing deleted
+			deleted, err = i.Delete(ctx, []string{"missing"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(deleted, convey.ShouldEqual, 0)
+			convey.So(fake.requests, convey.ShouldResemble, []string{
+				"POST /collections/docs/points/count",
+				"POST /collections/docs/points/delete?wait=true",
+				"POST /collections/docs/points/count",
+			})
+
+			deleted, err = i.Delete(ctx, nil)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(deleted, convey.ShouldEqual, 0)
+		})
+
+		convey.Convey("test delete by filter", func() {
+			deleted, err := i.DeleteByFilter(ctx, map[string]any{"lang": "en", "page": float64(1)})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(deleted, convey.ShouldEqual, 1)
+			convey.So(fake.ids(), convey.ShouldResemble, []string{"b", "c"})
+
+			deleted, err = i.DeleteByFilter(ctx, map[string]any{"score": 0.7, "draft": true})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(deleted, convey.ShouldEqual, 1)
+			convey.So(fake.ids(), convey.ShouldResemble, []string{"c"})
+		})
+
+		convey.Convey("test delete failed", func() {
+			_, err := i.DeleteByFilter(ctx, nil)
+			convey.So(err, convey.ShouldNotBeNil)
+
+			_, err = i.DeleteByFilter(ctx, map[string]any{"tags": []string{"x"}})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			fake.failPath = "/count"
+			_, err = i.Delete(ctx, []string{"a"})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			fake.failPath = "/delete"
+			_, err = i.DeleteByFilter(ctx, map[string]any{"lang": "en"})
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(fake.ids(), convey.ShouldResemble, []string{"a", "b", "c"})
+		})
+	})
+}
+
+func TestUpsert(t *testing.T) {
+	convey.Convey("test Upsert", t, func() {
+		ctx := context.Background()
+		fake, cli := newFakeQdrant(t)
+
+		i, err := NewIndexer(ctx, &IndexerConfig{Client: cli, Collection: "docs", Embedding: &mockEmbedding{dims: 1}})
+		convey.So(err, convey.ShouldBeNil)
+
+		chunk := func(id, source string) *schema.Document {
+			return &schema.Document{ID: id, Content: id, MetaData: map[string]any{defaultParentKey: source}}
+		}
+
+		_, err = i.Store(ctx, []*schema.Document{
+			chunk("a1", "a.md"), chunk("a2", "a.md"), chunk("a3", "a.md"),
+			chunk("b1", "b.md"),
+		})
+		convey.So(err, convey.ShouldBeNil)
+
+		convey.Convey("test replace chunks of parents", func() {
+			ids, err := i.Upsert(ctx, []*schema.Document{chunk("a1", "a.md"), chunk("a4", "a.md")})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids, convey.ShouldResemble, []string{"a1", "a4"})
+			convey.So(fake.ids(), convey.ShouldResemble, []string{"a1", "a4", "b1"})
+		})
+
+		convey.Convey("test missing parent", func() {
+			_, err := i.Upsert(ctx, []*schema.Document{{ID: "x", Content: "x"}})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			_, err = i.Upsert(ctx, []*schema.Document{{ID: "x", Content: "x", MetaData: map[string]any{defaultParentKey: []string{"a"}}}})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test upsert failed", func() {
+			i.config.Embedding = nil
+			_, err := i.Upsert(ctx, []*schema.Document{chunk("a1", "a.md")})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			i.config.Embedding = &mockEmbedding{dims: 1}
+			fake.failPath = "/points"
+			_, err = i.Upsert(ctx, []*schema.Document{chunk("a1", "a.md")})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			fake.failPath = "/count"
+			_, err = i.Upsert(ctx, []*schema.Document{chunk("a1", "a.md")})
+			convey.So(err, convey.ShouldNotBeNil)
+			// the stored chunk is kept and the stale ones are left for the next upsert
+			convey.So(fake.ids(), convey.ShouldResemble, []string{"a1", "a2", "a3", "b1"})
+		})
+	})
+}
+
+var (
+	_ mutation.Deleter  = (*Indexer)(nil)
+	_ mutation.Upserter = (*Indexer)(nil)
+)
+
+func TestMutationConsts(t *testing.T) {
+	convey.Convey("test callback extra keys and operations same as mutation", t, func() {
+		convey.So(extraKeyOperation, convey.ShouldEqual, mutation.ExtraKeyOperation)
+		convey.So(extraKeyIDs, convey.ShouldEqual, mutation.ExtraKeyIDs)
+		convey.So(extraKeyFilter, convey.ShouldEqual, mutation.ExtraKeyFilter)
+		convey.So(extraKeyDeleted, convey.ShouldEqual, mutation.ExtraKeyDeleted)
+		convey.So(operationDelete, convey.ShouldEqual, mutation.OperationDelete)
+		convey.So(operationUpsert, convey.ShouldEqual, mutation.OperationUpsert)
+		convey.So(defaultParentKey, convey.ShouldEqual, mutation.MetaKeyParent)
+	})
+}
@@ -19,4 +19,22 @@ package redis
 const (
 	defaultReturnFieldContent       = "content"
 	defaultReturnFieldVectorContent = "vector_content"
+
+	// defaultParentKey matches mutation.MetaKeyParent of github.com/cloudwego/eino-ext/components/indexer/mutation.
+	defaultParentKey = "_source"
+)
+
+// callback extra keys and operations, same as the ones of github.com/cloudwego/eino-ext/components/indexer/mutation,
+// checked by TestMutationConsts.
+const (
+	extraKeyOperation = "operation"
+	extraKeyIDs       = "ids"
+	extraKeyFilter    = "filter"
+	extraKeyDeleted   = "deleted"
+
+	operationDelete = "delete"
+	operationUpsert = "upsert"
 )
+
+// scanCount is the COUNT hint of SCAN when looking up hashes by filter.
+const scanCount = 500
@@ -0,0 +1,103 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	ri "github.com/cloudwego/eino-ext/components/indexer/redis"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+	"github.com/redis/go-redis/v9"
+)
+
+// This example builds a new versioned index, then swaps alias "eino_docs" to it.
+// Retrievers configured with Index "eino_docs" switch to the new index atomically.
+
+func main() {
+	ctx := context.Background()
+	client := redis.NewClient(&redis.Options{
+		Addr: "localhost:6379",
+	})
+
+	b, err := os.ReadFile("./examples/embeddings.json")
+	if err != nil {
+		panic(err)
+	}
+
+	var dense [][]float64
+	if err = json.Unmarshal(b, &dense); err != nil {
+		panic(err)
+	}
+
+	indexer, err := ri.NewIndexer(ctx, &ri.IndexerConfig{
+		Client:    client,
+		KeyPrefix: "eino_docs_v2:",
+		BatchSize: 5,
+		Embedding: &mockEmbedding{dense}, // replace with real embedding
+		Index: &ri.IndexConfig{
+			Name:  "eino_docs_v2",
+			Alias: "eino_docs",
+			Mode:  ri.IndexModeCreate, // create if not exists, otherwise validate schema below
+			Schema: []*redis.FieldSchema{
+				{
+					FieldName: "content",
+					FieldType: redis.SearchFieldTypeText,
+				},
+				{
+					FieldName: "vector_content",
+					FieldType: redis.SearchFieldTypeVector,
+					VectorArgs: &redis.FTVectorArgs{
+						HNSWOptions: &redis.FTHNSWOptions{
+							Type:           "FLOAT32", // indexer writes FLOAT32 vectors
+							Dim:            1024,      // Store fails with ErrDimensionMismatch if embedding differs
+							DistanceMetric: "COSINE",
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	if _, err = indexer.Store(ctx, []*schema.Document{
+		{ID: "1", Content: "Eiffel Tower: Located in Paris, France."},
+	}); err != nil {
+		panic(err)
+	}
+
+	previous, err := indexer.SwapAlias(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(previous) // eino_docs_v1, could be dropped by FT.DROPINDEX when no longer used
+}
+
+// mockEmbedding returns embeddings with 1024 dimensions
+type mockEmbedding struct {
+	dense [][]float64
+}
+
+func (m mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	return m.dense[:len(texts)], nil
+}
@@ -0,0 +1,199 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// fakeRedis answers commands in-process through a client hook, in place of Redis with the search module.
+// FT.INFO replies are shaped as RESP2 or RESP3 ones, which is what the client returns for raw commands.
+type fakeRedis struct {
+	resp3   bool
+	hashes  map[string]map[string]any
+	indexes map[string][]map[string]any // index name - attributes
+	aliases map[string]string           // alias - index name
+	txs     [][]string                  // commands of each MULTI / EXEC transaction
+	inTx    bool
+	matches []string // MATCH patterns of SCAN
+}
+
+func newFakeRedis(resp3 bool) *fakeRedis {
+	return &fakeRedis{
+		resp3:   resp3,
+		hashes:  map[string]map[string]any{},
+		indexes: map[string][]map[string]any{},
+		aliases: map[string]string{},
+	}
+}
+
+func (f *fakeRedis) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return nil, fmt.Errorf("fake redis doesn't dial")
+	}
+}
+
+func (f *fakeRedis) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return f.process
+}
+
+func (f *fakeRedis) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		for _, cmd := range cmds {
+			if err := f.process(ctx, cmd); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func (f *fakeRedis) process(_ context.Context, cmder redis.Cmder) error {
+	args := cmder.Args()
+	var err error
+	if name := strings.ToUpper(toString(args[0])); f.inTx && name != "EXEC" {
+		f.txs[len(f.txs)-1] = append(f.txs[len(f.txs)-1], name)
+	}
+	switch strings.ToUpper(toString(args[0])) {
+	case "HSET":
+		fields := map[string]any{}
+		for i := 2; i+1 < len(args); i += 2 {
+			fields[toString(args[i])] = args[i+1]
+		}
+		f.hashes[toString(args[1])] = fields
+		cmder.(*redis.IntCmd).SetVal(int64(len(fields)))
+	case "HMGET":
+		vals := make([]any, 0, len(args)-2)
+		for _, field := range args[2:] {
+			if val, found := f.hashes[toString(args[1])][toString(field)]; found {
+				vals = append(vals, formatArg(val))
+			} else {
+				vals = append(vals, nil)
+			}
+		}
+		cmder.(*redis.SliceCmd).SetVal(vals)
+	case "DEL":
+		var deleted int64
+		for _, key := range args[1:] {
+			if _, found := f.hashes[toString(key)]; found {
+				delete(f.hashes, toString(key))
+				deleted++
+			}
+		}
+		cmder.(*redis.IntCmd).SetVal(deleted)
+	case "SCAN":
+		// all keys are returned in one page
+		var prefix string
+		for i := 2; i+1 < len(args); i += 2 {
+			if strings.ToUpper(toString(args[i])) == "MATCH" {
+				f.matches = append(f.matches, toString(args[i+1]))
+				prefix = strings.ReplaceAll(strings.TrimSuffix(toString(args[i+1]), "*"), "\\", "")
+			}
+		}
+		var keys []string
+		for key := range f.hashes {
+			if strings.HasPrefix(key, prefix) {
+				keys = append(keys, key)
+			}
+		}
+		cmder.(*redis.ScanCmd).SetVal(keys, 0)
+	case "MULTI":
+		f.txs, f.inTx = append(f.txs, nil), true
+		cmder.(*redis.StatusCmd).SetVal("OK")
+	case "EXEC":
+		f.inTx = false
+		cmder.(*redis.SliceCmd).SetVal(nil)
+	case "FT.INFO":
+		name := toString(args[1])
+		if index, found := f.aliases[name]; found {
+			name = index
+		}
+		attrs, found := f.indexes[name]
+		if !found {
+			err = fmt.Errorf("Unknown index name")
+			break
+		}
+		replies := make([]any, 0, len(attrs))
+		for _, attr := range attrs {
+			replies = append(replies, f.kv(attr))
+		}
+		cmder.(*redis.Cmd).SetVal(f.kv(map[string]any{"index_name": name, "attributes": replies}))
+	case "FT.CREATE":
+		f.indexes[toString(args[1])] = parseCreateArgs(args)
+		cmder.(*redis.StatusCmd).SetVal("OK")
+	case "FT.ALIASADD", "FT.ALIASUPDATE":
+		f.aliases[toString(args[1])] = toString(args[2])
+		cmder.(*redis.StatusCmd).SetVal("OK")
+	default:
+		err = fmt.Errorf("fake redis: unsupported command %v", args[0])
+	}
+	if err != nil {
+		cmder.SetErr(err)
+	}
+	return err
+}
+
+// parseCreateArgs parses SCHEMA of FT.CREATE into FT.INFO attributes, supports field name, type and vector params.
+func parseCreateArgs(args []any) []map[string]any {
+	i := 0
+	for ; i < len(args) && toString(args[i]) != "SCHEMA"; i++ {
+	}
+
+	var attrs []map[string]any
+	for i++; i+1 < len(args); {
+		name, typ := toString(args[i]), toString(args[i+1])
+		attr := map[string]any{"identifier": name, "attribute": name, "type": typ}
+		i += 2
+		if typ == "VECTOR" && i+1 < len(args) {
+			attr["algorithm"] = toString(args[i])
+			n := toInt(args[i+1])
+			i += 2
+			for j := 0; j+1 < n && i+1 < len(args); j, i = j+2, i+2 {
+				switch key := strings.ToLower(toString(args[i])); key {
+				case "dim":
+					attr[key] = int64(toInt(args[i+1]))
+				case "type":
+					attr["data_type"] = toString(args[i+1])
+				default:
+					attr[key] = toString(args[i+1])
+				}
+			}
+		}
+		attrs = append(attrs, attr)
+	}
+	return attrs
+}
+
+func (f *fakeRedis) kv(m map[string]any) any {
+	if f.resp3 {
+		resp := make(map[any]any, len(m))
+		for k, v := range m {
+			resp[k] = v
+		}
+		return resp
+	}
+	resp := make([]any, 0, 2*len(m))
+	for k, v := range m {
+		resp = append(resp, k, v)
+	}
+	return resp
+}
@@ -0,0 +1,325 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	// ErrIndexNotFound is returned when the index doesn't exist and IndexConfig.Mode is IndexModeValidate.
+	ErrIndexNotFound = errors.New("redis index not found")
+	// ErrSchemaMismatch is returned when the existing index doesn't match IndexConfig.Schema.
+	ErrSchemaMismatch = errors.New("redis index schema mismatch")
+	// ErrDimensionMismatch is returned by Store when an embedding doesn't match the dimension of its vector field.
+	ErrDimensionMismatch = errors.New("embedding dimension mismatch")
+)
+
+type IndexMode string
+
+const (
+	// IndexModeValidate requires the index to exist and to contain the fields declared in Schema.
+	IndexModeValidate IndexMode = "validate"
+	// IndexModeCreate creates the index from Schema with FT.CREATE if it doesn't exist, otherwise validates it.
+	IndexModeCreate IndexMode = "create"
+)
+
+// IndexConfig declares the RediSearch index over the hashes written by the indexer.
+type IndexConfig struct {
+	// Name of the index. When Alias is used, Name is the versioned index behind it, e.g. "eino_docs_v2".
+	// Required
+	Name string
+	// Alias is added to the index when it doesn't exist yet, afterwards Indexer.SwapAlias moves it to this index.
+	// Retrievers should search the alias, so that a rebuilt index can be swapped in atomically.
+	// Optional
+	Alias string
+	// Mode controls whether the index is created or only validated.
+	// Default IndexModeValidate.
+	Mode IndexMode
+	// Schema declares text, tag, numeric and vector fields of the index.
+	// Vector fields must use FLOAT32 type, which the indexer writes, with Dim equal to the embedding dimension.
+	// see: https://redis.io/docs/latest/commands/ft.create/
+	// Required when Mode is IndexModeCreate; with IndexModeValidate the declared fields are checked if set.
+	Schema []*redis.FieldSchema
+	// Prefixes of the keys indexed.
+	// Default []string{IndexerConfig.KeyPrefix} if KeyPrefix is set.
+	Prefixes []string
+}
+
+// indexField is a field of an existing index reported by FT.INFO.
+type indexField struct {
+	identifier string
+	attribute  string
+	typ        string
+	dim        int
+}
+
+type indexInfo struct {
+	name   string
+	fields []*indexField
+}
+
+func (info *indexInfo) field(name string) *indexField {
+	for _, f := range info.fields {
+		if f.identifier == name || f.attribute == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// ensureIndex creates or validates the index and returns the dimension of each vector field keyed by hash field.
+func (i *Indexer) ensureIndex(ctx context.Context) (map[string]int, error) {
+	conf := i.config.Index
+	if conf.Name == "" {
+		return nil, fmt.Errorf("[ensureIndex] index name not provided")
+	}
+	if conf.Mode == "" {
+		conf.Mode = IndexModeValidate
+	}
+	if len(conf.Prefixes) == 0 && i.config.KeyPrefix != "" {
+		conf.Prefixes = []string{i.config.KeyPrefix}
+	}
+
+	info, err := getIndexInfo(ctx, i.config.Client, conf.Name)
+	switch {
+	case errors.Is(err, ErrIndexNotFound) && conf.Mode == IndexModeCreate:
+		if err = createIndex(ctx, i.config.Client, conf); err != nil {
+			return nil, err
+		}
+		if info, err = getIndexInfo(ctx, i.config.Client, conf.Name); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, fmt.Errorf("[ensureIndex] %w", err)
+	default:
+		if err = validateIndex(info, conf.Schema); err != nil {
+			return nil, fmt.Errorf("[ensureIndex] %w", err)
+		}
+	}
+
+	if conf.Alias != "" {
+		if _, err = getIndexInfo(ctx, i.config.Client, conf.Alias); errors.Is(err, ErrIndexNotFound) {
+			if err = i.config.Client.FTAliasAdd(ctx, conf.Name, conf.Alias).Err(); err != nil {
+				return nil, fmt.Errorf("[ensureIndex] add alias failed, %w", err)
+			}
+		} else if err != nil {
+			return nil, fmt.Errorf("[ensureIndex] %w", err)
+		}
+	}
+
+	dims := make(map[string]int)
+	for _, f := range info.fields {
+		if f.dim > 0 {
+			dims[f.identifier] = f.dim
+		}
+	}
+	return dims, nil
+}
+
+// SwapAlias points IndexConfig.Alias to IndexConfig.Name with FT.ALIASUPDATE, which is atomic for searches.
+// It returns the index the alias pointed to before, empty if none, so that it can be dropped once unused.
+func (i *Indexer) SwapAlias(ctx context.Context) (previous string, err error) {
+	conf := i.config.Index
+	if conf == nil || conf.Name == "" || conf.Alias == "" {
+		return "", fmt.Errorf("[SwapAlias] index name and alias not configured")
+	}
+
+	info, err := getIndexInfo(ctx, i.config.Client, conf.Alias)
+	switch {
+	case errors.Is(err, ErrIndexNotFound):
+	case err != nil:
+		return "", fmt.Errorf("[SwapAlias] %w", err)
+	default:
+		previous = info.name
+	}
+
+	if err = i.config.Client.FTAliasUpdate(ctx, conf.Name, conf.Alias).Err(); err != nil {
+		return "", fmt.Errorf("[SwapAlias] update alias failed, %w", err)
+	}
+	return previous, nil
+}
+
+func createIndex(ctx context.Context, client redis.UniversalClient, conf *IndexConfig) error {
+	if len(conf.Schema) == 0 {
+		return fmt.Errorf("[createIndex] schema not provided for index %s", conf.Name)
+	}
+	for _, fs := range conf.Schema {
+		if fs.FieldType != redis.SearchFieldTypeVector {
+			continue
+		}
+		if fs.VectorArgs == nil || (fs.VectorArgs.FlatOptions == nil) == (fs.VectorArgs.HNSWOptions == nil) {
+			return fmt.Errorf("[createIndex] vector field %s requires exactly one of FLAT and HNSW options", fs.FieldName)
+		}
+		if vectorDim(fs) <= 0 {
+			return fmt.Errorf("[createIndex] vector field %s requires dim", fs.FieldName)
+		}
+		if t := vectorType(fs); t != "FLOAT32" {
+			return fmt.Errorf("[createIndex] vector field %s must be FLOAT32, got %s", fs.FieldName, t)
+		}
+	}
+
+	prefixes := make([]any, 0, len(conf.Prefixes))
+	for _, p := range conf.Prefixes {
+		prefixes = append(prefixes, p)
+	}
+
+	if err := client.FTCreate(ctx, conf.Name, &redis.FTCreateOptions{
+		OnHash: true,
+		Prefix: prefixes,
+	}, conf.Schema...).Err(); err != nil {
+		return fmt.Errorf("[createIndex] create index %s failed, %w", conf.Name, err)
+	}
+	return nil
+}
+
+// validateIndex checks that each declared field exists with the same type, and the same dimension for vectors.
+func validateIndex(info *indexInfo, schema []*redis.FieldSchema) error {
+	for _, fs := range schema {
+		f := info.field(fs.FieldName)
+		if f == nil {
+			return fmt.Errorf("%w: field %s not found in index %s", ErrSchemaMismatch, fs.FieldName, info.name)
+		}
+		if !strings.EqualFold(f.typ, fs.FieldType.String()) {
+			return fmt.Errorf("%w: field %s of index %s is %s, declared %s",
+				ErrSchemaMismatch, fs.FieldName, info.name, f.typ, fs.FieldType.String())
+		}
+		if dim := vectorDim(fs); dim > 0 && f.dim > 0 && dim != f.dim {
+			return fmt.Errorf("%w: vector field %s of index %s has dim %d, declared %d",
+				ErrSchemaMismatch, fs.FieldName, info.name, f.dim, dim)
+		}
+	}
+	return nil
+}
+
+func vectorDim(fs *redis.FieldSchema) int {
+	switch {
+	case fs.VectorArgs == nil:
+		return 0
+	case fs.VectorArgs.FlatOptions != nil:
+		return fs.VectorArgs.FlatOptions.Dim
+	case fs.VectorArgs.HNSWOptions != nil:
+		return fs.VectorArgs.HNSWOptions.Dim
+	default:
+		return 0
+	}
+}
+
+func vectorType(fs *redis.FieldSchema) string {
+	switch {
+	case fs.VectorArgs == nil:
+		return ""
+	case fs.VectorArgs.FlatOptions != nil:
+		return strings.ToUpper(fs.VectorArgs.FlatOptions.Type)
+	case fs.VectorArgs.HNSWOptions != nil:
+		return strings.ToUpper(fs.VectorArgs.HNSWOptions.Type)
+	default:
+		return ""
+	}
+}
+
+// getIndexInfo runs FT.INFO on an index or alias.
+// The raw reply is parsed since the typed FTInfo of go-redis drops vector params.
+func getIndexInfo(ctx context.Context, client redis.UniversalClient, name string) (*indexInfo, error) {
+	raw, err := client.Do(ctx, "FT.INFO", name).Result()
+	if err != nil {
+		msg := strings.ToLower(err.Error())
+		if strings.Contains(msg, "unknown index name") || strings.Contains(msg, "no such index") {
+			return nil, fmt.Errorf("%w: %s", ErrIndexNotFound, name)
+		}
+		return nil, err
+	}
+	return parseIndexInfo(raw)
+}
+
+func parseIndexInfo(raw any) (*indexInfo, error) {
+	kv := toKV(raw)
+	if kv == nil {
+		return nil, fmt.Errorf("[parseIndexInfo] unexpected FT.INFO reply type %T", raw)
+	}
+
+	info := &indexInfo{name: toString(kv["index_name"])}
+	attrs, _ := kv["attributes"].([]any)
+	for _, attr := range attrs {
+		akv := toKV(attr)
+		if akv == nil {
+			continue
+		}
+		info.fields = append(info.fields, &indexField{
+			identifier: toString(akv["identifier"]),
+			attribute:  toString(akv["attribute"]),
+			typ:        toString(akv["type"]),
+			dim:        toInt(akv["dim"]),
+		})
+	}
+	return info, nil
+}
+
+// toKV converts a RESP2 flat array or a RESP3 map reply to a map with lower-cased keys.
+func toKV(v any) map[string]any {
+	kv := make(map[string]any)
+	switch t := v.(type) {
+	case []any:
+		for i := 0; i+1 < len(t); i += 2 {
+			kv[strings.ToLower(toString(t[i]))] = t[i+1]
+		}
+	case map[any]any:
+		for k, val := range t {
+			kv[strings.ToLower(toString(k))] = val
+		}
+	case map[string]any:
+		for k, val := range t {
+			kv[strings.ToLower(k)] = val
+		}
+	default:
+		return nil
+	}
+	return kv
+}
+
+func toString(v any) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case []byte:
+		return string(t)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(t)
+	}
+}
+
+func toInt(v any) int {
+	switch t := v.(type) {
+	case int64:
+		return int(t)
+	case int:
+		return t
+	case string:
+		n, _ := strconv.Atoi(t)
+		return n
+	default:
+		return 0
+	}
+}
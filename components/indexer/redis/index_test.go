@@ -0,0 +1,231 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/redis/go-redis/v9"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseIndexInfo(t *testing.T) {
+	convey.Convey("test parseIndexInfo", t, func() {
+		convey.Convey("test resp2 reply", func() {
+			raw := []any{
+				"index_name", "eino_docs_v1",
+				"index_options", []any{},
+				"attributes", []any{
+					[]any{"identifier", "content", "attribute", "content", "type", "TEXT", "WEIGHT", "1"},
+					[]any{"identifier", "vector_content", "attribute", "vector_content", "type", "VECTOR",
+						"algorithm", "FLAT", "data_type", "FLOAT32", "dim", int64(1024), "distance_metric", "COSINE"},
+				},
+			}
+			info, err := parseIndexInfo(raw)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(info.name, convey.ShouldEqual, "eino_docs_v1")
+			convey.So(info.fields, convey.ShouldHaveLength, 2)
+			convey.So(info.field("vector_content").dim, convey.ShouldEqual, 1024)
+			convey.So(info.field("content").typ, convey.ShouldEqual, "TEXT")
+		})
+
+		convey.Convey("test resp3 reply", func() {
+			raw := map[any]any{
+				"index_name": "eino_docs_v2",
+				"attributes": []any{
+					map[any]any{"identifier": "$.vec", "attribute": "vec", "type": "VECTOR", "dim": int64(8)},
+				},
+			}
+			info, err := parseIndexInfo(raw)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(info.name, convey.ShouldEqual, "eino_docs_v2")
+			convey.So(info.field("vec").dim, convey.ShouldEqual, 8)
+			convey.So(info.field("$.vec"), convey.ShouldEqual, info.field("vec"))
+		})
+
+		convey.Convey("test unexpected reply", func() {
+			_, err := parseIndexInfo("OK")
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestValidateIndex(t *testing.T) {
+	convey.Convey("test validateIndex", t, func() {
+		info := &indexInfo{
+			name: "eino_docs_v1",
+			fields: []*indexField{
+				{identifier: "content", attribute: "content", typ: "TEXT"},
+				{identifier: "vector_content", attribute: "vector_content", typ: "VECTOR", dim: 1024},
+			},
+		}
+		vectorSchema := func(dim int) *redis.FieldSchema {
+			return &redis.FieldSchema{
+				FieldName: "vector_content",
+				FieldType: redis.SearchFieldTypeVector,
+				VectorArgs: &redis.FTVectorArgs{
+					HNSWOptions: &redis.FTHNSWOptions{Type: "FLOAT32", Dim: dim, DistanceMetric: "COSINE"},
+				},
+			}
+		}
+
+		convey.Convey("test match", func() {
+			err := validateIndex(info, []*redis.FieldSchema{
+				{FieldName: "content", FieldType: redis.SearchFieldTypeText},
+				vectorSchema(1024),
+			})
+			convey.So(err, convey.ShouldBeNil)
+		})
+
+		convey.Convey("test field not found", func() {
+			err := validateIndex(info, []*redis.FieldSchema{
+				{FieldName: "tag", FieldType: redis.SearchFieldTypeTag},
+			})
+			convey.So(errors.Is(err, ErrSchemaMismatch), convey.ShouldBeTrue)
+		})
+
+		convey.Convey("test type mismatch", func() {
+			err := validateIndex(info, []*redis.FieldSchema{
+				{FieldName: "content", FieldType: redis.SearchFieldTypeTag},
+			})
+			convey.So(errors.Is(err, ErrSchemaMismatch), convey.ShouldBeTrue)
+		})
+
+		convey.Convey("test dim mismatch", func() {
+			err := validateIndex(info, []*redis.FieldSchema{vectorSchema(768)})
+			convey.So(errors.Is(err, ErrSchemaMismatch), convey.ShouldBeTrue)
+		})
+	})
+}
+
+func TestIndexLifecycle(t *testing.T) {
+	convey.Convey("test index lifecycle", t, func() {
+		ctx := context.Background()
+		schemaV1 := []*redis.FieldSchema{
+			{FieldName: defaultReturnFieldContent, FieldType: redis.SearchFieldTypeText},
+			{
+				FieldName: defaultReturnFieldVectorContent,
+				FieldType: redis.SearchFieldTypeVector,
+				VectorArgs: &redis.FTVectorArgs{
+					FlatOptions: &redis.FTFlatOptions{Type: "FLOAT32", Dim: 4, DistanceMetric: "COSINE"},
+				},
+			},
+		}
+		docs := []*schema.Document{{ID: "1", Content: "asd"}, {ID: "2", Content: "qwe"}}
+
+		for _, resp3 := range []bool{false, true} {
+			fake := newFakeRedis(resp3)
+			client := redis.NewClient(&redis.Options{Addr: "fake:6379"})
+			client.AddHook(fake)
+
+			convey.Convey(fmt.Sprintf("test validate not found, resp3=%v", resp3), func() {
+				_, err := NewIndexer(ctx, &IndexerConfig{
+					Client:    client,
+					Embedding: &mockEmbedding{},
+					Index:     &IndexConfig{Name: "eino_docs_v1"},
+				})
+				convey.So(errors.Is(err, ErrIndexNotFound), convey.ShouldBeTrue)
+			})
+
+			convey.Convey(fmt.Sprintf("test create, store and swap alias, resp3=%v", resp3), func() {
+				i, err := NewIndexer(ctx, &IndexerConfig{
+					Client:    client,
+					KeyPrefix: "eino_docs_v1:",
+					Embedding: &mockEmbedding{sizeForCall: []int{2}, dims: 4},
+					Index:     &IndexConfig{Name: "eino_docs_v1", Alias: "eino_docs", Mode: IndexModeCreate, Schema: schemaV1},
+				})
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(fake.indexes["eino_docs_v1"], convey.ShouldHaveLength, 2)
+				convey.So(fake.aliases["eino_docs"], convey.ShouldEqual, "eino_docs_v1")
+				convey.So(i.vectorDims, convey.ShouldResemble, map[string]int{defaultReturnFieldVectorContent: 4})
+
+				ids, err := i.Store(ctx, docs)
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(ids, convey.ShouldResemble, []string{"1", "2"})
+				convey.So(fake.hashes["eino_docs_v1:1"][defaultReturnFieldContent], convey.ShouldEqual, "asd")
+
+				// existing index is validated, alias is kept
+				_, err = NewIndexer(ctx, &IndexerConfig{
+					Client:    client,
+					Embedding: &mockEmbedding{},
+					Index:     &IndexConfig{Name: "eino_docs_v1", Alias: "eino_docs", Mode: IndexModeCreate, Schema: schemaV1},
+				})
+				convey.So(err, convey.ShouldBeNil)
+
+				schemaV2 := []*redis.FieldSchema{schemaV1[0], {
+					FieldName: defaultReturnFieldVectorContent,
+					FieldType: redis.SearchFieldTypeVector,
+					VectorArgs: &redis.FTVectorArgs{
+						HNSWOptions: &redis.FTHNSWOptions{Type: "FLOAT32", Dim: 8, DistanceMetric: "IP"},
+					},
+				}}
+				_, err = NewIndexer(ctx, &IndexerConfig{
+					Client:    client,
+					Embedding: &mockEmbedding{},
+					Index:     &IndexConfig{Name: "eino_docs_v1", Mode: IndexModeCreate, Schema: schemaV2},
+				})
+				convey.So(errors.Is(err, ErrSchemaMismatch), convey.ShouldBeTrue)
+
+				i2, err := NewIndexer(ctx, &IndexerConfig{
+					Client:    client,
+					KeyPrefix: "eino_docs_v2:",
+					Embedding: &mockEmbedding{sizeForCall: []int{2}, dims: 4},
+					Index:     &IndexConfig{Name: "eino_docs_v2", Alias: "eino_docs", Mode: IndexModeCreate, Schema: schemaV2},
+				})
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(fake.aliases["eino_docs"], convey.ShouldEqual, "eino_docs_v1")
+
+				_, err = i2.Store(ctx, docs)
+				convey.So(errors.Is(err, ErrDimensionMismatch), convey.ShouldBeTrue)
+
+				previous, err := i2.SwapAlias(ctx)
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(previous, convey.ShouldEqual, "eino_docs_v1")
+				convey.So(fake.aliases["eino_docs"], convey.ShouldEqual, "eino_docs_v2")
+			})
+		}
+
+		convey.Convey("test cluster client", func() {
+			fake := newFakeRedis(true)
+			client := redis.NewClusterClient(&redis.ClusterOptions{Addrs: []string{"fake:7000"}})
+			client.AddHook(fake)
+
+			_, err := NewIndexer(ctx, &IndexerConfig{
+				Client:    client,
+				KeyPrefix: "eino_doc:",
+				Embedding: &mockEmbedding{},
+			})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			i, err := NewIndexer(ctx, &IndexerConfig{
+				Client:    client,
+				KeyPrefix: "{eino_doc}:",
+				Embedding: &mockEmbedding{sizeForCall: []int{2}, dims: 4},
+				Index:     &IndexConfig{Name: "{eino_doc}idx", Mode: IndexModeCreate, Schema: schemaV1},
+			})
+			convey.So(err, convey.ShouldBeNil)
+			_, err = i.Store(ctx, docs)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(fake.hashes, convey.ShouldContainKey, "{eino_doc}:1")
+			convey.So(fake.hashes, convey.ShouldContainKey, "{eino_doc}:2")
+		})
+	})
+}
@@ -26,15 +26,20 @@ import (
 	"github.com/cloudwego/eino/components/indexer"
 	"github.com/cloudwego/eino/schema"
 	"github.com/redis/go-redis/v9"
+
+	"github.com/cloudwego/eino-ext/libs/acl/rediscluster"
 )
 
 type IndexerConfig struct {
 	// Client is a Redis client representing a pool of zero or more underlying connections.
 	// It's safe for concurrent use by multiple goroutines, which means is okay to pass
 	// an existed Client to create a new Indexer component.
-	Client *redis.Client
+	// Any of redis.Client, redis.ClusterClient and sentinel failover client works.
+	Client redis.UniversalClient
 	// KeyPrefix prefix for each key, hset key would be KeyPrefix+Hashes.Key.
 	// If not set, make sure each key from DocumentToHashes contains same prefix, for ft.Create requires.
+	// With redis.ClusterClient, KeyPrefix should contain a hash tag, e.g. "{eino_doc}:", so that all hashes
+	// land on the shard of the index, and the index name should contain the same hash tag, e.g. "{eino_doc}idx".
 	// see: https://redis.io/docs/latest/develop/interact/search-and-query/advanced-concepts/vectors/#create-a-vector-index
 	KeyPrefix string
 	// DocumentToHashes supports customize key, field and value for redis hash.
@@ -49,6 +54,14 @@ type IndexerConfig struct {
 	BatchSize int `json:"batch_size"`
 	// Embedding vectorization method for values need to be embedded from FieldValue.
 	Embedding embedding.Embedder
+	// Index if set, NewIndexer creates or validates the index, and Store checks
+	// each embedding against the dimension of its vector field.
+	// Default nil, the index is managed outside the indexer.
+	Index *IndexConfig
+	// ParentKey is the metadata key of the parent document of a chunk, which Upsert replaces the chunks of.
+	// It must be stored as a hash field of the same name, as defaultDocumentToFields does for all metadata.
+	// Default "_source", the source uri set by the file loader.
+	ParentKey string
 }
 
 type Hashes struct {
@@ -71,6 +84,8 @@ type FieldValue struct {
 
 type Indexer struct {
 	config *IndexerConfig
+	// vectorDims vector field - dimension pairs of the index, empty if IndexerConfig.Index not set.
+	vectorDims map[string]int
 }
 
 func NewIndexer(ctx context.Context, config *IndexerConfig) (*Indexer, error) {
@@ -82,6 +97,15 @@ func NewIndexer(ctx context.Context, config *IndexerConfig) (*Indexer, error) {
 		return nil, fmt.Errorf("[NewIndexer] redis client not provided")
 	}
 
+	if _, ok := config.Client.(*redis.ClusterClient); ok {
+		if config.KeyPrefix != "" && !rediscluster.HasHashTag(config.KeyPrefix) {
+			return nil, fmt.Errorf("[NewIndexer] key prefix should contain a hash tag with cluster client, got=%s", config.KeyPrefix)
+		}
+		if config.Index != nil && !rediscluster.HasHashTag(config.Index.Name) {
+			return nil, fmt.Errorf("[NewIndexer] index name should contain a hash tag with cluster client, got=%s", config.Index.Name)
+		}
+	}
+
 	if config.DocumentToHashes == nil {
 		config.DocumentToHashes = defaultDocumentToFields
 	}
@@ -90,9 +114,23 @@ func NewIndexer(ctx context.Context, config *IndexerConfig) (*Indexer, error) {
 		config.BatchSize = 10
 	}
 
-	return &Indexer{
+	if config.ParentKey == "" {
+		config.ParentKey = defaultParentKey
+	}
+
+	i := &Indexer{
 		config: config,
-	}, nil
+	}
+
+	if config.Index != nil {
+		dims, err := i.ensureIndex(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("[NewIndexer] %w", err)
+		}
+		i.vectorDims = dims
+	}
+
+	return i, nil
 }
 
 func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
@@ -124,8 +162,21 @@ func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...in
 }
 
 func (i *Indexer) pipelineHSet(ctx context.Context, docs []*schema.Document, options *indexer.Options) (err error) {
-	emb := options.Embedding
 	pipeline := i.config.Client.Pipeline()
+	if _, err = i.queueHSet(ctx, pipeline, docs, options); err != nil {
+		return err
+	}
+
+	if _, err = pipeline.Exec(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// queueHSet embeds docs and queues their hset commands to pipeline, returns the keys of the hashes.
+func (i *Indexer) queueHSet(ctx context.Context, pipeline redis.Pipeliner, docs []*schema.Document, options *indexer.Options) (keys []string, err error) {
+	emb := options.Embedding
 
 	var (
 		tuples []tuple
@@ -153,10 +204,15 @@ func (i *Indexer) pipelineHSet(ctx context.Context, docs []*schema.Document, opt
 		for _, t := range tuples {
 			fields := t.fields
 			for k, idx := range t.key2Idx {
+				if dim, found := i.vectorDims[k]; found && dim != len(vectors[idx]) {
+					return fmt.Errorf("[pipelineHSet] %w, field=%s, expected=%d, got=%d",
+						ErrDimensionMismatch, k, dim, len(vectors[idx]))
+				}
 				fields[k] = vector2Bytes(vectors[idx])
 			}
 
 			pipeline.HSet(ctx, i.config.KeyPrefix+t.key, flatten(fields)...)
+			keys = append(keys, i.config.KeyPrefix+t.key)
 		}
 
 		tuples = tuples[:0]
@@ -168,7 +224,7 @@ func (i *Indexer) pipelineHSet(ctx context.Context, docs []*schema.Document, opt
 	for _, doc := range docs {
 		hashes, err := i.config.DocumentToHashes(ctx, doc)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		key := hashes.Key
@@ -183,13 +239,13 @@ func (i *Indexer) pipelineHSet(ctx context.Context, docs []*schema.Document, opt
 		}
 
 		if embSize > i.config.BatchSize {
-			return fmt.Errorf("[pipelineHSet] embedding size over batch size, batch size=%d, got size=%d",
+			return nil, fmt.Errorf("[pipelineHSet] embedding size over batch size, batch size=%d, got size=%d",
 				i.config.BatchSize, embSize)
 		}
 
 		if len(texts)+embSize > i.config.BatchSize {
 			if err = embAndAdd(); err != nil {
-				return err
+				return nil, err
 			}
 		}
 
@@ -197,20 +253,20 @@ func (i *Indexer) pipelineHSet(ctx context.Context, docs []*schema.Document, opt
 		for k, v := range field2Value {
 			if v.EmbedKey != "" {
 				if _, found := fields[v.EmbedKey]; found {
-					return fmt.Errorf("[pipelineHSet] duplicate key for value and vector, field=%s", k)
+					return nil, fmt.Errorf("[pipelineHSet] duplicate key for value and vector, field=%s", k)
 				}
 
 				var text string
 				if v.Stringify != nil {
 					text, err = v.Stringify(v.Value)
 					if err != nil {
-						return err
+						return nil, err
 					}
 				} else {
 					var ok bool
 					text, ok = v.Value.(string)
 					if !ok {
-						return fmt.Errorf("[pipelineHSet] assert value as string failed, key=%s, emb_key=%s", k, v.EmbedKey)
+						return nil, fmt.Errorf("[pipelineHSet] assert value as string failed, key=%s, emb_key=%s", k, v.EmbedKey)
 					}
 				}
 
@@ -228,15 +284,11 @@ func (i *Indexer) pipelineHSet(ctx context.Context, docs []*schema.Document, opt
 
 	if len(tuples) > 0 {
 		if err = embAndAdd(); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
-	if _, err = pipeline.Exec(ctx); err != nil {
-		return err
-	}
-
-	return nil
+	return keys, nil
 }
 
 func (i *Indexer) makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Context {
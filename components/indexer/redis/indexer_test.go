@@ -18,6 +18,7 @@ package redis
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"testing"
@@ -132,6 +133,22 @@ func TestPipelineHSet(t *testing.T) {
 			}), convey.ShouldBeError, fmt.Errorf("[pipelineHSet] invalid vector length, expected=1, got=2"))
 		})
 
+		PatchConvey("test dimension mismatch", func() {
+			i := &Indexer{
+				config: &IndexerConfig{
+					Client:           mockClient,
+					DocumentToHashes: defaultDocumentToFields,
+					BatchSize:        10,
+				},
+				vectorDims: map[string]int{defaultReturnFieldVectorContent: 1024},
+			}
+
+			err := i.pipelineHSet(ctx, docs, &indexer.Options{
+				Embedding: &mockEmbedding{sizeForCall: []int{2}, dims: 3},
+			})
+			convey.So(errors.Is(err, ErrDimensionMismatch), convey.ShouldBeTrue)
+		})
+
 		PatchConvey("test success", func() {
 			args := make(map[string][]any)
 			pl := &redis.Pipeline{}
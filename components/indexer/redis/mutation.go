@@ -0,0 +1,325 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+	"github.com/redis/go-redis/v9"
+)
+
+// Delete deletes the hashes of ids, the key of each hash is KeyPrefix+id.
+// It implements mutation.Deleter of github.com/cloudwego/eino-ext/components/indexer/mutation.
+func (i *Indexer) Delete(ctx context.Context, ids []string, opts ...indexer.Option) (deleted int64, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{
+		Extra: map[string]any{extraKeyOperation: operationDelete, extraKeyIDs: ids},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, i.config.KeyPrefix+id)
+	}
+
+	if deleted, err = i.del(ctx, keys); err != nil {
+		return 0, err
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{Extra: map[string]any{extraKeyDeleted: deleted}})
+
+	return deleted, nil
+}
+
+// DeleteByFilter deletes the hashes under KeyPrefix whose fields equal every key - value pair of filter.
+// Metadata keys are hash fields of the same name with defaultDocumentToFields, values are compared
+// in the form the client writes them, e.g. true is "1".
+// Hashes are looked up by SCAN with MATCH KeyPrefix*, so that only the hashes under KeyPrefix are read,
+// every key of the database is walked by Redis when KeyPrefix is empty.
+func (i *Indexer) DeleteByFilter(ctx context.Context, filter map[string]any, opts ...indexer.Option) (deleted int64, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{
+		Extra: map[string]any{extraKeyOperation: operationDelete, extraKeyFilter: filter},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	keys, err := i.scanKeys(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	if deleted, err = i.del(ctx, keys); err != nil {
+		return 0, err
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{Extra: map[string]any{extraKeyDeleted: deleted}})
+
+	return deleted, nil
+}
+
+// Upsert stores docs and deletes the other hashes of their parents in one MULTI / EXEC transaction,
+// the parent of a doc is its ParentKey metadata.
+// The other hashes are looked up by SCAN before the transaction without WATCH, so Upsert is not atomic
+// against concurrent writers of the same parents: a hash they add meanwhile is kept, and one they
+// rewrite meanwhile may be deleted.
+// It implements mutation.Upserter of github.com/cloudwego/eino-ext/components/indexer/mutation.
+func (i *Indexer) Upsert(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
+	options := indexer.GetCommonOptions(&indexer.Options{
+		Embedding: i.config.Embedding,
+	}, opts...)
+
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{
+		Docs:  docs,
+		Extra: map[string]any{extraKeyOperation: operationUpsert},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	parents, err := getParents(docs, i.config.ParentKey)
+	if err != nil {
+		return nil, fmt.Errorf("[Upsert] %w", err)
+	}
+
+	pipeline := i.config.Client.TxPipeline()
+	keys, err := i.queueHSet(ctx, pipeline, docs, options)
+	if err != nil {
+		return nil, err
+	}
+
+	written := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		written[key] = struct{}{}
+	}
+
+	var stale []string
+	for _, parent := range parents {
+		found, err := i.scanKeys(ctx, map[string]any{i.config.ParentKey: parent})
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range found {
+			if _, ok := written[key]; !ok {
+				stale = append(stale, key)
+			}
+		}
+	}
+
+	var delCmd *redis.IntCmd
+	if len(stale) > 0 {
+		delCmd = pipeline.Del(ctx, stale...)
+	}
+
+	if _, err = pipeline.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("[Upsert] transaction failed, %w", err)
+	}
+
+	var deleted int64
+	if delCmd != nil {
+		deleted = delCmd.Val()
+	}
+
+	ids = make([]string, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{
+		IDs:   ids,
+		Extra: map[string]any{extraKeyDeleted: deleted},
+	})
+
+	return ids, nil
+}
+
+func (i *Indexer) del(ctx context.Context, keys []string) (int64, error) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	deleted, err := i.config.Client.Del(ctx, keys...).Result()
+	if err != nil {
+		return 0, fmt.Errorf("[Delete] del failed, %w", err)
+	}
+
+	return deleted, nil
+}
+
+// scanKeys returns the keys under KeyPrefix whose hash fields match filter.
+func (i *Indexer) scanKeys(ctx context.Context, filter map[string]any) ([]string, error) {
+	if len(filter) == 0 {
+		return nil, fmt.Errorf("[scanKeys] empty filter")
+	}
+
+	fields := make([]string, 0, len(filter))
+	for field := range filter {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	expected := make([]string, len(fields))
+	for idx, field := range fields {
+		expected[idx] = formatArg(filter[field])
+	}
+
+	var (
+		mu   sync.Mutex
+		keys []string
+	)
+
+	scan := func(ctx context.Context, c redis.Cmdable) error {
+		var cursor uint64
+		for {
+			batch, next, err := c.Scan(ctx, cursor, escapeGlob(i.config.KeyPrefix)+"*", scanCount).Result()
+			if err != nil {
+				return fmt.Errorf("[scanKeys] scan failed, %w", err)
+			}
+
+			matched, err := matchHashes(ctx, c, batch, fields, expected)
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			keys = append(keys, matched...)
+			mu.Unlock()
+
+			if cursor = next; cursor == 0 {
+				return nil
+			}
+		}
+	}
+
+	if cc, ok := i.config.Client.(*redis.ClusterClient); ok {
+		err := cc.ForEachMaster(ctx, func(ctx context.Context, c *redis.Client) error {
+			return scan(ctx, c)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return keys, nil
+	}
+
+	if err := scan(ctx, i.config.Client); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// matchHashes returns the keys whose fields equal expected.
+func matchHashes(ctx context.Context, c redis.Cmdable, keys []string, fields []string, expected []string) ([]string, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	pipeline := c.Pipeline()
+	cmds := make([]*redis.SliceCmd, len(keys))
+	for idx, key := range keys {
+		cmds[idx] = pipeline.HMGet(ctx, key, fields...)
+	}
+	if _, err := pipeline.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("[scanKeys] hmget failed, %w", err)
+	}
+
+	var matched []string
+	for idx, cmd := range cmds {
+		ok := true
+		for j, val := range cmd.Val() {
+			if s, isStr := val.(string); !isStr || s != expected[j] {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			matched = append(matched, keys[idx])
+		}
+	}
+
+	return matched, nil
+}
+
+// getParents returns the distinct parents of docs in order.
+func getParents(docs []*schema.Document, parentKey string) ([]any, error) {
+	var parents []any
+	seen := make(map[any]struct{})
+	for _, doc := range docs {
+		parent, ok := doc.MetaData[parentKey]
+		if !ok || parent == nil {
+			return nil, fmt.Errorf("parent metadata %q not found in document, id=%s", parentKey, doc.ID)
+		}
+		if _, found := seen[parent]; !found {
+			seen[parent] = struct{}{}
+			parents = append(parents, parent)
+		}
+	}
+	return parents, nil
+}
+
+// formatArg formats v the way the client writes a command argument.
+func formatArg(v any) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case []byte:
+		return string(val)
+	case bool:
+		if val {
+			return "1"
+		}
+		return "0"
+	case float32:
+		return strconv.FormatFloat(float64(val), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// escapeGlob escapes the special characters of a SCAN MATCH pattern.
+func escapeGlob(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+	"github.com/redis/go-redis/v9"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/indexer/mutation"
+)
+
+func TestMutation(t *testing.T) {
+	convey.Convey("test delete and upsert", t, func() {
+		ctx := context.Background()
+		fake := newFakeRedis(false)
+		client := redis.NewClient(&redis.Options{Addr: "fake:6379"})
+		client.AddHook(fake)
+
+		i, err := NewIndexer(ctx, &IndexerConfig{
+			Client:    client,
+			KeyPrefix: "eino_doc:",
+			Embedding: &mockEmbedding{sizeForCall: []int{3, 2}, dims: 4},
+		})
+		convey.So(err, convey.ShouldBeNil)
+
+		_, err = i.Store(ctx, []*schema.Document{
+			{ID: "a1", Content: "a1", MetaData: map[string]any{"_source": "a.md", "page": 1}},
+			{ID: "a2", Content: "a2", MetaData: map[string]any{"_source": "a.md", "page": 2}},
+			{ID: "b1", Content: "b1", MetaData: map[string]any{"_source": "b.md", "page": 1}},
+		})
+		convey.So(err, convey.ShouldBeNil)
+		// a hash out of KeyPrefix is never touched
+		fake.hashes["other:a1"] = map[string]any{"_source": "a.md"}
+
+		var deleted []int64
+		ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, callbacks.NewHandlerBuilder().
+			OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+				if n, ok := indexer.ConvCallbackOutput(output).Extra[extraKeyDeleted].(int64); ok {
+					deleted = append(deleted, n)
+				}
+				return ctx
+			}).Build())
+
+		convey.Convey("test delete by ids", func() {
+			n, err := i.Delete(ctx, []string{"a1", "b1", "c1"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(n, convey.ShouldEqual, 2)
+			convey.So(deleted, convey.ShouldResemble, []int64{2})
+			convey.So(fake.hashes, convey.ShouldContainKey, "eino_doc:a2")
+			convey.So(fake.hashes, convey.ShouldNotContainKey, "eino_doc:a1")
+		})
+
+		convey.Convey("test delete by filter", func() {
+			n, err := i.DeleteByFilter(ctx, map[string]any{"_source": "a.md", "page": 2})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(n, convey.ShouldEqual, 1)
+			convey.So(fake.hashes, convey.ShouldNotContainKey, "eino_doc:a2")
+
+			n, err = i.DeleteByFilter(ctx, map[string]any{"_source": "a.md"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(n, convey.ShouldEqual, 1)
+			convey.So(fake.hashes, convey.ShouldContainKey, "eino_doc:b1")
+			convey.So(fake.hashes, convey.ShouldContainKey, "other:a1")
+			convey.So(deleted, convey.ShouldResemble, []int64{1, 1})
+			convey.So(fake.matches, convey.ShouldResemble, []string{"eino_doc:*", "eino_doc:*"})
+
+			_, err = i.DeleteByFilter(ctx, nil)
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test upsert", func() {
+			ids, err := i.Upsert(ctx, []*schema.Document{
+				{ID: "a1", Content: "a1 new", MetaData: map[string]any{"_source": "a.md"}},
+				{ID: "a3", Content: "a3", MetaData: map[string]any{"_source": "a.md"}},
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids, convey.ShouldResemble, []string{"a1", "a3"})
+			convey.So(deleted, convey.ShouldResemble, []int64{1})
+			convey.So(fake.hashes["eino_doc:a1"][defaultReturnFieldContent], convey.ShouldEqual, "a1 new")
+			convey.So(fake.hashes, convey.ShouldContainKey, "eino_doc:a3")
+			convey.So(fake.hashes, convey.ShouldNotContainKey, "eino_doc:a2")
+			convey.So(fake.hashes, convey.ShouldContainKey, "eino_doc:b1")
+			convey.So(fake.txs, convey.ShouldResemble, [][]string{{"HSET", "HSET", "DEL"}})
+
+			_, err = i.Upsert(ctx, []*schema.Document{{ID: "c1", Content: "c1"}})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestFormatArg(t *testing.T) {
+	convey.Convey("test formatArg and escapeGlob", t, func() {
+		convey.So(formatArg("a"), convey.ShouldEqual, "a")
+		convey.So(formatArg(true), convey.ShouldEqual, "1")
+		convey.So(formatArg(1.5), convey.ShouldEqual, "1.5")
+		convey.So(formatArg(int64(3)), convey.ShouldEqual, "3")
+		convey.So(escapeGlob("{doc}[1]*?"), convey.ShouldEqual, "{doc}\\[1\\]\\*\\?")
+	})
+}
+
+var (
+	_ mutation.Deleter  = (*Indexer)(nil)
+	_ mutation.Upserter = (*Indexer)(nil)
+)
+
+func TestMutationConsts(t *testing.T) {
+	convey.Convey("test callback extra keys and operations same as mutation", t, func() {
+		convey.So(extraKeyOperation, convey.ShouldEqual, mutation.ExtraKeyOperation)
+		convey.So(extraKeyIDs, convey.ShouldEqual, mutation.ExtraKeyIDs)
+		convey.So(extraKeyFilter, convey.ShouldEqual, mutation.ExtraKeyFilter)
+		convey.So(extraKeyDeleted, convey.ShouldEqual, mutation.ExtraKeyDeleted)
+		convey.So(operationDelete, convey.ShouldEqual, mutation.OperationDelete)
+		convey.So(operationUpsert, convey.ShouldEqual, mutation.OperationUpsert)
+		convey.So(defaultParentKey, convey.ShouldEqual, mutation.MetaKeyParent)
+	})
+}
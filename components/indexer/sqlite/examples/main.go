@@ -0,0 +1,93 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/cloudwego/eino-ext/components/indexer/sqlite"
+)
+
+// run with: go run -tags sqlite_fts5 .
+func main() {
+	ctx := context.Background()
+
+	path := os.Getenv("SQLITE_PATH")
+	if path == "" {
+		path = filepath.Join(os.TempDir(), "eino_docs.db")
+	}
+
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		log.Fatalf("sql.Open failed, err=%v", err)
+	}
+	defer db.Close()
+
+	idx, err := sqlite.NewIndexer(ctx, &sqlite.IndexerConfig{
+		DB:        db,
+		Table:     "eino_docs",
+		Tokenizer: "porter unicode61",
+		Embedding: &letterEmbedding{}, // replace it with real embedding component
+	})
+	if err != nil {
+		log.Fatalf("NewIndexer failed, err=%v", err)
+	}
+
+	ids, err := idx.Store(ctx, []*schema.Document{
+		{ID: "intro_1", Content: "eino is a llm application framework", MetaData: map[string]any{"_source": "intro.md", "page": 1}},
+		{ID: "intro_2", Content: "eino components are composed by graphs", MetaData: map[string]any{"_source": "intro.md", "page": 2}},
+		{ID: "sqlite_1", Content: "sqlite keeps documents in a single file", MetaData: map[string]any{"_source": "sqlite.md", "page": 1}},
+	})
+	if err != nil {
+		log.Fatalf("Store failed, err=%v", err)
+	}
+	log.Printf("stored ids=%v to %s", ids, path)
+
+	// intro.md is split to one chunk now, the stale chunk intro_2 is deleted
+	ids, err = idx.Upsert(ctx, []*schema.Document{
+		{ID: "intro_1", Content: "eino is a llm application framework written in go", MetaData: map[string]any{"_source": "intro.md", "page": 1}},
+	})
+	if err != nil {
+		log.Fatalf("Upsert failed, err=%v", err)
+	}
+	log.Printf("upserted ids=%v", ids)
+}
+
+// letterEmbedding embeds a text to its letter frequencies, only for the example.
+type letterEmbedding struct{}
+
+func (l *letterEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = make([]float64, 26)
+		for _, c := range strings.ToLower(text) {
+			if c >= 'a' && c <= 'z' {
+				vectors[i][c-'a']++
+			}
+		}
+	}
+	return vectors, nil
+}
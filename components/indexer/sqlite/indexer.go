@@ -0,0 +1,298 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+)
+
+type IndexerConfig struct {
+	// DB SQLite database, e.g. opened by sql.Open("sqlite", "docs.db") with driver modernc.org/sqlite,
+	// or sql.Open("sqlite3", "docs.db") with github.com/mattn/go-sqlite3 built with tag sqlite_fts5.
+	// The driver should support FTS5 unless DisableFullText is set.
+	// Required.
+	DB *sql.DB
+	// Table stores documents in columns id, content, metadata (json text) and embedding (float32 blob),
+	// it's created with its FTS5 table Table+"_fts" if they don't exist.
+	// Default "eino_docs".
+	Table string
+	// Tokenizer of the FTS5 table, e.g. "porter unicode61" for english stemming, or "trigram" for CJK text.
+	// It only takes effect when the FTS5 table is created.
+	// see: https://www.sqlite.org/fts5.html#tokenizers
+	// Default "unicode61".
+	Tokenizer string
+	// DisableFullText skips the FTS5 table, for drivers without FTS5, the retriever only searches by vector then.
+	// Default false.
+	DisableFullText bool
+	// Embedding vectorization method for the content of documents without a dense vector.
+	// Documents carrying schema.Document.DenseVector() are stored with it, without embedding.
+	// Required unless every document carries a dense vector.
+	Embedding embedding.Embedder
+	// BatchSize controls max texts size for embedding.
+	// Default 10.
+	BatchSize int
+	// ParentKey is the metadata key of the parent document of a chunk, which Upsert replaces the chunks of.
+	// Default "_source", the source uri set by the file loader.
+	ParentKey string
+}
+
+type Indexer struct {
+	config *IndexerConfig
+}
+
+func NewIndexer(ctx context.Context, config *IndexerConfig) (*Indexer, error) {
+	if config.DB == nil {
+		return nil, fmt.Errorf("[NewIndexer] db not provided")
+	}
+
+	if config.Table == "" {
+		config.Table = defaultTable
+	}
+
+	if config.Tokenizer == "" {
+		config.Tokenizer = defaultTokenizer
+	}
+
+	if config.BatchSize == 0 {
+		config.BatchSize = defaultBatchSize
+	}
+
+	if config.ParentKey == "" {
+		config.ParentKey = defaultParentKey
+	}
+
+	tokenizer := config.Tokenizer
+	if config.DisableFullText {
+		tokenizer = ""
+	}
+	if err := createTable(ctx, config.DB, createTableStatements(config.Table, tokenizer)); err != nil {
+		return nil, fmt.Errorf("[NewIndexer] %w", err)
+	}
+
+	return &Indexer{
+		config: config,
+	}, nil
+}
+
+func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{Docs: docs})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	options := indexer.GetCommonOptions(&indexer.Options{
+		Embedding: i.config.Embedding,
+	}, opts...)
+
+	rows, err := i.makeRows(ctx, docs, options.Embedding)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.withTx(ctx, func(tx *sql.Tx) error {
+		return i.insertRows(ctx, tx, rows)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids = docIDs(docs)
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{IDs: ids})
+
+	return ids, nil
+}
+
+type row struct {
+	id       string
+	content  string
+	metadata string
+	vector   []byte
+}
+
+// makeRows maps docs to rows, embedding the content of docs without a dense vector.
+func (i *Indexer) makeRows(ctx context.Context, docs []*schema.Document, emb embedding.Embedder) ([]*row, error) {
+	var (
+		rows    = make([]*row, 0, len(docs))
+		texts   []string
+		targets []int
+	)
+
+	for idx, doc := range docs {
+		if doc.ID == "" {
+			return nil, fmt.Errorf("[makeRows] document id not provided, index=%d", idx)
+		}
+
+		kept := make(map[string]any, len(doc.MetaData))
+		for k, v := range doc.MetaData {
+			if k != docMetaDataKeyDenseVector {
+				kept[k] = v
+			}
+		}
+
+		metadata, err := json.Marshal(kept)
+		if err != nil {
+			return nil, fmt.Errorf("[makeRows] marshal metadata failed, id=%s, %w", doc.ID, err)
+		}
+
+		r := &row{id: doc.ID, content: doc.Content, metadata: string(metadata)}
+		if v := doc.DenseVector(); len(v) > 0 {
+			r.vector = encodeVector(v)
+		} else {
+			targets = append(targets, idx)
+			texts = append(texts, doc.Content)
+		}
+		rows = append(rows, r)
+	}
+
+	vectors, err := i.embed(ctx, texts, emb)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx, target := range targets {
+		rows[target].vector = encodeVector(vectors[idx])
+	}
+
+	return rows, nil
+}
+
+// embed vectorizes texts in batches of BatchSize.
+func (i *Indexer) embed(ctx context.Context, texts []string, emb embedding.Embedder) ([][]float64, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+
+	if emb == nil {
+		return nil, fmt.Errorf("[embed] embedding method not provided")
+	}
+
+	vectors := make([][]float64, 0, len(texts))
+	for start := 0; start < len(texts); start += i.config.BatchSize {
+		end := start + i.config.BatchSize
+		if end > len(texts) {
+			end = len(texts)
+		}
+
+		batch, err := emb.EmbedStrings(i.makeEmbeddingCtx(ctx, emb), texts[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("[embed] embedding failed, %w", err)
+		}
+
+		if len(batch) != end-start {
+			return nil, fmt.Errorf("[embed] invalid vector length, expected=%d, got=%d", end-start, len(batch))
+		}
+
+		vectors = append(vectors, batch...)
+	}
+
+	return vectors, nil
+}
+
+// insertRows inserts rows, updating the rows of existing ids, so that the FTS5 table is updated by the update trigger.
+func (i *Indexer) insertRows(ctx context.Context, tx *sql.Tx, rows []*row) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s, %s, %s, %s) VALUES (?, ?, ?, ?) "+
+		"ON CONFLICT (%s) DO UPDATE SET %s = excluded.%s, %s = excluded.%s, %s = excluded.%s",
+		quoteIdent(i.config.Table), quoteIdent(columnID), quoteIdent(columnContent), quoteIdent(columnMetadata), quoteIdent(columnVector),
+		quoteIdent(columnID),
+		quoteIdent(columnContent), quoteIdent(columnContent),
+		quoteIdent(columnMetadata), quoteIdent(columnMetadata),
+		quoteIdent(columnVector), quoteIdent(columnVector))
+
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("[insertRows] prepare failed, %w", err)
+	}
+	defer stmt.Close()
+
+	for _, r := range rows {
+		if _, err = stmt.ExecContext(ctx, r.id, r.content, r.metadata, r.vector); err != nil {
+			return fmt.Errorf("[insertRows] exec failed, id=%s, %w", r.id, err)
+		}
+	}
+
+	return nil
+}
+
+func (i *Indexer) withTx(ctx context.Context, fn func(tx *sql.Tx) error) (err error) {
+	tx, err := i.config.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("[withTx] begin failed, %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("[withTx] commit failed, %w", err)
+	}
+
+	return nil
+}
+
+func (i *Indexer) makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfEmbedding,
+	}
+
+	if embType, ok := components.GetType(emb); ok {
+		runInfo.Type = embType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+func (i *Indexer) GetType() string {
+	return typ
+}
+
+func (i *Indexer) IsCallbacksEnabled() bool {
+	return true
+}
+
+func docIDs(docs []*schema.Document) []string {
+	ids := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+	return ids
+}
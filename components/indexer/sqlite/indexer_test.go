@@ -0,0 +1,200 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+// mockEmbedding embeds a text to its length and its first byte.
+type mockEmbedding struct {
+	calls int
+	err   error
+}
+
+func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		if text == "" {
+			vectors[i] = []float64{0, 0}
+			continue
+		}
+		vectors[i] = []float64{float64(len(text)), float64(text[0])}
+	}
+	return vectors, nil
+}
+
+// openDB opens a database in a temp file, and reports whether the driver supports FTS5,
+// which github.com/mattn/go-sqlite3 does with build tag sqlite_fts5.
+func openDB(t *testing.T) (*sql.DB, bool) {
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "docs.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	_, err = db.Exec("CREATE VIRTUAL TABLE temp.fts5_probe USING fts5(x)")
+	return db, err == nil
+}
+
+type storedRow struct {
+	content  string
+	metadata string
+	vector   []byte
+}
+
+func getRow(db *sql.DB, table, id string) (*storedRow, error) {
+	r := &storedRow{}
+	err := db.QueryRow(`SELECT content, metadata, embedding FROM "`+table+`" WHERE id = ?`, id).Scan(&r.content, &r.metadata, &r.vector)
+	return r, err
+}
+
+func countRows(db *sql.DB, query string, args ...any) int {
+	var n int
+	convey.So(db.QueryRow(query, args...).Scan(&n), convey.ShouldBeNil)
+	return n
+}
+
+func TestIndexer(t *testing.T) {
+	convey.Convey("test sqlite indexer", t, func() {
+		ctx := context.Background()
+		db, fts := openDB(t)
+		emb := &mockEmbedding{}
+
+		_, err := NewIndexer(ctx, &IndexerConfig{})
+		convey.So(err, convey.ShouldNotBeNil)
+
+		i, err := NewIndexer(ctx, &IndexerConfig{DB: db, Embedding: emb, DisableFullText: !fts, BatchSize: 2})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(i.config.Table, convey.ShouldEqual, defaultTable)
+		convey.So(i.config.Tokenizer, convey.ShouldEqual, defaultTokenizer)
+		convey.So(i.config.ParentKey, convey.ShouldEqual, defaultParentKey)
+		convey.So(i.GetType(), convey.ShouldEqual, typ)
+		convey.So(i.IsCallbacksEnabled(), convey.ShouldBeTrue)
+
+		// created again
+		_, err = NewIndexer(ctx, &IndexerConfig{DB: db, DisableFullText: !fts})
+		convey.So(err, convey.ShouldBeNil)
+
+		convey.Convey("test store", func() {
+			docs := []*schema.Document{
+				{ID: "1", Content: "eino is a framework", MetaData: map[string]any{"lang": "en", "page": 1}},
+				{ID: "2", Content: "graphs compose components"},
+				{ID: "3", Content: "carries a vector"},
+			}
+			docs[2].WithDenseVector([]float64{0.5, -1})
+
+			ids, err := i.Store(ctx, docs)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids, convey.ShouldResemble, []string{"1", "2", "3"})
+			convey.So(emb.calls, convey.ShouldEqual, 1)
+
+			r, err := getRow(db, defaultTable, "1")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(r.content, convey.ShouldEqual, "eino is a framework")
+			convey.So(r.metadata, convey.ShouldEqual, `{"lang":"en","page":1}`)
+			convey.So(r.vector, convey.ShouldResemble, encodeVector([]float64{19, 'e'}))
+
+			r, err = getRow(db, defaultTable, "2")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(r.metadata, convey.ShouldEqual, "{}")
+
+			r, err = getRow(db, defaultTable, "3")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(r.vector, convey.ShouldResemble, encodeVector([]float64{0.5, -1}))
+			convey.So(r.metadata, convey.ShouldEqual, "{}")
+
+			// stored again, updated
+			_, err = i.Store(ctx, []*schema.Document{{ID: "1", Content: "eino is a go framework"}})
+			convey.So(err, convey.ShouldBeNil)
+			r, err = getRow(db, defaultTable, "1")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(r.content, convey.ShouldEqual, "eino is a go framework")
+			convey.So(r.metadata, convey.ShouldEqual, "{}")
+			convey.So(countRows(db, `SELECT count(*) FROM "eino_docs"`), convey.ShouldEqual, 3)
+
+			if fts {
+				// the fts table follows inserts and updates
+				match := `SELECT count(*) FROM "eino_docs_fts" WHERE "eino_docs_fts" MATCH ?`
+				convey.So(countRows(db, match, "framework"), convey.ShouldEqual, 1)
+				convey.So(countRows(db, match, "go"), convey.ShouldEqual, 1)
+				convey.So(countRows(db, match, "vector"), convey.ShouldEqual, 1)
+			}
+		})
+
+		convey.Convey("test store errors", func() {
+			_, err := i.Store(ctx, []*schema.Document{{Content: "no id"}})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			_, err = i.Store(ctx, []*schema.Document{{ID: "1", MetaData: map[string]any{"c": make(chan int)}}})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			_, err = i.Store(ctx, []*schema.Document{{ID: "1"}}, indexer.WithEmbedding(&mockEmbedding{err: errors.New("mock err")}))
+			convey.So(err, convey.ShouldNotBeNil)
+
+			i.config.Embedding = nil
+			_, err = i.Store(ctx, []*schema.Document{{ID: "1"}})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			_, err = i.embed(ctx, []string{"a", "b"}, embedding.Embedder(&badEmbedding{}))
+			convey.So(err, convey.ShouldNotBeNil)
+
+			convey.So(db.Close(), convey.ShouldBeNil)
+			_, err = i.Store(ctx, []*schema.Document{(&schema.Document{ID: "1"}).WithDenseVector([]float64{1})})
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = NewIndexer(ctx, &IndexerConfig{DB: db})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+// badEmbedding returns no vector.
+type badEmbedding struct{}
+
+func (b *badEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	return nil, nil
+}
+
+func TestCreateTableStatements(t *testing.T) {
+	convey.Convey("test create table statements", t, func() {
+		convey.So(createTableStatements("docs", ""), convey.ShouldResemble, []string{
+			`CREATE TABLE IF NOT EXISTS "docs" ("id" TEXT PRIMARY KEY, "content" TEXT NOT NULL, "metadata" TEXT NOT NULL DEFAULT '{}', "embedding" BLOB)`,
+		})
+
+		stmts := createTableStatements("docs", "porter unicode61")
+		convey.So(len(stmts), convey.ShouldEqual, 5)
+		convey.So(stmts[1], convey.ShouldEqual,
+			`CREATE VIRTUAL TABLE IF NOT EXISTS "docs_fts" USING fts5("content", content='docs', content_rowid='rowid', tokenize='porter unicode61')`)
+		convey.So(stmts[4], convey.ShouldEqual, `CREATE TRIGGER IF NOT EXISTS "docs_fts_au" AFTER UPDATE ON "docs" BEGIN `+
+			`INSERT INTO "docs_fts"("docs_fts", rowid, "content") VALUES ('delete', old.rowid, old."content"); `+
+			`INSERT INTO "docs_fts"(rowid, "content") VALUES (new.rowid, new."content"); END`)
+	})
+}
@@ -0,0 +1,263 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Delete deletes the documents of ids.
+// It implements mutation.Deleter of github.com/cloudwego/eino-ext/components/indexer/mutation.
+func (i *Indexer) Delete(ctx context.Context, ids []string, opts ...indexer.Option) (deleted int64, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{
+		Extra: map[string]any{extraKeyOperation: operationDelete, extraKeyIDs: ids},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	err = i.withTx(ctx, func(tx *sql.Tx) error {
+		for start := 0; start < len(ids); start += maxParams {
+			end := start + maxParams
+			if end > len(ids) {
+				end = len(ids)
+			}
+
+			query := fmt.Sprintf("DELETE FROM %s WHERE %s IN (%s)",
+				quoteIdent(i.config.Table), quoteIdent(columnID), placeholders(end-start))
+			n, err := execAffected(ctx, tx, query, toArgs(ids[start:end])...)
+			if err != nil {
+				return err
+			}
+			deleted += n
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("[Delete] %w", err)
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{Extra: map[string]any{extraKeyDeleted: deleted}})
+
+	return deleted, nil
+}
+
+// DeleteByFilter deletes the documents whose metadata equals every key - value pair of filter, matched by json_extract.
+// Values should be scalars, numbers are compared by value regardless of their type.
+func (i *Indexer) DeleteByFilter(ctx context.Context, filter map[string]any, opts ...indexer.Option) (deleted int64, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{
+		Extra: map[string]any{extraKeyOperation: operationDelete, extraKeyFilter: filter},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	if len(filter) == 0 {
+		return 0, fmt.Errorf("[DeleteByFilter] empty filter")
+	}
+
+	keys := make([]string, 0, len(filter))
+	for k := range filter {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var (
+		conds []string
+		args  []any
+	)
+	for _, k := range keys {
+		cond, arg, err := equalCond(k, filter[k])
+		if err != nil {
+			return 0, fmt.Errorf("[DeleteByFilter] %w", err)
+		}
+		conds = append(conds, cond)
+		args = append(args, arg...)
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE %s", quoteIdent(i.config.Table), strings.Join(conds, " AND "))
+	if deleted, err = execAffected(ctx, i.config.DB, query, args...); err != nil {
+		return 0, fmt.Errorf("[DeleteByFilter] %w", err)
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{Extra: map[string]any{extraKeyDeleted: deleted}})
+
+	return deleted, nil
+}
+
+// Upsert stores docs, which overwrites the documents of the same ids, then deletes the other documents of their parents,
+// the parent of a doc is its ParentKey metadata. Both run in one transaction.
+// It implements mutation.Upserter of github.com/cloudwego/eino-ext/components/indexer/mutation.
+func (i *Indexer) Upsert(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{
+		Docs:  docs,
+		Extra: map[string]any{extraKeyOperation: operationUpsert},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	options := indexer.GetCommonOptions(&indexer.Options{
+		Embedding: i.config.Embedding,
+	}, opts...)
+
+	parents, err := getParents(docs, i.config.ParentKey)
+	if err != nil {
+		return nil, fmt.Errorf("[Upsert] %w", err)
+	}
+
+	rows, err := i.makeRows(ctx, docs, options.Embedding)
+	if err != nil {
+		return nil, err
+	}
+
+	ids = docIDs(docs)
+
+	var deleted int64
+	err = i.withTx(ctx, func(tx *sql.Tx) error {
+		if err := i.insertRows(ctx, tx, rows); err != nil {
+			return err
+		}
+
+		if len(parents) == 0 {
+			return nil
+		}
+
+		query, args, err := i.deleteStaleStatement(parents, ids)
+		if err != nil {
+			return err
+		}
+
+		deleted, err = execAffected(ctx, tx, query, args...)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[Upsert] %w", err)
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{
+		IDs:   ids,
+		Extra: map[string]any{extraKeyDeleted: deleted},
+	})
+
+	return ids, nil
+}
+
+// deleteStaleStatement deletes the documents of parents except ids, ids are matched by a json array
+// so that the statement isn't limited by the number of bind parameters.
+func (i *Indexer) deleteStaleStatement(parents []any, ids []string) (string, []any, error) {
+	path, err := jsonPathKey(i.config.ParentKey)
+	if err != nil {
+		return "", nil, err
+	}
+
+	args := []any{path}
+	for _, parent := range parents {
+		arg, _ := scalarArg(parent) // checked by getParents
+		args = append(args, arg)
+	}
+
+	idList, err := jsonArray(ids)
+	if err != nil {
+		return "", nil, err
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE json_extract(%s, ?) IN (%s) AND %s NOT IN (SELECT value FROM json_each(?))",
+		quoteIdent(i.config.Table), quoteIdent(columnMetadata), placeholders(len(parents)), quoteIdent(columnID))
+
+	return query, append(args, idList), nil
+}
+
+// equalCond matches documents whose metadata key equals value.
+func equalCond(key string, value any) (string, []any, error) {
+	path, err := jsonPathKey(key)
+	if err != nil {
+		return "", nil, err
+	}
+
+	arg, err := scalarArg(value)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid value of key %q, %w", key, err)
+	}
+
+	return fmt.Sprintf("json_extract(%s, ?) = ?", quoteIdent(columnMetadata)), []any{path, arg}, nil
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// execAffected executes query and returns the number of affected rows.
+func execAffected(ctx context.Context, db execer, query string, args ...any) (int64, error) {
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("exec failed, %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("get affected rows failed, %w", err)
+	}
+
+	return n, nil
+}
+
+// getParents returns the distinct parents of docs in order, parents should be scalars.
+func getParents(docs []*schema.Document, parentKey string) ([]any, error) {
+	var parents []any
+	seen := make(map[any]struct{})
+	for _, doc := range docs {
+		parent, ok := doc.MetaData[parentKey]
+		if !ok || parent == nil {
+			return nil, fmt.Errorf("parent metadata %q not found in document, id=%s", parentKey, doc.ID)
+		}
+		if _, err := scalarArg(parent); err != nil {
+			return nil, fmt.Errorf("invalid parent metadata %q in document, id=%s, %w", parentKey, doc.ID, err)
+		}
+		if _, found := seen[parent]; !found {
+			seen[parent] = struct{}{}
+			parents = append(parents, parent)
+		}
+	}
+	return parents, nil
+}
+
+func toArgs(ids []string) []any {
+	args := make([]any, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	return args
+}
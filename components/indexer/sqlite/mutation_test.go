@@ -0,0 +1,162 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/indexer/mutation"
+)
+
+func TestMutation(t *testing.T) {
+	convey.Convey("test sqlite indexer mutation", t, func() {
+		ctx := context.Background()
+		db, fts := openDB(t)
+
+		i, err := NewIndexer(ctx, &IndexerConfig{DB: db, Embedding: &mockEmbedding{}, DisableFullText: !fts})
+		convey.So(err, convey.ShouldBeNil)
+
+		_, err = i.Store(ctx, []*schema.Document{
+			{ID: "a1", Content: "a one", MetaData: map[string]any{"_source": "a.md", "page": 1, "draft": true}},
+			{ID: "a2", Content: "a two", MetaData: map[string]any{"_source": "a.md", "page": 2}},
+			{ID: "b1", Content: "b one", MetaData: map[string]any{"_source": "b.md", "page": 1.0}},
+		})
+		convey.So(err, convey.ShouldBeNil)
+
+		var extras []map[string]any
+		handler := callbacks.NewHandlerBuilder().
+			OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+				extras = append(extras, indexer.ConvCallbackOutput(output).Extra)
+				return ctx
+			}).Build()
+		ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, handler)
+
+		count := func() int {
+			return countRows(db, `SELECT count(*) FROM "eino_docs"`)
+		}
+
+		convey.Convey("test delete", func() {
+			deleted, err := i.Delete(ctx, []string{"a1", "b1", "c1"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(deleted, convey.ShouldEqual, 2)
+			convey.So(count(), convey.ShouldEqual, 1)
+			convey.So(extras[0][extraKeyDeleted], convey.ShouldEqual, int64(2))
+
+			// more ids than the parameters of a statement
+			ids := make([]string, 0, maxParams+2)
+			for n := 0; n < maxParams+1; n++ {
+				ids = append(ids, fmt.Sprint(n))
+			}
+			deleted, err = i.Delete(ctx, append(ids, "a2"))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(deleted, convey.ShouldEqual, 1)
+			convey.So(count(), convey.ShouldEqual, 0)
+
+			if fts {
+				convey.So(countRows(db, `SELECT count(*) FROM "eino_docs_fts" WHERE "eino_docs_fts" MATCH 'one'`), convey.ShouldEqual, 0)
+			}
+		})
+
+		convey.Convey("test delete by filter", func() {
+			_, err := i.DeleteByFilter(ctx, nil)
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = i.DeleteByFilter(ctx, map[string]any{"tags": []string{"a"}})
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = i.DeleteByFilter(ctx, map[string]any{`a"b`: 1})
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = i.DeleteByFilter(ctx, map[string]any{"page": nil})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			deleted, err := i.DeleteByFilter(ctx, map[string]any{"draft": true})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(deleted, convey.ShouldEqual, 1)
+
+			// numbers regardless of their type
+			deleted, err = i.DeleteByFilter(ctx, map[string]any{"page": 1, "_source": "b.md"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(deleted, convey.ShouldEqual, 1)
+			convey.So(count(), convey.ShouldEqual, 1)
+		})
+
+		convey.Convey("test upsert", func() {
+			ids, err := i.Upsert(ctx, []*schema.Document{
+				{ID: "a1", Content: "a one again", MetaData: map[string]any{"_source": "a.md"}},
+				{ID: "a3", Content: "a three", MetaData: map[string]any{"_source": "a.md"}},
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids, convey.ShouldResemble, []string{"a1", "a3"})
+			convey.So(extras[0][extraKeyDeleted], convey.ShouldEqual, int64(1))
+
+			_, err = getRow(db, defaultTable, "a2")
+			convey.So(err, convey.ShouldNotBeNil)
+			r, err := getRow(db, defaultTable, "a1")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(r.content, convey.ShouldEqual, "a one again")
+			convey.So(count(), convey.ShouldEqual, 3)
+
+			_, err = i.Upsert(ctx, []*schema.Document{{ID: "c1"}})
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = i.Upsert(ctx, []*schema.Document{{ID: "c1", MetaData: map[string]any{"_source": map[string]any{}}}})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			i.config.ParentKey = `a"b`
+			_, err = i.Upsert(ctx, []*schema.Document{{ID: "c1", MetaData: map[string]any{`a"b`: "c"}}})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			// nothing is changed on failures
+			convey.So(count(), convey.ShouldEqual, 3)
+
+			ids, err = i.Upsert(ctx, nil)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids, convey.ShouldBeEmpty)
+		})
+
+		convey.Convey("test closed db", func() {
+			convey.So(db.Close(), convey.ShouldBeNil)
+			_, err := i.Delete(ctx, []string{"a1"})
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = i.DeleteByFilter(ctx, map[string]any{"page": 1})
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = i.Upsert(ctx, []*schema.Document{{ID: "c1", Content: "c", MetaData: map[string]any{"_source": "c.md"}}})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+var (
+	_ mutation.Deleter  = (*Indexer)(nil)
+	_ mutation.Upserter = (*Indexer)(nil)
+)
+
+func TestMutationConsts(t *testing.T) {
+	convey.Convey("test callback extra keys and operations same as mutation", t, func() {
+		convey.So(extraKeyOperation, convey.ShouldEqual, mutation.ExtraKeyOperation)
+		convey.So(extraKeyIDs, convey.ShouldEqual, mutation.ExtraKeyIDs)
+		convey.So(extraKeyFilter, convey.ShouldEqual, mutation.ExtraKeyFilter)
+		convey.So(extraKeyDeleted, convey.ShouldEqual, mutation.ExtraKeyDeleted)
+		convey.So(operationDelete, convey.ShouldEqual, mutation.OperationDelete)
+		convey.So(operationUpsert, convey.ShouldEqual, mutation.OperationUpsert)
+		convey.So(defaultParentKey, convey.ShouldEqual, mutation.MetaKeyParent)
+	})
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// createTableStatements returns the statements creating the documents table, and its FTS5 table with the triggers
+// keeping it in sync if tokenizer isn't empty.
+func createTableStatements(table, tokenizer string) []string {
+	name := quoteIdent(table)
+	stmts := []string{
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s TEXT PRIMARY KEY, %s TEXT NOT NULL, %s TEXT NOT NULL DEFAULT '{}', %s BLOB)",
+			name, quoteIdent(columnID), quoteIdent(columnContent), quoteIdent(columnMetadata), quoteIdent(columnVector)),
+	}
+	if tokenizer == "" {
+		return stmts
+	}
+
+	fts := quoteIdent(table + ftsSuffix)
+	content := quoteIdent(columnContent)
+	insert := fmt.Sprintf("INSERT INTO %s(rowid, %s) VALUES (new.rowid, new.%s);", fts, content, content)
+	remove := fmt.Sprintf("INSERT INTO %s(%s, rowid, %s) VALUES ('delete', old.rowid, old.%s);", fts, fts, content, content)
+
+	return append(stmts,
+		// an external content table, the content is read from the documents table
+		fmt.Sprintf("CREATE VIRTUAL TABLE IF NOT EXISTS %s USING fts5(%s, content=%s, content_rowid='rowid', tokenize=%s)",
+			fts, content, quoteLiteral(table), quoteLiteral(tokenizer)),
+		fmt.Sprintf("CREATE TRIGGER IF NOT EXISTS %s AFTER INSERT ON %s BEGIN %s END",
+			quoteIdent(table+ftsSuffix+"_ai"), name, insert),
+		fmt.Sprintf("CREATE TRIGGER IF NOT EXISTS %s AFTER DELETE ON %s BEGIN %s END",
+			quoteIdent(table+ftsSuffix+"_ad"), name, remove),
+		fmt.Sprintf("CREATE TRIGGER IF NOT EXISTS %s AFTER UPDATE ON %s BEGIN %s %s END",
+			quoteIdent(table+ftsSuffix+"_au"), name, remove, insert),
+	)
+}
+
+func createTable(ctx context.Context, db *sql.DB, stmts []string) error {
+	for _, stmt := range stmts {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("[createTable] exec failed, sql=%s, %w", stmt, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlite
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// quoteIdent quotes an identifier, e.g. docs to "docs".
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// encodeVector encodes v as a blob of little endian float32, the format of the embedding column,
+// which is also the vector format of the sqlite-vec extension.
+func encodeVector(v []float64) []byte {
+	b := make([]byte, 4*len(v))
+	for i, f := range v {
+		binary.LittleEndian.PutUint32(b[4*i:], math.Float32bits(float32(f)))
+	}
+	return b
+}
+
+// jsonPathKey returns the json path of a top level key, e.g. $."page".
+func jsonPathKey(field string) (string, error) {
+	if strings.Contains(field, `"`) {
+		return "", fmt.Errorf("metadata key with double quotes isn't supported, key=%s", field)
+	}
+	return `$."` + field + `"`, nil
+}
+
+// scalarArg converts a metadata value to the arg compared with json_extract, which returns 1 and 0 for true and false.
+func scalarArg(v any) (any, error) {
+	switch t := v.(type) {
+	case bool:
+		if t {
+			return int64(1), nil
+		}
+		return int64(0), nil
+	case string, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return v, nil
+	case nil:
+		return nil, fmt.Errorf("null value isn't supported")
+	}
+
+	switch reflect.Indirect(reflect.ValueOf(v)).Kind() {
+	case reflect.Map, reflect.Struct, reflect.Array, reflect.Slice:
+		return nil, fmt.Errorf("non scalar value isn't supported, type=%T", v)
+	default:
+		return v, nil
+	}
+}
+
+// placeholders returns n comma separated placeholders, e.g. ?,?.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
+
+func jsonArray(values []string) (string, error) {
+	b, err := json.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("marshal json array failed, %w", err)
+	}
+	return string(b), nil
+}
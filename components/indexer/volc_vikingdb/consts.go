@@ -36,3 +36,22 @@ const (
 	vikingEmbeddingRespSentenceDense  = "sentence_dense_embedding"
 	vikingEmbeddingRespSentenceSparse = "sentence_sparse_embedding"
 )
+
+const (
+	// defaultParentKey matches mutation.MetaKeyParent of github.com/cloudwego/eino-ext/components/indexer/mutation
+	defaultParentKey = "_source"
+	// deleteSearchLimit is the page size when searching the primary keys to delete by filter
+	deleteSearchLimit = 100
+)
+
+// callback extra keys and operations, same as the ones of github.com/cloudwego/eino-ext/components/indexer/mutation,
+// checked by TestMutationConsts
+const (
+	extraKeyOperation = "operation"
+	extraKeyIDs       = "ids"
+	extraKeyFilter    = "filter"
+	extraKeyDeleted   = "deleted"
+
+	operationDelete = "delete"
+	operationUpsert = "upsert"
+)
@@ -48,6 +48,14 @@ type IndexerConfig struct {
 	EmbeddingConfig EmbeddingConfig `json:"embedding_config"`
 
 	AddBatchSize int `json:"add_batch_size"`
+
+	// Index 索引名称，DeleteByFilter 和 Upsert 通过该索引按标量字段查找数据
+	Index string `json:"index"`
+	// ParentKey 文档 metadata 中标识父文档的 key，Upsert 会替换同一父文档的全部分片，默认 "_source"
+	ParentKey string `json:"parent_key"`
+	// MetadataKeyToField 将 DeleteByFilter 与 ParentKey 的 metadata key 映射为 collection 的标量字段名
+	// 默认去掉 key 开头的下划线，例如 "_source" 对应字段 "source"
+	MetadataKeyToField func(key string) string `json:"-"`
 }
 
 type EmbeddingConfig struct {
@@ -88,6 +96,14 @@ func NewIndexer(ctx context.Context, config *IndexerConfig) (*Indexer, error) {
 		config.AddBatchSize = defaultAddBatchSize
 	}
 
+	if config.ParentKey == "" {
+		config.ParentKey = defaultParentKey
+	}
+
+	if config.MetadataKeyToField == nil {
+		config.MetadataKeyToField = defaultMetadataKeyToField
+	}
+
 	service := vikingdb.NewVikingDBService(config.Host, config.Region, config.AK, config.SK, config.Scheme)
 	if config.ConnectionTimeout != 0 {
 		service.SetConnectionTimeout(config.ConnectionTimeout)
@@ -132,6 +148,17 @@ func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...in
 		}
 	}()
 
+	ids, err = i.upsertDocuments(ctx, docs, options)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = callbacks.OnEnd(ctx, &indexer.CallbackOutput{IDs: ids})
+
+	return ids, nil
+}
+
+func (i *Indexer) upsertDocuments(ctx context.Context, docs []*schema.Document, options *indexer.Options) (ids []string, err error) {
 	ids = make([]string, 0, len(docs))
 	for _, sub := range chunk(docs, i.config.AddBatchSize) {
 		data, err := i.convertDocuments(ctx, sub, options)
@@ -146,8 +173,6 @@ func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...in
 		ids = append(ids, iter(sub, func(t *schema.Document) string { return t.ID })...)
 	}
 
-	ctx = callbacks.OnEnd(ctx, &indexer.CallbackOutput{IDs: ids})
-
 	return ids, nil
 }
 
@@ -0,0 +1,230 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package volc_vikingdb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/volcengine/volc-sdk-golang/service/vikingdb"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Delete deletes the data of ids.
+// VikingDB doesn't report whether an id exists, so the number of ids is returned as the deleted count.
+// It implements mutation.Deleter of github.com/cloudwego/eino-ext/components/indexer/mutation.
+func (i *Indexer) Delete(ctx context.Context, ids []string, opts ...indexer.Option) (deleted int64, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{
+		Extra: map[string]any{extraKeyOperation: operationDelete, extraKeyIDs: ids},
+	})
+	defer func() {
+		if err != nil {
+			ctx = callbacks.OnError(ctx, err)
+		}
+	}()
+
+	if deleted, err = i.deleteData(ids); err != nil {
+		return 0, err
+	}
+
+	ctx = callbacks.OnEnd(ctx, &indexer.CallbackOutput{Extra: map[string]any{extraKeyDeleted: deleted}})
+
+	return deleted, nil
+}
+
+// DeleteByFilter deletes the data whose scalar fields equal every key - value pair of filter,
+// keys are mapped to fields by MetadataKeyToField. The data is looked up through IndexerConfig.Index,
+// which must index the fields as scalar fields.
+func (i *Indexer) DeleteByFilter(ctx context.Context, filter map[string]any, opts ...indexer.Option) (deleted int64, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{
+		Extra: map[string]any{extraKeyOperation: operationDelete, extraKeyFilter: filter},
+	})
+	defer func() {
+		if err != nil {
+			ctx = callbacks.OnError(ctx, err)
+		}
+	}()
+
+	if len(filter) == 0 {
+		return 0, fmt.Errorf("[DeleteByFilter] empty filter")
+	}
+
+	keys := make([]string, 0, len(filter))
+	for k := range filter {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	conds := make([]map[string]interface{}, 0, len(keys))
+	for _, k := range keys {
+		conds = append(conds, map[string]interface{}{
+			"op":    "must",
+			"field": i.config.MetadataKeyToField(k),
+			"conds": []interface{}{filter[k]},
+		})
+	}
+
+	if deleted, err = i.deleteBySearch(andFilter(conds), nil); err != nil {
+		return 0, err
+	}
+
+	ctx = callbacks.OnEnd(ctx, &indexer.CallbackOutput{Extra: map[string]any{extraKeyDeleted: deleted}})
+
+	return deleted, nil
+}
+
+// Upsert upserts docs, then deletes the other data of their parents, the parent of a doc is its ParentKey metadata,
+// which is written to the scalar field MetadataKeyToField(ParentKey) unless the doc sets it by SetExtraDataFields.
+// VikingDB has no transaction, the stale data is searchable until it's deleted after the upsert.
+// It implements mutation.Upserter of github.com/cloudwego/eino-ext/components/indexer/mutation.
+func (i *Indexer) Upsert(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
+	options := indexer.GetCommonOptions(&indexer.Options{
+		Embedding: i.config.EmbeddingConfig.Embedding,
+	}, opts...)
+
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{
+		Docs:  docs,
+		Extra: map[string]any{extraKeyOperation: operationUpsert},
+	})
+	defer func() {
+		if err != nil {
+			ctx = callbacks.OnError(ctx, err)
+		}
+	}()
+
+	parentField := i.config.MetadataKeyToField(i.config.ParentKey)
+	parents := make([]interface{}, 0, len(docs))
+	seen := make(map[any]struct{}, len(docs))
+	for _, doc := range docs {
+		parent, ok := doc.MetaData[i.config.ParentKey]
+		if !ok || parent == nil {
+			return nil, fmt.Errorf("[Upsert] parent metadata %q not found in document, id=%s", i.config.ParentKey, doc.ID)
+		}
+		if _, found := seen[parent]; !found {
+			seen[parent] = struct{}{}
+			parents = append(parents, parent)
+		}
+
+		fields, _ := GetExtraVikingDBFields(doc)
+		if _, found := fields[parentField]; !found {
+			merged := make(map[string]interface{}, len(fields)+1)
+			for k, v := range fields {
+				merged[k] = v
+			}
+			merged[parentField] = parent
+			SetExtraDataFields(doc, merged)
+		}
+	}
+
+	if ids, err = i.upsertDocuments(ctx, docs, options); err != nil {
+		return nil, err
+	}
+
+	filter := map[string]interface{}{"op": "must", "field": parentField, "conds": parents}
+	deleted, err := i.deleteBySearch(filter, iter(ids, func(id string) interface{} { return id }))
+	if err != nil {
+		return ids, err
+	}
+
+	ctx = callbacks.OnEnd(ctx, &indexer.CallbackOutput{
+		IDs:   ids,
+		Extra: map[string]any{extraKeyDeleted: deleted},
+	})
+
+	return ids, nil
+}
+
+// deleteBySearch searches the primary keys matching filter page by page and deletes them,
+// primary keys of excluded are kept.
+func (i *Indexer) deleteBySearch(filter map[string]interface{}, excluded []interface{}) (int64, error) {
+	if i.config.Index == "" {
+		return 0, fmt.Errorf("[deleteBySearch] index not provided")
+	}
+
+	index := &vikingdb.Index{
+		CollectionName:  i.config.Collection,
+		IndexName:       i.config.Index,
+		VikingDBService: i.service,
+	}
+
+	var deleted int64
+	for {
+		opts := vikingdb.NewSearchOptions().
+			SetFilter(filter).
+			SetLimit(deleteSearchLimit).
+			SetOutputFields([]string{})
+		if len(excluded) > 0 {
+			// deleted data may still be searchable for a while, so it's excluded as well
+			opts.SetPrimaryKeyNotIn(excluded)
+		}
+
+		data, err := index.Search(nil, opts)
+		if err != nil {
+			return deleted, fmt.Errorf("[deleteBySearch] search failed: %w", err)
+		}
+		if len(data) == 0 {
+			return deleted, nil
+		}
+
+		ids := make([]string, 0, len(data))
+		for _, d := range data {
+			ids = append(ids, fmt.Sprint(d.Id))
+			excluded = append(excluded, d.Id)
+		}
+
+		n, err := i.deleteData(ids)
+		if err != nil {
+			return deleted, err
+		}
+		deleted += n
+
+		if len(data) < deleteSearchLimit {
+			return deleted, nil
+		}
+	}
+}
+
+func (i *Indexer) deleteData(ids []string) (int64, error) {
+	for _, sub := range chunk(ids, deleteSearchLimit) {
+		if err := i.collection.DeleteData(sub); err != nil {
+			return 0, fmt.Errorf("DeleteData failed: %w", err)
+		}
+	}
+
+	return int64(len(ids)), nil
+}
+
+func andFilter(conds []map[string]interface{}) map[string]interface{} {
+	if len(conds) == 1 {
+		return conds[0]
+	}
+
+	return map[string]interface{}{"op": "and", "conds": conds}
+}
+
+func defaultMetadataKeyToField(key string) string {
+	return strings.TrimLeft(key, "_")
+}
@@ -0,0 +1,188 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package volc_vikingdb
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/indexer/mutation"
+)
+
+// vikingServer emulates the collection and search apis of vikingdb, search returns the next page of pages.
+type vikingServer struct {
+	mu       sync.Mutex
+	upserted []map[string]any
+	deleted  []any
+	searches []map[string]any
+	pages    [][]string
+}
+
+func (v *vikingServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	var body map[string]any
+	_ = json.NewDecoder(r.Body).Decode(&body)
+
+	resp := map[string]any{"code": 0, "message": "success"}
+	switch r.URL.Path {
+	case "/api/viking_db/data/ping":
+	case "/api/collection/info":
+		resp["data"] = map[string]any{"collection_name": body["collection_name"], "primary_key": defaultFieldID}
+	case "/api/collection/upsert_data":
+		for _, field := range body["fields"].([]any) {
+			v.upserted = append(v.upserted, field.(map[string]any))
+		}
+	case "/api/collection/del_data":
+		v.deleted = append(v.deleted, body["primary_keys"].([]any)...)
+	case "/api/index/search":
+		v.searches = append(v.searches, body["search"].(map[string]any))
+		var items []any
+		if len(v.pages) > 0 {
+			for _, id := range v.pages[0] {
+				items = append(items, map[string]any{defaultFieldID: id, "score": 1})
+			}
+			v.pages = v.pages[1:]
+		}
+		resp["data"] = []any{items}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+type dimEmbedding struct{}
+
+func (d *dimEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	vectors := make([][]float64, len(texts))
+	for idx := range texts {
+		vectors[idx] = []float64{0.1, 0.2}
+	}
+	return vectors, nil
+}
+
+func TestMutation(t *testing.T) {
+	convey.Convey("test delete and upsert", t, func() {
+		ctx := context.Background()
+		srv := &vikingServer{}
+		ts := httptest.NewServer(srv)
+		defer ts.Close()
+
+		i, err := NewIndexer(ctx, &IndexerConfig{
+			Host:            strings.TrimPrefix(ts.URL, "http://"),
+			Region:          "cn-beijing",
+			AK:              "ak",
+			SK:              "sk",
+			Scheme:          "http",
+			Collection:      "eino_collection",
+			Index:           "eino_index",
+			EmbeddingConfig: EmbeddingConfig{Embedding: &dimEmbedding{}},
+		})
+		convey.So(err, convey.ShouldBeNil)
+
+		var deleted []int64
+		ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, callbacks.NewHandlerBuilder().
+			OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+				if n, ok := indexer.ConvCallbackOutput(output).Extra[extraKeyDeleted].(int64); ok {
+					deleted = append(deleted, n)
+				}
+				return ctx
+			}).Build())
+
+		convey.Convey("test delete by ids", func() {
+			n, err := i.Delete(ctx, []string{"1", "2"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(n, convey.ShouldEqual, 2)
+			convey.So(srv.deleted, convey.ShouldResemble, []any{"1", "2"})
+			convey.So(deleted, convey.ShouldResemble, []int64{2})
+		})
+
+		convey.Convey("test delete by filter", func() {
+			page := make([]string, deleteSearchLimit)
+			for idx := range page {
+				page[idx] = "p" + strings.Repeat("x", idx)
+			}
+			srv.pages = [][]string{page, {"last"}}
+
+			n, err := i.DeleteByFilter(ctx, map[string]any{"_source": "a.md", "page": 1})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(n, convey.ShouldEqual, deleteSearchLimit+1)
+			convey.So(srv.deleted, convey.ShouldHaveLength, deleteSearchLimit+1)
+			convey.So(srv.searches, convey.ShouldHaveLength, 2)
+			convey.So(srv.searches[0]["filter"], convey.ShouldResemble, map[string]any{"op": "and", "conds": []any{
+				map[string]any{"op": "must", "field": "source", "conds": []any{"a.md"}},
+				map[string]any{"op": "must", "field": "page", "conds": []any{float64(1)}},
+			}})
+			convey.So(srv.searches[0], convey.ShouldNotContainKey, "primary_key_not_in")
+			convey.So(srv.searches[1]["primary_key_not_in"], convey.ShouldHaveLength, deleteSearchLimit)
+			convey.So(deleted, convey.ShouldResemble, []int64{deleteSearchLimit + 1})
+		})
+
+		convey.Convey("test upsert", func() {
+			srv.pages = [][]string{{"a2"}}
+			ids, err := i.Upsert(ctx, []*schema.Document{
+				{ID: "a1", Content: "a1", MetaData: map[string]any{"_source": "a.md"}},
+				{ID: "b1", Content: "b1", MetaData: map[string]any{"_source": "b.md"}},
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids, convey.ShouldResemble, []string{"a1", "b1"})
+			convey.So(srv.upserted, convey.ShouldHaveLength, 2)
+			convey.So(srv.upserted[0]["source"], convey.ShouldEqual, "a.md")
+			convey.So(srv.searches[0]["filter"], convey.ShouldResemble,
+				map[string]any{"op": "must", "field": "source", "conds": []any{"a.md", "b.md"}})
+			convey.So(srv.searches[0]["primary_key_not_in"], convey.ShouldResemble, []any{"a1", "b1"})
+			convey.So(srv.deleted, convey.ShouldResemble, []any{"a2"})
+			convey.So(deleted, convey.ShouldResemble, []int64{1})
+
+			_, err = i.Upsert(ctx, []*schema.Document{{ID: "c1", Content: "c1"}})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+var (
+	_ mutation.Deleter  = (*Indexer)(nil)
+	_ mutation.Upserter = (*Indexer)(nil)
+)
+
+func TestMutationConsts(t *testing.T) {
+	convey.Convey("test callback extra keys and operations same as mutation", t, func() {
+		convey.So(extraKeyOperation, convey.ShouldEqual, mutation.ExtraKeyOperation)
+		convey.So(extraKeyIDs, convey.ShouldEqual, mutation.ExtraKeyIDs)
+		convey.So(extraKeyFilter, convey.ShouldEqual, mutation.ExtraKeyFilter)
+		convey.So(extraKeyDeleted, convey.ShouldEqual, mutation.ExtraKeyDeleted)
+		convey.So(operationDelete, convey.ShouldEqual, mutation.OperationDelete)
+		convey.So(operationUpsert, convey.ShouldEqual, mutation.OperationUpsert)
+		convey.So(defaultParentKey, convey.ShouldEqual, mutation.MetaKeyParent)
+	})
+}
@@ -0,0 +1,383 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package semanticcache caches the responses of a chat model by the similarity of the last user turn.
+package semanticcache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime/debug"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+type Config struct {
+	// ChatModel generates the responses of cache misses.
+	// Required.
+	ChatModel model.ToolCallingChatModel
+	// Store keeps the cached responses, e.g. a MemoryStore or a RetrieverStore.
+	// Required.
+	Store Store
+	// Embedding embeds the last user turn for stores searching by vector, e.g. MemoryStore.
+	// Optional for stores embedding prompts themselves, e.g. RetrieverStore.
+	Embedding embedding.Embedder
+	// Threshold is the lowest similarity score of the prompts of a cache hit.
+	// Default 0.95.
+	Threshold float64
+	// TTL is how long a response is cached, negative never expires.
+	// Default 24 hours.
+	TTL time.Duration
+	// Namespace is the namespace of requests without WithNamespace.
+	// Optional, and the default value is "".
+	Namespace string
+	// ReplayChunkRunes splits the content of a cached response into chunks of this many runes when Stream replays it.
+	// Optional, and the default value is 0, replaying the response in one chunk.
+	ReplayChunkRunes int
+}
+
+// ChatModel is a model.ToolCallingChatModel returning the cached response of a similar prompt.
+//
+// A request is looked up by the last user turn, in the namespace of the request and among the responses
+// to the same system prompts, preceding turns, tools, tool choice and model. Requests whose last message isn't a user message,
+// or has parts other than text, are passed through. Responses with tool calls aren't cached.
+type ChatModel struct {
+	config *Config
+	tools  []*schema.ToolInfo
+}
+
+var _ model.ToolCallingChatModel = (*ChatModel)(nil)
+
+func NewChatModel(_ context.Context, config *Config) (*ChatModel, error) {
+	if config.ChatModel == nil {
+		return nil, fmt.Errorf("[NewChatModel] chat model not provided")
+	}
+
+	if config.Store == nil {
+		return nil, fmt.Errorf("[NewChatModel] store not provided")
+	}
+
+	if config.Threshold == 0 {
+		config.Threshold = defaultThreshold
+	}
+
+	if config.TTL == 0 {
+		config.TTL = defaultTTL
+	}
+
+	return &ChatModel{config: config}, nil
+}
+
+func (c *ChatModel) Generate(ctx context.Context, in []*schema.Message, opts ...model.Option) (outMsg *schema.Message, err error) {
+	co := model.GetCommonOptions(&model.Options{Tools: c.tools}, opts...)
+
+	ctx = callbacks.EnsureRunInfo(ctx, c.GetType(), components.ComponentOfChatModel)
+	ctx = callbacks.OnStart(ctx, &model.CallbackInput{
+		Messages: in,
+		Tools:    co.Tools,
+		Config:   configOf(co),
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	q, hit, err := c.lookup(ctx, in, co, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if hit != nil {
+		callbacks.OnEnd(ctx, &model.CallbackOutput{Message: hit.Entry.Message, Config: configOf(co), Extra: hitExtra(hit)})
+		return hit.Entry.Message, nil
+	}
+
+	outMsg, err = c.config.ChatModel.Generate(c.makeChatModelCtx(ctx), in, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("[SemanticCache] generate failed, %w", err)
+	}
+
+	c.save(ctx, q, outMsg)
+
+	callbacks.OnEnd(ctx, &model.CallbackOutput{Message: outMsg, Config: configOf(co), Extra: hitExtra(nil)})
+
+	return outMsg, nil
+}
+
+func (c *ChatModel) Stream(ctx context.Context, in []*schema.Message, opts ...model.Option) (outStream *schema.StreamReader[*schema.Message], err error) {
+	co := model.GetCommonOptions(&model.Options{Tools: c.tools}, opts...)
+
+	ctx = callbacks.EnsureRunInfo(ctx, c.GetType(), components.ComponentOfChatModel)
+	ctx = callbacks.OnStart(ctx, &model.CallbackInput{
+		Messages: in,
+		Tools:    co.Tools,
+		Config:   configOf(co),
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	q, hit, err := c.lookup(ctx, in, co, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if hit != nil {
+		return c.streamOutput(ctx, schema.StreamReaderFromArray(c.replayChunks(hit.Entry.Message)), co, hit), nil
+	}
+
+	sr, err := c.config.ChatModel.Stream(c.makeChatModelCtx(ctx), in, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("[SemanticCache] stream failed, %w", err)
+	}
+
+	if q != nil {
+		sr = c.saveStream(ctx, q, sr)
+	}
+
+	return c.streamOutput(ctx, sr, co, nil), nil
+}
+
+// WithTools returns a ChatModel caching the responses of the inner chat model bound to tools.
+// The responses with tools are cached apart from those without.
+func (c *ChatModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	inner, err := c.config.ChatModel.WithTools(tools)
+	if err != nil {
+		return nil, err
+	}
+
+	config := *c.config
+	config.ChatModel = inner
+
+	return &ChatModel{config: &config, tools: tools}, nil
+}
+
+// lookup returns the query of the request and its hit, the query is nil if the request can't be cached.
+func (c *ChatModel) lookup(ctx context.Context, in []*schema.Message, co *model.Options, opts ...model.Option) (*Query, *Hit, error) {
+	prompt, ok := promptOf(in)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	fp, err := fingerprint(in, co)
+	if err != nil {
+		return nil, nil, fmt.Errorf("[SemanticCache] %w", err)
+	}
+
+	o := model.GetImplSpecificOptions(&implOptions{Namespace: &c.config.Namespace}, opts...)
+
+	q := &Query{
+		Namespace:   *o.Namespace,
+		Fingerprint: fp,
+		Prompt:      prompt,
+		Threshold:   c.config.Threshold,
+	}
+
+	if emb := c.config.Embedding; emb != nil {
+		vectors, err := emb.EmbedStrings(c.makeEmbeddingCtx(ctx), []string{prompt})
+		if err != nil {
+			return nil, nil, fmt.Errorf("[SemanticCache] embedding has error: %w", err)
+		}
+		if len(vectors) != 1 {
+			return nil, nil, fmt.Errorf("[SemanticCache] invalid return length of vector, got=%d, expected=1", len(vectors))
+		}
+		q.Vector = vectors[0]
+	}
+
+	hit, err := c.config.Store.Lookup(ctx, q)
+	if err != nil {
+		return nil, nil, fmt.Errorf("[SemanticCache] lookup failed, %w", err)
+	}
+
+	return q, hit, nil
+}
+
+// save caches msg as the response of q, failures are skipped as the response is generated anyway.
+func (c *ChatModel) save(ctx context.Context, q *Query, msg *schema.Message) {
+	if q == nil || msg == nil || len(msg.ToolCalls) > 0 {
+		return
+	}
+
+	e := &Entry{
+		Namespace:   q.Namespace,
+		Fingerprint: q.Fingerprint,
+		Prompt:      q.Prompt,
+		Vector:      q.Vector,
+		Message:     msg,
+	}
+	if c.config.TTL > 0 {
+		e.ExpiresAt = time.Now().Add(c.config.TTL)
+	}
+
+	_ = c.config.Store.Save(ctx, e)
+}
+
+// saveStream forwards the chunks of sr, and caches their concatenation once sr ends without error.
+// The returned stream ends after the response is cached.
+func (c *ChatModel) saveStream(ctx context.Context, q *Query, sr *schema.StreamReader[*schema.Message]) *schema.StreamReader[*schema.Message] {
+	out, sw := schema.Pipe[*schema.Message](1)
+
+	go func() {
+		defer func() {
+			if panicErr := recover(); panicErr != nil {
+				_ = sw.Send(nil, fmt.Errorf("[SemanticCache] panic: %v, stack: %s", panicErr, debug.Stack()))
+			}
+
+			sw.Close()
+			sr.Close()
+		}()
+
+		var chunks []*schema.Message
+		for {
+			chunk, err := sr.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				_ = sw.Send(nil, err)
+				return
+			}
+
+			chunks = append(chunks, chunk)
+			if closed := sw.Send(chunk, nil); closed {
+				return
+			}
+		}
+
+		if msg, err := schema.ConcatMessages(chunks); err == nil {
+			c.save(ctx, q, msg)
+		}
+	}()
+
+	return out
+}
+
+// replayChunks splits msg into chunks of Config.ReplayChunkRunes runes of content, which concatenate to msg.
+func (c *ChatModel) replayChunks(msg *schema.Message) []*schema.Message {
+	runes := []rune(msg.Content)
+	size := c.config.ReplayChunkRunes
+	if size <= 0 || len(runes) <= size {
+		return []*schema.Message{msg}
+	}
+
+	chunks := make([]*schema.Message, 0, (len(runes)+size-1)/size)
+	for start := 0; start < len(runes); start += size {
+		end := start + size
+		if end > len(runes) {
+			end = len(runes)
+		}
+
+		chunk := &schema.Message{Role: msg.Role, Content: string(runes[start:end])}
+		if start == 0 {
+			// the other fields are in the first chunk, and the response meta in the last one
+			first := *msg
+			first.Content = chunk.Content
+			first.ResponseMeta = nil
+			chunk = &first
+		}
+		chunks = append(chunks, chunk)
+	}
+	chunks[len(chunks)-1].ResponseMeta = msg.ResponseMeta
+
+	return chunks
+}
+
+func (c *ChatModel) streamOutput(ctx context.Context, sr *schema.StreamReader[*schema.Message], co *model.Options, hit *Hit) *schema.StreamReader[*schema.Message] {
+	_, nsr := callbacks.OnEndWithStreamOutput(ctx, schema.StreamReaderWithConvert(sr,
+		func(msg *schema.Message) (callbacks.CallbackOutput, error) {
+			return &model.CallbackOutput{Message: msg, Config: configOf(co), Extra: hitExtra(hit)}, nil
+		}))
+
+	return schema.StreamReaderWithConvert(nsr,
+		func(src callbacks.CallbackOutput) (*schema.Message, error) {
+			s := src.(*model.CallbackOutput)
+			if s.Message == nil {
+				return nil, schema.ErrNoValue
+			}
+
+			return s.Message, nil
+		})
+}
+
+func hitExtra(hit *Hit) map[string]any {
+	if hit == nil {
+		return map[string]any{ExtraKeyCacheHit: false}
+	}
+	return map[string]any{ExtraKeyCacheHit: true, ExtraKeyCacheScore: hit.Score}
+}
+
+func configOf(co *model.Options) *model.Config {
+	conf := &model.Config{Stop: co.Stop}
+	if co.Model != nil {
+		conf.Model = *co.Model
+	}
+	if co.MaxTokens != nil {
+		conf.MaxTokens = *co.MaxTokens
+	}
+	if co.Temperature != nil {
+		conf.Temperature = *co.Temperature
+	}
+	if co.TopP != nil {
+		conf.TopP = *co.TopP
+	}
+	return conf
+}
+
+func (c *ChatModel) makeChatModelCtx(ctx context.Context) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfChatModel,
+	}
+
+	if modelType, ok := components.GetType(c.config.ChatModel); ok {
+		runInfo.Type = modelType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+func (c *ChatModel) makeEmbeddingCtx(ctx context.Context) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfEmbedding,
+	}
+
+	if embType, ok := components.GetType(c.config.Embedding); ok {
+		runInfo.Type = embType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+func (c *ChatModel) GetType() string {
+	return typ
+}
+
+func (c *ChatModel) IsCallbacksEnabled() bool {
+	return true
+}
@@ -0,0 +1,416 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package semanticcache
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+// mockEmbedding embeds texts by their vectors, and the others to {0, 0, 1}.
+type mockEmbedding struct {
+	vectors map[string][]float64
+	err     error
+}
+
+func (m *mockEmbedding) EmbedStrings(_ context.Context, texts []string, _ ...embedding.Option) ([][]float64, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	result := make([][]float64, 0, len(texts))
+	for _, text := range texts {
+		v, ok := m.vectors[text]
+		if !ok {
+			v = []float64{0, 0, 1}
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
+
+// mockChatModel answers "answer: <last message>", streaming it in two chunks.
+type mockChatModel struct {
+	mu        sync.Mutex
+	calls     int
+	tools     []*schema.ToolInfo
+	toolCalls []schema.ToolCall
+	err       error
+}
+
+func (m *mockChatModel) Generate(_ context.Context, in []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	msg := schema.AssistantMessage("answer: "+in[len(in)-1].Content, m.toolCalls)
+	msg.ResponseMeta = &schema.ResponseMeta{FinishReason: "stop"}
+	return msg, nil
+}
+
+func (m *mockChatModel) Stream(ctx context.Context, in []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	msg, err := m.Generate(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return schema.StreamReaderFromArray([]*schema.Message{
+		schema.AssistantMessage("answer: ", nil),
+		{Role: schema.Assistant, Content: strings.TrimPrefix(msg.Content, "answer: "), ToolCalls: msg.ToolCalls, ResponseMeta: msg.ResponseMeta},
+	}), nil
+}
+
+func (m *mockChatModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	if len(tools) == 0 {
+		return nil, errors.New("no tools to bind")
+	}
+	return &mockChatModel{tools: tools}, nil
+}
+
+func (m *mockChatModel) Calls() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls
+}
+
+func readAll(sr *schema.StreamReader[*schema.Message]) ([]*schema.Message, error) {
+	defer sr.Close()
+	var chunks []*schema.Message
+	for {
+		chunk, err := sr.Recv()
+		if err != nil {
+			if err.Error() == "EOF" {
+				return chunks, nil
+			}
+			return chunks, err
+		}
+		chunks = append(chunks, chunk)
+	}
+}
+
+func TestChatModel(t *testing.T) {
+	convey.Convey("test semantic cache chat model", t, func() {
+		ctx := context.Background()
+		cm := &mockChatModel{}
+		emb := &mockEmbedding{vectors: map[string][]float64{
+			"how do I reset my password": {1, 0, 0},
+			"how to reset my password?":  {0.99, 0.1, 0},
+			"where is my order":          {0, 1, 0},
+		}}
+		store, err := NewMemoryStore(nil)
+		convey.So(err, convey.ShouldBeNil)
+
+		_, err = NewChatModel(ctx, &Config{Store: store})
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = NewChatModel(ctx, &Config{ChatModel: cm})
+		convey.So(err, convey.ShouldNotBeNil)
+
+		c, err := NewChatModel(ctx, &Config{ChatModel: cm, Store: store, Embedding: emb})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(c.config.Threshold, convey.ShouldEqual, defaultThreshold)
+		convey.So(c.config.TTL, convey.ShouldEqual, defaultTTL)
+		convey.So(c.GetType(), convey.ShouldEqual, typ)
+		convey.So(c.IsCallbacksEnabled(), convey.ShouldBeTrue)
+
+		sys := schema.SystemMessage("you are a support agent")
+		ask := func(q string) []*schema.Message {
+			return []*schema.Message{sys, schema.UserMessage(q)}
+		}
+
+		var (
+			mu      sync.Mutex
+			outputs []*model.CallbackOutput
+		)
+		handler := callbacks.NewHandlerBuilder().
+			OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+				mu.Lock()
+				defer mu.Unlock()
+				outputs = append(outputs, model.ConvCallbackOutput(output))
+				return ctx
+			}).
+			OnEndWithStreamOutputFn(func(ctx context.Context, info *callbacks.RunInfo, output *schema.StreamReader[callbacks.CallbackOutput]) context.Context {
+				defer output.Close()
+				for {
+					chunk, err := output.Recv()
+					if err != nil {
+						return ctx
+					}
+					mu.Lock()
+					outputs = append(outputs, model.ConvCallbackOutput(chunk))
+					mu.Unlock()
+				}
+			}).Build()
+		ctx = callbacks.InitCallbacks(ctx, nil, handler)
+		lastOutput := func() *model.CallbackOutput {
+			mu.Lock()
+			defer mu.Unlock()
+			return outputs[len(outputs)-1]
+		}
+
+		convey.Convey("test generate", func() {
+			msg, err := c.Generate(ctx, ask("how do I reset my password"))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(msg.Content, convey.ShouldEqual, "answer: how do I reset my password")
+			convey.So(cm.Calls(), convey.ShouldEqual, 1)
+			convey.So(lastOutput().Extra[ExtraKeyCacheHit], convey.ShouldBeFalse)
+			convey.So(store.Len(), convey.ShouldEqual, 1)
+
+			// a similar prompt hits
+			msg, err = c.Generate(ctx, ask("how to reset my password?"))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(msg.Content, convey.ShouldEqual, "answer: how do I reset my password")
+			convey.So(msg.ResponseMeta.FinishReason, convey.ShouldEqual, "stop")
+			convey.So(cm.Calls(), convey.ShouldEqual, 1)
+			convey.So(lastOutput().Extra[ExtraKeyCacheHit], convey.ShouldBeTrue)
+			convey.So(lastOutput().Extra[ExtraKeyCacheScore], convey.ShouldBeGreaterThanOrEqualTo, defaultThreshold)
+			convey.So(lastOutput().Message, convey.ShouldEqual, msg)
+
+			// a different prompt misses
+			msg, err = c.Generate(ctx, ask("where is my order"))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(msg.Content, convey.ShouldEqual, "answer: where is my order")
+			convey.So(cm.Calls(), convey.ShouldEqual, 2)
+		})
+
+		convey.Convey("test fingerprint isolation", func() {
+			_, err := c.Generate(ctx, ask("how do I reset my password"))
+			convey.So(err, convey.ShouldBeNil)
+
+			// another system prompt
+			_, err = c.Generate(ctx, []*schema.Message{schema.SystemMessage("you are a pirate"), schema.UserMessage("how do I reset my password")})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(cm.Calls(), convey.ShouldEqual, 2)
+
+			// another model
+			_, err = c.Generate(ctx, ask("how do I reset my password"), model.WithModel("other"))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(cm.Calls(), convey.ShouldEqual, 3)
+
+			// tools by option
+			tools := []*schema.ToolInfo{{Name: "lookup_order", Desc: "look up an order", ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+				"id": {Type: schema.String, Required: true},
+			})}}
+			_, err = c.Generate(ctx, ask("how do I reset my password"), model.WithTools(tools))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(cm.Calls(), convey.ShouldEqual, 4)
+			_, err = c.Generate(ctx, ask("how do I reset my password"), model.WithTools(tools))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(cm.Calls(), convey.ShouldEqual, 4)
+
+			// tools bound share the entries of the same tools by option
+			tc, err := c.WithTools(tools)
+			convey.So(err, convey.ShouldBeNil)
+			bound := tc.(*ChatModel).config.ChatModel.(*mockChatModel)
+			convey.So(bound.tools, convey.ShouldResemble, tools)
+			_, err = tc.Generate(ctx, ask("how do I reset my password"))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(bound.Calls(), convey.ShouldEqual, 0)
+			convey.So(c.tools, convey.ShouldBeNil)
+
+			_, err = c.WithTools(nil)
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test preceding turns isolation", func() {
+			followUp := func(q string) []*schema.Message {
+				return []*schema.Message{sys, schema.UserMessage(q), schema.AssistantMessage("answer: "+q, nil), schema.UserMessage("tell me more")}
+			}
+
+			msg, err := c.Generate(ctx, followUp("how do I reset my password"))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(cm.Calls(), convey.ShouldEqual, 1)
+
+			// the same follow-up of another conversation misses
+			_, err = c.Generate(ctx, followUp("where is my order"))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(cm.Calls(), convey.ShouldEqual, 2)
+			_, err = c.Generate(ctx, ask("tell me more"))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(cm.Calls(), convey.ShouldEqual, 3)
+
+			// the same conversation hits
+			hit, err := c.Generate(ctx, followUp("how do I reset my password"))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(cm.Calls(), convey.ShouldEqual, 3)
+			convey.So(hit.Content, convey.ShouldEqual, msg.Content)
+		})
+
+		convey.Convey("test namespace", func() {
+			_, err := c.Generate(ctx, ask("how do I reset my password"), WithNamespace("tenant-a"))
+			convey.So(err, convey.ShouldBeNil)
+			_, err = c.Generate(ctx, ask("how do I reset my password"), WithNamespace("tenant-b"))
+			convey.So(err, convey.ShouldBeNil)
+			_, err = c.Generate(ctx, ask("how do I reset my password"))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(cm.Calls(), convey.ShouldEqual, 3)
+
+			_, err = c.Generate(ctx, ask("how to reset my password?"), WithNamespace("tenant-a"))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(cm.Calls(), convey.ShouldEqual, 3)
+
+			c.config.Namespace = "tenant-b"
+			_, err = c.Generate(ctx, ask("how to reset my password?"))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(cm.Calls(), convey.ShouldEqual, 3)
+		})
+
+		convey.Convey("test ttl", func() {
+			c.config.TTL = time.Millisecond
+			_, err := c.Generate(ctx, ask("how do I reset my password"))
+			convey.So(err, convey.ShouldBeNil)
+			time.Sleep(5 * time.Millisecond)
+			_, err = c.Generate(ctx, ask("how do I reset my password"))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(cm.Calls(), convey.ShouldEqual, 2)
+			// the expired entry is dropped when saving the new one
+			convey.So(store.Len(), convey.ShouldEqual, 1)
+
+			c.config.TTL = -1
+			_, err = c.Generate(ctx, ask("where is my order"))
+			convey.So(err, convey.ShouldBeNil)
+			time.Sleep(5 * time.Millisecond)
+			_, err = c.Generate(ctx, ask("where is my order"))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(cm.Calls(), convey.ShouldEqual, 3)
+		})
+
+		convey.Convey("test not cached", func() {
+			// the last message isn't a user message
+			in := append(ask("where is my order"), schema.ToolMessage("shipped", "call_1"))
+			_, err := c.Generate(ctx, in)
+			convey.So(err, convey.ShouldBeNil)
+			_, err = c.Generate(ctx, in)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(cm.Calls(), convey.ShouldEqual, 2)
+
+			// images
+			in = []*schema.Message{{Role: schema.User, MultiContent: []schema.ChatMessagePart{
+				{Type: schema.ChatMessagePartTypeText, Text: "what is it"},
+				{Type: schema.ChatMessagePartTypeImageURL, ImageURL: &schema.ChatMessageImageURL{URL: "https://example.com/a.png"}},
+			}}}
+			_, err = c.Generate(ctx, in)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(cm.Calls(), convey.ShouldEqual, 3)
+
+			// tool calls
+			cm.toolCalls = []schema.ToolCall{{ID: "call_1", Function: schema.FunctionCall{Name: "lookup_order"}}}
+			_, err = c.Generate(ctx, ask("where is my order"))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(store.Len(), convey.ShouldEqual, 0)
+		})
+
+		convey.Convey("test text parts", func() {
+			in := []*schema.Message{{Role: schema.User, MultiContent: []schema.ChatMessagePart{
+				{Type: schema.ChatMessagePartTypeText, Text: "where is"},
+				{Type: schema.ChatMessagePartTypeText, Text: "my order"},
+			}}}
+			prompt, ok := promptOf(in)
+			convey.So(ok, convey.ShouldBeTrue)
+			convey.So(prompt, convey.ShouldEqual, "where is\nmy order")
+
+			_, ok = promptOf([]*schema.Message{schema.UserMessage("  ")})
+			convey.So(ok, convey.ShouldBeFalse)
+			_, ok = promptOf(nil)
+			convey.So(ok, convey.ShouldBeFalse)
+		})
+
+		convey.Convey("test stream", func() {
+			sr, err := c.Stream(ctx, ask("how do I reset my password"))
+			convey.So(err, convey.ShouldBeNil)
+			chunks, err := readAll(sr)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(len(chunks), convey.ShouldEqual, 2)
+			convey.So(store.Len(), convey.ShouldEqual, 1)
+
+			sr, err = c.Stream(ctx, ask("how to reset my password?"))
+			convey.So(err, convey.ShouldBeNil)
+			chunks, err = readAll(sr)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(len(chunks), convey.ShouldEqual, 1)
+			convey.So(chunks[0].Content, convey.ShouldEqual, "answer: how do I reset my password")
+			convey.So(cm.Calls(), convey.ShouldEqual, 1)
+			convey.So(lastOutput().Extra[ExtraKeyCacheHit], convey.ShouldBeTrue)
+
+			// replay in chunks
+			c.config.ReplayChunkRunes = 10
+			sr, err = c.Stream(ctx, ask("how to reset my password?"))
+			convey.So(err, convey.ShouldBeNil)
+			chunks, err = readAll(sr)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(len(chunks), convey.ShouldEqual, 4)
+			convey.So(chunks[0].ResponseMeta, convey.ShouldBeNil)
+			msg, err := schema.ConcatMessages(chunks)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(msg.Content, convey.ShouldEqual, "answer: how do I reset my password")
+			convey.So(msg.ResponseMeta.FinishReason, convey.ShouldEqual, "stop")
+
+			// a generated response is replayed by stream, and a streamed one by generate
+			msg, err = c.Generate(ctx, ask("how to reset my password?"))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(msg.Content, convey.ShouldEqual, "answer: how do I reset my password")
+			convey.So(cm.Calls(), convey.ShouldEqual, 1)
+
+			// stream not cached
+			sr, err = c.Stream(ctx, []*schema.Message{schema.AssistantMessage("hi", nil)})
+			convey.So(err, convey.ShouldBeNil)
+			_, err = readAll(sr)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(store.Len(), convey.ShouldEqual, 1)
+			convey.So(lastOutput().Extra[ExtraKeyCacheHit], convey.ShouldBeFalse)
+		})
+
+		convey.Convey("test stream closed early", func() {
+			sr, err := c.Stream(ctx, ask("where is my order"))
+			convey.So(err, convey.ShouldBeNil)
+			_, err = sr.Recv()
+			convey.So(err, convey.ShouldBeNil)
+			sr.Close()
+			time.Sleep(10 * time.Millisecond)
+			// cached only if the inner stream ended before the close
+			convey.So(store.Len(), convey.ShouldBeLessThanOrEqualTo, 1)
+		})
+
+		convey.Convey("test errors", func() {
+			cm.err = errors.New("mock err")
+			_, err := c.Generate(ctx, ask("where is my order"))
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = c.Stream(ctx, ask("where is my order"))
+			convey.So(err, convey.ShouldNotBeNil)
+
+			emb.err = errors.New("mock err")
+			_, err = c.Generate(ctx, ask("where is my order"))
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = c.Stream(ctx, ask("where is my order"))
+			convey.So(err, convey.ShouldNotBeNil)
+
+			// memory store searches by vector
+			c.config.Embedding = nil
+			_, err = c.Generate(ctx, ask("where is my order"))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,39 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package semanticcache
+
+import "time"
+
+const typ = "SemanticCache"
+
+func GetType() string {
+	return typ
+}
+
+const (
+	defaultThreshold     = 0.95
+	defaultTTL           = 24 * time.Hour
+	defaultRetrieverTopK = 5
+)
+
+// Keys of model.CallbackOutput.Extra set by ChatModel.
+const (
+	// ExtraKeyCacheHit is true if the response is replayed from the cache.
+	ExtraKeyCacheHit = "cache_hit"
+	// ExtraKeyCacheScore is the similarity of the cached prompt to the request, set on cache hits.
+	ExtraKeyCacheScore = "cache_score"
+)
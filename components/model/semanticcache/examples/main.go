@@ -0,0 +1,120 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/model/semanticcache"
+)
+
+func main() {
+	ctx := context.Background()
+
+	store, err := semanticcache.NewMemoryStore(nil)
+	if err != nil {
+		log.Fatalf("NewMemoryStore failed, err=%v", err)
+	}
+
+	cm, err := semanticcache.NewChatModel(ctx, &semanticcache.Config{
+		ChatModel:        &echoChatModel{},   // replace with a real chat model, e.g. ark or openai
+		Embedding:        &letterEmbedding{}, // replace with a real embedder, e.g. ark or openai
+		Store:            store,
+		Threshold:        0.9,
+		ReplayChunkRunes: 8,
+	})
+	if err != nil {
+		log.Fatalf("NewChatModel failed, err=%v", err)
+	}
+
+	handler := callbacks.NewHandlerBuilder().
+		OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+			if info.Type == semanticcache.GetType() {
+				log.Printf("cache hit: %v", model.ConvCallbackOutput(output).Extra[semanticcache.ExtraKeyCacheHit])
+			}
+			return ctx
+		}).Build()
+	ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, handler)
+
+	system := schema.SystemMessage("You are the support agent of an online shop.")
+	for _, question := range []string{
+		"How do I reset my password?",
+		"how can I reset my password",
+		"Where is my order?",
+	} {
+		msg, err := cm.Generate(ctx, []*schema.Message{system, schema.UserMessage(question)},
+			semanticcache.WithNamespace("tenant-1"))
+		if err != nil {
+			log.Fatalf("Generate failed, err=%v", err)
+		}
+		log.Printf("question: %s, answer: %s", question, msg.Content)
+	}
+
+	// the cached answer is replayed in chunks
+	sr, err := cm.Stream(ctx, []*schema.Message{system, schema.UserMessage("How to reset my password?")},
+		semanticcache.WithNamespace("tenant-1"))
+	if err != nil {
+		log.Fatalf("Stream failed, err=%v", err)
+	}
+	defer sr.Close()
+	for {
+		chunk, err := sr.Recv()
+		if err != nil {
+			break
+		}
+		log.Printf("chunk: %q", chunk.Content)
+	}
+}
+
+// echoChatModel answers by repeating the question, only for the example.
+type echoChatModel struct{}
+
+func (e *echoChatModel) Generate(ctx context.Context, in []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	return schema.AssistantMessage("you asked: "+in[len(in)-1].Content, nil), nil
+}
+
+func (e *echoChatModel) Stream(ctx context.Context, in []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	msg, _ := e.Generate(ctx, in, opts...)
+	return schema.StreamReaderFromArray([]*schema.Message{msg}), nil
+}
+
+func (e *echoChatModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	return e, nil
+}
+
+// letterEmbedding embeds a text to its letter frequencies, only for the example.
+type letterEmbedding struct{}
+
+func (l *letterEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = make([]float64, 26)
+		for _, c := range strings.ToLower(text) {
+			if c >= 'a' && c <= 'z' {
+				vectors[i][c-'a']++
+			}
+		}
+	}
+	return vectors, nil
+}
@@ -0,0 +1,127 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package semanticcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+// promptOf returns the text of the last user turn of in, false if the request can't be cached:
+// the last message isn't a user message, e.g. a tool result, or it has parts other than text.
+func promptOf(in []*schema.Message) (string, bool) {
+	if len(in) == 0 || in[len(in)-1].Role != schema.User {
+		return "", false
+	}
+
+	prompt, ok := textOf(in[len(in)-1])
+	return prompt, ok && strings.TrimSpace(prompt) != ""
+}
+
+func textOf(msg *schema.Message) (string, bool) {
+	if len(msg.MultiContent) == 0 {
+		return msg.Content, true
+	}
+
+	texts := make([]string, 0, len(msg.MultiContent))
+	for _, part := range msg.MultiContent {
+		if part.Type != schema.ChatMessagePartTypeText {
+			return "", false
+		}
+		texts = append(texts, part.Text)
+	}
+	return strings.Join(texts, "\n"), true
+}
+
+type fingerprintTool struct {
+	Name   string          `json:"name"`
+	Desc   string          `json:"desc"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+type fingerprintTurn struct {
+	Role      schema.RoleType          `json:"role"`
+	Content   string                   `json:"content,omitempty"`
+	Parts     []schema.ChatMessagePart `json:"parts,omitempty"`
+	ToolCalls []schema.FunctionCall    `json:"tool_calls,omitempty"`
+}
+
+func turnOf(msg *schema.Message) *fingerprintTurn {
+	turn := &fingerprintTurn{Role: msg.Role}
+	if text, ok := textOf(msg); ok {
+		turn.Content = text
+	} else {
+		turn.Parts = msg.MultiContent
+	}
+	// tool call ids are generated per response, only the calls themselves are kept
+	for _, tc := range msg.ToolCalls {
+		turn.ToolCalls = append(turn.ToolCalls, tc.Function)
+	}
+	return turn
+}
+
+// fingerprint hashes what the response depends on besides the last user turn:
+// the system prompts, the preceding turns of the conversation, tools, tool choice and model.
+func fingerprint(in []*schema.Message, co *model.Options) (string, error) {
+	var fp struct {
+		System     []string           `json:"system,omitempty"`
+		History    []*fingerprintTurn `json:"history,omitempty"`
+		Tools      []*fingerprintTool `json:"tools,omitempty"`
+		ToolChoice *schema.ToolChoice `json:"tool_choice,omitempty"`
+		Model      *string            `json:"model,omitempty"`
+	}
+
+	for i, msg := range in {
+		switch {
+		case msg.Role == schema.System:
+			text, _ := textOf(msg)
+			fp.System = append(fp.System, text)
+		case i < len(in)-1:
+			fp.History = append(fp.History, turnOf(msg))
+		}
+	}
+
+	for _, tool := range co.Tools {
+		params, err := tool.ToOpenAPIV3()
+		if err != nil {
+			return "", fmt.Errorf("convert params of tool %s failed, %w", tool.Name, err)
+		}
+		ft := &fingerprintTool{Name: tool.Name, Desc: tool.Desc}
+		if params != nil {
+			if ft.Params, err = json.Marshal(params); err != nil {
+				return "", fmt.Errorf("marshal params of tool %s failed, %w", tool.Name, err)
+			}
+		}
+		fp.Tools = append(fp.Tools, ft)
+	}
+
+	fp.ToolChoice = co.ToolChoice
+	fp.Model = co.Model
+
+	b, err := json.Marshal(fp)
+	if err != nil {
+		return "", fmt.Errorf("marshal fingerprint failed, %w", err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package semanticcache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/indexer/memory"
+)
+
+type MemoryStoreConfig struct {
+	// Store keeps the entries with their vectors, e.g. to share it with a memory indexer or to snapshot it.
+	// Its metric should score higher for more similar vectors, as all of memory.Metric do.
+	// Optional, and the default value is a flat store of cosine similarity.
+	Store *memory.Store
+}
+
+// MemoryStore is a Store in memory, it searches by Query.Vector, so Config.Embedding is required with it.
+// Expired entries are dropped when entries are saved.
+type MemoryStore struct {
+	store *memory.Store
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+func NewMemoryStore(config *MemoryStoreConfig) (*MemoryStore, error) {
+	if config == nil {
+		config = &MemoryStoreConfig{}
+	}
+
+	store := config.Store
+	if store == nil {
+		var err error
+		if store, err = memory.NewStore(&memory.StoreConfig{Metric: memory.MetricCosine}); err != nil {
+			return nil, fmt.Errorf("[NewMemoryStore] %w", err)
+		}
+	}
+
+	return &MemoryStore{store: store}, nil
+}
+
+func (m *MemoryStore) Lookup(_ context.Context, q *Query) (*Hit, error) {
+	if len(q.Vector) == 0 {
+		return nil, fmt.Errorf("[MemoryStore] query vector not provided")
+	}
+
+	now := time.Now()
+	hits, err := m.store.Search(q.Vector, 1, func(doc *schema.Document) bool {
+		return matches(doc, q, now)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[MemoryStore] search failed, %w", err)
+	}
+	if len(hits) == 0 || hits[0].Score < q.Threshold {
+		return nil, nil
+	}
+
+	e, err := fromDocument(hits[0].Doc)
+	if err != nil {
+		return nil, fmt.Errorf("[MemoryStore] %w", err)
+	}
+
+	return &Hit{Entry: e, Score: hits[0].Score}, nil
+}
+
+func (m *MemoryStore) Save(_ context.Context, e *Entry) error {
+	if len(e.Vector) == 0 {
+		return fmt.Errorf("[MemoryStore] entry vector not provided")
+	}
+
+	doc, err := toDocument(e)
+	if err != nil {
+		return fmt.Errorf("[MemoryStore] %w", err)
+	}
+
+	now := time.Now()
+	m.store.DeleteFunc(func(doc *schema.Document) bool {
+		return expired(doc, now)
+	})
+
+	if err = m.store.Add([]*schema.Document{doc}, [][]float64{e.Vector}); err != nil {
+		return fmt.Errorf("[MemoryStore] add failed, %w", err)
+	}
+
+	return nil
+}
+
+// Len returns the number of stored entries, expired ones included until they're dropped.
+func (m *MemoryStore) Len() int {
+	return m.store.Len()
+}
@@ -0,0 +1,33 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package semanticcache
+
+import (
+	"github.com/cloudwego/eino/components/model"
+)
+
+type implOptions struct {
+	Namespace *string
+}
+
+// WithNamespace sets the namespace of a request, e.g. the tenant id, so that it only hits responses cached in the same namespace.
+// It overrides Config.Namespace.
+func WithNamespace(namespace string) model.Option {
+	return model.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.Namespace = &namespace
+	})
+}
@@ -0,0 +1,129 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package semanticcache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+)
+
+type RetrieverStoreConfig struct {
+	// Indexer stores entries as documents, the prompt is the content and the rest is in metadata.
+	// It should replace documents of the same id, and embed the content with the same embedding as Retriever.
+	// Required.
+	Indexer indexer.Indexer
+	// Retriever searches the documents stored by Indexer, returning their metadata and a similarity score, higher is more similar.
+	// Required.
+	Retriever retriever.Retriever
+	// TopK is the number of documents retrieved per lookup. Entries of other namespaces, fingerprints or expired ones are skipped
+	// client side, so raise it for retrievers not supporting the filter of github.com/cloudwego/eino-ext/components/retriever/filter.
+	// Default 5.
+	TopK int
+}
+
+// RetrieverStore is a Store over any indexer and retriever pair of the same index, which embed prompts themselves,
+// so Config.Embedding isn't needed with it. Expired entries are skipped, but not deleted.
+type RetrieverStore struct {
+	config *RetrieverStoreConfig
+}
+
+var _ Store = (*RetrieverStore)(nil)
+
+func NewRetrieverStore(_ context.Context, config *RetrieverStoreConfig) (*RetrieverStore, error) {
+	if config.Indexer == nil {
+		return nil, fmt.Errorf("[NewRetrieverStore] indexer not provided")
+	}
+
+	if config.Retriever == nil {
+		return nil, fmt.Errorf("[NewRetrieverStore] retriever not provided")
+	}
+
+	if config.TopK == 0 {
+		config.TopK = defaultRetrieverTopK
+	}
+
+	return &RetrieverStore{config: config}, nil
+}
+
+func (r *RetrieverStore) Lookup(ctx context.Context, q *Query) (*Hit, error) {
+	docs, err := r.config.Retriever.Retrieve(r.makeCtx(ctx, r.config.Retriever, components.ComponentOfRetriever), q.Prompt,
+		retriever.WithTopK(r.config.TopK),
+		retriever.WithScoreThreshold(q.Threshold),
+		filter.WithFilter(filter.And(
+			filter.Eq(metaKeyNamespace, q.Namespace),
+			filter.Eq(metaKeyFingerprint, q.Fingerprint),
+		)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("[RetrieverStore] retrieve failed, %w", err)
+	}
+
+	now := time.Now()
+	for _, doc := range docs {
+		if doc.Score() < q.Threshold || !matches(doc, q, now) {
+			continue
+		}
+
+		e, err := fromDocument(doc)
+		if err != nil {
+			return nil, fmt.Errorf("[RetrieverStore] %w", err)
+		}
+		return &Hit{Entry: e, Score: doc.Score()}, nil
+	}
+
+	return nil, nil
+}
+
+func (r *RetrieverStore) Save(ctx context.Context, e *Entry) error {
+	doc, err := toDocument(e)
+	if err != nil {
+		return fmt.Errorf("[RetrieverStore] %w", err)
+	}
+	if len(e.Vector) > 0 {
+		// indexers storing the dense vector of documents don't embed the prompt again
+		doc.WithDenseVector(e.Vector)
+	}
+
+	if _, err = r.config.Indexer.Store(r.makeCtx(ctx, r.config.Indexer, components.ComponentOfIndexer), []*schema.Document{doc}); err != nil {
+		return fmt.Errorf("[RetrieverStore] store failed, %w", err)
+	}
+
+	return nil
+}
+
+func (r *RetrieverStore) makeCtx(ctx context.Context, component any, kind components.Component) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: kind,
+	}
+
+	if typ, ok := components.GetType(component); ok {
+		runInfo.Type = typ
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
@@ -0,0 +1,164 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package semanticcache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// Entry is a cached response.
+type Entry struct {
+	// Namespace isolates the entries of tenants, see WithNamespace.
+	Namespace string
+	// Fingerprint is the hash of the system prompt, tools and model of the request.
+	Fingerprint string
+	// Prompt is the text of the last user turn.
+	Prompt string
+	// Vector is the embedding of Prompt, nil if Config.Embedding isn't set.
+	Vector []float64
+	// Message is the response of the chat model.
+	Message *schema.Message
+	// ExpiresAt is when the entry expires, the zero value never expires.
+	ExpiresAt time.Time
+}
+
+// Query looks up the entry of the most similar prompt.
+type Query struct {
+	Namespace   string
+	Fingerprint string
+	Prompt      string
+	// Vector is the embedding of Prompt, nil if Config.Embedding isn't set.
+	Vector []float64
+	// Threshold is the lowest similarity score of a hit.
+	Threshold float64
+}
+
+// Hit is the entry found by a Query.
+type Hit struct {
+	Entry *Entry
+	// Score is the similarity of the prompts, higher is more similar.
+	Score float64
+}
+
+// Store is the vector store of cached responses.
+type Store interface {
+	// Lookup returns the most similar unexpired entry of the namespace and fingerprint of q, scored at least q.Threshold.
+	// It returns nil if there's no such entry.
+	Lookup(ctx context.Context, q *Query) (*Hit, error)
+	// Save stores e, replacing the entry of the same namespace, fingerprint and prompt.
+	Save(ctx context.Context, e *Entry) error
+}
+
+const (
+	metaKeyNamespace   = "semantic_cache_namespace"
+	metaKeyFingerprint = "semantic_cache_fingerprint"
+	metaKeyMessage     = "semantic_cache_message"
+	// metaKeyExpiresAt is the expiration in unix milliseconds, 0 never expires.
+	metaKeyExpiresAt = "semantic_cache_expires_at"
+)
+
+// entryID is the document id of an entry, the same for the same namespace, fingerprint and prompt.
+func entryID(e *Entry) string {
+	h := sha256.New()
+	for _, s := range []string{e.Namespace, e.Fingerprint, e.Prompt} {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// toDocument encodes e as a document, its content is the prompt.
+func toDocument(e *Entry) (*schema.Document, error) {
+	msg, err := json.Marshal(e.Message)
+	if err != nil {
+		return nil, fmt.Errorf("marshal message failed, %w", err)
+	}
+
+	var expiresAt int64
+	if !e.ExpiresAt.IsZero() {
+		expiresAt = e.ExpiresAt.UnixMilli()
+	}
+
+	return &schema.Document{
+		ID:      entryID(e),
+		Content: e.Prompt,
+		MetaData: map[string]any{
+			metaKeyNamespace:   e.Namespace,
+			metaKeyFingerprint: e.Fingerprint,
+			metaKeyMessage:     string(msg),
+			metaKeyExpiresAt:   expiresAt,
+		},
+	}, nil
+}
+
+// fromDocument decodes an entry encoded by toDocument.
+func fromDocument(doc *schema.Document) (*Entry, error) {
+	e := &Entry{Prompt: doc.Content}
+	e.Namespace, _ = doc.MetaData[metaKeyNamespace].(string)
+	e.Fingerprint, _ = doc.MetaData[metaKeyFingerprint].(string)
+
+	raw, ok := doc.MetaData[metaKeyMessage].(string)
+	if !ok {
+		return nil, fmt.Errorf("message not found in document %s", doc.ID)
+	}
+	if err := json.Unmarshal([]byte(raw), &e.Message); err != nil {
+		return nil, fmt.Errorf("unmarshal message of document %s failed, %w", doc.ID, err)
+	}
+
+	if ms, ok := toInt64(doc.MetaData[metaKeyExpiresAt]); ok && ms > 0 {
+		e.ExpiresAt = time.UnixMilli(ms)
+	}
+
+	return e, nil
+}
+
+// matches reports whether doc is an unexpired entry of the namespace and fingerprint of q.
+func matches(doc *schema.Document, q *Query, now time.Time) bool {
+	if doc.MetaData[metaKeyNamespace] != q.Namespace || doc.MetaData[metaKeyFingerprint] != q.Fingerprint {
+		return false
+	}
+	return !expired(doc, now)
+}
+
+func expired(doc *schema.Document, now time.Time) bool {
+	ms, ok := toInt64(doc.MetaData[metaKeyExpiresAt])
+	return ok && ms > 0 && ms <= now.UnixMilli()
+}
+
+// toInt64 accepts the number types metadata may be decoded to by stores.
+func toInt64(v any) (int64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return n, true
+	case int:
+		return int64(n), true
+	case float64:
+		return int64(n), true
+	case json.Number:
+		i, err := n.Int64()
+		return i, err == nil
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,230 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package semanticcache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/indexer/memory"
+	memretriever "github.com/cloudwego/eino-ext/components/retriever/memory"
+)
+
+type mockIndexer struct {
+	docs []*schema.Document
+	err  error
+}
+
+func (m *mockIndexer) Store(_ context.Context, docs []*schema.Document, _ ...indexer.Option) ([]string, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.docs = append(m.docs, docs...)
+	return []string{docs[0].ID}, nil
+}
+
+// mockRetriever ignores the filter, returning docs as they are.
+type mockRetriever struct {
+	docs []*schema.Document
+	err  error
+}
+
+func (m *mockRetriever) Retrieve(_ context.Context, _ string, _ ...retriever.Option) ([]*schema.Document, error) {
+	return m.docs, m.err
+}
+
+func TestDocument(t *testing.T) {
+	convey.Convey("test entry document", t, func() {
+		e := &Entry{
+			Namespace:   "tenant",
+			Fingerprint: "fp",
+			Prompt:      "where is my order",
+			Message:     schema.AssistantMessage("shipped", nil),
+			ExpiresAt:   time.UnixMilli(time.Now().Add(time.Hour).UnixMilli()),
+		}
+		doc, err := toDocument(e)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(doc.ID, convey.ShouldEqual, entryID(e))
+		convey.So(doc.Content, convey.ShouldEqual, e.Prompt)
+
+		got, err := fromDocument(doc)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(got, convey.ShouldResemble, e)
+
+		// numbers decoded from json by stores
+		doc.MetaData[metaKeyExpiresAt] = float64(e.ExpiresAt.UnixMilli())
+		got, err = fromDocument(doc)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(got.ExpiresAt.Equal(e.ExpiresAt), convey.ShouldBeTrue)
+		convey.So(expired(doc, e.ExpiresAt), convey.ShouldBeTrue)
+		convey.So(expired(doc, time.Now()), convey.ShouldBeFalse)
+
+		delete(doc.MetaData, metaKeyExpiresAt)
+		got, err = fromDocument(doc)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(got.ExpiresAt.IsZero(), convey.ShouldBeTrue)
+
+		doc.MetaData[metaKeyMessage] = "{"
+		_, err = fromDocument(doc)
+		convey.So(err, convey.ShouldNotBeNil)
+		delete(doc.MetaData, metaKeyMessage)
+		_, err = fromDocument(doc)
+		convey.So(err, convey.ShouldNotBeNil)
+
+		// the id differs by namespace
+		other := *e
+		other.Namespace = "other"
+		convey.So(entryID(&other), convey.ShouldNotEqual, entryID(e))
+	})
+}
+
+func TestMemoryStore(t *testing.T) {
+	convey.Convey("test memory store", t, func() {
+		ctx := context.Background()
+		ms, err := NewMemoryStore(nil)
+		convey.So(err, convey.ShouldBeNil)
+
+		e := &Entry{Namespace: "ns", Fingerprint: "fp", Prompt: "p", Vector: []float64{1, 0}, Message: schema.AssistantMessage("a", nil)}
+		convey.So(ms.Save(ctx, e), convey.ShouldBeNil)
+		// saved again, replaced
+		convey.So(ms.Save(ctx, e), convey.ShouldBeNil)
+		convey.So(ms.Len(), convey.ShouldEqual, 1)
+
+		hit, err := ms.Lookup(ctx, &Query{Namespace: "ns", Fingerprint: "fp", Vector: []float64{1, 0.1}, Threshold: 0.9})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(hit.Entry.Message.Content, convey.ShouldEqual, "a")
+		convey.So(hit.Score, convey.ShouldBeGreaterThan, 0.9)
+
+		hit, err = ms.Lookup(ctx, &Query{Namespace: "ns", Fingerprint: "fp", Vector: []float64{0, 1}, Threshold: 0.9})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(hit, convey.ShouldBeNil)
+
+		hit, err = ms.Lookup(ctx, &Query{Namespace: "ns", Fingerprint: "other", Vector: []float64{1, 0}, Threshold: 0.9})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(hit, convey.ShouldBeNil)
+
+		_, err = ms.Lookup(ctx, &Query{Namespace: "ns", Fingerprint: "fp", Vector: []float64{1, 0, 0}})
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = ms.Lookup(ctx, &Query{})
+		convey.So(err, convey.ShouldNotBeNil)
+		convey.So(ms.Save(ctx, &Entry{}), convey.ShouldNotBeNil)
+		convey.So(ms.Save(ctx, &Entry{Vector: []float64{1, 0, 0}}), convey.ShouldNotBeNil)
+
+		// a shared store
+		store, err := memory.NewStore(&memory.StoreConfig{Metric: memory.MetricIP})
+		convey.So(err, convey.ShouldBeNil)
+		ms, err = NewMemoryStore(&MemoryStoreConfig{Store: store})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(ms.Save(ctx, e), convey.ShouldBeNil)
+		convey.So(store.Len(), convey.ShouldEqual, 1)
+	})
+}
+
+func TestRetrieverStore(t *testing.T) {
+	convey.Convey("test retriever store", t, func() {
+		ctx := context.Background()
+
+		_, err := NewRetrieverStore(ctx, &RetrieverStoreConfig{Retriever: &mockRetriever{}})
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = NewRetrieverStore(ctx, &RetrieverStoreConfig{Indexer: &mockIndexer{}})
+		convey.So(err, convey.ShouldNotBeNil)
+
+		convey.Convey("test memory indexer and retriever", func() {
+			emb := &mockEmbedding{vectors: map[string][]float64{
+				"how do I reset my password": {1, 0, 0},
+				"how to reset my password?":  {0.99, 0.1, 0},
+			}}
+			store, err := memory.NewStore(nil)
+			convey.So(err, convey.ShouldBeNil)
+			idx, err := memory.NewIndexer(ctx, &memory.IndexerConfig{Store: store, Embedding: emb})
+			convey.So(err, convey.ShouldBeNil)
+			ret, err := memretriever.NewRetriever(ctx, &memretriever.RetrieverConfig{Store: store, Embedding: emb})
+			convey.So(err, convey.ShouldBeNil)
+
+			rs, err := NewRetrieverStore(ctx, &RetrieverStoreConfig{Indexer: idx, Retriever: ret})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(rs.config.TopK, convey.ShouldEqual, defaultRetrieverTopK)
+
+			cm := &mockChatModel{}
+			c, err := NewChatModel(ctx, &Config{ChatModel: cm, Store: rs})
+			convey.So(err, convey.ShouldBeNil)
+
+			ask := func(q string) []*schema.Message { return []*schema.Message{schema.UserMessage(q)} }
+			_, err = c.Generate(ctx, ask("how do I reset my password"), WithNamespace("a"))
+			convey.So(err, convey.ShouldBeNil)
+			msg, err := c.Generate(ctx, ask("how to reset my password?"), WithNamespace("a"))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(msg.Content, convey.ShouldEqual, "answer: how do I reset my password")
+			convey.So(cm.Calls(), convey.ShouldEqual, 1)
+
+			_, err = c.Generate(ctx, ask("how to reset my password?"), WithNamespace("b"))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(cm.Calls(), convey.ShouldEqual, 2)
+			convey.So(store.Len(), convey.ShouldEqual, 2)
+		})
+
+		convey.Convey("test filter ignored by retriever", func() {
+			q := &Query{Namespace: "a", Fingerprint: "fp", Prompt: "p", Threshold: 0.9}
+			doc := func(ns string, expiresAt time.Time, score float64) *schema.Document {
+				d, err := toDocument(&Entry{Namespace: ns, Fingerprint: "fp", Prompt: "p", Message: schema.AssistantMessage(ns, nil), ExpiresAt: expiresAt})
+				convey.So(err, convey.ShouldBeNil)
+				return d.WithScore(score)
+			}
+			ret := &mockRetriever{docs: []*schema.Document{
+				doc("b", time.Time{}, 1),
+				doc("a", time.Now().Add(-time.Second), 1),
+				doc("a", time.Time{}, 0.95),
+			}}
+			idx := &mockIndexer{}
+			rs, err := NewRetrieverStore(ctx, &RetrieverStoreConfig{Indexer: idx, Retriever: ret})
+			convey.So(err, convey.ShouldBeNil)
+
+			hit, err := rs.Lookup(ctx, q)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(hit.Score, convey.ShouldEqual, 0.95)
+			convey.So(hit.Entry.Message.Content, convey.ShouldEqual, "a")
+
+			q.Threshold = 0.99
+			hit, err = rs.Lookup(ctx, q)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(hit, convey.ShouldBeNil)
+
+			q.Threshold = 0.9
+			ret.docs[2].MetaData[metaKeyMessage] = "{"
+			_, err = rs.Lookup(ctx, q)
+			convey.So(err, convey.ShouldNotBeNil)
+
+			ret.err = errors.New("mock err")
+			_, err = rs.Lookup(ctx, q)
+			convey.So(err, convey.ShouldNotBeNil)
+
+			// the vector is passed to the indexer
+			convey.So(rs.Save(ctx, &Entry{Prompt: "p", Vector: []float64{1, 0}, Message: schema.AssistantMessage("a", nil)}), convey.ShouldBeNil)
+			convey.So(idx.docs[0].DenseVector(), convey.ShouldResemble, []float64{1, 0})
+
+			idx.err = errors.New("mock err")
+			convey.So(rs.Save(ctx, &Entry{Prompt: "p"}), convey.ShouldNotBeNil)
+		})
+	})
+}
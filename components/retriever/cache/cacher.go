@@ -0,0 +1,61 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// Cacher stores the documents retrieved for cache keys, e.g. a MemoryCacher,
+// or the redis cacher of github.com/cloudwego/eino-ext/components/retriever/cache/redis.
+type Cacher interface {
+	// Set stores docs with the given key, overwriting the documents of an existing key.
+	// An expire of 0 never expires.
+	Set(ctx context.Context, key string, docs []*schema.Document, expire time.Duration) error
+
+	// Get retrieves the documents of the given key.
+	// If the key doesn't exist or has expired, the bool return value is false, otherwise it returns true.
+	Get(ctx context.Context, key string) ([]*schema.Document, bool, error)
+}
+
+// CopyDocuments returns copies of docs with their own metadata maps, metadata values are shared.
+// Cachers keeping documents in memory copy them on Set and Get, so callers modifying the documents
+// of a result, e.g. setting scores, don't change the cached ones.
+func CopyDocuments(docs []*schema.Document) []*schema.Document {
+	if docs == nil {
+		return nil
+	}
+
+	copied := make([]*schema.Document, len(docs))
+	for i, doc := range docs {
+		if doc == nil {
+			continue
+		}
+		c := &schema.Document{ID: doc.ID, Content: doc.Content}
+		if doc.MetaData != nil {
+			c.MetaData = make(map[string]any, len(doc.MetaData))
+			for k, v := range doc.MetaData {
+				c.MetaData[k] = v
+			}
+		}
+		copied[i] = c
+	}
+	return copied
+}
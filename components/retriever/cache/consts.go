@@ -0,0 +1,39 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import "time"
+
+const typ = "Cache"
+
+func GetType() string {
+	return typ
+}
+
+const (
+	defaultTTL        = time.Minute
+	defaultMaxEntries = 1000
+)
+
+// Keys of retriever.CallbackOutput.Extra set by Retriever.
+const (
+	// ExtraKeyCacheHit is true if the documents aren't retrieved by the inner retriever for this call,
+	// but read from the cache or shared by a concurrent identical call.
+	ExtraKeyCacheHit = "cache_hit"
+	// ExtraKeyCacheShared is true if the documents are shared by a concurrent identical call, see Config.DisableSingleFlight.
+	ExtraKeyCacheShared = "cache_shared"
+)
@@ -0,0 +1,94 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/retriever/cache"
+)
+
+func main() {
+	ctx := context.Background()
+
+	cacher, err := cache.NewMemoryCacher(&cache.MemoryCacherConfig{MaxEntries: 100})
+	if err != nil {
+		log.Fatalf("NewMemoryCacher failed, err=%v", err)
+	}
+
+	inner := &slowRetriever{} // replace it with real retriever component, e.g. dify or volc_knowledge
+	r, err := cache.NewRetriever(ctx, &cache.Config{
+		Retriever: inner,
+		Cacher:    cacher,
+		TTL:       30 * time.Second,
+		Namespace: "kb_docs",
+	})
+	if err != nil {
+		log.Fatalf("NewRetriever failed, err=%v", err)
+	}
+
+	ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, callbacks.NewHandlerBuilder().
+		OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+			if info.Type == cache.GetType() {
+				extra := retriever.ConvCallbackOutput(output).Extra
+				log.Printf("cache_hit=%v, cache_shared=%v", extra[cache.ExtraKeyCacheHit], extra[cache.ExtraKeyCacheShared])
+			}
+			return ctx
+		}).Build())
+
+	// concurrent identical calls share one retrieval
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := r.Retrieve(ctx, "what is eino?", retriever.WithTopK(3)); err != nil {
+				log.Printf("Retrieve failed, err=%v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// later calls hit the cache
+	docs, err := r.Retrieve(ctx, "  what is   eino? ", retriever.WithTopK(3))
+	if err != nil {
+		log.Fatalf("Retrieve failed, err=%v", err)
+	}
+	for _, doc := range docs {
+		log.Printf("id=%s, content=%s", doc.ID, doc.Content)
+	}
+	log.Printf("inner retriever called %d times", inner.calls.Load())
+}
+
+// slowRetriever takes a while to return a canned document, only for the example.
+type slowRetriever struct {
+	calls atomic.Int32
+}
+
+func (s *slowRetriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) ([]*schema.Document, error) {
+	s.calls.Add(1)
+	time.Sleep(200 * time.Millisecond)
+	return []*schema.Document{{ID: "1", Content: "eino is a llm application framework in go"}}, nil
+}
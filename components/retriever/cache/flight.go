@@ -0,0 +1,78 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// flightGroup coalesces concurrent calls of the same key into one.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flight
+}
+
+type flight struct {
+	done chan struct{}
+	docs []*schema.Document
+	err  error
+}
+
+// do runs fn for key, or waits for the result of the running call of key, shared is true for the waiters.
+// A waiter returns ctx.Err() if ctx is done first, and the running call goes on. A panic of fn is returned as an error.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(ctx context.Context) ([]*schema.Document, error)) (
+	docs []*schema.Document, shared bool, err error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flight)
+	}
+	if f, found := g.calls[key]; found {
+		g.mu.Unlock()
+		select {
+		case <-f.done:
+			return f.docs, true, f.err
+		case <-ctx.Done():
+			return nil, true, ctx.Err()
+		}
+	}
+
+	f := &flight{done: make(chan struct{})}
+	g.calls[key] = f
+	g.mu.Unlock()
+
+	defer func() {
+		if panicErr := recover(); panicErr != nil {
+			f.docs, f.err = nil, fmt.Errorf("panic: %v, stack: %s", panicErr, debug.Stack())
+		}
+
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(f.done)
+
+		docs, err = f.docs, f.err
+	}()
+
+	f.docs, f.err = fn(ctx)
+
+	return f.docs, false, f.err
+}
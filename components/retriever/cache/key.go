@@ -0,0 +1,98 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/retriever"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+)
+
+// normalizeQuery trims query and collapses its whitespace.
+func normalizeQuery(query string) string {
+	return strings.Join(strings.Fields(query), " ")
+}
+
+// hasInnerImplOptions reports whether opts has implementation specific options of the inner retriever, which cacheKey
+// can't read, i.e. options which are neither common options, nor options of this package or filter.WithFilter.
+// An option setting nothing, e.g. retriever.WithEmbedding(nil), is taken as one of them.
+func hasInnerImplOptions(opts []retriever.Option) bool {
+	for _, opt := range opts {
+		if !reflect.DeepEqual(*retriever.GetCommonOptions(&retriever.Options{}, opt), retriever.Options{}) {
+			continue
+		}
+		if retriever.GetImplSpecificOptions(&implOptions{}, opt).applied > 0 {
+			continue
+		}
+		if filter.GetFilter(opt) != nil {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// cacheKey hashes what the documents of a call depend on: the namespace, the normalized query,
+// the common options, the portable filter and the extra of WithKeyExtra.
+func (r *Retriever) cacheKey(query string, co *retriever.Options, f *filter.Filter, io *implOptions) (string, error) {
+	var key struct {
+		Namespace      string         `json:"namespace,omitempty"`
+		Query          string         `json:"query"`
+		Index          *string        `json:"index,omitempty"`
+		SubIndex       *string        `json:"sub_index,omitempty"`
+		TopK           *int           `json:"top_k,omitempty"`
+		ScoreThreshold *float64       `json:"score_threshold,omitempty"`
+		Embedding      string         `json:"embedding,omitempty"`
+		DSL            map[string]any `json:"dsl,omitempty"`
+		Filter         string         `json:"filter,omitempty"`
+		Extra          []string       `json:"extra,omitempty"`
+	}
+
+	key.Namespace = r.config.Namespace
+	key.Query = r.config.NormalizeQuery(query)
+	key.Index = co.Index
+	key.SubIndex = co.SubIndex
+	key.TopK = co.TopK
+	key.ScoreThreshold = co.ScoreThreshold
+	key.DSL = co.DSLInfo
+	key.Filter = f.String()
+	key.Extra = io.KeyExtra
+
+	if co.Embedding != nil {
+		// embedders can't be compared, calls with embedders of the same type share the cached documents
+		if embType, ok := components.GetType(co.Embedding); ok {
+			key.Embedding = embType
+		} else {
+			key.Embedding = fmt.Sprintf("%T", co.Embedding)
+		}
+	}
+
+	b, err := json.Marshal(key)
+	if err != nil {
+		return "", fmt.Errorf("marshal cache key failed, %w", err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
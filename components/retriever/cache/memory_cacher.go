@@ -0,0 +1,130 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+type MemoryCacherConfig struct {
+	// MaxEntries bounds the number of cached keys, the least recently used key is evicted when it's exceeded.
+	// Default 1000.
+	MaxEntries int
+}
+
+// MemoryCacher is an in-process LRU Cacher. It's safe for concurrent use.
+// Expired entries are dropped when they're read or evicted.
+type MemoryCacher struct {
+	maxEntries int
+
+	mu sync.Mutex
+	// lru is ordered from the most recently used entry to the least
+	lru     *list.List
+	entries map[string]*list.Element
+}
+
+type memoryEntry struct {
+	key  string
+	docs []*schema.Document
+	// expiresAt is zero for entries which never expire
+	expiresAt time.Time
+}
+
+var _ Cacher = (*MemoryCacher)(nil)
+
+func NewMemoryCacher(config *MemoryCacherConfig) (*MemoryCacher, error) {
+	if config == nil {
+		config = &MemoryCacherConfig{}
+	}
+
+	if config.MaxEntries < 0 {
+		return nil, fmt.Errorf("[NewMemoryCacher] invalid max entries: %d", config.MaxEntries)
+	}
+
+	maxEntries := config.MaxEntries
+	if maxEntries == 0 {
+		maxEntries = defaultMaxEntries
+	}
+
+	return &MemoryCacher{
+		maxEntries: maxEntries,
+		lru:        list.New(),
+		entries:    make(map[string]*list.Element),
+	}, nil
+}
+
+func (m *MemoryCacher) Set(_ context.Context, key string, docs []*schema.Document, expire time.Duration) error {
+	e := &memoryEntry{key: key, docs: CopyDocuments(docs)}
+	if expire > 0 {
+		e.expiresAt = time.Now().Add(expire)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elem, found := m.entries[key]; found {
+		elem.Value = e
+		m.lru.MoveToFront(elem)
+		return nil
+	}
+
+	m.entries[key] = m.lru.PushFront(e)
+	for m.lru.Len() > m.maxEntries {
+		m.remove(m.lru.Back())
+	}
+
+	return nil
+}
+
+func (m *MemoryCacher) Get(_ context.Context, key string) ([]*schema.Document, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, found := m.entries[key]
+	if !found {
+		return nil, false, nil
+	}
+
+	e := elem.Value.(*memoryEntry)
+	if !e.expiresAt.IsZero() && !time.Now().Before(e.expiresAt) {
+		m.remove(elem)
+		return nil, false, nil
+	}
+
+	m.lru.MoveToFront(elem)
+
+	return CopyDocuments(e.docs), true, nil
+}
+
+// Len returns the number of cached keys, expired ones included until they're read or evicted.
+func (m *MemoryCacher) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lru.Len()
+}
+
+// remove drops elem, requires the lock.
+func (m *MemoryCacher) remove(elem *list.Element) {
+	m.lru.Remove(elem)
+	delete(m.entries, elem.Value.(*memoryEntry).key)
+}
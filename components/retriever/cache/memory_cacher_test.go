@@ -0,0 +1,111 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestMemoryCacher(t *testing.T) {
+	convey.Convey("test memory cacher", t, func() {
+		ctx := context.Background()
+
+		_, err := NewMemoryCacher(&MemoryCacherConfig{MaxEntries: -1})
+		convey.So(err, convey.ShouldNotBeNil)
+
+		m, err := NewMemoryCacher(nil)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(m.maxEntries, convey.ShouldEqual, defaultMaxEntries)
+
+		convey.Convey("test set and get", func() {
+			docs := []*schema.Document{{ID: "1", Content: "eino", MetaData: map[string]any{"a": 1}}}
+			convey.So(m.Set(ctx, "k", docs, 0), convey.ShouldBeNil)
+
+			// the cached documents are copies
+			docs[0].MetaData["a"] = 2
+			got, ok, err := m.Get(ctx, "k")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ok, convey.ShouldBeTrue)
+			convey.So(got[0].MetaData["a"], convey.ShouldEqual, 1)
+
+			got[0].WithScore(0.5)
+			got, _, _ = m.Get(ctx, "k")
+			_, found := got[0].MetaData["_score"]
+			convey.So(found, convey.ShouldBeFalse)
+
+			convey.So(m.Set(ctx, "k", []*schema.Document{}, 0), convey.ShouldBeNil)
+			got, ok, _ = m.Get(ctx, "k")
+			convey.So(ok, convey.ShouldBeTrue)
+			convey.So(got, convey.ShouldResemble, []*schema.Document{})
+			convey.So(m.Len(), convey.ShouldEqual, 1)
+
+			_, ok, err = m.Get(ctx, "missing")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ok, convey.ShouldBeFalse)
+		})
+
+		convey.Convey("test expire", func() {
+			convey.So(m.Set(ctx, "k", []*schema.Document{{ID: "1"}}, 20*time.Millisecond), convey.ShouldBeNil)
+			_, ok, _ := m.Get(ctx, "k")
+			convey.So(ok, convey.ShouldBeTrue)
+
+			time.Sleep(30 * time.Millisecond)
+			_, ok, _ = m.Get(ctx, "k")
+			convey.So(ok, convey.ShouldBeFalse)
+			convey.So(m.Len(), convey.ShouldEqual, 0)
+		})
+
+		convey.Convey("test lru", func() {
+			m, err := NewMemoryCacher(&MemoryCacherConfig{MaxEntries: 2})
+			convey.So(err, convey.ShouldBeNil)
+
+			convey.So(m.Set(ctx, "a", nil, 0), convey.ShouldBeNil)
+			convey.So(m.Set(ctx, "b", nil, 0), convey.ShouldBeNil)
+			// a is used more recently than b
+			_, ok, _ := m.Get(ctx, "a")
+			convey.So(ok, convey.ShouldBeTrue)
+			convey.So(m.Set(ctx, "c", nil, 0), convey.ShouldBeNil)
+
+			convey.So(m.Len(), convey.ShouldEqual, 2)
+			_, ok, _ = m.Get(ctx, "b")
+			convey.So(ok, convey.ShouldBeFalse)
+			_, ok, _ = m.Get(ctx, "a")
+			convey.So(ok, convey.ShouldBeTrue)
+			_, ok, _ = m.Get(ctx, "c")
+			convey.So(ok, convey.ShouldBeTrue)
+		})
+	})
+}
+
+func TestCopyDocuments(t *testing.T) {
+	convey.Convey("test copy documents", t, func() {
+		convey.So(CopyDocuments(nil), convey.ShouldBeNil)
+
+		docs := []*schema.Document{{ID: "1", Content: "eino"}, nil, {ID: "2", MetaData: map[string]any{"a": 1}}}
+		copied := CopyDocuments(docs)
+		convey.So(copied, convey.ShouldResemble, docs)
+		convey.So(copied[0], convey.ShouldNotPointTo, docs[0])
+
+		copied[2].MetaData["a"] = 2
+		convey.So(docs[2].MetaData["a"], convey.ShouldEqual, 1)
+	})
+}
@@ -0,0 +1,56 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"github.com/cloudwego/eino/components/retriever"
+)
+
+type implOptions struct {
+	KeyExtra []string
+	Refresh  bool
+	NoCache  bool
+
+	// applied is the number of the options of this package, to tell them from the ones of the inner retriever.
+	applied int
+}
+
+// WithKeyExtra adds extra to the cache key of a call. The implementation specific options of the inner retriever
+// can't be read, so calls with them pass through to the inner retriever like WithNoCache, unless they're described
+// by extra, e.g. WithKeyExtra("match=" + match) for WithMatchQuery of the sqlite retriever.
+func WithKeyExtra(extra ...string) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.KeyExtra = append(o.KeyExtra, extra...)
+		o.applied++
+	})
+}
+
+// WithRefresh retrieves by the inner retriever even if the documents are cached, and caches the result.
+func WithRefresh() retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.Refresh = true
+		o.applied++
+	})
+}
+
+// WithNoCache passes the call through to the inner retriever, without reading, writing or coalescing.
+func WithNoCache() retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.NoCache = true
+		o.applied++
+	})
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/cloudwego/eino-ext/components/retriever/cache"
+)
+
+type Cacher struct {
+	rdb    redis.UniversalClient
+	prefix string
+	codec  codec
+}
+
+type Option interface {
+	apply(*Cacher)
+}
+
+type optionFunc func(*Cacher)
+
+func (f optionFunc) apply(c *Cacher) {
+	f(c)
+}
+
+// WithPrefix sets the prefix of the redis keys, default "eino:retriever:".
+func WithPrefix(prefix string) Option {
+	return optionFunc(func(c *Cacher) {
+		c.prefix = strings.TrimSuffix(prefix, ":") + ":"
+	})
+}
+
+var _ cache.Cacher = (*Cacher)(nil)
+
+// NewCacher creates a [cache.Cacher] storing documents as json strings in redis, which expire by the redis TTL.
+//
+// Metadata values are decoded from json, so numbers are float64, and other slices and maps are []any and map[string]any,
+// except the dense vector and sub indexes of schema.Document, which are restored to []float64 and []string.
+func NewCacher(rdb redis.UniversalClient, opts ...Option) *Cacher {
+	cacher := &Cacher{
+		rdb:    rdb,
+		prefix: "eino:retriever:",
+		codec:  defaultCodec,
+	}
+	for _, opt := range opts {
+		opt.apply(cacher)
+	}
+	return cacher
+}
+
+func (c *Cacher) Set(ctx context.Context, key string, docs []*schema.Document, expire time.Duration) error {
+	if docs == nil {
+		// cached as an empty result, rather than "null"
+		docs = []*schema.Document{}
+	}
+	data, err := c.codec.Marshal(docs)
+	if err != nil {
+		return err
+	}
+	return c.rdb.Set(ctx, c.prefix+key, data, expire).Err()
+}
+
+func (c *Cacher) Get(ctx context.Context, key string) ([]*schema.Document, bool, error) {
+	data, err := c.rdb.Get(ctx, c.prefix+key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	var docs []*schema.Document
+	if err := c.codec.Unmarshal(data, &docs); err != nil {
+		return nil, false, err
+	}
+	for _, doc := range docs {
+		restoreMetadata(doc)
+	}
+	return docs, true, nil
+}
+
+const (
+	// metadata keys of schema.Document
+	metaKeyDenseVector = "_dense_vector"
+	metaKeySubIndexes  = "_sub_indexes"
+)
+
+// restoreMetadata restores the types of the metadata read by the methods of schema.Document.
+func restoreMetadata(doc *schema.Document) {
+	if doc == nil {
+		return
+	}
+
+	if values, ok := doc.MetaData[metaKeyDenseVector].([]any); ok {
+		vector := make([]float64, 0, len(values))
+		for _, v := range values {
+			if f, ok := v.(float64); ok {
+				vector = append(vector, f)
+			}
+		}
+		if len(vector) == len(values) {
+			doc.WithDenseVector(vector)
+		}
+	}
+
+	if values, ok := doc.MetaData[metaKeySubIndexes].([]any); ok {
+		indexes := make([]string, 0, len(values))
+		for _, v := range values {
+			if s, ok := v.(string); ok {
+				indexes = append(indexes, s)
+			}
+		}
+		if len(indexes) == len(values) {
+			doc.WithSubIndexes(indexes)
+		}
+	}
+}
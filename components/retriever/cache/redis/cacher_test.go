@@ -0,0 +1,143 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/redis/go-redis/v9"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+// fakeRedisClient keeps the values of Set in a map.
+type fakeRedisClient struct {
+	redis.UniversalClient
+	values  map[string][]byte
+	expires map[string]time.Duration
+	err     error
+}
+
+func (f *fakeRedisClient) Set(ctx context.Context, key string, value any, expiration time.Duration) *redis.StatusCmd {
+	cmd := redis.NewStatusCmd(ctx)
+	if f.err != nil {
+		cmd.SetErr(f.err)
+		return cmd
+	}
+	f.values[key] = value.([]byte)
+	f.expires[key] = expiration
+	cmd.SetVal("OK")
+	return cmd
+}
+
+func (f *fakeRedisClient) Get(ctx context.Context, key string) *redis.StringCmd {
+	cmd := redis.NewStringCmd(ctx)
+	if f.err != nil {
+		cmd.SetErr(f.err)
+		return cmd
+	}
+	value, found := f.values[key]
+	if !found {
+		cmd.SetErr(redis.Nil)
+		return cmd
+	}
+	cmd.SetVal(string(value))
+	return cmd
+}
+
+type failingCodec struct{}
+
+func (*failingCodec) Marshal(v any) ([]byte, error) {
+	return nil, errors.New("mock")
+}
+
+func (*failingCodec) Unmarshal(data []byte, v any) error {
+	return errors.New("mock")
+}
+
+func TestCacher(t *testing.T) {
+	convey.Convey("test redis cacher", t, func() {
+		ctx := context.Background()
+		rdb := &fakeRedisClient{values: map[string][]byte{}, expires: map[string]time.Duration{}}
+
+		c := NewCacher(rdb)
+		convey.So(c.prefix, convey.ShouldEqual, "eino:retriever:")
+		c = NewCacher(rdb, WithPrefix("app:docs:"))
+		convey.So(c.prefix, convey.ShouldEqual, "app:docs:")
+
+		convey.Convey("test set and get", func() {
+			doc := (&schema.Document{ID: "1", Content: "eino", MetaData: map[string]any{"page": 2, "tags": []string{"go"}}}).
+				WithScore(0.5).
+				WithDenseVector([]float64{0.1, 0.2}).
+				WithSubIndexes([]string{"faq"})
+			convey.So(c.Set(ctx, "k", []*schema.Document{doc}, time.Minute), convey.ShouldBeNil)
+			convey.So(rdb.expires["app:docs:k"], convey.ShouldEqual, time.Minute)
+
+			docs, ok, err := c.Get(ctx, "k")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ok, convey.ShouldBeTrue)
+			convey.So(len(docs), convey.ShouldEqual, 1)
+			convey.So(docs[0].ID, convey.ShouldEqual, "1")
+			convey.So(docs[0].Content, convey.ShouldEqual, "eino")
+			convey.So(docs[0].Score(), convey.ShouldEqual, 0.5)
+			convey.So(docs[0].DenseVector(), convey.ShouldResemble, []float64{0.1, 0.2})
+			convey.So(docs[0].SubIndexes(), convey.ShouldResemble, []string{"faq"})
+			convey.So(docs[0].MetaData["page"], convey.ShouldEqual, 2)
+			convey.So(docs[0].MetaData["tags"], convey.ShouldResemble, []any{"go"})
+
+			convey.So(c.Set(ctx, "empty", nil, 0), convey.ShouldBeNil)
+			docs, ok, err = c.Get(ctx, "empty")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ok, convey.ShouldBeTrue)
+			convey.So(docs, convey.ShouldResemble, []*schema.Document{})
+
+			_, ok, err = c.Get(ctx, "missing")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ok, convey.ShouldBeFalse)
+		})
+
+		convey.Convey("test errors", func() {
+			rdb.values["app:docs:broken"] = []byte("not json")
+			_, _, err := c.Get(ctx, "broken")
+			convey.So(err, convey.ShouldNotBeNil)
+
+			c.codec = &failingCodec{}
+			convey.So(c.Set(ctx, "k", nil, 0), convey.ShouldNotBeNil)
+
+			rdb.err = errors.New("mock")
+			c.codec = defaultCodec
+			convey.So(c.Set(ctx, "k", nil, 0), convey.ShouldNotBeNil)
+			_, _, err = c.Get(ctx, "k")
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test restore metadata", func() {
+			restoreMetadata(nil)
+
+			doc := &schema.Document{MetaData: map[string]any{
+				metaKeyDenseVector: []any{0.1, "x"},
+				metaKeySubIndexes:  []any{"a", 1},
+			}}
+			restoreMetadata(doc)
+			convey.So(doc.MetaData[metaKeyDenseVector], convey.ShouldResemble, []any{0.1, "x"})
+			convey.So(doc.MetaData[metaKeySubIndexes], convey.ShouldResemble, []any{"a", 1})
+		})
+	})
+}
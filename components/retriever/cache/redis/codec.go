@@ -0,0 +1,36 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import "github.com/bytedance/sonic"
+
+var defaultCodec codec = &sonicCodec{}
+
+type codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+type sonicCodec struct{}
+
+func (*sonicCodec) Marshal(v any) ([]byte, error) {
+	return sonic.Marshal(v)
+}
+
+func (*sonicCodec) Unmarshal(data []byte, v any) error {
+	return sonic.Unmarshal(data, v)
+}
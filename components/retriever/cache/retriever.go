@@ -0,0 +1,237 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package cache caches the documents of a retriever by the query and options of calls,
+// and coalesces concurrent identical calls into one.
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+)
+
+type Config struct {
+	// Retriever inner retriever whose documents are cached, e.g. dify or volc_knowledge.
+	// Required.
+	Retriever retriever.Retriever
+	// Cacher stores the documents, e.g. a MemoryCacher,
+	// or the redis cacher of github.com/cloudwego/eino-ext/components/retriever/cache/redis.
+	// Default a MemoryCacher of 1000 entries.
+	Cacher Cacher
+	// TTL is how long documents are cached, negative never expires.
+	// Default 1 minute.
+	TTL time.Duration
+	// Namespace is part of every cache key, so retrievers sharing a Cacher don't share documents.
+	// Default "".
+	Namespace string
+	// NormalizeQuery returns the query in cache keys, queries normalized to the same text share the cached documents.
+	// The inner retriever is called with the original query.
+	// Default trims the query and collapses its whitespace.
+	NormalizeQuery func(query string) string
+	// SkipEmpty doesn't cache results without documents, e.g. while the knowledge base is being indexed.
+	// Default false.
+	SkipEmpty bool
+	// DisableSingleFlight retrieves each of concurrent identical calls by the inner retriever,
+	// instead of sharing the result of the first call with the others.
+	// Default false.
+	DisableSingleFlight bool
+}
+
+// Retriever is a retriever.Retriever returning the cached documents of identical calls.
+//
+// Calls are identical if they have the same normalized query, common options (index, sub index, top k,
+// score threshold, embedding type and dsl), portable filter and WithKeyExtra. Concurrent identical calls
+// which miss the cache share one call of the inner retriever. Cache hits and shared results are marked in the Extra
+// of retriever.CallbackOutput, see ExtraKeyCacheHit.
+type Retriever struct {
+	config  *Config
+	flights flightGroup
+}
+
+var _ retriever.Retriever = (*Retriever)(nil)
+
+func NewRetriever(_ context.Context, config *Config) (*Retriever, error) {
+	if config.Retriever == nil {
+		return nil, fmt.Errorf("[NewRetriever] inner retriever not provided")
+	}
+
+	if config.Cacher == nil {
+		cacher, err := NewMemoryCacher(nil)
+		if err != nil {
+			return nil, fmt.Errorf("[NewRetriever] %w", err)
+		}
+		config.Cacher = cacher
+	}
+
+	if config.TTL == 0 {
+		config.TTL = defaultTTL
+	}
+
+	if config.NormalizeQuery == nil {
+		config.NormalizeQuery = normalizeQuery
+	}
+
+	return &Retriever{
+		config: config,
+	}, nil
+}
+
+func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) (docs []*schema.Document, err error) {
+	co := retriever.GetCommonOptions(&retriever.Options{}, opts...)
+	io := retriever.GetImplSpecificOptions(&implOptions{}, opts...)
+	f := filter.GetFilter(opts...)
+
+	input := &retriever.CallbackInput{
+		Query:          query,
+		Filter:         f.String(),
+		ScoreThreshold: co.ScoreThreshold,
+	}
+	if co.TopK != nil {
+		input.TopK = *co.TopK
+	}
+
+	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
+	ctx = callbacks.OnStart(ctx, input)
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	// calls with options the key can't cover aren't cached, unless they're described by WithKeyExtra
+	if io.NoCache || len(io.KeyExtra) == 0 && hasInnerImplOptions(opts) {
+		if docs, err = r.retrieve(ctx, query, opts...); err != nil {
+			return nil, err
+		}
+		callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs, Extra: hitExtra(false, false)})
+		return docs, nil
+	}
+
+	key, err := r.cacheKey(query, co, f, io)
+	if err != nil {
+		return nil, fmt.Errorf("[RetrieverCache] %w", err)
+	}
+
+	if !io.Refresh {
+		cached, ok, err := r.config.Cacher.Get(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("[RetrieverCache] get failed, %w", err)
+		}
+		if ok {
+			callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: cached, Extra: hitExtra(true, false)})
+			return cached, nil
+		}
+	}
+
+	docs, shared, err := r.load(ctx, key, query, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs, Extra: hitExtra(shared, shared)})
+
+	return docs, nil
+}
+
+// load retrieves by the inner retriever and caches the documents. Unless DisableSingleFlight, concurrent calls of key
+// share one retrieval, and a call sharing a retrieval canceled by the context of its caller retries with its own context.
+func (r *Retriever) load(ctx context.Context, key, query string, opts ...retriever.Option) ([]*schema.Document, bool, error) {
+	fetch := func(ctx context.Context) ([]*schema.Document, error) {
+		docs, err := r.retrieve(ctx, query, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(docs) > 0 || !r.config.SkipEmpty {
+			// skip caching if there's an error, the documents are retrieved anyway
+			_ = r.config.Cacher.Set(ctx, key, docs, r.expiration())
+		}
+
+		return docs, nil
+	}
+
+	if r.config.DisableSingleFlight {
+		docs, err := fetch(ctx)
+		return docs, false, err
+	}
+
+	for {
+		docs, shared, err := r.flights.do(ctx, key, fetch)
+		if shared && isContextError(err) && ctx.Err() == nil {
+			continue
+		}
+		if shared {
+			// the documents of the first call are returned to it as they are
+			docs = CopyDocuments(docs)
+		}
+		return docs, shared, err
+	}
+}
+
+func (r *Retriever) retrieve(ctx context.Context, query string, opts ...retriever.Option) ([]*schema.Document, error) {
+	docs, err := r.config.Retriever.Retrieve(r.makeRetrieverCtx(ctx), query, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("[RetrieverCache] retrieve failed, %w", err)
+	}
+	return docs, nil
+}
+
+// expiration is the expire passed to Cacher.Set, 0 never expires.
+func (r *Retriever) expiration() time.Duration {
+	if r.config.TTL < 0 {
+		return 0
+	}
+	return r.config.TTL
+}
+
+func (r *Retriever) GetType() string {
+	return typ
+}
+
+func (r *Retriever) IsCallbacksEnabled() bool {
+	return true
+}
+
+func (r *Retriever) makeRetrieverCtx(ctx context.Context) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfRetriever,
+	}
+
+	if retrieverType, ok := components.GetType(r.config.Retriever); ok {
+		runInfo.Type = retrieverType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+func hitExtra(hit, shared bool) map[string]any {
+	return map[string]any{ExtraKeyCacheHit: hit, ExtraKeyCacheShared: shared}
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,441 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+)
+
+// mockRetriever returns a document of the query, and blocks until gate is closed if it's set.
+type mockRetriever struct {
+	calls atomic.Int32
+	gate  chan struct{}
+	// started receives a value when a call starts, if it's set
+	started chan struct{}
+}
+
+func (m *mockRetriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) ([]*schema.Document, error) {
+	m.calls.Add(1)
+	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{Query: query})
+	if m.started != nil {
+		m.started <- struct{}{}
+	}
+	if m.gate != nil {
+		select {
+		case <-m.gate:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	switch query {
+	case "error":
+		return nil, errors.New("mock")
+	case "panic":
+		panic("mock")
+	case "empty":
+		return []*schema.Document{}, nil
+	}
+
+	co := retriever.GetCommonOptions(&retriever.Options{}, opts...)
+	doc := &schema.Document{ID: query, Content: query, MetaData: map[string]any{}}
+	if co.TopK != nil {
+		doc.MetaData["top_k"] = *co.TopK
+	}
+	docs := []*schema.Document{doc.WithScore(1)}
+	callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
+	return docs, nil
+}
+
+func (m *mockRetriever) GetType() string {
+	return "Mock"
+}
+
+// failingCacher fails Get or Set.
+type failingCacher struct {
+	Cacher
+	failGet bool
+}
+
+func (f *failingCacher) Get(ctx context.Context, key string) ([]*schema.Document, bool, error) {
+	if f.failGet {
+		return nil, false, errors.New("mock")
+	}
+	return f.Cacher.Get(ctx, key)
+}
+
+func (f *failingCacher) Set(ctx context.Context, key string, docs []*schema.Document, expire time.Duration) error {
+	return errors.New("mock")
+}
+
+func TestRetriever(t *testing.T) {
+	convey.Convey("test retriever cache", t, func() {
+		ctx := context.Background()
+		inner := &mockRetriever{}
+
+		_, err := NewRetriever(ctx, &Config{})
+		convey.So(err, convey.ShouldNotBeNil)
+
+		r, err := NewRetriever(ctx, &Config{Retriever: inner})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(r.config.Cacher, convey.ShouldNotBeNil)
+		convey.So(r.config.TTL, convey.ShouldEqual, defaultTTL)
+		convey.So(r.config.NormalizeQuery("  eino \n agents "), convey.ShouldEqual, "eino agents")
+		convey.So(r.GetType(), convey.ShouldEqual, typ)
+		convey.So(r.IsCallbacksEnabled(), convey.ShouldBeTrue)
+
+		convey.Convey("test hit", func() {
+			var mu sync.Mutex
+			var extras []map[string]any
+			var innerEnds int
+			ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, callbacks.NewHandlerBuilder().
+				OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, out callbacks.CallbackOutput) context.Context {
+					mu.Lock()
+					defer mu.Unlock()
+					if info.Type == "Mock" {
+						innerEnds++
+					} else if info.Type == typ {
+						extras = append(extras, retriever.ConvCallbackOutput(out).Extra)
+					}
+					return ctx
+				}).Build())
+
+			docs, err := r.Retrieve(ctx, "eino agents", retriever.WithTopK(3))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(docs[0].ID, convey.ShouldEqual, "eino agents")
+
+			// modifying the result doesn't change the cached documents
+			docs[0].MetaData["modified"] = true
+
+			docs, err = r.Retrieve(ctx, " eino   agents ", retriever.WithTopK(3))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(docs[0].ID, convey.ShouldEqual, "eino agents")
+			convey.So(docs[0].Score(), convey.ShouldEqual, 1)
+			convey.So(docs[0].MetaData["top_k"], convey.ShouldEqual, 3)
+			_, found := docs[0].MetaData["modified"]
+			convey.So(found, convey.ShouldBeFalse)
+
+			convey.So(inner.calls.Load(), convey.ShouldEqual, 1)
+			convey.So(innerEnds, convey.ShouldEqual, 1)
+			convey.So(extras, convey.ShouldResemble, []map[string]any{
+				{ExtraKeyCacheHit: false, ExtraKeyCacheShared: false},
+				{ExtraKeyCacheHit: true, ExtraKeyCacheShared: false},
+			})
+		})
+
+		convey.Convey("test keys", func() {
+			calls := [][]retriever.Option{
+				nil,
+				{retriever.WithTopK(3)},
+				{retriever.WithTopK(4)},
+				{retriever.WithIndex("docs")},
+				{retriever.WithSubIndex("faq")},
+				{retriever.WithScoreThreshold(0.5)},
+				{retriever.WithDSLInfo(map[string]any{"a": 1})},
+				{retriever.WithEmbedding(&mockEmbedder{})},
+				{filter.WithFilter(filter.Eq("lang", "go"))},
+				{filter.WithFilter(filter.Eq("lang", "rust"))},
+				{WithKeyExtra("dataset=1")},
+			}
+			for _, opts := range calls {
+				_, err := r.Retrieve(ctx, "eino", opts...)
+				convey.So(err, convey.ShouldBeNil)
+			}
+			convey.So(inner.calls.Load(), convey.ShouldEqual, len(calls))
+
+			for _, opts := range calls {
+				_, err := r.Retrieve(ctx, "eino", opts...)
+				convey.So(err, convey.ShouldBeNil)
+			}
+			convey.So(inner.calls.Load(), convey.ShouldEqual, len(calls))
+
+			// the same dsl in another order of keys
+			_, err = r.Retrieve(ctx, "eino", retriever.WithDSLInfo(map[string]any{"b": 2, "a": 1}))
+			convey.So(err, convey.ShouldBeNil)
+			_, err = r.Retrieve(ctx, "eino", retriever.WithDSLInfo(map[string]any{"a": 1, "b": 2}))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(inner.calls.Load(), convey.ShouldEqual, len(calls)+1)
+
+			// another namespace sharing the cacher
+			other, err := NewRetriever(ctx, &Config{Retriever: inner, Cacher: r.config.Cacher, Namespace: "other"})
+			convey.So(err, convey.ShouldBeNil)
+			_, err = other.Retrieve(ctx, "eino")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(inner.calls.Load(), convey.ShouldEqual, len(calls)+2)
+		})
+
+		convey.Convey("test refresh and no cache", func() {
+			_, err := r.Retrieve(ctx, "eino")
+			convey.So(err, convey.ShouldBeNil)
+			_, err = r.Retrieve(ctx, "eino", WithRefresh())
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(inner.calls.Load(), convey.ShouldEqual, 2)
+
+			_, err = r.Retrieve(ctx, "agents", WithNoCache())
+			convey.So(err, convey.ShouldBeNil)
+			_, err = r.Retrieve(ctx, "agents", WithNoCache())
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(inner.calls.Load(), convey.ShouldEqual, 4)
+			convey.So(r.config.Cacher.(*MemoryCacher).Len(), convey.ShouldEqual, 1)
+
+			_, err = r.Retrieve(ctx, "error", WithNoCache())
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test inner impl specific options", func() {
+			type innerOptions struct{ match string }
+			withMatch := func(match string) retriever.Option {
+				return retriever.WrapImplSpecificOptFn(func(o *innerOptions) { o.match = match })
+			}
+
+			// the key can't cover them, the calls pass through
+			for _, match := range []string{"a", "b", "a"} {
+				_, err := r.Retrieve(ctx, "eino", withMatch(match), retriever.WithTopK(3), filter.WithFilter(filter.Eq("lang", "go")))
+				convey.So(err, convey.ShouldBeNil)
+			}
+			convey.So(inner.calls.Load(), convey.ShouldEqual, 3)
+			convey.So(r.config.Cacher.(*MemoryCacher).Len(), convey.ShouldEqual, 0)
+
+			// described by the key extra, they're cached
+			for _, match := range []string{"a", "b", "a"} {
+				_, err := r.Retrieve(ctx, "eino", withMatch(match), WithKeyExtra("match="+match))
+				convey.So(err, convey.ShouldBeNil)
+			}
+			convey.So(inner.calls.Load(), convey.ShouldEqual, 5)
+			convey.So(r.config.Cacher.(*MemoryCacher).Len(), convey.ShouldEqual, 2)
+
+			convey.So(hasInnerImplOptions([]retriever.Option{retriever.WithTopK(3), WithRefresh(), filter.WithFilter(filter.Eq("lang", "go"))}), convey.ShouldBeFalse)
+			convey.So(hasInnerImplOptions([]retriever.Option{retriever.WithTopK(3), withMatch("a")}), convey.ShouldBeTrue)
+		})
+
+		convey.Convey("test empty and errors", func() {
+			_, err := r.Retrieve(ctx, "empty")
+			convey.So(err, convey.ShouldBeNil)
+			_, err = r.Retrieve(ctx, "empty")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(inner.calls.Load(), convey.ShouldEqual, 1)
+
+			_, err = r.Retrieve(ctx, "error")
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = r.Retrieve(ctx, "error")
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(inner.calls.Load(), convey.ShouldEqual, 3)
+
+			_, err = r.Retrieve(ctx, "panic")
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "panic: mock")
+
+			skip, err := NewRetriever(ctx, &Config{Retriever: inner, SkipEmpty: true})
+			convey.So(err, convey.ShouldBeNil)
+			_, err = skip.Retrieve(ctx, "empty")
+			convey.So(err, convey.ShouldBeNil)
+			_, err = skip.Retrieve(ctx, "empty")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(inner.calls.Load(), convey.ShouldEqual, 6)
+
+			failing, err := NewRetriever(ctx, &Config{Retriever: inner, Cacher: &failingCacher{Cacher: r.config.Cacher}})
+			convey.So(err, convey.ShouldBeNil)
+			docs, err := failing.Retrieve(ctx, "eino")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(len(docs), convey.ShouldEqual, 1)
+
+			failing.config.Cacher = &failingCacher{failGet: true}
+			_, err = failing.Retrieve(ctx, "eino")
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test ttl", func() {
+			r, err := NewRetriever(ctx, &Config{Retriever: inner, TTL: 20 * time.Millisecond})
+			convey.So(err, convey.ShouldBeNil)
+			_, _ = r.Retrieve(ctx, "eino")
+			_, _ = r.Retrieve(ctx, "eino")
+			convey.So(inner.calls.Load(), convey.ShouldEqual, 1)
+
+			time.Sleep(30 * time.Millisecond)
+			_, _ = r.Retrieve(ctx, "eino")
+			convey.So(inner.calls.Load(), convey.ShouldEqual, 2)
+
+			r, err = NewRetriever(ctx, &Config{Retriever: inner, TTL: -1})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(r.expiration(), convey.ShouldEqual, 0)
+		})
+	})
+}
+
+func TestSingleFlight(t *testing.T) {
+	convey.Convey("test single flight", t, func() {
+		ctx := context.Background()
+		const n = 8
+
+		retrieveAll := func(r *Retriever, query string) ([][]*schema.Document, []error) {
+			results := make([][]*schema.Document, n)
+			errs := make([]error, n)
+			var wg sync.WaitGroup
+			for i := 0; i < n; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					results[i], errs[i] = r.Retrieve(ctx, query)
+				}(i)
+			}
+			wg.Wait()
+			return results, errs
+		}
+
+		convey.Convey("test coalesce", func() {
+			inner := &mockRetriever{gate: make(chan struct{})}
+			r, err := NewRetriever(ctx, &Config{Retriever: inner})
+			convey.So(err, convey.ShouldBeNil)
+
+			var shared atomic.Int32
+			ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, callbacks.NewHandlerBuilder().
+				OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, out callbacks.CallbackOutput) context.Context {
+					if info.Type == typ && retriever.ConvCallbackOutput(out).Extra[ExtraKeyCacheShared] == true {
+						shared.Add(1)
+					}
+					return ctx
+				}).Build())
+
+			go func() {
+				// let the calls queue up behind the first one
+				time.Sleep(50 * time.Millisecond)
+				close(inner.gate)
+			}()
+			results, errs := retrieveAll(r, "eino")
+			for i := range results {
+				convey.So(errs[i], convey.ShouldBeNil)
+				convey.So(results[i][0].ID, convey.ShouldEqual, "eino")
+			}
+			convey.So(inner.calls.Load(), convey.ShouldEqual, 1)
+			convey.So(shared.Load(), convey.ShouldEqual, n-1)
+
+			// the callers get their own documents
+			results[0][0].MetaData["modified"] = true
+			for _, docs := range results[1:] {
+				_, found := docs[0].MetaData["modified"]
+				convey.So(found, convey.ShouldBeFalse)
+			}
+		})
+
+		convey.Convey("test disable single flight", func() {
+			inner := &mockRetriever{}
+			r, err := NewRetriever(ctx, &Config{Retriever: inner, DisableSingleFlight: true})
+			convey.So(err, convey.ShouldBeNil)
+
+			inner.gate = make(chan struct{})
+			inner.started = make(chan struct{}, n)
+			go func() {
+				for i := 0; i < n; i++ {
+					<-inner.started
+				}
+				close(inner.gate)
+			}()
+			_, errs := retrieveAll(r, "eino")
+			for _, err := range errs {
+				convey.So(err, convey.ShouldBeNil)
+			}
+			convey.So(inner.calls.Load(), convey.ShouldEqual, n)
+		})
+
+		convey.Convey("test shared error", func() {
+			inner := &mockRetriever{gate: make(chan struct{})}
+			r, err := NewRetriever(ctx, &Config{Retriever: inner})
+			convey.So(err, convey.ShouldBeNil)
+
+			go func() {
+				time.Sleep(50 * time.Millisecond)
+				close(inner.gate)
+			}()
+			_, errs := retrieveAll(r, "error")
+			for _, err := range errs {
+				convey.So(err, convey.ShouldNotBeNil)
+			}
+			convey.So(inner.calls.Load(), convey.ShouldEqual, 1)
+		})
+
+		convey.Convey("test canceled leader", func() {
+			inner := &mockRetriever{gate: make(chan struct{}), started: make(chan struct{}, 2)}
+			r, err := NewRetriever(ctx, &Config{Retriever: inner})
+			convey.So(err, convey.ShouldBeNil)
+
+			leaderCtx, cancel := context.WithCancel(ctx)
+			leaderErr := make(chan error, 1)
+			go func() {
+				_, err := r.Retrieve(leaderCtx, "eino")
+				leaderErr <- err
+			}()
+			<-inner.started
+
+			waiterDone := make(chan []*schema.Document, 1)
+			go func() {
+				docs, _ := r.Retrieve(ctx, "eino")
+				waiterDone <- docs
+			}()
+			time.Sleep(20 * time.Millisecond)
+
+			// the waiter retries once the leader is canceled, and leads the next call
+			cancel()
+			convey.So(errors.Is(<-leaderErr, context.Canceled), convey.ShouldBeTrue)
+			<-inner.started
+			close(inner.gate)
+			docs := <-waiterDone
+			convey.So(docs[0].ID, convey.ShouldEqual, "eino")
+			convey.So(inner.calls.Load(), convey.ShouldEqual, 2)
+		})
+
+		convey.Convey("test canceled waiter", func() {
+			inner := &mockRetriever{gate: make(chan struct{}), started: make(chan struct{}, 1)}
+			r, err := NewRetriever(ctx, &Config{Retriever: inner})
+			convey.So(err, convey.ShouldBeNil)
+
+			leaderDone := make(chan error, 1)
+			go func() {
+				_, err := r.Retrieve(ctx, "eino")
+				leaderDone <- err
+			}()
+			<-inner.started
+
+			waiterCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+			defer cancel()
+			_, err = r.Retrieve(waiterCtx, "eino")
+			convey.So(errors.Is(err, context.DeadlineExceeded), convey.ShouldBeTrue)
+
+			close(inner.gate)
+			convey.So(<-leaderDone, convey.ShouldBeNil)
+			convey.So(inner.calls.Load(), convey.ShouldEqual, 1)
+		})
+	})
+}
+
+type mockEmbedder struct{}
+
+func (m *mockEmbedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	return nil, nil
+}
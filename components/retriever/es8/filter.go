@@ -0,0 +1,96 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package es8
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+)
+
+// TranslateFilter translates a portable filter to an es query:
+// eq to term, ne to bool.must_not term, in to terms, range to range, exists to exists,
+// and to bool.filter, or to bool.should with minimum_should_match 1, and not to bool.must_not.
+// Term queries match keyword, numeric and boolean fields exactly, not analyzed text fields.
+func TranslateFilter(f *filter.Filter) (types.Query, error) {
+	switch f.Op {
+	case filter.OpEq:
+		return types.Query{Term: map[string]types.TermQuery{f.Field: {Value: f.Value}}}, nil
+	case filter.OpNe:
+		return types.Query{Bool: &types.BoolQuery{MustNot: []types.Query{
+			{Term: map[string]types.TermQuery{f.Field: {Value: f.Value}}},
+		}}}, nil
+	case filter.OpIn:
+		return types.Query{Terms: &types.TermsQuery{TermsQuery: map[string]types.TermsQueryField{f.Field: f.Values}}}, nil
+	case filter.OpRange:
+		rq := types.UntypedRangeQuery{}
+		for _, b := range []struct {
+			value any
+			dst   *json.RawMessage
+		}{{f.Bounds.Gt, &rq.Gt}, {f.Bounds.Gte, &rq.Gte}, {f.Bounds.Lt, &rq.Lt}, {f.Bounds.Lte, &rq.Lte}} {
+			if b.value == nil {
+				continue
+			}
+			raw, err := json.Marshal(b.value)
+			if err != nil {
+				return types.Query{}, fmt.Errorf("[TranslateFilter] marshal range bound of field %s failed, %w", f.Field, err)
+			}
+			*b.dst = raw
+		}
+		return types.Query{Range: map[string]types.RangeQuery{f.Field: rq}}, nil
+	case filter.OpExists:
+		return types.Query{Exists: &types.ExistsQuery{Field: f.Field}}, nil
+	case filter.OpAnd, filter.OpOr, filter.OpNot:
+		children := make([]types.Query, 0, len(f.Children))
+		for _, child := range f.Children {
+			q, err := TranslateFilter(child)
+			if err != nil {
+				return types.Query{}, err
+			}
+			children = append(children, q)
+		}
+		switch f.Op {
+		case filter.OpAnd:
+			return types.Query{Bool: &types.BoolQuery{Filter: children}}, nil
+		case filter.OpOr:
+			return types.Query{Bool: &types.BoolQuery{Should: children, MinimumShouldMatch: 1}}, nil
+		default:
+			return types.Query{Bool: &types.BoolQuery{MustNot: children}}, nil
+		}
+	default:
+		return types.Query{}, filter.Unsupported("es8", f, "")
+	}
+}
+
+// withPortableFilter appends the filter set by filter.WithFilter to the filters of ImplOptions.
+func withPortableFilter(opts []retriever.Option) ([]retriever.Option, error) {
+	q, ok, err := filter.Translate(filter.GetFilter(opts...), TranslateFilter)
+	if err != nil || !ok {
+		return opts, err
+	}
+
+	io := retriever.GetImplSpecificOptions(&ImplOptions{}, opts...)
+	filters := make([]types.Query, 0, len(io.Filters)+1)
+	filters = append(filters, io.Filters...)
+	filters = append(filters, q)
+
+	return append(opts[:len(opts):len(opts)], WithFilters(filters)), nil
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package es8
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+)
+
+func TestTranslateFilter(t *testing.T) {
+	convey.Convey("test TranslateFilter", t, func() {
+		q, err := TranslateFilter(filter.And(
+			filter.Eq("source", "a.md"),
+			filter.Ne("draft", true),
+			filter.In("lang", "en", "zh"),
+			filter.Range("page", filter.Bounds{Gte: 1, Lt: 10}),
+			filter.Or(filter.Exists("author"), filter.Not(filter.Gt("score", 0.5))),
+		))
+		convey.So(err, convey.ShouldBeNil)
+		b, err := json.Marshal(q)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(string(b), convey.ShouldEqual, `{"bool":{"filter":[`+
+			`{"term":{"source":{"value":"a.md"}}},`+
+			`{"bool":{"must_not":[{"term":{"draft":{"value":true}}}]}},`+
+			`{"terms":{"lang":["en","zh"]}},`+
+			`{"range":{"page":{"gte":1,"lt":10}}},`+
+			`{"bool":{"minimum_should_match":1,"should":[{"exists":{"field":"author"}},{"bool":{"must_not":[{"range":{"score":{"gt":0.5}}}]}}]}}`+
+			`]}}`)
+
+		_, err = TranslateFilter(&filter.Filter{Op: "like", Field: "title"})
+		convey.So(errors.Is(err, filter.ErrUnsupported), convey.ShouldBeTrue)
+	})
+
+	convey.Convey("test withPortableFilter", t, func() {
+		opts, err := withPortableFilter([]retriever.Option{retriever.WithTopK(1)})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(opts, convey.ShouldHaveLength, 1)
+
+		native := []types.Query{{Match: map[string]types.MatchQuery{"label": {Query: "good"}}}}
+		opts, err = withPortableFilter([]retriever.Option{WithFilters(native), filter.WithFilter(filter.Eq("source", "a.md"))})
+		convey.So(err, convey.ShouldBeNil)
+		io := retriever.GetImplSpecificOptions(&ImplOptions{}, opts...)
+		convey.So(io.Filters, convey.ShouldHaveLength, 2)
+		convey.So(io.Filters[1].Term["source"].Value, convey.ShouldEqual, "a.md")
+		convey.So(native, convey.ShouldHaveLength, 1)
+
+		_, err = withPortableFilter([]retriever.Option{filter.WithFilter(filter.In("source"))})
+		convey.So(err, convey.ShouldNotBeNil)
+	})
+}
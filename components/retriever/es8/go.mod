@@ -2,10 +2,16 @@ module github.com/cloudwego/eino-ext/components/retriever/es8
 
 go 1.23.0
 
+replace (
+	github.com/cloudwego/eino-ext/components/retriever/filter => ../filter
+	github.com/cloudwego/eino-ext/libs/acl/esindex => ../../../libs/acl/esindex
+)
 
 require (
 	github.com/bytedance/mockey v1.2.13
 	github.com/cloudwego/eino v0.3.27
+	github.com/cloudwego/eino-ext/components/retriever/filter v0.0.0-00010101000000-000000000000
+	github.com/cloudwego/eino-ext/libs/acl/esindex v0.0.0-00010101000000-000000000000
 	github.com/elastic/go-elasticsearch/v8 v8.16.0
 	github.com/smartystreets/goconvey v1.8.1
 	github.com/stretchr/testify v1.9.0
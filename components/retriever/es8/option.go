@@ -26,6 +26,88 @@ import (
 type ImplOptions struct {
 	Filters      []types.Query      `json:"filters,omitempty"`
 	SparseVector map[string]float32 `json:"sparse_vector,omitempty"`
+
+	Highlight      *Highlight               `json:"highlight,omitempty"`
+	Collapse       *Collapse                `json:"collapse,omitempty"`
+	Sort           []types.SortCombinations `json:"sort,omitempty"`
+	SearchAfter    []types.FieldValue       `json:"search_after,omitempty"`
+	SourceIncludes []string                 `json:"source_includes,omitempty"`
+	SourceExcludes []string                 `json:"source_excludes,omitempty"`
+}
+
+// Highlight requests highlighted fragments of the matched terms,
+// placed in the MetaData of each document, see GetHighlight.
+type Highlight struct {
+	// Fields to highlight, e.g. the content field.
+	// Required.
+	Fields []string
+	// PreTags and PostTags wrap the highlighted terms.
+	// Default is "<em>" and "</em>".
+	PreTags  []string
+	PostTags []string
+	// FragmentSize is the size of the fragments in characters.
+	// Default is 100.
+	FragmentSize int
+	// NumberOfFragments is the max number of fragments of each field, 0 returns the whole field.
+	// Default is 5, set a negative value for 0.
+	NumberOfFragments int
+}
+
+// Collapse keeps the top hit of each value of Field, e.g. the best chunk of each parent document.
+type Collapse struct {
+	// Field to collapse on, a keyword or numeric field with doc values.
+	// Required.
+	Field string
+	// InnerHits is the max number of the other hits of each group, parsed by ResultParser and
+	// placed in the MetaData of the top document, see GetInnerHits.
+	// Default is 0, no inner hits.
+	InnerHits int
+	// MaxConcurrentGroupSearches limits the concurrent requests fetching the inner hits of the groups.
+	// Default is decided by es.
+	MaxConcurrentGroupSearches int
+}
+
+// WithHighlight set highlight for retrieve query.
+// It takes effect in all search modes.
+func WithHighlight(highlight *Highlight) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.Highlight = highlight
+	})
+}
+
+// WithCollapse set field collapse for retrieve query.
+// It takes effect in all search modes.
+func WithCollapse(collapse *Collapse) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.Collapse = collapse
+	})
+}
+
+// WithSort set sort for retrieve query, scores are still computed.
+// The sort values of each document are placed in its MetaData, see GetSortValues,
+// and the ones of the last document are the cursor of the next page, see WithSearchAfter.
+// End it with a unique field, e.g. a keyword id field, as tiebreaker for stable pages.
+func WithSort(sort ...types.SortCombinations) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.Sort = sort
+	})
+}
+
+// WithSearchAfter set the cursor of the page to retrieve, the sort values of the last document of the previous page.
+// It requires WithSort with the same sort.
+func WithSearchAfter(cursor []types.FieldValue) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.SearchAfter = cursor
+	})
+}
+
+// WithSourceFilter set the fields of _source passed to ResultParser, e.g. to exclude vector fields.
+// Wildcards are supported, empty includes means all fields.
+func WithSourceFilter(includes, excludes []string) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.SourceIncludes = includes
+		o.SourceExcludes = excludes
+	})
 }
 
 // WithFilters set filters for retrieve query.
@@ -108,11 +108,20 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		}
 	}()
 
+	opts, err = withPortableFilter(opts)
+	if err != nil {
+		return nil, fmt.Errorf("[es8 retriever] invalid filter: %w", err)
+	}
+
 	req, err := r.config.SearchMode.BuildRequest(ctx, r.config, query, opts...)
 	if err != nil {
 		return nil, err
 	}
 
+	if err = applySearchOptions(req, retriever.GetImplSpecificOptions(&ImplOptions{}, opts...)); err != nil {
+		return nil, fmt.Errorf("[es8 retriever] invalid search options: %w", err)
+	}
+
 	resp, err := search.NewSearchFunc(r.client)().
 		Index(r.config.Index).
 		Request(req).
@@ -135,7 +144,7 @@ func (r *Retriever) parseSearchResult(ctx context.Context, resp *search.Response
 	docs = make([]*schema.Document, 0, len(resp.Hits.Hits))
 
 	for _, hit := range resp.Hits.Hits {
-		doc, err := r.config.ResultParser(ctx, hit)
+		doc, err := r.parseHit(ctx, hit)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,86 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package es8
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+
+	"github.com/cloudwego/eino-ext/libs/acl/esindex"
+)
+
+// SchemaResultParser returns a ResultParser reading documents written by the es8 indexer with the same Schema:
+// the content field is read into Content, metadata fields into MetaData by their keys, the dense_vector of the content field
+// into DenseVector, and the hit score into Score. Undeclared fields are ignored.
+func SchemaResultParser(s *esindex.Schema) func(ctx context.Context, hit types.Hit) (*schema.Document, error) {
+	var contentVector string
+	if content := s.ContentField(); content != nil {
+		if f := s.DenseVectorOf(content.Name); f != nil {
+			contentVector = f.Name
+		}
+	}
+
+	return func(ctx context.Context, hit types.Hit) (*schema.Document, error) {
+		doc := &schema.Document{MetaData: map[string]any{}}
+		if hit.Id_ != nil {
+			doc.ID = *hit.Id_
+		}
+
+		src := make(map[string]json.RawMessage)
+		if len(hit.Source_) > 0 {
+			if err := json.Unmarshal(hit.Source_, &src); err != nil {
+				return nil, fmt.Errorf("[SchemaResultParser] unmarshal source of hit %s failed, %w", doc.ID, err)
+			}
+		}
+
+		for _, f := range s.Fields {
+			raw, found := src[f.Name]
+			if !found {
+				continue
+			}
+
+			switch {
+			case f.Content:
+				if err := json.Unmarshal(raw, &doc.Content); err != nil {
+					return nil, fmt.Errorf("[SchemaResultParser] content field %s of hit %s isn't string, %w", f.Name, doc.ID, err)
+				}
+			case f.Name == contentVector:
+				var v []float64
+				if err := json.Unmarshal(raw, &v); err != nil {
+					return nil, fmt.Errorf("[SchemaResultParser] vector field %s of hit %s isn't float array, %w", f.Name, doc.ID, err)
+				}
+				doc.WithDenseVector(v)
+			case f.MetadataKey != "":
+				var v any
+				if err := json.Unmarshal(raw, &v); err != nil {
+					return nil, fmt.Errorf("[SchemaResultParser] unmarshal field %s of hit %s failed, %w", f.Name, doc.ID, err)
+				}
+				doc.MetaData[f.MetadataKey] = v
+			}
+		}
+
+		if hit.Score_ != nil {
+			doc.WithScore(float64(*hit.Score_))
+		}
+
+		return doc, nil
+	}
+}
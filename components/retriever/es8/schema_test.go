@@ -0,0 +1,65 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package es8
+
+import (
+	"context"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/libs/acl/esindex"
+)
+
+func TestSchemaResultParser(t *testing.T) {
+	convey.Convey("test SchemaResultParser", t, func() {
+		ctx := context.Background()
+		parse := SchemaResultParser(&esindex.Schema{Fields: []*esindex.Field{
+			{Name: "content", Type: esindex.FieldTypeText, Content: true},
+			{Name: "content_vector", Type: esindex.FieldTypeDenseVector, VectorOf: "content"},
+			{Name: "source", Type: esindex.FieldTypeKeyword, MetadataKey: "_source"},
+			{Name: "page", Type: esindex.FieldTypeLong, MetadataKey: "page"},
+		}})
+
+		id := "1"
+		score := types.Float64(1.5)
+		doc, err := parse(ctx, types.Hit{
+			Id_:     &id,
+			Score_:  &score,
+			Source_: []byte(`{"content": "eino", "content_vector": [0.1, 0.2], "source": "docs/intro.md", "page": 3, "other": true}`),
+		})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(doc.ID, convey.ShouldEqual, "1")
+		convey.So(doc.Content, convey.ShouldEqual, "eino")
+		convey.So(doc.DenseVector(), convey.ShouldResemble, []float64{0.1, 0.2})
+		convey.So(doc.MetaData["_source"], convey.ShouldEqual, "docs/intro.md")
+		convey.So(doc.MetaData["page"], convey.ShouldEqual, float64(3))
+		convey.So(doc.Score(), convey.ShouldEqual, 1.5)
+
+		doc, err = parse(ctx, types.Hit{Id_: &id})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(doc.Content, convey.ShouldEqual, "")
+
+		_, err = parse(ctx, types.Hit{Id_: &id, Source_: []byte(`[]`)})
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = parse(ctx, types.Hit{Id_: &id, Source_: []byte(`{"content": 1}`)})
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = parse(ctx, types.Hit{Id_: &id, Source_: []byte(`{"content_vector": "x"}`)})
+		convey.So(err, convey.ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package search_mode
+
+import (
+	"fmt"
+
+	"github.com/cloudwego/eino-ext/libs/acl/esindex"
+
+	"github.com/cloudwego/eino-ext/components/retriever/es8"
+)
+
+// ApproximateConfigFromSchema returns an ApproximateConfig searching the content field declared by s with its dense_vector field,
+// other options can be set on the returned config.
+func ApproximateConfigFromSchema(s *esindex.Schema) (*ApproximateConfig, error) {
+	content, vector, err := contentAndVector(s)
+	if err != nil {
+		return nil, fmt.Errorf("[ApproximateConfigFromSchema] %w", err)
+	}
+
+	return &ApproximateConfig{
+		QueryFieldName:  content.Name,
+		VectorFieldName: vector.Name,
+	}, nil
+}
+
+// SearchModeDenseVectorSimilarityFromSchema returns SearchModeDenseVectorSimilarity of the dense_vector field of the content field declared by s.
+func SearchModeDenseVectorSimilarityFromSchema(typ DenseVectorSimilarityType, s *esindex.Schema) (es8.SearchMode, error) {
+	_, vector, err := contentAndVector(s)
+	if err != nil {
+		return nil, fmt.Errorf("[SearchModeDenseVectorSimilarityFromSchema] %w", err)
+	}
+
+	return SearchModeDenseVectorSimilarity(typ, vector.Name), nil
+}
+
+// SearchModeExactMatchFromSchema returns SearchModeExactMatch of the content field declared by s.
+func SearchModeExactMatchFromSchema(s *esindex.Schema) (es8.SearchMode, error) {
+	if err := s.Validate(); err != nil {
+		return nil, fmt.Errorf("[SearchModeExactMatchFromSchema] %w", err)
+	}
+
+	content := s.ContentField()
+	if content == nil {
+		return nil, fmt.Errorf("[SearchModeExactMatchFromSchema] content field not declared")
+	}
+
+	return SearchModeExactMatch(content.Name), nil
+}
+
+// SparseVectorQueryConfigFromSchema returns a SparseVectorQueryConfig searching the sparse field declared by s,
+// other options can be set on the returned config.
+func SparseVectorQueryConfigFromSchema(s *esindex.Schema) (*SparseVectorQueryConfig, error) {
+	if err := s.Validate(); err != nil {
+		return nil, fmt.Errorf("[SparseVectorQueryConfigFromSchema] %w", err)
+	}
+
+	sparse := s.SparseVectorField()
+	if sparse == nil || sparse.Type != esindex.FieldTypeSparseVector {
+		return nil, fmt.Errorf("[SparseVectorQueryConfigFromSchema] sparse_vector field not declared")
+	}
+
+	return &SparseVectorQueryConfig{Field: sparse.Name}, nil
+}
+
+func contentAndVector(s *esindex.Schema) (content, vector *esindex.Field, err error) {
+	if err = s.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	if content = s.ContentField(); content == nil {
+		return nil, nil, fmt.Errorf("content field not declared")
+	}
+
+	if vector = s.DenseVectorOf(content.Name); vector == nil {
+		return nil, nil, fmt.Errorf("dense_vector field of %s not declared", content.Name)
+	}
+
+	return content, vector, nil
+}
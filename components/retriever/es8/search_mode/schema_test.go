@@ -0,0 +1,88 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package search_mode
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/retriever/es8"
+	"github.com/cloudwego/eino-ext/libs/acl/esindex"
+)
+
+func TestSearchModeFromSchema(t *testing.T) {
+	convey.Convey("test search modes from schema", t, func() {
+		s := &esindex.Schema{Fields: []*esindex.Field{
+			{Name: "text", Type: esindex.FieldTypeText, Content: true},
+			{Name: "text_vector", Type: esindex.FieldTypeDenseVector, VectorOf: "text"},
+			{Name: "text_sparse", Type: esindex.FieldTypeSparseVector, VectorOf: "text"},
+		}}
+		noVector := &esindex.Schema{Fields: []*esindex.Field{{Name: "text", Type: esindex.FieldTypeText, Content: true}}}
+		invalid := &esindex.Schema{Fields: []*esindex.Field{{Name: "text"}}}
+
+		convey.Convey("test ApproximateConfigFromSchema", func() {
+			conf, err := ApproximateConfigFromSchema(s)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(conf, convey.ShouldResemble, &ApproximateConfig{QueryFieldName: "text", VectorFieldName: "text_vector"})
+
+			_, err = ApproximateConfigFromSchema(noVector)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "dense_vector field of text not declared")
+			_, err = ApproximateConfigFromSchema(&esindex.Schema{})
+			convey.So(err.Error(), convey.ShouldContainSubstring, "content field not declared")
+			_, err = ApproximateConfigFromSchema(invalid)
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test SearchModeDenseVectorSimilarityFromSchema", func() {
+			sm, err := SearchModeDenseVectorSimilarityFromSchema(DenseVectorSimilarityTypeCosineSimilarity, s)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(sm.(*denseVectorSimilarity).script, convey.ShouldContainSubstring, "'text_vector'")
+
+			_, err = SearchModeDenseVectorSimilarityFromSchema(DenseVectorSimilarityTypeCosineSimilarity, noVector)
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test SearchModeExactMatchFromSchema", func() {
+			sm, err := SearchModeExactMatchFromSchema(s)
+			convey.So(err, convey.ShouldBeNil)
+			req, err := sm.BuildRequest(context.Background(), &es8.RetrieverConfig{}, "eino")
+			convey.So(err, convey.ShouldBeNil)
+			b, err := json.Marshal(req.Query)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(string(b), convey.ShouldContainSubstring, `"text"`)
+
+			_, err = SearchModeExactMatchFromSchema(&esindex.Schema{})
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = SearchModeExactMatchFromSchema(invalid)
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test SparseVectorQueryConfigFromSchema", func() {
+			conf, err := SparseVectorQueryConfigFromSchema(s)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(conf.Field, convey.ShouldEqual, "text_sparse")
+
+			_, err = SparseVectorQueryConfigFromSchema(noVector)
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = SparseVectorQueryConfigFromSchema(invalid)
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
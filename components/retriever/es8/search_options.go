@@ -0,0 +1,165 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package es8
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+)
+
+// document metadata keys of the search options.
+const (
+	metaKeyHighlight  = "_highlight"
+	metaKeyInnerHits  = "_inner_hits"
+	metaKeySortValues = "_sort_values"
+)
+
+// innerHitsName is the name of the inner hits of Collapse.
+const innerHitsName = "collapsed"
+
+// GetHighlight returns the highlighted fragments of each field of doc, requested by WithHighlight.
+func GetHighlight(doc *schema.Document) map[string][]string {
+	h, _ := doc.MetaData[metaKeyHighlight].(map[string][]string)
+	return h
+}
+
+// GetInnerHits returns the other documents of the group of doc, requested by WithCollapse.
+func GetInnerHits(doc *schema.Document) []*schema.Document {
+	docs, _ := doc.MetaData[metaKeyInnerHits].([]*schema.Document)
+	return docs
+}
+
+// GetSortValues returns the sort values of doc, requested by WithSort.
+func GetSortValues(doc *schema.Document) []types.FieldValue {
+	values, _ := doc.MetaData[metaKeySortValues].([]types.FieldValue)
+	return values
+}
+
+// NextCursor returns the cursor of the page after docs for WithSearchAfter, nil if docs is empty.
+func NextCursor(docs []*schema.Document) []types.FieldValue {
+	if len(docs) == 0 {
+		return nil
+	}
+	return GetSortValues(docs[len(docs)-1])
+}
+
+// applySearchOptions sets the highlight, collapse, sort, search_after and _source filter of io to the request built by the search mode.
+func applySearchOptions(req *search.Request, io *ImplOptions) error {
+	if h := io.Highlight; h != nil {
+		if len(h.Fields) == 0 {
+			return fmt.Errorf("highlight fields not provided")
+		}
+
+		hl := &types.Highlight{
+			Fields:   make(map[string]types.HighlightField, len(h.Fields)),
+			PreTags:  h.PreTags,
+			PostTags: h.PostTags,
+		}
+		for _, f := range h.Fields {
+			hl.Fields[f] = types.HighlightField{}
+		}
+		if h.FragmentSize > 0 {
+			hl.FragmentSize = &h.FragmentSize
+		}
+		if h.NumberOfFragments != 0 {
+			n := h.NumberOfFragments
+			if n < 0 {
+				n = 0
+			}
+			hl.NumberOfFragments = &n
+		}
+		req.Highlight = hl
+	}
+
+	if c := io.Collapse; c != nil {
+		if c.Field == "" {
+			return fmt.Errorf("collapse field not provided")
+		}
+
+		fc := &types.FieldCollapse{Field: c.Field}
+		if c.InnerHits > 0 {
+			fc.InnerHits = []types.InnerHits{{Name: of(innerHitsName), Size: of(c.InnerHits)}}
+		}
+		if c.MaxConcurrentGroupSearches > 0 {
+			fc.MaxConcurrentGroupSearches = of(c.MaxConcurrentGroupSearches)
+		}
+		req.Collapse = fc
+	}
+
+	if len(io.SearchAfter) > 0 && len(io.Sort) == 0 {
+		return fmt.Errorf("search after needs sort")
+	}
+	if len(io.Sort) > 0 {
+		req.Sort = io.Sort
+		req.TrackScores = of(true)
+	}
+	if len(io.SearchAfter) > 0 {
+		req.SearchAfter = io.SearchAfter
+	}
+
+	if len(io.SourceIncludes) > 0 || len(io.SourceExcludes) > 0 {
+		req.Source_ = &types.SourceFilter{Includes: io.SourceIncludes, Excludes: io.SourceExcludes}
+	}
+
+	return nil
+}
+
+// parseHit parses hit by ResultParser, and sets its highlight, inner hits and sort values to the metadata of the document.
+func (r *Retriever) parseHit(ctx context.Context, hit types.Hit) (*schema.Document, error) {
+	doc, err := r.config.ResultParser(ctx, hit)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(hit.Highlight) == 0 && len(hit.InnerHits) == 0 && len(hit.Sort) == 0 {
+		return doc, nil
+	}
+
+	if doc.MetaData == nil {
+		doc.MetaData = map[string]any{}
+	}
+
+	if len(hit.Highlight) > 0 {
+		doc.MetaData[metaKeyHighlight] = hit.Highlight
+	}
+
+	if inner, found := hit.InnerHits[innerHitsName]; found && inner.Hits != nil {
+		docs := make([]*schema.Document, 0, len(inner.Hits.Hits))
+		for _, innerHit := range inner.Hits.Hits {
+			innerDoc, err := r.config.ResultParser(ctx, innerHit)
+			if err != nil {
+				return nil, err
+			}
+			docs = append(docs, innerDoc)
+		}
+		doc.MetaData[metaKeyInnerHits] = docs
+	}
+
+	if len(hit.Sort) > 0 {
+		doc.MetaData[metaKeySortValues] = hit.Sort
+	}
+
+	return doc, nil
+}
+
+func of[T any](v T) *T {
+	return &v
+}
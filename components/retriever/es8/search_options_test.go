@@ -0,0 +1,157 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package es8
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/sortorder"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+// searchServer emulates the _search endpoint, replying resp and recording the request body.
+type searchServer struct {
+	resp string
+	body map[string]any
+}
+
+func (s *searchServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Elastic-Product", "Elasticsearch")
+	w.Header().Set("Content-Type", "application/json")
+	b, _ := io.ReadAll(r.Body)
+	s.body = map[string]any{}
+	_ = json.Unmarshal(b, &s.body)
+	_, _ = w.Write([]byte(s.resp))
+}
+
+// matchSearchMode matches the query in field content.
+type matchSearchMode struct{}
+
+func (m *matchSearchMode) BuildRequest(ctx context.Context, conf *RetrieverConfig, query string, opts ...retriever.Option) (*search.Request, error) {
+	return &search.Request{Query: &types.Query{Match: map[string]types.MatchQuery{"content": {Query: query}}}}, nil
+}
+
+func TestSearchOptions(t *testing.T) {
+	convey.Convey("test search options", t, func() {
+		ctx := context.Background()
+		srv := &searchServer{resp: `{"took": 1, "timed_out": false, "_shards": {"total": 1, "successful": 1, "skipped": 0, "failed": 0},
+			"hits": {"total": {"value": 2, "relation": "eq"}, "max_score": 2.0, "hits": [
+				{"_index": "docs", "_id": "1", "_score": 2.0, "_source": {"content": "eino graph", "parent": "a"},
+					"highlight": {"content": ["eino <em>graph</em>"]}, "sort": [2.0, "1"],
+					"inner_hits": {"collapsed": {"hits": {"total": {"value": 1, "relation": "eq"}, "hits": [
+						{"_index": "docs", "_id": "3", "_score": 1.0, "_source": {"content": "graph nodes", "parent": "a"}}
+					]}}}},
+				{"_index": "docs", "_id": "2", "_score": 1.5, "_source": {"content": "graph edges", "parent": "b"}, "sort": [1.5, "2"]}
+			]}}`}
+		ts := httptest.NewServer(srv)
+		defer ts.Close()
+		client, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{ts.URL}})
+		convey.So(err, convey.ShouldBeNil)
+
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			Client:     client,
+			Index:      "docs",
+			SearchMode: &matchSearchMode{},
+			ResultParser: func(ctx context.Context, hit types.Hit) (*schema.Document, error) {
+				var src struct {
+					Content string `json:"content"`
+				}
+				if err := json.Unmarshal(hit.Source_, &src); err != nil {
+					return nil, err
+				}
+				return &schema.Document{ID: *hit.Id_, Content: src.Content}, nil
+			},
+		})
+		convey.So(err, convey.ShouldBeNil)
+
+		convey.Convey("test all options", func() {
+			sort := []types.SortCombinations{
+				types.SortOptions{Score_: &types.ScoreSort{Order: &sortorder.Desc}},
+				types.SortOptions{SortOptions: map[string]types.FieldSort{"id": {Order: &sortorder.Asc}}},
+			}
+			docs, err := r.Retrieve(ctx, "graph",
+				WithHighlight(&Highlight{Fields: []string{"content"}, PreTags: []string{"["}, PostTags: []string{"]"}, FragmentSize: 50, NumberOfFragments: -1}),
+				WithCollapse(&Collapse{Field: "parent", InnerHits: 2, MaxConcurrentGroupSearches: 4}),
+				WithSort(sort...),
+				WithSearchAfter([]types.FieldValue{3.0, "0"}),
+				WithSourceFilter([]string{"content", "parent"}, []string{"*_vector"}),
+			)
+			convey.So(err, convey.ShouldBeNil)
+
+			b, _ := json.Marshal(srv.body)
+			convey.So(srv.body["highlight"], convey.ShouldResemble, map[string]any{
+				"fields": map[string]any{"content": map[string]any{}}, "pre_tags": []any{"["}, "post_tags": []any{"]"},
+				"fragment_size": float64(50), "number_of_fragments": float64(0),
+			})
+			convey.So(srv.body["collapse"], convey.ShouldResemble, map[string]any{
+				"field": "parent", "inner_hits": []any{map[string]any{"name": "collapsed", "size": float64(2)}}, "max_concurrent_group_searches": float64(4),
+			})
+			convey.So(srv.body["search_after"], convey.ShouldResemble, []any{float64(3), "0"})
+			convey.So(srv.body["track_scores"], convey.ShouldEqual, true)
+			convey.So(string(b), convey.ShouldContainSubstring, `"sort":[{"_score":{"order":"desc"}},{"id":{"order":"asc"}}]`)
+			convey.So(srv.body["_source"], convey.ShouldResemble, map[string]any{"includes": []any{"content", "parent"}, "excludes": []any{"*_vector"}})
+			convey.So(srv.body["query"], convey.ShouldNotBeNil)
+
+			convey.So(docs, convey.ShouldHaveLength, 2)
+			convey.So(GetHighlight(docs[0]), convey.ShouldResemble, map[string][]string{"content": {"eino <em>graph</em>"}})
+			inner := GetInnerHits(docs[0])
+			convey.So(inner, convey.ShouldHaveLength, 1)
+			convey.So(inner[0].ID, convey.ShouldEqual, "3")
+			convey.So(inner[0].Content, convey.ShouldEqual, "graph nodes")
+			convey.So(GetSortValues(docs[0]), convey.ShouldResemble, []types.FieldValue{2.0, "1"})
+			convey.So(GetHighlight(docs[1]), convey.ShouldBeNil)
+			convey.So(GetInnerHits(docs[1]), convey.ShouldBeNil)
+			convey.So(NextCursor(docs), convey.ShouldResemble, []types.FieldValue{1.5, "2"})
+			convey.So(NextCursor(nil), convey.ShouldBeNil)
+		})
+
+		convey.Convey("test no options", func() {
+			docs, err := r.Retrieve(ctx, "graph")
+			convey.So(err, convey.ShouldBeNil)
+			for _, key := range []string{"highlight", "collapse", "sort", "search_after", "_source", "track_scores"} {
+				convey.So(srv.body, convey.ShouldNotContainKey, key)
+			}
+			convey.So(docs, convey.ShouldHaveLength, 2)
+		})
+
+		convey.Convey("test invalid options", func() {
+			_, err := r.Retrieve(ctx, "graph", WithHighlight(&Highlight{}))
+			convey.So(err.Error(), convey.ShouldContainSubstring, "highlight fields not provided")
+			_, err = r.Retrieve(ctx, "graph", WithCollapse(&Collapse{}))
+			convey.So(err.Error(), convey.ShouldContainSubstring, "collapse field not provided")
+			_, err = r.Retrieve(ctx, "graph", WithSearchAfter([]types.FieldValue{1.0}))
+			convey.So(err.Error(), convey.ShouldContainSubstring, "search after needs sort")
+		})
+
+		convey.Convey("test inner hit parse error", func() {
+			srv.resp = `{"hits": {"hits": [{"_index": "docs", "_id": "1", "_source": {"content": "a"},
+				"inner_hits": {"collapsed": {"hits": {"hits": [{"_index": "docs", "_id": "3", "_source": []}]}}}}]}}`
+			_, err := r.Retrieve(ctx, "graph", WithCollapse(&Collapse{Field: "parent", InnerHits: 1}))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
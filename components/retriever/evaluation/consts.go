@@ -0,0 +1,27 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evaluation
+
+const (
+	defaultMaxContentRunes = 2000
+	defaultConcurrency     = 1
+	// defaultPoolDepthFactor times TopK is the default PoolDepth.
+	defaultPoolDepthFactor = 2
+)
+
+// defaultKs are the default cutoffs of the metrics.
+var defaultKs = []int{1, 3, 5, 10}
@@ -0,0 +1,108 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evaluation
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Sample is a labelled query of a dataset, a line of a JSONL dataset file, e.g.
+//
+//	{"id": "q1", "query": "what is eino", "relevant_ids": ["doc-1", "doc-7"]}
+//	{"id": "q2", "query": "eino graph", "relevance": {"doc-3": 2, "doc-4": 1}}
+//	{"id": "q3", "query": "eino callbacks"}
+//
+// A sample without labels, e.g. q3, is judged by Config.Judge.
+type Sample struct {
+	// ID of the sample in reports.
+	// Optional, and the default value is the line number.
+	ID    string `json:"id,omitempty"`
+	Query string `json:"query"`
+	// RelevantIDs are the ids of the relevant documents, each of grade 1 if not in Relevance.
+	RelevantIDs []string `json:"relevant_ids,omitempty"`
+	// Relevance is the graded relevance of document ids used by nDCG, higher is more relevant, 0 isn't relevant.
+	Relevance map[string]float64 `json:"relevance,omitempty"`
+}
+
+// labelled reports whether s has relevance labels.
+func (s *Sample) labelled() bool {
+	return len(s.RelevantIDs) > 0 || len(s.Relevance) > 0
+}
+
+// grades returns the relevance grade of each relevant document id.
+func (s *Sample) grades() map[string]float64 {
+	grades := make(map[string]float64, len(s.RelevantIDs)+len(s.Relevance))
+	for _, id := range s.RelevantIDs {
+		grades[id] = 1
+	}
+	for id, grade := range s.Relevance {
+		if grade > 0 {
+			grades[id] = grade
+		} else {
+			delete(grades, id)
+		}
+	}
+	return grades
+}
+
+// LoadDataset reads the samples of a JSONL dataset, one Sample per line, empty lines are skipped.
+func LoadDataset(r io.Reader) ([]*Sample, error) {
+	var samples []*Sample
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		s := &Sample{}
+		if err := json.Unmarshal([]byte(text), s); err != nil {
+			return nil, fmt.Errorf("[LoadDataset] invalid sample at line %d, %w", line, err)
+		}
+		if s.Query == "" {
+			return nil, fmt.Errorf("[LoadDataset] query not provided at line %d", line)
+		}
+		if s.ID == "" {
+			s.ID = fmt.Sprint(line)
+		}
+		samples = append(samples, s)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("[LoadDataset] read failed, %w", err)
+	}
+
+	return samples, nil
+}
+
+// LoadDatasetFile reads the samples of the JSONL dataset file at path.
+func LoadDatasetFile(path string) ([]*Sample, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("[LoadDatasetFile] %w", err)
+	}
+	defer f.Close()
+
+	return LoadDataset(f)
+}
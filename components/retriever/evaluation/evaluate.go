@@ -0,0 +1,368 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evaluation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+)
+
+// System is a retriever compared by Compare, e.g. a redis KNN retriever and an es8 hybrid retriever of the same corpus.
+type System struct {
+	// Name of the system in reports.
+	// Required.
+	Name      string
+	Retriever retriever.Retriever
+	// Options of each Retrieve call, applied after retriever.WithTopK(Config.TopK), or Config.PoolDepth for samples judged.
+	Options []retriever.Option
+}
+
+type Config struct {
+	// Ks are the cutoffs of recall, precision and nDCG.
+	// Default {1, 3, 5, 10}.
+	Ks []int
+	// TopK of each Retrieve call, the results after TopK are dropped, see PoolDepth for the samples judged.
+	// Default the max of Ks, and never less than it.
+	TopK int
+	// Concurrency number of concurrent Retrieve calls of a system, and of Judge calls.
+	// Systems are evaluated one after another, so that their latencies don't disturb each other,
+	// raise it to evaluate large datasets faster at the cost of latency accuracy.
+	// Default 1.
+	Concurrency int
+	// Judge grades the documents retrieved for samples without labels.
+	// Each distinct document in the top PoolDepth results of any system is judged once, and the relevant documents of
+	// the sample are the pool of them judged relevant, so recall is relative to what the compared systems found.
+	// Default nil, samples without labels are skipped.
+	Judge Judge
+	// PoolDepth number of results of each system judged for samples without labels, the metrics are computed on the top
+	// TopK of them, so that relevant documents ranked after TopK count in recall, which is 1 for a single system otherwise.
+	// Default 2 * TopK when Judge is set, and never less than TopK.
+	PoolDepth int
+}
+
+// QueryResult is the result of a sample retrieved by a system.
+type QueryResult struct {
+	SampleID     string   `json:"sample_id"`
+	Query        string   `json:"query"`
+	RetrievedIDs []string `json:"retrieved_ids"`
+	// Judged is true if the relevance of the sample is graded by Config.Judge.
+	Judged bool `json:"judged,omitempty"`
+	// Relevant is the number of relevant documents of the sample.
+	Relevant int `json:"relevant"`
+	// Metrics of the query, nil if it failed or was skipped.
+	Metrics   *Metrics `json:"metrics,omitempty"`
+	LatencyMs float64  `json:"latency_ms"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// Report is the evaluation of a system on a dataset.
+type Report struct {
+	Name    string `json:"name"`
+	Samples int    `json:"samples"`
+	// Evaluated is the number of samples Metrics is averaged over.
+	Evaluated int `json:"evaluated"`
+	// Skipped is the number of samples without labels, when Config.Judge is not set.
+	Skipped int `json:"skipped"`
+	// Failed is the number of samples whose Retrieve or Judge call failed.
+	Failed  int            `json:"failed"`
+	Metrics *Metrics       `json:"metrics"`
+	Latency *Latency       `json:"latency"`
+	Queries []*QueryResult `json:"queries"`
+}
+
+// Comparison is the side-by-side evaluation of systems on the same dataset, the first system is the baseline of Markdown.
+type Comparison struct {
+	Ks      []int     `json:"ks"`
+	TopK    int       `json:"top_k"`
+	Reports []*Report `json:"reports"`
+}
+
+// Evaluate evaluates r on samples, the report is named after the type of r.
+func Evaluate(ctx context.Context, r retriever.Retriever, samples []*Sample, config *Config) (*Report, error) {
+	name, ok := components.GetType(r)
+	if !ok {
+		name = "retriever"
+	}
+
+	c, err := Compare(ctx, []*System{{Name: name, Retriever: r}}, samples, config)
+	if err != nil {
+		return nil, err
+	}
+	return c.Reports[0], nil
+}
+
+// Compare evaluates each of systems on samples.
+func Compare(ctx context.Context, systems []*System, samples []*Sample, config *Config) (*Comparison, error) {
+	if config == nil {
+		config = &Config{}
+	}
+	if err := config.check(); err != nil {
+		return nil, fmt.Errorf("[Compare] %w", err)
+	}
+
+	if len(systems) == 0 {
+		return nil, fmt.Errorf("[Compare] systems not provided")
+	}
+	names := make(map[string]struct{}, len(systems))
+	for _, s := range systems {
+		if s.Name == "" || s.Retriever == nil {
+			return nil, fmt.Errorf("[Compare] system name or retriever not provided")
+		}
+		if _, found := names[s.Name]; found {
+			return nil, fmt.Errorf("[Compare] duplicate system name: %s", s.Name)
+		}
+		names[s.Name] = struct{}{}
+	}
+
+	runs := make([][]*run, len(systems))
+	for i, s := range systems {
+		runs[i] = config.retrieveAll(ctx, s, samples)
+	}
+
+	grades, judgeErrs := config.judgeAll(ctx, samples, runs)
+
+	c := &Comparison{Ks: config.Ks, TopK: config.TopK}
+	for i, s := range systems {
+		c.Reports = append(c.Reports, config.report(s.Name, samples, runs[i], grades, judgeErrs))
+	}
+
+	return c, nil
+}
+
+func (c *Config) check() error {
+	if len(c.Ks) == 0 {
+		c.Ks = defaultKs
+	}
+
+	ks := append([]int(nil), c.Ks...)
+	sort.Ints(ks)
+	for i, k := range ks {
+		if k <= 0 || i > 0 && k == ks[i-1] {
+			return fmt.Errorf("invalid ks: %v", c.Ks)
+		}
+	}
+	c.Ks = ks
+
+	if maxK := ks[len(ks)-1]; c.TopK < maxK {
+		c.TopK = maxK
+	}
+
+	if c.Concurrency == 0 {
+		c.Concurrency = defaultConcurrency
+	}
+	if c.Concurrency < 0 {
+		return fmt.Errorf("invalid concurrency: %d", c.Concurrency)
+	}
+
+	if c.PoolDepth < 0 {
+		return fmt.Errorf("invalid pool depth: %d", c.PoolDepth)
+	}
+	if c.PoolDepth == 0 && c.Judge != nil {
+		c.PoolDepth = defaultPoolDepthFactor * c.TopK
+	}
+	if c.PoolDepth < c.TopK {
+		c.PoolDepth = c.TopK
+	}
+
+	return nil
+}
+
+// run is a Retrieve call of a sample.
+type run struct {
+	docs    []*schema.Document
+	latency time.Duration
+	err     error
+}
+
+// retrieveAll retrieves each sample with system, keeping the first TopK distinct documents,
+// or the first PoolDepth ones of the samples to judge.
+func (c *Config) retrieveAll(ctx context.Context, system *System, samples []*Sample) []*run {
+	runs := make([]*run, len(samples))
+	forEach(len(samples), c.Concurrency, func(i int) {
+		depth := c.TopK
+		if c.Judge != nil && !samples[i].labelled() {
+			depth = c.PoolDepth
+		}
+		opts := append([]retriever.Option{retriever.WithTopK(depth)}, system.Options...)
+
+		start := time.Now()
+		docs, err := safeRetrieve(ctx, system.Retriever, samples[i].Query, opts)
+		r := &run{latency: time.Since(start), err: err}
+
+		seen := make(map[string]struct{}, len(docs))
+		for _, doc := range docs {
+			if len(r.docs) == depth {
+				break
+			}
+			if _, found := seen[doc.ID]; !found {
+				seen[doc.ID] = struct{}{}
+				r.docs = append(r.docs, doc)
+			}
+		}
+		runs[i] = r
+	})
+
+	return runs
+}
+
+func safeRetrieve(ctx context.Context, r retriever.Retriever, query string, opts []retriever.Option) (docs []*schema.Document, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("retrieve panic: %v", e)
+		}
+	}()
+	return r.Retrieve(ctx, query, opts...)
+}
+
+// judgeAll grades the distinct documents retrieved by any system for each sample without labels,
+// and returns the grades of the relevant ones and the judge errors by sample index.
+func (c *Config) judgeAll(ctx context.Context, samples []*Sample, runs [][]*run) (map[int]map[string]float64, map[int]error) {
+	type task struct {
+		sample int
+		doc    *schema.Document
+	}
+
+	grades := make(map[int]map[string]float64)
+	errs := make(map[int]error)
+	if c.Judge == nil {
+		return grades, errs
+	}
+
+	var tasks []task
+	for i, s := range samples {
+		if s.labelled() {
+			continue
+		}
+		grades[i] = map[string]float64{}
+		seen := make(map[string]struct{})
+		for _, sysRuns := range runs {
+			for _, doc := range sysRuns[i].docs {
+				if _, found := seen[doc.ID]; !found {
+					seen[doc.ID] = struct{}{}
+					tasks = append(tasks, task{sample: i, doc: doc})
+				}
+			}
+		}
+	}
+
+	var mu sync.Mutex
+	forEach(len(tasks), c.Concurrency, func(i int) {
+		t := tasks[i]
+		grade, err := safeJudge(ctx, c.Judge, samples[t.sample].Query, t.doc)
+
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case err != nil:
+			if errs[t.sample] == nil {
+				errs[t.sample] = fmt.Errorf("judge document %s failed, %w", t.doc.ID, err)
+			}
+		case grade > 0:
+			grades[t.sample][t.doc.ID] = grade
+		}
+	})
+
+	return grades, errs
+}
+
+func safeJudge(ctx context.Context, j Judge, query string, doc *schema.Document) (grade float64, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("judge panic: %v", e)
+		}
+	}()
+	return j.Judge(ctx, query, doc)
+}
+
+// report computes the metrics of the runs of a system.
+func (c *Config) report(name string, samples []*Sample, runs []*run, judged map[int]map[string]float64, judgeErrs map[int]error) *Report {
+	r := &Report{Name: name, Samples: len(samples)}
+
+	var (
+		metrics   []*Metrics
+		latencies []time.Duration
+	)
+	for i, s := range samples {
+		run := runs[i]
+		q := &QueryResult{
+			SampleID:     s.ID,
+			Query:        s.Query,
+			RetrievedIDs: make([]string, 0, min(len(run.docs), c.TopK)),
+			LatencyMs:    ms(run.latency),
+		}
+		// the results after TopK of a judged sample are only in the pool
+		for j := 0; j < len(run.docs) && j < c.TopK; j++ {
+			q.RetrievedIDs = append(q.RetrievedIDs, run.docs[j].ID)
+		}
+		r.Queries = append(r.Queries, q)
+
+		if run.err != nil {
+			q.Error = run.err.Error()
+			r.Failed++
+			continue
+		}
+		latencies = append(latencies, run.latency)
+
+		grades := s.grades()
+		if !s.labelled() {
+			if err := judgeErrs[i]; err != nil {
+				q.Error = err.Error()
+				r.Failed++
+				continue
+			}
+			if grades = judged[i]; grades == nil {
+				r.Skipped++
+				continue
+			}
+			q.Judged = true
+		}
+
+		q.Relevant = len(grades)
+		q.Metrics = computeMetrics(q.RetrievedIDs, grades, c.Ks)
+		metrics = append(metrics, q.Metrics)
+	}
+
+	r.Evaluated = len(metrics)
+	r.Metrics = averageMetrics(metrics, c.Ks)
+	r.Latency = latencyStats(latencies)
+	return r
+}
+
+// forEach calls fn for 0 to n-1 with at most concurrency calls at a time, and waits for them.
+func forEach(n, concurrency int, fn func(i int)) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
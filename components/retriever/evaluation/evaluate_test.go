@@ -0,0 +1,338 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evaluation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+// mockRetriever returns the documents of each query.
+type mockRetriever struct {
+	docs   map[string][]string
+	errs   map[string]error
+	panics bool
+	mu     sync.Mutex
+	topKs  []int
+}
+
+func (m *mockRetriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) ([]*schema.Document, error) {
+	co := retriever.GetCommonOptions(&retriever.Options{}, opts...)
+	m.mu.Lock()
+	m.topKs = append(m.topKs, *co.TopK)
+	m.mu.Unlock()
+
+	if err := m.errs[query]; err != nil {
+		return nil, err
+	}
+	if m.panics {
+		panic("boom")
+	}
+	var docs []*schema.Document
+	for _, id := range m.docs[query] {
+		docs = append(docs, &schema.Document{ID: id, Content: "content of " + id})
+	}
+	return docs, nil
+}
+
+func (m *mockRetriever) GetType() string {
+	return "Mock"
+}
+
+// mockChatModel replies with the grade of the document in the prompt.
+type mockChatModel struct {
+	replies map[string]string
+	err     error
+	mu      sync.Mutex
+	prompts []string
+}
+
+func (m *mockChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	callbacks.OnStart(ctx, &model.CallbackInput{Messages: input})
+	prompt := input[0].Content
+	m.mu.Lock()
+	m.prompts = append(m.prompts, prompt)
+	m.mu.Unlock()
+
+	if m.err != nil {
+		return nil, m.err
+	}
+	for content, reply := range m.replies {
+		if strings.Contains(prompt, content) {
+			return schema.AssistantMessage(reply, nil), nil
+		}
+	}
+	return schema.AssistantMessage("0", nil), nil
+}
+
+func (m *mockChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func TestCompare(t *testing.T) {
+	ctx := context.Background()
+	samples := []*Sample{
+		{ID: "q1", Query: "a", RelevantIDs: []string{"1", "2"}},
+		{ID: "q2", Query: "b", Relevance: map[string]float64{"3": 2}},
+		{ID: "q3", Query: "c"},
+	}
+
+	convey.Convey("test Compare", t, func() {
+		base := &mockRetriever{docs: map[string][]string{
+			"a": {"1", "x", "1", "y"},
+			"b": {"x", "3"},
+			"c": {"5", "6"},
+		}}
+		better := &mockRetriever{docs: map[string][]string{
+			"a": {"1", "2"},
+			"b": {"3"},
+			"c": {"6", "7"},
+		}}
+		systems := []*System{{Name: "base", Retriever: base}, {Name: "better", Retriever: better}}
+
+		convey.Convey("invalid config", func() {
+			_, err := Compare(ctx, systems, samples, &Config{Ks: []int{1, 1}})
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = Compare(ctx, systems, samples, &Config{Ks: []int{0}})
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = Compare(ctx, systems, samples, &Config{Concurrency: -1})
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = Compare(ctx, nil, samples, nil)
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = Compare(ctx, []*System{{Name: "base"}}, samples, nil)
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = Compare(ctx, []*System{systems[0], {Name: "base", Retriever: better}}, samples, nil)
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("labelled samples only without judge", func() {
+			c, err := Compare(ctx, systems, samples, &Config{Ks: []int{2, 1}, Concurrency: 2})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(c.Ks, convey.ShouldResemble, []int{1, 2})
+			convey.So(c.TopK, convey.ShouldEqual, 2)
+			convey.So(base.topKs, convey.ShouldResemble, []int{2, 2, 2})
+
+			r := c.Reports[0]
+			convey.So(r.Name, convey.ShouldEqual, "base")
+			convey.So(r.Samples, convey.ShouldEqual, 3)
+			convey.So(r.Evaluated, convey.ShouldEqual, 2)
+			convey.So(r.Skipped, convey.ShouldEqual, 1)
+			convey.So(r.Queries[0].RetrievedIDs, convey.ShouldResemble, []string{"1", "x"})
+			convey.So(r.Queries[0].Relevant, convey.ShouldEqual, 2)
+			convey.So(r.Queries[2].Metrics, convey.ShouldBeNil)
+			convey.So(r.Metrics.MRR, convey.ShouldEqual, 0.75)
+			convey.So(r.Metrics.Recall[2], convey.ShouldEqual, 0.75)
+
+			r = c.Reports[1]
+			convey.So(r.Metrics.MRR, convey.ShouldEqual, 1)
+			convey.So(r.Metrics.Recall[2], convey.ShouldEqual, 1)
+			convey.So(r.Metrics.NDCG[2], convey.ShouldAlmostEqual, 1)
+		})
+
+		convey.Convey("judged samples", func() {
+			var mu sync.Mutex
+			calls := map[string]int{}
+			judge := JudgeFunc(func(ctx context.Context, query string, doc *schema.Document) (float64, error) {
+				mu.Lock()
+				calls[query+"/"+doc.ID]++
+				mu.Unlock()
+				if doc.ID == "6" || doc.ID == "7" {
+					return 1, nil
+				}
+				return 0, nil
+			})
+
+			c, err := Compare(ctx, systems, samples, &Config{Ks: []int{2}, Judge: judge})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(calls, convey.ShouldResemble, map[string]int{"c/5": 1, "c/6": 1, "c/7": 1})
+
+			q := c.Reports[0].Queries[2]
+			convey.So(q.Judged, convey.ShouldBeTrue)
+			convey.So(q.Relevant, convey.ShouldEqual, 2)
+			convey.So(q.Metrics.Recall[2], convey.ShouldEqual, 0.5)
+			convey.So(q.Metrics.MRR, convey.ShouldEqual, 0.5)
+			convey.So(c.Reports[1].Queries[2].Metrics.Recall[2], convey.ShouldEqual, 1)
+			convey.So(c.Reports[0].Evaluated, convey.ShouldEqual, 3)
+		})
+
+		convey.Convey("judged samples with a pool deeper than top k", func() {
+			judge := JudgeFunc(func(ctx context.Context, query string, doc *schema.Document) (float64, error) {
+				if doc.ID == "6" || doc.ID == "8" {
+					return 1, nil
+				}
+				return 0, nil
+			})
+			deep := &mockRetriever{docs: map[string][]string{"c": {"5", "6", "7", "8", "9"}}}
+
+			// the relevant document after top k isn't found, recall isn't always 1 with a single system
+			c, err := Compare(ctx, []*System{{Name: "deep", Retriever: deep}}, samples[2:], &Config{Ks: []int{2}, Judge: judge})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(deep.topKs, convey.ShouldResemble, []int{4})
+			q := c.Reports[0].Queries[0]
+			convey.So(q.RetrievedIDs, convey.ShouldResemble, []string{"5", "6"})
+			convey.So(q.Relevant, convey.ShouldEqual, 2)
+			convey.So(q.Metrics.Recall[2], convey.ShouldEqual, 0.5)
+			convey.So(q.Metrics.Precision[2], convey.ShouldEqual, 0.5)
+
+			_, err = Compare(ctx, systems, samples, &Config{Judge: judge, PoolDepth: -1})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			// labelled samples are retrieved with TopK, and a pool shallower than TopK is raised to it
+			c, err = Compare(ctx, []*System{{Name: "base", Retriever: base}}, samples, &Config{Ks: []int{2}, Judge: judge, PoolDepth: 1})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(base.topKs, convey.ShouldResemble, []int{2, 2, 2})
+		})
+
+		convey.Convey("judge error", func() {
+			judge := JudgeFunc(func(ctx context.Context, query string, doc *schema.Document) (float64, error) {
+				if doc.ID == "7" {
+					panic("judge boom")
+				}
+				return 0, fmt.Errorf("judge failed")
+			})
+
+			c, err := Compare(ctx, systems, samples, &Config{Judge: judge})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(c.Reports[0].Failed, convey.ShouldEqual, 1)
+			convey.So(c.Reports[0].Queries[2].Error, convey.ShouldContainSubstring, "judge")
+			convey.So(c.Reports[0].Evaluated, convey.ShouldEqual, 2)
+		})
+
+		convey.Convey("retrieve error", func() {
+			failing := &mockRetriever{errs: map[string]error{"a": fmt.Errorf("unavailable")}}
+			panicking := &mockRetriever{panics: true}
+
+			c, err := Compare(ctx, []*System{
+				{Name: "failing", Retriever: failing},
+				{Name: "panicking", Retriever: panicking},
+			}, samples[:2], nil)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(c.TopK, convey.ShouldEqual, 10)
+
+			r := c.Reports[0]
+			convey.So(r.Failed, convey.ShouldEqual, 1)
+			convey.So(r.Evaluated, convey.ShouldEqual, 1)
+			convey.So(r.Queries[0].Error, convey.ShouldEqual, "unavailable")
+
+			r = c.Reports[1]
+			convey.So(r.Failed, convey.ShouldEqual, 2)
+			convey.So(r.Evaluated, convey.ShouldEqual, 0)
+			convey.So(r.Queries[1].Error, convey.ShouldContainSubstring, "panic")
+		})
+	})
+}
+
+func TestEvaluate(t *testing.T) {
+	convey.Convey("test Evaluate", t, func() {
+		ctx := context.Background()
+		r := &mockRetriever{docs: map[string][]string{"a": {"1"}}}
+
+		report, err := Evaluate(ctx, r, []*Sample{{ID: "q1", Query: "a", RelevantIDs: []string{"1"}}}, &Config{Ks: []int{1}, TopK: 5})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(report.Name, convey.ShouldEqual, "Mock")
+		convey.So(report.Metrics.Precision[1], convey.ShouldEqual, 1)
+		convey.So(r.topKs, convey.ShouldResemble, []int{5})
+
+		_, err = Evaluate(ctx, r, nil, &Config{Ks: []int{-1}})
+		convey.So(err, convey.ShouldNotBeNil)
+	})
+}
+
+func TestLLMJudge(t *testing.T) {
+	ctx := context.Background()
+
+	convey.Convey("test LLMJudge", t, func() {
+		convey.Convey("invalid config", func() {
+			_, err := NewLLMJudge(ctx, &LLMJudgeConfig{})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("grade", func() {
+			cm := &mockChatModel{replies: map[string]string{
+				"content of 1": "2",
+				"content of 2": " 1. Somewhat relevant",
+				"content of 3": "irrelevant",
+			}}
+			var started []string
+			handler := callbacks.NewHandlerBuilder().OnStartFn(func(ctx context.Context, info *callbacks.RunInfo, input callbacks.CallbackInput) context.Context {
+				started = append(started, info.Name)
+				return ctx
+			}).Build()
+			ctx := callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, handler)
+
+			j, err := NewLLMJudge(ctx, &LLMJudgeConfig{ChatModel: cm, MaxContentRunes: 12})
+			convey.So(err, convey.ShouldBeNil)
+
+			grade, err := j.Judge(ctx, "q", &schema.Document{ID: "1", Content: "content of 1 and more"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(grade, convey.ShouldEqual, 2)
+			convey.So(cm.prompts[0], convey.ShouldContainSubstring, "content of 1")
+			convey.So(cm.prompts[0], convey.ShouldNotContainSubstring, "and more")
+			convey.So(started, convey.ShouldResemble, []string{"ChatModel"})
+
+			grade, err = j.Judge(ctx, "q", &schema.Document{ID: "2", Content: "content of 2"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(grade, convey.ShouldEqual, 1)
+
+			_, err = j.Judge(ctx, "q", &schema.Document{ID: "3", Content: "content of 3"})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("generate error", func() {
+			j, err := NewLLMJudge(ctx, &LLMJudgeConfig{ChatModel: &mockChatModel{err: fmt.Errorf("rate limited")}, Prompt: "{query} {document}"})
+			convey.So(err, convey.ShouldBeNil)
+			_, err = j.Judge(ctx, "q", &schema.Document{ID: "1"})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestComparisonReport(t *testing.T) {
+	convey.Convey("test Comparison report", t, func() {
+		ctx := context.Background()
+		samples := []*Sample{{ID: "q1", Query: "a", RelevantIDs: []string{"1", "2"}}}
+		c, err := Compare(ctx, []*System{
+			{Name: "base", Retriever: &mockRetriever{docs: map[string][]string{"a": {"1", "x"}}}},
+			{Name: "a|b", Retriever: &mockRetriever{docs: map[string][]string{"a": {"1", "2"}}}},
+		}, samples, &Config{Ks: []int{1, 2}})
+		convey.So(err, convey.ShouldBeNil)
+
+		md := c.Markdown()
+		convey.So(md, convey.ShouldStartWith, "| Metric | base | a\\|b |\n|---|---:|---:|\n")
+		convey.So(md, convey.ShouldContainSubstring, "| Recall@2 | 0.500 | 1.000 (+100.0%) |")
+		convey.So(md, convey.ShouldContainSubstring, "| Precision@1 | 1.000 | 1.000 (+0.0%) |")
+		convey.So(md, convey.ShouldContainSubstring, "| Evaluated / Skipped / Failed | 1 / 0 / 0 | 1 / 0 / 0 |")
+
+		data, err := c.JSON()
+		convey.So(err, convey.ShouldBeNil)
+		var decoded Comparison
+		convey.So(json.Unmarshal(data, &decoded), convey.ShouldBeNil)
+		convey.So(decoded.Reports[1].Name, convey.ShouldEqual, "a|b")
+		convey.So(decoded.Reports[1].Metrics.Recall[2], convey.ShouldEqual, 1)
+		convey.So(decoded.Reports[0].Queries[0].RetrievedIDs, convey.ShouldResemble, []string{"1", "x"})
+	})
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/retriever/evaluation"
+)
+
+const dataset = `{"id": "q1", "query": "what is eino", "relevant_ids": ["1"]}
+{"id": "q2", "query": "how to build agents", "relevance": {"2": 2, "3": 1}}
+{"id": "q3", "query": "graph components", "relevant_ids": ["3"]}
+`
+
+func main() {
+	ctx := context.Background()
+
+	samples, err := evaluation.LoadDataset(strings.NewReader(dataset)) // or evaluation.LoadDatasetFile("dataset.jsonl")
+	if err != nil {
+		log.Fatalf("LoadDataset failed, err=%v", err)
+	}
+
+	docs := []*schema.Document{
+		{ID: "1", Content: "eino is a llm application framework in go"},
+		{ID: "2", Content: "agents are built by react graphs with tools"},
+		{ID: "3", Content: "graphs compose components like chat models and retrievers"},
+	}
+
+	comparison, err := evaluation.Compare(ctx, []*evaluation.System{
+		{Name: "keyword", Retriever: &keywordRetriever{docs: docs}},                         // replace it with real retriever component
+		{Name: "keyword-reversed", Retriever: &keywordRetriever{docs: docs, reverse: true}}, // e.g. the es8 hybrid retriever
+	}, samples, &evaluation.Config{Ks: []int{1, 3}})
+	if err != nil {
+		log.Fatalf("Compare failed, err=%v", err)
+	}
+
+	fmt.Println(comparison.Markdown())
+
+	data, err := comparison.JSON()
+	if err != nil {
+		log.Fatalf("JSON failed, err=%v", err)
+	}
+	log.Printf("json report: %d bytes", len(data))
+}
+
+// keywordRetriever returns documents sharing words with the query, only for the example.
+type keywordRetriever struct {
+	docs    []*schema.Document
+	reverse bool
+}
+
+func (k *keywordRetriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) ([]*schema.Document, error) {
+	words := strings.Fields(strings.ToLower(query))
+	var result []*schema.Document
+	for _, doc := range k.docs {
+		for _, w := range words {
+			if len(w) > 3 && strings.Contains(doc.Content, w) {
+				result = append(result, doc)
+				break
+			}
+		}
+	}
+	if k.reverse {
+		for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+			result[i], result[j] = result[j], result[i]
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evaluation
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Judge grades the relevance of a retrieved document to the query of an unlabelled sample, 0 isn't relevant.
+type Judge interface {
+	Judge(ctx context.Context, query string, doc *schema.Document) (float64, error)
+}
+
+// JudgeFunc is a function implementing Judge.
+type JudgeFunc func(ctx context.Context, query string, doc *schema.Document) (float64, error)
+
+func (f JudgeFunc) Judge(ctx context.Context, query string, doc *schema.Document) (float64, error) {
+	return f(ctx, query, doc)
+}
+
+// DefaultJudgePrompt is the default prompt of the LLM judge, "{query}" and "{document}" are replaced.
+const DefaultJudgePrompt = `You are evaluating a search engine. Grade how relevant the document is to the query:
+0 - not relevant, it doesn't help to answer the query.
+1 - partially relevant, it's on the topic but doesn't answer the query.
+2 - highly relevant, it answers the query.
+Reply with the grade only.
+
+Query: {query}
+
+Document: {document}`
+
+type LLMJudgeConfig struct {
+	// ChatModel grades documents.
+	// Required.
+	ChatModel model.BaseChatModel
+	// Prompt asks for the grade of a document, "{query}" and "{document}" are replaced by the query and the document content.
+	// The reply should start with the grade, a non negative number.
+	// Default DefaultJudgePrompt, grading 0, 1 or 2.
+	Prompt string
+	// MaxContentRunes truncates document content in the prompt.
+	// Default 2000.
+	MaxContentRunes int
+}
+
+// LLMJudge is a Judge asking a chat model for the relevance grade of each document.
+type LLMJudge struct {
+	config *LLMJudgeConfig
+}
+
+func NewLLMJudge(_ context.Context, config *LLMJudgeConfig) (*LLMJudge, error) {
+	if config.ChatModel == nil {
+		return nil, fmt.Errorf("[NewLLMJudge] chat model not provided")
+	}
+
+	if config.Prompt == "" {
+		config.Prompt = DefaultJudgePrompt
+	}
+
+	if config.MaxContentRunes == 0 {
+		config.MaxContentRunes = defaultMaxContentRunes
+	}
+
+	return &LLMJudge{config: config}, nil
+}
+
+func (j *LLMJudge) Judge(ctx context.Context, query string, doc *schema.Document) (float64, error) {
+	content := doc.Content
+	if runes := []rune(content); len(runes) > j.config.MaxContentRunes {
+		content = string(runes[:j.config.MaxContentRunes])
+	}
+	prompt := strings.NewReplacer("{query}", query, "{document}", content).Replace(j.config.Prompt)
+
+	msg, err := j.config.ChatModel.Generate(j.makeChatModelCtx(ctx), []*schema.Message{schema.UserMessage(prompt)})
+	if err != nil {
+		return 0, fmt.Errorf("[LLMJudge] generate failed, %w", err)
+	}
+
+	reply := strings.TrimSpace(msg.Content)
+	end := strings.IndexFunc(reply, func(r rune) bool { return !unicode.IsDigit(r) && r != '.' })
+	if end < 0 {
+		end = len(reply)
+	}
+	grade, err := strconv.ParseFloat(reply[:end], 64)
+	if err != nil {
+		return 0, fmt.Errorf("[LLMJudge] invalid grade in reply %q", reply)
+	}
+	return grade, nil
+}
+
+func (j *LLMJudge) makeChatModelCtx(ctx context.Context) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfChatModel,
+	}
+
+	if modelType, ok := components.GetType(j.config.ChatModel); ok {
+		runInfo.Type = modelType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
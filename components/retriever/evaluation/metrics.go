@@ -0,0 +1,167 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evaluation
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Metrics of ranked retrieval results, averaged over the evaluated queries in a Report.
+type Metrics struct {
+	// Recall at k, the fraction of the relevant documents in the top k.
+	Recall map[int]float64 `json:"recall"`
+	// Precision at k, the fraction of the top k which is relevant, k counts missing results.
+	Precision map[int]float64 `json:"precision"`
+	// NDCG at k, normalized discounted cumulative gain with gain 2^grade-1 and discount log2(rank+1).
+	NDCG map[int]float64 `json:"ndcg"`
+	// MRR mean reciprocal rank of the first relevant document in the results, 0 if none is relevant.
+	MRR float64 `json:"mrr"`
+}
+
+// computeMetrics computes the metrics of the ranked document ids of a query, whose relevant ids have grades.
+// The duplicates of an id are dropped, so that a relevant document is counted once.
+func computeMetrics(ranked []string, grades map[string]float64, ks []int) *Metrics {
+	m := &Metrics{
+		Recall:    make(map[int]float64, len(ks)),
+		Precision: make(map[int]float64, len(ks)),
+		NDCG:      make(map[int]float64, len(ks)),
+	}
+
+	distinct := make([]string, 0, len(ranked))
+	seen := make(map[string]struct{}, len(ranked))
+	for _, id := range ranked {
+		if _, found := seen[id]; !found {
+			seen[id] = struct{}{}
+			distinct = append(distinct, id)
+		}
+	}
+	ranked = distinct
+
+	for i, id := range ranked {
+		if grades[id] > 0 {
+			m.MRR = 1 / float64(i+1)
+			break
+		}
+	}
+
+	ideal := make([]float64, 0, len(grades))
+	for _, grade := range grades {
+		ideal = append(ideal, grade)
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(ideal)))
+
+	for _, k := range ks {
+		var hits int
+		var dcg, idcg float64
+		for i := 0; i < k && i < len(ranked); i++ {
+			if grade := grades[ranked[i]]; grade > 0 {
+				hits++
+				dcg += gain(grade, i)
+			}
+		}
+		for i := 0; i < k && i < len(ideal); i++ {
+			idcg += gain(ideal[i], i)
+		}
+
+		if len(grades) > 0 {
+			m.Recall[k] = float64(hits) / float64(len(grades))
+		}
+		m.Precision[k] = float64(hits) / float64(k)
+		if idcg > 0 {
+			m.NDCG[k] = dcg / idcg
+		}
+	}
+
+	return m
+}
+
+// gain is the discounted gain of grade at 0 based rank i.
+func gain(grade float64, i int) float64 {
+	return (math.Pow(2, grade) - 1) / math.Log2(float64(i+2))
+}
+
+// averageMetrics returns the mean of metrics, zero values if metrics is empty.
+func averageMetrics(metrics []*Metrics, ks []int) *Metrics {
+	avg := &Metrics{
+		Recall:    make(map[int]float64, len(ks)),
+		Precision: make(map[int]float64, len(ks)),
+		NDCG:      make(map[int]float64, len(ks)),
+	}
+	if len(metrics) == 0 {
+		return avg
+	}
+
+	n := float64(len(metrics))
+	for _, m := range metrics {
+		for _, k := range ks {
+			avg.Recall[k] += m.Recall[k] / n
+			avg.Precision[k] += m.Precision[k] / n
+			avg.NDCG[k] += m.NDCG[k] / n
+		}
+		avg.MRR += m.MRR / n
+	}
+	return avg
+}
+
+// Latency percentiles of the Retrieve calls, in milliseconds.
+type Latency struct {
+	Mean float64 `json:"mean_ms"`
+	P50  float64 `json:"p50_ms"`
+	P90  float64 `json:"p90_ms"`
+	P95  float64 `json:"p95_ms"`
+	P99  float64 `json:"p99_ms"`
+	Max  float64 `json:"max_ms"`
+}
+
+// latencyStats returns the mean, the nearest-rank percentiles and the max of durations.
+func latencyStats(durations []time.Duration) *Latency {
+	l := &Latency{}
+	if len(durations) == 0 {
+		return l
+	}
+
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+
+	percentile := func(p float64) float64 {
+		rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+		if rank < 1 {
+			rank = 1
+		}
+		return ms(sorted[rank-1])
+	}
+
+	l.Mean = ms(total) / float64(len(sorted))
+	l.P50 = percentile(50)
+	l.P90 = percentile(90)
+	l.P95 = percentile(95)
+	l.P99 = percentile(99)
+	l.Max = ms(sorted[len(sorted)-1])
+	return l
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,140 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evaluation
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestComputeMetrics(t *testing.T) {
+	convey.Convey("test computeMetrics", t, func() {
+		convey.Convey("binary relevance", func() {
+			m := computeMetrics([]string{"a", "b", "c", "d"}, map[string]float64{"b": 1, "d": 1, "x": 1}, []int{1, 3, 5})
+			convey.So(m.MRR, convey.ShouldEqual, 0.5)
+			convey.So(m.Recall[1], convey.ShouldEqual, 0)
+			convey.So(m.Recall[3], convey.ShouldAlmostEqual, 1.0/3)
+			convey.So(m.Recall[5], convey.ShouldAlmostEqual, 2.0/3)
+			convey.So(m.Precision[1], convey.ShouldEqual, 0)
+			convey.So(m.Precision[3], convey.ShouldAlmostEqual, 1.0/3)
+			convey.So(m.Precision[5], convey.ShouldAlmostEqual, 2.0/5)
+
+			dcg := 1/math.Log2(3) + 1/math.Log2(5)
+			idcg := 1 + 1/math.Log2(3) + 1/math.Log2(4)
+			convey.So(m.NDCG[5], convey.ShouldAlmostEqual, dcg/idcg)
+			convey.So(m.NDCG[1], convey.ShouldEqual, 0)
+		})
+
+		convey.Convey("graded relevance", func() {
+			m := computeMetrics([]string{"b", "a"}, map[string]float64{"a": 2, "b": 1}, []int{2})
+			convey.So(m.MRR, convey.ShouldEqual, 1)
+			dcg := 1 + 3/math.Log2(3)
+			idcg := 3 + 1/math.Log2(3)
+			convey.So(m.NDCG[2], convey.ShouldAlmostEqual, dcg/idcg)
+
+			m = computeMetrics([]string{"a", "b"}, map[string]float64{"a": 2, "b": 1}, []int{2})
+			convey.So(m.NDCG[2], convey.ShouldAlmostEqual, 1)
+		})
+
+		convey.Convey("duplicate ids", func() {
+			m := computeMetrics([]string{"a", "a", "b"}, map[string]float64{"a": 1}, []int{2})
+			convey.So(m.Recall[2], convey.ShouldEqual, 1)
+			convey.So(m.Precision[2], convey.ShouldEqual, 0.5)
+			convey.So(m.NDCG[2], convey.ShouldAlmostEqual, 1)
+		})
+
+		convey.Convey("no relevant documents", func() {
+			m := computeMetrics([]string{"a"}, map[string]float64{}, []int{1})
+			convey.So(m.MRR, convey.ShouldEqual, 0)
+			convey.So(m.Recall[1], convey.ShouldEqual, 0)
+			convey.So(m.NDCG[1], convey.ShouldEqual, 0)
+		})
+	})
+}
+
+func TestAverageMetrics(t *testing.T) {
+	convey.Convey("test averageMetrics", t, func() {
+		avg := averageMetrics(nil, []int{1})
+		convey.So(avg.Recall, convey.ShouldResemble, map[int]float64{})
+
+		avg = averageMetrics([]*Metrics{
+			{Recall: map[int]float64{1: 1}, Precision: map[int]float64{1: 1}, NDCG: map[int]float64{1: 1}, MRR: 1},
+			{Recall: map[int]float64{1: 0}, Precision: map[int]float64{1: 0}, NDCG: map[int]float64{1: 0.5}, MRR: 0.5},
+		}, []int{1})
+		convey.So(avg.Recall[1], convey.ShouldEqual, 0.5)
+		convey.So(avg.Precision[1], convey.ShouldEqual, 0.5)
+		convey.So(avg.NDCG[1], convey.ShouldEqual, 0.75)
+		convey.So(avg.MRR, convey.ShouldEqual, 0.75)
+	})
+}
+
+func TestLatencyStats(t *testing.T) {
+	convey.Convey("test latencyStats", t, func() {
+		convey.So(latencyStats(nil), convey.ShouldResemble, &Latency{})
+
+		var durations []time.Duration
+		for i := 100; i >= 1; i-- {
+			durations = append(durations, time.Duration(i)*time.Millisecond)
+		}
+		l := latencyStats(durations)
+		convey.So(l.Mean, convey.ShouldAlmostEqual, 50.5)
+		convey.So(l.P50, convey.ShouldEqual, 50)
+		convey.So(l.P90, convey.ShouldEqual, 90)
+		convey.So(l.P95, convey.ShouldEqual, 95)
+		convey.So(l.P99, convey.ShouldEqual, 99)
+		convey.So(l.Max, convey.ShouldEqual, 100)
+		convey.So(durations[0], convey.ShouldEqual, 100*time.Millisecond)
+	})
+}
+
+func TestLoadDataset(t *testing.T) {
+	convey.Convey("test LoadDataset", t, func() {
+		convey.Convey("success", func() {
+			samples, err := LoadDataset(strings.NewReader(`{"id": "q1", "query": "what is eino", "relevant_ids": ["doc-1", "doc-7"]}
+
+{"query": "eino graph", "relevance": {"doc-3": 2, "doc-4": 1, "doc-1": 0}, "relevant_ids": ["doc-1"]}
+{"query": "eino callbacks"}
+`))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(len(samples), convey.ShouldEqual, 3)
+			convey.So(samples[0].ID, convey.ShouldEqual, "q1")
+			convey.So(samples[0].grades(), convey.ShouldResemble, map[string]float64{"doc-1": 1, "doc-7": 1})
+			convey.So(samples[1].ID, convey.ShouldEqual, "3")
+			convey.So(samples[1].grades(), convey.ShouldResemble, map[string]float64{"doc-3": 2, "doc-4": 1})
+			convey.So(samples[2].labelled(), convey.ShouldBeFalse)
+		})
+
+		convey.Convey("invalid json", func() {
+			_, err := LoadDataset(strings.NewReader("{"))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("query not provided", func() {
+			_, err := LoadDataset(strings.NewReader(`{"id": "q1"}`))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("file not found", func() {
+			_, err := LoadDatasetFile("not_exists.jsonl")
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evaluation
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// JSON returns the indented json of c.
+func (c *Comparison) JSON() ([]byte, error) {
+	return json.MarshalIndent(c, "", "  ")
+}
+
+// Markdown returns a table of the metrics and latencies with a column per system,
+// each value of the other systems is followed by its relative difference to the first one.
+func (c *Comparison) Markdown() string {
+	sb := &strings.Builder{}
+
+	sb.WriteString("| Metric |")
+	for _, r := range c.Reports {
+		sb.WriteString(" " + escapeCell(r.Name) + " |")
+	}
+	sb.WriteString("\n|---|")
+	for range c.Reports {
+		sb.WriteString("---:|")
+	}
+	sb.WriteString("\n")
+
+	row := func(name string, format string, value func(r *Report) float64) {
+		sb.WriteString("| " + name + " |")
+		base := value(c.Reports[0])
+		for i, r := range c.Reports {
+			v := value(r)
+			sb.WriteString(" " + fmt.Sprintf(format, v))
+			if i > 0 && base != 0 {
+				sb.WriteString(fmt.Sprintf(" (%+.1f%%)", (v-base)/base*100))
+			}
+			sb.WriteString(" |")
+		}
+		sb.WriteString("\n")
+	}
+
+	for _, k := range c.Ks {
+		row(fmt.Sprintf("Recall@%d", k), "%.3f", func(r *Report) float64 { return r.Metrics.Recall[k] })
+	}
+	for _, k := range c.Ks {
+		row(fmt.Sprintf("Precision@%d", k), "%.3f", func(r *Report) float64 { return r.Metrics.Precision[k] })
+	}
+	row("MRR", "%.3f", func(r *Report) float64 { return r.Metrics.MRR })
+	for _, k := range c.Ks {
+		row(fmt.Sprintf("nDCG@%d", k), "%.3f", func(r *Report) float64 { return r.Metrics.NDCG[k] })
+	}
+	row("Latency mean (ms)", "%.2f", func(r *Report) float64 { return r.Latency.Mean })
+	row("Latency p50 (ms)", "%.2f", func(r *Report) float64 { return r.Latency.P50 })
+	row("Latency p90 (ms)", "%.2f", func(r *Report) float64 { return r.Latency.P90 })
+	row("Latency p95 (ms)", "%.2f", func(r *Report) float64 { return r.Latency.P95 })
+	row("Latency p99 (ms)", "%.2f", func(r *Report) float64 { return r.Latency.P99 })
+	row("Latency max (ms)", "%.2f", func(r *Report) float64 { return r.Latency.Max })
+
+	sb.WriteString("| Evaluated / Skipped / Failed |")
+	for _, r := range c.Reports {
+		sb.WriteString(fmt.Sprintf(" %d / %d / %d |", r.Evaluated, r.Skipped, r.Failed))
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+func escapeCell(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"log"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+)
+
+func main() {
+	f := filter.And(
+		filter.Eq("source", "docs/intro.md"),
+		filter.In("lang", "en", "zh"),
+		filter.Range("page", filter.Bounds{Gte: 1, Lt: 10}),
+		filter.Not(filter.Eq("draft", true)),
+	)
+	if err := f.Validate(); err != nil {
+		log.Fatalf("Validate failed, err=%v", err)
+	}
+	log.Printf("filter: %s", f)
+
+	// pass it to any retriever supporting portable filters, e.g. es8, milvus, redis or volc_vikingdb:
+	// docs, err := r.Retrieve(ctx, "what is eino", filter.WithFilter(f))
+
+	// retrievers translate the filter with filter.Translate, which validates it first,
+	// and report operators their store can't express with filter.ErrUnsupported
+	_, _, err := filter.Translate(filter.Or(f, filter.Exists("author")), translate)
+	log.Printf("unsupported: %v, err=%v", errors.Is(err, filter.ErrUnsupported), err)
+
+	_, _, err = filter.Translate(filter.In("lang"), translate)
+	log.Printf("malformed: err=%v", err)
+}
+
+// translate is a toy translator of a store without exists.
+func translate(f *filter.Filter) (string, error) {
+	for _, child := range f.Children {
+		if _, err := translate(child); err != nil {
+			return "", err
+		}
+	}
+	if f.Op == filter.OpExists {
+		return "", filter.Unsupported("example", f, "")
+	}
+	return f.String(), nil
+}
@@ -0,0 +1,302 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Op is the operator of a Filter node.
+type Op string
+
+const (
+	// OpEq matches documents whose Field equals Value.
+	OpEq Op = "eq"
+	// OpNe matches documents whose Field doesn't equal Value.
+	OpNe Op = "ne"
+	// OpIn matches documents whose Field equals any of Values.
+	OpIn Op = "in"
+	// OpRange matches documents whose Field is within Bounds.
+	OpRange Op = "range"
+	// OpExists matches documents which have Field.
+	OpExists Op = "exists"
+	// OpAnd matches documents matching all of Children.
+	OpAnd Op = "and"
+	// OpOr matches documents matching any of Children.
+	OpOr Op = "or"
+	// OpNot matches documents not matching its only child.
+	OpNot Op = "not"
+)
+
+// Filter is a node of a metadata filter expression, which retrievers of this repository translate
+// to the filter language of their store. Build it with Eq, Ne, In, Range, Exists, And, Or and Not.
+//
+// Values are strings, bools, integers or floats. Field names are passed to the store as is,
+// except for milvus, where fields not in the collection are looked up in the metadata json field.
+type Filter struct {
+	Op    Op
+	Field string
+	// Value of OpEq and OpNe.
+	Value any
+	// Values of OpIn.
+	Values []any
+	// Bounds of OpRange.
+	Bounds *Bounds
+	// Children of OpAnd, OpOr and OpNot.
+	Children []*Filter
+}
+
+// Bounds of a range, nil bounds are open. Bounds are numbers, or strings for stores comparing them lexically.
+type Bounds struct {
+	Gt, Gte, Lt, Lte any
+}
+
+// Eq matches documents whose field equals value.
+func Eq(field string, value any) *Filter {
+	return &Filter{Op: OpEq, Field: field, Value: value}
+}
+
+// Ne matches documents whose field doesn't equal value.
+func Ne(field string, value any) *Filter {
+	return &Filter{Op: OpNe, Field: field, Value: value}
+}
+
+// In matches documents whose field equals any of values.
+func In(field string, values ...any) *Filter {
+	return &Filter{Op: OpIn, Field: field, Values: values}
+}
+
+// Range matches documents whose field is within bounds.
+func Range(field string, bounds Bounds) *Filter {
+	return &Filter{Op: OpRange, Field: field, Bounds: &bounds}
+}
+
+// Gt matches documents whose field is greater than value.
+func Gt(field string, value any) *Filter {
+	return Range(field, Bounds{Gt: value})
+}
+
+// Gte matches documents whose field is greater than or equal to value.
+func Gte(field string, value any) *Filter {
+	return Range(field, Bounds{Gte: value})
+}
+
+// Lt matches documents whose field is less than value.
+func Lt(field string, value any) *Filter {
+	return Range(field, Bounds{Lt: value})
+}
+
+// Lte matches documents whose field is less than or equal to value.
+func Lte(field string, value any) *Filter {
+	return Range(field, Bounds{Lte: value})
+}
+
+// Exists matches documents which have field.
+func Exists(field string) *Filter {
+	return &Filter{Op: OpExists, Field: field}
+}
+
+// And matches documents matching all of filters.
+func And(filters ...*Filter) *Filter {
+	return &Filter{Op: OpAnd, Children: filters}
+}
+
+// Or matches documents matching any of filters.
+func Or(filters ...*Filter) *Filter {
+	return &Filter{Op: OpOr, Children: filters}
+}
+
+// Not matches documents not matching f.
+func Not(f *Filter) *Filter {
+	return &Filter{Op: OpNot, Children: []*Filter{f}}
+}
+
+// Validate checks that f is well-formed: leaves have a field and scalar values, In has values,
+// Range has a bound, And / Or have children and Not has exactly one.
+func (f *Filter) Validate() error {
+	if f == nil {
+		return fmt.Errorf("[filter] nil filter")
+	}
+
+	switch f.Op {
+	case OpEq, OpNe:
+		if err := f.checkField(); err != nil {
+			return err
+		}
+		return f.checkValue(f.Value)
+	case OpIn:
+		if err := f.checkField(); err != nil {
+			return err
+		}
+		if len(f.Values) == 0 {
+			return fmt.Errorf("[filter] in on field %q has no values", f.Field)
+		}
+		for _, v := range f.Values {
+			if err := f.checkValue(v); err != nil {
+				return err
+			}
+		}
+		return nil
+	case OpRange:
+		if err := f.checkField(); err != nil {
+			return err
+		}
+		if f.Bounds == nil || (f.Bounds.Gt == nil && f.Bounds.Gte == nil && f.Bounds.Lt == nil && f.Bounds.Lte == nil) {
+			return fmt.Errorf("[filter] range on field %q has no bounds", f.Field)
+		}
+		if f.Bounds.Gt != nil && f.Bounds.Gte != nil || f.Bounds.Lt != nil && f.Bounds.Lte != nil {
+			return fmt.Errorf("[filter] range on field %q has both exclusive and inclusive bounds on one side", f.Field)
+		}
+		for _, v := range []any{f.Bounds.Gt, f.Bounds.Gte, f.Bounds.Lt, f.Bounds.Lte} {
+			if v == nil {
+				continue
+			}
+			if err := f.checkValue(v); err != nil {
+				return err
+			}
+		}
+		return nil
+	case OpExists:
+		return f.checkField()
+	case OpAnd, OpOr:
+		if len(f.Children) == 0 {
+			return fmt.Errorf("[filter] %s has no children", f.Op)
+		}
+	case OpNot:
+		if len(f.Children) != 1 {
+			return fmt.Errorf("[filter] not should have exactly 1 child, got=%d", len(f.Children))
+		}
+	default:
+		return fmt.Errorf("[filter] unknown operator %q", f.Op)
+	}
+
+	for _, child := range f.Children {
+		if err := child.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Filter) checkField() error {
+	if f.Field == "" {
+		return fmt.Errorf("[filter] %s has no field", f.Op)
+	}
+	return nil
+}
+
+func (f *Filter) checkValue(v any) error {
+	if !IsNumber(v) {
+		switch v.(type) {
+		case string, bool:
+		default:
+			return fmt.Errorf("[filter] %s on field %q has unsupported value type %T", f.Op, f.Field, v)
+		}
+	}
+	return nil
+}
+
+// String returns a readable form of f, e.g. and(eq(source, "a.md"), range(page, gte 1)), used in callbacks.
+func (f *Filter) String() string {
+	if f == nil {
+		return ""
+	}
+
+	switch f.Op {
+	case OpEq, OpNe:
+		return fmt.Sprintf("%s(%s, %#v)", f.Op, f.Field, f.Value)
+	case OpIn:
+		values := make([]string, 0, len(f.Values))
+		for _, v := range f.Values {
+			values = append(values, fmt.Sprintf("%#v", v))
+		}
+		return fmt.Sprintf("in(%s, [%s])", f.Field, strings.Join(values, ", "))
+	case OpRange:
+		var bounds []string
+		if f.Bounds != nil {
+			for _, b := range []struct {
+				name  string
+				value any
+			}{{"gt", f.Bounds.Gt}, {"gte", f.Bounds.Gte}, {"lt", f.Bounds.Lt}, {"lte", f.Bounds.Lte}} {
+				if b.value != nil {
+					bounds = append(bounds, fmt.Sprintf("%s %#v", b.name, b.value))
+				}
+			}
+		}
+		return fmt.Sprintf("range(%s, %s)", f.Field, strings.Join(bounds, ", "))
+	case OpExists:
+		return fmt.Sprintf("exists(%s)", f.Field)
+	default:
+		children := make([]string, 0, len(f.Children))
+		for _, child := range f.Children {
+			children = append(children, child.String())
+		}
+		return fmt.Sprintf("%s(%s)", f.Op, strings.Join(children, ", "))
+	}
+}
+
+// IsNumber reports whether v is an integer or a float.
+func IsNumber(v any) bool {
+	if v == nil {
+		return false
+	}
+	switch reflect.TypeOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// ErrUnsupported is matched by errors.Is for filters using an operator or a value a store can't express.
+var ErrUnsupported = errors.New("unsupported by store")
+
+// UnsupportedError reports a filter node a translator can't express.
+type UnsupportedError struct {
+	// Store is the name of the translator, e.g. "redis".
+	Store string
+	Op    Op
+	Field string
+	// Reason is optional detail, e.g. "range bounds must be numbers".
+	Reason string
+}
+
+// Unsupported returns an *UnsupportedError of node f.
+func Unsupported(store string, f *Filter, reason string) error {
+	return &UnsupportedError{Store: store, Op: f.Op, Field: f.Field, Reason: reason}
+}
+
+func (e *UnsupportedError) Error() string {
+	msg := fmt.Sprintf("[filter] operator %s", e.Op)
+	if e.Field != "" {
+		msg += fmt.Sprintf(" on field %q", e.Field)
+	}
+	msg += " is not supported by " + e.Store
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	return msg
+}
+
+func (e *UnsupportedError) Is(target error) bool {
+	return target == ErrUnsupported
+}
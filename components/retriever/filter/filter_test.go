@@ -0,0 +1,104 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	valid := And(
+		Eq("source", "a.md"),
+		Ne("draft", true),
+		In("lang", "en", "zh"),
+		Range("page", Bounds{Gte: 1, Lt: int64(10)}),
+		Or(Exists("author"), Not(Lte("score", 0.5))),
+	)
+	assert.NoError(t, valid.Validate())
+
+	for name, f := range map[string]*Filter{
+		"nil":           nil,
+		"no field":      Eq("", 1),
+		"bad value":     Eq("a", []int{1}),
+		"nil value":     Ne("a", nil),
+		"no values":     In("a"),
+		"no bounds":     Range("a", Bounds{}),
+		"both lower":    Range("a", Bounds{Gt: 1, Gte: 1}),
+		"empty and":     And(),
+		"not children":  {Op: OpNot},
+		"unknown":       {Op: "like", Field: "a"},
+		"invalid child": Or(Eq("a", 1), Exists("")),
+	} {
+		assert.Error(t, f.Validate(), name)
+	}
+}
+
+func TestString(t *testing.T) {
+	f := And(Eq("source", "a.md"), In("page", 1, 2), Range("score", Bounds{Gt: 0.5}), Not(Exists("draft")))
+	assert.Equal(t, `and(eq(source, "a.md"), in(page, [1, 2]), range(score, gt 0.5), not(exists(draft)))`, f.String())
+	assert.Equal(t, "", (*Filter)(nil).String())
+}
+
+func TestOption(t *testing.T) {
+	assert.Nil(t, GetFilter(retriever.WithTopK(1)))
+
+	f := Eq("source", "a.md")
+	assert.Equal(t, f, GetFilter(retriever.WithTopK(1), WithFilter(f)))
+}
+
+func TestTranslate(t *testing.T) {
+	translate := func(f *Filter) (string, error) {
+		if f.Op == OpExists {
+			return "", Unsupported("test", f, "")
+		}
+		return f.String(), nil
+	}
+
+	_, ok, err := Translate(nil, translate)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	expr, ok, err := Translate(Eq("a", 1), translate)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "eq(a, 1)", expr)
+
+	_, _, err = Translate(In("a"), translate)
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrUnsupported))
+
+	_, _, err = Translate(Exists("a"), translate)
+	assert.True(t, errors.Is(fmt.Errorf("wrapped: %w", err), ErrUnsupported))
+	assert.EqualError(t, err, `[filter] operator exists on field "a" is not supported by test`)
+
+	err = Unsupported("redis", Range("a", Bounds{Gt: "x"}), "range bounds must be numbers")
+	assert.EqualError(t, err, `[filter] operator range on field "a" is not supported by redis: range bounds must be numbers`)
+}
+
+func TestIsNumber(t *testing.T) {
+	for _, v := range []any{1, int8(1), uint64(1), float32(1), 1.5} {
+		assert.True(t, IsNumber(v))
+	}
+	for _, v := range []any{nil, "1", true, []int{1}} {
+		assert.False(t, IsNumber(v))
+	}
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package filter
+
+import (
+	"github.com/cloudwego/eino/components/retriever"
+)
+
+// Options carries the portable filter of a Retrieve call.
+type Options struct {
+	Filter *Filter
+}
+
+// WithFilter sets a portable metadata filter for Retrieve. Retrievers supporting it (es8, milvus, redis
+// and volc_vikingdb) translate it when Retrieve parses its options, and fail before searching if the filter
+// is malformed or uses an operator their store doesn't support. It's combined with the store specific filter
+// option, if both are set.
+func WithFilter(f *Filter) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *Options) {
+		o.Filter = f
+	})
+}
+
+// GetFilter returns the filter set by WithFilter, nil if absent.
+func GetFilter(opts ...retriever.Option) *Filter {
+	return retriever.GetImplSpecificOptions(&Options{}, opts...).Filter
+}
+
+// Translate validates f, then translates it with translate if it's not nil.
+// Retrievers call it on the result of GetFilter.
+func Translate[T any](f *Filter, translate func(f *Filter) (T, error)) (result T, ok bool, err error) {
+	if f == nil {
+		return result, false, nil
+	}
+	if err = f.Validate(); err != nil {
+		return result, false, err
+	}
+	result, err = translate(f)
+	if err != nil {
+		return result, false, err
+	}
+	return result, true, nil
+}
@@ -0,0 +1,44 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+const typ = "Graph"
+
+func GetType() string {
+	return typ
+}
+
+const (
+	defaultMaxSeeds = 10
+	defaultMaxHops  = 2
+	defaultMaxNodes = 50
+	defaultTopK     = 5
+
+	summaryID = "graph_summary"
+)
+
+const (
+	// MetaKeyEntityNames is the metadata key of the names of the visited entities a returned chunk mentions, []string.
+	MetaKeyEntityNames = "graph_entities"
+	// MetaKeyRelationSummaries is the metadata key of the followed relations a returned chunk states, []string,
+	// each like "Alice -[works at]-> Acme Corp: Alice joined Acme Corp in 2020".
+	MetaKeyRelationSummaries = "graph_relations"
+	// MetaKeyHops is the metadata key of the least number of hops from a seed entity to an entity a returned chunk mentions, int.
+	MetaKeyHops = "graph_hops"
+	// MetaKeySummary is the metadata key set to true on the summary document, see RetrieverConfig.SummaryDocument.
+	MetaKeySummary = "graph_summary"
+)
@@ -0,0 +1,95 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudwego/eino/schema"
+
+	indexer "github.com/cloudwego/eino-ext/components/indexer/graph"
+	"github.com/cloudwego/eino-ext/components/retriever/graph"
+)
+
+func main() {
+	ctx := context.Background()
+
+	path := os.Getenv("GRAPH_PATH")
+	if path == "" {
+		path = filepath.Join(os.TempDir(), "eino_graph.json")
+	}
+
+	store, err := indexer.NewMemoryStore(&indexer.MemoryStoreConfig{Path: path})
+	if err != nil {
+		log.Fatalf("NewMemoryStore failed, err=%v", err)
+	}
+
+	idx, err := indexer.NewIndexer(ctx, &indexer.IndexerConfig{Store: store})
+	if err != nil {
+		log.Fatalf("NewIndexer failed, err=%v", err)
+	}
+
+	// the metadata is usually written by the graph extractor,
+	// github.com/cloudwego/eino-ext/components/document/transformer/extractor/graph
+	_, err = idx.Upsert(ctx, []*schema.Document{
+		{
+			ID:      "people_1",
+			Content: "Alice works at Acme Corp as an engineer.",
+			MetaData: map[string]any{
+				"_source": "people.md",
+				indexer.MetaKeyRelations: []map[string]any{
+					{"source": "Alice", "target": "Acme Corp", "type": "works at"},
+				},
+			},
+		},
+		{
+			ID:      "companies_1",
+			Content: "Acme Corp is headquartered in Berlin.",
+			MetaData: map[string]any{
+				"_source": "companies.md",
+				indexer.MetaKeyRelations: []map[string]any{
+					{"source": "Acme Corp", "target": "Berlin", "type": "based in", "description": "headquarters"},
+				},
+			},
+		},
+	})
+	if err != nil {
+		log.Fatalf("Upsert failed, err=%v", err)
+	}
+
+	r, err := graph.NewRetriever(ctx, &graph.RetrieverConfig{
+		Store:           store,
+		MaxHops:         2,
+		TopK:            5,
+		SummaryDocument: true,
+	})
+	if err != nil {
+		log.Fatalf("NewRetriever failed, err=%v", err)
+	}
+
+	// the chunk about berlin doesn't mention alice, it's reached through acme corp
+	docs, err := r.Retrieve(ctx, "In which city does Alice work?")
+	if err != nil {
+		log.Fatalf("Retrieve failed, err=%v", err)
+	}
+	for _, doc := range docs {
+		log.Printf("id=%s, score=%.4f, hops=%v, content=%q", doc.ID, doc.Score(), doc.MetaData[graph.MetaKeyHops], doc.Content)
+	}
+}
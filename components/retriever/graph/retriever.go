@@ -0,0 +1,256 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/indexer/graph"
+)
+
+type RetrieverConfig struct {
+	// Store is the graph written by the graph indexer of github.com/cloudwego/eino-ext/components/indexer/graph,
+	// e.g. a graph.MemoryStore.
+	// Required.
+	Store graph.Store
+	// ExtractEntities returns the names of the entities in the query, e.g. asked from a chat model, which are looked up
+	// by graph.NodeKey, in addition to the entities whose names the query mentions, found by Store.MatchNodes.
+	// Default nil, only the entities the query mentions are found.
+	ExtractEntities func(ctx context.Context, query string) ([]string, error)
+	// MaxSeeds limits the number of entities found in the query, which the traversal starts from.
+	// Default 10.
+	MaxSeeds int
+	// MaxHops is the number of relations followed from the seed entities,
+	// negative follows none and returns the chunks mentioning the seed entities only.
+	// Default 2.
+	MaxHops int
+	// MaxNodes limits the number of entities visited, including the seeds,
+	// relations stated by more chunks are followed first.
+	// Default 50.
+	MaxNodes int
+	// TopK limits number of results given, not counting the summary document.
+	// Default 5.
+	TopK int
+	// ScoreThreshold drops chunks with lower score, see Retrieve for the score.
+	// Default nil.
+	ScoreThreshold *float64
+	// SummaryDocument prepends a document listing every relation followed, one per line, to the results,
+	// which gives the model the paths between entities that no single chunk states, for multi-hop questions.
+	// Default false.
+	SummaryDocument bool
+}
+
+// Retriever finds the entities of the query in a knowledge graph, expands them by relations up to MaxHops,
+// and returns the chunks mentioning the visited entities and stating the followed relations.
+type Retriever struct {
+	config *RetrieverConfig
+}
+
+func NewRetriever(_ context.Context, config *RetrieverConfig) (*Retriever, error) {
+	if config.Store == nil {
+		return nil, fmt.Errorf("[NewRetriever] store not provided")
+	}
+
+	if config.MaxSeeds < 0 || config.MaxNodes < 0 {
+		return nil, fmt.Errorf("[NewRetriever] invalid limits, max seeds=%d, max nodes=%d", config.MaxSeeds, config.MaxNodes)
+	}
+
+	if config.MaxSeeds == 0 {
+		config.MaxSeeds = defaultMaxSeeds
+	}
+
+	if config.MaxHops == 0 {
+		config.MaxHops = defaultMaxHops
+	}
+
+	if config.MaxNodes == 0 {
+		config.MaxNodes = defaultMaxNodes
+	}
+
+	if config.TopK == 0 {
+		config.TopK = defaultTopK
+	}
+
+	return &Retriever{
+		config: config,
+	}, nil
+}
+
+// Retrieve scores each chunk by the entities it mentions and the relations it states in the visited subgraph,
+// an entity or relation h hops away from the seeds adds 1/(1+h), so chunks about the query entities rank first,
+// and chunks connecting many visited entities rank above chunks mentioning one of them.
+func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) (docs []*schema.Document, err error) {
+	co := retriever.GetCommonOptions(&retriever.Options{
+		TopK:           &r.config.TopK,
+		ScoreThreshold: r.config.ScoreThreshold,
+	}, opts...)
+	io := retriever.GetImplSpecificOptions(&implOptions{MaxHops: r.config.MaxHops}, opts...)
+
+	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
+	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
+		Query:          query,
+		TopK:           *co.TopK,
+		ScoreThreshold: co.ScoreThreshold,
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	seeds, err := r.seeds(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("[graph retriever] %w", err)
+	}
+
+	sg, err := r.traverse(ctx, seeds, io.MaxHops)
+	if err != nil {
+		return nil, fmt.Errorf("[graph retriever] %w", err)
+	}
+
+	docs, err = r.rank(ctx, sg, *co.TopK, co.ScoreThreshold)
+	if err != nil {
+		return nil, fmt.Errorf("[graph retriever] %w", err)
+	}
+
+	if r.config.SummaryDocument && len(sg.edges) > 0 {
+		docs = append([]*schema.Document{sg.summary(docs)}, docs...)
+	}
+
+	callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
+
+	return docs, nil
+}
+
+func (r *Retriever) GetType() string {
+	return typ
+}
+
+func (r *Retriever) IsCallbacksEnabled() bool {
+	return true
+}
+
+// seeds returns the entities extracted from the query first, then the entities the query mentions, up to MaxSeeds.
+func (r *Retriever) seeds(ctx context.Context, query string) ([]*graph.Node, error) {
+	var nodes []*graph.Node
+
+	if r.config.ExtractEntities != nil {
+		names, err := r.config.ExtractEntities(ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("extract entities failed, %w", err)
+		}
+
+		keys := make([]string, 0, len(names))
+		for _, name := range names {
+			if key := graph.NodeKey(name); key != "" {
+				keys = append(keys, key)
+			}
+		}
+
+		if len(keys) > 0 {
+			if nodes, err = r.config.Store.GetNodes(ctx, keys); err != nil {
+				return nil, fmt.Errorf("get nodes failed, %w", err)
+			}
+		}
+	}
+
+	matched, err := r.config.Store.MatchNodes(ctx, query, r.config.MaxSeeds)
+	if err != nil {
+		return nil, fmt.Errorf("match nodes failed, %w", err)
+	}
+
+	seeds := make([]*graph.Node, 0, r.config.MaxSeeds)
+	seen := make(map[string]struct{})
+	for _, node := range append(nodes, matched...) {
+		if len(seeds) == r.config.MaxSeeds {
+			break
+		}
+		if _, found := seen[node.Key]; found {
+			continue
+		}
+		seen[node.Key] = struct{}{}
+		seeds = append(seeds, node)
+	}
+
+	return seeds, nil
+}
+
+// rank scores the chunks of sg, and returns the topK of them scoring at least threshold, best first.
+func (r *Retriever) rank(ctx context.Context, sg *subgraph, topK int, threshold *float64) ([]*schema.Document, error) {
+	scores := make(map[string]float64)
+	hops := make(map[string]int)
+	for _, key := range sg.order {
+		h := sg.hops[key]
+		for _, id := range sg.nodes[key].ChunkIDs {
+			scores[id] += 1 / float64(1+h)
+			if prev, found := hops[id]; !found || h < prev {
+				hops[id] = h
+			}
+		}
+	}
+	for _, e := range sg.edges {
+		h := max(sg.hops[e.Source], sg.hops[e.Target])
+		for _, id := range e.ChunkIDs {
+			scores[id] += 1 / float64(1+h)
+		}
+	}
+
+	ids := make([]string, 0, len(scores))
+	for id, score := range scores {
+		if threshold == nil || score >= *threshold {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if scores[ids[i]] != scores[ids[j]] {
+			return scores[ids[i]] > scores[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	if topK > 0 && len(ids) > topK {
+		ids = ids[:topK]
+	}
+	if len(ids) == 0 {
+		return []*schema.Document{}, nil
+	}
+
+	chunks, err := r.config.Store.GetChunks(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("get chunks failed, %w", err)
+	}
+
+	docs := make([]*schema.Document, 0, len(chunks))
+	for _, chunk := range chunks {
+		doc := &schema.Document{ID: chunk.ID, Content: chunk.Content, MetaData: make(map[string]any, len(chunk.MetaData)+4)}
+		for k, v := range chunk.MetaData {
+			doc.MetaData[k] = v
+		}
+		doc.MetaData[MetaKeyEntityNames] = sg.entityNames(chunk.ID)
+		doc.MetaData[MetaKeyRelationSummaries] = sg.relationSummaries(chunk.ID)
+		doc.MetaData[MetaKeyHops] = hops[chunk.ID]
+		docs = append(docs, doc.WithScore(scores[chunk.ID]))
+	}
+
+	return docs, nil
+}
@@ -0,0 +1,264 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/indexer/graph"
+)
+
+func chunk(id, content string, entities []map[string]any, relations []map[string]any) *schema.Document {
+	return &schema.Document{ID: id, Content: content, MetaData: map[string]any{
+		"_source":              id + ".md",
+		graph.MetaKeyEntities:  entities,
+		graph.MetaKeyRelations: relations,
+	}}
+}
+
+func newStore(ctx context.Context) (*graph.MemoryStore, error) {
+	store, err := graph.NewMemoryStore(nil)
+	if err != nil {
+		return nil, err
+	}
+	idx, err := graph.NewIndexer(ctx, &graph.IndexerConfig{Store: store})
+	if err != nil {
+		return nil, err
+	}
+	_, err = idx.Store(ctx, []*schema.Document{
+		chunk("c1", "Alice works at Acme Corp.",
+			[]map[string]any{{"name": "Alice", "type": "person"}},
+			[]map[string]any{{"source": "Alice", "target": "Acme Corp", "type": "works at", "description": "Alice joined in 2020"}}),
+		chunk("c2", "Acme Corp is based in Berlin.", nil,
+			[]map[string]any{{"source": "Acme Corp", "target": "Berlin", "type": "based in"}}),
+		chunk("c3", "Berlin is the capital of Germany.", nil,
+			[]map[string]any{{"source": "Berlin", "target": "Germany", "type": "capital of"}}),
+		chunk("c4", "Bob likes tea.", []map[string]any{{"name": "Bob", "type": "person"}}, nil),
+	})
+	return store, err
+}
+
+// failingStore fails the method named by fail.
+type failingStore struct {
+	graph.Store
+	fail string
+}
+
+func (f *failingStore) GetChunks(ctx context.Context, ids []string) ([]*schema.Document, error) {
+	if f.fail == "GetChunks" {
+		return nil, errors.New("mock")
+	}
+	return f.Store.GetChunks(ctx, ids)
+}
+
+func (f *failingStore) GetNodes(ctx context.Context, keys []string) ([]*graph.Node, error) {
+	if f.fail == "GetNodes" {
+		return nil, errors.New("mock")
+	}
+	return f.Store.GetNodes(ctx, keys)
+}
+
+func (f *failingStore) MatchNodes(ctx context.Context, text string, limit int) ([]*graph.Node, error) {
+	if f.fail == "MatchNodes" {
+		return nil, errors.New("mock")
+	}
+	return f.Store.MatchNodes(ctx, text, limit)
+}
+
+func (f *failingStore) GetEdges(ctx context.Context, keys []string) ([]*graph.Edge, error) {
+	if f.fail == "GetEdges" {
+		return nil, errors.New("mock")
+	}
+	return f.Store.GetEdges(ctx, keys)
+}
+
+func docIDs(docs []*schema.Document) []string {
+	ids := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+	return ids
+}
+
+func TestRetriever(t *testing.T) {
+	convey.Convey("test graph retriever", t, func() {
+		ctx := context.Background()
+		store, err := newStore(ctx)
+		convey.So(err, convey.ShouldBeNil)
+
+		_, err = NewRetriever(ctx, &RetrieverConfig{})
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = NewRetriever(ctx, &RetrieverConfig{Store: store, MaxNodes: -1})
+		convey.So(err, convey.ShouldNotBeNil)
+
+		r, err := NewRetriever(ctx, &RetrieverConfig{Store: store})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(r.config.MaxSeeds, convey.ShouldEqual, defaultMaxSeeds)
+		convey.So(r.config.MaxHops, convey.ShouldEqual, defaultMaxHops)
+		convey.So(r.config.MaxNodes, convey.ShouldEqual, defaultMaxNodes)
+		convey.So(r.config.TopK, convey.ShouldEqual, defaultTopK)
+		convey.So(r.GetType(), convey.ShouldEqual, typ)
+		convey.So(r.IsCallbacksEnabled(), convey.ShouldBeTrue)
+
+		convey.Convey("test retrieve", func() {
+			var input *retriever.CallbackInput
+			var output *retriever.CallbackOutput
+			ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, callbacks.NewHandlerBuilder().
+				OnStartFn(func(ctx context.Context, info *callbacks.RunInfo, in callbacks.CallbackInput) context.Context {
+					input = retriever.ConvCallbackInput(in)
+					return ctx
+				}).
+				OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, out callbacks.CallbackOutput) context.Context {
+					output = retriever.ConvCallbackOutput(out)
+					return ctx
+				}).Build())
+
+			docs, err := r.Retrieve(ctx, "Where does Alice work?")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(input.Query, convey.ShouldEqual, "Where does Alice work?")
+			convey.So(input.TopK, convey.ShouldEqual, defaultTopK)
+			convey.So(len(output.Docs), convey.ShouldEqual, 3)
+
+			// alice is the seed, acme corp 1 hop and berlin 2 hops away, germany isn't reached
+			convey.So(docIDs(docs), convey.ShouldResemble, []string{"c1", "c2", "c3"})
+			convey.So(docs[0].Score(), convey.ShouldAlmostEqual, 2.0)
+			convey.So(docs[1].Score(), convey.ShouldAlmostEqual, 0.5+1.0/3+1.0/3)
+			convey.So(docs[2].Score(), convey.ShouldAlmostEqual, 1.0/3)
+
+			convey.So(docs[0].Content, convey.ShouldEqual, "Alice works at Acme Corp.")
+			convey.So(docs[0].MetaData["_source"], convey.ShouldEqual, "c1.md")
+			convey.So(docs[0].MetaData[MetaKeyEntityNames], convey.ShouldResemble, []string{"Alice", "Acme Corp"})
+			convey.So(docs[0].MetaData[MetaKeyRelationSummaries], convey.ShouldResemble,
+				[]string{"Alice -[works at]-> Acme Corp: Alice joined in 2020"})
+			convey.So(docs[0].MetaData[MetaKeyHops], convey.ShouldEqual, 0)
+			convey.So(docs[1].MetaData[MetaKeyRelationSummaries], convey.ShouldResemble, []string{"Acme Corp -[based in]-> Berlin"})
+			convey.So(docs[1].MetaData[MetaKeyHops], convey.ShouldEqual, 1)
+			convey.So(docs[2].MetaData[MetaKeyEntityNames], convey.ShouldResemble, []string{"Berlin"})
+			convey.So(docs[2].MetaData[MetaKeyRelationSummaries], convey.ShouldResemble, []string{})
+			convey.So(docs[2].MetaData[MetaKeyHops], convey.ShouldEqual, 2)
+
+			// the stored chunks aren't changed
+			stored, err := store.GetChunks(ctx, []string{"c1"})
+			convey.So(err, convey.ShouldBeNil)
+			_, found := stored[0].MetaData[MetaKeyEntityNames]
+			convey.So(found, convey.ShouldBeFalse)
+
+			docs, err = r.Retrieve(ctx, "Where does Alice work?", WithMaxHops(0))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(docIDs(docs), convey.ShouldResemble, []string{"c1"})
+			convey.So(docs[0].Score(), convey.ShouldAlmostEqual, 1.0)
+
+			docs, err = r.Retrieve(ctx, "Where does Alice work?", WithMaxHops(3))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(docIDs(docs), convey.ShouldResemble, []string{"c1", "c2", "c3"})
+			convey.So(docs[2].Score(), convey.ShouldAlmostEqual, 1.0/3+1.0/4+1.0/4)
+
+			docs, err = r.Retrieve(ctx, "Where does Alice work?", retriever.WithTopK(1))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(docIDs(docs), convey.ShouldResemble, []string{"c1"})
+
+			docs, err = r.Retrieve(ctx, "Where does Alice work?", retriever.WithScoreThreshold(1))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(docIDs(docs), convey.ShouldResemble, []string{"c1", "c2"})
+
+			docs, err = r.Retrieve(ctx, "Who is Carol?")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(docs, convey.ShouldResemble, []*schema.Document{})
+		})
+
+		convey.Convey("test limits", func() {
+			r, err := NewRetriever(ctx, &RetrieverConfig{Store: store, MaxSeeds: 1, MaxNodes: 2})
+			convey.So(err, convey.ShouldBeNil)
+
+			// acme corp is the longer name of the two mentioned, the relation to berlin is visited before the one to alice
+			// by the order of edge keys, and then alice is out of MaxNodes
+			docs, err := r.Retrieve(ctx, "Does Alice work at Acme Corp?")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(docIDs(docs), convey.ShouldResemble, []string{"c2", "c1", "c3"})
+			convey.So(docs[0].Score(), convey.ShouldAlmostEqual, 2.0)
+			convey.So(docs[1].MetaData[MetaKeyEntityNames], convey.ShouldResemble, []string{"Acme Corp"})
+			convey.So(docs[1].MetaData[MetaKeyRelationSummaries], convey.ShouldResemble, []string{})
+			convey.So(docs[2].MetaData[MetaKeyHops], convey.ShouldEqual, 1)
+
+			r, err = NewRetriever(ctx, &RetrieverConfig{Store: store, MaxHops: -1})
+			convey.So(err, convey.ShouldBeNil)
+			docs, err = r.Retrieve(ctx, "Berlin")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(docIDs(docs), convey.ShouldResemble, []string{"c2", "c3"})
+		})
+
+		convey.Convey("test extract entities", func() {
+			r, err := NewRetriever(ctx, &RetrieverConfig{
+				Store: store,
+				ExtractEntities: func(ctx context.Context, query string) ([]string, error) {
+					if query == "error" {
+						return nil, errors.New("mock")
+					}
+					return []string{" BOB ", "", "Carol"}, nil
+				},
+			})
+			convey.So(err, convey.ShouldBeNil)
+
+			docs, err := r.Retrieve(ctx, "Who likes tea?")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(docIDs(docs), convey.ShouldResemble, []string{"c4"})
+
+			_, err = r.Retrieve(ctx, "error")
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test summary document", func() {
+			r, err := NewRetriever(ctx, &RetrieverConfig{Store: store, SummaryDocument: true, TopK: 1})
+			convey.So(err, convey.ShouldBeNil)
+
+			docs, err := r.Retrieve(ctx, "Where does Alice work?")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(docIDs(docs), convey.ShouldResemble, []string{summaryID, "c1"})
+			convey.So(docs[0].Content, convey.ShouldEqual,
+				"Relations:\nAlice -[works at]-> Acme Corp: Alice joined in 2020\nAcme Corp -[based in]-> Berlin")
+			convey.So(docs[0].Score(), convey.ShouldAlmostEqual, docs[1].Score())
+			convey.So(docs[0].MetaData[MetaKeySummary], convey.ShouldBeTrue)
+			convey.So(docs[0].MetaData[MetaKeyEntityNames], convey.ShouldResemble, []string{"Alice", "Acme Corp", "Berlin"})
+
+			// no relation is followed
+			docs, err = r.Retrieve(ctx, "Bob")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(docIDs(docs), convey.ShouldResemble, []string{"c4"})
+		})
+
+		convey.Convey("test store failures", func() {
+			for _, fail := range []string{"MatchNodes", "GetEdges", "GetNodes", "GetChunks"} {
+				r, err := NewRetriever(ctx, &RetrieverConfig{
+					Store: &failingStore{Store: store, fail: fail},
+					ExtractEntities: func(ctx context.Context, query string) ([]string, error) {
+						return []string{"Alice"}, nil
+					},
+				})
+				convey.So(err, convey.ShouldBeNil)
+				_, err = r.Retrieve(ctx, "Where does Alice work?")
+				convey.So(err, convey.ShouldNotBeNil)
+			}
+		})
+	})
+}
@@ -0,0 +1,201 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/indexer/graph"
+)
+
+// subgraph is the part of the graph visited by a traversal.
+type subgraph struct {
+	nodes map[string]*graph.Node
+	// hops is the number of relations from the nearest seed to each node
+	hops map[string]int
+	// order of the nodes by hops, then by visit
+	order []string
+	edges []*graph.Edge
+}
+
+// traverse visits the seeds, then the neighbors of the nodes visited last for each hop up to maxHops,
+// following the relations stated by more chunks first, until MaxNodes nodes are visited.
+// A relation is kept if both of its nodes are visited.
+func (r *Retriever) traverse(ctx context.Context, seeds []*graph.Node, maxHops int) (*subgraph, error) {
+	sg := &subgraph{
+		nodes: make(map[string]*graph.Node),
+		hops:  make(map[string]int),
+	}
+	for _, node := range seeds {
+		if len(sg.order) == r.config.MaxNodes {
+			break
+		}
+		sg.visit(node, 0)
+	}
+
+	seenEdges := make(map[string]struct{})
+	frontier := sg.order
+	for h := 1; h <= maxHops && len(frontier) > 0; h++ {
+		edges, err := r.config.Store.GetEdges(ctx, frontier)
+		if err != nil {
+			return nil, fmt.Errorf("get edges failed, %w", err)
+		}
+
+		var candidates []*graph.Edge
+		for _, e := range edges {
+			if _, found := seenEdges[edgeKey(e)]; !found {
+				candidates = append(candidates, e)
+			}
+		}
+		sort.SliceStable(candidates, func(i, j int) bool {
+			if len(candidates[i].ChunkIDs) != len(candidates[j].ChunkIDs) {
+				return len(candidates[i].ChunkIDs) > len(candidates[j].ChunkIDs)
+			}
+			return edgeKey(candidates[i]) < edgeKey(candidates[j])
+		})
+
+		// pick the new nodes within MaxNodes in order of the relations leading to them
+		var keys []string
+		picked := make(map[string]struct{})
+		for _, e := range candidates {
+			for _, key := range []string{e.Source, e.Target} {
+				if _, found := sg.nodes[key]; found {
+					continue
+				}
+				if _, found := picked[key]; found {
+					continue
+				}
+				if len(sg.order)+len(keys) == r.config.MaxNodes {
+					continue
+				}
+				picked[key] = struct{}{}
+				keys = append(keys, key)
+			}
+		}
+
+		frontier = nil
+		if len(keys) > 0 {
+			nodes, err := r.config.Store.GetNodes(ctx, keys)
+			if err != nil {
+				return nil, fmt.Errorf("get nodes failed, %w", err)
+			}
+			for _, node := range nodes {
+				sg.visit(node, h)
+				frontier = append(frontier, node.Key)
+			}
+		}
+
+		for _, e := range candidates {
+			_, sourceFound := sg.nodes[e.Source]
+			_, targetFound := sg.nodes[e.Target]
+			if sourceFound && targetFound {
+				seenEdges[edgeKey(e)] = struct{}{}
+				sg.edges = append(sg.edges, e)
+			}
+		}
+	}
+
+	return sg, nil
+}
+
+func (sg *subgraph) visit(node *graph.Node, hops int) {
+	if _, found := sg.nodes[node.Key]; found {
+		return
+	}
+	sg.nodes[node.Key] = node
+	sg.hops[node.Key] = hops
+	sg.order = append(sg.order, node.Key)
+}
+
+// entityNames returns the names of the visited nodes mentioned by chunk id, nearest first.
+func (sg *subgraph) entityNames(id string) []string {
+	names := make([]string, 0)
+	for _, key := range sg.order {
+		if containsString(sg.nodes[key].ChunkIDs, id) {
+			names = append(names, sg.nodes[key].Name)
+		}
+	}
+	return names
+}
+
+// relationSummaries returns the summaries of the followed relations stated by chunk id.
+func (sg *subgraph) relationSummaries(id string) []string {
+	summaries := make([]string, 0)
+	for _, e := range sg.edges {
+		if containsString(e.ChunkIDs, id) {
+			summaries = append(summaries, sg.relationSummary(e))
+		}
+	}
+	return summaries
+}
+
+// relationSummary formats e like "Alice -[works at]-> Acme Corp: description 1; description 2".
+func (sg *subgraph) relationSummary(e *graph.Edge) string {
+	s := fmt.Sprintf("%s -[%s]-> %s", sg.nodes[e.Source].Name, e.Type, sg.nodes[e.Target].Name)
+	if len(e.Descriptions) > 0 {
+		s += ": " + strings.Join(e.Descriptions, "; ")
+	}
+	return s
+}
+
+// summary returns the summary document of the followed relations, scored as the best of docs.
+func (sg *subgraph) summary(docs []*schema.Document) *schema.Document {
+	lines := make([]string, 0, len(sg.edges))
+	for _, e := range sg.edges {
+		lines = append(lines, sg.relationSummary(e))
+	}
+
+	names := make([]string, 0, len(sg.order))
+	for _, key := range sg.order {
+		names = append(names, sg.nodes[key].Name)
+	}
+
+	var score float64
+	if len(docs) > 0 {
+		score = docs[0].Score()
+	}
+
+	doc := &schema.Document{
+		ID:      summaryID,
+		Content: "Relations:\n" + strings.Join(lines, "\n"),
+		MetaData: map[string]any{
+			MetaKeySummary:           true,
+			MetaKeyEntityNames:       names,
+			MetaKeyRelationSummaries: lines,
+		},
+	}
+	return doc.WithScore(score)
+}
+
+// edgeKey identifies e by its nodes and normalized type, same as the edges of graph.MemoryStore.
+func edgeKey(e *graph.Edge) string {
+	return e.Source + "\x00" + graph.NodeKey(e.Type) + "\x00" + e.Target
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+
+	indexer "github.com/cloudwego/eino-ext/components/indexer/memory"
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+	"github.com/cloudwego/eino-ext/components/retriever/memory"
+)
+
+func main() {
+	ctx := context.Background()
+	emb := &letterEmbedding{} // replace with a real embedder, e.g. ark or openai
+
+	store, err := indexer.NewStore(&indexer.StoreConfig{Metric: indexer.MetricCosine})
+	if err != nil {
+		log.Fatalf("NewStore failed, err=%v", err)
+	}
+
+	idx, err := indexer.NewIndexer(ctx, &indexer.IndexerConfig{Store: store, Embedding: emb})
+	if err != nil {
+		log.Fatalf("NewIndexer failed, err=%v", err)
+	}
+	if _, err = idx.Store(ctx, []*schema.Document{
+		{ID: "1", Content: "eino is a llm application framework", MetaData: map[string]any{"lang": "en", "page": 1}},
+		{ID: "2", Content: "eino components are composed by graphs", MetaData: map[string]any{"lang": "en", "page": 2}},
+		{ID: "3", Content: "eino 是一个大模型应用开发框架", MetaData: map[string]any{"lang": "zh", "page": 1}},
+	}); err != nil {
+		log.Fatalf("Store failed, err=%v", err)
+	}
+
+	r, err := memory.NewRetriever(ctx, &memory.RetrieverConfig{
+		Store:     store,
+		Embedding: emb,
+		TopK:      2,
+	})
+	if err != nil {
+		log.Fatalf("NewRetriever failed, err=%v", err)
+	}
+
+	docs, err := r.Retrieve(ctx, "what is eino framework",
+		retriever.WithScoreThreshold(0.1),
+		filter.WithFilter(filter.And(filter.Eq("lang", "en"), filter.Lte("page", 2))),
+	)
+	if err != nil {
+		log.Fatalf("Retrieve failed, err=%v", err)
+	}
+	for _, doc := range docs {
+		log.Printf("id=%s, score=%.3f, content=%s", doc.ID, doc.Score(), doc.Content)
+	}
+}
+
+// letterEmbedding embeds a text to its letter frequencies, only for the example.
+type letterEmbedding struct{}
+
+func (l *letterEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = make([]float64, 26)
+		for _, c := range strings.ToLower(text) {
+			if c >= 'a' && c <= 'z' {
+				vectors[i][c-'a']++
+			}
+		}
+	}
+	return vectors, nil
+}
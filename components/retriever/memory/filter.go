@@ -0,0 +1,178 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"reflect"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+)
+
+type matcher func(doc *schema.Document) bool
+
+// compileFilter compiles f to a predicate on document metadata, supporting every operator of the filter package.
+// Numbers are compared numerically regardless of their types, and range bounds are compared
+// numerically with numbers or lexically with strings.
+func compileFilter(f *filter.Filter) (func(doc *schema.Document) bool, error) {
+	return compile(f), nil
+}
+
+func compile(f *filter.Filter) matcher {
+	switch f.Op {
+	case filter.OpEq:
+		return func(doc *schema.Document) bool {
+			v, ok := doc.MetaData[f.Field]
+			return ok && valueEqual(v, f.Value)
+		}
+	case filter.OpNe:
+		return func(doc *schema.Document) bool {
+			v, ok := doc.MetaData[f.Field]
+			return !ok || !valueEqual(v, f.Value)
+		}
+	case filter.OpIn:
+		return func(doc *schema.Document) bool {
+			v, ok := doc.MetaData[f.Field]
+			if !ok {
+				return false
+			}
+			for _, value := range f.Values {
+				if valueEqual(v, value) {
+					return true
+				}
+			}
+			return false
+		}
+	case filter.OpRange:
+		b := f.Bounds
+		return func(doc *schema.Document) bool {
+			v, ok := doc.MetaData[f.Field]
+			if !ok {
+				return false
+			}
+			return checkBound(v, b.Gt, func(c int) bool { return c > 0 }) &&
+				checkBound(v, b.Gte, func(c int) bool { return c >= 0 }) &&
+				checkBound(v, b.Lt, func(c int) bool { return c < 0 }) &&
+				checkBound(v, b.Lte, func(c int) bool { return c <= 0 })
+		}
+	case filter.OpExists:
+		return func(doc *schema.Document) bool {
+			_, ok := doc.MetaData[f.Field]
+			return ok
+		}
+	case filter.OpAnd:
+		children := compileChildren(f.Children)
+		return func(doc *schema.Document) bool {
+			for _, child := range children {
+				if !child(doc) {
+					return false
+				}
+			}
+			return true
+		}
+	case filter.OpOr:
+		children := compileChildren(f.Children)
+		return func(doc *schema.Document) bool {
+			for _, child := range children {
+				if child(doc) {
+					return true
+				}
+			}
+			return false
+		}
+	default: // filter.OpNot, validated to have one child
+		child := compile(f.Children[0])
+		return func(doc *schema.Document) bool {
+			return !child(doc)
+		}
+	}
+}
+
+func compileChildren(filters []*filter.Filter) []matcher {
+	children := make([]matcher, 0, len(filters))
+	for _, f := range filters {
+		children = append(children, compile(f))
+	}
+	return children
+}
+
+// checkBound reports whether v compared to bound satisfies ok, or true if bound is nil.
+// Values not comparable to bound never satisfy it.
+func checkBound(v, bound any, ok func(c int) bool) bool {
+	if bound == nil {
+		return true
+	}
+	c, comparable := compare(v, bound)
+	return comparable && ok(c)
+}
+
+func compare(a, b any) (int, bool) {
+	if fa, ok := toFloat(a); ok {
+		fb, ok := toFloat(b)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case fa < fb:
+			return -1, true
+		case fa > fb:
+			return 1, true
+		default:
+			return 0, true
+		}
+	}
+
+	sa, ok := a.(string)
+	if !ok {
+		return 0, false
+	}
+	sb, ok := b.(string)
+	if !ok {
+		return 0, false
+	}
+	switch {
+	case sa < sb:
+		return -1, true
+	case sa > sb:
+		return 1, true
+	default:
+		return 0, true
+	}
+}
+
+func valueEqual(a, b any) bool {
+	if fa, ok := toFloat(a); ok {
+		fb, ok := toFloat(b)
+		return ok && fa == fb
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func toFloat(v any) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/indexer/memory"
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+)
+
+type RetrieverConfig struct {
+	// Store is the in-memory vector store written by the memory indexer.
+	// Required
+	Store *memory.Store
+	// Embedding vectorization method for query.
+	// Required
+	Embedding embedding.Embedder
+	// TopK limits number of results given.
+	// Optional, and the default value is 5
+	TopK int
+	// ScoreThreshold drops documents scored lower, see memory.Metric for the score of each metric.
+	// Optional, and the default value is nil(no threshold)
+	ScoreThreshold *float64
+}
+
+type Retriever struct {
+	config *RetrieverConfig
+}
+
+func NewRetriever(_ context.Context, config *RetrieverConfig) (*Retriever, error) {
+	if config.Store == nil {
+		return nil, fmt.Errorf("[NewRetriever] store not provided for memory retriever")
+	}
+
+	if config.Embedding == nil {
+		return nil, fmt.Errorf("[NewRetriever] embedding not provided for memory retriever")
+	}
+
+	if config.TopK == 0 {
+		config.TopK = defaultTopK
+	}
+
+	return &Retriever{
+		config: config,
+	}, nil
+}
+
+func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) (docs []*schema.Document, err error) {
+	co := retriever.GetCommonOptions(&retriever.Options{
+		TopK:           &r.config.TopK,
+		ScoreThreshold: r.config.ScoreThreshold,
+		Embedding:      r.config.Embedding,
+	}, opts...)
+	io := retriever.GetImplSpecificOptions(&implOptions{}, opts...)
+	f := filter.GetFilter(opts...)
+	match, _, err := filter.Translate(f, compileFilter)
+
+	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
+	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
+		Query:          query,
+		TopK:           *co.TopK,
+		Filter:         f.String(),
+		ScoreThreshold: co.ScoreThreshold,
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	if err != nil {
+		return nil, fmt.Errorf("[memory retriever] invalid filter: %w", err)
+	}
+
+	emb := co.Embedding
+	if emb == nil {
+		return nil, fmt.Errorf("[memory retriever] embedding not provided")
+	}
+
+	vectors, err := emb.EmbedStrings(r.makeEmbeddingCtx(ctx, emb), []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("[memory retriever] embedding has error: %w", err)
+	}
+
+	if len(vectors) != 1 {
+		return nil, fmt.Errorf("[memory retriever] invalid return length of vector, got=%d, expected=1", len(vectors))
+	}
+
+	accept := func(doc *schema.Document) bool {
+		return (match == nil || match(doc)) && (io.FilterFunc == nil || io.FilterFunc(doc))
+	}
+
+	hits, err := r.config.Store.Search(vectors[0], *co.TopK, accept)
+	if err != nil {
+		return nil, fmt.Errorf("[memory retriever] search has error: %w", err)
+	}
+
+	docs = make([]*schema.Document, 0, len(hits))
+	for _, hit := range hits {
+		if co.ScoreThreshold != nil && hit.Score < *co.ScoreThreshold {
+			break
+		}
+		docs = append(docs, hit.Doc.WithScore(hit.Score))
+	}
+
+	callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
+
+	return docs, nil
+}
+
+func (r *Retriever) makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfEmbedding,
+	}
+
+	if embType, ok := components.GetType(emb); ok {
+		runInfo.Type = embType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+func (r *Retriever) GetType() string {
+	return typ
+}
+
+func (r *Retriever) IsCallbacksEnabled() bool {
+	return true
+}
@@ -0,0 +1,161 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/indexer/memory"
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+)
+
+type mockEmbedding struct {
+	vector []float64
+	err    error
+}
+
+func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return [][]float64{m.vector}, nil
+}
+
+func TestRetriever(t *testing.T) {
+	convey.Convey("test memory retriever", t, func() {
+		ctx := context.Background()
+		store, err := memory.NewStore(&memory.StoreConfig{Metric: memory.MetricIP})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(store.Add([]*schema.Document{
+			{ID: "1", Content: "1", MetaData: map[string]any{"lang": "go", "page": 1}},
+			{ID: "2", Content: "2", MetaData: map[string]any{"lang": "go", "page": 2}},
+			{ID: "3", Content: "3", MetaData: map[string]any{"lang": "rust", "page": int64(3)}},
+			{ID: "4", Content: "4", MetaData: map[string]any{"page": 4.0}},
+		}, [][]float64{{1, 0}, {2, 0}, {3, 0}, {4, 0}}), convey.ShouldBeNil)
+		emb := &mockEmbedding{vector: []float64{1, 0}}
+
+		_, err = NewRetriever(ctx, &RetrieverConfig{Embedding: emb})
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = NewRetriever(ctx, &RetrieverConfig{Store: store})
+		convey.So(err, convey.ShouldNotBeNil)
+
+		r, err := NewRetriever(ctx, &RetrieverConfig{Store: store, Embedding: emb, TopK: 2})
+		convey.So(err, convey.ShouldBeNil)
+
+		ids := func(docs []*schema.Document) []string {
+			result := make([]string, 0, len(docs))
+			for _, doc := range docs {
+				result = append(result, doc.ID)
+			}
+			return result
+		}
+
+		convey.Convey("test retrieve", func() {
+			docs, err := r.Retrieve(ctx, "query")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids(docs), convey.ShouldResemble, []string{"4", "3"})
+			convey.So(docs[0].Score(), convey.ShouldEqual, 4)
+
+			docs, err = r.Retrieve(ctx, "query", retriever.WithTopK(10), retriever.WithScoreThreshold(2))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids(docs), convey.ShouldResemble, []string{"4", "3", "2"})
+		})
+
+		convey.Convey("test filter", func() {
+			docs, err := r.Retrieve(ctx, "query", filter.WithFilter(filter.Eq("lang", "go")))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids(docs), convey.ShouldResemble, []string{"2", "1"})
+
+			docs, err = r.Retrieve(ctx, "query", filter.WithFilter(filter.Not(filter.Exists("lang"))))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids(docs), convey.ShouldResemble, []string{"4"})
+
+			docs, err = r.Retrieve(ctx, "query",
+				filter.WithFilter(filter.Range("page", filter.Bounds{Gte: 2, Lt: 4})),
+				WithFilterFunc(func(doc *schema.Document) bool { return doc.ID != "3" }))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ids(docs), convey.ShouldResemble, []string{"2"})
+
+			var input *retriever.CallbackInput
+			ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, callbacks.NewHandlerBuilder().
+				OnStartFn(func(ctx context.Context, info *callbacks.RunInfo, in callbacks.CallbackInput) context.Context {
+					input = retriever.ConvCallbackInput(in)
+					return ctx
+				}).Build())
+			_, err = r.Retrieve(ctx, "query", filter.WithFilter(filter.In("page", 1, 3)))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(input.Filter, convey.ShouldEqual, "in(page, [1, 3])")
+
+			_, err = r.Retrieve(ctx, "query", filter.WithFilter(filter.Eq("", 1)))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test embedding error", func() {
+			emb.err = errors.New("mock err")
+			_, err := r.Retrieve(ctx, "query")
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestCompileFilter(t *testing.T) {
+	convey.Convey("test compile filter", t, func() {
+		doc := &schema.Document{MetaData: map[string]any{
+			"source": "a.md",
+			"page":   int32(3),
+			"draft":  false,
+			"tag":    "b",
+		}}
+
+		cases := []struct {
+			f     *filter.Filter
+			match bool
+		}{
+			{filter.Eq("source", "a.md"), true},
+			{filter.Eq("page", 3.0), true},
+			{filter.Eq("draft", false), true},
+			{filter.Eq("missing", 1), false},
+			{filter.Ne("source", "b.md"), true},
+			{filter.Ne("missing", 1), true},
+			{filter.In("page", 1, uint8(3)), true},
+			{filter.In("source", "b.md", "c.md"), false},
+			{filter.Gt("page", 3), false},
+			{filter.Gte("page", 3), true},
+			{filter.Range("page", filter.Bounds{Gt: 1, Lte: 3}), true},
+			{filter.Range("tag", filter.Bounds{Gte: "a", Lt: "c"}), true},
+			{filter.Lt("source", 1), false},
+			{filter.Exists("draft"), true},
+			{filter.And(filter.Eq("source", "a.md"), filter.Gt("page", 5)), false},
+			{filter.Or(filter.Eq("source", "x"), filter.Eq("tag", "b")), true},
+			{filter.Not(filter.Exists("missing")), true},
+		}
+		for _, c := range cases {
+			match, ok, err := filter.Translate(c.f, compileFilter)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(ok, convey.ShouldBeTrue)
+			convey.So(match(doc), convey.ShouldEqual, c.match)
+		}
+	})
+}
@@ -26,7 +26,12 @@ const (
 	defaultAutoIndexLevel = 1
 	defaultLoadedProgress = 100
 
-	defaultMetricType = entity.HAMMING
+	defaultMetricType       = entity.HAMMING
+	defaultFloatMetricType  = entity.COSINE
+	defaultSparseMetricType = entity.IP
 
 	typeParamDim = "dim"
+
+	// defaultMetadataField is the json field of document metadata in the default collection schema of milvus indexer
+	defaultMetadataField = "metadata"
 )
@@ -0,0 +1,132 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package milvus
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+)
+
+// TranslateFilter translates a portable filter to a milvus boolean expression, see https://milvus.io/docs/boolean.md.
+// Filter fields in fields are matched against the collection field, other fields against the key of the metadata
+// json field, e.g. filter.Eq("source", "a.md") is translated to metadata["source"] == "a.md".
+// Exists is not supported.
+func TranslateFilter(f *filter.Filter, fields []string) (string, error) {
+	set := make(map[string]struct{}, len(fields))
+	for _, field := range fields {
+		set[field] = struct{}{}
+	}
+	return translateFilter(f, set)
+}
+
+func translateFilter(f *filter.Filter, fields map[string]struct{}) (string, error) {
+	ref := f.Field
+	if _, ok := fields[f.Field]; !ok {
+		ref = fmt.Sprintf("%s[%s]", defaultMetadataField, strconv.Quote(f.Field))
+	}
+
+	switch f.Op {
+	case filter.OpEq, filter.OpNe:
+		literal, err := exprLiteral(f.Value)
+		if err != nil {
+			return "", err
+		}
+		op := "=="
+		if f.Op == filter.OpNe {
+			op = "!="
+		}
+		return fmt.Sprintf("%s %s %s", ref, op, literal), nil
+	case filter.OpIn:
+		literals := make([]string, 0, len(f.Values))
+		for _, v := range f.Values {
+			literal, err := exprLiteral(v)
+			if err != nil {
+				return "", err
+			}
+			literals = append(literals, literal)
+		}
+		return fmt.Sprintf("%s in [%s]", ref, strings.Join(literals, ", ")), nil
+	case filter.OpRange:
+		var conds []string
+		for _, b := range []struct {
+			op    string
+			value any
+		}{{">", f.Bounds.Gt}, {">=", f.Bounds.Gte}, {"<", f.Bounds.Lt}, {"<=", f.Bounds.Lte}} {
+			if b.value == nil {
+				continue
+			}
+			literal, err := exprLiteral(b.value)
+			if err != nil {
+				return "", err
+			}
+			conds = append(conds, fmt.Sprintf("%s %s %s", ref, b.op, literal))
+		}
+		return strings.Join(conds, " and "), nil
+	case filter.OpAnd, filter.OpOr:
+		conds := make([]string, 0, len(f.Children))
+		for _, child := range f.Children {
+			cond, err := translateFilter(child, fields)
+			if err != nil {
+				return "", err
+			}
+			conds = append(conds, "("+cond+")")
+		}
+		return strings.Join(conds, " "+string(f.Op)+" "), nil
+	case filter.OpNot:
+		cond, err := translateFilter(f.Children[0], fields)
+		if err != nil {
+			return "", err
+		}
+		return "not (" + cond + ")", nil
+	default:
+		return "", filter.Unsupported("milvus", f, "")
+	}
+}
+
+// exprLiteral formats v as a literal of milvus boolean expression.
+// Same as exprLiteral in github.com/cloudwego/eino-ext/components/indexer/milvus.
+func exprLiteral(v any) (string, error) {
+	switch val := v.(type) {
+	case string:
+		return strconv.Quote(val), nil
+	case bool:
+		return strconv.FormatBool(val), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(val), nil
+	case float32:
+		return strconv.FormatFloat(float64(val), 'g', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(val, 'g', -1, 64), nil
+	default:
+		return "", fmt.Errorf("unsupported type %T", v)
+	}
+}
+
+// mergeFilter combines the native filter expression and the translated portable filter with and.
+func mergeFilter(native, portable string) string {
+	switch {
+	case native == "":
+		return portable
+	case portable == "":
+		return native
+	default:
+		return fmt.Sprintf("(%s) and (%s)", native, portable)
+	}
+}
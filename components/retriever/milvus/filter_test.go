@@ -0,0 +1,73 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package milvus
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+)
+
+func TestTranslateFilter(t *testing.T) {
+	convey.Convey("test TranslateFilter", t, func() {
+		expr, err := TranslateFilter(filter.And(
+			filter.Eq("source", "a.md"),
+			filter.Ne("id", "1"),
+			filter.In("lang", "en", "zh"),
+			filter.Range("page", filter.Bounds{Gte: 1, Lt: 10}),
+			filter.Or(filter.Eq("draft", false), filter.Not(filter.Gt("score", 0.5))),
+		), []string{"id", "content"})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(expr, convey.ShouldEqual, `(metadata["source"] == "a.md") and (id != "1") and `+
+			`(metadata["lang"] in ["en", "zh"]) and (metadata["page"] >= 1 and metadata["page"] < 10) and `+
+			`((metadata["draft"] == false) or (not (metadata["score"] > 0.5)))`)
+
+		_, err = TranslateFilter(filter.Or(filter.Eq("a", 1), filter.Exists("b")), nil)
+		convey.So(errors.Is(err, filter.ErrUnsupported), convey.ShouldBeTrue)
+		convey.So(err.Error(), convey.ShouldContainSubstring, "exists")
+	})
+
+	convey.Convey("test retrieve with filter", t, func() {
+		ctx := context.Background()
+		cli := &fakeClient{schema: floatSchema(false).WithField(entity.NewField().WithName("id").WithDataType(entity.FieldTypeVarChar))}
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			Client:     cli,
+			Embedding:  &mockEmbedding{dims: 4, sizeForCall: []int{1, 1, 1}},
+			VectorType: VectorTypeFloat,
+		})
+		convey.So(err, convey.ShouldBeNil)
+
+		_, err = r.Retrieve(ctx, "query", filter.WithFilter(filter.In("id", "1", "2")))
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(cli.expr, convey.ShouldEqual, `id in ["1", "2"]`)
+
+		_, err = r.Retrieve(ctx, "query", WithFilter(`content like "a%"`), filter.WithFilter(filter.Eq("source", "a.md")))
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(cli.expr, convey.ShouldEqual, `(content like "a%") and (metadata["source"] == "a.md")`)
+
+		cli.expr = ""
+		_, err = r.Retrieve(ctx, "query", retriever.WithTopK(1), filter.WithFilter(filter.Exists("source")))
+		convey.So(errors.Is(err, filter.ErrUnsupported), convey.ShouldBeTrue)
+		convey.So(cli.expr, convey.ShouldBeEmpty)
+	})
+}
@@ -0,0 +1,216 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package milvus
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+// fakeClient answers from a loaded collection, unimplemented methods of client.Client panic
+type fakeClient struct {
+	client.Client
+	schema   *entity.Schema
+	topK     int
+	requests []*client.ANNSearchRequest
+	reranker client.Reranker
+	vectors  []entity.Vector
+	expr     string
+}
+
+func (f *fakeClient) HasCollection(ctx context.Context, collName string) (bool, error) {
+	return true, nil
+}
+
+func (f *fakeClient) DescribeCollection(ctx context.Context, collName string) (*entity.Collection, error) {
+	return &entity.Collection{Name: collName, Schema: f.schema, Loaded: true}, nil
+}
+
+func (f *fakeClient) Search(ctx context.Context, collName string, partitions []string, expr string, outputFields []string,
+	vectors []entity.Vector, vectorField string, metricType entity.MetricType, topK int, sp entity.SearchParam,
+	opts ...client.SearchQueryOptionFunc) ([]client.SearchResult, error) {
+	f.topK, f.vectors, f.expr = topK, vectors, expr
+	return f.results(), nil
+}
+
+func (f *fakeClient) HybridSearch(ctx context.Context, collName string, partitions []string, limit int, outputFields []string,
+	reranker client.Reranker, subRequests []*client.ANNSearchRequest, opts ...client.SearchQueryOptionFunc) ([]client.SearchResult, error) {
+	f.topK, f.reranker, f.requests = limit, reranker, subRequests
+	return f.results(), nil
+}
+
+func (f *fakeClient) results() []client.SearchResult {
+	return []client.SearchResult{{
+		IDs: entity.NewColumnVarChar("id", []string{"1", "2"}),
+		Fields: []entity.Column{
+			entity.NewColumnVarChar("id", []string{"1", "2"}),
+			entity.NewColumnVarChar("content", []string{"asd", "qwe"}),
+		},
+	}}
+}
+
+func floatSchema(sparse bool) *entity.Schema {
+	s := entity.NewSchema().WithField(entity.NewField().WithName(defaultVectorField).
+		WithDataType(entity.FieldTypeFloatVector).WithDim(4))
+	if sparse {
+		s.WithField(entity.NewField().WithName("sparse").WithDataType(entity.FieldTypeSparseVector))
+	}
+	return s
+}
+
+// annField reads the unexported fields of client.ANNSearchRequest
+func annField(req *client.ANNSearchRequest, name string) reflect.Value {
+	return reflect.ValueOf(req).Elem().FieldByName(name)
+}
+
+func TestVectorConverter(t *testing.T) {
+	convey.Convey("test default vector converter", t, func() {
+		ctx := context.Background()
+		vectors := [][]float64{{1, -2, 0.5}}
+
+		vec, err := defaultVectorConverter(VectorTypeBinary)(ctx, vectors)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(vec[0], convey.ShouldHaveSameTypeAs, entity.BinaryVector{})
+		convey.So(vec[0].Serialize(), convey.ShouldResemble, vector2Bytes(vectors[0]))
+
+		vec, err = defaultVectorConverter(VectorTypeFloat)(ctx, vectors)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(vec[0], convey.ShouldResemble, entity.FloatVector{1, -2, 0.5})
+
+		vec, err = defaultVectorConverter(VectorTypeFloat16)(ctx, vectors)
+		convey.So(err, convey.ShouldBeNil)
+		b := vec[0].Serialize()
+		convey.So(b, convey.ShouldHaveLength, 6)
+		convey.So(binary.LittleEndian.Uint16(b[0:]), convey.ShouldEqual, 0x3c00)
+		convey.So(binary.LittleEndian.Uint16(b[2:]), convey.ShouldEqual, 0xc000)
+		convey.So(binary.LittleEndian.Uint16(b[4:]), convey.ShouldEqual, 0x3800)
+		convey.So(float32ToFloat16(float32(math.Inf(1))), convey.ShouldEqual, 0x7c00)
+	})
+}
+
+func TestHybridRetriever(t *testing.T) {
+	convey.Convey("test hybrid retriever", t, func() {
+		ctx := context.Background()
+		sparseEmbedding := func(ctx context.Context, texts []string) ([]map[int]float64, error) {
+			return []map[int]float64{{9: 0.2, 3: 0.8}}, nil
+		}
+
+		convey.Convey("test hybrid requires float vector type", func() {
+			_, err := NewRetriever(ctx, &RetrieverConfig{
+				Client:    &fakeClient{schema: floatSchema(true)},
+				Embedding: &mockEmbedding{dims: 4, sizeForCall: []int{1}},
+				Hybrid:    &HybridConfig{SparseVectorField: "sparse", SparseEmbedding: sparseEmbedding},
+			})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test sparse embedding not provided", func() {
+			_, err := NewRetriever(ctx, &RetrieverConfig{
+				Client:     &fakeClient{schema: floatSchema(true)},
+				Embedding:  &mockEmbedding{dims: 4, sizeForCall: []int{1}},
+				VectorType: VectorTypeFloat,
+				Hybrid:     &HybridConfig{SparseVectorField: "sparse"},
+			})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test sparse field not in schema", func() {
+			_, err := NewRetriever(ctx, &RetrieverConfig{
+				Client:     &fakeClient{schema: floatSchema(false)},
+				Embedding:  &mockEmbedding{dims: 4, sizeForCall: []int{1}},
+				VectorType: VectorTypeFloat,
+				Hybrid:     &HybridConfig{SparseVectorField: "sparse", SparseEmbedding: sparseEmbedding},
+			})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test float vector search", func() {
+			cli := &fakeClient{schema: floatSchema(false)}
+			r, err := NewRetriever(ctx, &RetrieverConfig{
+				Client:     cli,
+				Embedding:  &mockEmbedding{dims: 4, sizeForCall: []int{1}},
+				VectorType: VectorTypeFloat,
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(r.config.MetricType, convey.ShouldEqual, entity.COSINE)
+			convey.So(r.config.Sp.Params()["level"], convey.ShouldEqual, defaultAutoIndexLevel)
+
+			docs, err := r.Retrieve(ctx, "query")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(docs, convey.ShouldHaveLength, 2)
+			convey.So(cli.vectors[0], convey.ShouldHaveSameTypeAs, entity.FloatVector{})
+		})
+
+		convey.Convey("test hybrid search", func() {
+			cli := &fakeClient{schema: floatSchema(true)}
+			r, err := NewRetriever(ctx, &RetrieverConfig{
+				Client:     cli,
+				Embedding:  &mockEmbedding{dims: 4, sizeForCall: []int{1}},
+				VectorType: VectorTypeFloat,
+				TopK:       3,
+				Hybrid: &HybridConfig{
+					SparseVectorField: "sparse",
+					SparseEmbedding:   sparseEmbedding,
+					Limit:             10,
+				},
+			})
+			convey.So(err, convey.ShouldBeNil)
+
+			docs, err := r.Retrieve(ctx, "query", WithFilter("id > 0"))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(docs, convey.ShouldResemble, []*schema.Document{
+				{ID: "1", Content: "asd", MetaData: map[string]any{}},
+				{ID: "2", Content: "qwe", MetaData: map[string]any{}},
+			})
+			convey.So(cli.topK, convey.ShouldEqual, 3)
+			convey.So(cli.reranker.GetParams()[1].Value, convey.ShouldEqual, `{"k":60}`)
+			convey.So(cli.requests, convey.ShouldHaveLength, 2)
+
+			dense, sparse := cli.requests[0], cli.requests[1]
+			convey.So(annField(dense, "fieldName").String(), convey.ShouldEqual, defaultVectorField)
+			convey.So(annField(dense, "metricType").String(), convey.ShouldEqual, string(entity.COSINE))
+			convey.So(annField(dense, "expr").String(), convey.ShouldEqual, "id > 0")
+			convey.So(annField(dense, "limit").Int(), convey.ShouldEqual, 10)
+			convey.So(annField(sparse, "fieldName").String(), convey.ShouldEqual, "sparse")
+			convey.So(annField(sparse, "metricType").String(), convey.ShouldEqual, string(entity.IP))
+			convey.So(annField(sparse, "expr").String(), convey.ShouldEqual, "id > 0")
+
+			convey.So(annField(sparse, "vectors").Len(), convey.ShouldEqual, 1)
+		})
+
+		convey.Convey("test sparse2Embedding sorts positions", func() {
+			vec, err := sparse2Embedding(map[int]float64{9: 0.2, 3: 0.8})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(vec.Len(), convey.ShouldEqual, 2)
+			pos, val, ok := vec.Get(0)
+			convey.So(ok, convey.ShouldBeTrue)
+			convey.So(pos, convey.ShouldEqual, 3)
+			convey.So(val, convey.ShouldAlmostEqual, 0.8, 1e-6)
+
+			_, err = sparse2Embedding(map[int]float64{-1: 1})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
@@ -28,6 +28,8 @@ import (
 	"github.com/cloudwego/eino/schema"
 	"github.com/milvus-io/milvus-sdk-go/v2/client"
 	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+	
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
 )
 
 type RetrieverConfig struct {
@@ -53,9 +55,13 @@ type RetrieverConfig struct {
 	// Optional, and the default value is defaultDocumentConverter
 	DocumentConverter func(ctx context.Context, doc client.SearchResult) ([]*schema.Document, error)
 	// VectorConverter is the function to convert the vectors to entity.Vector
+	// Optional, and the default value converts the vectors by VectorType
 	VectorConverter func(ctx context.Context, vectors [][]float64) ([]entity.Vector, error)
+	// VectorType is the data type of VectorField, it should match the collection schema
+	// Optional, and the default value is VectorTypeBinary
+	VectorType VectorType
 	// MetricType is the metric type for vector
-	// Optional, and the default value is "HAMMING"
+	// Optional, and the default value is "HAMMING", or "COSINE" with VectorTypeFloat / VectorTypeFloat16
 	MetricType entity.MetricType
 	// TopK is the top k results to be returned
 	// Optional, and the default value is 5
@@ -66,6 +72,9 @@ type RetrieverConfig struct {
 	// SearchParams
 	// Optional, and the default value is entity.IndexAUTOINDEXSearchParam, and the level is 1
 	Sp entity.SearchParam
+	// Hybrid enables hybrid search of dense and sparse vectors, it requires VectorTypeFloat / VectorTypeFloat16
+	// Optional, and the default value is nil(dense vector search only)
+	Hybrid *HybridConfig
 	
 	// Embedding is the embedding vectorization method for values needs to be embedded from schema.Document's content.
 	// Required
@@ -74,6 +83,8 @@ type RetrieverConfig struct {
 
 type Retriever struct {
 	config RetrieverConfig
+	// fields of the collection, filter fields not in it are looked up in the metadata json field
+	fields map[string]struct{}
 }
 
 func NewRetriever(ctx context.Context, config *RetrieverConfig) (*Retriever, error) {
@@ -106,6 +117,11 @@ func NewRetriever(ctx context.Context, config *RetrieverConfig) (*Retriever, err
 	if err := checkCollectionSchema(config.VectorField, collection.Schema); err != nil {
 		return nil, fmt.Errorf("[NewRetriever] collection schema not match: %w", err)
 	}
+	if config.Hybrid != nil {
+		if err := checkCollectionSchema(config.Hybrid.SparseVectorField, collection.Schema); err != nil {
+			return nil, fmt.Errorf("[NewRetriever] collection schema not match, sparse %w", err)
+		}
+	}
 	
 	// check the collection load state
 	if !collection.Loaded {
@@ -115,20 +131,26 @@ func NewRetriever(ctx context.Context, config *RetrieverConfig) (*Retriever, err
 		}
 	}
 	
-	if config.Sp == nil {
-		dim, err := getCollectionDim(config.VectorField, collection.Schema)
-		if err != nil {
-			return nil, fmt.Errorf("[NewRetriever] failed to get collection dim: %w", err)
+	if config.VectorType != VectorTypeBinary {
+		if config.Sp == nil {
+			config.Sp = defaultFloatSearchParam(config.ScoreThreshold)
 		}
-		config.Sp = defaultSearchParam(config.ScoreThreshold, dim)
-	}
-	
-	// get the score threshold
-	scoreThreshold, ok := config.Sp.Params()["range_filter"]
-	if !ok {
-		config.ScoreThreshold = 0
 	} else {
-		config.ScoreThreshold = scoreThreshold.(float64)
+		if config.Sp == nil {
+			dim, err := getCollectionDim(config.VectorField, collection.Schema)
+			if err != nil {
+				return nil, fmt.Errorf("[NewRetriever] failed to get collection dim: %w", err)
+			}
+			config.Sp = defaultSearchParam(config.ScoreThreshold, dim)
+		}
+		
+		// get the score threshold
+		scoreThreshold, ok := config.Sp.Params()["range_filter"]
+		if !ok {
+			config.ScoreThreshold = 0
+		} else {
+			config.ScoreThreshold = scoreThreshold.(float64)
+		}
 	}
 	
 	// build the retriever
@@ -141,12 +163,15 @@ func NewRetriever(ctx context.Context, config *RetrieverConfig) (*Retriever, err
 			OutputFields:      config.OutputFields,
 			DocumentConverter: config.DocumentConverter,
 			VectorConverter:   config.VectorConverter,
+			VectorType:        config.VectorType,
 			MetricType:        config.MetricType,
 			TopK:              config.TopK,
 			ScoreThreshold:    config.ScoreThreshold,
 			Sp:                config.Sp,
+			Hybrid:            config.Hybrid,
 			Embedding:         config.Embedding,
 		},
+		fields: schemaFields(collection.Schema),
 	}, nil
 }
 
@@ -160,13 +185,18 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 	}, opts...)
 	// get impl specific options
 	io := retriever.GetImplSpecificOptions(&ImplOptions{}, opts...)
+	// translate the portable filter and combine it with the filter expression
+	portable, _, err := filter.Translate(filter.GetFilter(opts...), func(f *filter.Filter) (string, error) {
+		return translateFilter(f, r.fields)
+	})
+	expr := mergeFilter(io.Filter, portable)
 	
 	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
 	// callback info on start
 	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
 		Query:          query,
 		TopK:           *co.TopK,
-		Filter:         io.Filter,
+		Filter:         expr,
 		ScoreThreshold: co.ScoreThreshold,
 		Extra: map[string]any{
 			"metric_type": r.config.MetricType,
@@ -177,6 +207,9 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 			callbacks.OnError(ctx, err)
 		}
 	}()
+	if err != nil {
+		return nil, fmt.Errorf("[milvus retriever] invalid filter: %w", err)
+	}
 	
 	// get the embedding vector
 	emb := co.Embedding
@@ -207,19 +240,23 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		searchParams = append(searchParams, io.SearchQueryOptFn)
 	}
 	
-	results, err = r.config.Client.Search(
-		ctx,
-		r.config.Collection,
-		r.config.Partition,
-		io.Filter,
-		r.config.OutputFields,
-		vec,
-		r.config.VectorField,
-		r.config.MetricType,
-		*co.TopK,
-		r.config.Sp,
-		searchParams...,
-	)
+	if r.config.Hybrid != nil {
+		results, err = r.hybridSearch(ctx, query, vec, expr, *co.TopK, searchParams)
+	} else {
+		results, err = r.config.Client.Search(
+			ctx,
+			r.config.Collection,
+			r.config.Partition,
+			expr,
+			r.config.OutputFields,
+			vec,
+			r.config.VectorField,
+			r.config.MetricType,
+			*co.TopK,
+			r.config.Sp,
+			searchParams...,
+		)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("[milvus retriever] search has error: %w", err)
 	}
@@ -250,6 +287,34 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 	return documents, nil
 }
 
+// hybridSearch searches the dense vector and the sparse vector of query, and reranks the results
+func (r *Retriever) hybridSearch(ctx context.Context, query string, dense []entity.Vector, filter string, topK int,
+	opts []client.SearchQueryOptionFunc) ([]client.SearchResult, error) {
+	h := r.config.Hybrid
+	sparseVectors, err := h.SparseEmbedding(ctx, []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("sparse embedding has error: %w", err)
+	}
+	if len(sparseVectors) != 1 {
+		return nil, fmt.Errorf("invalid return length of sparse vector, got=%d, expected=1", len(sparseVectors))
+	}
+	sparse, err := sparse2Embedding(sparseVectors[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert sparse vector: %w", err)
+	}
+	
+	limit := h.Limit
+	if limit <= 0 {
+		limit = topK
+	}
+	requests := []*client.ANNSearchRequest{
+		client.NewANNSearchRequest(r.config.VectorField, r.config.MetricType, filter, dense, r.config.Sp, limit),
+		client.NewANNSearchRequest(h.SparseVectorField, h.SparseMetricType, filter, []entity.Vector{sparse}, h.SparseSearchParam, limit),
+	}
+	return r.config.Client.HybridSearch(ctx, r.config.Collection, r.config.Partition, topK, r.config.OutputFields,
+		h.Reranker, requests, opts...)
+}
+
 func (r *Retriever) GetType() string {
 	return typ
 }
@@ -284,14 +349,35 @@ func (r *RetrieverConfig) check() error {
 	if r.DocumentConverter == nil {
 		r.DocumentConverter = defaultDocumentConverter()
 	}
+	if r.VectorType == "" {
+		r.VectorType = VectorTypeBinary
+	}
+	switch r.VectorType {
+	case VectorTypeBinary:
+		if r.Hybrid != nil {
+			return fmt.Errorf("[NewRetriever] hybrid search requires float or float16 vector type")
+		}
+	case VectorTypeFloat, VectorTypeFloat16:
+	default:
+		return fmt.Errorf("[NewRetriever] unknown vector type: %s", r.VectorType)
+	}
 	if r.VectorConverter == nil {
-		r.VectorConverter = defaultVectorConverter()
+		r.VectorConverter = defaultVectorConverter(r.VectorType)
 	}
 	if r.TopK == 0 {
 		r.TopK = defaultTopK
 	}
 	if r.MetricType == "" {
-		r.MetricType = defaultMetricType
+		if r.VectorType == VectorTypeBinary {
+			r.MetricType = defaultMetricType
+		} else {
+			r.MetricType = defaultFloatMetricType
+		}
+	}
+	if r.Hybrid != nil {
+		if err := r.Hybrid.check(); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -0,0 +1,86 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package milvus
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// VectorType is the data type of the vector field, same as VectorType of the milvus indexer
+type VectorType string
+
+const (
+	// VectorTypeBinary queries with the float32 bytes of the embedding as a binary vector, kept for compatibility.
+	VectorTypeBinary VectorType = "binary"
+	// VectorTypeFloat queries with a float32 vector.
+	VectorTypeFloat VectorType = "float"
+	// VectorTypeFloat16 queries with a half precision vector.
+	VectorTypeFloat16 VectorType = "float16"
+)
+
+// HybridConfig is the config of hybrid search, which combines the dense vector request on VectorField
+// and the sparse vector request on SparseVectorField by Reranker.
+// see: https://milvus.io/docs/multi-vector-search.md
+type HybridConfig struct {
+	// SparseVectorField is the sparse vector field name in the collection
+	// Required
+	SparseVectorField string
+	// SparseEmbedding embeds the query into a sparse vector, it should be the one used by the indexer
+	// Required
+	SparseEmbedding func(ctx context.Context, texts []string) ([]map[int]float64, error)
+	// SparseMetricType is the metric type for sparse vector
+	// Optional, and the default value is IP
+	SparseMetricType entity.MetricType
+	// SparseSearchParam is the search param of the sparse request
+	// Optional, and the default value is entity.IndexSparseInvertedSearchParam with drop ratio 0
+	SparseSearchParam entity.SearchParam
+	// Reranker combines the results of the dense and sparse requests,
+	// e.g. client.NewRRFReranker().WithK(60), or client.NewWeightedReranker([]float64{0.7, 0.3}) with dense weight first
+	// Optional, and the default value is client.NewRRFReranker()
+	Reranker client.Reranker
+	// Limit is the number of candidates of each request before reranking
+	// Optional, and the default value is TopK
+	Limit int
+}
+
+// check the hybrid config and set the default value
+func (h *HybridConfig) check() error {
+	if h.SparseVectorField == "" {
+		return fmt.Errorf("[NewRetriever] sparse vector field not provided for hybrid search")
+	}
+	if h.SparseEmbedding == nil {
+		return fmt.Errorf("[NewRetriever] sparse embedding not provided for hybrid search")
+	}
+	if h.SparseMetricType == "" {
+		h.SparseMetricType = defaultSparseMetricType
+	}
+	if h.SparseSearchParam == nil {
+		sp, err := entity.NewIndexSparseInvertedSearchParam(0)
+		if err != nil {
+			return fmt.Errorf("[NewRetriever] failed to create sparse search param: %w", err)
+		}
+		h.SparseSearchParam = sp
+	}
+	if h.Reranker == nil {
+		h.Reranker = client.NewRRFReranker()
+	}
+	return nil
+}
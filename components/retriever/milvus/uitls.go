@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 	"strconv"
 	"time"
 
@@ -89,12 +90,29 @@ func defaultDocumentConverter() func(ctx context.Context, doc client.SearchResul
 	}
 }
 
+// defaultFloatSearchParam returns the default search param for float vectors,
+// the score threshold is used as the radius of range search if it's set
+func defaultFloatSearchParam(score float64) entity.SearchParam {
+	searchParam, _ := entity.NewIndexAUTOINDEXSearchParam(defaultAutoIndexLevel)
+	if score > 0 {
+		searchParam.AddRadius(score)
+	}
+	return searchParam
+}
+
 // defaultVectorConverter returns the default vector converter
-func defaultVectorConverter() func(ctx context.Context, vectors [][]float64) ([]entity.Vector, error) {
+func defaultVectorConverter(vectorType VectorType) func(ctx context.Context, vectors [][]float64) ([]entity.Vector, error) {
 	return func(ctx context.Context, vectors [][]float64) ([]entity.Vector, error) {
 		vec := make([]entity.Vector, 0, len(vectors))
 		for _, vector := range vectors {
-			vec = append(vec, entity.BinaryVector(vector2Bytes(vector)))
+			switch vectorType {
+			case VectorTypeFloat:
+				vec = append(vec, entity.FloatVector(vector2Float32(vector)))
+			case VectorTypeFloat16:
+				vec = append(vec, entity.Float16Vector(vector2Float16Bytes(vector)))
+			default:
+				vec = append(vec, entity.BinaryVector(vector2Bytes(vector)))
+			}
 		}
 		return vec, nil
 	}
@@ -110,6 +128,15 @@ func checkCollectionSchema(field string, s *entity.Schema) error {
 	return errors.New("vector field not found")
 }
 
+// schemaFields returns the field names of the schema
+func schemaFields(s *entity.Schema) map[string]struct{} {
+	fields := make(map[string]struct{}, len(s.Fields))
+	for _, column := range s.Fields {
+		fields[column.Name] = struct{}{}
+	}
+	return fields
+}
+
 // getCollectionDim gets the dimension of the vector field
 func getCollectionDim(field string, s *entity.Schema) (float64, error) {
 	for _, column := range s.Fields {
@@ -194,3 +221,76 @@ func vector2Bytes(vector []float64) []byte {
 	}
 	return bytes
 }
+
+// the helpers below are duplicated from the milvus indexer to keep the query vectors consistent with the stored ones
+
+// vector2Float32 converts vector to float32 vector
+func vector2Float32(vector []float64) []float32 {
+	float32Arr := make([]float32, len(vector))
+	for i, v := range vector {
+		float32Arr[i] = float32(v)
+	}
+	return float32Arr
+}
+
+// vector2Float16Bytes converts vector to little endian IEEE 754 half precision bytes
+func vector2Float16Bytes(vector []float64) []byte {
+	bytes := make([]byte, len(vector)*2)
+	for i, v := range vector {
+		binary.LittleEndian.PutUint16(bytes[i*2:], float32ToFloat16(float32(v)))
+	}
+	return bytes
+}
+
+// float32ToFloat16 converts f to half precision bits, rounding to nearest even
+func float32ToFloat16(f float32) uint16 {
+	bits := math.Float32bits(f)
+	sign := uint16(bits>>16) & 0x8000
+	exp := int32(bits>>23&0xff) - 127 + 15
+	mant := bits & 0x7fffff
+
+	switch {
+	case bits&0x7fffffff == 0:
+		return sign
+	case exp >= 0x1f:
+		if bits&0x7fffffff > 0x7f800000 {
+			return sign | 0x7e00 // NaN
+		}
+		return sign | 0x7c00 // overflow to infinity
+	case exp <= 0:
+		if exp < -10 {
+			return sign // underflow to zero
+		}
+		// subnormal
+		mant |= 0x800000
+		shift := uint32(14 - exp)
+		half := uint16(mant >> shift)
+		if rem := mant & (1<<shift - 1); rem > 1<<(shift-1) || (rem == 1<<(shift-1) && half&1 == 1) {
+			half++
+		}
+		return sign | half
+	default:
+		half := uint16(exp)<<10 | uint16(mant>>13)
+		if rem := mant & 0x1fff; rem > 0x1000 || (rem == 0x1000 && half&1 == 1) {
+			half++ // carry into exponent is the correct rounding
+		}
+		return sign | half
+	}
+}
+
+// sparse2Embedding converts the sparse vector of schema.Document to milvus sparse embedding
+func sparse2Embedding(sparse map[int]float64) (entity.SparseEmbedding, error) {
+	positions := make([]uint32, 0, len(sparse))
+	for pos := range sparse {
+		if pos < 0 {
+			return nil, fmt.Errorf("invalid sparse vector position: %d", pos)
+		}
+		positions = append(positions, uint32(pos))
+	}
+	sort.Slice(positions, func(i, j int) bool { return positions[i] < positions[j] })
+	values := make([]float32, 0, len(positions))
+	for _, pos := range positions {
+		values = append(values, float32(sparse[int(pos)]))
+	}
+	return entity.NewSliceSparseEmbedding(positions, values)
+}
@@ -0,0 +1,140 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+	"github.com/cloudwego/eino-ext/components/retriever/opensearch"
+	"github.com/cloudwego/eino-ext/components/retriever/opensearch/search_mode"
+	oscli "github.com/cloudwego/eino-ext/libs/acl/opensearch"
+)
+
+const (
+	indexName          = "eino_docs"
+	fieldContent       = "content"
+	fieldContentVector = "content_vector"
+	fieldSource        = "source"
+	pipelineName       = "eino-hybrid-search"
+)
+
+// documents are stored by components/indexer/opensearch/examples/main.go
+func main() {
+	ctx := context.Background()
+
+	// e.g. https://localhost:9200
+	cli, err := oscli.NewClient(&oscli.Config{
+		BaseURL:  os.Getenv("OPENSEARCH_URL"),
+		Username: os.Getenv("OPENSEARCH_USERNAME"),
+		Password: os.Getenv("OPENSEARCH_PASSWORD"),
+	})
+	if err != nil {
+		log.Fatalf("NewClient failed, err=%v", err)
+	}
+
+	// hybrid queries need a search pipeline normalizing the scores of the text query and the knn query
+	err = cli.PutSearchPipeline(ctx, pipelineName, oscli.NormalizationPipeline(&oscli.NormalizationConfig{
+		Normalization: "min_max",
+		Combination:   "arithmetic_mean",
+		Weights:       []float64{0.3, 0.7},
+	}))
+	if err != nil {
+		log.Fatalf("PutSearchPipeline failed, err=%v", err)
+	}
+
+	modes := map[string]opensearch.SearchMode{
+		"approximate": search_mode.SearchModeApproximate(&search_mode.ApproximateConfig{
+			VectorFieldName: fieldContentVector,
+		}),
+		"exact": search_mode.SearchModeExact(&search_mode.ExactConfig{
+			VectorFieldName: fieldContentVector,
+			SpaceType:       oscli.SpaceTypeCosine,
+		}),
+		"hybrid": search_mode.SearchModeHybrid(&search_mode.HybridConfig{
+			QueryFieldName:  fieldContent,
+			VectorFieldName: fieldContentVector,
+			SearchPipeline:  pipelineName,
+		}),
+	}
+
+	for name, mode := range modes {
+		r, err := opensearch.NewRetriever(ctx, &opensearch.RetrieverConfig{
+			Client:       cli,
+			Index:        indexName,
+			TopK:         3,
+			SearchMode:   mode,
+			ResultParser: parseHit,
+			Embedding:    &letterEmbedding{}, // replace it with real embedding component
+		})
+		if err != nil {
+			log.Fatalf("NewRetriever failed, err=%v", err)
+		}
+
+		docs, err := r.Retrieve(ctx, "eino graphs", filter.WithFilter(filter.Eq(fieldSource, "intro.md")))
+		if err != nil {
+			log.Fatalf("Retrieve failed, err=%v", err)
+		}
+
+		for _, doc := range docs {
+			log.Printf("%s: id=%s, score=%.3f, content=%s", name, doc.ID, doc.Score(), doc.Content)
+		}
+	}
+}
+
+func parseHit(ctx context.Context, hit *oscli.Hit) (*schema.Document, error) {
+	var src map[string]any
+	if err := json.Unmarshal(hit.Source, &src); err != nil {
+		return nil, err
+	}
+
+	doc := &schema.Document{ID: hit.ID, MetaData: map[string]any{}}
+	if content, ok := src[fieldContent].(string); ok {
+		doc.Content = content
+	}
+	if source, ok := src[fieldSource].(string); ok {
+		doc.MetaData["_source"] = source
+	}
+	if hit.Score != nil {
+		doc.WithScore(*hit.Score)
+	}
+
+	return doc, nil
+}
+
+// letterEmbedding embeds a text to its letter frequencies, only for the example.
+type letterEmbedding struct{}
+
+func (l *letterEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = make([]float64, 26)
+		for _, c := range strings.ToLower(text) {
+			if c >= 'a' && c <= 'z' {
+				vectors[i][c-'a']++
+			}
+		}
+	}
+	return vectors, nil
+}
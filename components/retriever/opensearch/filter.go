@@ -0,0 +1,91 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package opensearch
+
+import (
+	"github.com/cloudwego/eino/components/retriever"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+)
+
+// TranslateFilter translates a portable filter to an opensearch query:
+// eq to term, ne to bool.must_not term, in to terms, range to range, exists to exists,
+// and to bool.filter, or to bool.should with minimum_should_match 1, and not to bool.must_not.
+// Term queries match keyword, numeric and boolean fields exactly, not analyzed text fields.
+func TranslateFilter(f *filter.Filter) (map[string]any, error) {
+	switch f.Op {
+	case filter.OpEq:
+		return map[string]any{"term": map[string]any{f.Field: f.Value}}, nil
+	case filter.OpNe:
+		return boolQuery("must_not", []map[string]any{{"term": map[string]any{f.Field: f.Value}}}), nil
+	case filter.OpIn:
+		return map[string]any{"terms": map[string]any{f.Field: f.Values}}, nil
+	case filter.OpRange:
+		bounds := map[string]any{}
+		for _, b := range []struct {
+			key   string
+			value any
+		}{{"gt", f.Bounds.Gt}, {"gte", f.Bounds.Gte}, {"lt", f.Bounds.Lt}, {"lte", f.Bounds.Lte}} {
+			if b.value != nil {
+				bounds[b.key] = b.value
+			}
+		}
+		return map[string]any{"range": map[string]any{f.Field: bounds}}, nil
+	case filter.OpExists:
+		return map[string]any{"exists": map[string]any{"field": f.Field}}, nil
+	case filter.OpAnd, filter.OpOr, filter.OpNot:
+		children := make([]map[string]any, 0, len(f.Children))
+		for _, child := range f.Children {
+			q, err := TranslateFilter(child)
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, q)
+		}
+		switch f.Op {
+		case filter.OpAnd:
+			return boolQuery("filter", children), nil
+		case filter.OpOr:
+			q := boolQuery("should", children)
+			q["bool"].(map[string]any)["minimum_should_match"] = 1
+			return q, nil
+		default:
+			return boolQuery("must_not", children), nil
+		}
+	default:
+		return nil, filter.Unsupported("opensearch", f, "")
+	}
+}
+
+func boolQuery(occur string, clauses []map[string]any) map[string]any {
+	return map[string]any{"bool": map[string]any{occur: clauses}}
+}
+
+// withPortableFilter appends the filter set by filter.WithFilter to the filters of ImplOptions.
+func withPortableFilter(opts []retriever.Option) ([]retriever.Option, error) {
+	q, ok, err := filter.Translate(filter.GetFilter(opts...), TranslateFilter)
+	if err != nil || !ok {
+		return opts, err
+	}
+
+	io := retriever.GetImplSpecificOptions(&ImplOptions{}, opts...)
+	filters := make([]map[string]any, 0, len(io.Filters)+1)
+	filters = append(filters, io.Filters...)
+	filters = append(filters, q)
+
+	return append(opts[:len(opts):len(opts)], WithFilters(filters)), nil
+}
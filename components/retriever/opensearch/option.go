@@ -0,0 +1,36 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package opensearch
+
+import (
+	"github.com/cloudwego/eino/components/retriever"
+)
+
+// ImplOptions opensearch specified options
+// Use retriever.GetImplSpecificOptions[ImplOptions] to get ImplOptions from options.
+type ImplOptions struct {
+	// Filters are query clauses the hits must match, e.g. {"term": {"source": "a.md"}}.
+	Filters []map[string]any `json:"filters,omitempty"`
+}
+
+// WithFilters sets query clauses the hits must match, in filter context so they don't change scores.
+// They're applied in every search mode, see the search modes for how.
+func WithFilters(filters []map[string]any) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.Filters = filters
+	})
+}
@@ -0,0 +1,179 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package opensearch
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/libs/acl/opensearch"
+)
+
+type RetrieverConfig struct {
+	// Client opensearch client, e.g. opensearch.NewClient of github.com/cloudwego/eino-ext/libs/acl/opensearch.
+	// Required.
+	Client *opensearch.Client
+	// Index to retrieve documents from.
+	// Required.
+	Index string
+	// TopK number of result to return as top hits.
+	// Default is 10
+	TopK int
+	// ScoreThreshold drops hits of lower scores.
+	// Optional.
+	ScoreThreshold *float64
+
+	// SearchMode retrieve strategy, see prepared impls in search_mode package:
+	// use search_mode.SearchModeApproximate for approximate k-NN search
+	// use search_mode.SearchModeExact for exact k-NN search with script score
+	// use search_mode.SearchModeHybrid for a text and k-NN hybrid query with score normalization
+	// Required.
+	SearchMode SearchMode
+	// ResultParser parse document from opensearch search hits.
+	// Required.
+	ResultParser func(ctx context.Context, hit *opensearch.Hit) (doc *schema.Document, err error)
+	// Embedding vectorization method, must provide when SearchMode needed
+	Embedding embedding.Embedder
+}
+
+type SearchMode interface {
+	// BuildRequest generate search request from config, query and options.
+	// Additionally, some specified options (like filters for query) will be provided in options,
+	// and use retriever.GetImplSpecificOptions[options.ImplOptions] to get it.
+	BuildRequest(ctx context.Context, conf *RetrieverConfig, query string, opts ...retriever.Option) (*SearchRequest, error)
+}
+
+// SearchRequest is a request of the search api of the index.
+type SearchRequest struct {
+	// Body of the request, e.g. {"size": 10, "query": {...}}.
+	Body map[string]any
+	// Pipeline is the name of the search pipeline to run, e.g. a normalization pipeline of hybrid queries.
+	// Optional.
+	Pipeline string
+}
+
+type Retriever struct {
+	client *opensearch.Client
+	config *RetrieverConfig
+}
+
+func NewRetriever(_ context.Context, conf *RetrieverConfig) (*Retriever, error) {
+	if conf.SearchMode == nil {
+		return nil, fmt.Errorf("[NewRetriever] search mode not provided")
+	}
+
+	if conf.TopK == 0 {
+		conf.TopK = defaultTopK
+	}
+
+	if conf.ResultParser == nil {
+		return nil, fmt.Errorf("[NewRetriever] result parser not provided")
+	}
+
+	if conf.Client == nil {
+		return nil, fmt.Errorf("[NewRetriever] opensearch client not provided")
+	}
+
+	if conf.Index == "" {
+		return nil, fmt.Errorf("[NewRetriever] index not provided")
+	}
+
+	return &Retriever{
+		client: conf.Client,
+		config: conf,
+	}, nil
+}
+
+func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) (docs []*schema.Document, err error) {
+	options := retriever.GetCommonOptions(&retriever.Options{
+		Index:          &r.config.Index,
+		TopK:           &r.config.TopK,
+		ScoreThreshold: r.config.ScoreThreshold,
+		Embedding:      r.config.Embedding,
+	}, opts...)
+
+	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
+	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
+		Query:          query,
+		TopK:           *options.TopK,
+		ScoreThreshold: options.ScoreThreshold,
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	opts, err = withPortableFilter(opts)
+	if err != nil {
+		return nil, fmt.Errorf("[opensearch retriever] invalid filter: %w", err)
+	}
+
+	req, err := r.config.SearchMode.BuildRequest(ctx, r.config, query, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.client.Search(ctx, *options.Index, req.Body, req.Pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	docs, err = r.parseSearchResult(ctx, resp, options.ScoreThreshold)
+	if err != nil {
+		return nil, err
+	}
+
+	callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
+
+	return docs, nil
+}
+
+// parseSearchResult parses the hits of resp, dropping the ones scored below threshold,
+// as min_score doesn't apply to the normalized scores of hybrid queries.
+func (r *Retriever) parseSearchResult(ctx context.Context, resp *opensearch.SearchResponse, threshold *float64) (docs []*schema.Document, err error) {
+	docs = make([]*schema.Document, 0, len(resp.Hits.Hits))
+
+	for _, hit := range resp.Hits.Hits {
+		if threshold != nil && hit.Score != nil && *hit.Score < *threshold {
+			continue
+		}
+
+		doc, err := r.config.ResultParser(ctx, hit)
+		if err != nil {
+			return nil, err
+		}
+
+		docs = append(docs, doc)
+	}
+
+	return docs, nil
+}
+
+func (r *Retriever) GetType() string {
+	return typ
+}
+
+func (r *Retriever) IsCallbacksEnabled() bool {
+	return true
+}
@@ -0,0 +1,201 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+	"github.com/cloudwego/eino-ext/libs/acl/opensearch"
+)
+
+// searchServer is a fake search api replying with response, and recording the last request.
+type searchServer struct {
+	status   int
+	response string
+	path     string
+	query    string
+	body     map[string]any
+}
+
+func newSearchServer(t *testing.T, response string) (*searchServer, *opensearch.Client) {
+	s := &searchServer{status: http.StatusOK, response: response}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.path, s.query = r.URL.Path, r.URL.RawQuery
+		b, _ := io.ReadAll(r.Body)
+		s.body = nil
+		_ = json.Unmarshal(b, &s.body)
+		w.WriteHeader(s.status)
+		_, _ = w.Write([]byte(s.response))
+	}))
+	t.Cleanup(srv.Close)
+
+	cli, err := opensearch.NewClient(&opensearch.Config{BaseURL: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, cli
+}
+
+// mockSearchMode searches with a term query of each filter, and pipeline.
+type mockSearchMode struct {
+	pipeline string
+	err      error
+}
+
+func (m *mockSearchMode) BuildRequest(ctx context.Context, conf *RetrieverConfig, query string, opts ...retriever.Option) (*SearchRequest, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	co := retriever.GetCommonOptions(&retriever.Options{TopK: &conf.TopK}, opts...)
+	io := retriever.GetImplSpecificOptions(&ImplOptions{}, opts...)
+	return &SearchRequest{
+		Body:     map[string]any{"size": *co.TopK, "query": map[string]any{"bool": map[string]any{"filter": io.Filters}}},
+		Pipeline: m.pipeline,
+	}, nil
+}
+
+func parseHit(ctx context.Context, hit *opensearch.Hit) (*schema.Document, error) {
+	var src struct {
+		Content string `json:"content"`
+	}
+	if err := json.Unmarshal(hit.Source, &src); err != nil {
+		return nil, err
+	}
+	doc := &schema.Document{ID: hit.ID, Content: src.Content}
+	if hit.Score != nil {
+		doc.WithScore(*hit.Score)
+	}
+	return doc, nil
+}
+
+const hitsResponse = `{"took":1,"hits":{"total":{"value":2,"relation":"eq"},"max_score":0.9,"hits":[` +
+	`{"_index":"docs","_id":"1","_score":0.9,"_source":{"content":"a"}},` +
+	`{"_index":"docs","_id":"2","_score":0.4,"_source":{"content":"b"}}]}}`
+
+func TestNewRetriever(t *testing.T) {
+	convey.Convey("test NewRetriever", t, func() {
+		ctx := context.Background()
+		_, cli := newSearchServer(t, hitsResponse)
+
+		_, err := NewRetriever(ctx, &RetrieverConfig{})
+		convey.So(err, convey.ShouldNotBeNil)
+
+		_, err = NewRetriever(ctx, &RetrieverConfig{SearchMode: &mockSearchMode{}})
+		convey.So(err, convey.ShouldNotBeNil)
+
+		_, err = NewRetriever(ctx, &RetrieverConfig{SearchMode: &mockSearchMode{}, ResultParser: parseHit})
+		convey.So(err, convey.ShouldNotBeNil)
+
+		_, err = NewRetriever(ctx, &RetrieverConfig{Client: cli, SearchMode: &mockSearchMode{}, ResultParser: parseHit})
+		convey.So(err, convey.ShouldNotBeNil)
+
+		r, err := NewRetriever(ctx, &RetrieverConfig{Client: cli, Index: "docs", SearchMode: &mockSearchMode{}, ResultParser: parseHit})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(r.config.TopK, convey.ShouldEqual, defaultTopK)
+		convey.So(r.GetType(), convey.ShouldEqual, GetType())
+		convey.So(r.IsCallbacksEnabled(), convey.ShouldBeTrue)
+	})
+}
+
+func TestRetrieve(t *testing.T) {
+	convey.Convey("test Retrieve", t, func() {
+		ctx := context.Background()
+		srv, cli := newSearchServer(t, hitsResponse)
+		mode := &mockSearchMode{pipeline: "hybrid-search"}
+
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			Client:       cli,
+			Index:        "docs",
+			TopK:         5,
+			SearchMode:   mode,
+			ResultParser: parseHit,
+		})
+		convey.So(err, convey.ShouldBeNil)
+
+		convey.Convey("test success", func() {
+			var started, ended bool
+			handler := callbacks.NewHandlerBuilder().
+				OnStartFn(func(ctx context.Context, info *callbacks.RunInfo, input callbacks.CallbackInput) context.Context {
+					started = true
+					convey.So(retriever.ConvCallbackInput(input).TopK, convey.ShouldEqual, 2)
+					return ctx
+				}).
+				OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+					ended = true
+					return ctx
+				}).Build()
+			ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, handler)
+
+			docs, err := r.Retrieve(ctx, "query", retriever.WithTopK(2),
+				WithFilters([]map[string]any{{"term": map[string]any{"lang": "en"}}}),
+				filter.WithFilter(filter.Eq("source", "a.md")))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(started && ended, convey.ShouldBeTrue)
+			convey.So(docs, convey.ShouldHaveLength, 2)
+			convey.So(docs[0].ID, convey.ShouldEqual, "1")
+			convey.So(docs[0].Content, convey.ShouldEqual, "a")
+			convey.So(docs[0].Score(), convey.ShouldEqual, 0.9)
+			convey.So(srv.path, convey.ShouldEqual, "/docs/_search")
+			convey.So(srv.query, convey.ShouldEqual, "search_pipeline=hybrid-search")
+			convey.So(srv.body, convey.ShouldResemble, map[string]any{"size": 2.0, "query": map[string]any{"bool": map[string]any{"filter": []any{
+				map[string]any{"term": map[string]any{"lang": "en"}},
+				map[string]any{"term": map[string]any{"source": "a.md"}},
+			}}}})
+		})
+
+		convey.Convey("test score threshold", func() {
+			docs, err := r.Retrieve(ctx, "query", retriever.WithScoreThreshold(0.5), retriever.WithIndex("other"))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(docs, convey.ShouldHaveLength, 1)
+			convey.So(docs[0].ID, convey.ShouldEqual, "1")
+			convey.So(srv.path, convey.ShouldEqual, "/other/_search")
+		})
+
+		convey.Convey("test errors", func() {
+			_, err := r.Retrieve(ctx, "query", filter.WithFilter(filter.In("source")))
+			convey.So(err, convey.ShouldNotBeNil)
+
+			mode.err = fmt.Errorf("mock err")
+			_, err = r.Retrieve(ctx, "query")
+			convey.So(err, convey.ShouldNotBeNil)
+			mode.err = nil
+
+			srv.status = http.StatusBadRequest
+			srv.response = `{"error":{"type":"parsing_exception","reason":"unknown query [hybrid]"},"status":400}`
+			_, err = r.Retrieve(ctx, "query")
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "unknown query [hybrid]")
+
+			srv.status = http.StatusOK
+			srv.response = `{"hits":{"hits":[{"_id":"1","_source":"not an object"}]}}`
+			_, err = r.Retrieve(ctx, "query")
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package search_mode
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/components/retriever"
+
+	"github.com/cloudwego/eino-ext/components/retriever/opensearch"
+)
+
+// SearchModeApproximate retrieve with approximate k-NN search of a knn_vector field.
+// Filters are applied during the search, which requires the lucene or faiss engine, set PostFilter for nmslib.
+// see: https://opensearch.org/docs/latest/search-plugins/knn/approximate-knn/
+// see: https://opensearch.org/docs/latest/search-plugins/knn/filter-search-knn/
+func SearchModeApproximate(config *ApproximateConfig) opensearch.SearchMode {
+	return &approximate{config}
+}
+
+type ApproximateConfig struct {
+	// VectorFieldName the name of the knn_vector field to search against.
+	// Required.
+	VectorFieldName string
+	// K the number of nearest neighbors each shard and segment returns.
+	// Default is TopK.
+	K int
+	// MethodParameters overrides the parameters of the knn method of the field at search time, e.g. {"ef_search": 100}.
+	// Optional.
+	MethodParameters map[string]any
+	// PostFilter applies filters to the k nearest neighbors after the search rather than during it,
+	// so fewer than TopK documents may return.
+	// Default false.
+	PostFilter bool
+}
+
+type approximate struct {
+	config *ApproximateConfig
+}
+
+func (a *approximate) BuildRequest(ctx context.Context, conf *opensearch.RetrieverConfig, query string, opts ...retriever.Option) (*opensearch.SearchRequest, error) {
+	if a.config.VectorFieldName == "" {
+		return nil, fmt.Errorf("[BuildRequest][SearchModeApproximate] vector field name not provided")
+	}
+
+	co := commonOptions(conf, opts)
+	io := retriever.GetImplSpecificOptions(&opensearch.ImplOptions{}, opts...)
+
+	vector, err := embedQuery(ctx, co.Embedding, query)
+	if err != nil {
+		return nil, fmt.Errorf("[BuildRequest][SearchModeApproximate] %w", err)
+	}
+
+	k := a.config.K
+	if k == 0 {
+		k = *co.TopK
+	}
+
+	var q map[string]any
+	if a.config.PostFilter && len(io.Filters) > 0 {
+		q = filterQuery(io.Filters)
+		q["bool"].(map[string]any)["must"] = []map[string]any{knnQuery(a.config.VectorFieldName, vector, k, a.config.MethodParameters, nil)}
+	} else {
+		q = knnQuery(a.config.VectorFieldName, vector, k, a.config.MethodParameters, io.Filters)
+	}
+
+	body := map[string]any{"size": *co.TopK, "query": q}
+	if co.ScoreThreshold != nil {
+		body["min_score"] = *co.ScoreThreshold
+	}
+
+	return &opensearch.SearchRequest{Body: body}, nil
+}
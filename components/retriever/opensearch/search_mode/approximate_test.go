@@ -0,0 +1,102 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package search_mode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/retriever/opensearch"
+)
+
+type mockEmbedding struct {
+	size       int
+	mockVector []float64
+	err        error
+}
+
+func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	resp := make([][]float64, m.size)
+	for i := range resp {
+		resp[i] = m.mockVector
+	}
+	return resp, nil
+}
+
+func marshal(req *opensearch.SearchRequest) string {
+	b, err := json.Marshal(req.Body)
+	convey.So(err, convey.ShouldBeNil)
+	return string(b)
+}
+
+func TestSearchModeApproximate(t *testing.T) {
+	convey.Convey("test SearchModeApproximate", t, func() {
+		ctx := context.Background()
+		conf := &opensearch.RetrieverConfig{TopK: 5, Embedding: &mockEmbedding{size: 1, mockVector: []float64{1.1, 1.2}}}
+		filters := opensearch.WithFilters([]map[string]any{{"term": map[string]any{"source": "a.md"}}})
+
+		convey.Convey("test knn", func() {
+			req, err := SearchModeApproximate(&ApproximateConfig{VectorFieldName: "vector"}).BuildRequest(ctx, conf, "query")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(req.Pipeline, convey.ShouldBeEmpty)
+			convey.So(marshal(req), convey.ShouldEqual, `{"query":{"knn":{"vector":{"k":5,"vector":[1.1,1.2]}}},"size":5}`)
+		})
+
+		convey.Convey("test knn with filters", func() {
+			req, err := SearchModeApproximate(&ApproximateConfig{
+				VectorFieldName:  "vector",
+				K:                20,
+				MethodParameters: map[string]any{"ef_search": 100},
+			}).BuildRequest(ctx, conf, "query", filters, retriever.WithTopK(3), retriever.WithScoreThreshold(0.5))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(marshal(req), convey.ShouldEqual, `{"min_score":0.5,"query":{"knn":{"vector":{`+
+				`"filter":{"bool":{"filter":[{"term":{"source":"a.md"}}]}},"k":20,"method_parameters":{"ef_search":100},"vector":[1.1,1.2]}}},"size":3}`)
+		})
+
+		convey.Convey("test post filter", func() {
+			req, err := SearchModeApproximate(&ApproximateConfig{VectorFieldName: "vector", PostFilter: true}).
+				BuildRequest(ctx, conf, "query", filters)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(marshal(req), convey.ShouldEqual, `{"query":{"bool":{"filter":[{"term":{"source":"a.md"}}],`+
+				`"must":[{"knn":{"vector":{"k":5,"vector":[1.1,1.2]}}}]}},"size":5}`)
+		})
+
+		convey.Convey("test errors", func() {
+			_, err := SearchModeApproximate(&ApproximateConfig{}).BuildRequest(ctx, conf, "query")
+			convey.So(err, convey.ShouldNotBeNil)
+
+			a := SearchModeApproximate(&ApproximateConfig{VectorFieldName: "vector"})
+			_, err = a.BuildRequest(ctx, &opensearch.RetrieverConfig{TopK: 5}, "query")
+			convey.So(err, convey.ShouldNotBeNil)
+
+			_, err = a.BuildRequest(ctx, conf, "query", retriever.WithEmbedding(&mockEmbedding{err: fmt.Errorf("mock err")}))
+			convey.So(err, convey.ShouldNotBeNil)
+
+			_, err = a.BuildRequest(ctx, conf, "query", retriever.WithEmbedding(&mockEmbedding{size: 2}))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package search_mode
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/components/retriever"
+
+	"github.com/cloudwego/eino-ext/components/retriever/opensearch"
+	oscli "github.com/cloudwego/eino-ext/libs/acl/opensearch"
+)
+
+// SearchModeExact retrieve with exact k-NN search, scoring every document matching the filters by a knn_score script.
+// It suits small indices or selective filters, and works on knn_vector fields without a knn method.
+// see: https://opensearch.org/docs/latest/search-plugins/knn/knn-score-script/
+func SearchModeExact(config *ExactConfig) opensearch.SearchMode {
+	return &exact{config}
+}
+
+type ExactConfig struct {
+	// VectorFieldName the name of the knn_vector field to search against.
+	// Required.
+	VectorFieldName string
+	// SpaceType the distance function of scores.
+	// Default oscli.SpaceTypeCosine.
+	SpaceType oscli.SpaceType
+}
+
+type exact struct {
+	config *ExactConfig
+}
+
+func (e *exact) BuildRequest(ctx context.Context, conf *opensearch.RetrieverConfig, query string, opts ...retriever.Option) (*opensearch.SearchRequest, error) {
+	if e.config.VectorFieldName == "" {
+		return nil, fmt.Errorf("[BuildRequest][SearchModeExact] vector field name not provided")
+	}
+
+	co := commonOptions(conf, opts)
+	io := retriever.GetImplSpecificOptions(&opensearch.ImplOptions{}, opts...)
+
+	vector, err := embedQuery(ctx, co.Embedding, query)
+	if err != nil {
+		return nil, fmt.Errorf("[BuildRequest][SearchModeExact] %w", err)
+	}
+
+	spaceType := e.config.SpaceType
+	if spaceType == "" {
+		spaceType = oscli.SpaceTypeCosine
+	}
+
+	candidates := map[string]any{"match_all": map[string]any{}}
+	if len(io.Filters) > 0 {
+		candidates = filterQuery(io.Filters)
+	}
+
+	body := map[string]any{
+		"size": *co.TopK,
+		"query": map[string]any{"script_score": map[string]any{
+			"query": candidates,
+			"script": map[string]any{
+				"source": "knn_score",
+				"lang":   "knn",
+				"params": map[string]any{
+					"field":       e.config.VectorFieldName,
+					"query_value": vector,
+					"space_type":  spaceType,
+				},
+			},
+		}},
+	}
+	if co.ScoreThreshold != nil {
+		body["min_score"] = *co.ScoreThreshold
+	}
+
+	return &opensearch.SearchRequest{Body: body}, nil
+}
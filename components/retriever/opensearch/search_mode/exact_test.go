@@ -0,0 +1,58 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package search_mode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/retriever/opensearch"
+	oscli "github.com/cloudwego/eino-ext/libs/acl/opensearch"
+)
+
+func TestSearchModeExact(t *testing.T) {
+	convey.Convey("test SearchModeExact", t, func() {
+		ctx := context.Background()
+		conf := &opensearch.RetrieverConfig{TopK: 5, Embedding: &mockEmbedding{size: 1, mockVector: []float64{1.1, 1.2}}}
+
+		convey.Convey("test match all", func() {
+			req, err := SearchModeExact(&ExactConfig{VectorFieldName: "vector"}).BuildRequest(ctx, conf, "query")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(marshal(req), convey.ShouldEqual, `{"query":{"script_score":{"query":{"match_all":{}},`+
+				`"script":{"lang":"knn","params":{"field":"vector","query_value":[1.1,1.2],"space_type":"cosinesimil"},"source":"knn_score"}}},"size":5}`)
+		})
+
+		convey.Convey("test filters", func() {
+			req, err := SearchModeExact(&ExactConfig{VectorFieldName: "vector", SpaceType: oscli.SpaceTypeL2}).BuildRequest(ctx, conf, "query",
+				opensearch.WithFilters([]map[string]any{{"term": map[string]any{"source": "a.md"}}}), retriever.WithScoreThreshold(0.8))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(marshal(req), convey.ShouldEqual, `{"min_score":0.8,"query":{"script_score":{"query":{"bool":{"filter":[{"term":{"source":"a.md"}}]}},`+
+				`"script":{"lang":"knn","params":{"field":"vector","query_value":[1.1,1.2],"space_type":"l2"},"source":"knn_score"}}},"size":5}`)
+		})
+
+		convey.Convey("test errors", func() {
+			_, err := SearchModeExact(&ExactConfig{}).BuildRequest(ctx, conf, "query")
+			convey.So(err, convey.ShouldNotBeNil)
+
+			_, err = SearchModeExact(&ExactConfig{VectorFieldName: "vector"}).BuildRequest(ctx, &opensearch.RetrieverConfig{TopK: 5}, "query")
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,104 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package search_mode
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/components/retriever"
+
+	"github.com/cloudwego/eino-ext/components/retriever/opensearch"
+	oscli "github.com/cloudwego/eino-ext/libs/acl/opensearch"
+)
+
+// SearchModeHybrid retrieve with a hybrid query of a match query and a knn query,
+// whose scores are normalized and combined by a normalization search pipeline.
+// Filters are applied to both queries.
+// ScoreThreshold applies to the combined scores, as the retriever drops hits of lower scores.
+// see: https://opensearch.org/docs/latest/search-plugins/hybrid-search/
+func SearchModeHybrid(config *HybridConfig) opensearch.SearchMode {
+	return &hybrid{config}
+}
+
+type HybridConfig struct {
+	// QueryFieldName the name of the text field of the match query.
+	// Required.
+	QueryFieldName string
+	// VectorFieldName the name of the knn_vector field of the knn query.
+	// Required.
+	VectorFieldName string
+	// K the number of nearest neighbors of the knn query.
+	// Default is TopK.
+	K int
+	// SearchPipeline the name of a normalization search pipeline created in advance,
+	// e.g. by oscli.Client.PutSearchPipeline with oscli.NormalizationPipeline.
+	// Either SearchPipeline or Normalization is required.
+	SearchPipeline string
+	// Normalization sends a temporary normalization pipeline with each request, which requires OpenSearch 2.9 or later.
+	// The weights are of the match query and the knn query in order.
+	Normalization *oscli.NormalizationConfig
+}
+
+type hybrid struct {
+	config *HybridConfig
+}
+
+func (h *hybrid) BuildRequest(ctx context.Context, conf *opensearch.RetrieverConfig, query string, opts ...retriever.Option) (*opensearch.SearchRequest, error) {
+	if h.config.QueryFieldName == "" || h.config.VectorFieldName == "" {
+		return nil, fmt.Errorf("[BuildRequest][SearchModeHybrid] query field name or vector field name not provided")
+	}
+
+	if (h.config.SearchPipeline == "") == (h.config.Normalization == nil) {
+		return nil, fmt.Errorf("[BuildRequest][SearchModeHybrid] either search pipeline or normalization should be provided")
+	}
+
+	co := commonOptions(conf, opts)
+	io := retriever.GetImplSpecificOptions(&opensearch.ImplOptions{}, opts...)
+
+	vector, err := embedQuery(ctx, co.Embedding, query)
+	if err != nil {
+		return nil, fmt.Errorf("[BuildRequest][SearchModeHybrid] %w", err)
+	}
+
+	k := h.config.K
+	if k == 0 {
+		k = *co.TopK
+	}
+
+	text := map[string]any{"match": map[string]any{h.config.QueryFieldName: map[string]any{"query": query}}}
+	if len(io.Filters) > 0 {
+		q := filterQuery(io.Filters)
+		q["bool"].(map[string]any)["must"] = []map[string]any{text}
+		text = q
+	}
+
+	body := map[string]any{
+		"size": *co.TopK,
+		"query": map[string]any{"hybrid": map[string]any{"queries": []map[string]any{
+			text,
+			knnQuery(h.config.VectorFieldName, vector, k, nil, io.Filters),
+		}}},
+	}
+
+	req := &opensearch.SearchRequest{Body: body, Pipeline: h.config.SearchPipeline}
+	if h.config.Normalization != nil {
+		body["search_pipeline"] = oscli.NormalizationPipeline(h.config.Normalization)
+	}
+
+	return req, nil
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package search_mode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/retriever/opensearch"
+	oscli "github.com/cloudwego/eino-ext/libs/acl/opensearch"
+)
+
+func TestSearchModeHybrid(t *testing.T) {
+	convey.Convey("test SearchModeHybrid", t, func() {
+		ctx := context.Background()
+		conf := &opensearch.RetrieverConfig{TopK: 5, Embedding: &mockEmbedding{size: 1, mockVector: []float64{1.1, 1.2}}}
+
+		convey.Convey("test search pipeline", func() {
+			req, err := SearchModeHybrid(&HybridConfig{
+				QueryFieldName:  "content",
+				VectorFieldName: "vector",
+				K:               10,
+				SearchPipeline:  "hybrid-search",
+			}).BuildRequest(ctx, conf, "query")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(req.Pipeline, convey.ShouldEqual, "hybrid-search")
+			convey.So(marshal(req), convey.ShouldEqual, `{"query":{"hybrid":{"queries":[`+
+				`{"match":{"content":{"query":"query"}}},`+
+				`{"knn":{"vector":{"k":10,"vector":[1.1,1.2]}}}]}},"size":5}`)
+		})
+
+		convey.Convey("test temporary pipeline with filters", func() {
+			req, err := SearchModeHybrid(&HybridConfig{
+				QueryFieldName:  "content",
+				VectorFieldName: "vector",
+				Normalization:   &oscli.NormalizationConfig{Weights: []float64{0.3, 0.7}},
+			}).BuildRequest(ctx, conf, "query", opensearch.WithFilters([]map[string]any{{"term": map[string]any{"source": "a.md"}}}))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(req.Pipeline, convey.ShouldBeEmpty)
+			convey.So(marshal(req), convey.ShouldEqual, `{"query":{"hybrid":{"queries":[`+
+				`{"bool":{"filter":[{"term":{"source":"a.md"}}],"must":[{"match":{"content":{"query":"query"}}}]}},`+
+				`{"knn":{"vector":{"filter":{"bool":{"filter":[{"term":{"source":"a.md"}}]}},"k":5,"vector":[1.1,1.2]}}}]}},`+
+				`"search_pipeline":{"description":"hybrid search score normalization","phase_results_processors":[{"normalization-processor":{`+
+				`"combination":{"parameters":{"weights":[0.3,0.7]},"technique":"arithmetic_mean"},"normalization":{"technique":"min_max"}}}]},"size":5}`)
+		})
+
+		convey.Convey("test errors", func() {
+			_, err := SearchModeHybrid(&HybridConfig{VectorFieldName: "vector", SearchPipeline: "p"}).BuildRequest(ctx, conf, "query")
+			convey.So(err, convey.ShouldNotBeNil)
+
+			_, err = SearchModeHybrid(&HybridConfig{QueryFieldName: "content", VectorFieldName: "vector"}).BuildRequest(ctx, conf, "query")
+			convey.So(err, convey.ShouldNotBeNil)
+
+			_, err = SearchModeHybrid(&HybridConfig{QueryFieldName: "content", VectorFieldName: "vector", SearchPipeline: "p",
+				Normalization: &oscli.NormalizationConfig{}}).BuildRequest(ctx, conf, "query")
+			convey.So(err, convey.ShouldNotBeNil)
+
+			_, err = SearchModeHybrid(&HybridConfig{QueryFieldName: "content", VectorFieldName: "vector", SearchPipeline: "p"}).
+				BuildRequest(ctx, &opensearch.RetrieverConfig{TopK: 5}, "query")
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
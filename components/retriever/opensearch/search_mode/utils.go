@@ -0,0 +1,88 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package search_mode
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+
+	"github.com/cloudwego/eino-ext/components/retriever/opensearch"
+)
+
+// commonOptions returns the common options of opts, defaulting to conf.
+func commonOptions(conf *opensearch.RetrieverConfig, opts []retriever.Option) *retriever.Options {
+	return retriever.GetCommonOptions(&retriever.Options{
+		Index:          &conf.Index,
+		TopK:           &conf.TopK,
+		ScoreThreshold: conf.ScoreThreshold,
+		Embedding:      conf.Embedding,
+	}, opts...)
+}
+
+// embedQuery returns the vector of query by emb.
+func embedQuery(ctx context.Context, emb embedding.Embedder, query string) ([]float64, error) {
+	if emb == nil {
+		return nil, fmt.Errorf("embedding not provided")
+	}
+
+	vectors, err := emb.EmbedStrings(makeEmbeddingCtx(ctx, emb), []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("embedding failed, %w", err)
+	}
+
+	if len(vectors) != 1 {
+		return nil, fmt.Errorf("vector len error, expected=1, got=%d", len(vectors))
+	}
+
+	return vectors[0], nil
+}
+
+// knnQuery returns a knn query of field, with filters applied during the search if there're any.
+func knnQuery(field string, vector []float64, k int, params map[string]any, filters []map[string]any) map[string]any {
+	q := map[string]any{"vector": vector, "k": k}
+	if len(params) > 0 {
+		q["method_parameters"] = params
+	}
+	if len(filters) > 0 {
+		q["filter"] = filterQuery(filters)
+	}
+	return map[string]any{"knn": map[string]any{field: q}}
+}
+
+// filterQuery returns a bool query of filters in filter context.
+func filterQuery(filters []map[string]any) map[string]any {
+	return map[string]any{"bool": map[string]any{"filter": filters}}
+}
+
+func makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfEmbedding,
+	}
+
+	if embType, ok := components.GetType(emb); ok {
+		runInfo.Type = embType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
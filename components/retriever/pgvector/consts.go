@@ -0,0 +1,41 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pgvector
+
+const typ = "PGVector"
+
+func GetType() string {
+	return typ
+}
+
+const (
+	defaultTopK = 5
+
+	defaultIDColumn       = "id"
+	defaultContentColumn  = "content"
+	defaultMetadataColumn = "metadata"
+	defaultVectorColumn   = "embedding"
+
+	defaultTextSearchConfig = "english"
+)
+
+const (
+	// MetaKeyTextScore is the metadata key of the ts_rank_cd score in hybrid mode, absent if the document only matched the vector query.
+	MetaKeyTextScore = "text_score"
+	// MetaKeyVectorDistance is the metadata key of the vector distance in hybrid mode, absent if the document only matched the text query.
+	MetaKeyVectorDistance = "vector_distance"
+)
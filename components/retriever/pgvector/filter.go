@@ -0,0 +1,141 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pgvector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+)
+
+// TranslateFilter translates f to a sql condition on the jsonb column metadataColumn, with placeholders from $1 and their args.
+// Eq, Ne and In are jsonb containment (@>), Range and Exists are jsonpath predicates (@?),
+// all supported by a GIN index of the column, e.g. USING gin (metadata jsonb_path_ops).
+// Range compares numbers with numbers and strings with strings, documents of other value types don't match.
+// Ne and Not match documents without the field.
+func TranslateFilter(f *filter.Filter, metadataColumn string) (string, []any, error) {
+	b := &binder{}
+	cond, _, err := filter.Translate(f, func(f *filter.Filter) (string, error) {
+		return translateFilter(f, quoteIdent(metadataColumn), b)
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return cond, b.args, nil
+}
+
+func translateFilter(f *filter.Filter, column string, b *binder) (string, error) {
+	switch f.Op {
+	case filter.OpEq:
+		return containsCond(column, f.Field, f.Value, b)
+	case filter.OpNe:
+		cond, err := containsCond(column, f.Field, f.Value, b)
+		if err != nil {
+			return "", err
+		}
+		return "NOT " + cond, nil
+	case filter.OpIn:
+		conds := make([]string, 0, len(f.Values))
+		for _, v := range f.Values {
+			cond, err := containsCond(column, f.Field, v, b)
+			if err != nil {
+				return "", err
+			}
+			conds = append(conds, cond)
+		}
+		return joinConds(conds, " OR "), nil
+	case filter.OpRange:
+		var preds []string
+		for _, bound := range []struct {
+			op    string
+			value any
+		}{{">", f.Bounds.Gt}, {">=", f.Bounds.Gte}, {"<", f.Bounds.Lt}, {"<=", f.Bounds.Lte}} {
+			if bound.value == nil {
+				continue
+			}
+			literal, err := json.Marshal(bound.value)
+			if err != nil {
+				return "", fmt.Errorf("[filter] marshal range bound of field %q failed, %w", f.Field, err)
+			}
+			preds = append(preds, fmt.Sprintf("@ %s %s", bound.op, literal))
+		}
+		path := fmt.Sprintf("%s ? (%s)", jsonPathKey(f.Field), strings.Join(preds, " && "))
+		return fmt.Sprintf("%s @? %s::jsonpath", column, b.bind(path)), nil
+	case filter.OpExists:
+		return fmt.Sprintf("%s @? %s::jsonpath", column, b.bind(jsonPathKey(f.Field))), nil
+	case filter.OpAnd, filter.OpOr:
+		conds := make([]string, 0, len(f.Children))
+		for _, child := range f.Children {
+			cond, err := translateFilter(child, column, b)
+			if err != nil {
+				return "", err
+			}
+			conds = append(conds, cond)
+		}
+		return joinConds(conds, " "+strings.ToUpper(string(f.Op))+" "), nil
+	default: // filter.OpNot, validated to have one child
+		cond, err := translateFilter(f.Children[0], column, b)
+		if err != nil {
+			return "", err
+		}
+		return "NOT (" + cond + ")", nil
+	}
+}
+
+// containsCond matches documents whose field equals value, jsonb compares numbers regardless of their types.
+func containsCond(column, field string, value any, b *binder) (string, error) {
+	contains, err := json.Marshal(map[string]any{field: value})
+	if err != nil {
+		return "", fmt.Errorf("[filter] marshal value of field %q failed, %w", field, err)
+	}
+	return fmt.Sprintf("(%s @> %s::jsonb)", column, b.bind(string(contains))), nil
+}
+
+func joinConds(conds []string, sep string) string {
+	if len(conds) == 1 {
+		return conds[0]
+	}
+	return "(" + strings.Join(conds, sep) + ")"
+}
+
+// jsonPathKey returns the jsonpath of a top level key, e.g. $."page".
+func jsonPathKey(field string) string {
+	key, _ := json.Marshal(field)
+	return "$." + string(key)
+}
+
+// binder collects the args of positional placeholders.
+type binder struct {
+	args []any
+}
+
+func (b *binder) bind(v any) string {
+	b.args = append(b.args, v)
+	return "$" + strconv.Itoa(len(b.args))
+}
+
+// quoteIdent quotes a possibly schema qualified identifier, e.g. public.docs to "public"."docs".
+func quoteIdent(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = `"` + strings.ReplaceAll(part, `"`, `""`) + `"`
+	}
+	return strings.Join(parts, ".")
+}
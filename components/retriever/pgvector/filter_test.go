@@ -0,0 +1,64 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pgvector
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+)
+
+func TestTranslateFilter(t *testing.T) {
+	convey.Convey("test TranslateFilter", t, func() {
+		cases := []struct {
+			f    *filter.Filter
+			cond string
+			args []any
+		}{
+			{filter.Eq("source", "a.md"), `("metadata" @> $1::jsonb)`, []any{`{"source":"a.md"}`}},
+			{filter.Ne("draft", true), `NOT ("metadata" @> $1::jsonb)`, []any{`{"draft":true}`}},
+			{filter.In("page", 1, 2.5), `(("metadata" @> $1::jsonb) OR ("metadata" @> $2::jsonb))`, []any{`{"page":1}`, `{"page":2.5}`}},
+			{filter.Range("page", filter.Bounds{Gt: 1, Lte: 10}), `"metadata" @? $1::jsonpath`, []any{`$."page" ? (@ > 1 && @ <= 10)`}},
+			{filter.Lt("date", "2025-01-01"), `"metadata" @? $1::jsonpath`, []any{`$."date" ? (@ < "2025-01-01")`}},
+			{filter.Exists(`a"b`), `"metadata" @? $1::jsonpath`, []any{`$."a\"b"`}},
+			{
+				filter.And(filter.Eq("lang", "go"), filter.Or(filter.Exists("author"), filter.Not(filter.Gte("page", 3)))),
+				`(("metadata" @> $1::jsonb) AND ("metadata" @? $2::jsonpath OR NOT ("metadata" @? $3::jsonpath)))`,
+				[]any{`{"lang":"go"}`, `$."author"`, `$."page" ? (@ >= 3)`},
+			},
+		}
+
+		for _, c := range cases {
+			cond, args, err := TranslateFilter(c.f, "metadata")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(cond, convey.ShouldEqual, c.cond)
+			convey.So(args, convey.ShouldResemble, c.args)
+		}
+
+		_, _, err := TranslateFilter(filter.And(), "metadata")
+		convey.So(err, convey.ShouldNotBeNil)
+		convey.So(errors.Is(err, filter.ErrUnsupported), convey.ShouldBeFalse)
+
+		cond, args, err := TranslateFilter(nil, "metadata")
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(cond, convey.ShouldEqual, "")
+		convey.So(args, convey.ShouldBeNil)
+	})
+}
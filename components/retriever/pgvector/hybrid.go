@@ -0,0 +1,277 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pgvector
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+type FusionMode string
+
+const (
+	// FusionRRF scores a document by sum of 1/(RankConstant+rank) over the text and vector result lists.
+	// see: https://plg.uwaterloo.ca/~gvcormac/cormacksigir09-rrf.pdf
+	FusionRRF FusionMode = "rrf"
+	// FusionWeighted min-max normalizes text scores and vector distances of each list to [0, 1],
+	// then scores a document by TextWeight*text + VectorWeight*vector, smaller distance scoring higher.
+	FusionWeighted FusionMode = "weighted"
+)
+
+type HybridConfig struct {
+	// TSVColumn tsvector column matched by the query, e.g. content_tsv created by the pgvector indexer with TextSearchConfig.
+	// Default empty, to_tsvector(TextSearchConfig, ContentColumn) is computed for each row, which can't use an index.
+	TSVColumn string
+	// TextSearchConfig text search configuration parsing the query by websearch_to_tsquery, should be the one of TSVColumn.
+	// Default "english".
+	TextSearchConfig string
+	// Fusion method to combine the text and vector result lists.
+	// Default FusionRRF.
+	Fusion FusionMode
+	// RankConstant k of RRF, larger values give lower ranked documents more influence.
+	// Default 60.
+	RankConstant int
+	// TextWeight and VectorWeight of FusionWeighted.
+	// Default 0.5 each if both not set.
+	TextWeight   float64
+	VectorWeight float64
+	// WindowSize number of candidates fetched by each of the two queries before fusion.
+	// Default 2*TopK, and never less than TopK.
+	WindowSize int
+	// ScoreThreshold drops documents with fused score below it.
+	// Default nil.
+	ScoreThreshold *float64
+}
+
+func (h *HybridConfig) check() error {
+	if h.TextSearchConfig == "" {
+		h.TextSearchConfig = defaultTextSearchConfig
+	}
+
+	switch h.Fusion {
+	case "":
+		h.Fusion = FusionRRF
+	case FusionRRF, FusionWeighted:
+	default:
+		return fmt.Errorf("[hybrid] unknown fusion mode: %s", h.Fusion)
+	}
+
+	if h.RankConstant == 0 {
+		h.RankConstant = 60
+	}
+
+	if h.TextWeight < 0 || h.VectorWeight < 0 {
+		return fmt.Errorf("[hybrid] weights should not be negative")
+	}
+
+	if h.TextWeight == 0 && h.VectorWeight == 0 {
+		h.TextWeight, h.VectorWeight = 0.5, 0.5
+	}
+
+	return nil
+}
+
+type hybridHit struct {
+	doc        *schema.Document
+	textScore  *float64
+	distance   *float64
+	textRank   int
+	vectorRank int
+	score      float64
+}
+
+// hybridSearch fetches the nearest WindowSize documents and the WindowSize best full-text matches in one query,
+// joins them by id and fuses their ranks or scores.
+func (r *Retriever) hybridSearch(ctx context.Context, query string, vector []float64, conds []string, b *binder, topK int) ([]*schema.Document, error) {
+	h := r.config.Hybrid
+	window := h.WindowSize
+	if window == 0 {
+		window = 2 * topK
+	}
+	if window < topK {
+		window = topK
+	}
+
+	var (
+		op, _  = r.config.Metric.operator()
+		id     = quoteIdent(r.config.IDColumn)
+		table  = quoteIdent(r.config.Table)
+		tsv    string
+		config = b.bind(h.TextSearchConfig)
+	)
+	if h.TSVColumn != "" {
+		tsv = quoteIdent(h.TSVColumn)
+	} else {
+		tsv = fmt.Sprintf("to_tsvector(%s::regconfig, %s)", config, quoteIdent(r.config.ContentColumn))
+	}
+
+	sqlQuery := fmt.Sprintf("WITH vector_hits AS ("+
+		"SELECT %[1]s AS hit_id, %[2]s %[3]s %[4]s::vector AS distance FROM %[5]s%[6]s ORDER BY distance LIMIT %[7]d"+
+		"), text_hits AS ("+
+		"SELECT %[1]s AS hit_id, ts_rank_cd(%[8]s, hit_query) AS text_score "+
+		"FROM %[5]s, websearch_to_tsquery(%[9]s::regconfig, %[10]s) AS hit_query WHERE %[8]s @@ hit_query%[11]s ORDER BY text_score DESC LIMIT %[7]d"+
+		") SELECT d.%[1]s, d.%[12]s, d.%[13]s, v.distance, t.text_score "+
+		"FROM vector_hits v FULL OUTER JOIN text_hits t ON v.hit_id = t.hit_id "+
+		"JOIN %[5]s d ON d.%[1]s = COALESCE(v.hit_id, t.hit_id)",
+		id, quoteIdent(r.config.VectorColumn), op, b.bind(vectorLiteral(vector)), table, where(conds), window,
+		tsv, config, b.bind(query), and(conds),
+		quoteIdent(r.config.ContentColumn), quoteIdent(r.config.MetadataColumn))
+
+	var hits []*hybridHit
+	err := r.query(ctx, sqlQuery, b.args, func(rows *sql.Rows) error {
+		var distance, textScore sql.NullFloat64
+		doc, err := scanDocument(rows, &distance, &textScore)
+		if err != nil {
+			return err
+		}
+
+		hit := &hybridHit{doc: doc}
+		if distance.Valid {
+			hit.distance = &distance.Float64
+		}
+		if textScore.Valid {
+			hit.textScore = &textScore.Float64
+		}
+		hits = append(hits, hit)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[hybridSearch] %w", err)
+	}
+
+	rankHits(hits)
+	fuse(h, hits)
+
+	ranked := make([]*hybridHit, 0, len(hits))
+	for _, hit := range hits {
+		if h.ScoreThreshold != nil && hit.score < *h.ScoreThreshold {
+			continue
+		}
+		ranked = append(ranked, hit)
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].score > ranked[j].score
+	})
+	if len(ranked) > topK {
+		ranked = ranked[:topK]
+	}
+
+	docs := make([]*schema.Document, 0, len(ranked))
+	for _, hit := range ranked {
+		if hit.textScore != nil {
+			hit.doc.MetaData[MetaKeyTextScore] = *hit.textScore
+		}
+		if hit.distance != nil {
+			hit.doc.MetaData[MetaKeyVectorDistance] = *hit.distance
+		}
+		docs = append(docs, hit.doc.WithScore(hit.score))
+	}
+
+	return docs, nil
+}
+
+// rankHits sets the 1-based ranks of hits in the vector list by ascending distance, and in the text list by descending score,
+// hits keep the order of the id for ties.
+func rankHits(hits []*hybridHit) {
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].doc.ID < hits[j].doc.ID
+	})
+
+	rank := func(has func(hit *hybridHit) bool, less func(a, b *hybridHit) bool, set func(hit *hybridHit, rank int)) {
+		list := make([]*hybridHit, 0, len(hits))
+		for _, hit := range hits {
+			if has(hit) {
+				list = append(list, hit)
+			}
+		}
+		sort.SliceStable(list, func(i, j int) bool { return less(list[i], list[j]) })
+		for i, hit := range list {
+			set(hit, i+1)
+		}
+	}
+
+	rank(func(hit *hybridHit) bool { return hit.distance != nil },
+		func(a, b *hybridHit) bool { return *a.distance < *b.distance },
+		func(hit *hybridHit, rank int) { hit.vectorRank = rank })
+	rank(func(hit *hybridHit) bool { return hit.textScore != nil },
+		func(a, b *hybridHit) bool { return *a.textScore > *b.textScore },
+		func(hit *hybridHit, rank int) { hit.textRank = rank })
+}
+
+func fuse(h *HybridConfig, hits []*hybridHit) {
+	if h.Fusion == FusionRRF {
+		k := float64(h.RankConstant)
+		for _, hit := range hits {
+			if hit.textRank > 0 {
+				hit.score += 1 / (k + float64(hit.textRank))
+			}
+			if hit.vectorRank > 0 {
+				hit.score += 1 / (k + float64(hit.vectorRank))
+			}
+		}
+		return
+	}
+
+	textMin, textMax := scoreRange(hits, func(hit *hybridHit) *float64 { return hit.textScore })
+	distMin, distMax := scoreRange(hits, func(hit *hybridHit) *float64 { return hit.distance })
+	for _, hit := range hits {
+		if hit.textScore != nil {
+			hit.score += h.TextWeight * normalize(*hit.textScore, textMin, textMax)
+		}
+		if hit.distance != nil {
+			hit.score += h.VectorWeight * normalize(-*hit.distance, -distMax, -distMin)
+		}
+	}
+}
+
+func scoreRange(hits []*hybridHit, get func(hit *hybridHit) *float64) (minScore, maxScore float64) {
+	first := true
+	for _, hit := range hits {
+		v := get(hit)
+		if v == nil {
+			continue
+		}
+		if first || *v < minScore {
+			minScore = *v
+		}
+		if first || *v > maxScore {
+			maxScore = *v
+		}
+		first = false
+	}
+	return minScore, maxScore
+}
+
+// normalize maps v to [0, 1] by min-max, a list of equal scores maps to 1.
+func normalize(v, minScore, maxScore float64) float64 {
+	if maxScore == minScore {
+		return 1
+	}
+	return (v - minScore) / (maxScore - minScore)
+}
+
+func and(conds []string) string {
+	if len(conds) == 0 {
+		return ""
+	}
+	return " AND " + strings.Join(conds, " AND ")
+}
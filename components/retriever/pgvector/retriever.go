@@ -0,0 +1,397 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pgvector
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+)
+
+// Metric is the distance of vectors, it must match the operator class of the vector index, otherwise the index isn't used.
+type Metric string
+
+const (
+	// MetricCosine cosine distance, operator <=>, scored by 1 - distance.
+	MetricCosine Metric = "cosine"
+	// MetricL2 euclidean distance, operator <->, scored by 1 / (1 + distance).
+	MetricL2 Metric = "l2"
+	// MetricIP negative inner product, operator <#>, scored by the inner product.
+	MetricIP Metric = "ip"
+)
+
+func (m Metric) operator() (string, error) {
+	switch m {
+	case MetricCosine:
+		return "<=>", nil
+	case MetricL2:
+		return "<->", nil
+	case MetricIP:
+		return "<#>", nil
+	default:
+		return "", fmt.Errorf("unknown metric: %s", m)
+	}
+}
+
+// score converts a distance to a similarity score, higher is more similar.
+func (m Metric) score(distance float64) float64 {
+	switch m {
+	case MetricL2:
+		return 1 / (1 + distance)
+	case MetricIP:
+		return -distance
+	default:
+		return 1 - distance
+	}
+}
+
+type RetrieverConfig struct {
+	// DB postgres database with the pgvector extension, e.g. opened by sql.Open("pgx", dsn)
+	// with driver github.com/jackc/pgx/v5/stdlib, or sql.Open("postgres", dsn) with github.com/lib/pq.
+	// Required.
+	DB *sql.DB
+	// Table to search, may be schema qualified, e.g. "public.docs".
+	// Required.
+	Table string
+	// Embedding vectorization method for query.
+	// Required.
+	Embedding embedding.Embedder
+	// Metric distance of the vector column.
+	// Default MetricCosine.
+	Metric Metric
+	// IDColumn, ContentColumn, MetadataColumn and VectorColumn are the columns of document ID, Content, MetaData (jsonb)
+	// and the vector of the query, default "id", "content", "metadata" and "embedding", the columns of the pgvector indexer.
+	IDColumn       string
+	ContentColumn  string
+	MetadataColumn string
+	VectorColumn   string
+	// TopK limits number of results given.
+	// Default 5.
+	TopK int
+	// ScoreThreshold drops documents with lower score in vector mode, see Metric for the score of each metric.
+	// Default nil.
+	ScoreThreshold *float64
+	// Hybrid if set, Retrieve runs a full-text query besides the vector query, and fuses both result lists, see HybridConfig.
+	// Default nil, vector mode.
+	Hybrid *HybridConfig
+	// SearchSettings are set for the transaction of each search, e.g.
+	// {"hnsw.ef_search": "100", "hnsw.iterative_scan": "relaxed_order", "ivfflat.probes": "10"},
+	// raise them when filters drop too many candidates of the vector index.
+	// see: https://github.com/pgvector/pgvector#query-options
+	// Default nil.
+	SearchSettings map[string]string
+}
+
+type Retriever struct {
+	config *RetrieverConfig
+}
+
+func NewRetriever(_ context.Context, config *RetrieverConfig) (*Retriever, error) {
+	if config.DB == nil {
+		return nil, fmt.Errorf("[NewRetriever] db not provided")
+	}
+
+	if config.Table == "" {
+		return nil, fmt.Errorf("[NewRetriever] table not provided")
+	}
+
+	if config.Embedding == nil {
+		return nil, fmt.Errorf("[NewRetriever] embedding not provided")
+	}
+
+	if config.Metric == "" {
+		config.Metric = MetricCosine
+	}
+
+	if _, err := config.Metric.operator(); err != nil {
+		return nil, fmt.Errorf("[NewRetriever] %w", err)
+	}
+
+	if config.IDColumn == "" {
+		config.IDColumn = defaultIDColumn
+	}
+
+	if config.ContentColumn == "" {
+		config.ContentColumn = defaultContentColumn
+	}
+
+	if config.MetadataColumn == "" {
+		config.MetadataColumn = defaultMetadataColumn
+	}
+
+	if config.VectorColumn == "" {
+		config.VectorColumn = defaultVectorColumn
+	}
+
+	if config.TopK == 0 {
+		config.TopK = defaultTopK
+	}
+
+	if config.Hybrid != nil {
+		if err := config.Hybrid.check(); err != nil {
+			return nil, fmt.Errorf("[NewRetriever] %w", err)
+		}
+	}
+
+	return &Retriever{
+		config: config,
+	}, nil
+}
+
+func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) (docs []*schema.Document, err error) {
+	co := retriever.GetCommonOptions(&retriever.Options{
+		TopK:           &r.config.TopK,
+		ScoreThreshold: r.config.ScoreThreshold,
+		Embedding:      r.config.Embedding,
+	}, opts...)
+	io := retriever.GetImplSpecificOptions(&implOptions{}, opts...)
+	f := filter.GetFilter(opts...)
+
+	b := &binder{}
+	conds, err := r.filterConds(io, f, b)
+
+	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
+	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
+		Query:          query,
+		TopK:           *co.TopK,
+		Filter:         filterString(io, f),
+		ScoreThreshold: co.ScoreThreshold,
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	if err != nil {
+		return nil, fmt.Errorf("[pgvector retriever] invalid filter: %w", err)
+	}
+
+	emb := co.Embedding
+	if emb == nil {
+		return nil, fmt.Errorf("[pgvector retriever] embedding not provided")
+	}
+
+	vectors, err := emb.EmbedStrings(r.makeEmbeddingCtx(ctx, emb), []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("[pgvector retriever] embedding has error: %w", err)
+	}
+
+	if len(vectors) != 1 {
+		return nil, fmt.Errorf("[pgvector retriever] invalid return length of vector, got=%d, expected=1", len(vectors))
+	}
+
+	if r.config.Hybrid != nil {
+		docs, err = r.hybridSearch(ctx, query, vectors[0], conds, b, *co.TopK)
+	} else {
+		docs, err = r.vectorSearch(ctx, vectors[0], conds, b, *co.TopK, co.ScoreThreshold)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
+
+	return docs, nil
+}
+
+func (r *Retriever) vectorSearch(ctx context.Context, vector []float64, conds []string, b *binder, topK int, threshold *float64) ([]*schema.Document, error) {
+	op, _ := r.config.Metric.operator()
+	query := fmt.Sprintf("SELECT %s, %s, %s, %s %s %s::vector AS distance FROM %s%s ORDER BY distance LIMIT %d",
+		quoteIdent(r.config.IDColumn), quoteIdent(r.config.ContentColumn), quoteIdent(r.config.MetadataColumn),
+		quoteIdent(r.config.VectorColumn), op, b.bind(vectorLiteral(vector)),
+		quoteIdent(r.config.Table), where(conds), topK)
+
+	var docs []*schema.Document
+	err := r.query(ctx, query, b.args, func(rows *sql.Rows) error {
+		var distance float64
+		doc, err := scanDocument(rows, &distance)
+		if err != nil {
+			return err
+		}
+
+		score := r.config.Metric.score(distance)
+		if threshold != nil && score < *threshold {
+			return nil
+		}
+		docs = append(docs, doc.WithScore(score))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[vectorSearch] %w", err)
+	}
+
+	return docs, nil
+}
+
+// query runs query in a read only transaction with SearchSettings, and calls scan for each row.
+func (r *Retriever) query(ctx context.Context, query string, args []any, scan func(rows *sql.Rows) error) error {
+	tx, err := r.config.DB.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("begin failed, %w", err)
+	}
+	// nothing is written, the transaction only scopes the settings
+	defer func() { _ = tx.Rollback() }()
+
+	keys := make([]string, 0, len(r.config.SearchSettings))
+	for k := range r.config.SearchSettings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if _, err = tx.ExecContext(ctx, "SELECT set_config($1, $2, true)", k, r.config.SearchSettings[k]); err != nil {
+			return fmt.Errorf("set %s failed, %w", k, err)
+		}
+	}
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("query failed, %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err = scan(rows); err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("read rows failed, %w", err)
+	}
+
+	return nil
+}
+
+// filterConds returns the sql conditions of the metadata filter and the portable filter.
+func (r *Retriever) filterConds(io *implOptions, f *filter.Filter, b *binder) ([]string, error) {
+	var conds []string
+	column := quoteIdent(r.config.MetadataColumn)
+
+	if len(io.MetadataFilter) > 0 {
+		contains, err := json.Marshal(io.MetadataFilter)
+		if err != nil {
+			return nil, fmt.Errorf("marshal metadata filter failed, %w", err)
+		}
+		conds = append(conds, fmt.Sprintf("%s @> %s::jsonb", column, b.bind(string(contains))))
+	}
+
+	cond, ok, err := filter.Translate(f, func(f *filter.Filter) (string, error) {
+		return translateFilter(f, column, b)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		conds = append(conds, cond)
+	}
+
+	return conds, nil
+}
+
+func (r *Retriever) makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfEmbedding,
+	}
+
+	if embType, ok := components.GetType(emb); ok {
+		runInfo.Type = embType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+func (r *Retriever) GetType() string {
+	return typ
+}
+
+func (r *Retriever) IsCallbacksEnabled() bool {
+	return true
+}
+
+// scanDocument scans the id, content and metadata columns of a row to a document, followed by the scores.
+func scanDocument(rows *sql.Rows, scores ...any) (*schema.Document, error) {
+	var (
+		id       string
+		content  sql.NullString
+		metadata []byte
+	)
+
+	if err := rows.Scan(append([]any{&id, &content, &metadata}, scores...)...); err != nil {
+		return nil, fmt.Errorf("scan row failed, %w", err)
+	}
+
+	doc := &schema.Document{ID: id, Content: content.String, MetaData: map[string]any{}}
+	if len(metadata) > 0 {
+		if err := json.Unmarshal(metadata, &doc.MetaData); err != nil {
+			return nil, fmt.Errorf("unmarshal metadata failed, id=%s, %w", id, err)
+		}
+		if doc.MetaData == nil {
+			doc.MetaData = map[string]any{}
+		}
+	}
+
+	return doc, nil
+}
+
+func filterString(io *implOptions, f *filter.Filter) string {
+	var parts []string
+	if len(io.MetadataFilter) > 0 {
+		b, _ := json.Marshal(io.MetadataFilter)
+		parts = append(parts, "metadata @> "+string(b))
+	}
+	if f != nil {
+		parts = append(parts, f.String())
+	}
+	return strings.Join(parts, " AND ")
+}
+
+func where(conds []string) string {
+	if len(conds) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conds, " AND ")
+}
+
+// vectorLiteral formats v as the text input of the pgvector vector type, e.g. [1,2.5,3].
+func vectorLiteral(v []float64) string {
+	var sb strings.Builder
+	sb.WriteByte('[')
+	for i, f := range v {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(strconv.FormatFloat(f, 'g', -1, 32))
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
@@ -0,0 +1,265 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pgvector
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+)
+
+type mockEmbedding struct {
+	vector []float64
+	err    error
+}
+
+func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return [][]float64{m.vector}, nil
+}
+
+func newMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, mock
+}
+
+func TestNewRetriever(t *testing.T) {
+	convey.Convey("test NewRetriever", t, func() {
+		ctx := context.Background()
+		db, _ := newMockDB(t)
+		emb := &mockEmbedding{}
+
+		_, err := NewRetriever(ctx, &RetrieverConfig{Table: "docs", Embedding: emb})
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = NewRetriever(ctx, &RetrieverConfig{DB: db, Embedding: emb})
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = NewRetriever(ctx, &RetrieverConfig{DB: db, Table: "docs"})
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = NewRetriever(ctx, &RetrieverConfig{DB: db, Table: "docs", Embedding: emb, Metric: "x"})
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = NewRetriever(ctx, &RetrieverConfig{DB: db, Table: "docs", Embedding: emb, Hybrid: &HybridConfig{Fusion: "x"}})
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = NewRetriever(ctx, &RetrieverConfig{DB: db, Table: "docs", Embedding: emb, Hybrid: &HybridConfig{TextWeight: -1}})
+		convey.So(err, convey.ShouldNotBeNil)
+
+		r, err := NewRetriever(ctx, &RetrieverConfig{DB: db, Table: "docs", Embedding: emb, Hybrid: &HybridConfig{}})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(r.config.TopK, convey.ShouldEqual, defaultTopK)
+		convey.So(r.config.Metric, convey.ShouldEqual, MetricCosine)
+		convey.So(r.config.Hybrid.Fusion, convey.ShouldEqual, FusionRRF)
+		convey.So(r.config.Hybrid.TextSearchConfig, convey.ShouldEqual, "english")
+	})
+}
+
+func TestVectorSearch(t *testing.T) {
+	convey.Convey("test vector search", t, func() {
+		ctx := context.Background()
+		db, mock := newMockDB(t)
+		emb := &mockEmbedding{vector: []float64{0.5, 1}}
+
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			DB:             db,
+			Table:          "public.docs",
+			Embedding:      emb,
+			TopK:           3,
+			SearchSettings: map[string]string{"hnsw.iterative_scan": "relaxed_order", "hnsw.ef_search": "100"},
+		})
+		convey.So(err, convey.ShouldBeNil)
+
+		convey.Convey("test retrieve", func() {
+			mock.ExpectBegin()
+			mock.ExpectExec("SELECT set_config($1, $2, true)").WithArgs("hnsw.ef_search", "100").WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec("SELECT set_config($1, $2, true)").WithArgs("hnsw.iterative_scan", "relaxed_order").WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectQuery(`SELECT "id", "content", "metadata", "embedding" <=> $3::vector AS distance FROM "public"."docs" `+
+				`WHERE "metadata" @> $1::jsonb AND "metadata" @? $2::jsonpath ORDER BY distance LIMIT 2`).
+				WithArgs(`{"lang":"go"}`, `$."page" ? (@ >= 1)`, "[0.5,1]").
+				WillReturnRows(sqlmock.NewRows([]string{"id", "content", "metadata", "distance"}).
+					AddRow("1", "a", []byte(`{"lang":"go","page":1}`), 0.1).
+					AddRow("2", nil, nil, 0.6))
+			mock.ExpectRollback()
+
+			var input *retriever.CallbackInput
+			ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, callbacks.NewHandlerBuilder().
+				OnStartFn(func(ctx context.Context, info *callbacks.RunInfo, in callbacks.CallbackInput) context.Context {
+					input = retriever.ConvCallbackInput(in)
+					return ctx
+				}).Build())
+
+			docs, err := r.Retrieve(ctx, "query",
+				retriever.WithTopK(2),
+				retriever.WithScoreThreshold(0.5),
+				WithMetadataFilter(map[string]any{"lang": "go"}),
+				filter.WithFilter(filter.Gte("page", 1)),
+			)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(len(docs), convey.ShouldEqual, 1)
+			convey.So(docs[0].ID, convey.ShouldEqual, "1")
+			convey.So(docs[0].Content, convey.ShouldEqual, "a")
+			convey.So(docs[0].MetaData["page"], convey.ShouldEqual, float64(1))
+			convey.So(docs[0].Score(), convey.ShouldAlmostEqual, 0.9)
+			convey.So(input.Filter, convey.ShouldEqual, `metadata @> {"lang":"go"} AND range(page, gte 1)`)
+			convey.So(mock.ExpectationsWereMet(), convey.ShouldBeNil)
+		})
+
+		convey.Convey("test metrics", func() {
+			r.config.SearchSettings = nil
+			for _, c := range []struct {
+				metric Metric
+				op     string
+				score  float64
+			}{
+				{MetricL2, "<->", 1.0 / 3},
+				{MetricIP, "<#>", -2},
+			} {
+				r.config.Metric = c.metric
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT "id", "content", "metadata", "embedding" ` + c.op + ` $1::vector AS distance FROM "public"."docs" ORDER BY distance LIMIT 3`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "content", "metadata", "distance"}).AddRow("1", "a", []byte(`{}`), 2.0))
+				mock.ExpectRollback()
+
+				docs, err := r.Retrieve(ctx, "query")
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(docs[0].Score(), convey.ShouldAlmostEqual, c.score)
+			}
+			convey.So(mock.ExpectationsWereMet(), convey.ShouldBeNil)
+		})
+
+		convey.Convey("test error", func() {
+			_, err := r.Retrieve(ctx, "query", filter.WithFilter(filter.In("page")))
+			convey.So(err, convey.ShouldNotBeNil)
+
+			mock.ExpectBegin()
+			mock.ExpectExec("SELECT set_config($1, $2, true)").WillReturnError(errors.New("mock err"))
+			mock.ExpectRollback()
+			_, err = r.Retrieve(ctx, "query")
+			convey.So(err, convey.ShouldNotBeNil)
+
+			emb.err = errors.New("mock err")
+			_, err = r.Retrieve(ctx, "query")
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(mock.ExpectationsWereMet(), convey.ShouldBeNil)
+		})
+	})
+}
+
+func TestHybridSearch(t *testing.T) {
+	convey.Convey("test hybrid search", t, func() {
+		ctx := context.Background()
+		db, mock := newMockDB(t)
+
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			DB:        db,
+			Table:     "docs",
+			Embedding: &mockEmbedding{vector: []float64{1, 0}},
+			TopK:      2,
+			Hybrid:    &HybridConfig{TSVColumn: "content_tsv"},
+		})
+		convey.So(err, convey.ShouldBeNil)
+
+		rows := func() *sqlmock.Rows {
+			return sqlmock.NewRows([]string{"id", "content", "metadata", "distance", "text_score"}).
+				AddRow("1", "a", []byte(`{}`), 0.1, nil).
+				AddRow("2", "b", []byte(`{}`), 0.3, 0.5).
+				AddRow("3", "c", []byte(`{}`), nil, 0.9).
+				AddRow("4", "d", []byte(`{}`), 0.2, 0.1)
+		}
+
+		convey.Convey("test rrf", func() {
+			mock.ExpectBegin()
+			mock.ExpectQuery(`WITH vector_hits AS (`+
+				`SELECT "id" AS hit_id, "embedding" <=> $3::vector AS distance FROM "docs" WHERE "metadata" @> $1::jsonb ORDER BY distance LIMIT 4`+
+				`), text_hits AS (`+
+				`SELECT "id" AS hit_id, ts_rank_cd("content_tsv", hit_query) AS text_score `+
+				`FROM "docs", websearch_to_tsquery($2::regconfig, $4) AS hit_query WHERE "content_tsv" @@ hit_query AND "metadata" @> $1::jsonb ORDER BY text_score DESC LIMIT 4`+
+				`) SELECT d."id", d."content", d."metadata", v.distance, t.text_score `+
+				`FROM vector_hits v FULL OUTER JOIN text_hits t ON v.hit_id = t.hit_id `+
+				`JOIN "docs" d ON d."id" = COALESCE(v.hit_id, t.hit_id)`).
+				WithArgs(`{"lang":"go"}`, "english", "[1,0]", "query").
+				WillReturnRows(rows())
+			mock.ExpectRollback()
+
+			docs, err := r.Retrieve(ctx, "query", WithMetadataFilter(map[string]any{"lang": "go"}))
+			convey.So(err, convey.ShouldBeNil)
+			// vector ranks 1, 4, 2, text ranks 3, 2, 4, ties keep the id order
+			convey.So(len(docs), convey.ShouldEqual, 2)
+			convey.So(docs[0].ID, convey.ShouldEqual, "2")
+			convey.So(docs[0].Score(), convey.ShouldAlmostEqual, 1.0/62+1.0/63)
+			convey.So(docs[0].MetaData[MetaKeyTextScore], convey.ShouldEqual, 0.5)
+			convey.So(docs[0].MetaData[MetaKeyVectorDistance], convey.ShouldEqual, 0.3)
+			convey.So(docs[1].ID, convey.ShouldEqual, "4")
+			convey.So(mock.ExpectationsWereMet(), convey.ShouldBeNil)
+		})
+
+		convey.Convey("test weighted", func() {
+			threshold := 0.4
+			r.config.Hybrid = &HybridConfig{Fusion: FusionWeighted, TextWeight: 0.5, VectorWeight: 0.5, WindowSize: 10, ScoreThreshold: &threshold}
+			convey.So(r.config.Hybrid.check(), convey.ShouldBeNil)
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(`WITH vector_hits AS (`+
+				`SELECT "id" AS hit_id, "embedding" <=> $2::vector AS distance FROM "docs" ORDER BY distance LIMIT 10`+
+				`), text_hits AS (`+
+				`SELECT "id" AS hit_id, ts_rank_cd(to_tsvector($1::regconfig, "content"), hit_query) AS text_score `+
+				`FROM "docs", websearch_to_tsquery($1::regconfig, $3) AS hit_query WHERE to_tsvector($1::regconfig, "content") @@ hit_query ORDER BY text_score DESC LIMIT 10`+
+				`) SELECT d."id", d."content", d."metadata", v.distance, t.text_score `+
+				`FROM vector_hits v FULL OUTER JOIN text_hits t ON v.hit_id = t.hit_id `+
+				`JOIN "docs" d ON d."id" = COALESCE(v.hit_id, t.hit_id)`).
+				WithArgs("english", "[1,0]", "query").
+				WillReturnRows(rows())
+			mock.ExpectRollback()
+
+			docs, err := r.Retrieve(ctx, "query", retriever.WithTopK(3))
+			convey.So(err, convey.ShouldBeNil)
+			// 1: 0.5*1, 2: 0.5*0.5+0.5*0, 3: 0.5*1, 4: 0.5*0+0.5*0.5
+			convey.So(len(docs), convey.ShouldEqual, 2)
+			convey.So(docs[0].ID, convey.ShouldEqual, "1")
+			convey.So(docs[1].ID, convey.ShouldEqual, "3")
+			convey.So(docs[1].Score(), convey.ShouldAlmostEqual, 0.5)
+			convey.So(mock.ExpectationsWereMet(), convey.ShouldBeNil)
+		})
+	})
+}
+
+func TestScanDocument(t *testing.T) {
+	convey.Convey("test scan document", t, func() {
+		db, mock := newMockDB(t)
+		mock.ExpectQuery("q").WillReturnRows(sqlmock.NewRows([]string{"id", "content", "metadata"}).AddRow(1, "a", []byte(`[1]`)))
+
+		rows, err := db.Query("q")
+		convey.So(err, convey.ShouldBeNil)
+		defer rows.Close()
+		convey.So(rows.Next(), convey.ShouldBeTrue)
+		_, err = scanDocument(rows)
+		convey.So(err, convey.ShouldNotBeNil)
+
+		convey.So(vectorLiteral([]float64{1, 0.25}), convey.ShouldEqual, "[1,0.25]")
+	})
+}
@@ -0,0 +1,33 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+const typ = "Qdrant"
+
+func GetType() string {
+	return typ
+}
+
+const (
+	defaultTopK = 5
+
+	defaultContentKey  = "content"
+	defaultMetadataKey = "metadata"
+
+	// payloadKeyDocID is the payload key of the document id set by the qdrant indexer.
+	payloadKeyDocID = "doc_id"
+)
@@ -0,0 +1,105 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+	"github.com/cloudwego/eino-ext/components/retriever/qdrant"
+	qdrantcli "github.com/cloudwego/eino-ext/libs/acl/qdrant"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// e.g. http://localhost:6333
+	cli, err := qdrantcli.NewRESTClient(&qdrantcli.Config{
+		BaseURL: os.Getenv("QDRANT_URL"),
+		APIKey:  os.Getenv("QDRANT_API_KEY"),
+	})
+	if err != nil {
+		log.Fatalf("NewRESTClient failed, err=%v", err)
+	}
+
+	// the collection eino_docs is created and filled by the example of the qdrant indexer
+	r, err := qdrant.NewRetriever(ctx, &qdrant.RetrieverConfig{
+		Client:     cli,
+		Collection: "eino_docs",
+		VectorName: "dense",
+		Embedding:  &letterEmbedding{}, // replace it with real embedding component
+		TopK:       3,
+		Hybrid: &qdrant.HybridConfig{
+			SparseVectorName: "sparse",
+			SparseEmbedding:  termFrequencies, // replace it with the sparse embedding of the indexer
+			Fusion:           qdrantcli.FusionRRF,
+		},
+	})
+	if err != nil {
+		log.Fatalf("NewRetriever failed, err=%v", err)
+	}
+
+	docs, err := r.Retrieve(ctx, "eino framework",
+		retriever.WithTopK(2),
+		qdrant.WithFilter(&qdrantcli.Filter{Must: []*qdrantcli.Condition{qdrantcli.NewMatch("metadata._source", "intro.md")}}),
+		filter.WithFilter(filter.Lte("page", 2)),
+	)
+	if err != nil {
+		log.Fatalf("Retrieve failed, err=%v", err)
+	}
+	for _, doc := range docs {
+		log.Printf("id=%s, score=%.4f, content=%s", doc.ID, doc.Score(), doc.Content)
+	}
+}
+
+// letterEmbedding embeds a text to its letter frequencies, only for the example.
+type letterEmbedding struct{}
+
+func (l *letterEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = make([]float64, 26)
+		for _, c := range strings.ToLower(text) {
+			if c >= 'a' && c <= 'z' {
+				vectors[i][c-'a']++
+			}
+		}
+	}
+	return vectors, nil
+}
+
+// termFrequencies maps words to hashed indices with their counts, only for the example.
+func termFrequencies(ctx context.Context, texts []string) ([]map[int]float64, error) {
+	result := make([]map[int]float64, len(texts))
+	for i, text := range texts {
+		result[i] = map[int]float64{}
+		for _, word := range strings.Fields(strings.ToLower(text)) {
+			h := 0
+			for _, c := range word {
+				h = (h*31 + int(c)) % 100003
+			}
+			result[i][h]++
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,164 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+import (
+	"math"
+	"reflect"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+	"github.com/cloudwego/eino-ext/libs/acl/qdrant"
+)
+
+const storeName = "qdrant"
+
+// TranslateFilter translates f to a qdrant filter on the payload, fields are keys of the metadataKey payload object.
+// Eq and In match keywords, integers and bools exactly, non-integral floats are matched by a closed range.
+// Range only compares numbers. Ne and Not match documents without the field, Exists matches fields which aren't null or [].
+// see: https://qdrant.tech/documentation/concepts/filtering/
+func TranslateFilter(f *filter.Filter, metadataKey string) (*qdrant.Filter, error) {
+	cond, ok, err := filter.Translate(f, func(f *filter.Filter) (*qdrant.Condition, error) {
+		return translateFilter(f, metadataKey)
+	})
+	if err != nil || !ok {
+		return nil, err
+	}
+	return toFilter(cond), nil
+}
+
+func translateFilter(f *filter.Filter, metadataKey string) (*qdrant.Condition, error) {
+	key := metadataKey + "." + f.Field
+
+	switch f.Op {
+	case filter.OpEq:
+		return matchCondition(f, key, f.Value)
+	case filter.OpNe:
+		cond, err := matchCondition(f, key, f.Value)
+		if err != nil {
+			return nil, err
+		}
+		return qdrant.NewFilterCondition(&qdrant.Filter{MustNot: []*qdrant.Condition{cond}}), nil
+	case filter.OpIn:
+		if canMatchAny(f.Values) {
+			return qdrant.NewMatchAny(key, f.Values...), nil
+		}
+		conds := make([]*qdrant.Condition, 0, len(f.Values))
+		for _, v := range f.Values {
+			cond, err := matchCondition(f, key, v)
+			if err != nil {
+				return nil, err
+			}
+			conds = append(conds, cond)
+		}
+		return qdrant.NewFilterCondition(&qdrant.Filter{Should: conds}), nil
+	case filter.OpRange:
+		r := &qdrant.Range{}
+		for _, bound := range []struct {
+			target **float64
+			value  any
+		}{{&r.Gt, f.Bounds.Gt}, {&r.Gte, f.Bounds.Gte}, {&r.Lt, f.Bounds.Lt}, {&r.Lte, f.Bounds.Lte}} {
+			if bound.value == nil {
+				continue
+			}
+			if !filter.IsNumber(bound.value) {
+				return nil, filter.Unsupported(storeName, f, "range bounds must be numbers")
+			}
+			v := toFloat(bound.value)
+			*bound.target = &v
+		}
+		return qdrant.NewRange(key, r), nil
+	case filter.OpExists:
+		return qdrant.NewFilterCondition(&qdrant.Filter{MustNot: []*qdrant.Condition{qdrant.NewIsEmpty(key)}}), nil
+	case filter.OpAnd, filter.OpOr, filter.OpNot:
+		conds := make([]*qdrant.Condition, 0, len(f.Children))
+		for _, child := range f.Children {
+			cond, err := translateFilter(child, metadataKey)
+			if err != nil {
+				return nil, err
+			}
+			conds = append(conds, cond)
+		}
+		switch f.Op {
+		case filter.OpAnd:
+			return qdrant.NewFilterCondition(&qdrant.Filter{Must: conds}), nil
+		case filter.OpOr:
+			return qdrant.NewFilterCondition(&qdrant.Filter{Should: conds}), nil
+		default:
+			return qdrant.NewFilterCondition(&qdrant.Filter{MustNot: conds}), nil
+		}
+	default:
+		return nil, filter.Unsupported(storeName, f, "")
+	}
+}
+
+// matchCondition matches key equal to v. Integral floats, e.g. numbers decoded from json, are matched as integers,
+// and other floats by a closed range, as qdrant only matches keywords, integers and bools.
+func matchCondition(f *filter.Filter, key string, v any) (*qdrant.Condition, error) {
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.String:
+		return qdrant.NewMatch(key, rv.String()), nil
+	case reflect.Bool:
+		return qdrant.NewMatch(key, rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return qdrant.NewMatch(key, rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return qdrant.NewMatch(key, rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		n := rv.Float()
+		if n == math.Trunc(n) && math.Abs(n) < 1<<53 {
+			return qdrant.NewMatch(key, int64(n)), nil
+		}
+		return qdrant.NewRange(key, &qdrant.Range{Gte: &n, Lte: &n}), nil
+	default:
+		return nil, filter.Unsupported(storeName, f, "values must be strings, bools or numbers")
+	}
+}
+
+// canMatchAny reports whether values are all strings or all integers, which match any accepts.
+func canMatchAny(values []any) bool {
+	var strs, ints int
+	for _, v := range values {
+		switch reflect.ValueOf(v).Kind() {
+		case reflect.String:
+			strs++
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			ints++
+		}
+	}
+	return strs == len(values) || ints == len(values)
+}
+
+func toFloat(v any) float64 {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint())
+	default:
+		return rv.Float()
+	}
+}
+
+// toFilter returns the nested filter of cond, or a filter of must cond.
+func toFilter(cond *qdrant.Condition) *qdrant.Filter {
+	if cond.Filter != nil && cond.Key == "" && cond.HasID == nil && cond.IsEmpty == nil && cond.IsNull == nil {
+		return cond.Filter
+	}
+	return &qdrant.Filter{Must: []*qdrant.Condition{cond}}
+}
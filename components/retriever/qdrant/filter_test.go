@@ -0,0 +1,87 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+)
+
+func TestTranslateFilter(t *testing.T) {
+	convey.Convey("test TranslateFilter", t, func() {
+		cases := []struct {
+			f    *filter.Filter
+			json string
+		}{
+			{filter.Eq("source", "a.md"), `{"must":[{"key":"metadata.source","match":{"value":"a.md"}}]}`},
+			{filter.Eq("page", float64(2)), `{"must":[{"key":"metadata.page","match":{"value":2}}]}`},
+			{filter.Eq("score", 0.5), `{"must":[{"key":"metadata.score","range":{"gte":0.5,"lte":0.5}}]}`},
+			{filter.Ne("draft", false), `{"must_not":[{"key":"metadata.draft","match":{"value":false}}]}`},
+			{filter.In("lang", "go", "rust"), `{"must":[{"key":"metadata.lang","match":{"any":["go","rust"]}}]}`},
+			{filter.In("page", 1, uint8(2)), `{"must":[{"key":"metadata.page","match":{"any":[1,2]}}]}`},
+			{
+				filter.In("page", 1, 2.5),
+				`{"should":[{"key":"metadata.page","match":{"value":1}},{"key":"metadata.page","range":{"gte":2.5,"lte":2.5}}]}`,
+			},
+			{filter.Range("page", filter.Bounds{Gt: 1, Lte: float32(10)}), `{"must":[{"key":"metadata.page","range":{"gt":1,"lte":10}}]}`},
+			{filter.Exists("author"), `{"must_not":[{"is_empty":{"key":"metadata.author"}}]}`},
+			{
+				filter.And(filter.Eq("lang", "go"), filter.Or(filter.Exists("author"), filter.Not(filter.Gte("page", uint(3))))),
+				`{"must":[
+					{"key":"metadata.lang","match":{"value":"go"}},
+					{"should":[
+						{"must_not":[{"is_empty":{"key":"metadata.author"}}]},
+						{"must_not":[{"key":"metadata.page","range":{"gte":3}}]}
+					]}
+				]}`,
+			},
+		}
+
+		for _, c := range cases {
+			qf, err := TranslateFilter(c.f, "metadata")
+			convey.So(err, convey.ShouldBeNil)
+			b, err := json.Marshal(qf)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(string(b), convey.ShouldEqualJSON, c.json)
+		}
+
+		_, err := TranslateFilter(filter.Lt("date", "2025-01-01"), "metadata")
+		convey.So(errors.Is(err, filter.ErrUnsupported), convey.ShouldBeTrue)
+
+		_, err = TranslateFilter(filter.Not(filter.Gt("date", "2025-01-01")), "metadata")
+		convey.So(errors.Is(err, filter.ErrUnsupported), convey.ShouldBeTrue)
+
+		_, err = TranslateFilter(filter.In("page", 1, "2.5", []int{1}), "metadata")
+		convey.So(err, convey.ShouldNotBeNil)
+
+		_, err = TranslateFilter(filter.And(), "metadata")
+		convey.So(err, convey.ShouldNotBeNil)
+		convey.So(errors.Is(err, filter.ErrUnsupported), convey.ShouldBeFalse)
+
+		_, err = TranslateFilter(filter.Ne("tags", struct{}{}), "metadata")
+		convey.So(err, convey.ShouldNotBeNil)
+
+		qf, err := TranslateFilter(nil, "metadata")
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(qf, convey.ShouldBeNil)
+	})
+}
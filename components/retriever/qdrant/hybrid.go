@@ -0,0 +1,121 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino-ext/libs/acl/qdrant"
+)
+
+type HybridConfig struct {
+	// SparseVectorName sparse vector searched besides the dense vector, e.g. the one stored by the qdrant indexer.
+	// Required.
+	SparseVectorName string
+	// SparseEmbedding produces the sparse vector of the query, it should be the one of the indexed documents.
+	// Required.
+	SparseEmbedding func(ctx context.Context, texts []string) ([]map[int]float64, error)
+	// Fusion method to combine the dense and sparse result lists, qdrant.FusionRRF or qdrant.FusionDBSF.
+	// Default qdrant.FusionRRF.
+	Fusion qdrant.Fusion
+	// WindowSize number of candidates fetched by each of the two prefetch queries before fusion.
+	// Default 2*TopK, and never less than TopK.
+	WindowSize int
+	// ScoreThreshold drops documents with fused score below it.
+	// Default nil.
+	ScoreThreshold *float64
+}
+
+func (h *HybridConfig) check() error {
+	if h.SparseVectorName == "" {
+		return fmt.Errorf("[hybrid] sparse vector name not provided")
+	}
+
+	if h.SparseEmbedding == nil {
+		return fmt.Errorf("[hybrid] sparse embedding not provided")
+	}
+
+	switch h.Fusion {
+	case "":
+		h.Fusion = qdrant.FusionRRF
+	case qdrant.FusionRRF, qdrant.FusionDBSF:
+	default:
+		return fmt.Errorf("[hybrid] unknown fusion: %s", h.Fusion)
+	}
+
+	if h.WindowSize < 0 {
+		return fmt.Errorf("[hybrid] invalid window size: %d", h.WindowSize)
+	}
+
+	return nil
+}
+
+func (h *HybridConfig) windowSize(topK int) int {
+	size := h.WindowSize
+	if size == 0 {
+		size = 2 * topK
+	}
+	if size < topK {
+		size = topK
+	}
+	return size
+}
+
+// hybridQuery prefetches the candidates of the dense and the sparse vector of the query, both filtered by filter,
+// then fuses them. The score threshold of the dense vector applies to its prefetch.
+func (r *Retriever) hybridQuery(ctx context.Context, query string, req *qdrant.QueryRequest) (*qdrant.QueryRequest, error) {
+	h := r.config.Hybrid
+
+	sparse, err := h.SparseEmbedding(ctx, []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("[hybridQuery] sparse embedding has error: %w", err)
+	}
+
+	if len(sparse) != 1 {
+		return nil, fmt.Errorf("[hybridQuery] invalid return length of sparse vector, got=%d, expected=1", len(sparse))
+	}
+
+	sv, err := qdrant.NewSparseVector(sparse[0])
+	if err != nil {
+		return nil, fmt.Errorf("[hybridQuery] %w", err)
+	}
+
+	window := h.windowSize(req.Limit)
+	return &qdrant.QueryRequest{
+		Prefetch: []*qdrant.Prefetch{
+			{
+				Query:          req.Query,
+				Using:          req.Using,
+				Filter:         req.Filter,
+				Params:         req.Params,
+				Limit:          window,
+				ScoreThreshold: req.ScoreThreshold,
+			},
+			{
+				Query:  sv,
+				Using:  h.SparseVectorName,
+				Filter: req.Filter,
+				Limit:  window,
+			},
+		},
+		Query:          &qdrant.FusionQuery{Fusion: h.Fusion},
+		Limit:          req.Limit,
+		ScoreThreshold: h.ScoreThreshold,
+		WithPayload:    true,
+	}, nil
+}
@@ -0,0 +1,35 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+import (
+	"github.com/cloudwego/eino/components/retriever"
+
+	"github.com/cloudwego/eino-ext/libs/acl/qdrant"
+)
+
+type implOptions struct {
+	Filter *qdrant.Filter
+}
+
+// WithFilter sets a qdrant filter on the payload, keys of metadata are prefixed by MetadataKey, e.g. "metadata.page".
+// It's combined with filter.WithFilter of github.com/cloudwego/eino-ext/components/retriever/filter, if both are set.
+func WithFilter(filter *qdrant.Filter) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.Filter = filter
+	})
+}
@@ -0,0 +1,254 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+	"github.com/cloudwego/eino-ext/libs/acl/qdrant"
+)
+
+type RetrieverConfig struct {
+	// Client qdrant client, e.g. qdrant.NewRESTClient of github.com/cloudwego/eino-ext/libs/acl/qdrant.
+	// Required.
+	Client qdrant.Client
+	// Collection to search.
+	// Required.
+	Collection string
+	// Embedding vectorization method for query.
+	// Required.
+	Embedding embedding.Embedder
+	// VectorName of the dense vector searched.
+	// Default "", the unnamed vector of the collection.
+	VectorName string
+	// ContentKey and MetadataKey are the payload keys of document Content and MetaData,
+	// default "content" and "metadata", the keys of the qdrant indexer.
+	ContentKey  string
+	MetadataKey string
+	// TopK limits number of results given.
+	// Default 5.
+	TopK int
+	// ScoreThreshold drops documents with lower score of the dense vector, the similarity for Cosine and Dot,
+	// for Euclid and Manhattan, whose score is the distance, it drops documents of larger distance.
+	// Default nil.
+	ScoreThreshold *float64
+	// SearchParams of the dense vector search, e.g. HnswEf, raise it when filters drop too many candidates.
+	// Default nil.
+	SearchParams *qdrant.SearchParams
+	// Hybrid if set, Retrieve searches the sparse vector of the query besides the dense vector, and fuses both result lists,
+	// see HybridConfig.
+	// Default nil, dense vector mode.
+	Hybrid *HybridConfig
+}
+
+type Retriever struct {
+	config *RetrieverConfig
+}
+
+func NewRetriever(_ context.Context, config *RetrieverConfig) (*Retriever, error) {
+	if config.Client == nil {
+		return nil, fmt.Errorf("[NewRetriever] qdrant client not provided")
+	}
+
+	if config.Collection == "" {
+		return nil, fmt.Errorf("[NewRetriever] collection not provided")
+	}
+
+	if config.Embedding == nil {
+		return nil, fmt.Errorf("[NewRetriever] embedding not provided")
+	}
+
+	if config.ContentKey == "" {
+		config.ContentKey = defaultContentKey
+	}
+
+	if config.MetadataKey == "" {
+		config.MetadataKey = defaultMetadataKey
+	}
+
+	if config.TopK == 0 {
+		config.TopK = defaultTopK
+	}
+
+	if config.Hybrid != nil {
+		if err := config.Hybrid.check(); err != nil {
+			return nil, fmt.Errorf("[NewRetriever] %w", err)
+		}
+	}
+
+	return &Retriever{
+		config: config,
+	}, nil
+}
+
+func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) (docs []*schema.Document, err error) {
+	co := retriever.GetCommonOptions(&retriever.Options{
+		TopK:           &r.config.TopK,
+		ScoreThreshold: r.config.ScoreThreshold,
+		Embedding:      r.config.Embedding,
+	}, opts...)
+	io := retriever.GetImplSpecificOptions(&implOptions{}, opts...)
+	f := filter.GetFilter(opts...)
+
+	qf, err := r.makeFilter(io, f)
+
+	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
+	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
+		Query:          query,
+		TopK:           *co.TopK,
+		Filter:         filterString(io, f),
+		ScoreThreshold: co.ScoreThreshold,
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	if err != nil {
+		return nil, fmt.Errorf("[qdrant retriever] invalid filter: %w", err)
+	}
+
+	emb := co.Embedding
+	if emb == nil {
+		return nil, fmt.Errorf("[qdrant retriever] embedding not provided")
+	}
+
+	vectors, err := emb.EmbedStrings(r.makeEmbeddingCtx(ctx, emb), []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("[qdrant retriever] embedding has error: %w", err)
+	}
+
+	if len(vectors) != 1 {
+		return nil, fmt.Errorf("[qdrant retriever] invalid return length of vector, got=%d, expected=1", len(vectors))
+	}
+
+	req := &qdrant.QueryRequest{
+		Query:          vectors[0],
+		Using:          r.config.VectorName,
+		Filter:         qf,
+		Params:         r.config.SearchParams,
+		Limit:          *co.TopK,
+		ScoreThreshold: co.ScoreThreshold,
+		WithPayload:    true,
+	}
+
+	if r.config.Hybrid != nil {
+		if req, err = r.hybridQuery(ctx, query, req); err != nil {
+			return nil, err
+		}
+	}
+
+	points, err := r.config.Client.QueryPoints(ctx, r.config.Collection, req)
+	if err != nil {
+		return nil, fmt.Errorf("[qdrant retriever] %w", err)
+	}
+
+	docs = make([]*schema.Document, 0, len(points))
+	for _, p := range points {
+		docs = append(docs, r.toDocument(p))
+	}
+
+	callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
+
+	return docs, nil
+}
+
+// makeFilter combines the qdrant filter and the translated portable filter.
+func (r *Retriever) makeFilter(io *implOptions, f *filter.Filter) (*qdrant.Filter, error) {
+	translated, err := TranslateFilter(f, r.config.MetadataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case io.Filter == nil:
+		return translated, nil
+	case translated == nil:
+		return io.Filter, nil
+	default:
+		return &qdrant.Filter{Must: []*qdrant.Condition{
+			qdrant.NewFilterCondition(io.Filter),
+			qdrant.NewFilterCondition(translated),
+		}}, nil
+	}
+}
+
+// toDocument maps a point to a document, the id is the doc_id payload, or the point id if absent.
+func (r *Retriever) toDocument(p *qdrant.ScoredPoint) *schema.Document {
+	doc := &schema.Document{MetaData: map[string]any{}}
+
+	if id, ok := p.Payload[payloadKeyDocID].(string); ok {
+		doc.ID = id
+	} else {
+		doc.ID = fmt.Sprint(p.ID)
+	}
+
+	if content, ok := p.Payload[r.config.ContentKey].(string); ok {
+		doc.Content = content
+	}
+
+	if metadata, ok := p.Payload[r.config.MetadataKey].(map[string]any); ok {
+		doc.MetaData = metadata
+	}
+
+	return doc.WithScore(p.Score)
+}
+
+func (r *Retriever) makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfEmbedding,
+	}
+
+	if embType, ok := components.GetType(emb); ok {
+		runInfo.Type = embType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+func (r *Retriever) GetType() string {
+	return typ
+}
+
+func (r *Retriever) IsCallbacksEnabled() bool {
+	return true
+}
+
+func filterString(io *implOptions, f *filter.Filter) string {
+	var parts []string
+	if io.Filter != nil {
+		b, _ := json.Marshal(io.Filter)
+		parts = append(parts, string(b))
+	}
+	if f != nil {
+		parts = append(parts, f.String())
+	}
+	return strings.Join(parts, " AND ")
+}
@@ -0,0 +1,290 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+	"github.com/cloudwego/eino-ext/libs/acl/qdrant"
+)
+
+type mockEmbedding struct {
+	vectors [][]float64
+	err     error
+}
+
+func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	return m.vectors, m.err
+}
+
+// fakeQdrant records the body of query requests and replies with result.
+type fakeQdrant struct {
+	path   string
+	body   string
+	status int
+	result string
+}
+
+func newFakeQdrant(t *testing.T) (*fakeQdrant, qdrant.Client) {
+	f := &fakeQdrant{status: http.StatusOK, result: `{"points":[]}`}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body json.RawMessage
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.path, f.body = r.URL.Path, string(body)
+		w.WriteHeader(f.status)
+		if f.status != http.StatusOK {
+			_, _ = fmt.Fprintf(w, `{"status":{"error":%q}}`, f.result)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"result":%s,"status":"ok"}`, f.result)
+	}))
+	t.Cleanup(srv.Close)
+
+	cli, err := qdrant.NewRESTClient(&qdrant.Config{BaseURL: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f, cli
+}
+
+func TestNewRetriever(t *testing.T) {
+	convey.Convey("test NewRetriever", t, func() {
+		ctx := context.Background()
+		_, cli := newFakeQdrant(t)
+		emb := &mockEmbedding{}
+
+		_, err := NewRetriever(ctx, &RetrieverConfig{})
+		convey.So(err, convey.ShouldNotBeNil)
+
+		_, err = NewRetriever(ctx, &RetrieverConfig{Client: cli})
+		convey.So(err, convey.ShouldNotBeNil)
+
+		_, err = NewRetriever(ctx, &RetrieverConfig{Client: cli, Collection: "docs"})
+		convey.So(err, convey.ShouldNotBeNil)
+
+		for _, h := range []*HybridConfig{
+			{},
+			{SparseVectorName: "sparse"},
+			{SparseVectorName: "sparse", SparseEmbedding: sparseEmbedding, Fusion: "sum"},
+			{SparseVectorName: "sparse", SparseEmbedding: sparseEmbedding, WindowSize: -1},
+		} {
+			_, err = NewRetriever(ctx, &RetrieverConfig{Client: cli, Collection: "docs", Embedding: emb, Hybrid: h})
+			convey.So(err, convey.ShouldNotBeNil)
+		}
+
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			Client:     cli,
+			Collection: "docs",
+			Embedding:  emb,
+			Hybrid:     &HybridConfig{SparseVectorName: "sparse", SparseEmbedding: sparseEmbedding},
+		})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(r.config.TopK, convey.ShouldEqual, defaultTopK)
+		convey.So(r.config.ContentKey, convey.ShouldEqual, defaultContentKey)
+		convey.So(r.config.MetadataKey, convey.ShouldEqual, defaultMetadataKey)
+		convey.So(r.config.Hybrid.Fusion, convey.ShouldEqual, qdrant.FusionRRF)
+		convey.So(r.GetType(), convey.ShouldEqual, GetType())
+		convey.So(r.IsCallbacksEnabled(), convey.ShouldBeTrue)
+	})
+}
+
+func TestRetrieve(t *testing.T) {
+	convey.Convey("test Retrieve", t, func() {
+		ctx := context.Background()
+		fake, cli := newFakeQdrant(t)
+		hnswEf := 128
+		threshold := 0.3
+
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			Client:         cli,
+			Collection:     "docs",
+			Embedding:      &mockEmbedding{vectors: [][]float64{{0.1, 0.2}}},
+			VectorName:     "dense",
+			TopK:           2,
+			ScoreThreshold: &threshold,
+			SearchParams:   &qdrant.SearchParams{HnswEf: &hnswEf},
+		})
+		convey.So(err, convey.ShouldBeNil)
+
+		convey.Convey("test dense search", func() {
+			fake.result = `{"points":[
+				{"id":"3f622591-baa6-5888-8a4f-6b3813e16a44","score":0.9,"payload":{"doc_id":"doc-1","content":"a","metadata":{"page":1}}},
+				{"id":7,"score":0.5,"payload":{"content":"b"}}
+			]}`
+			docs, err := r.Retrieve(ctx, "query")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(fake.path, convey.ShouldEqual, "/collections/docs/points/query")
+			convey.So(fake.body, convey.ShouldEqualJSON, `{
+				"query":[0.1,0.2],"using":"dense","params":{"hnsw_ef":128},
+				"limit":2,"score_threshold":0.3,"with_payload":true
+			}`)
+			convey.So(docs, convey.ShouldResemble, []*schema.Document{
+				(&schema.Document{ID: "doc-1", Content: "a", MetaData: map[string]any{"page": 1.0}}).WithScore(0.9),
+				(&schema.Document{ID: "7", Content: "b", MetaData: map[string]any{}}).WithScore(0.5),
+			})
+		})
+
+		convey.Convey("test options and filters", func() {
+			_, err := r.Retrieve(ctx, "query",
+				retriever.WithTopK(4),
+				retriever.WithScoreThreshold(0.6),
+				WithFilter(&qdrant.Filter{Must: []*qdrant.Condition{qdrant.NewMatch("metadata.lang", "go")}}),
+				filter.WithFilter(filter.Gte("page", 2)),
+			)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(fake.body, convey.ShouldEqualJSON, `{
+				"query":[0.1,0.2],"using":"dense","params":{"hnsw_ef":128},
+				"filter":{"must":[
+					{"must":[{"key":"metadata.lang","match":{"value":"go"}}]},
+					{"must":[{"key":"metadata.page","range":{"gte":2}}]}
+				]},
+				"limit":4,"score_threshold":0.6,"with_payload":true
+			}`)
+
+			_, err = r.Retrieve(ctx, "query", WithFilter(&qdrant.Filter{MustNot: []*qdrant.Condition{qdrant.NewHasID(uint64(1))}}))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(fake.body, convey.ShouldContainSubstring, `"filter":{"must_not":[{"has_id":[1]}]}`)
+
+			other := &mockEmbedding{vectors: [][]float64{{1}}}
+			_, err = r.Retrieve(ctx, "query", retriever.WithEmbedding(other))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(fake.body, convey.ShouldContainSubstring, `"query":[1]`)
+		})
+
+		convey.Convey("test error", func() {
+			_, err := r.Retrieve(ctx, "query", filter.WithFilter(filter.Lt("date", "2025")))
+			convey.So(err, convey.ShouldNotBeNil)
+
+			_, err = r.Retrieve(ctx, "query", retriever.WithEmbedding(&mockEmbedding{err: fmt.Errorf("mock err")}))
+			convey.So(err, convey.ShouldNotBeNil)
+
+			_, err = r.Retrieve(ctx, "query", retriever.WithEmbedding(&mockEmbedding{}))
+			convey.So(err, convey.ShouldNotBeNil)
+
+			r.config.Embedding = nil
+			_, err = r.Retrieve(ctx, "query")
+			convey.So(err, convey.ShouldNotBeNil)
+
+			r.config.Embedding = &mockEmbedding{vectors: [][]float64{{1}}}
+			fake.status, fake.result = http.StatusNotFound, "Collection docs doesn't exist"
+			_, err = r.Retrieve(ctx, "query")
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "Collection docs doesn't exist")
+		})
+	})
+}
+
+func TestHybridRetrieve(t *testing.T) {
+	convey.Convey("test hybrid Retrieve", t, func() {
+		ctx := context.Background()
+		fake, cli := newFakeQdrant(t)
+		threshold, fusedThreshold := 0.2, 0.01
+
+		hybrid := &HybridConfig{SparseVectorName: "sparse", SparseEmbedding: sparseEmbedding, ScoreThreshold: &fusedThreshold}
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			Client:         cli,
+			Collection:     "docs",
+			Embedding:      &mockEmbedding{vectors: [][]float64{{0.1, 0.2}}},
+			VectorName:     "dense",
+			TopK:           3,
+			ScoreThreshold: &threshold,
+			Hybrid:         hybrid,
+		})
+		convey.So(err, convey.ShouldBeNil)
+
+		convey.Convey("test rrf", func() {
+			fake.result = `{"points":[{"id":1,"score":0.03,"payload":{"doc_id":"1","content":"a"}}]}`
+			docs, err := r.Retrieve(ctx, "eino graph", filter.WithFilter(filter.Eq("lang", "go")))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(fake.body, convey.ShouldEqualJSON, `{
+				"prefetch":[
+					{"query":[0.1,0.2],"using":"dense","filter":{"must":[{"key":"metadata.lang","match":{"value":"go"}}]},"limit":6,"score_threshold":0.2},
+					{"query":{"indices":[4,5],"values":[1,1]},"using":"sparse","filter":{"must":[{"key":"metadata.lang","match":{"value":"go"}}]},"limit":6}
+				],
+				"query":{"fusion":"rrf"},
+				"limit":3,"score_threshold":0.01,"with_payload":true
+			}`)
+			convey.So(docs, convey.ShouldHaveLength, 1)
+			convey.So(docs[0].Score(), convey.ShouldEqual, 0.03)
+		})
+
+		convey.Convey("test dbsf and window size", func() {
+			hybrid.Fusion, hybrid.WindowSize, hybrid.ScoreThreshold = qdrant.FusionDBSF, 1, nil
+			_, err := r.Retrieve(ctx, "eino")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(fake.body, convey.ShouldEqualJSON, `{
+				"prefetch":[
+					{"query":[0.1,0.2],"using":"dense","limit":3,"score_threshold":0.2},
+					{"query":{"indices":[4],"values":[1]},"using":"sparse","limit":3}
+				],
+				"query":{"fusion":"dbsf"},
+				"limit":3,"with_payload":true
+			}`)
+		})
+
+		convey.Convey("test sparse embedding error", func() {
+			hybrid.SparseEmbedding = func(ctx context.Context, texts []string) ([]map[int]float64, error) {
+				return nil, fmt.Errorf("mock err")
+			}
+			_, err := r.Retrieve(ctx, "eino")
+			convey.So(err, convey.ShouldNotBeNil)
+
+			hybrid.SparseEmbedding = func(ctx context.Context, texts []string) ([]map[int]float64, error) {
+				return nil, nil
+			}
+			_, err = r.Retrieve(ctx, "eino")
+			convey.So(err, convey.ShouldNotBeNil)
+
+			hybrid.SparseEmbedding = func(ctx context.Context, texts []string) ([]map[int]float64, error) {
+				return []map[int]float64{{-1: 1}}, nil
+			}
+			_, err = r.Retrieve(ctx, "eino")
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+// sparseEmbedding maps each word to the index of its length.
+func sparseEmbedding(ctx context.Context, texts []string) ([]map[int]float64, error) {
+	result := make([]map[int]float64, len(texts))
+	for i, text := range texts {
+		result[i] = map[int]float64{}
+		word := 0
+		for _, c := range text + " " {
+			if c == ' ' {
+				if word > 0 {
+					result[i][word]++
+				}
+				word = 0
+				continue
+			}
+			word++
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,34 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package querytransform
+
+const typ = "QueryTransform"
+
+func GetType() string {
+	return typ
+}
+
+const (
+	defaultNumQueries   = 3
+	defaultRankConstant = 60
+)
+
+const (
+	// ExtraKeyQueries is the key of the queries ([]*Query) retrieved with, original one first, in the Extra of
+	// retriever.CallbackOutput, so tracing handlers, e.g. langfuse and cozeloop, record the generated queries.
+	ExtraKeyQueries = "transformed_queries"
+)
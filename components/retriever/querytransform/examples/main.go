@@ -0,0 +1,103 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/retriever/querytransform"
+)
+
+func main() {
+	ctx := context.Background()
+
+	r, err := querytransform.NewRetriever(ctx, &querytransform.Config{
+		Retriever: &keywordRetriever{docs: []*schema.Document{
+			{ID: "1", Content: "eino is a llm application framework in go"},
+			{ID: "2", Content: "agents are built by react graphs with tools"},
+			{ID: "3", Content: "graphs compose components like chat models and retrievers"},
+		}}, // replace it with real retriever component
+		ChatModel: &cannedChatModel{}, // replace it with real chat model component
+		Modes:     []querytransform.Mode{querytransform.ModeMultiQuery, querytransform.ModeStepBack},
+		TopK:      3,
+	})
+	if err != nil {
+		log.Fatalf("NewRetriever failed, err=%v", err)
+	}
+
+	ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, callbacks.NewHandlerBuilder().
+		OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+			if queries, ok := querytransform.QueriesFromExtra(retriever.ConvCallbackOutput(output).Extra); ok {
+				for _, q := range queries {
+					log.Printf("query: mode=%s, text=%s", q.Mode, q.Text)
+				}
+			}
+			return ctx
+		}).Build())
+
+	docs, err := r.Retrieve(ctx, "eino agents?")
+	if err != nil {
+		log.Fatalf("Retrieve failed, err=%v", err)
+	}
+	for _, doc := range docs {
+		log.Printf("id=%s, score=%.4f, content=%s", doc.ID, doc.Score(), doc.Content)
+	}
+}
+
+// cannedChatModel replies canned queries, only for the example.
+type cannedChatModel struct{}
+
+func (c *cannedChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	if strings.Contains(input[0].Content, "step-back") {
+		return schema.AssistantMessage("what are graphs in eino", nil), nil
+	}
+	return schema.AssistantMessage("how to build agents with eino\nwhich tools do eino agents use", nil), nil
+}
+
+func (c *cannedChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	msg, err := c.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return schema.StreamReaderFromArray([]*schema.Message{msg}), nil
+}
+
+// keywordRetriever returns documents sharing words with the query, only for the example.
+type keywordRetriever struct {
+	docs []*schema.Document
+}
+
+func (k *keywordRetriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) ([]*schema.Document, error) {
+	words := strings.Fields(strings.ToLower(strings.Trim(query, "?")))
+	var result []*schema.Document
+	for _, doc := range k.docs {
+		for _, w := range words {
+			if len(w) > 3 && strings.Contains(doc.Content, w) {
+				result = append(result, doc)
+				break
+			}
+		}
+	}
+	return result, nil
+}
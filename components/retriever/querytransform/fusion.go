@@ -0,0 +1,100 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package querytransform
+
+import (
+	"sort"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+type FusionMode string
+
+const (
+	// FusionRRF scores a document by sum of 1/(RankConstant+rank) over the result lists of the queries,
+	// documents found by more queries rank higher.
+	// see: https://plg.uwaterloo.ca/~gvcormac/cormacksigir09-rrf.pdf
+	FusionRRF FusionMode = "rrf"
+	// FusionMaxScore scores a document by its highest score of the inner retriever over the result lists,
+	// which suits retrievers whose scores of different queries are comparable, e.g. cosine similarity.
+	FusionMaxScore FusionMode = "max_score"
+)
+
+type fused struct {
+	doc   *schema.Document
+	score float64
+}
+
+// fuse merges the result lists of queries into one list of distinct documents by descending fused score,
+// ties keep the order of first appearance. Returned documents are copies scored by the fused score.
+func fuse(results [][]*schema.Document, mode FusionMode, rankConstant int) []*schema.Document {
+	var (
+		order []*fused
+		byKey = make(map[string]*fused)
+	)
+
+	for _, docs := range results {
+		for rank, doc := range docs {
+			score := doc.Score()
+			if mode == FusionRRF {
+				score = 1 / float64(rankConstant+rank+1)
+			}
+
+			key := dedupKey(doc)
+			f, found := byKey[key]
+			if !found {
+				f = &fused{doc: doc, score: score}
+				byKey[key] = f
+				order = append(order, f)
+				continue
+			}
+
+			switch {
+			case mode == FusionRRF:
+				f.score += score
+			case score > f.score:
+				f.doc, f.score = doc, score
+			}
+		}
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return order[i].score > order[j].score
+	})
+
+	docs := make([]*schema.Document, len(order))
+	for i, f := range order {
+		docs[i] = copyDocument(f.doc).WithScore(f.score)
+	}
+	return docs
+}
+
+func dedupKey(doc *schema.Document) string {
+	if doc.ID != "" {
+		return "id:" + doc.ID
+	}
+	return "content:" + doc.Content
+}
+
+// copyDocument copies doc and its metadata, so that scoring it doesn't change the documents of the inner retriever.
+func copyDocument(doc *schema.Document) *schema.Document {
+	metadata := make(map[string]any, len(doc.MetaData)+1)
+	for k, v := range doc.MetaData {
+		metadata[k] = v
+	}
+	return &schema.Document{ID: doc.ID, Content: doc.Content, MetaData: metadata}
+}
@@ -0,0 +1,278 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package querytransform
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+)
+
+type Config struct {
+	// Retriever inner retriever, which Retrieve retrieves with each query, with the options of Retrieve.
+	// Required.
+	Retriever retriever.Retriever
+	// ChatModel generates the queries of Modes.
+	// Required.
+	ChatModel model.BaseChatModel
+	// Modes to transform the query, each generates its queries concurrently.
+	// Default {ModeMultiQuery}.
+	Modes []Mode
+	// NumQueries max number of paraphrases of ModeMultiQuery.
+	// Default 3.
+	NumQueries int
+	// ExcludeOriginal doesn't retrieve with the original query, only with the generated ones.
+	// Default false.
+	ExcludeOriginal bool
+	// Prompts overrides the default prompt of modes, e.g. DefaultMultiQueryPrompt,
+	// "{query}" is replaced by the query, and "{num}" by NumQueries.
+	// ModeMultiQuery expects one query per line in the reply, the other modes take the whole reply.
+	// Default nil.
+	Prompts map[Mode]string
+	// Fusion method to merge the documents of the queries, deduplicated by ID, or by Content if ID is empty.
+	// Default FusionRRF.
+	Fusion FusionMode
+	// RankConstant k of FusionRRF.
+	// Default 60.
+	RankConstant int
+	// TopK limits number of fused results, and is the default TopK of the inner retriever,
+	// retriever.WithTopK of Retrieve overrides both.
+	// Default 0, the default TopK of the inner retriever, and no limit of fused results.
+	TopK int
+}
+
+type Retriever struct {
+	config *Config
+}
+
+func NewRetriever(_ context.Context, config *Config) (*Retriever, error) {
+	if config.Retriever == nil {
+		return nil, fmt.Errorf("[NewRetriever] inner retriever not provided")
+	}
+
+	if config.ChatModel == nil {
+		return nil, fmt.Errorf("[NewRetriever] chat model not provided")
+	}
+
+	if len(config.Modes) == 0 {
+		config.Modes = []Mode{ModeMultiQuery}
+	}
+
+	seen := make(map[Mode]struct{}, len(config.Modes))
+	for _, mode := range config.Modes {
+		if _, ok := defaultPrompts[mode]; !ok {
+			return nil, fmt.Errorf("[NewRetriever] unknown mode: %s", mode)
+		}
+		if _, found := seen[mode]; found {
+			return nil, fmt.Errorf("[NewRetriever] duplicate mode: %s", mode)
+		}
+		seen[mode] = struct{}{}
+	}
+
+	if config.NumQueries == 0 {
+		config.NumQueries = defaultNumQueries
+	}
+
+	if config.NumQueries < 0 {
+		return nil, fmt.Errorf("[NewRetriever] invalid num queries: %d", config.NumQueries)
+	}
+
+	switch config.Fusion {
+	case "":
+		config.Fusion = FusionRRF
+	case FusionRRF, FusionMaxScore:
+	default:
+		return nil, fmt.Errorf("[NewRetriever] unknown fusion mode: %s", config.Fusion)
+	}
+
+	if config.RankConstant == 0 {
+		config.RankConstant = defaultRankConstant
+	}
+
+	if config.TopK < 0 {
+		return nil, fmt.Errorf("[NewRetriever] invalid top k: %d", config.TopK)
+	}
+
+	return &Retriever{
+		config: config,
+	}, nil
+}
+
+// Retrieve transforms query by Modes, retrieves with the original and the generated queries concurrently,
+// and fuses their documents. The queries are recorded in the callback output Extra, see ExtraKeyQueries.
+func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) (docs []*schema.Document, err error) {
+	if r.config.TopK > 0 {
+		opts = append([]retriever.Option{retriever.WithTopK(r.config.TopK)}, opts...)
+	}
+	co := retriever.GetCommonOptions(&retriever.Options{}, opts...)
+
+	input := &retriever.CallbackInput{
+		Query:          query,
+		ScoreThreshold: co.ScoreThreshold,
+	}
+	if co.TopK != nil {
+		input.TopK = *co.TopK
+	}
+
+	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
+	ctx = callbacks.OnStart(ctx, input)
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	queries, err := r.transform(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("[QueryTransform] %w", err)
+	}
+
+	results, err := r.retrieveAll(ctx, queries, opts)
+	if err != nil {
+		return nil, fmt.Errorf("[QueryTransform] %w", err)
+	}
+
+	docs = fuse(results, r.config.Fusion, r.config.RankConstant)
+	if co.TopK != nil && len(docs) > *co.TopK {
+		docs = docs[:*co.TopK]
+	}
+
+	callbacks.OnEnd(ctx, &retriever.CallbackOutput{
+		Docs:  docs,
+		Extra: map[string]any{ExtraKeyQueries: queries},
+	})
+
+	return docs, nil
+}
+
+// transform returns the original query unless excluded, followed by the queries of each mode in the order of Modes,
+// generated concurrently. Duplicate queries are dropped.
+func (r *Retriever) transform(ctx context.Context, query string) ([]*Query, error) {
+	generated := make([][]string, len(r.config.Modes))
+	errs := make([]error, len(r.config.Modes))
+	parallel(len(r.config.Modes), func(i int) (err error) {
+		generated[i], err = r.generate(ctx, r.config.Modes[i], query)
+		return err
+	}, errs)
+
+	var queries []*Query
+	seen := make(map[string]struct{})
+	add := func(mode Mode, text string) {
+		key := strings.ToLower(strings.TrimSpace(text))
+		if _, found := seen[key]; found {
+			return
+		}
+		seen[key] = struct{}{}
+		queries = append(queries, &Query{Mode: mode, Text: text})
+	}
+
+	if !r.config.ExcludeOriginal {
+		add(ModeOriginal, query)
+	}
+	for i, mode := range r.config.Modes {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		for _, text := range generated[i] {
+			add(mode, text)
+		}
+	}
+
+	if len(queries) == 0 {
+		return nil, fmt.Errorf("no query generated")
+	}
+
+	return queries, nil
+}
+
+// retrieveAll retrieves with each query concurrently, and returns the documents of each query in order.
+func (r *Retriever) retrieveAll(ctx context.Context, queries []*Query, opts []retriever.Option) ([][]*schema.Document, error) {
+	results := make([][]*schema.Document, len(queries))
+	errs := make([]error, len(queries))
+	innerCtx := r.makeRetrieverCtx(ctx)
+
+	parallel(len(queries), func(i int) (err error) {
+		results[i], err = r.config.Retriever.Retrieve(innerCtx, queries[i].Text, opts...)
+		if err != nil {
+			return fmt.Errorf("[retrieveAll] retrieve with %s query %q failed, %w", queries[i].Mode, queries[i].Text, err)
+		}
+		return nil
+	}, errs)
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// parallel runs fn for 0 to n-1 concurrently and waits for them, errs[i] is the error of fn(i) or its panic.
+func parallel(n int, fn func(i int) error, errs []error) {
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() {
+				if e := recover(); e != nil {
+					errs[i] = fmt.Errorf("panic: %v", e)
+				}
+			}()
+			errs[i] = fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// makeRetrieverCtx gives the inner retriever its own run info, so that its callbacks
+// are not reported as the ones of this wrapper.
+func (r *Retriever) makeRetrieverCtx(ctx context.Context) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfRetriever,
+	}
+
+	if retrieverType, ok := components.GetType(r.config.Retriever); ok {
+		runInfo.Type = retrieverType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+// QueriesFromExtra reads the queries recorded in callback output extra.
+func QueriesFromExtra(extra map[string]any) ([]*Query, bool) {
+	queries, ok := extra[ExtraKeyQueries].([]*Query)
+	return queries, ok
+}
+
+func (r *Retriever) GetType() string {
+	return typ
+}
+
+func (r *Retriever) IsCallbacksEnabled() bool {
+	return true
+}
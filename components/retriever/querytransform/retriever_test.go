@@ -0,0 +1,280 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package querytransform
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+// mockChatModel replies to the prompt of each mode.
+type mockChatModel struct {
+	replies map[Mode]string
+	errs    map[Mode]error
+	mu      sync.Mutex
+	prompts []string
+}
+
+func (m *mockChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	prompt := input[0].Content
+	m.mu.Lock()
+	m.prompts = append(m.prompts, prompt)
+	m.mu.Unlock()
+
+	mode := ModeMultiQuery
+	switch {
+	case strings.Contains(prompt, "passage"):
+		mode = ModeHyDE
+	case strings.Contains(prompt, "step-back"):
+		mode = ModeStepBack
+	}
+	if err := m.errs[mode]; err != nil {
+		return nil, err
+	}
+	return schema.AssistantMessage(m.replies[mode], nil), nil
+}
+
+func (m *mockChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+// mockRetriever returns the documents of each query.
+type mockRetriever struct {
+	docs map[string][]*schema.Document
+	err  error
+	mu   sync.Mutex
+	topK map[string]int
+}
+
+func (m *mockRetriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) ([]*schema.Document, error) {
+	co := retriever.GetCommonOptions(&retriever.Options{}, opts...)
+	m.mu.Lock()
+	if m.topK == nil {
+		m.topK = map[string]int{}
+	}
+	if co.TopK != nil {
+		m.topK[query] = *co.TopK
+	} else {
+		m.topK[query] = 0
+	}
+	m.mu.Unlock()
+
+	if query == "panic" {
+		panic("mock panic")
+	}
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.docs[query], nil
+}
+
+func doc(id string, score float64) *schema.Document {
+	return (&schema.Document{ID: id, Content: "content of " + id}).WithScore(score)
+}
+
+func TestNewRetriever(t *testing.T) {
+	convey.Convey("test NewRetriever", t, func() {
+		ctx := context.Background()
+		inner, cm := &mockRetriever{}, &mockChatModel{}
+
+		for _, conf := range []*Config{
+			{},
+			{Retriever: inner},
+			{Retriever: inner, ChatModel: cm, Modes: []Mode{"unknown"}},
+			{Retriever: inner, ChatModel: cm, Modes: []Mode{ModeOriginal}},
+			{Retriever: inner, ChatModel: cm, Modes: []Mode{ModeHyDE, ModeHyDE}},
+			{Retriever: inner, ChatModel: cm, NumQueries: -1},
+			{Retriever: inner, ChatModel: cm, Fusion: "sum"},
+			{Retriever: inner, ChatModel: cm, TopK: -1},
+		} {
+			_, err := NewRetriever(ctx, conf)
+			convey.So(err, convey.ShouldNotBeNil)
+		}
+
+		r, err := NewRetriever(ctx, &Config{Retriever: inner, ChatModel: cm})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(r.config.Modes, convey.ShouldResemble, []Mode{ModeMultiQuery})
+		convey.So(r.config.NumQueries, convey.ShouldEqual, defaultNumQueries)
+		convey.So(r.config.Fusion, convey.ShouldEqual, FusionRRF)
+		convey.So(r.config.RankConstant, convey.ShouldEqual, defaultRankConstant)
+		convey.So(r.GetType(), convey.ShouldEqual, GetType())
+		convey.So(r.IsCallbacksEnabled(), convey.ShouldBeTrue)
+	})
+}
+
+func TestRetrieve(t *testing.T) {
+	convey.Convey("test Retrieve", t, func() {
+		ctx := context.Background()
+		cm := &mockChatModel{replies: map[Mode]string{
+			ModeMultiQuery: "1. what is eino\n\n- eino framework overview\n* What is Eino?\nhow to use eino\nextra",
+			ModeHyDE:       "  Eino is a LLM application framework in Go.  ",
+			ModeStepBack:   "what are llm application frameworks",
+		}}
+		inner := &mockRetriever{docs: map[string][]*schema.Document{
+			"what is eino?":           {doc("a", 0.9), doc("b", 0.8)},
+			"what is eino":            {doc("b", 0.95), doc("c", 0.7)},
+			"eino framework overview": {doc("c", 0.6)},
+			"how to use eino":         {doc("d", 0.5)},
+			"Eino is a LLM application framework in Go.": {doc("a", 0.99)},
+			"what are llm application frameworks":        {doc("e", 0.4)},
+		}}
+
+		r, err := NewRetriever(ctx, &Config{
+			Retriever:  inner,
+			ChatModel:  cm,
+			Modes:      []Mode{ModeMultiQuery, ModeHyDE, ModeStepBack},
+			NumQueries: 4,
+		})
+		convey.So(err, convey.ShouldBeNil)
+
+		var output *retriever.CallbackOutput
+		ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, callbacks.NewHandlerBuilder().
+			OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, out callbacks.CallbackOutput) context.Context {
+				if info.Type == typ {
+					output = retriever.ConvCallbackOutput(out)
+				}
+				return ctx
+			}).Build())
+
+		convey.Convey("test rrf", func() {
+			docs, err := r.Retrieve(ctx, "what is eino?")
+			convey.So(err, convey.ShouldBeNil)
+
+			convey.So(cm.prompts, convey.ShouldHaveLength, 3)
+			for _, prompt := range cm.prompts {
+				convey.So(prompt, convey.ShouldContainSubstring, "Question: what is eino?")
+			}
+
+			// "What is Eino?" duplicates the original query, "extra" exceeds NumQueries
+			queries := []*Query{
+				{Mode: ModeOriginal, Text: "what is eino?"},
+				{Mode: ModeMultiQuery, Text: "what is eino"},
+				{Mode: ModeMultiQuery, Text: "eino framework overview"},
+				{Mode: ModeMultiQuery, Text: "how to use eino"},
+				{Mode: ModeHyDE, Text: "Eino is a LLM application framework in Go."},
+				{Mode: ModeStepBack, Text: "what are llm application frameworks"},
+			}
+			convey.So(output.Extra[ExtraKeyQueries], convey.ShouldResemble, queries)
+			recorded, ok := QueriesFromExtra(output.Extra)
+			convey.So(ok, convey.ShouldBeTrue)
+			convey.So(recorded, convey.ShouldResemble, queries)
+
+			ids := make([]string, len(docs))
+			for i, d := range docs {
+				ids[i] = d.ID
+			}
+			// a: rank 1 twice, b: rank 2 and 1, c: rank 2 and 1, then rank 1 of d and e in order
+			convey.So(ids, convey.ShouldResemble, []string{"a", "b", "c", "d", "e"})
+			convey.So(docs[0].Score(), convey.ShouldAlmostEqual, 2.0/61)
+			convey.So(docs[1].Score(), convey.ShouldAlmostEqual, 1.0/62+1.0/61)
+			convey.So(output.Docs, convey.ShouldResemble, docs)
+
+			// documents of the inner retriever are not changed
+			convey.So(inner.docs["what is eino?"][0].Score(), convey.ShouldEqual, 0.9)
+		})
+
+		convey.Convey("test max score and top k", func() {
+			r.config.Fusion = FusionMaxScore
+			r.config.TopK = 3
+			docs, err := r.Retrieve(ctx, "what is eino?")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(docs, convey.ShouldHaveLength, 3)
+			convey.So(docs[0].ID, convey.ShouldEqual, "a")
+			convey.So(docs[0].Score(), convey.ShouldEqual, 0.99)
+			convey.So(docs[1].ID, convey.ShouldEqual, "b")
+			convey.So(docs[1].Score(), convey.ShouldEqual, 0.95)
+			convey.So(docs[2].ID, convey.ShouldEqual, "c")
+			convey.So(inner.topK["what is eino"], convey.ShouldEqual, 3)
+
+			docs, err = r.Retrieve(ctx, "what is eino?", retriever.WithTopK(1))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(docs, convey.ShouldHaveLength, 1)
+			convey.So(inner.topK["what is eino"], convey.ShouldEqual, 1)
+		})
+
+		convey.Convey("test exclude original and custom prompt", func() {
+			r.config.ExcludeOriginal = true
+			r.config.Modes = []Mode{ModeStepBack}
+			r.config.Prompts = map[Mode]string{ModeStepBack: "step-back of {query}"}
+			docs, err := r.Retrieve(ctx, "what is eino?")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(cm.prompts, convey.ShouldResemble, []string{"step-back of what is eino?"})
+			convey.So(docs, convey.ShouldHaveLength, 1)
+			convey.So(docs[0].ID, convey.ShouldEqual, "e")
+			convey.So(inner.topK, convey.ShouldNotContainKey, "what is eino?")
+		})
+
+		convey.Convey("test error", func() {
+			cm.errs = map[Mode]error{ModeHyDE: fmt.Errorf("mock err")}
+			_, err := r.Retrieve(ctx, "what is eino?")
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "mock err")
+
+			cm.errs = nil
+			inner.err = fmt.Errorf("mock err")
+			_, err = r.Retrieve(ctx, "what is eino?")
+			convey.So(err, convey.ShouldNotBeNil)
+
+			inner.err = nil
+			r.config.Modes = []Mode{ModeHyDE}
+			_, err = r.Retrieve(ctx, "panic")
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "mock panic")
+
+			r.config.ExcludeOriginal = true
+			cm.replies[ModeHyDE] = " "
+			_, err = r.Retrieve(ctx, "what is eino?")
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestTrimListMarker(t *testing.T) {
+	convey.Convey("test trimListMarker", t, func() {
+		for line, expected := range map[string]string{
+			"  plain question ": "plain question",
+			"1. numbered":       "numbered",
+			"12) numbered":      "numbered",
+			"- dash":            "dash",
+			"• bullet":          "bullet",
+			"2024 roadmap":      "2024 roadmap",
+			"":                  "",
+		} {
+			convey.So(trimListMarker(line), convey.ShouldEqual, expected)
+		}
+	})
+}
+
+func TestFuseWithoutID(t *testing.T) {
+	convey.Convey("test fuse documents without id", t, func() {
+		docs := fuse([][]*schema.Document{
+			{{Content: "x"}, {Content: "y"}},
+			{{Content: "y"}},
+		}, FusionRRF, 60)
+		convey.So(docs, convey.ShouldHaveLength, 2)
+		convey.So(docs[0].Content, convey.ShouldEqual, "y")
+	})
+}
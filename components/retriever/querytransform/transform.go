@@ -0,0 +1,143 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package querytransform
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Mode is a way to transform the query of Retrieve.
+type Mode string
+
+const (
+	// ModeOriginal is the mode of the original query.
+	ModeOriginal Mode = "original"
+	// ModeMultiQuery rewrites the query into NumQueries paraphrases from different perspectives.
+	ModeMultiQuery Mode = "multi_query"
+	// ModeHyDE writes a hypothetical answer document of the query, which is closer to the answer documents
+	// in embedding space than a short question.
+	// see: https://arxiv.org/abs/2212.10496
+	ModeHyDE Mode = "hyde"
+	// ModeStepBack rewrites the query into a more general question about its underlying concept,
+	// which retrieves background knowledge.
+	// see: https://arxiv.org/abs/2310.06117
+	ModeStepBack Mode = "step_back"
+)
+
+// Query is a query Retrieve retrieves with, and the mode generating it.
+type Query struct {
+	Mode Mode   `json:"mode"`
+	Text string `json:"text"`
+}
+
+// Default prompts of the modes, "{query}" is replaced by the query, and "{num}" by NumQueries.
+const (
+	DefaultMultiQueryPrompt = `You are helping to retrieve documents from a search index.
+Write {num} different versions of the user question below, each from a different perspective, to improve the recall of similarity search.
+Output one question per line, without numbering or any other text.
+
+Question: {query}`
+
+	DefaultHyDEPrompt = `Write a short passage answering the question below, as it could appear in a reference document.
+Output only the passage.
+
+Question: {query}`
+
+	DefaultStepBackPrompt = `Rewrite the question below as a more general step-back question about its underlying concept or principle,
+which helps to find background knowledge for answering it.
+Output only the question.
+
+Question: {query}`
+)
+
+var defaultPrompts = map[Mode]string{
+	ModeMultiQuery: DefaultMultiQueryPrompt,
+	ModeHyDE:       DefaultHyDEPrompt,
+	ModeStepBack:   DefaultStepBackPrompt,
+}
+
+// generate asks the chat model to transform query by mode.
+func (r *Retriever) generate(ctx context.Context, mode Mode, query string) ([]string, error) {
+	prompt := strings.NewReplacer("{query}", query, "{num}", fmt.Sprint(r.config.NumQueries)).Replace(r.prompt(mode))
+
+	msg, err := r.config.ChatModel.Generate(r.makeChatModelCtx(ctx), []*schema.Message{schema.UserMessage(prompt)})
+	if err != nil {
+		return nil, fmt.Errorf("[generate] %s failed, %w", mode, err)
+	}
+
+	if mode != ModeMultiQuery {
+		text := strings.TrimSpace(msg.Content)
+		if text == "" {
+			return nil, nil
+		}
+		return []string{text}, nil
+	}
+
+	var queries []string
+	for _, line := range strings.Split(msg.Content, "\n") {
+		if q := trimListMarker(line); q != "" {
+			queries = append(queries, q)
+		}
+	}
+	if len(queries) > r.config.NumQueries {
+		queries = queries[:r.config.NumQueries]
+	}
+	return queries, nil
+}
+
+func (r *Retriever) prompt(mode Mode) string {
+	if p, ok := r.config.Prompts[mode]; ok {
+		return p
+	}
+	return defaultPrompts[mode]
+}
+
+// trimListMarker trims spaces and a leading bullet or number, e.g. "- ", "* " or "2. ", of line,
+// which models tend to add despite the prompt.
+func trimListMarker(line string) string {
+	line = strings.TrimSpace(line)
+	if rest := strings.TrimLeft(line, "-*•"); rest != line {
+		return strings.TrimSpace(rest)
+	}
+
+	digits := strings.TrimLeftFunc(line, unicode.IsDigit)
+	if digits != line && (strings.HasPrefix(digits, ".") || strings.HasPrefix(digits, ")")) {
+		return strings.TrimSpace(digits[1:])
+	}
+	return line
+}
+
+func (r *Retriever) makeChatModelCtx(ctx context.Context) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfChatModel,
+	}
+
+	if modelType, ok := components.GetType(r.config.ChatModel); ok {
+		runInfo.Type = modelType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
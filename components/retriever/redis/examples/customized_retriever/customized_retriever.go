@@ -43,9 +43,7 @@ const (
 func main() {
 	ctx := context.Background()
 	client := redis.NewClient(&redis.Options{
-		Addr:          "localhost:6379",
-		Protocol:      2,
-		UnstableResp3: true,
+		Addr: "localhost:6379",
 	})
 
 	b, err := os.ReadFile("./examples/embeddings.json")
@@ -0,0 +1,84 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	rr "github.com/cloudwego/eino-ext/components/retriever/redis"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/redis/go-redis/v9"
+)
+
+// This example related to example in https://github.com/cloudwego/eino-ext/tree/main/components/indexer/redis/examples/default_indexer
+
+func main() {
+	ctx := context.Background()
+	client := redis.NewClient(&redis.Options{
+		Addr: "localhost:6379",
+	})
+
+	b, err := os.ReadFile("./examples/embeddings.json")
+	if err != nil {
+		panic(err)
+	}
+
+	var dense [][]float64
+	if err = json.Unmarshal(b, &dense); err != nil {
+		panic(err)
+	}
+
+	r, err := rr.NewRetriever(ctx, &rr.RetrieverConfig{
+		Client:    client,
+		Index:     "test_index",          // created index name
+		Embedding: &mockEmbedding{dense}, // replace with real embedding.
+		Hybrid: &rr.HybridConfig{
+			TextFields: []string{"content"}, // TEXT fields matched by the query
+			Fusion:     rr.FusionRRF,        // or rr.FusionWeighted with TextWeight / VectorWeight
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	// second page of 3 documents
+	docs, err := r.Retrieve(ctx, "tourist attraction in Paris",
+		retriever.WithTopK(3),
+		rr.WithOffset(3),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, doc := range docs {
+		fmt.Printf("id:%s, score:%.4f, text_score:%v, vector_distance:%v\n", doc.ID, doc.Score(),
+			doc.MetaData[rr.MetaKeyTextScore], doc.MetaData[rr.MetaKeyVectorDistance])
+	}
+}
+
+// mockEmbedding returns embeddings with 1024 dimensions
+type mockEmbedding struct {
+	dense [][]float64
+}
+
+func (m mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	return m.dense[:len(texts)], nil
+}
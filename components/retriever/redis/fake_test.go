@@ -0,0 +1,169 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type fakeDoc struct {
+	id     string
+	score  float64
+	fields map[string]string
+}
+
+// fakeRedis answers commands in-process through a client hook, in place of Redis with the search module.
+// Replies are shaped as RESP2 or RESP3 ones, which is what the client returns for raw commands.
+type fakeRedis struct {
+	resp3 bool
+	// attributes of FT.INFO reply, each is field - value pairs
+	attributes []map[string]any
+	// search returns documents of FT.SEARCH by args
+	search func(args []any) []fakeDoc
+	// searches received FT.SEARCH args
+	searches [][]any
+}
+
+func (f *fakeRedis) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return nil, fmt.Errorf("fake redis doesn't dial")
+	}
+}
+
+func (f *fakeRedis) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return f.process
+}
+
+func (f *fakeRedis) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		for _, cmd := range cmds {
+			if err := f.process(ctx, cmd); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func (f *fakeRedis) process(_ context.Context, cmder redis.Cmder) error {
+	cmd, ok := cmder.(*redis.Cmd)
+	if !ok {
+		err := fmt.Errorf("fake redis: unsupported cmd type %T", cmder)
+		cmder.SetErr(err)
+		return err
+	}
+
+	args := cmd.Args()
+	switch strings.ToUpper(toString(args[0])) {
+	case "FT.INFO":
+		cmd.SetVal(f.infoReply(toString(args[1])))
+	case "FT.SEARCH":
+		f.searches = append(f.searches, args)
+		var docs []fakeDoc
+		if f.search != nil {
+			docs = f.search(args)
+		}
+		cmd.SetVal(f.searchReply(docs, hasArg(args, "WITHSCORES")))
+	default:
+		err := fmt.Errorf("fake redis: unsupported command %v", args[0])
+		cmd.SetErr(err)
+		return err
+	}
+	return nil
+}
+
+func (f *fakeRedis) infoReply(index string) any {
+	attrs := make([]any, 0, len(f.attributes))
+	for _, attr := range f.attributes {
+		attrs = append(attrs, f.kv(attr))
+	}
+	return f.kv(map[string]any{"index_name": index, "attributes": attrs})
+}
+
+func (f *fakeRedis) searchReply(docs []fakeDoc, withScores bool) any {
+	if !f.resp3 {
+		reply := []any{int64(len(docs))}
+		for _, doc := range docs {
+			reply = append(reply, doc.id)
+			if withScores {
+				reply = append(reply, strconv.FormatFloat(doc.score, 'f', -1, 64))
+			}
+			fields := make([]any, 0, 2*len(doc.fields))
+			for k, v := range doc.fields {
+				fields = append(fields, k, v)
+			}
+			reply = append(reply, fields)
+		}
+		return reply
+	}
+
+	results := make([]any, 0, len(docs))
+	for _, doc := range docs {
+		attrs := make(map[any]any, len(doc.fields))
+		for k, v := range doc.fields {
+			attrs[k] = v
+		}
+		result := map[any]any{"id": doc.id, "extra_attributes": attrs, "values": []any{}}
+		if withScores {
+			result["score"] = doc.score
+		}
+		results = append(results, result)
+	}
+	return map[any]any{
+		"attributes":    []any{},
+		"format":        "STRING",
+		"results":       results,
+		"total_results": int64(len(docs)),
+		"warning":       []any{},
+	}
+}
+
+func (f *fakeRedis) kv(m map[string]any) any {
+	if f.resp3 {
+		resp := make(map[any]any, len(m))
+		for k, v := range m {
+			resp[k] = v
+		}
+		return resp
+	}
+	resp := make([]any, 0, 2*len(m))
+	for k, v := range m {
+		resp = append(resp, k, v)
+	}
+	return resp
+}
+
+func hasArg(args []any, arg string) bool {
+	for _, a := range args {
+		if s, ok := a.(string); ok && s == arg {
+			return true
+		}
+	}
+	return false
+}
+
+func newFakeClient(f *fakeRedis) redis.UniversalClient {
+	client := redis.NewClient(&redis.Options{Addr: "fake:6379"})
+	client.AddHook(f)
+	return client
+}
@@ -0,0 +1,147 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+)
+
+// TranslateFilter translates a portable filter to a RediSearch query, with dialect 2.
+// Strings and bools are matched as TAG fields, bools being stored as "1" / "0" by go-redis,
+// numbers as NUMERIC fields, e.g. filter.Eq("source", "a.md") is translated to @source:{a\.md}.
+// Range bounds must be numbers, and Exists is not supported as it requires indexing missing values.
+func TranslateFilter(f *filter.Filter) (string, error) {
+	switch f.Op {
+	case filter.OpEq:
+		return matchQuery(f, f.Value)
+	case filter.OpNe:
+		q, err := matchQuery(f, f.Value)
+		if err != nil {
+			return "", err
+		}
+		return "-" + q, nil
+	case filter.OpIn:
+		var tags, numbers []string
+		for _, v := range f.Values {
+			if filter.IsNumber(v) {
+				q, err := matchQuery(f, v)
+				if err != nil {
+					return "", err
+				}
+				numbers = append(numbers, q)
+			} else {
+				tags = append(tags, tagValue(v))
+			}
+		}
+		if len(tags) > 0 {
+			numbers = append(numbers, fmt.Sprintf("@%s:{%s}", f.Field, strings.Join(tags, " | ")))
+		}
+		if len(numbers) == 1 {
+			return numbers[0], nil
+		}
+		return "(" + strings.Join(numbers, " | ") + ")", nil
+	case filter.OpRange:
+		lower, upper := "-inf", "+inf"
+		for _, b := range []struct {
+			value     any
+			dst       *string
+			exclusive bool
+		}{{f.Bounds.Gt, &lower, true}, {f.Bounds.Gte, &lower, false}, {f.Bounds.Lt, &upper, true}, {f.Bounds.Lte, &upper, false}} {
+			if b.value == nil {
+				continue
+			}
+			if !filter.IsNumber(b.value) {
+				return "", filter.Unsupported("redis", f, "range bounds must be numbers")
+			}
+			*b.dst = numberArg(b.value)
+			if b.exclusive {
+				*b.dst = "(" + *b.dst
+			}
+		}
+		return fmt.Sprintf("@%s:[%s %s]", f.Field, lower, upper), nil
+	case filter.OpAnd, filter.OpOr:
+		queries := make([]string, 0, len(f.Children))
+		for _, child := range f.Children {
+			q, err := TranslateFilter(child)
+			if err != nil {
+				return "", err
+			}
+			queries = append(queries, q)
+		}
+		sep := " "
+		if f.Op == filter.OpOr {
+			sep = " | "
+		}
+		return "(" + strings.Join(queries, sep) + ")", nil
+	case filter.OpNot:
+		q, err := TranslateFilter(f.Children[0])
+		if err != nil {
+			return "", err
+		}
+		return "-(" + q + ")", nil
+	default:
+		return "", filter.Unsupported("redis", f, "")
+	}
+}
+
+// matchQuery matches field f.Field equal to v.
+func matchQuery(f *filter.Filter, v any) (string, error) {
+	if filter.IsNumber(v) {
+		n := numberArg(v)
+		return fmt.Sprintf("@%s:[%s %s]", f.Field, n, n), nil
+	}
+	return fmt.Sprintf("@%s:{%s}", f.Field, tagValue(v)), nil
+}
+
+func tagValue(v any) string {
+	switch val := v.(type) {
+	case bool:
+		if val {
+			return "1"
+		}
+		return "0"
+	default:
+		return escapeTerm(fmt.Sprint(val))
+	}
+}
+
+func numberArg(v any) string {
+	switch val := v.(type) {
+	case float32:
+		return strconv.FormatFloat(float64(val), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(val, 'g', -1, 64)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// filterQuery combines the filter query and the translated portable filter.
+func filterQuery(native string, f *filter.Filter) (string, error) {
+	portable, ok, err := filter.Translate(f, TranslateFilter)
+	if err != nil || !ok {
+		return native, err
+	}
+	if native == "" {
+		return portable, nil
+	}
+	return fmt.Sprintf("(%s) %s", native, portable), nil
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+)
+
+func TestTranslateFilter(t *testing.T) {
+	convey.Convey("test TranslateFilter", t, func() {
+		q, err := TranslateFilter(filter.And(
+			filter.Eq("source", "docs/a.md"),
+			filter.Ne("draft", true),
+			filter.In("lang", "en", "zh"),
+			filter.In("year", 2024, "unknown"),
+			filter.Range("page", filter.Bounds{Gt: 1, Lte: 10.5}),
+			filter.Or(filter.Eq("page", 3), filter.Not(filter.Lt("score", 0.5))),
+		))
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(q, convey.ShouldEqual, `(@source:{docs\/a\.md} -@draft:{1} @lang:{en | zh} `+
+			`(@year:[2024 2024] | @year:{unknown}) @page:[(1 10.5] (@page:[3 3] | -(@score:[-inf (0.5])))`)
+
+		_, err = TranslateFilter(filter.Gte("date", "2024-01-01"))
+		convey.So(errors.Is(err, filter.ErrUnsupported), convey.ShouldBeTrue)
+		convey.So(err.Error(), convey.ShouldContainSubstring, "range bounds must be numbers")
+
+		_, err = TranslateFilter(filter.Not(filter.Exists("author")))
+		convey.So(errors.Is(err, filter.ErrUnsupported), convey.ShouldBeTrue)
+	})
+
+	convey.Convey("test retrieve with filter", t, func() {
+		ctx := context.Background()
+		fake := &fakeRedis{search: func(args []any) []fakeDoc { return nil }}
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			Client:    newFakeClient(fake),
+			Index:     "test_index",
+			Embedding: &mockEmbedding{sizeForCall: []int{1, 1, 1}, dims: 10},
+		})
+		convey.So(err, convey.ShouldBeNil)
+
+		_, err = r.Retrieve(ctx, "test_query", filter.WithFilter(filter.Eq("source", "a.md")))
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(fake.searches[0][2], convey.ShouldEqual, `(@source:{a\.md})=>[KNN 5 @vector_content $vector AS distance]`)
+
+		_, err = r.Retrieve(ctx, "test_query", WithFilterQuery("@year:[2020 +inf]"), filter.WithFilter(filter.Eq("source", "a.md")))
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(fake.searches[1][2], convey.ShouldEqual, `((@year:[2020 +inf]) @source:{a\.md})=>[KNN 5 @vector_content $vector AS distance]`)
+
+		_, err = r.Retrieve(ctx, "test_query", filter.WithFilter(filter.Exists("source")))
+		convey.So(errors.Is(err, filter.ErrUnsupported), convey.ShouldBeTrue)
+		convey.So(fake.searches, convey.ShouldHaveLength, 2)
+	})
+}
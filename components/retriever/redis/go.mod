@@ -2,10 +2,15 @@ module github.com/cloudwego/eino-ext/components/retriever/redis
 
 go 1.23.0
 
+replace (
+	github.com/cloudwego/eino-ext/components/retriever/filter => ../filter
+	github.com/cloudwego/eino-ext/libs/acl/rediscluster => ../../../libs/acl/rediscluster
+)
 
 require (
-	github.com/bytedance/mockey v1.2.13
 	github.com/cloudwego/eino v0.3.27
+	github.com/cloudwego/eino-ext/components/retriever/filter v0.0.0-00010101000000-000000000000
+	github.com/cloudwego/eino-ext/libs/acl/rediscluster v0.0.0-00010101000000-000000000000
 	github.com/redis/go-redis/v9 v9.10.0
 	github.com/smartystreets/goconvey v1.8.1
 )
@@ -0,0 +1,364 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/redis/go-redis/v9"
+)
+
+type FusionMode string
+
+const (
+	// FusionRRF scores a document by sum of 1/(RankConstant+rank) over the text and vector result lists.
+	// see: https://plg.uwaterloo.ca/~gvcormac/cormacksigir09-rrf.pdf
+	FusionRRF FusionMode = "rrf"
+	// FusionWeighted min-max normalizes text scores and vector distances of each list to [0, 1],
+	// then scores a document by TextWeight*text + VectorWeight*vector, smaller distance scoring higher.
+	FusionWeighted FusionMode = "weighted"
+)
+
+const (
+	// MetaKeyTextScore is the metadata key of the full-text score in hybrid mode, absent if the document only matched the vector query.
+	MetaKeyTextScore = "text_score"
+	// MetaKeyVectorDistance is the metadata key of the vector distance in hybrid mode, absent if the document only matched the text query.
+	MetaKeyVectorDistance = "vector_distance"
+)
+
+type HybridConfig struct {
+	// TextFields TEXT fields of the index which the query is matched against.
+	// Required.
+	TextFields []string
+	// Scorer full-text scoring function, e.g. BM25STD or TFIDF.
+	// Default empty, server default is used, BM25STD for Redis >= 8 and TFIDF before.
+	// see: https://redis.io/docs/latest/develop/interact/search-and-query/advanced-concepts/scoring/
+	Scorer string
+	// Fusion method to combine the text and vector result lists.
+	// Default FusionRRF.
+	Fusion FusionMode
+	// RankConstant k of RRF, larger values give lower ranked documents more influence.
+	// Default 60.
+	RankConstant int
+	// TextWeight and VectorWeight of FusionWeighted.
+	// Default 0.5 each if both not set.
+	TextWeight   float64
+	VectorWeight float64
+	// WindowSize number of candidates fetched by each of the two queries before fusion.
+	// Default 2*(offset+TopK), and never less than offset+TopK.
+	WindowSize int
+	// ScoreThreshold drops documents with fused score below it.
+	// Default nil.
+	ScoreThreshold *float64
+}
+
+func (h *HybridConfig) check() error {
+	if len(h.TextFields) == 0 {
+		return fmt.Errorf("[hybrid] text fields not provided")
+	}
+
+	switch h.Fusion {
+	case "":
+		h.Fusion = FusionRRF
+	case FusionRRF, FusionWeighted:
+	default:
+		return fmt.Errorf("[hybrid] unknown fusion mode: %s", h.Fusion)
+	}
+
+	if h.RankConstant == 0 {
+		h.RankConstant = 60
+	}
+
+	if h.TextWeight < 0 || h.VectorWeight < 0 {
+		return fmt.Errorf("[hybrid] weights should not be negative")
+	}
+
+	if h.TextWeight == 0 && h.VectorWeight == 0 {
+		h.TextWeight, h.VectorWeight = 0.5, 0.5
+	}
+
+	return nil
+}
+
+type hybridHit struct {
+	fields     map[string]string
+	textScore  *float64
+	distance   *float64
+	textRank   int
+	vectorRank int
+	score      float64
+}
+
+func (r *Retriever) hybridSearch(ctx context.Context, query string, vector []float64, co *retriever.Options, io *implOptions) (docs []*schema.Document, err error) {
+	h := r.config.Hybrid
+	page := io.Offset + *co.TopK
+	window := h.WindowSize
+	if window == 0 {
+		window = 2 * page
+	}
+	if window < page {
+		window = page
+	}
+
+	var textDocs []redis.Document
+	if textQuery := buildTextQuery(h.TextFields, query, io.FilterQuery); textQuery != "" {
+		textDocs, err = ftSearch(ctx, r.config.Client, *co.Index, textQuery, &redis.FTSearchOptions{
+			Return:         searchReturn(withoutField(r.config.ReturnFields, SortByDistanceAttributeName)),
+			WithScores:     true,
+			Scorer:         h.Scorer,
+			Limit:          window,
+			DialectVersion: r.config.Dialect,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("[hybridSearch] text search failed, %w", err)
+		}
+	}
+
+	params, vectorQuery := r.vectorQuery(vector, io.FilterQuery, window)
+	vectorDocs, err := ftSearch(ctx, r.config.Client, *co.Index, vectorQuery, &redis.FTSearchOptions{
+		Return:         searchReturn(withField(r.config.ReturnFields, SortByDistanceAttributeName)),
+		SortBy:         []redis.FTSearchSortBy{{FieldName: SortByDistanceAttributeName, Asc: true}},
+		Limit:          window,
+		DialectVersion: r.config.Dialect,
+		Params:         params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[hybridSearch] vector search failed, %w", err)
+	}
+
+	ids, hits := mergeHits(textDocs, vectorDocs)
+	fuse(h, ids, hits)
+
+	ranked := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if h.ScoreThreshold != nil && hits[id].score < *h.ScoreThreshold {
+			continue
+		}
+		ranked = append(ranked, id)
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return hits[ranked[i]].score > hits[ranked[j]].score
+	})
+	// sort all fused candidates before paginating, so that pages follow the requested order
+	if io.SortBy != nil {
+		sortByField(ranked, hits, io.SortBy)
+	}
+
+	if io.Offset >= len(ranked) {
+		return nil, nil
+	}
+	ranked = ranked[io.Offset:]
+	if len(ranked) > *co.TopK {
+		ranked = ranked[:*co.TopK]
+	}
+
+	docs = make([]*schema.Document, 0, len(ranked))
+	for _, id := range ranked {
+		hit := hits[id]
+		doc, err := r.config.DocumentConverter(ctx, redis.Document{ID: id, Fields: hit.fields})
+		if err != nil {
+			return nil, err
+		}
+		if doc.MetaData == nil {
+			doc.MetaData = map[string]any{}
+		}
+		if hit.textScore != nil {
+			doc.MetaData[MetaKeyTextScore] = *hit.textScore
+		}
+		if hit.distance != nil {
+			doc.MetaData[MetaKeyVectorDistance] = *hit.distance
+		}
+		docs = append(docs, doc.WithScore(hit.score))
+	}
+
+	return docs, nil
+}
+
+// mergeHits joins text and vector results by document id, ids keep the order of first appearance.
+func mergeHits(textDocs, vectorDocs []redis.Document) ([]string, map[string]*hybridHit) {
+	var ids []string
+	hits := make(map[string]*hybridHit, len(textDocs)+len(vectorDocs))
+	get := func(doc redis.Document) *hybridHit {
+		hit, found := hits[doc.ID]
+		if !found {
+			hit = &hybridHit{fields: map[string]string{}}
+			hits[doc.ID] = hit
+			ids = append(ids, doc.ID)
+		}
+		for k, v := range doc.Fields {
+			hit.fields[k] = v
+		}
+		return hit
+	}
+
+	for i, doc := range textDocs {
+		hit := get(doc)
+		hit.textRank = i + 1
+		hit.textScore = doc.Score
+	}
+
+	for i, doc := range vectorDocs {
+		hit := get(doc)
+		hit.vectorRank = i + 1
+		if d, err := strconv.ParseFloat(doc.Fields[SortByDistanceAttributeName], 64); err == nil {
+			hit.distance = &d
+		}
+	}
+
+	return ids, hits
+}
+
+func fuse(h *HybridConfig, ids []string, hits map[string]*hybridHit) {
+	if h.Fusion == FusionRRF {
+		k := float64(h.RankConstant)
+		for _, id := range ids {
+			hit := hits[id]
+			if hit.textRank > 0 {
+				hit.score += 1 / (k + float64(hit.textRank))
+			}
+			if hit.vectorRank > 0 {
+				hit.score += 1 / (k + float64(hit.vectorRank))
+			}
+		}
+		return
+	}
+
+	textMin, textMax := scoreRange(ids, hits, func(hit *hybridHit) *float64 { return hit.textScore })
+	distMin, distMax := scoreRange(ids, hits, func(hit *hybridHit) *float64 { return hit.distance })
+	for _, id := range ids {
+		hit := hits[id]
+		if hit.textScore != nil {
+			hit.score += h.TextWeight * normalize(*hit.textScore, textMin, textMax)
+		}
+		if hit.distance != nil {
+			hit.score += h.VectorWeight * normalize(-*hit.distance, -distMax, -distMin)
+		}
+	}
+}
+
+func scoreRange(ids []string, hits map[string]*hybridHit, get func(hit *hybridHit) *float64) (minScore, maxScore float64) {
+	first := true
+	for _, id := range ids {
+		v := get(hits[id])
+		if v == nil {
+			continue
+		}
+		if first || *v < minScore {
+			minScore = *v
+		}
+		if first || *v > maxScore {
+			maxScore = *v
+		}
+		first = false
+	}
+	return minScore, maxScore
+}
+
+// normalize maps v to [0, 1] by min-max, a list of equal scores maps to 1.
+func normalize(v, minScore, maxScore float64) float64 {
+	if maxScore == minScore {
+		return 1
+	}
+	return (v - minScore) / (maxScore - minScore)
+}
+
+func sortByField(ids []string, hits map[string]*hybridHit, by *SortBy) {
+	sort.SliceStable(ids, func(i, j int) bool {
+		c := compareValues(hits[ids[i]].fields[by.Field], hits[ids[j]].fields[by.Field])
+		if by.Asc {
+			return c < 0
+		}
+		return c > 0
+	})
+}
+
+// compareValues compares numerically if both values are numbers, otherwise lexically.
+func compareValues(a, b string) int {
+	fa, errA := strconv.ParseFloat(a, 64)
+	fb, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		default:
+			return 0
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+// buildTextQuery matches any term of query in fields, returns empty if query has no terms.
+// e.g. fields [title, content], query "eiffel tower" => @title|content:(eiffel|tower)
+func buildTextQuery(fields []string, query, filterQuery string) string {
+	terms := strings.Fields(query)
+	escaped := make([]string, 0, len(terms))
+	for _, term := range terms {
+		if t := escapeTerm(term); t != "" {
+			escaped = append(escaped, t)
+		}
+	}
+	if len(escaped) == 0 {
+		return ""
+	}
+
+	textQuery := fmt.Sprintf("@%s:(%s)", strings.Join(fields, "|"), strings.Join(escaped, "|"))
+	if filterQuery != "" {
+		textQuery = fmt.Sprintf("(%s) %s", filterQuery, textQuery)
+	}
+	return textQuery
+}
+
+// escapeTerm escapes punctuation which is query syntax of RediSearch.
+// see: https://redis.io/docs/latest/develop/interact/search-and-query/advanced-concepts/escaping/
+func escapeTerm(term string) string {
+	var sb strings.Builder
+	for _, c := range term {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_' {
+			sb.WriteRune('\\')
+		}
+		sb.WriteRune(c)
+	}
+	return sb.String()
+}
+
+func withField(fields []string, field string) []string {
+	for _, f := range fields {
+		if f == field {
+			return fields
+		}
+	}
+	return append(append(make([]string, 0, len(fields)+1), fields...), field)
+}
+
+func withoutField(fields []string, field string) []string {
+	resp := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f != field {
+			resp = append(resp, f)
+		}
+	}
+	return resp
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestBuildTextQuery(t *testing.T) {
+	convey.Convey("test buildTextQuery", t, func() {
+		convey.So(buildTextQuery([]string{"title", "content"}, "eiffel tower", ""),
+			convey.ShouldEqual, "@title|content:(eiffel|tower)")
+		convey.So(buildTextQuery([]string{"content"}, "paris, france", "@year:[1800 1900]"),
+			convey.ShouldEqual, `(@year:[1800 1900]) @content:(paris\,|france)`)
+		convey.So(buildTextQuery([]string{"content"}, "  ", ""), convey.ShouldEqual, "")
+		convey.So(escapeTerm("a-b@c"), convey.ShouldEqual, `a\-b\@c`)
+	})
+}
+
+func TestHybridConfigCheck(t *testing.T) {
+	convey.Convey("test HybridConfig check", t, func() {
+		convey.So((&HybridConfig{}).check(), convey.ShouldNotBeNil)
+		convey.So((&HybridConfig{TextFields: []string{"content"}, Fusion: "max"}).check(), convey.ShouldNotBeNil)
+		convey.So((&HybridConfig{TextFields: []string{"content"}, TextWeight: -1}).check(), convey.ShouldNotBeNil)
+
+		h := &HybridConfig{TextFields: []string{"content"}}
+		convey.So(h.check(), convey.ShouldBeNil)
+		convey.So(h.Fusion, convey.ShouldEqual, FusionRRF)
+		convey.So(h.RankConstant, convey.ShouldEqual, 60)
+		convey.So(h.TextWeight, convey.ShouldEqual, 0.5)
+		convey.So(h.VectorWeight, convey.ShouldEqual, 0.5)
+	})
+}
+
+func TestFuse(t *testing.T) {
+	convey.Convey("test fuse", t, func() {
+		s1, s2 := 3.0, 1.0
+		textDocs := []redis.Document{
+			{ID: "a", Score: &s1, Fields: map[string]string{"content": "a"}},
+			{ID: "b", Score: &s2, Fields: map[string]string{"content": "b"}},
+		}
+		vectorDocs := []redis.Document{
+			{ID: "c", Fields: map[string]string{"content": "c", SortByDistanceAttributeName: "0.1"}},
+			{ID: "b", Fields: map[string]string{"content": "b", SortByDistanceAttributeName: "0.3"}},
+		}
+
+		convey.Convey("test merge", func() {
+			ids, hits := mergeHits(textDocs, vectorDocs)
+			convey.So(ids, convey.ShouldResemble, []string{"a", "b", "c"})
+			convey.So(*hits["b"].textScore, convey.ShouldEqual, 1.0)
+			convey.So(*hits["b"].distance, convey.ShouldEqual, 0.3)
+			convey.So(hits["a"].distance, convey.ShouldBeNil)
+			convey.So(hits["c"].textScore, convey.ShouldBeNil)
+			convey.So(hits["b"].fields[SortByDistanceAttributeName], convey.ShouldEqual, "0.3")
+		})
+
+		convey.Convey("test rrf", func() {
+			h := &HybridConfig{TextFields: []string{"content"}}
+			convey.So(h.check(), convey.ShouldBeNil)
+			ids, hits := mergeHits(textDocs, vectorDocs)
+			fuse(h, ids, hits)
+			convey.So(hits["a"].score, convey.ShouldAlmostEqual, 1.0/61, 1e-9)
+			convey.So(hits["b"].score, convey.ShouldAlmostEqual, 2.0/62, 1e-9)
+			convey.So(hits["c"].score, convey.ShouldAlmostEqual, 1.0/61, 1e-9)
+		})
+
+		convey.Convey("test weighted", func() {
+			h := &HybridConfig{TextFields: []string{"content"}, Fusion: FusionWeighted, TextWeight: 0.3, VectorWeight: 0.7}
+			convey.So(h.check(), convey.ShouldBeNil)
+			ids, hits := mergeHits(textDocs, vectorDocs)
+			fuse(h, ids, hits)
+			convey.So(hits["a"].score, convey.ShouldAlmostEqual, 0.3, 1e-9)
+			convey.So(hits["b"].score, convey.ShouldAlmostEqual, 0, 1e-9)
+			convey.So(hits["c"].score, convey.ShouldAlmostEqual, 0.7, 1e-9)
+		})
+	})
+}
+
+func TestSortByField(t *testing.T) {
+	convey.Convey("test sortByField", t, func() {
+		hits := map[string]*hybridHit{
+			"a": {fields: map[string]string{"year": "1889"}},
+			"b": {fields: map[string]string{"year": "537"}},
+			"c": {fields: map[string]string{"year": "1653"}},
+		}
+		ids := []string{"a", "b", "c"}
+		sortByField(ids, hits, &SortBy{Field: "year", Asc: true})
+		convey.So(ids, convey.ShouldResemble, []string{"b", "c", "a"})
+		sortByField(ids, hits, &SortBy{Field: "year", Asc: false})
+		convey.So(ids, convey.ShouldResemble, []string{"a", "c", "b"})
+	})
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// validateIndex checks with FT.INFO that VectorField is a vector field of the index and ReturnFields exist.
+func validateIndex(ctx context.Context, client redis.UniversalClient, conf *RetrieverConfig) error {
+	raw, err := client.Do(ctx, "FT.INFO", conf.Index).Result()
+	if err != nil {
+		return fmt.Errorf("[validateIndex] get info of index %s failed, %w", conf.Index, err)
+	}
+
+	fields, err := parseIndexFields(raw)
+	if err != nil {
+		return err
+	}
+
+	return checkFields(conf, fields)
+}
+
+func checkFields(conf *RetrieverConfig, fields map[string]string) error {
+	typ, found := fields[conf.VectorField]
+	if !found {
+		return fmt.Errorf("[validateIndex] vector field %s not found in index %s", conf.VectorField, conf.Index)
+	}
+	if !strings.EqualFold(typ, "VECTOR") {
+		return fmt.Errorf("[validateIndex] field %s of index %s is %s, not VECTOR", conf.VectorField, conf.Index, typ)
+	}
+
+	for _, rf := range conf.ReturnFields {
+		if rf == SortByDistanceAttributeName {
+			continue
+		}
+		if _, found = fields[rf]; !found {
+			return fmt.Errorf("[validateIndex] return field %s not found in index %s", rf, conf.Index)
+		}
+	}
+
+	return nil
+}
+
+// parseIndexFields returns field type keyed by both identifier and attribute name.
+// The reply is a flat array with RESP2 and a map with RESP3.
+// Same as parseIndexInfo in github.com/cloudwego/eino-ext/components/indexer/redis.
+func parseIndexFields(raw any) (map[string]string, error) {
+	kv := toKV(raw)
+	if kv == nil {
+		return nil, fmt.Errorf("[validateIndex] unexpected FT.INFO reply type %T", raw)
+	}
+
+	fields := make(map[string]string)
+	attrs, _ := kv["attributes"].([]any)
+	for _, attr := range attrs {
+		akv := toKV(attr)
+		if akv == nil {
+			continue
+		}
+		typ := toString(akv["type"])
+		if id := toString(akv["identifier"]); id != "" {
+			fields[id] = typ
+		}
+		if name := toString(akv["attribute"]); name != "" {
+			fields[name] = typ
+		}
+	}
+	return fields, nil
+}
+
+// toKV converts a RESP2 flat array or a RESP3 map reply to a map with lower-cased keys.
+func toKV(v any) map[string]any {
+	pairs := toPairs(v)
+	if pairs == nil {
+		return nil
+	}
+	kv := make(map[string]any, len(pairs))
+	for k, val := range pairs {
+		kv[strings.ToLower(k)] = val
+	}
+	return kv
+}
+
+// toPairs converts a RESP2 flat array or a RESP3 map reply to a map, keys kept as is.
+func toPairs(v any) map[string]any {
+	kv := make(map[string]any)
+	switch t := v.(type) {
+	case []any:
+		for i := 0; i+1 < len(t); i += 2 {
+			kv[toString(t[i])] = t[i+1]
+		}
+	case map[any]any:
+		for k, val := range t {
+			kv[toString(k)] = val
+		}
+	case map[string]any:
+		for k, val := range t {
+			kv[k] = val
+		}
+	default:
+		return nil
+	}
+	return kv
+}
+
+func toString(v any) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case []byte:
+		return string(t)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(t)
+	}
+}
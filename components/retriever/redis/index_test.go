@@ -0,0 +1,71 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestCheckFields(t *testing.T) {
+	convey.Convey("test checkFields", t, func() {
+		raw := []any{
+			"index_name", "eino_docs_v1",
+			"attributes", []any{
+				[]any{"identifier", "content", "attribute", "content", "type", "TEXT"},
+				[]any{"identifier", "vector_content", "attribute", "vector_content", "type", "VECTOR", "dim", int64(4)},
+			},
+		}
+		fields, err := parseIndexFields(raw)
+		convey.So(err, convey.ShouldBeNil)
+
+		conf := &RetrieverConfig{
+			Index:        "eino_docs_v1",
+			VectorField:  "vector_content",
+			ReturnFields: []string{"content", SortByDistanceAttributeName},
+		}
+
+		convey.Convey("test success", func() {
+			convey.So(checkFields(conf, fields), convey.ShouldBeNil)
+		})
+
+		convey.Convey("test vector field not found", func() {
+			conf.VectorField = "vec"
+			convey.So(checkFields(conf, fields), convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test vector field not vector", func() {
+			conf.VectorField = "content"
+			convey.So(checkFields(conf, fields), convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test return field not found", func() {
+			conf.ReturnFields = []string{"extra"}
+			convey.So(checkFields(conf, fields), convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test resp3 reply", func() {
+			fields, err = parseIndexFields(map[any]any{
+				"attributes": []any{map[any]any{"identifier": "$.v", "attribute": "vector_content", "type": "VECTOR"}},
+			})
+			convey.So(err, convey.ShouldBeNil)
+			conf.ReturnFields = nil
+			convey.So(checkFields(conf, fields), convey.ShouldBeNil)
+		})
+	})
+}
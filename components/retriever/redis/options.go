@@ -22,6 +22,15 @@ import (
 
 type implOptions struct {
 	FilterQuery string
+	Offset      int
+	SortBy      *SortBy
+}
+
+type SortBy struct {
+	// Field to sort by, must be declared SORTABLE in the index.
+	Field string
+	// Asc sorts ascending if true, descending otherwise.
+	Asc bool
 }
 
 // WithFilterQuery redis filter query.
@@ -31,3 +40,20 @@ func WithFilterQuery(filter string) retriever.Option {
 		o.FilterQuery = filter
 	})
 }
+
+// WithOffset skips the first offset results, used with TopK for pagination.
+func WithOffset(offset int) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.Offset = offset
+	})
+}
+
+// WithSortBy sorts results by field instead of distance (or fused score in hybrid mode).
+// In vector mode it's sent as SORTBY, and field must be SORTABLE in the index.
+// In hybrid mode the fused candidates of the window are sorted on the client before Offset and TopK apply,
+// and field must be one of ReturnFields.
+func WithSortBy(field string, asc bool) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.SortBy = &SortBy{Field: field, Asc: asc}
+	})
+}
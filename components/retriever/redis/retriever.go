@@ -26,17 +26,20 @@ import (
 	"github.com/cloudwego/eino/components/retriever"
 	"github.com/cloudwego/eino/schema"
 	"github.com/redis/go-redis/v9"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+	"github.com/cloudwego/eino-ext/libs/acl/rediscluster"
 )
 
 type RetrieverConfig struct {
 	// Client is a Redis client representing a pool of zero or more underlying connections.
 	// It's safe for concurrent use by multiple goroutines, which means is okay to pass
 	// an existed Client to create a new Retriever component.
-	// ***NOTICE***: two client conn options should be configured correctly to enable ft.search.
-	// 1. Protocol: default is 3, here needs 2, or FT.Search result is raw.
-	// 2. UnstableResp3: default is false, here needs true.
-	Client *redis.Client
+	// Any of redis.Client, redis.ClusterClient and sentinel failover client works, with either RESP2 or RESP3.
+	Client redis.UniversalClient
 	// Index name of index to search.
+	// With redis.ClusterClient, FT.SEARCH is routed by index name, so Index should contain the hash tag
+	// of the indexed keys, e.g. "{eino_doc}idx" for keys "{eino_doc}:1", "{eino_doc}:2" ...
 	// see: https://redis.io/docs/latest/develop/interact/search-and-query/advanced-concepts/vectors/#create-a-vector-index
 	Index string
 	// VectorField vector field name in search query, correspond to FieldValue.EmbedKey from redis indexer.
@@ -61,6 +64,14 @@ type RetrieverConfig struct {
 	TopK int
 	// Embedding vectorization method for query.
 	Embedding embedding.Embedder
+	// ValidateIndex if true, NewRetriever runs FT.INFO and checks that VectorField is a vector field
+	// of Index and each of ReturnFields exists, SortByDistanceAttributeName excepted.
+	// Default false.
+	ValidateIndex bool
+	// Hybrid if set, Retrieve runs a full-text query over Hybrid.TextFields besides the vector query,
+	// and fuses both result lists, see HybridConfig.
+	// Default nil, vector query only.
+	Hybrid *HybridConfig
 }
 
 type Retriever struct {
@@ -80,6 +91,10 @@ func NewRetriever(ctx context.Context, config *RetrieverConfig) (*Retriever, err
 		return nil, fmt.Errorf("[NewRetriever] redis client not provided")
 	}
 
+	if _, ok := config.Client.(*redis.ClusterClient); ok && !rediscluster.HasHashTag(config.Index) {
+		return nil, fmt.Errorf("[NewRetriever] index name should contain a hash tag with cluster client, got=%s", config.Index)
+	}
+
 	if config.Dialect < 2 {
 		// Support for vector search also was introduced in the 2.4
 		config.Dialect = 2
@@ -104,6 +119,18 @@ func NewRetriever(ctx context.Context, config *RetrieverConfig) (*Retriever, err
 		config.DocumentConverter = defaultResultParser(config.ReturnFields)
 	}
 
+	if config.Hybrid != nil {
+		if err := config.Hybrid.check(); err != nil {
+			return nil, fmt.Errorf("[NewRetriever] %w", err)
+		}
+	}
+
+	if config.ValidateIndex {
+		if err := validateIndex(ctx, config.Client, config); err != nil {
+			return nil, fmt.Errorf("[NewRetriever] %w", err)
+		}
+	}
+
 	return &Retriever{
 		config: config,
 	}, nil
@@ -117,6 +144,7 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		Embedding:      r.config.Embedding,
 	}, opts...)
 	io := retriever.GetImplSpecificOptions(&implOptions{}, opts...)
+	io.FilterQuery, err = filterQuery(io.FilterQuery, filter.GetFilter(opts...))
 
 	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
 	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
@@ -131,6 +159,10 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		}
 	}()
 
+	if err != nil {
+		return nil, fmt.Errorf("[redis retriever] invalid filter: %w", err)
+	}
+
 	emb := co.Embedding
 	if emb == nil {
 		return nil, fmt.Errorf("[redis retriever] embedding not provided")
@@ -145,51 +177,44 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		return nil, fmt.Errorf("[redis retriever] invalid return length of vector, got=%d, expected=1", len(vectors))
 	}
 
-	params := map[string]any{
-		paramVector: vector2Bytes(vectors[0]),
+	if r.config.Hybrid != nil {
+		docs, err = r.hybridSearch(ctx, query, vectors[0], co, io)
+	} else {
+		docs, err = r.vectorSearch(ctx, vectors[0], co, io)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	var searchQuery string
-	if r.config.DistanceThreshold != nil {
-		params[paramDistanceThreshold] = dereferenceOrZero(r.config.DistanceThreshold)
-		baseQuery := fmt.Sprintf("@%s:[VECTOR_RANGE $%s $%s]", r.config.VectorField, paramDistanceThreshold, paramVector)
+	callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
 
-		if io.FilterQuery != "" {
-			baseQuery = io.FilterQuery + " " + baseQuery
-		}
+	return docs, nil
+}
 
-		searchQuery = fmt.Sprintf("%s=>{$yield_distance_as: %s}", baseQuery, SortByDistanceAttributeName)
-	} else {
-		filter := "*"
-		if io.FilterQuery != "" {
-			filter = io.FilterQuery
-		}
+func (r *Retriever) vectorSearch(ctx context.Context, vector []float64, co *retriever.Options, io *implOptions) (docs []*schema.Document, err error) {
+	params, searchQuery := r.vectorQuery(vector, io.FilterQuery, io.Offset+*co.TopK)
 
-		searchQuery = fmt.Sprintf("(%s)=>[KNN %d @%s $%s AS %s]",
-			filter, *co.TopK, r.config.VectorField, paramVector, SortByDistanceAttributeName)
-	}
-
-	sr := make([]redis.FTSearchReturn, 0, len(r.config.ReturnFields))
-	for _, field := range r.config.ReturnFields {
-		sr = append(sr, redis.FTSearchReturn{FieldName: field})
+	sortBy := []redis.FTSearchSortBy{{FieldName: SortByDistanceAttributeName, Asc: true}}
+	if io.SortBy != nil {
+		sortBy = []redis.FTSearchSortBy{{FieldName: io.SortBy.Field, Asc: io.SortBy.Asc, Desc: !io.SortBy.Asc}}
 	}
 
 	searchOptions := &redis.FTSearchOptions{
-		Return:         sr,
-		SortBy:         []redis.FTSearchSortBy{{FieldName: SortByDistanceAttributeName, Asc: true}},
+		Return:         searchReturn(r.config.ReturnFields),
+		SortBy:         sortBy,
+		LimitOffset:    io.Offset,
 		Limit:          *co.TopK,
 		DialectVersion: r.config.Dialect,
 		Params:         params,
 		WithScores:     false,
 	}
 
-	cmd := r.config.Client.FTSearchWithArgs(ctx, *co.Index, searchQuery, searchOptions)
-	result, err := cmd.Result() // here required RESP protocol=2
+	result, err := ftSearch(ctx, r.config.Client, *co.Index, searchQuery, searchOptions)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, raw := range result.Docs {
+	for _, raw := range result {
 		doc, err := r.config.DocumentConverter(ctx, raw)
 		if err != nil {
 			return nil, err
@@ -197,10 +222,43 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		docs = append(docs, doc)
 	}
 
-	callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
-
 	return docs, nil
+}
+
+// vectorQuery builds vector range query if DistanceThreshold is set, otherwise KNN query with k nearest neighbours.
+func (r *Retriever) vectorQuery(vector []float64, filterQuery string, k int) (params map[string]any, searchQuery string) {
+	params = map[string]any{
+		paramVector: vector2Bytes(vector),
+	}
+
+	if r.config.DistanceThreshold != nil {
+		params[paramDistanceThreshold] = dereferenceOrZero(r.config.DistanceThreshold)
+		baseQuery := fmt.Sprintf("@%s:[VECTOR_RANGE $%s $%s]", r.config.VectorField, paramDistanceThreshold, paramVector)
+
+		if filterQuery != "" {
+			baseQuery = filterQuery + " " + baseQuery
+		}
 
+		searchQuery = fmt.Sprintf("%s=>{$yield_distance_as: %s}", baseQuery, SortByDistanceAttributeName)
+	} else {
+		filter := "*"
+		if filterQuery != "" {
+			filter = filterQuery
+		}
+
+		searchQuery = fmt.Sprintf("(%s)=>[KNN %d @%s $%s AS %s]",
+			filter, k, r.config.VectorField, paramVector, SortByDistanceAttributeName)
+	}
+
+	return params, searchQuery
+}
+
+func searchReturn(fields []string) []redis.FTSearchReturn {
+	sr := make([]redis.FTSearchReturn, 0, len(fields))
+	for _, field := range fields {
+		sr = append(sr, redis.FTSearchReturn{FieldName: field})
+	}
+	return sr
 }
 
 func (r *Retriever) makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Context {
@@ -237,6 +295,10 @@ func defaultResultParser(returnFields []string) func(ctx context.Context, doc re
 
 		for _, field := range returnFields {
 			val, found := doc.Fields[field]
+			if !found && field == SortByDistanceAttributeName {
+				// documents only matched by full-text query in hybrid mode have no distance
+				continue
+			}
 			if !found {
 				return nil, fmt.Errorf("[defaultResultParser] field=%s not found in doc, doc=%v", field, doc)
 			}
@@ -20,21 +20,22 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 	"testing"
 
-	. "github.com/bytedance/mockey"
 	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
 	"github.com/cloudwego/eino/schema"
 	"github.com/redis/go-redis/v9"
 	"github.com/smartystreets/goconvey/convey"
 )
 
 func TestNewRetriever(t *testing.T) {
-	PatchConvey("test NewRetriever", t, func() {
+	convey.Convey("test NewRetriever", t, func() {
 		ctx := context.Background()
-		mockClient := &redis.Client{}
+		mockClient := redis.NewClient(&redis.Options{})
 
-		PatchConvey("test embedding not provided", func() {
+		convey.Convey("test embedding not provided", func() {
 			r, err := NewRetriever(ctx, &RetrieverConfig{
 				Client:    mockClient,
 				Index:     "asd",
@@ -44,7 +45,7 @@ func TestNewRetriever(t *testing.T) {
 			convey.So(r, convey.ShouldBeNil)
 		})
 
-		PatchConvey("test index not provided", func() {
+		convey.Convey("test index not provided", func() {
 			r, err := NewRetriever(ctx, &RetrieverConfig{
 				Client:    mockClient,
 				Index:     "",
@@ -54,7 +55,7 @@ func TestNewRetriever(t *testing.T) {
 			convey.So(r, convey.ShouldBeNil)
 		})
 
-		PatchConvey("test redis client not provided", func() {
+		convey.Convey("test redis client not provided", func() {
 			r, err := NewRetriever(ctx, &RetrieverConfig{
 				Client:    nil,
 				Index:     "asd",
@@ -64,7 +65,7 @@ func TestNewRetriever(t *testing.T) {
 			convey.So(r, convey.ShouldBeNil)
 		})
 
-		PatchConvey("test success", func() {
+		convey.Convey("test success", func() {
 			r, err := NewRetriever(ctx, &RetrieverConfig{
 				Client:    mockClient,
 				Index:     "asd",
@@ -77,9 +78,8 @@ func TestNewRetriever(t *testing.T) {
 }
 
 func TestRetrieve(t *testing.T) {
-	PatchConvey("test Retrieve", t, func() {
+	convey.Convey("test Retrieve", t, func() {
 		ctx := context.Background()
-		mockClient := redis.NewClient(&redis.Options{Addr: "123"})
 		expv := make([]float64, 10)
 		for i := range expv {
 			expv[i] = 1.1
@@ -90,14 +90,14 @@ func TestRetrieve(t *testing.T) {
 		d2.WithDenseVector(expv)
 		docs := []*schema.Document{d1, d2}
 
-		PatchConvey("test Embedding not provided", func() {
+		convey.Convey("test Embedding not provided", func() {
 			r := &Retriever{config: &RetrieverConfig{Embedding: nil}}
 			resp, err := r.Retrieve(ctx, "test_query")
 			convey.So(err, convey.ShouldBeError, fmt.Errorf("[redis retriever] embedding not provided"))
 			convey.So(resp, convey.ShouldBeNil)
 		})
 
-		PatchConvey("test Embedding error", func() {
+		convey.Convey("test Embedding error", func() {
 			mockErr := fmt.Errorf("mock err")
 			r := &Retriever{config: &RetrieverConfig{Embedding: &mockEmbedding{err: mockErr}}}
 			resp, err := r.Retrieve(ctx, "test_query")
@@ -105,127 +105,216 @@ func TestRetrieve(t *testing.T) {
 			convey.So(resp, convey.ShouldBeNil)
 		})
 
-		PatchConvey("test vector size invalid", func() {
+		convey.Convey("test vector size invalid", func() {
 			r := &Retriever{config: &RetrieverConfig{Embedding: &mockEmbedding{sizeForCall: []int{2}, dims: 10}}}
 			resp, err := r.Retrieve(ctx, "test_query")
 			convey.So(err, convey.ShouldBeError, fmt.Errorf("[redis retriever] invalid return length of vector, got=2, expected=1"))
 			convey.So(resp, convey.ShouldBeNil)
 		})
 
-		PatchConvey("test vector range query", func() {
-			dis := 10.2
-			//origin := mockClient.FTSearchWithArgs
-			var (
-				//cmd     *redis.FTSearchCmd
-				mockCmd *redis.FTSearchCmd
-			)
-
-			//Mock(GetMethod(mockClient, "FTSearchWithArgs")).To(
-			//	func(ctx context.Context, index string, query string, options *redis.FTSearchOptions) *redis.FTSearchCmd {
-			//		cmd = origin(ctx, index, query, options)
-			//		return mockCmd
-			//	}).Origin(&origin).Build()
-
-			Mock(GetMethod(mockClient, "FTSearchWithArgs")).Return(mockCmd).Build()
-			Mock(GetMethod(mockCmd, "Result")).Return(redis.FTSearchResult{
-				Total: 2,
-				Docs: []redis.Document{
-					{
-						ID: "1",
-						Fields: map[string]string{
+		for _, resp3 := range []bool{false, true} {
+			fake := &fakeRedis{
+				resp3: resp3,
+				search: func(args []any) []fakeDoc {
+					return []fakeDoc{
+						{id: "1", fields: map[string]string{
 							defaultReturnFieldContent:       d1.Content,
 							defaultReturnFieldVectorContent: string(vector2Bytes(expv)),
-						},
-					},
-					{
-						ID: "2",
-						Fields: map[string]string{
+						}},
+						{id: "2", fields: map[string]string{
 							defaultReturnFieldContent:       d2.Content,
 							defaultReturnFieldVectorContent: string(vector2Bytes(expv)),
-						},
-					},
+						}},
+					}
 				},
-			}, nil).Build()
+			}
+			client := newFakeClient(fake)
 
-			r, err := NewRetriever(ctx, &RetrieverConfig{
-				Client:            mockClient,
-				Index:             "test_index",
-				DistanceThreshold: &dis,
-				Embedding:         &mockEmbedding{sizeForCall: []int{1}, dims: 10},
-			})
-			convey.So(err, convey.ShouldBeNil)
-			resp, err := r.Retrieve(ctx, "test_query")
-			convey.So(err, convey.ShouldBeNil)
-			//s := "FT.SEARCH test_index @vector_content:[VECTOR_RANGE $distance_threshold $vector]=>{$yield_distance_as: distance} RETURN 2 content vector_content SORTBY distance ASC LIMIT 0 5"
-			//convey.So(strings.HasPrefix(cmd.String(), s), convey.ShouldBeTrue)
-			for i := range resp {
-				got := resp[i]
-				exp := docs[i]
-				convey.So(got.ID, convey.ShouldEqual, exp.ID)
-				convey.So(got.Content, convey.ShouldEqual, exp.Content)
-				convey.So(len(got.DenseVector()), convey.ShouldEqual, len(exp.DenseVector()))
-				for j, gf := range got.DenseVector() {
-					convey.So(gf, convey.ShouldAlmostEqual, exp.DenseVector()[j], 0.01)
+			check := func(resp []*schema.Document) {
+				convey.So(len(resp), convey.ShouldEqual, len(docs))
+				for i := range resp {
+					got := resp[i]
+					exp := docs[i]
+					convey.So(got.ID, convey.ShouldEqual, exp.ID)
+					convey.So(got.Content, convey.ShouldEqual, exp.Content)
+					convey.So(len(got.DenseVector()), convey.ShouldEqual, len(exp.DenseVector()))
+					for j, gf := range got.DenseVector() {
+						convey.So(gf, convey.ShouldAlmostEqual, exp.DenseVector()[j], 0.01)
+					}
 				}
 			}
-		})
 
-		PatchConvey("test knn vector search", func() {
-			//origin := mockClient.FTSearchWithArgs
-			var (
-				//cmd     *redis.FTSearchCmd
-				mockCmd *redis.FTSearchCmd
-			)
+			convey.Convey(fmt.Sprintf("test vector range query, resp3=%v", resp3), func() {
+				dis := 10.2
+				r, err := NewRetriever(ctx, &RetrieverConfig{
+					Client:            client,
+					Index:             "test_index",
+					DistanceThreshold: &dis,
+					Embedding:         &mockEmbedding{sizeForCall: []int{1}, dims: 10},
+				})
+				convey.So(err, convey.ShouldBeNil)
+				resp, err := r.Retrieve(ctx, "test_query")
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(fake.searches, convey.ShouldHaveLength, 1)
+				args := fake.searches[0]
+				convey.So(args[1], convey.ShouldEqual, "test_index")
+				convey.So(args[2], convey.ShouldEqual, "@vector_content:[VECTOR_RANGE $distance_threshold $vector]=>{$yield_distance_as: distance}")
+				convey.So(args[3:11], convey.ShouldResemble, []any{"RETURN", 2, "content", "vector_content", "SORTBY", "distance", "ASC", "LIMIT"})
+				check(resp)
+			})
 
-			//Mock(GetMethod(mockClient, "FTSearchWithArgs")).To(
-			//	func(ctx context.Context, index string, query string, options *redis.FTSearchOptions) *redis.FTSearchCmd {
-			//		cmd = origin(ctx, index, query, options)
-			//		return mockCmd
-			//	}).Origin(&origin).Build()
+			convey.Convey(fmt.Sprintf("test knn vector search, resp3=%v", resp3), func() {
+				r, err := NewRetriever(ctx, &RetrieverConfig{
+					Client:    client,
+					Index:     "test_index",
+					Embedding: &mockEmbedding{sizeForCall: []int{1}, dims: 10},
+				})
+				convey.So(err, convey.ShouldBeNil)
+				resp, err := r.Retrieve(ctx, "test_query")
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(fake.searches, convey.ShouldHaveLength, 1)
+				args := fake.searches[0]
+				convey.So(args[2], convey.ShouldEqual, "(*)=>[KNN 5 @vector_content $vector AS distance]")
+				convey.So(args[7:13], convey.ShouldResemble, []any{"SORTBY", "distance", "ASC", "LIMIT", 0, 5})
+				check(resp)
+			})
 
-			Mock(GetMethod(mockClient, "FTSearchWithArgs")).Return(mockCmd).Build()
-			Mock(GetMethod(mockCmd, "Result")).Return(redis.FTSearchResult{
-				Total: 2,
-				Docs: []redis.Document{
-					{
-						ID: "1",
-						Fields: map[string]string{
-							defaultReturnFieldContent:       d1.Content,
-							defaultReturnFieldVectorContent: string(vector2Bytes(expv)),
-						},
-					},
-					{
-						ID: "2",
-						Fields: map[string]string{
-							defaultReturnFieldContent:       d2.Content,
-							defaultReturnFieldVectorContent: string(vector2Bytes(expv)),
-						},
-					},
-				},
-			}, nil).Build()
+			convey.Convey(fmt.Sprintf("test knn vector search with pagination and sort, resp3=%v", resp3), func() {
+				r, err := NewRetriever(ctx, &RetrieverConfig{
+					Client:    client,
+					Index:     "test_index",
+					Embedding: &mockEmbedding{sizeForCall: []int{1}, dims: 10},
+				})
+				convey.So(err, convey.ShouldBeNil)
+				_, err = r.Retrieve(ctx, "test_query", WithOffset(5), WithSortBy("year", false))
+				convey.So(err, convey.ShouldBeNil)
+				args := fake.searches[0]
+				convey.So(args[2], convey.ShouldEqual, "(*)=>[KNN 10 @vector_content $vector AS distance]")
+				convey.So(args[7:13], convey.ShouldResemble, []any{"SORTBY", "year", "DESC", "LIMIT", 5, 5})
+			})
 
-			r, err := NewRetriever(ctx, &RetrieverConfig{
-				Client:    mockClient,
+			convey.Convey(fmt.Sprintf("test hybrid search, resp3=%v", resp3), func() {
+				fake.search = func(args []any) []fakeDoc {
+					if strings.Contains(toString(args[2]), "KNN") {
+						return []fakeDoc{
+							{id: "2", fields: map[string]string{defaultReturnFieldContent: d2.Content, SortByDistanceAttributeName: "0.2"}},
+							{id: "3", fields: map[string]string{defaultReturnFieldContent: "zxc", SortByDistanceAttributeName: "0.4"}},
+						}
+					}
+					return []fakeDoc{
+						{id: "1", score: 2.5, fields: map[string]string{defaultReturnFieldContent: d1.Content}},
+						{id: "2", score: 1.5, fields: map[string]string{defaultReturnFieldContent: d2.Content}},
+					}
+				}
+				r, err := NewRetriever(ctx, &RetrieverConfig{
+					Client:       client,
+					Index:        "test_index",
+					ReturnFields: []string{defaultReturnFieldContent},
+					Embedding:    &mockEmbedding{sizeForCall: []int{1}, dims: 10},
+					Hybrid:       &HybridConfig{TextFields: []string{defaultReturnFieldContent}},
+				})
+				convey.So(err, convey.ShouldBeNil)
+				resp, err := r.Retrieve(ctx, "test query")
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(fake.searches, convey.ShouldHaveLength, 2)
+				convey.So(fake.searches[0][2], convey.ShouldEqual, "@content:(test|query)")
+				convey.So(len(resp), convey.ShouldEqual, 3)
+				convey.So(resp[0].ID, convey.ShouldEqual, "2")
+				convey.So(resp[0].Score(), convey.ShouldAlmostEqual, 1.0/61+1.0/62, 1e-9)
+				convey.So(resp[0].MetaData[MetaKeyTextScore], convey.ShouldEqual, 1.5)
+				convey.So(resp[0].MetaData[MetaKeyVectorDistance], convey.ShouldEqual, 0.2)
+				convey.So(resp[1].ID, convey.ShouldEqual, "1")
+				convey.So(resp[1].Content, convey.ShouldEqual, d1.Content)
+				convey.So(resp[1].MetaData[MetaKeyVectorDistance], convey.ShouldBeNil)
+				convey.So(resp[2].ID, convey.ShouldEqual, "3")
+				convey.So(resp[2].MetaData[MetaKeyTextScore], convey.ShouldBeNil)
+			})
+
+			convey.Convey(fmt.Sprintf("test hybrid search sorted by field before pagination, resp3=%v", resp3), func() {
+				fake.search = func(args []any) []fakeDoc {
+					if strings.Contains(toString(args[2]), "KNN") {
+						return []fakeDoc{
+							{id: "2", fields: map[string]string{"year": "1800", SortByDistanceAttributeName: "0.2"}},
+							{id: "3", fields: map[string]string{"year": "2000", SortByDistanceAttributeName: "0.4"}},
+						}
+					}
+					return []fakeDoc{
+						{id: "1", score: 2.5, fields: map[string]string{"year": "1900"}},
+						{id: "2", score: 1.5, fields: map[string]string{"year": "1800"}},
+					}
+				}
+				r, err := NewRetriever(ctx, &RetrieverConfig{
+					Client:       client,
+					Index:        "test_index",
+					ReturnFields: []string{"year"},
+					Embedding:    &mockEmbedding{sizeForCall: []int{1, 1}, dims: 10},
+					Hybrid:       &HybridConfig{TextFields: []string{defaultReturnFieldContent}},
+				})
+				convey.So(err, convey.ShouldBeNil)
+				// fused order is 2, 1, 3, the first page by year desc is 3, 1
+				resp, err := r.Retrieve(ctx, "test query", retriever.WithTopK(2), WithSortBy("year", false))
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(len(resp), convey.ShouldEqual, 2)
+				convey.So(resp[0].ID, convey.ShouldEqual, "3")
+				convey.So(resp[1].ID, convey.ShouldEqual, "1")
+
+				resp, err = r.Retrieve(ctx, "test query", retriever.WithTopK(2), WithOffset(2), WithSortBy("year", false))
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(len(resp), convey.ShouldEqual, 1)
+				convey.So(resp[0].ID, convey.ShouldEqual, "2")
+			})
+
+			convey.Convey(fmt.Sprintf("test validate index, resp3=%v", resp3), func() {
+				fake.attributes = []map[string]any{
+					{"identifier": "content", "attribute": "content", "type": "TEXT"},
+					{"identifier": "vector_content", "attribute": "vector_content", "type": "VECTOR", "dim": int64(10)},
+				}
+				r, err := NewRetriever(ctx, &RetrieverConfig{
+					Client:        client,
+					Index:         "test_index",
+					Embedding:     &mockEmbedding{},
+					ValidateIndex: true,
+				})
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(r, convey.ShouldNotBeNil)
+
+				_, err = NewRetriever(ctx, &RetrieverConfig{
+					Client:        client,
+					Index:         "test_index",
+					ReturnFields:  []string{"content", "extra"},
+					Embedding:     &mockEmbedding{},
+					ValidateIndex: true,
+				})
+				convey.So(err, convey.ShouldNotBeNil)
+			})
+		}
+
+		convey.Convey("test cluster client", func() {
+			fake := &fakeRedis{search: func(args []any) []fakeDoc {
+				return []fakeDoc{{id: "{eino_doc}:1", fields: map[string]string{defaultReturnFieldContent: d1.Content}}}
+			}}
+			client := redis.NewClusterClient(&redis.ClusterOptions{Addrs: []string{"fake:7000"}})
+			client.AddHook(fake)
+
+			_, err := NewRetriever(ctx, &RetrieverConfig{
+				Client:    client,
 				Index:     "test_index",
-				Embedding: &mockEmbedding{sizeForCall: []int{1}, dims: 10},
+				Embedding: &mockEmbedding{},
+			})
+			convey.So(err, convey.ShouldNotBeNil)
+
+			r, err := NewRetriever(ctx, &RetrieverConfig{
+				Client:       client,
+				Index:        "{eino_doc}idx",
+				ReturnFields: []string{defaultReturnFieldContent},
+				Embedding:    &mockEmbedding{sizeForCall: []int{1}, dims: 10},
 			})
 			convey.So(err, convey.ShouldBeNil)
 			resp, err := r.Retrieve(ctx, "test_query")
 			convey.So(err, convey.ShouldBeNil)
-			//s := "FT.SEARCH test_index (*)=>[KNN 5 @vector_content $vector AS distance] RETURN 2 content vector_content SORTBY distance ASC LIMIT 0 5"
-			//convey.So(strings.HasPrefix(cmd.String(), s), convey.ShouldBeTrue)
-			for i := range resp {
-				got := resp[i]
-				exp := docs[i]
-				convey.So(got.ID, convey.ShouldEqual, exp.ID)
-				convey.So(got.Content, convey.ShouldEqual, exp.Content)
-				convey.So(len(got.DenseVector()), convey.ShouldEqual, len(exp.DenseVector()))
-				for j, gf := range got.DenseVector() {
-					convey.So(gf, convey.ShouldAlmostEqual, exp.DenseVector()[j], 0.01)
-				}
-			}
+			convey.So(len(resp), convey.ShouldEqual, 1)
+			convey.So(resp[0].Content, convey.ShouldEqual, d1.Content)
 		})
-
 	})
 }
 
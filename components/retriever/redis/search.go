@@ -0,0 +1,122 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ftSearch runs FT.SEARCH as a raw command and parses either RESP2 or RESP3 reply,
+// so neither Protocol 2 nor UnstableResp3 is required on the client.
+func ftSearch(ctx context.Context, client redis.UniversalClient, index, query string, options *redis.FTSearchOptions) ([]redis.Document, error) {
+	args := append([]any{"FT.SEARCH", index}, redis.FTSearchQuery(query, options)...)
+	raw, err := client.Do(ctx, args...).Result()
+	if err != nil {
+		return nil, err
+	}
+	return parseSearchReply(raw, options.WithScores)
+}
+
+func parseSearchReply(raw any, withScores bool) ([]redis.Document, error) {
+	switch t := raw.(type) {
+	case []any:
+		return parseSearchReplyResp2(t, withScores)
+	case map[any]any, map[string]any:
+		return parseSearchReplyResp3(toKV(t))
+	default:
+		return nil, fmt.Errorf("[ftSearch] unexpected reply type %T", raw)
+	}
+}
+
+// parseSearchReplyResp2 parses [total, id, score?, [field, value, ...], id, ...].
+func parseSearchReplyResp2(data []any, withScores bool) ([]redis.Document, error) {
+	if len(data) < 1 {
+		return nil, fmt.Errorf("[ftSearch] unexpected reply length 0")
+	}
+
+	var docs []redis.Document
+	for i := 1; i < len(data); {
+		doc := redis.Document{ID: toString(data[i]), Fields: map[string]string{}}
+		i++
+
+		if withScores && i < len(data) {
+			score, err := toFloat(data[i])
+			if err != nil {
+				return nil, fmt.Errorf("[ftSearch] invalid score of doc %s, %w", doc.ID, err)
+			}
+			doc.Score = &score
+			i++
+		}
+
+		if i < len(data) {
+			if fields, ok := data[i].([]any); ok {
+				for j := 0; j+1 < len(fields); j += 2 {
+					doc.Fields[toString(fields[j])] = toString(fields[j+1])
+				}
+				i++
+			}
+		}
+
+		docs = append(docs, doc)
+	}
+
+	return docs, nil
+}
+
+// parseSearchReplyResp3 parses {total_results, results: [{id, score?, extra_attributes: {field: value}}]}.
+func parseSearchReplyResp3(kv map[string]any) ([]redis.Document, error) {
+	results, _ := kv["results"].([]any)
+	docs := make([]redis.Document, 0, len(results))
+	for _, result := range results {
+		rkv := toKV(result)
+		if rkv == nil {
+			return nil, fmt.Errorf("[ftSearch] unexpected result type %T", result)
+		}
+
+		doc := redis.Document{ID: toString(rkv["id"]), Fields: map[string]string{}}
+		if s, found := rkv["score"]; found {
+			score, err := toFloat(s)
+			if err != nil {
+				return nil, fmt.Errorf("[ftSearch] invalid score of doc %s, %w", doc.ID, err)
+			}
+			doc.Score = &score
+		}
+
+		for k, v := range toPairs(rkv["extra_attributes"]) {
+			doc.Fields[k] = toString(v)
+		}
+
+		docs = append(docs, doc)
+	}
+
+	return docs, nil
+}
+
+func toFloat(v any) (float64, error) {
+	switch t := v.(type) {
+	case float64:
+		return t, nil
+	case int64:
+		return float64(t), nil
+	default:
+		return strconv.ParseFloat(toString(v), 64)
+	}
+}
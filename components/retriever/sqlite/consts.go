@@ -0,0 +1,47 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlite
+
+const typ = "SQLite"
+
+func GetType() string {
+	return typ
+}
+
+const (
+	defaultTable = "eino_docs"
+	defaultTopK  = 5
+
+	// columns of the documents table, created by the sqlite indexer of
+	// github.com/cloudwego/eino-ext/components/indexer/sqlite
+	columnID       = "id"
+	columnContent  = "content"
+	columnMetadata = "metadata"
+	columnVector   = "embedding"
+
+	// ftsSuffix is appended to the table name for the name of its FTS5 table
+	ftsSuffix = "_fts"
+	// maxParams is the max number of bind parameters of a statement on SQLite before 3.32.0.
+	maxParams = 999
+)
+
+const (
+	// MetaKeyTextScore is the metadata key of the BM25 score in hybrid mode, absent if the document only matched the vector query.
+	MetaKeyTextScore = "text_score"
+	// MetaKeyVectorScore is the metadata key of the vector score in hybrid mode, absent if the document only matched the text query.
+	MetaKeyVectorScore = "vector_score"
+)
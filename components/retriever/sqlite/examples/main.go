@@ -0,0 +1,94 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+	"github.com/cloudwego/eino-ext/components/retriever/sqlite"
+)
+
+// run with: go run -tags sqlite_fts5 .
+func main() {
+	ctx := context.Background()
+
+	// the file is written by the example of the sqlite indexer
+	path := os.Getenv("SQLITE_PATH")
+	if path == "" {
+		path = filepath.Join(os.TempDir(), "eino_docs.db")
+	}
+
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		log.Fatalf("sql.Open failed, err=%v", err)
+	}
+	defer db.Close()
+
+	r, err := sqlite.NewRetriever(ctx, &sqlite.RetrieverConfig{
+		DB:         db,
+		Table:      "eino_docs",
+		SearchMode: sqlite.SearchModeHybrid,
+		Embedding:  &letterEmbedding{}, // replace it with real embedding component
+		Metric:     sqlite.MetricCosine,
+		TopK:       3,
+		Hybrid: &sqlite.HybridConfig{
+			Fusion: sqlite.FusionRRF,
+		},
+	})
+	if err != nil {
+		log.Fatalf("NewRetriever failed, err=%v", err)
+	}
+
+	docs, err := r.Retrieve(ctx, "eino framework",
+		retriever.WithTopK(2),
+		sqlite.WithMetadataFilter(map[string]any{"_source": "intro.md"}),
+		filter.WithFilter(filter.Lte("page", 2)),
+	)
+	if err != nil {
+		log.Fatalf("Retrieve failed, err=%v", err)
+	}
+	for _, doc := range docs {
+		log.Printf("id=%s, score=%.4f, text_score=%v, vector_score=%v, content=%s",
+			doc.ID, doc.Score(), doc.MetaData[sqlite.MetaKeyTextScore], doc.MetaData[sqlite.MetaKeyVectorScore], doc.Content)
+	}
+}
+
+// letterEmbedding embeds a text to its letter frequencies, only for the example.
+type letterEmbedding struct{}
+
+func (l *letterEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = make([]float64, 26)
+		for _, c := range strings.ToLower(text) {
+			if c >= 'a' && c <= 'z' {
+				vectors[i][c-'a']++
+			}
+		}
+	}
+	return vectors, nil
+}
@@ -0,0 +1,135 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlite
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+)
+
+// TranslateFilter translates f to a sql condition on the json text column metadataColumn, with ? placeholders and their args.
+// Eq, Ne and In compare json_extract of the field, which returns 1 and 0 for json true and false, so bools equal 1 and 0.
+// Range compares numbers with numbers and strings with strings, documents of other value types don't match.
+// Ne and Not match documents without the field.
+func TranslateFilter(f *filter.Filter, metadataColumn string) (string, []any, error) {
+	var args []any
+	cond, _, err := filter.Translate(f, func(f *filter.Filter) (string, error) {
+		return translateFilter(f, quoteIdent(metadataColumn), &args)
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return cond, args, nil
+}
+
+func translateFilter(f *filter.Filter, column string, args *[]any) (string, error) {
+	switch f.Op {
+	case filter.OpEq, filter.OpNe:
+		path, err := fieldPath(f)
+		if err != nil {
+			return "", err
+		}
+		value, err := scalarArg(f.Value)
+		if err != nil {
+			return "", fmt.Errorf("[filter] value of field %q: %w", f.Field, err)
+		}
+		*args = append(*args, path, value)
+		cond := fmt.Sprintf("json_extract(%s, ?) = ?", column)
+		if f.Op == filter.OpNe {
+			return "NOT IFNULL(" + cond + ", 0)", nil
+		}
+		return "(" + cond + ")", nil
+	case filter.OpIn:
+		path, err := fieldPath(f)
+		if err != nil {
+			return "", err
+		}
+		*args = append(*args, path)
+		for _, v := range f.Values {
+			value, err := scalarArg(v)
+			if err != nil {
+				return "", fmt.Errorf("[filter] value of field %q: %w", f.Field, err)
+			}
+			*args = append(*args, value)
+		}
+		return fmt.Sprintf("(json_extract(%s, ?) IN (%s))", column, placeholders(len(f.Values))), nil
+	case filter.OpRange:
+		path, err := fieldPath(f)
+		if err != nil {
+			return "", err
+		}
+		var conds []string
+		for _, bound := range []struct {
+			op    string
+			value any
+		}{{">", f.Bounds.Gt}, {">=", f.Bounds.Gte}, {"<", f.Bounds.Lt}, {"<=", f.Bounds.Lte}} {
+			if bound.value == nil {
+				continue
+			}
+			types := "'text'"
+			if filter.IsNumber(bound.value) {
+				types = "'integer', 'real'"
+			} else if _, ok := bound.value.(string); !ok {
+				return "", filter.Unsupported("sqlite", f, "range bounds must be numbers or strings")
+			}
+			*args = append(*args, path, path, bound.value)
+			conds = append(conds, fmt.Sprintf("json_type(%[1]s, ?) IN (%[2]s) AND json_extract(%[1]s, ?) %[3]s ?", column, types, bound.op))
+		}
+		return "(" + strings.Join(conds, " AND ") + ")", nil
+	case filter.OpExists:
+		path, err := fieldPath(f)
+		if err != nil {
+			return "", err
+		}
+		*args = append(*args, path)
+		return fmt.Sprintf("(json_type(%s, ?) IS NOT NULL)", column), nil
+	case filter.OpAnd, filter.OpOr:
+		conds := make([]string, 0, len(f.Children))
+		for _, child := range f.Children {
+			cond, err := translateFilter(child, column, args)
+			if err != nil {
+				return "", err
+			}
+			conds = append(conds, cond)
+		}
+		return joinConds(conds, " "+strings.ToUpper(string(f.Op))+" "), nil
+	default: // filter.OpNot, validated to have one child
+		cond, err := translateFilter(f.Children[0], column, args)
+		if err != nil {
+			return "", err
+		}
+		// a missing field makes the child NULL, which NOT keeps NULL
+		return "NOT IFNULL(" + cond + ", 0)", nil
+	}
+}
+
+func fieldPath(f *filter.Filter) (string, error) {
+	path, err := jsonPathKey(f.Field)
+	if err != nil {
+		return "", filter.Unsupported("sqlite", f, "field names with double quotes")
+	}
+	return path, nil
+}
+
+func joinConds(conds []string, sep string) string {
+	if len(conds) == 1 {
+		return conds[0]
+	}
+	return "(" + strings.Join(conds, sep) + ")"
+}
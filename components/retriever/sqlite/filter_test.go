@@ -0,0 +1,110 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+)
+
+func TestTranslateFilter(t *testing.T) {
+	convey.Convey("test TranslateFilter", t, func() {
+		cond, args, err := TranslateFilter(filter.And(
+			filter.Eq("lang", "en"),
+			filter.Not(filter.In("page", 1, 2)),
+			filter.Range("score", filter.Bounds{Gte: 0.5}),
+		), "metadata")
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(cond, convey.ShouldEqual, `((json_extract("metadata", ?) = ?) AND `+
+			`NOT IFNULL((json_extract("metadata", ?) IN (?,?)), 0) AND `+
+			`(json_type("metadata", ?) IN ('integer', 'real') AND json_extract("metadata", ?) >= ?))`)
+		convey.So(args, convey.ShouldResemble, []any{`$."lang"`, "en", `$."page"`, 1, 2, `$."score"`, `$."score"`, 0.5})
+
+		cond, args, err = TranslateFilter(nil, "metadata")
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(cond, convey.ShouldEqual, "")
+		convey.So(args, convey.ShouldBeNil)
+
+		_, _, err = TranslateFilter(filter.Range("draft", filter.Bounds{Gt: true}), "metadata")
+		convey.So(errors.Is(err, filter.ErrUnsupported), convey.ShouldBeTrue)
+
+		_, _, err = TranslateFilter(filter.Exists(`a"b`), "metadata")
+		convey.So(errors.Is(err, filter.ErrUnsupported), convey.ShouldBeTrue)
+
+		_, _, err = TranslateFilter(filter.In("lang"), "metadata")
+		convey.So(err, convey.ShouldNotBeNil)
+	})
+}
+
+func TestFilter(t *testing.T) {
+	convey.Convey("test filter", t, func() {
+		ctx := context.Background()
+		db, _ := openDB(t)
+
+		r, err := NewRetriever(ctx, &RetrieverConfig{DB: db, Embedding: &mockEmbedding{}, TopK: 10})
+		convey.So(err, convey.ShouldBeNil)
+
+		for _, c := range []struct {
+			f   *filter.Filter
+			ids []string
+		}{
+			{filter.Eq("lang", "en"), []string{"1", "2"}},
+			{filter.Eq("draft", true), []string{"2"}},
+			{filter.Eq("page", 1.0), []string{"1"}},
+			{filter.Ne("draft", true), []string{"1", "3"}},
+			{filter.In("lang", "zh", "fr"), []string{"3"}},
+			{filter.Range("page", filter.Bounds{Gte: 2}), []string{"2", "3"}},
+			{filter.Range("page", filter.Bounds{Gt: 1, Lt: 3}), []string{"2"}},
+			{filter.Range("lang", filter.Bounds{Gt: "f"}), []string{"3"}},
+			{filter.Range("lang", filter.Bounds{Gt: 0}), []string{}},
+			{filter.Exists("draft"), []string{"1", "2"}},
+			{filter.Not(filter.Eq("lang", "en")), []string{"3"}},
+			{filter.Not(filter.Eq("draft", false)), []string{"2", "3"}},
+			{filter.Or(filter.Eq("page", 1), filter.Eq("page", 3)), []string{"1", "3"}},
+			{filter.And(filter.Eq("lang", "en"), filter.Not(filter.Exists("missing"))), []string{"1", "2"}},
+		} {
+			docs, err := r.Retrieve(ctx, "sqlite", filter.WithFilter(c.f))
+			convey.So(err, convey.ShouldBeNil)
+			ids := docIDs(docs)
+			sort.Strings(ids)
+			convey.So(ids, convey.ShouldResemble, c.ids)
+		}
+
+		docs, err := r.Retrieve(ctx, "sqlite", WithMetadataFilter(map[string]any{"lang": "en", "draft": true}))
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(docIDs(docs), convey.ShouldResemble, []string{"2"})
+
+		docs, err = r.Retrieve(ctx, "sqlite", WithMetadataFilter(map[string]any{"lang": "en"}), filter.WithFilter(filter.Eq("page", 2)))
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(docIDs(docs), convey.ShouldResemble, []string{"2"})
+
+		_, err = r.Retrieve(ctx, "sqlite", WithMetadataFilter(map[string]any{"tags": []string{"a"}}))
+		convey.So(err, convey.ShouldNotBeNil)
+
+		_, err = r.Retrieve(ctx, "sqlite", WithMetadataFilter(map[string]any{`a"b`: 1}))
+		convey.So(err, convey.ShouldNotBeNil)
+
+		_, err = r.Retrieve(ctx, "sqlite", filter.WithFilter(filter.Range("draft", filter.Bounds{Gt: true})))
+		convey.So(errors.Is(err, filter.ErrUnsupported), convey.ShouldBeTrue)
+	})
+}
@@ -0,0 +1,208 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+type FusionMode string
+
+const (
+	// FusionRRF scores a document by sum of 1/(RankConstant+rank) over the text and vector result lists.
+	// see: https://plg.uwaterloo.ca/~gvcormac/cormacksigir09-rrf.pdf
+	FusionRRF FusionMode = "rrf"
+	// FusionWeighted min-max normalizes text and vector scores of each list to [0, 1],
+	// then scores a document by TextWeight*text + VectorWeight*vector.
+	FusionWeighted FusionMode = "weighted"
+)
+
+type HybridConfig struct {
+	// Fusion method to combine the text and vector result lists.
+	// Default FusionRRF.
+	Fusion FusionMode
+	// RankConstant k of RRF, larger values give lower ranked documents more influence.
+	// Default 60.
+	RankConstant int
+	// TextWeight and VectorWeight of FusionWeighted.
+	// Default 0.5 each if both not set.
+	TextWeight   float64
+	VectorWeight float64
+	// WindowSize number of candidates fetched by each of the two queries before fusion.
+	// Default 2*TopK, and never less than TopK.
+	WindowSize int
+}
+
+func (h *HybridConfig) check() error {
+	switch h.Fusion {
+	case "":
+		h.Fusion = FusionRRF
+	case FusionRRF, FusionWeighted:
+	default:
+		return fmt.Errorf("[hybrid] unknown fusion mode: %s", h.Fusion)
+	}
+
+	if h.RankConstant == 0 {
+		h.RankConstant = 60
+	}
+
+	if h.TextWeight < 0 || h.VectorWeight < 0 {
+		return fmt.Errorf("[hybrid] weights should not be negative")
+	}
+
+	if h.TextWeight == 0 && h.VectorWeight == 0 {
+		h.TextWeight, h.VectorWeight = 0.5, 0.5
+	}
+
+	return nil
+}
+
+type hybridHit struct {
+	doc         *schema.Document
+	textScore   *float64
+	vectorScore *float64
+	textRank    int
+	vectorRank  int
+	score       float64
+}
+
+// hybridSearch fetches the nearest WindowSize documents and the WindowSize best BM25 matches,
+// joins them by id and fuses their ranks or scores.
+func (r *Retriever) hybridSearch(ctx context.Context, tx *sql.Tx, vector []float64, match string, conds []string, args []any, topK int) ([]*schema.Document, error) {
+	h := r.config.Hybrid
+	window := h.WindowSize
+	if window == 0 {
+		window = 2 * topK
+	}
+	if window < topK {
+		window = topK
+	}
+
+	vectorDocs, err := r.vectorSearch(ctx, tx, vector, conds, args, window)
+	if err != nil {
+		return nil, err
+	}
+
+	textDocs, err := r.keywordSearch(ctx, tx, match, conds, args, window)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*hybridHit, len(vectorDocs)+len(textDocs))
+	var hits []*hybridHit
+	hitOf := func(doc *schema.Document) *hybridHit {
+		hit, ok := byID[doc.ID]
+		if !ok {
+			hit = &hybridHit{doc: doc}
+			byID[doc.ID] = hit
+			hits = append(hits, hit)
+		}
+		return hit
+	}
+	for i, doc := range vectorDocs {
+		hit := hitOf(doc)
+		score := doc.Score()
+		hit.vectorScore, hit.vectorRank = &score, i+1
+	}
+	for i, doc := range textDocs {
+		hit := hitOf(doc)
+		score := doc.Score()
+		hit.textScore, hit.textRank = &score, i+1
+	}
+
+	fuse(h, hits)
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].score != hits[j].score {
+			return hits[i].score > hits[j].score
+		}
+		return hits[i].doc.ID < hits[j].doc.ID
+	})
+	if len(hits) > topK {
+		hits = hits[:topK]
+	}
+
+	docs := make([]*schema.Document, 0, len(hits))
+	for _, hit := range hits {
+		if hit.textScore != nil {
+			hit.doc.MetaData[MetaKeyTextScore] = *hit.textScore
+		}
+		if hit.vectorScore != nil {
+			hit.doc.MetaData[MetaKeyVectorScore] = *hit.vectorScore
+		}
+		docs = append(docs, hit.doc.WithScore(hit.score))
+	}
+
+	return docs, nil
+}
+
+func fuse(h *HybridConfig, hits []*hybridHit) {
+	if h.Fusion == FusionRRF {
+		k := float64(h.RankConstant)
+		for _, hit := range hits {
+			if hit.textRank > 0 {
+				hit.score += 1 / (k + float64(hit.textRank))
+			}
+			if hit.vectorRank > 0 {
+				hit.score += 1 / (k + float64(hit.vectorRank))
+			}
+		}
+		return
+	}
+
+	textMin, textMax := scoreRange(hits, func(hit *hybridHit) *float64 { return hit.textScore })
+	vectorMin, vectorMax := scoreRange(hits, func(hit *hybridHit) *float64 { return hit.vectorScore })
+	for _, hit := range hits {
+		if hit.textScore != nil {
+			hit.score += h.TextWeight * normalize(*hit.textScore, textMin, textMax)
+		}
+		if hit.vectorScore != nil {
+			hit.score += h.VectorWeight * normalize(*hit.vectorScore, vectorMin, vectorMax)
+		}
+	}
+}
+
+func scoreRange(hits []*hybridHit, get func(hit *hybridHit) *float64) (minScore, maxScore float64) {
+	first := true
+	for _, hit := range hits {
+		v := get(hit)
+		if v == nil {
+			continue
+		}
+		if first || *v < minScore {
+			minScore = *v
+		}
+		if first || *v > maxScore {
+			maxScore = *v
+		}
+		first = false
+	}
+	return minScore, maxScore
+}
+
+// normalize maps v to [0, 1] by min-max, a list of equal scores maps to 1.
+func normalize(v, minScore, maxScore float64) float64 {
+	if maxScore == minScore {
+		return 1
+	}
+	return (v - minScore) / (maxScore - minScore)
+}
@@ -0,0 +1,43 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlite
+
+import (
+	"github.com/cloudwego/eino/components/retriever"
+)
+
+type implOptions struct {
+	MetadataFilter map[string]any
+	MatchQuery     string
+}
+
+// WithMetadataFilter keeps documents whose metadata has every key - value pair of filter, values must be scalars.
+// It's combined with filter.WithFilter of github.com/cloudwego/eino-ext/components/retriever/filter, if both are set.
+func WithMetadataFilter(filter map[string]any) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.MetadataFilter = filter
+	})
+}
+
+// WithMatchQuery sets the FTS5 query of keyword and hybrid mode as is, e.g. `"vector search" NEAR(sqlite)`,
+// instead of the one built from the query by RetrieverConfig.MatchQuery.
+// see: https://www.sqlite.org/fts5.html#full_text_query_syntax
+func WithMatchQuery(match string) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.MatchQuery = match
+	})
+}
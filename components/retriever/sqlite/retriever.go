@@ -0,0 +1,436 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+)
+
+// SearchMode selects the queries run by Retrieve.
+type SearchMode string
+
+const (
+	// SearchModeVector ranks documents by the similarity of their embedding to the query embedding.
+	SearchModeVector SearchMode = "vector"
+	// SearchModeKeyword ranks documents by the BM25 score of the FTS5 table, scored by -bm25(), higher is better.
+	SearchModeKeyword SearchMode = "keyword"
+	// SearchModeHybrid runs both and fuses the two result lists, see HybridConfig.
+	SearchModeHybrid SearchMode = "hybrid"
+)
+
+// Metric is the similarity of vectors.
+type Metric string
+
+const (
+	// MetricCosine cosine similarity, 1 - cosine distance.
+	MetricCosine Metric = "cosine"
+	// MetricL2 euclidean distance, scored by 1 / (1 + distance).
+	MetricL2 Metric = "l2"
+	// MetricIP inner product, not supported by VectorSearchSQLiteVec.
+	MetricIP Metric = "ip"
+)
+
+// VectorSearch is how vector mode finds the nearest documents.
+type VectorSearch string
+
+const (
+	// VectorSearchBruteForce reads the embeddings of the documents matching the filters and scores them in go,
+	// it works with any driver, and suits up to some hundred thousand documents.
+	VectorSearchBruteForce VectorSearch = "brute_force"
+	// VectorSearchSQLiteVec scores documents in sql by vec_distance_cosine or vec_distance_l2 of the sqlite-vec
+	// extension, which must be loaded into every connection of DB.
+	// see: https://github.com/asg017/sqlite-vec
+	VectorSearchSQLiteVec VectorSearch = "sqlite_vec"
+)
+
+type RetrieverConfig struct {
+	// DB SQLite database written by the sqlite indexer of github.com/cloudwego/eino-ext/components/indexer/sqlite,
+	// e.g. opened by sql.Open("sqlite", "docs.db") with driver modernc.org/sqlite,
+	// or sql.Open("sqlite3", "docs.db") with github.com/mattn/go-sqlite3 built with tag sqlite_fts5.
+	// Required.
+	DB *sql.DB
+	// Table of the documents, same as IndexerConfig.Table, its FTS5 table is Table+"_fts".
+	// Default "eino_docs".
+	Table string
+	// SearchMode of Retrieve, keyword and hybrid mode need the FTS5 table.
+	// Default SearchModeVector.
+	SearchMode SearchMode
+	// Embedding vectorization method for query.
+	// Required unless SearchMode is SearchModeKeyword.
+	Embedding embedding.Embedder
+	// Metric similarity of vectors.
+	// Default MetricCosine.
+	Metric Metric
+	// VectorSearch how vector and hybrid mode find the nearest documents.
+	// Default VectorSearchBruteForce.
+	VectorSearch VectorSearch
+	// MatchQuery builds the FTS5 query from the query of Retrieve, an empty result matches nothing.
+	// Default matches documents having any word of the query, each word quoted as an FTS5 string.
+	MatchQuery func(query string) string
+	// TopK limits number of results given.
+	// Default 5.
+	TopK int
+	// ScoreThreshold drops documents with lower score, which is the score of SearchMode, see SearchMode and Metric.
+	// Default nil.
+	ScoreThreshold *float64
+	// Hybrid fusion of SearchModeHybrid.
+	// Default &HybridConfig{Fusion: FusionRRF}.
+	Hybrid *HybridConfig
+}
+
+type Retriever struct {
+	config *RetrieverConfig
+}
+
+func NewRetriever(_ context.Context, config *RetrieverConfig) (*Retriever, error) {
+	if config.DB == nil {
+		return nil, fmt.Errorf("[NewRetriever] db not provided")
+	}
+
+	if config.Table == "" {
+		config.Table = defaultTable
+	}
+
+	switch config.SearchMode {
+	case "":
+		config.SearchMode = SearchModeVector
+	case SearchModeVector, SearchModeKeyword, SearchModeHybrid:
+	default:
+		return nil, fmt.Errorf("[NewRetriever] unknown search mode: %s", config.SearchMode)
+	}
+
+	if config.Embedding == nil && config.SearchMode != SearchModeKeyword {
+		return nil, fmt.Errorf("[NewRetriever] embedding not provided")
+	}
+
+	switch config.Metric {
+	case "":
+		config.Metric = MetricCosine
+	case MetricCosine, MetricL2, MetricIP:
+	default:
+		return nil, fmt.Errorf("[NewRetriever] unknown metric: %s", config.Metric)
+	}
+
+	switch config.VectorSearch {
+	case "":
+		config.VectorSearch = VectorSearchBruteForce
+	case VectorSearchBruteForce:
+	case VectorSearchSQLiteVec:
+		if config.Metric == MetricIP {
+			return nil, fmt.Errorf("[NewRetriever] metric %s isn't supported by sqlite-vec", config.Metric)
+		}
+	default:
+		return nil, fmt.Errorf("[NewRetriever] unknown vector search: %s", config.VectorSearch)
+	}
+
+	if config.MatchQuery == nil {
+		config.MatchQuery = matchAnyWord
+	}
+
+	if config.TopK == 0 {
+		config.TopK = defaultTopK
+	}
+
+	if config.Hybrid == nil {
+		config.Hybrid = &HybridConfig{}
+	}
+
+	if err := config.Hybrid.check(); err != nil {
+		return nil, fmt.Errorf("[NewRetriever] %w", err)
+	}
+
+	return &Retriever{
+		config: config,
+	}, nil
+}
+
+func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) (docs []*schema.Document, err error) {
+	co := retriever.GetCommonOptions(&retriever.Options{
+		TopK:           &r.config.TopK,
+		ScoreThreshold: r.config.ScoreThreshold,
+		Embedding:      r.config.Embedding,
+	}, opts...)
+	io := retriever.GetImplSpecificOptions(&implOptions{}, opts...)
+	f := filter.GetFilter(opts...)
+
+	conds, args, err := filterConds(io, f)
+
+	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
+	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
+		Query:          query,
+		TopK:           *co.TopK,
+		Filter:         filterString(io, f),
+		ScoreThreshold: co.ScoreThreshold,
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	if err != nil {
+		return nil, fmt.Errorf("[sqlite retriever] invalid filter: %w", err)
+	}
+
+	mode := r.config.SearchMode
+
+	var vector []float64
+	if mode != SearchModeKeyword {
+		if vector, err = r.embedQuery(ctx, co.Embedding, query); err != nil {
+			return nil, err
+		}
+	}
+
+	match := io.MatchQuery
+	if match == "" && mode != SearchModeVector {
+		match = r.config.MatchQuery(query)
+	}
+
+	err = r.read(ctx, func(tx *sql.Tx) error {
+		var e error
+		switch mode {
+		case SearchModeVector:
+			docs, e = r.vectorSearch(ctx, tx, vector, conds, args, *co.TopK)
+		case SearchModeKeyword:
+			docs, e = r.keywordSearch(ctx, tx, match, conds, args, *co.TopK)
+		default:
+			docs, e = r.hybridSearch(ctx, tx, vector, match, conds, args, *co.TopK)
+		}
+		return e
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[sqlite retriever] %w", err)
+	}
+
+	if co.ScoreThreshold != nil {
+		kept := docs[:0]
+		for _, doc := range docs {
+			if doc.Score() >= *co.ScoreThreshold {
+				kept = append(kept, doc)
+			}
+		}
+		docs = kept
+	}
+
+	callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
+
+	return docs, nil
+}
+
+func (r *Retriever) embedQuery(ctx context.Context, emb embedding.Embedder, query string) ([]float64, error) {
+	if emb == nil {
+		return nil, fmt.Errorf("[sqlite retriever] embedding not provided")
+	}
+
+	vectors, err := emb.EmbedStrings(r.makeEmbeddingCtx(ctx, emb), []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("[sqlite retriever] embedding has error: %w", err)
+	}
+
+	if len(vectors) != 1 {
+		return nil, fmt.Errorf("[sqlite retriever] invalid return length of vector, got=%d, expected=1", len(vectors))
+	}
+
+	return vectors[0], nil
+}
+
+// keywordSearch returns the topK best BM25 matches of match, an empty match matches nothing.
+func (r *Retriever) keywordSearch(ctx context.Context, tx *sql.Tx, match string, conds []string, args []any, topK int) ([]*schema.Document, error) {
+	if match == "" {
+		return nil, nil
+	}
+
+	fts := quoteIdent(r.config.Table + ftsSuffix)
+	query := fmt.Sprintf("SELECT d.%s, d.%s, d.%s, bm25(%s) AS rank FROM %s JOIN %s d ON d.rowid = %s.rowid%s ORDER BY rank LIMIT %d",
+		quoteIdent(columnID), quoteIdent(columnContent), quoteIdent(columnMetadata), fts,
+		fts, quoteIdent(r.config.Table), fts, where(append([]string{fts + " MATCH ?"}, conds...)), topK)
+
+	var docs []*schema.Document
+	err := queryRows(ctx, tx, query, append([]any{match}, args...), func(rows *sql.Rows) error {
+		var rank float64
+		doc, err := scanDocument(rows, &rank)
+		if err != nil {
+			return err
+		}
+		// bm25() is negative, lower is better
+		docs = append(docs, doc.WithScore(-rank))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[keywordSearch] %w", err)
+	}
+
+	return docs, nil
+}
+
+// read runs fn in a transaction, so the queries of a search read one snapshot.
+func (r *Retriever) read(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := r.config.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin failed, %w", err)
+	}
+	// nothing is written
+	defer func() { _ = tx.Rollback() }()
+
+	return fn(tx)
+}
+
+// queryRows runs query and calls scan for each row.
+func queryRows(ctx context.Context, tx *sql.Tx, query string, args []any, scan func(rows *sql.Rows) error) error {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("query failed, %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err = scan(rows); err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("read rows failed, %w", err)
+	}
+
+	return nil
+}
+
+// filterConds returns the sql conditions on the metadata column of the documents table aliased d,
+// of the metadata filter and the portable filter, and their args.
+func filterConds(io *implOptions, f *filter.Filter) ([]string, []any, error) {
+	var (
+		conds  []string
+		args   []any
+		column = "d." + quoteIdent(columnMetadata)
+	)
+
+	keys := make([]string, 0, len(io.MetadataFilter))
+	for k := range io.MetadataFilter {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		path, err := jsonPathKey(k)
+		if err != nil {
+			return nil, nil, err
+		}
+		value, err := scalarArg(io.MetadataFilter[k])
+		if err != nil {
+			return nil, nil, fmt.Errorf("metadata filter of key %s: %w", k, err)
+		}
+		conds = append(conds, fmt.Sprintf("json_extract(%s, ?) = ?", column))
+		args = append(args, path, value)
+	}
+
+	cond, ok, err := filter.Translate(f, func(f *filter.Filter) (string, error) {
+		return translateFilter(f, column, &args)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if ok {
+		conds = append(conds, cond)
+	}
+
+	return conds, args, nil
+}
+
+func (r *Retriever) makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfEmbedding,
+	}
+
+	if embType, ok := components.GetType(emb); ok {
+		runInfo.Type = embType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+func (r *Retriever) GetType() string {
+	return typ
+}
+
+func (r *Retriever) IsCallbacksEnabled() bool {
+	return true
+}
+
+// scanDocument scans the id, content and metadata columns of a row to a document, followed by the scores.
+func scanDocument(rows *sql.Rows, scores ...any) (*schema.Document, error) {
+	var (
+		id       string
+		content  sql.NullString
+		metadata sql.NullString
+	)
+
+	if err := rows.Scan(append([]any{&id, &content, &metadata}, scores...)...); err != nil {
+		return nil, fmt.Errorf("scan row failed, %w", err)
+	}
+
+	doc := &schema.Document{ID: id, Content: content.String, MetaData: map[string]any{}}
+	if metadata.String != "" {
+		if err := json.Unmarshal([]byte(metadata.String), &doc.MetaData); err != nil {
+			return nil, fmt.Errorf("unmarshal metadata failed, id=%s, %w", id, err)
+		}
+		if doc.MetaData == nil {
+			doc.MetaData = map[string]any{}
+		}
+	}
+
+	return doc, nil
+}
+
+func filterString(io *implOptions, f *filter.Filter) string {
+	var parts []string
+	if len(io.MetadataFilter) > 0 {
+		b, _ := json.Marshal(io.MetadataFilter)
+		parts = append(parts, "metadata has "+string(b))
+	}
+	if f != nil {
+		parts = append(parts, f.String())
+	}
+	return strings.Join(parts, " AND ")
+}
+
+// matchAnyWord is the default MatchQuery, e.g. `what's FTS5?` to `"what" OR "s" OR "FTS5"`.
+func matchAnyWord(query string) string {
+	words := strings.FieldsFunc(query, func(c rune) bool {
+		return !unicode.IsLetter(c) && !unicode.IsNumber(c)
+	})
+	for i, w := range words {
+		words[i] = `"` + w + `"`
+	}
+	return strings.Join(words, " OR ")
+}
@@ -0,0 +1,379 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/mattn/go-sqlite3"
+	"github.com/smartystreets/goconvey/convey"
+
+	indexer "github.com/cloudwego/eino-ext/components/indexer/sqlite"
+)
+
+// vecDriver is github.com/mattn/go-sqlite3 with go versions of the distance functions of sqlite-vec.
+const vecDriver = "sqlite3_vec_test"
+
+func init() {
+	sql.Register(vecDriver, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			if err := conn.RegisterFunc("vec_distance_cosine", func(a, b []byte) (float64, error) {
+				x, y, err := decodePair(a, b)
+				if err != nil {
+					return 0, err
+				}
+				var dot, nx, ny float64
+				for i := range x {
+					dot += x[i] * y[i]
+					nx += x[i] * x[i]
+					ny += y[i] * y[i]
+				}
+				return 1 - dot/math.Sqrt(nx*ny), nil
+			}, true); err != nil {
+				return err
+			}
+			return conn.RegisterFunc("vec_distance_l2", func(a, b []byte) (float64, error) {
+				x, y, err := decodePair(a, b)
+				if err != nil {
+					return 0, err
+				}
+				var d float64
+				for i := range x {
+					d += (x[i] - y[i]) * (x[i] - y[i])
+				}
+				return math.Sqrt(d), nil
+			}, true)
+		},
+	})
+}
+
+func decodePair(a, b []byte) ([]float64, []float64, error) {
+	if len(a) != len(b) {
+		return nil, nil, fmt.Errorf("dimension mismatch")
+	}
+	decode := func(blob []byte) []float64 {
+		v := make([]float64, len(blob)/4)
+		for i := range v {
+			v[i] = float64(math.Float32frombits(binary.LittleEndian.Uint32(blob[4*i:])))
+		}
+		return v
+	}
+	return decode(a), decode(b), nil
+}
+
+// mockEmbedding embeds queries by vectors, [1, 0] if absent.
+type mockEmbedding struct {
+	vectors map[string][]float64
+	err     error
+}
+
+func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		if v, ok := m.vectors[text]; ok {
+			vectors[i] = v
+		} else {
+			vectors[i] = []float64{1, 0}
+		}
+	}
+	return vectors, nil
+}
+
+// openDB opens a database in a temp file with the test documents stored by the sqlite indexer,
+// and reports whether the driver supports FTS5, which github.com/mattn/go-sqlite3 does with build tag sqlite_fts5.
+func openDB(t *testing.T) (*sql.DB, bool) {
+	db, err := sql.Open(vecDriver, filepath.Join(t.TempDir(), "docs.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	_, err = db.Exec("CREATE VIRTUAL TABLE temp.fts5_probe USING fts5(x)")
+	fts := err == nil
+
+	ctx := context.Background()
+	i, err := indexer.NewIndexer(ctx, &indexer.IndexerConfig{DB: db, DisableFullText: !fts})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	docs := []*schema.Document{
+		{ID: "1", Content: "sqlite stores documents in a single file", MetaData: map[string]any{"lang": "en", "page": 1, "draft": false}},
+		{ID: "2", Content: "fts5 gives bm25 keyword search in sqlite", MetaData: map[string]any{"lang": "en", "page": 2, "draft": true}},
+		{ID: "3", Content: "vectors are compared by cosine similarity", MetaData: map[string]any{"lang": "zh", "page": 3}},
+	}
+	docs[0].WithDenseVector([]float64{1, 0})
+	docs[1].WithDenseVector([]float64{0.8, 0.6})
+	docs[2].WithDenseVector([]float64{0, 1})
+	if _, err = i.Store(ctx, docs); err != nil {
+		t.Fatal(err)
+	}
+
+	// a document without embedding, only found by keyword
+	if _, err = db.Exec(`INSERT INTO eino_docs (id, content) VALUES ('4', 'notes about sqlite')`); err != nil {
+		t.Fatal(err)
+	}
+
+	return db, fts
+}
+
+func docIDs(docs []*schema.Document) []string {
+	ids := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+	return ids
+}
+
+func TestNewRetriever(t *testing.T) {
+	convey.Convey("test NewRetriever", t, func() {
+		ctx := context.Background()
+		db, _ := openDB(t)
+		emb := &mockEmbedding{}
+
+		for _, config := range []*RetrieverConfig{
+			{},
+			{DB: db},
+			{DB: db, Embedding: emb, SearchMode: "fuzzy"},
+			{DB: db, Embedding: emb, Metric: "hamming"},
+			{DB: db, Embedding: emb, VectorSearch: "hnsw"},
+			{DB: db, Embedding: emb, VectorSearch: VectorSearchSQLiteVec, Metric: MetricIP},
+			{DB: db, Embedding: emb, Hybrid: &HybridConfig{Fusion: "max"}},
+			{DB: db, Embedding: emb, Hybrid: &HybridConfig{TextWeight: -1}},
+		} {
+			_, err := NewRetriever(ctx, config)
+			convey.So(err, convey.ShouldNotBeNil)
+		}
+
+		r, err := NewRetriever(ctx, &RetrieverConfig{DB: db, Embedding: emb})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(r.config.Table, convey.ShouldEqual, defaultTable)
+		convey.So(r.config.SearchMode, convey.ShouldEqual, SearchModeVector)
+		convey.So(r.config.Metric, convey.ShouldEqual, MetricCosine)
+		convey.So(r.config.VectorSearch, convey.ShouldEqual, VectorSearchBruteForce)
+		convey.So(r.config.TopK, convey.ShouldEqual, defaultTopK)
+		convey.So(r.config.Hybrid, convey.ShouldResemble, &HybridConfig{Fusion: FusionRRF, RankConstant: 60, TextWeight: 0.5, VectorWeight: 0.5})
+		convey.So(r.GetType(), convey.ShouldEqual, typ)
+		convey.So(r.IsCallbacksEnabled(), convey.ShouldBeTrue)
+
+		// keyword mode doesn't embed
+		_, err = NewRetriever(ctx, &RetrieverConfig{DB: db, SearchMode: SearchModeKeyword})
+		convey.So(err, convey.ShouldBeNil)
+	})
+}
+
+func TestVectorSearch(t *testing.T) {
+	convey.Convey("test vector search", t, func() {
+		ctx := context.Background()
+		db, _ := openDB(t)
+		emb := &mockEmbedding{vectors: map[string][]float64{"3d": {1, 0, 0}, "zero": {0, 0}}}
+
+		for _, vs := range []VectorSearch{VectorSearchBruteForce, VectorSearchSQLiteVec} {
+			convey.Convey("test "+string(vs), func() {
+				r, err := NewRetriever(ctx, &RetrieverConfig{DB: db, Embedding: emb, VectorSearch: vs})
+				convey.So(err, convey.ShouldBeNil)
+
+				docs, err := r.Retrieve(ctx, "sqlite")
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(docIDs(docs), convey.ShouldResemble, []string{"1", "2", "3"})
+				convey.So(docs[0].Score(), convey.ShouldAlmostEqual, 1, 1e-6)
+				convey.So(docs[1].Score(), convey.ShouldAlmostEqual, 0.8, 1e-6)
+				convey.So(docs[2].Score(), convey.ShouldAlmostEqual, 0, 1e-6)
+				convey.So(docs[0].Content, convey.ShouldEqual, "sqlite stores documents in a single file")
+				convey.So(docs[0].MetaData["lang"], convey.ShouldEqual, "en")
+				convey.So(docs[0].MetaData["page"], convey.ShouldEqual, 1)
+				convey.So(docs[0].MetaData["draft"], convey.ShouldEqual, false)
+
+				docs, err = r.Retrieve(ctx, "sqlite", retriever.WithTopK(2), retriever.WithScoreThreshold(0.9))
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(docIDs(docs), convey.ShouldResemble, []string{"1"})
+
+				r.config.Metric = MetricL2
+				docs, err = r.Retrieve(ctx, "sqlite")
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(docIDs(docs), convey.ShouldResemble, []string{"1", "2", "3"})
+				convey.So(docs[0].Score(), convey.ShouldAlmostEqual, 1, 1e-6)
+				convey.So(docs[1].Score(), convey.ShouldAlmostEqual, 1/(1+math.Sqrt(0.4)), 1e-6)
+				convey.So(docs[2].Score(), convey.ShouldAlmostEqual, 1/(1+math.Sqrt2), 1e-6)
+
+				// the dimension of the query doesn't match
+				_, err = r.Retrieve(ctx, "3d")
+				convey.So(err, convey.ShouldNotBeNil)
+			})
+		}
+
+		convey.Convey("test inner product", func() {
+			r, err := NewRetriever(ctx, &RetrieverConfig{DB: db, Embedding: emb, Metric: MetricIP, TopK: 2})
+			convey.So(err, convey.ShouldBeNil)
+
+			docs, err := r.Retrieve(ctx, "sqlite")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(docIDs(docs), convey.ShouldResemble, []string{"1", "2"})
+			convey.So(docs[1].Score(), convey.ShouldAlmostEqual, 0.8, 1e-6)
+
+			// cosine of a zero vector scores 0, ties are broken by rowid
+			r.config.Metric = MetricCosine
+			docs, err = r.Retrieve(ctx, "zero")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(docIDs(docs), convey.ShouldResemble, []string{"1", "2"})
+			convey.So(docs[0].Score(), convey.ShouldEqual, 0)
+		})
+
+		convey.Convey("test errors", func() {
+			r, err := NewRetriever(ctx, &RetrieverConfig{DB: db, Embedding: &mockEmbedding{err: errors.New("mock")}})
+			convey.So(err, convey.ShouldBeNil)
+			_, err = r.Retrieve(ctx, "sqlite")
+			convey.So(err, convey.ShouldNotBeNil)
+
+			_, err = r.Retrieve(ctx, "sqlite", retriever.WithEmbedding(nil))
+			convey.So(err, convey.ShouldNotBeNil)
+
+			r, err = NewRetriever(ctx, &RetrieverConfig{DB: db, Embedding: emb, Table: "missing"})
+			convey.So(err, convey.ShouldBeNil)
+			_, err = r.Retrieve(ctx, "sqlite")
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestKeywordAndHybridSearch(t *testing.T) {
+	convey.Convey("test keyword and hybrid search", t, func() {
+		ctx := context.Background()
+		db, fts := openDB(t)
+		emb := &mockEmbedding{}
+
+		if !fts {
+			r, err := NewRetriever(ctx, &RetrieverConfig{DB: db, SearchMode: SearchModeKeyword})
+			convey.So(err, convey.ShouldBeNil)
+			// no FTS5 table
+			_, err = r.Retrieve(ctx, "sqlite")
+			convey.So(err, convey.ShouldNotBeNil)
+			return
+		}
+
+		convey.Convey("test keyword", func() {
+			r, err := NewRetriever(ctx, &RetrieverConfig{DB: db, SearchMode: SearchModeKeyword})
+			convey.So(err, convey.ShouldBeNil)
+
+			docs, err := r.Retrieve(ctx, "sqlite?")
+			convey.So(err, convey.ShouldBeNil)
+			ids := docIDs(docs)
+			sort.Strings(ids)
+			convey.So(ids, convey.ShouldResemble, []string{"1", "2", "4"})
+			for i, doc := range docs {
+				convey.So(doc.Score(), convey.ShouldBeGreaterThan, 0)
+				if i > 0 {
+					convey.So(doc.Score(), convey.ShouldBeLessThanOrEqualTo, docs[i-1].Score())
+				}
+			}
+
+			docs, err = r.Retrieve(ctx, "bm25 cosine")
+			convey.So(err, convey.ShouldBeNil)
+			ids = docIDs(docs)
+			sort.Strings(ids)
+			convey.So(ids, convey.ShouldResemble, []string{"2", "3"})
+
+			docs, err = r.Retrieve(ctx, "ignored", WithMatchQuery(`"keyword search"`))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(docIDs(docs), convey.ShouldResemble, []string{"2"})
+
+			// no words
+			docs, err = r.Retrieve(ctx, "?!")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(docs, convey.ShouldBeEmpty)
+
+			// malformed match query
+			_, err = r.Retrieve(ctx, "sqlite", WithMatchQuery(`"unbalanced`))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test hybrid", func() {
+			r, err := NewRetriever(ctx, &RetrieverConfig{DB: db, Embedding: emb, SearchMode: SearchModeHybrid, TopK: 4})
+			convey.So(err, convey.ShouldBeNil)
+
+			docs, err := r.Retrieve(ctx, "sqlite")
+			convey.So(err, convey.ShouldBeNil)
+			ids := docIDs(docs)
+			convey.So(ids[0], convey.ShouldEqual, "1")
+			sort.Strings(ids)
+			convey.So(ids, convey.ShouldResemble, []string{"1", "2", "3", "4"})
+			for _, doc := range docs {
+				_, hasText := doc.MetaData[MetaKeyTextScore]
+				_, hasVector := doc.MetaData[MetaKeyVectorScore]
+				convey.So(hasText, convey.ShouldEqual, doc.ID != "3")
+				convey.So(hasVector, convey.ShouldEqual, doc.ID != "4")
+			}
+			convey.So(docs[0].Score(), convey.ShouldAlmostEqual, 1.0/61+1.0/(60+rankOf(docs, "1")), 1e-9)
+
+			r.config.Hybrid = &HybridConfig{Fusion: FusionWeighted, TextWeight: 0, VectorWeight: 1, WindowSize: 1}
+			convey.So(r.config.Hybrid.check(), convey.ShouldBeNil)
+			docs, err = r.Retrieve(ctx, "sqlite", retriever.WithTopK(2))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(docs[0].ID, convey.ShouldEqual, "1")
+			convey.So(docs[0].Score(), convey.ShouldEqual, 1)
+			convey.So(len(docs), convey.ShouldBeLessThanOrEqualTo, 2)
+
+			// only the vector query matches
+			r.config.Hybrid = &HybridConfig{Fusion: FusionWeighted}
+			convey.So(r.config.Hybrid.check(), convey.ShouldBeNil)
+			docs, err = r.Retrieve(ctx, "nothing matches this")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(docIDs(docs), convey.ShouldResemble, []string{"1", "2", "3"})
+			convey.So(docs[0].Score(), convey.ShouldAlmostEqual, 0.5, 1e-6)
+			convey.So(docs[2].Score(), convey.ShouldAlmostEqual, 0, 1e-6)
+		})
+	})
+}
+
+// rankOf returns the rank of id in the text scores of docs.
+func rankOf(docs []*schema.Document, id string) float64 {
+	var target float64
+	for _, doc := range docs {
+		if doc.ID == id {
+			target = doc.MetaData[MetaKeyTextScore].(float64)
+		}
+	}
+	rank := 1
+	for _, doc := range docs {
+		if s, ok := doc.MetaData[MetaKeyTextScore].(float64); ok && s > target {
+			rank++
+		}
+	}
+	return float64(rank)
+}
+
+func TestMatchAnyWord(t *testing.T) {
+	convey.Convey("test matchAnyWord", t, func() {
+		convey.So(matchAnyWord(`what's "FTS5"?`), convey.ShouldEqual, `"what" OR "s" OR "FTS5"`)
+		convey.So(matchAnyWord("向量 检索"), convey.ShouldEqual, `"向量" OR "检索"`)
+		convey.So(matchAnyWord(" ?"), convey.ShouldEqual, "")
+	})
+}
@@ -0,0 +1,223 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlite
+
+import (
+	"container/heap"
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// vectorSearch returns the topK documents with embedding nearest to vector, with their scores.
+func (r *Retriever) vectorSearch(ctx context.Context, tx *sql.Tx, vector []float64, conds []string, args []any, topK int) ([]*schema.Document, error) {
+	if r.config.VectorSearch == VectorSearchSQLiteVec {
+		return r.sqliteVecSearch(ctx, tx, vector, conds, args, topK)
+	}
+	return r.bruteForceSearch(ctx, tx, vector, conds, args, topK)
+}
+
+func (r *Retriever) sqliteVecSearch(ctx context.Context, tx *sql.Tx, vector []float64, conds []string, args []any, topK int) ([]*schema.Document, error) {
+	fn := "vec_distance_cosine"
+	if r.config.Metric == MetricL2 {
+		fn = "vec_distance_l2"
+	}
+
+	query := fmt.Sprintf("SELECT d.%s, d.%s, d.%s, %s(d.%s, ?) AS distance FROM %s d%s ORDER BY distance LIMIT %d",
+		quoteIdent(columnID), quoteIdent(columnContent), quoteIdent(columnMetadata), fn, quoteIdent(columnVector),
+		quoteIdent(r.config.Table), where(append([]string{"d." + quoteIdent(columnVector) + " IS NOT NULL"}, conds...)), topK)
+
+	var docs []*schema.Document
+	err := queryRows(ctx, tx, query, append([]any{encodeVector(vector)}, args...), func(rows *sql.Rows) error {
+		var distance float64
+		doc, err := scanDocument(rows, &distance)
+		if err != nil {
+			return err
+		}
+		if r.config.Metric == MetricL2 {
+			docs = append(docs, doc.WithScore(1/(1+distance)))
+		} else {
+			docs = append(docs, doc.WithScore(1-distance))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[sqliteVecSearch] %w", err)
+	}
+
+	return docs, nil
+}
+
+// bruteForceSearch scores the embedding of every document matching conds, keeping the best topK,
+// then reads the content and metadata of them.
+func (r *Retriever) bruteForceSearch(ctx context.Context, tx *sql.Tx, vector []float64, conds []string, args []any, topK int) ([]*schema.Document, error) {
+	query := fmt.Sprintf("SELECT d.rowid, d.%s FROM %s d%s",
+		quoteIdent(columnVector), quoteIdent(r.config.Table),
+		where(append([]string{"d." + quoteIdent(columnVector) + " IS NOT NULL"}, conds...)))
+
+	s := newScorer(r.config.Metric, vector)
+	best := &vectorHits{}
+	err := queryRows(ctx, tx, query, args, func(rows *sql.Rows) error {
+		var (
+			rowID int64
+			blob  []byte
+		)
+		if err := rows.Scan(&rowID, &blob); err != nil {
+			return fmt.Errorf("scan row failed, %w", err)
+		}
+
+		score, err := s.score(blob)
+		if err != nil {
+			return fmt.Errorf("rowid=%d, %w", rowID, err)
+		}
+
+		hit := vectorHit{rowID: rowID, score: score}
+		if best.Len() < topK {
+			heap.Push(best, hit)
+		} else if best.Len() > 0 && best.worse((*best)[0], hit) {
+			(*best)[0] = hit
+			heap.Fix(best, 0)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[bruteForceSearch] %w", err)
+	}
+
+	hits := *best
+	sort.Slice(hits, func(i, j int) bool { return hits.worse(hits[j], hits[i]) })
+
+	docs, err := r.fetchByRowID(ctx, tx, hits)
+	if err != nil {
+		return nil, fmt.Errorf("[bruteForceSearch] %w", err)
+	}
+
+	return docs, nil
+}
+
+// fetchByRowID reads the documents of hits, in the order of hits, documents deleted meanwhile are skipped.
+func (r *Retriever) fetchByRowID(ctx context.Context, tx *sql.Tx, hits []vectorHit) ([]*schema.Document, error) {
+	found := make(map[int64]*schema.Document, len(hits))
+	for start := 0; start < len(hits); start += maxParams {
+		end := start + maxParams
+		if end > len(hits) {
+			end = len(hits)
+		}
+
+		args := make([]any, 0, end-start)
+		for _, hit := range hits[start:end] {
+			args = append(args, hit.rowID)
+		}
+
+		query := fmt.Sprintf("SELECT %s, %s, %s, rowid FROM %s WHERE rowid IN (%s)",
+			quoteIdent(columnID), quoteIdent(columnContent), quoteIdent(columnMetadata),
+			quoteIdent(r.config.Table), placeholders(len(args)))
+		err := queryRows(ctx, tx, query, args, func(rows *sql.Rows) error {
+			var rowID int64
+			doc, err := scanDocument(rows, &rowID)
+			if err != nil {
+				return err
+			}
+			found[rowID] = doc
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	docs := make([]*schema.Document, 0, len(hits))
+	for _, hit := range hits {
+		if doc, ok := found[hit.rowID]; ok {
+			docs = append(docs, doc.WithScore(hit.score))
+		}
+	}
+
+	return docs, nil
+}
+
+// scorer scores the float32 blobs of the embedding column against the query vector, higher is more similar.
+type scorer struct {
+	metric Metric
+	query  []float64
+	norm   float64
+}
+
+func newScorer(metric Metric, query []float64) *scorer {
+	var norm float64
+	for _, v := range query {
+		norm += v * v
+	}
+	return &scorer{metric: metric, query: query, norm: math.Sqrt(norm)}
+}
+
+func (s *scorer) score(blob []byte) (float64, error) {
+	if len(blob) != 4*len(s.query) {
+		return 0, fmt.Errorf("dimension mismatch, got=%d, expected=%d", len(blob)/4, len(s.query))
+	}
+
+	var dot, norm, dist float64
+	for i, q := range s.query {
+		v := float64(math.Float32frombits(binary.LittleEndian.Uint32(blob[4*i:])))
+		dot += q * v
+		norm += v * v
+		dist += (q - v) * (q - v)
+	}
+
+	switch s.metric {
+	case MetricL2:
+		return 1 / (1 + math.Sqrt(dist)), nil
+	case MetricIP:
+		return dot, nil
+	default:
+		if norm == 0 || s.norm == 0 {
+			return 0, nil
+		}
+		return dot / (math.Sqrt(norm) * s.norm), nil
+	}
+}
+
+type vectorHit struct {
+	rowID int64
+	score float64
+}
+
+// vectorHits is a min heap of hits, the worst hit on top, ties broken by the larger rowid being worse.
+type vectorHits []vectorHit
+
+func (h vectorHits) worse(a, b vectorHit) bool {
+	if a.score != b.score {
+		return a.score < b.score
+	}
+	return a.rowID > b.rowID
+}
+
+func (h vectorHits) Len() int           { return len(h) }
+func (h vectorHits) Less(i, j int) bool { return h.worse(h[i], h[j]) }
+func (h vectorHits) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *vectorHits) Push(x any)        { *h = append(*h, x.(vectorHit)) }
+func (h *vectorHits) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
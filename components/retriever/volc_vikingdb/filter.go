@@ -0,0 +1,99 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package volc_vikingdb
+
+import (
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+)
+
+// TranslateFilter translates a portable filter to a VikingDB filter DSL, see https://www.volcengine.com/docs/84313/1254609.
+// Eq and In are translated to must, Ne to must_not, and Not is pushed down to the leaves, as the DSL has no negation.
+// Range bounds must be numbers, and Exists is not supported.
+func TranslateFilter(f *filter.Filter) (map[string]any, error) {
+	return translateFilter(f, false)
+}
+
+func translateFilter(f *filter.Filter, negate bool) (map[string]any, error) {
+	switch f.Op {
+	case filter.OpEq, filter.OpNe, filter.OpIn:
+		conds := f.Values
+		if f.Op != filter.OpIn {
+			conds = []any{f.Value}
+		}
+		op := "must"
+		if (f.Op == filter.OpNe) != negate {
+			op = "must_not"
+		}
+		return map[string]any{"op": op, "field": f.Field, "conds": conds}, nil
+	case filter.OpRange:
+		bounds := []struct {
+			key, negated string
+			value        any
+		}{{"gt", "lte", f.Bounds.Gt}, {"gte", "lt", f.Bounds.Gte}, {"lt", "gte", f.Bounds.Lt}, {"lte", "gt", f.Bounds.Lte}}
+		dsl := map[string]any{"op": "range", "field": f.Field}
+		var outside []any
+		for _, b := range bounds {
+			if b.value == nil {
+				continue
+			}
+			if !filter.IsNumber(b.value) {
+				return nil, filter.Unsupported("vikingdb", f, "range bounds must be numbers")
+			}
+			dsl[b.key] = b.value
+			outside = append(outside, map[string]any{"op": "range", "field": f.Field, b.negated: b.value})
+		}
+		if !negate {
+			return dsl, nil
+		}
+		// not within the bounds is below the lower one or above the upper one
+		if len(outside) == 1 {
+			return outside[0].(map[string]any), nil
+		}
+		return map[string]any{"op": "or", "conds": outside}, nil
+	case filter.OpAnd, filter.OpOr:
+		op := string(f.Op)
+		if negate {
+			// De Morgan's laws
+			op = map[filter.Op]string{filter.OpAnd: "or", filter.OpOr: "and"}[f.Op]
+		}
+		conds := make([]any, 0, len(f.Children))
+		for _, child := range f.Children {
+			dsl, err := translateFilter(child, negate)
+			if err != nil {
+				return nil, err
+			}
+			conds = append(conds, dsl)
+		}
+		return map[string]any{"op": op, "conds": conds}, nil
+	case filter.OpNot:
+		return translateFilter(f.Children[0], !negate)
+	default:
+		return nil, filter.Unsupported("vikingdb", f, "")
+	}
+}
+
+// mergeFilterDSL combines the filter dsl and the translated portable filter with and.
+func mergeFilterDSL(dsl map[string]any, f *filter.Filter) (map[string]any, error) {
+	portable, ok, err := filter.Translate(f, TranslateFilter)
+	if err != nil || !ok {
+		return dsl, err
+	}
+	if len(dsl) == 0 {
+		return portable, nil
+	}
+	return map[string]any{"op": "and", "conds": []any{dsl, portable}}, nil
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package volc_vikingdb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
+)
+
+func TestTranslateFilter(t *testing.T) {
+	convey.Convey("test TranslateFilter", t, func() {
+		dsl, err := TranslateFilter(filter.And(
+			filter.Eq("source", "a.md"),
+			filter.Ne("draft", true),
+			filter.In("lang", "en", "zh"),
+			filter.Range("page", filter.Bounds{Gte: 1, Lt: 10}),
+		))
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(dsl, convey.ShouldResemble, map[string]any{"op": "and", "conds": []any{
+			map[string]any{"op": "must", "field": "source", "conds": []any{"a.md"}},
+			map[string]any{"op": "must_not", "field": "draft", "conds": []any{true}},
+			map[string]any{"op": "must", "field": "lang", "conds": []any{"en", "zh"}},
+			map[string]any{"op": "range", "field": "page", "gte": 1, "lt": 10},
+		}})
+
+		dsl, err = TranslateFilter(filter.Not(filter.Or(
+			filter.In("lang", "en"),
+			filter.Ne("draft", true),
+			filter.Range("page", filter.Bounds{Gte: 1, Lt: 10}),
+			filter.Not(filter.Gt("score", 0.5)),
+		)))
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(dsl, convey.ShouldResemble, map[string]any{"op": "and", "conds": []any{
+			map[string]any{"op": "must_not", "field": "lang", "conds": []any{"en"}},
+			map[string]any{"op": "must", "field": "draft", "conds": []any{true}},
+			map[string]any{"op": "or", "conds": []any{
+				map[string]any{"op": "range", "field": "page", "lt": 1},
+				map[string]any{"op": "range", "field": "page", "gte": 10},
+			}},
+			map[string]any{"op": "range", "field": "score", "gt": 0.5},
+		}})
+
+		_, err = TranslateFilter(filter.Gte("date", "2024-01-01"))
+		convey.So(errors.Is(err, filter.ErrUnsupported), convey.ShouldBeTrue)
+
+		_, err = TranslateFilter(filter.Exists("author"))
+		convey.So(errors.Is(err, filter.ErrUnsupported), convey.ShouldBeTrue)
+	})
+
+	convey.Convey("test mergeFilterDSL", t, func() {
+		native := map[string]any{"op": "must", "field": "lang", "conds": []any{"en"}}
+		dsl, err := mergeFilterDSL(native, nil)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(dsl, convey.ShouldResemble, native)
+
+		dsl, err = mergeFilterDSL(nil, filter.Eq("source", "a.md"))
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(dsl, convey.ShouldResemble, map[string]any{"op": "must", "field": "source", "conds": []any{"a.md"}})
+
+		dsl, err = mergeFilterDSL(native, filter.Eq("source", "a.md"))
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(dsl, convey.ShouldResemble, map[string]any{"op": "and", "conds": []any{
+			native,
+			map[string]any{"op": "must", "field": "source", "conds": []any{"a.md"}},
+		}})
+
+		_, err = mergeFilterDSL(native, filter.And())
+		convey.So(err, convey.ShouldNotBeNil)
+	})
+}
@@ -2,10 +2,12 @@ module github.com/cloudwego/eino-ext/components/retriever/volc_vikingdb
 
 go 1.23.0
 
+replace github.com/cloudwego/eino-ext/components/retriever/filter => ../filter
 
 require (
 	github.com/bytedance/mockey v1.2.13
 	github.com/cloudwego/eino v0.3.27
+	github.com/cloudwego/eino-ext/components/retriever/filter v0.0.0-00010101000000-000000000000
 	github.com/smartystreets/goconvey v1.8.1
 	github.com/volcengine/volc-sdk-golang v1.0.199
 )
@@ -28,6 +28,8 @@ import (
 	"github.com/cloudwego/eino/components/embedding"
 	"github.com/cloudwego/eino/components/retriever"
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/retriever/filter"
 )
 
 const (
@@ -148,6 +150,7 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		Embedding:      r.config.EmbeddingConfig.Embedding,
 		DSLInfo:        r.config.FilterDSL,
 	}, opts...)
+	options.DSLInfo, err = mergeFilterDSL(options.DSLInfo, filter.GetFilter(opts...))
 
 	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
 	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
@@ -162,6 +165,10 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		}
 	}()
 
+	if err != nil {
+		return nil, fmt.Errorf("[VikingDBRetriever] invalid filter: %w", err)
+	}
+
 	var result []*vikingdb.Data
 
 	if r.config.WithMultiModal {
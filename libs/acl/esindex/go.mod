@@ -0,0 +1,20 @@
+module github.com/cloudwego/eino-ext/libs/acl/esindex
+
+go 1.23.0
+
+require (
+	github.com/elastic/go-elasticsearch/v8 v8.16.0
+	github.com/stretchr/testify v1.9.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/elastic/elastic-transport-go/v8 v8.6.0 // indirect
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.opentelemetry.io/otel v1.28.0 // indirect
+	go.opentelemetry.io/otel/metric v1.28.0 // indirect
+	go.opentelemetry.io/otel/trace v1.28.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
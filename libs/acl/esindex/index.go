@@ -0,0 +1,238 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package esindex
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/reindex"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/indices/create"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/indices/delete"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/indices/exists"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/indices/existsalias"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/indices/getalias"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/indices/getmapping"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/indices/putindextemplate"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/indices/updatealiases"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+)
+
+// CreateIndex creates index with the mapping and settings of s, and adds aliases to it.
+func CreateIndex(ctx context.Context, client *elasticsearch.Client, index string, s *Schema, aliases ...string) error {
+	mapping, err := s.Mapping()
+	if err != nil {
+		return fmt.Errorf("[CreateIndex] %w", err)
+	}
+
+	req := &create.Request{Mappings: mapping, Settings: s.Settings}
+	if len(aliases) > 0 {
+		req.Aliases = make(map[string]types.Alias, len(aliases))
+		for _, alias := range aliases {
+			req.Aliases[alias] = types.Alias{}
+		}
+	}
+
+	if _, err = create.NewCreateFunc(client)(index).Request(req).Do(ctx); err != nil {
+		return fmt.Errorf("[CreateIndex] create index %s failed, %w", index, err)
+	}
+	return nil
+}
+
+// CheckIndex checks the mapping of index against s, index can be an alias or a pattern, whose indices are all checked.
+func CheckIndex(ctx context.Context, client *elasticsearch.Client, index string, s *Schema) error {
+	resp, err := getmapping.NewGetMappingFunc(client)().Index(index).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("[CheckIndex] get mapping of %s failed, %w", index, err)
+	}
+	if len(resp) == 0 {
+		return fmt.Errorf("[CheckIndex] index %s not found", index)
+	}
+
+	names := make([]string, 0, len(resp))
+	for name := range resp {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		mapping := resp[name].Mappings
+		if err = s.Check(&mapping); err != nil {
+			return fmt.Errorf("[CheckIndex] index %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// EnsureIndex creates index from s if neither an index nor an alias of the name exists, or checks it otherwise.
+func EnsureIndex(ctx context.Context, client *elasticsearch.Client, index string, s *Schema) error {
+	found, err := exists.NewExistsFunc(client)(index).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("[EnsureIndex] check existence of %s failed, %w", index, err)
+	}
+	if found {
+		return CheckIndex(ctx, client, index, s)
+	}
+	return CreateIndex(ctx, client, index, s)
+}
+
+// Template is an index template applying the mapping and settings of a Schema to the indices created with matching names,
+// e.g. the indices of a time series or the new indices of ReindexAndSwap.
+type Template struct {
+	// Name of the template.
+	// Required.
+	Name string
+	// IndexPatterns are the wildcard patterns of index names.
+	// Required.
+	IndexPatterns []string
+	// Priority of the template among the templates matching an index, the highest wins.
+	// Optional.
+	Priority *int64
+	// Aliases added to the created indices.
+	// Optional.
+	Aliases []string
+}
+
+// PutIndexTemplate creates or replaces the composable index template t with the mapping and settings of s.
+func PutIndexTemplate(ctx context.Context, client *elasticsearch.Client, t *Template, s *Schema) error {
+	if t.Name == "" || len(t.IndexPatterns) == 0 {
+		return fmt.Errorf("[PutIndexTemplate] template name or index patterns not provided")
+	}
+
+	mapping, err := s.Mapping()
+	if err != nil {
+		return fmt.Errorf("[PutIndexTemplate] %w", err)
+	}
+
+	tm := &types.IndexTemplateMapping{Mappings: mapping, Settings: s.Settings}
+	if len(t.Aliases) > 0 {
+		tm.Aliases = make(map[string]types.Alias, len(t.Aliases))
+		for _, alias := range t.Aliases {
+			tm.Aliases[alias] = types.Alias{}
+		}
+	}
+
+	_, err = putindextemplate.NewPutIndexTemplateFunc(client)(t.Name).Request(&putindextemplate.Request{
+		IndexPatterns: t.IndexPatterns,
+		Priority:      t.Priority,
+		Template:      tm,
+	}).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("[PutIndexTemplate] put template %s failed, %w", t.Name, err)
+	}
+	return nil
+}
+
+type ReindexConfig struct {
+	// NewIndex is the name of the index created from the schema.
+	// Default the alias with a timestamp suffix, e.g. "docs-20250102150405".
+	NewIndex string
+	// DeleteOld if true, deletes the indices the alias pointed to after the swap.
+	// Default false, keep them for rollback.
+	DeleteOld bool
+	// Script transforms the documents while reindexing, e.g. to rename fields.
+	// Optional.
+	Script *types.Script
+}
+
+// ReindexAndSwap migrates the documents behind alias to a new index created from s without downtime:
+// it creates the new index, copies the documents of the indices of alias into it, and atomically moves alias to it,
+// so that the indexer and the retriever using alias switch to the new mapping at once.
+// Documents written to alias during the copy aren't migrated, pause writes or reindex them again.
+// If alias doesn't exist yet, the new index is created with alias.
+// It returns the name of the new index.
+func ReindexAndSwap(ctx context.Context, client *elasticsearch.Client, alias string, s *Schema, config *ReindexConfig) (string, error) {
+	if config == nil {
+		config = &ReindexConfig{}
+	}
+
+	newIndex := config.NewIndex
+	if newIndex == "" {
+		newIndex = alias + "-" + time.Now().UTC().Format("20060102150405")
+	}
+
+	found, err := existsalias.NewExistsAliasFunc(client)(alias).Do(ctx)
+	if err != nil {
+		return "", fmt.Errorf("[ReindexAndSwap] check existence of alias %s failed, %w", alias, err)
+	}
+	if !found {
+		indexFound, err := exists.NewExistsFunc(client)(alias).Do(ctx)
+		if err != nil {
+			return "", fmt.Errorf("[ReindexAndSwap] check existence of %s failed, %w", alias, err)
+		}
+		if indexFound {
+			return "", fmt.Errorf("[ReindexAndSwap] %s is an index, not an alias, reindex it to another index and add the alias manually", alias)
+		}
+		if err = CreateIndex(ctx, client, newIndex, s, alias); err != nil {
+			return "", fmt.Errorf("[ReindexAndSwap] %w", err)
+		}
+		return newIndex, nil
+	}
+
+	resp, err := getalias.NewGetAliasFunc(client)().Name(alias).Do(ctx)
+	if err != nil {
+		return "", fmt.Errorf("[ReindexAndSwap] get alias %s failed, %w", alias, err)
+	}
+	oldIndices := make([]string, 0, len(resp))
+	for name := range resp {
+		if name == newIndex {
+			return "", fmt.Errorf("[ReindexAndSwap] alias %s already points to %s", alias, newIndex)
+		}
+		oldIndices = append(oldIndices, name)
+	}
+	sort.Strings(oldIndices)
+
+	if err = CreateIndex(ctx, client, newIndex, s); err != nil {
+		return "", fmt.Errorf("[ReindexAndSwap] %w", err)
+	}
+
+	reindexResp, err := reindex.NewReindexFunc(client)().Request(&reindex.Request{
+		Source: types.ReindexSource{Index: oldIndices},
+		Dest:   types.ReindexDestination{Index: newIndex},
+		Script: config.Script,
+	}).WaitForCompletion(true).Refresh(true).Do(ctx)
+	if err != nil {
+		return "", fmt.Errorf("[ReindexAndSwap] reindex %s to %s failed, %w", strings.Join(oldIndices, ","), newIndex, err)
+	}
+	if len(reindexResp.Failures) > 0 {
+		f := reindexResp.Failures[0]
+		return "", fmt.Errorf("[ReindexAndSwap] reindex %s to %s failed, %d failures, first: id=%s, type=%s",
+			strings.Join(oldIndices, ","), newIndex, len(reindexResp.Failures), f.Id, f.Type)
+	}
+
+	_, err = updatealiases.NewUpdateAliasesFunc(client)().Request(&updatealiases.Request{
+		Actions: []types.IndicesAction{
+			{Remove: &types.RemoveAction{Indices: oldIndices, Alias: &alias}},
+			{Add: &types.AddAction{Index: &newIndex, Alias: &alias}},
+		},
+	}).Do(ctx)
+	if err != nil {
+		return "", fmt.Errorf("[ReindexAndSwap] swap alias %s to %s failed, %w", alias, newIndex, err)
+	}
+
+	if config.DeleteOld {
+		if _, err = delete.NewDeleteFunc(client)(strings.Join(oldIndices, ",")).Do(ctx); err != nil {
+			return newIndex, fmt.Errorf("[ReindexAndSwap] delete old indices %s failed, %w", strings.Join(oldIndices, ","), err)
+		}
+	}
+
+	return newIndex, nil
+}
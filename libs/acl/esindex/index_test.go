@@ -0,0 +1,310 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package esindex
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeES is an in-memory es of indices, aliases and templates.
+type fakeES struct {
+	mu        sync.Mutex
+	mappings  map[string]json.RawMessage
+	aliases   map[string][]string
+	templates map[string]json.RawMessage
+	reindexed []string
+	failures  bool
+	requests  []string
+}
+
+func newFakeES() *fakeES {
+	return &fakeES{
+		mappings:  map[string]json.RawMessage{},
+		aliases:   map[string][]string{},
+		templates: map[string]json.RawMessage{},
+	}
+}
+
+func (f *fakeES) client(t *testing.T) *elasticsearch.Client {
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	client, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{srv.URL}})
+	require.NoError(t, err)
+	return client
+}
+
+func (f *fakeES) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w.Header().Set("X-Elastic-Product", "Elasticsearch")
+	w.Header().Set("Content-Type", "application/json")
+	body, _ := io.ReadAll(r.Body)
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+
+	reply := func(status int, v any) {
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(v)
+	}
+	notFound := func(what string) {
+		reply(http.StatusNotFound, map[string]any{"error": map[string]any{"type": "index_not_found_exception", "reason": what + " missing"}, "status": 404})
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case r.Method == http.MethodHead && len(parts) == 2 && parts[0] == "_alias":
+		if _, found := f.aliases[parts[1]]; !found {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case r.Method == http.MethodGet && len(parts) == 2 && parts[0] == "_alias":
+		indices, found := f.aliases[parts[1]]
+		if !found {
+			notFound(parts[1])
+			return
+		}
+		resp := map[string]any{}
+		for _, index := range indices {
+			resp[index] = map[string]any{"aliases": map[string]any{parts[1]: map[string]any{}}}
+		}
+		reply(http.StatusOK, resp)
+	case r.Method == http.MethodPut && len(parts) == 2 && parts[0] == "_index_template":
+		f.templates[parts[1]] = body
+		reply(http.StatusOK, map[string]any{"acknowledged": true})
+	case r.Method == http.MethodPost && parts[0] == "_reindex":
+		f.reindexed = append(f.reindexed, string(body))
+		if f.failures {
+			reply(http.StatusOK, map[string]any{"total": 1, "failures": []any{map[string]any{"id": "1", "index": "x", "status": 400, "type": "mapper_parsing_exception", "cause": map[string]any{"type": "mapper_parsing_exception"}}}})
+			return
+		}
+		reply(http.StatusOK, map[string]any{"total": 1, "created": 1})
+	case r.Method == http.MethodPost && parts[0] == "_aliases":
+		var req struct {
+			Actions []struct {
+				Add *struct {
+					Index string `json:"index"`
+					Alias string `json:"alias"`
+				} `json:"add"`
+				Remove *struct {
+					Indices []string `json:"indices"`
+					Alias   string   `json:"alias"`
+				} `json:"remove"`
+			} `json:"actions"`
+		}
+		_ = json.Unmarshal(body, &req)
+		for _, a := range req.Actions {
+			if a.Remove != nil {
+				delete(f.aliases, a.Remove.Alias)
+			}
+			if a.Add != nil {
+				f.aliases[a.Add.Alias] = append(f.aliases[a.Add.Alias], a.Add.Index)
+			}
+		}
+		reply(http.StatusOK, map[string]any{"acknowledged": true})
+	case len(parts) == 1 && r.Method == http.MethodHead:
+		if _, found := f.mappings[parts[0]]; !found {
+			if _, found = f.aliases[parts[0]]; !found {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}
+	case len(parts) == 1 && r.Method == http.MethodPut:
+		if _, found := f.mappings[parts[0]]; found {
+			reply(http.StatusBadRequest, map[string]any{"error": map[string]any{"type": "resource_already_exists_exception"}, "status": 400})
+			return
+		}
+		var req struct {
+			Mappings json.RawMessage     `json:"mappings"`
+			Aliases  map[string]struct{} `json:"aliases"`
+		}
+		_ = json.Unmarshal(body, &req)
+		f.mappings[parts[0]] = req.Mappings
+		for alias := range req.Aliases {
+			f.aliases[alias] = append(f.aliases[alias], parts[0])
+		}
+		reply(http.StatusOK, map[string]any{"acknowledged": true, "shards_acknowledged": true, "index": parts[0]})
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		for _, index := range strings.Split(parts[0], ",") {
+			delete(f.mappings, index)
+		}
+		reply(http.StatusOK, map[string]any{"acknowledged": true})
+	case len(parts) == 2 && parts[1] == "_mapping" && r.Method == http.MethodGet:
+		indices := f.aliases[parts[0]]
+		if _, found := f.mappings[parts[0]]; found {
+			indices = []string{parts[0]}
+		}
+		if len(indices) == 0 {
+			notFound(parts[0])
+			return
+		}
+		resp := map[string]any{}
+		for _, index := range indices {
+			resp[index] = map[string]any{"mappings": f.mappings[index]}
+		}
+		reply(http.StatusOK, resp)
+	default:
+		reply(http.StatusBadRequest, map[string]any{"error": map[string]any{"type": "unexpected " + r.Method + " " + r.URL.Path}, "status": 400})
+	}
+}
+
+func testSchema() *Schema {
+	return &Schema{Fields: []*Field{
+		{Name: "content", Type: FieldTypeText, Content: true, Analyzer: "standard"},
+		{Name: "content_vector", Type: FieldTypeDenseVector, VectorOf: "content"},
+		{Name: "content_sparse", Type: FieldTypeSparseVector, VectorOf: "content"},
+		{Name: "source", Type: FieldTypeKeyword, MetadataKey: "_source"},
+	}}
+}
+
+func TestCreateAndCheckIndex(t *testing.T) {
+	ctx := context.Background()
+	es := newFakeES()
+	client := es.client(t)
+
+	err := CreateIndex(ctx, client, "docs", testSchema())
+	assert.ErrorContains(t, err, "dims of dense vector field content_vector not provided")
+
+	s := testSchema().WithDims(4)
+	require.NoError(t, EnsureIndex(ctx, client, "docs", s))
+	assert.JSONEq(t, `{"properties": {
+		"content": {"type": "text", "analyzer": "standard"},
+		"content_vector": {"type": "dense_vector", "dims": 4, "index": true, "similarity": "cosine"},
+		"content_sparse": {"type": "sparse_vector"},
+		"source": {"type": "keyword"}
+	}}`, string(es.mappings["docs"]))
+
+	// exists, checked
+	require.NoError(t, EnsureIndex(ctx, client, "docs", s))
+	assert.NoError(t, CheckIndex(ctx, client, "docs", s))
+
+	err = CheckIndex(ctx, client, "docs", testSchema().WithDims(8))
+	assert.ErrorContains(t, err, "field content_vector has 4 dims, expected 8")
+
+	other := testSchema().WithDims(4)
+	other.Fields[1].Similarity = SimilarityDotProduct
+	other.Fields[3].Type = FieldTypeText
+	other.Fields = append(other.Fields, &Field{Name: "title", Type: FieldTypeText})
+	err = EnsureIndex(ctx, client, "docs", other)
+	assert.ErrorContains(t, err, "index docs: [Check] mapping mismatch: field content_vector has similarity cosine, expected dot_product; "+
+		"field source is keyword, expected text; field title not found")
+
+	err = CheckIndex(ctx, client, "missing", s)
+	assert.ErrorContains(t, err, "get mapping of missing failed")
+
+	require.NoError(t, CreateIndex(ctx, client, "docs-v2", s, "docs-read"))
+	assert.Equal(t, []string{"docs-v2"}, es.aliases["docs-read"])
+	assert.NoError(t, CheckIndex(ctx, client, "docs-read", s))
+}
+
+func TestPutIndexTemplate(t *testing.T) {
+	ctx := context.Background()
+	es := newFakeES()
+	client := es.client(t)
+	s := testSchema().WithDims(4)
+
+	err := PutIndexTemplate(ctx, client, &Template{Name: "docs"}, s)
+	assert.ErrorContains(t, err, "index patterns not provided")
+
+	err = PutIndexTemplate(ctx, client, &Template{Name: "docs", IndexPatterns: []string{"docs-*"}}, testSchema())
+	assert.ErrorContains(t, err, "dims of dense vector field")
+
+	priority := int64(10)
+	require.NoError(t, PutIndexTemplate(ctx, client, &Template{
+		Name:          "docs",
+		IndexPatterns: []string{"docs-*"},
+		Priority:      &priority,
+		Aliases:       []string{"docs"},
+	}, s))
+
+	var tmpl struct {
+		IndexPatterns []string `json:"index_patterns"`
+		Priority      int64    `json:"priority"`
+		Template      struct {
+			Aliases  map[string]any `json:"aliases"`
+			Mappings struct {
+				Properties map[string]map[string]any `json:"properties"`
+			} `json:"mappings"`
+		} `json:"template"`
+	}
+	require.NoError(t, json.Unmarshal(es.templates["docs"], &tmpl))
+	assert.Equal(t, []string{"docs-*"}, tmpl.IndexPatterns)
+	assert.Equal(t, int64(10), tmpl.Priority)
+	assert.Contains(t, tmpl.Template.Aliases, "docs")
+	assert.Equal(t, "dense_vector", tmpl.Template.Mappings.Properties["content_vector"]["type"])
+}
+
+func TestReindexAndSwap(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("alias not found", func(t *testing.T) {
+		es := newFakeES()
+		client := es.client(t)
+
+		index, err := ReindexAndSwap(ctx, client, "docs", testSchema().WithDims(4), &ReindexConfig{NewIndex: "docs-v1"})
+		require.NoError(t, err)
+		assert.Equal(t, "docs-v1", index)
+		assert.Equal(t, []string{"docs-v1"}, es.aliases["docs"])
+		assert.Empty(t, es.reindexed)
+	})
+
+	t.Run("index of the alias name", func(t *testing.T) {
+		es := newFakeES()
+		client := es.client(t)
+		require.NoError(t, CreateIndex(ctx, client, "docs", testSchema().WithDims(4)))
+
+		_, err := ReindexAndSwap(ctx, client, "docs", testSchema().WithDims(4), nil)
+		assert.ErrorContains(t, err, "docs is an index, not an alias")
+	})
+
+	t.Run("swap", func(t *testing.T) {
+		es := newFakeES()
+		client := es.client(t)
+		require.NoError(t, CreateIndex(ctx, client, "docs-v1", testSchema().WithDims(4), "docs"))
+
+		index, err := ReindexAndSwap(ctx, client, "docs", testSchema().WithDims(8), &ReindexConfig{DeleteOld: true})
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(index, "docs-2"), index)
+		assert.Equal(t, []string{index}, es.aliases["docs"])
+		assert.NotContains(t, es.mappings, "docs-v1")
+		assert.NoError(t, CheckIndex(ctx, client, "docs", testSchema().WithDims(8)))
+		require.Len(t, es.reindexed, 1)
+		assert.JSONEq(t, `{"source": {"index": ["docs-v1"]}, "dest": {"index": "`+index+`"}}`, es.reindexed[0])
+
+		_, err = ReindexAndSwap(ctx, client, "docs", testSchema().WithDims(8), &ReindexConfig{NewIndex: index})
+		assert.ErrorContains(t, err, "already points to")
+	})
+
+	t.Run("reindex failures", func(t *testing.T) {
+		es := newFakeES()
+		es.failures = true
+		client := es.client(t)
+		require.NoError(t, CreateIndex(ctx, client, "docs-v1", testSchema().WithDims(4), "docs"))
+
+		_, err := ReindexAndSwap(ctx, client, "docs", testSchema().WithDims(4), &ReindexConfig{NewIndex: "docs-v2"})
+		assert.ErrorContains(t, err, "1 failures, first: id=1, type=mapper_parsing_exception")
+		assert.Equal(t, []string{"docs-v1"}, es.aliases["docs"])
+	})
+}
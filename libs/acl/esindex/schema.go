@@ -0,0 +1,362 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package esindex
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+)
+
+// FieldType is the es mapping type of a Field.
+type FieldType string
+
+const (
+	FieldTypeText         FieldType = "text"
+	FieldTypeKeyword      FieldType = "keyword"
+	FieldTypeLong         FieldType = "long"
+	FieldTypeDouble       FieldType = "double"
+	FieldTypeBoolean      FieldType = "boolean"
+	FieldTypeDate         FieldType = "date"
+	FieldTypeDenseVector  FieldType = "dense_vector"
+	FieldTypeSparseVector FieldType = "sparse_vector"
+	FieldTypeRankFeatures FieldType = "rank_features"
+)
+
+// Similarity is the similarity of a dense_vector field.
+// see: https://www.elastic.co/guide/en/elasticsearch/reference/current/dense-vector.html#dense-vector-similarity
+type Similarity string
+
+const (
+	SimilarityCosine          Similarity = "cosine"
+	SimilarityDotProduct      Similarity = "dot_product"
+	SimilarityL2Norm          Similarity = "l2_norm"
+	SimilarityMaxInnerProduct Similarity = "max_inner_product"
+)
+
+// Field declares an es field written by the es8 indexer and searched by the es8 retriever.
+type Field struct {
+	// Name of the es field.
+	// Required.
+	Name string
+	// Type of the es field.
+	// Required.
+	Type FieldType
+	// Content if true, the field stores schema.Document.Content, only one text or keyword field can set it.
+	Content bool
+	// MetadataKey is the key of schema.Document.MetaData stored in the field.
+	MetadataKey string
+	// VectorOf is the name of the text or keyword field vectorized into this field.
+	// A dense_vector field stores the embedding of it, and a sparse_vector or rank_features field stores
+	// schema.Document.SparseVector, only one sparse field can set it.
+	VectorOf string
+	// Dims of a dense_vector field.
+	// Default 0, filled by WithDims, e.g. with the dimension of the embedder.
+	Dims int
+	// Similarity of a dense_vector field.
+	// Default SimilarityCosine.
+	Similarity Similarity
+	// Analyzer of a text field.
+	// Default the analyzer of the index.
+	Analyzer string
+}
+
+// Schema declares the fields of an index, so that the index, its mapping and templates can be created or checked from it,
+// and the indexer and the retriever agree on the field names.
+type Schema struct {
+	Fields []*Field
+	// Settings of the index created from the schema, e.g. shards, replicas and analyzers.
+	// Optional.
+	Settings *types.IndexSettings
+}
+
+// Validate checks the declaration of the fields.
+func (s *Schema) Validate() error {
+	names := make(map[string]*Field, len(s.Fields))
+	for _, f := range s.Fields {
+		if f.Name == "" {
+			return fmt.Errorf("[Validate] field name not provided")
+		}
+		if _, found := names[f.Name]; found {
+			return fmt.Errorf("[Validate] duplicate field: %s", f.Name)
+		}
+		names[f.Name] = f
+	}
+
+	var content, sparse *Field
+	for _, f := range s.Fields {
+		switch f.Type {
+		case FieldTypeText, FieldTypeKeyword, FieldTypeLong, FieldTypeDouble, FieldTypeBoolean, FieldTypeDate,
+			FieldTypeDenseVector, FieldTypeSparseVector, FieldTypeRankFeatures:
+		default:
+			return fmt.Errorf("[Validate] unknown type of field %s: %q", f.Name, f.Type)
+		}
+
+		if f.Content {
+			if f.Type != FieldTypeText && f.Type != FieldTypeKeyword {
+				return fmt.Errorf("[Validate] content field %s should be text or keyword", f.Name)
+			}
+			if content != nil {
+				return fmt.Errorf("[Validate] multiple content fields: %s, %s", content.Name, f.Name)
+			}
+			if f.MetadataKey != "" {
+				return fmt.Errorf("[Validate] content field %s can't store metadata", f.Name)
+			}
+			content = f
+		}
+
+		if f.Analyzer != "" && f.Type != FieldTypeText {
+			return fmt.Errorf("[Validate] analyzer of non text field %s", f.Name)
+		}
+
+		if !f.isVector() {
+			if f.VectorOf != "" || f.Dims != 0 || f.Similarity != "" {
+				return fmt.Errorf("[Validate] vector options of non vector field %s", f.Name)
+			}
+			continue
+		}
+
+		if f.Type != FieldTypeDenseVector && (f.Dims != 0 || f.Similarity != "") {
+			return fmt.Errorf("[Validate] dense vector options of sparse field %s", f.Name)
+		}
+		if f.Dims < 0 {
+			return fmt.Errorf("[Validate] invalid dims of field %s: %d", f.Name, f.Dims)
+		}
+		switch f.Similarity {
+		case "", SimilarityCosine, SimilarityDotProduct, SimilarityL2Norm, SimilarityMaxInnerProduct:
+		default:
+			return fmt.Errorf("[Validate] unknown similarity of field %s: %q", f.Name, f.Similarity)
+		}
+
+		if f.VectorOf == "" {
+			if f.MetadataKey == "" {
+				return fmt.Errorf("[Validate] vector field %s should set VectorOf or MetadataKey", f.Name)
+			}
+			continue
+		}
+		if f.MetadataKey != "" {
+			return fmt.Errorf("[Validate] vector field %s can't set both VectorOf and MetadataKey", f.Name)
+		}
+		source, found := names[f.VectorOf]
+		if !found || source.Type != FieldTypeText && source.Type != FieldTypeKeyword {
+			return fmt.Errorf("[Validate] vector field %s should be of a text or keyword field, got %q", f.Name, f.VectorOf)
+		}
+		if f.Type != FieldTypeDenseVector {
+			if sparse != nil {
+				return fmt.Errorf("[Validate] multiple sparse fields of document sparse vector: %s, %s", sparse.Name, f.Name)
+			}
+			sparse = f
+		} else if other := s.DenseVectorOf(f.VectorOf); other != f {
+			return fmt.Errorf("[Validate] multiple dense vector fields of %s: %s, %s", f.VectorOf, other.Name, f.Name)
+		}
+	}
+
+	return nil
+}
+
+// Field returns the field of name, nil if not found.
+func (s *Schema) Field(name string) *Field {
+	for _, f := range s.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// ContentField returns the field storing schema.Document.Content, nil if not declared.
+func (s *Schema) ContentField() *Field {
+	for _, f := range s.Fields {
+		if f.Content {
+			return f
+		}
+	}
+	return nil
+}
+
+// DenseVectorOf returns the dense_vector field of the field of name, nil if not declared.
+func (s *Schema) DenseVectorOf(name string) *Field {
+	for _, f := range s.Fields {
+		if f.Type == FieldTypeDenseVector && f.VectorOf == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// SparseVectorField returns the sparse_vector or rank_features field storing schema.Document.SparseVector, nil if not declared.
+func (s *Schema) SparseVectorField() *Field {
+	for _, f := range s.Fields {
+		if (f.Type == FieldTypeSparseVector || f.Type == FieldTypeRankFeatures) && f.VectorOf != "" {
+			return f
+		}
+	}
+	return nil
+}
+
+// NeedsDims reports whether a dense_vector field has no Dims.
+func (s *Schema) NeedsDims() bool {
+	for _, f := range s.Fields {
+		if f.Type == FieldTypeDenseVector && f.Dims == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// WithDims returns a copy of s with the dense_vector fields without Dims set to dims.
+func (s *Schema) WithDims(dims int) *Schema {
+	c := &Schema{Settings: s.Settings, Fields: make([]*Field, len(s.Fields))}
+	for i, f := range s.Fields {
+		cf := *f
+		if cf.Type == FieldTypeDenseVector && cf.Dims == 0 {
+			cf.Dims = dims
+		}
+		c.Fields[i] = &cf
+	}
+	return c
+}
+
+// Mapping returns the es mapping of the fields, the dims of all dense_vector fields should be known.
+func (s *Schema) Mapping() (*types.TypeMapping, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	m := &types.TypeMapping{Properties: make(map[string]types.Property, len(s.Fields))}
+	for _, f := range s.Fields {
+		var p types.Property
+		switch f.Type {
+		case FieldTypeText:
+			tp := types.NewTextProperty()
+			if f.Analyzer != "" {
+				tp.Analyzer = &f.Analyzer
+			}
+			p = tp
+		case FieldTypeKeyword:
+			p = types.NewKeywordProperty()
+		case FieldTypeLong:
+			p = types.NewLongNumberProperty()
+		case FieldTypeDouble:
+			p = types.NewDoubleNumberProperty()
+		case FieldTypeBoolean:
+			p = types.NewBooleanProperty()
+		case FieldTypeDate:
+			p = types.NewDateProperty()
+		case FieldTypeDenseVector:
+			if f.Dims == 0 {
+				return nil, fmt.Errorf("[Mapping] dims of dense vector field %s not provided", f.Name)
+			}
+			dp := types.NewDenseVectorProperty()
+			dp.Dims = of(f.Dims)
+			dp.Index = of(true)
+			dp.Similarity = of(string(f.similarity()))
+			p = dp
+		case FieldTypeSparseVector:
+			p = types.NewSparseVectorProperty()
+		case FieldTypeRankFeatures:
+			p = types.NewRankFeaturesProperty()
+		}
+		m.Properties[f.Name] = p
+	}
+
+	return m, nil
+}
+
+// Check compares the declared fields with the mapping of an existing index,
+// and returns an error listing the missing fields and the mismatched types, dims and similarities.
+// Fields of the mapping which aren't declared are ignored.
+func (s *Schema) Check(mapping *types.TypeMapping) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+
+	var problems []string
+	for _, f := range s.Fields {
+		p, found := mapping.Properties[f.Name]
+		if !found {
+			problems = append(problems, fmt.Sprintf("field %s not found", f.Name))
+			continue
+		}
+
+		actual, err := describe(p)
+		if err != nil {
+			return fmt.Errorf("[Check] decode mapping of field %s failed, %w", f.Name, err)
+		}
+		if actual.Type != string(f.Type) {
+			problems = append(problems, fmt.Sprintf("field %s is %s, expected %s", f.Name, actual.Type, f.Type))
+			continue
+		}
+		if f.Type != FieldTypeDenseVector {
+			continue
+		}
+		if f.Dims != 0 && actual.Dims != nil && *actual.Dims != f.Dims {
+			problems = append(problems, fmt.Sprintf("field %s has %d dims, expected %d", f.Name, *actual.Dims, f.Dims))
+		}
+		similarity := SimilarityCosine
+		if actual.Similarity != nil {
+			similarity = Similarity(*actual.Similarity)
+		}
+		if similarity != f.similarity() {
+			problems = append(problems, fmt.Sprintf("field %s has similarity %s, expected %s", f.Name, similarity, f.similarity()))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("[Check] mapping mismatch: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func (f *Field) isVector() bool {
+	return f.Type == FieldTypeDenseVector || f.Type == FieldTypeSparseVector || f.Type == FieldTypeRankFeatures
+}
+
+func (f *Field) similarity() Similarity {
+	if f.Similarity == "" {
+		return SimilarityCosine
+	}
+	return f.Similarity
+}
+
+type property struct {
+	Type       string  `json:"type"`
+	Dims       *int    `json:"dims"`
+	Similarity *string `json:"similarity"`
+}
+
+// describe returns the type, dims and similarity of a decoded mapping property.
+func describe(p types.Property) (*property, error) {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	d := &property{}
+	if err = json.Unmarshal(b, d); err != nil {
+		return nil, err
+	}
+	if d.Type == "" {
+		d.Type = "object"
+	}
+	return d, nil
+}
+
+func of[T any](v T) *T {
+	return &v
+}
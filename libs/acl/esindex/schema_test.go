@@ -0,0 +1,103 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package esindex
+
+import (
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaValidate(t *testing.T) {
+	valid := testSchema()
+	assert.NoError(t, valid.Validate())
+	assert.Equal(t, "content", valid.ContentField().Name)
+	assert.Equal(t, "content_vector", valid.DenseVectorOf("content").Name)
+	assert.Equal(t, "content_sparse", valid.SparseVectorField().Name)
+	assert.Equal(t, "source", valid.Field("source").Name)
+	assert.Nil(t, valid.Field("title"))
+	assert.Nil(t, valid.DenseVectorOf("source"))
+	assert.Nil(t, (&Schema{}).ContentField())
+	assert.Nil(t, (&Schema{}).SparseVectorField())
+
+	assert.True(t, valid.NeedsDims())
+	withDims := valid.WithDims(3)
+	assert.False(t, withDims.NeedsDims())
+	assert.Equal(t, 3, withDims.Fields[1].Dims)
+	assert.Equal(t, 0, valid.Fields[1].Dims)
+
+	cases := map[string][]*Field{
+		"field name not provided": {{Type: FieldTypeText}},
+		"duplicate field":         {{Name: "a", Type: FieldTypeText}, {Name: "a", Type: FieldTypeText}},
+		"unknown type":            {{Name: "a", Type: "vector"}},
+		"should be text or keyword": {
+			{Name: "a", Type: FieldTypeLong, Content: true},
+		},
+		"multiple content fields": {
+			{Name: "a", Type: FieldTypeText, Content: true}, {Name: "b", Type: FieldTypeText, Content: true},
+		},
+		"can't store metadata":   {{Name: "a", Type: FieldTypeText, Content: true, MetadataKey: "a"}},
+		"analyzer of non text":   {{Name: "a", Type: FieldTypeKeyword, Analyzer: "standard"}},
+		"vector options of non":  {{Name: "a", Type: FieldTypeKeyword, Dims: 3}},
+		"dense vector options":   {{Name: "a", Type: FieldTypeText}, {Name: "b", Type: FieldTypeSparseVector, VectorOf: "a", Dims: 3}},
+		"invalid dims":           {{Name: "a", Type: FieldTypeText}, {Name: "b", Type: FieldTypeDenseVector, VectorOf: "a", Dims: -1}},
+		"unknown similarity":     {{Name: "a", Type: FieldTypeText}, {Name: "b", Type: FieldTypeDenseVector, VectorOf: "a", Similarity: "jaccard"}},
+		"should set VectorOf":    {{Name: "b", Type: FieldTypeDenseVector}},
+		"can't set both":         {{Name: "a", Type: FieldTypeText}, {Name: "b", Type: FieldTypeDenseVector, VectorOf: "a", MetadataKey: "v"}},
+		"should be of a text":    {{Name: "a", Type: FieldTypeLong}, {Name: "b", Type: FieldTypeDenseVector, VectorOf: "a"}},
+		"multiple sparse fields": {{Name: "a", Type: FieldTypeText}, {Name: "b", Type: FieldTypeSparseVector, VectorOf: "a"}, {Name: "c", Type: FieldTypeRankFeatures, VectorOf: "a"}},
+		"multiple dense vector":  {{Name: "a", Type: FieldTypeText}, {Name: "b", Type: FieldTypeDenseVector, VectorOf: "a"}, {Name: "c", Type: FieldTypeDenseVector, VectorOf: "a"}},
+	}
+	for msg, fields := range cases {
+		err := (&Schema{Fields: fields}).Validate()
+		assert.ErrorContains(t, err, msg)
+	}
+
+	assert.NoError(t, (&Schema{Fields: []*Field{{Name: "v", Type: FieldTypeDenseVector, MetadataKey: "vector", Dims: 3}}}).Validate())
+}
+
+func TestSchemaMapping(t *testing.T) {
+	s := &Schema{Fields: []*Field{
+		{Name: "a", Type: FieldTypeKeyword, Content: true},
+		{Name: "b", Type: FieldTypeLong, MetadataKey: "b"},
+		{Name: "c", Type: FieldTypeDouble, MetadataKey: "c"},
+		{Name: "d", Type: FieldTypeBoolean, MetadataKey: "d"},
+		{Name: "e", Type: FieldTypeDate, MetadataKey: "e"},
+		{Name: "f", Type: FieldTypeRankFeatures, VectorOf: "a"},
+		{Name: "g", Type: FieldTypeDenseVector, VectorOf: "a", Dims: 2, Similarity: SimilarityL2Norm},
+	}}
+	m, err := s.Mapping()
+	assert.NoError(t, err)
+
+	got := map[string]string{}
+	for name, p := range m.Properties {
+		d, err := describe(p)
+		assert.NoError(t, err)
+		got[name] = d.Type
+	}
+	assert.Equal(t, map[string]string{"a": "keyword", "b": "long", "c": "double", "d": "boolean", "e": "date", "f": "rank_features", "g": "dense_vector"}, got)
+	assert.NoError(t, s.Check(m))
+
+	_, err = (&Schema{Fields: []*Field{{Name: "a"}}}).Mapping()
+	assert.Error(t, err)
+	assert.Error(t, (&Schema{Fields: []*Field{{Name: "a"}}}).Check(m))
+
+	// object fields have no type
+	err = (&Schema{Fields: []*Field{{Name: "a", Type: FieldTypeKeyword}}}).Check(&types.TypeMapping{Properties: map[string]types.Property{"a": types.NewObjectProperty()}})
+	assert.ErrorContains(t, err, "field a is object, expected keyword")
+}
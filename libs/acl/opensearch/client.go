@@ -0,0 +1,272 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Transport performs http requests of relative urls against an OpenSearch cluster.
+// *opensearch.Client of github.com/opensearch-project/opensearch-go implements it,
+// pass it to reuse its connection pool, TLS and AWS SigV4 signing.
+type Transport interface {
+	Perform(req *http.Request) (*http.Response, error)
+}
+
+type Config struct {
+	// BaseURL of the OpenSearch cluster, e.g. https://localhost:9200.
+	// Required if Transport is not provided.
+	BaseURL string
+	// Username and Password for basic authentication.
+	// Optional.
+	Username string
+	Password string
+	// HTTPClient sends requests to BaseURL.
+	// Optional, and the default value is a client with 30s timeout.
+	HTTPClient *http.Client
+	// Transport sends requests instead of BaseURL and HTTPClient, e.g. an opensearch-go client.
+	// Optional.
+	Transport Transport
+}
+
+// Client is the subset of the OpenSearch api used by the opensearch indexer and retriever.
+// see: https://opensearch.org/docs/latest/api-reference/
+type Client struct {
+	transport Transport
+}
+
+func NewClient(config *Config) (*Client, error) {
+	if config.Transport != nil {
+		return &Client{transport: config.Transport}, nil
+	}
+
+	if config.BaseURL == "" {
+		return nil, fmt.Errorf("[NewClient] base url not provided")
+	}
+
+	u, err := url.Parse(strings.TrimRight(config.BaseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("[NewClient] invalid base url, %w", err)
+	}
+
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	return &Client{transport: &httpTransport{
+		baseURL:    u,
+		username:   config.Username,
+		password:   config.Password,
+		httpClient: httpClient,
+	}}, nil
+}
+
+func (c *Client) IndexExists(ctx context.Context, index string) (bool, error) {
+	err := c.do(ctx, http.MethodHead, "/"+url.PathEscape(index), nil, nil, nil)
+	if err == nil {
+		return true, nil
+	}
+
+	var se *StatusError
+	if errors.As(err, &se) && se.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	return false, fmt.Errorf("[IndexExists] %w", err)
+}
+
+func (c *Client) CreateIndex(ctx context.Context, index string, req *CreateIndexRequest) error {
+	if err := c.do(ctx, http.MethodPut, "/"+url.PathEscape(index), nil, req, nil); err != nil {
+		return fmt.Errorf("[CreateIndex] %w", err)
+	}
+	return nil
+}
+
+// Bulk indexes docs, a failed item doesn't fail the request, check BulkResponse.Errors and the items.
+func (c *Client) Bulk(ctx context.Context, docs []*BulkDocument, refresh Refresh) (*BulkResponse, error) {
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, doc := range docs {
+		action := map[string]any{"index": bulkMeta{Index: doc.Index, ID: doc.ID}}
+		if err := enc.Encode(action); err != nil {
+			return nil, fmt.Errorf("[Bulk] marshal action failed, %w", err)
+		}
+		if err := enc.Encode(doc.Source); err != nil {
+			return nil, fmt.Errorf("[Bulk] marshal document failed, id=%s, %w", doc.ID, err)
+		}
+	}
+
+	var query url.Values
+	if refresh != "" {
+		query = url.Values{"refresh": []string{string(refresh)}}
+	}
+
+	resp := &BulkResponse{}
+	if err := c.do(ctx, http.MethodPost, "/_bulk", query, ndjson(body.Bytes()), resp); err != nil {
+		return nil, fmt.Errorf("[Bulk] %w", err)
+	}
+	return resp, nil
+}
+
+// Search searches index with body, running the search pipeline of name pipeline if it's not empty.
+func (c *Client) Search(ctx context.Context, index string, body any, pipeline string) (*SearchResponse, error) {
+	var query url.Values
+	if pipeline != "" {
+		query = url.Values{"search_pipeline": []string{pipeline}}
+	}
+
+	resp := &SearchResponse{}
+	if err := c.do(ctx, http.MethodPost, "/"+url.PathEscape(index)+"/_search", query, body, resp); err != nil {
+		return nil, fmt.Errorf("[Search] %w", err)
+	}
+	return resp, nil
+}
+
+// PutSearchPipeline creates or replaces the search pipeline of name, e.g. NormalizationPipeline for hybrid queries.
+func (c *Client) PutSearchPipeline(ctx context.Context, name string, pipeline *SearchPipeline) error {
+	if err := c.do(ctx, http.MethodPut, "/_search/pipeline/"+url.PathEscape(name), nil, pipeline, nil); err != nil {
+		return fmt.Errorf("[PutSearchPipeline] %w", err)
+	}
+	return nil
+}
+
+// StatusError is returned for responses of non 2xx status.
+type StatusError struct {
+	StatusCode int
+	// Type is the error type of the response, e.g. resource_already_exists_exception, empty if it isn't json.
+	Type string
+	// Reason is the error reason of the response, or the response body if it isn't json.
+	Reason string
+}
+
+func (e *StatusError) Error() string {
+	if e.Type == "" {
+		return fmt.Sprintf("opensearch status code %d: %s", e.StatusCode, e.Reason)
+	}
+	return fmt.Sprintf("opensearch status code %d: %s: %s", e.StatusCode, e.Type, e.Reason)
+}
+
+// ndjson is a request body sent as is with content type application/x-ndjson.
+type ndjson []byte
+
+// do sends a json request, and decodes the response to result if it's not nil.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, result any) error {
+	u := path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var (
+		reader      io.Reader
+		contentType string
+	)
+	switch b := body.(type) {
+	case nil:
+	case ndjson:
+		reader, contentType = bytes.NewReader(b), "application/x-ndjson"
+	default:
+		raw, err := json.Marshal(b)
+		if err != nil {
+			return fmt.Errorf("marshal request failed, %w", err)
+		}
+		reader, contentType = bytes.NewReader(raw), "application/json"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return fmt.Errorf("create request failed, %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.transport.Perform(req)
+	if err != nil {
+		return fmt.Errorf("request failed, %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response failed, %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newStatusError(resp.StatusCode, respBody)
+	}
+
+	if result == nil {
+		return nil
+	}
+	if err = json.Unmarshal(respBody, result); err != nil {
+		return fmt.Errorf("decode response failed, %w", err)
+	}
+	return nil
+}
+
+// newStatusError reads the error of an OpenSearch error response, of which error is either an object or a string.
+func newStatusError(statusCode int, body []byte) *StatusError {
+	var envelope struct {
+		Error json.RawMessage `json:"error"`
+	}
+	if json.Unmarshal(body, &envelope) == nil && len(envelope.Error) > 0 {
+		var cause ErrorCause
+		if json.Unmarshal(envelope.Error, &cause) == nil && cause.Reason != "" {
+			return &StatusError{StatusCode: statusCode, Type: cause.Type, Reason: cause.Reason}
+		}
+		var msg string
+		if json.Unmarshal(envelope.Error, &msg) == nil && msg != "" {
+			return &StatusError{StatusCode: statusCode, Reason: msg}
+		}
+	}
+	return &StatusError{StatusCode: statusCode, Reason: strings.TrimSpace(string(body))}
+}
+
+// httpTransport sends requests of relative urls to baseURL.
+type httpTransport struct {
+	baseURL    *url.URL
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+func (t *httpTransport) Perform(req *http.Request) (*http.Response, error) {
+	u := *t.baseURL
+	u.Path += req.URL.Path
+	u.RawPath = ""
+	if req.URL.RawPath != "" {
+		u.RawPath = t.baseURL.EscapedPath() + req.URL.RawPath
+	}
+	u.RawQuery = req.URL.RawQuery
+	req.URL = &u
+	req.Host = u.Host
+
+	if t.username != "" || t.password != "" {
+		req.SetBasicAuth(t.username, t.password)
+	}
+
+	return t.httpClient.Do(req)
+}
@@ -0,0 +1,210 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recorded struct {
+	method      string
+	path        string
+	query       string
+	contentType string
+	user        string
+	body        string
+}
+
+func newTestClient(t *testing.T, status int, response string) (*Client, *recorded) {
+	rec := &recorded{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec.method, rec.path, rec.query = r.Method, r.URL.EscapedPath(), r.URL.RawQuery
+		rec.contentType = r.Header.Get("Content-Type")
+		rec.user, _, _ = r.BasicAuth()
+		b, _ := io.ReadAll(r.Body)
+		rec.body = string(b)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(srv.Close)
+
+	cli, err := NewClient(&Config{BaseURL: srv.URL + "/prefix/", Username: "admin", Password: "pwd"})
+	require.NoError(t, err)
+	return cli, rec
+}
+
+type transportFunc func(req *http.Request) (*http.Response, error)
+
+func (f transportFunc) Perform(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNewClient(t *testing.T) {
+	_, err := NewClient(&Config{})
+	assert.Error(t, err)
+
+	_, err = NewClient(&Config{BaseURL: "http://a b:9200"})
+	assert.Error(t, err)
+
+	var path string
+	cli, err := NewClient(&Config{Transport: transportFunc(func(req *http.Request) (*http.Response, error) {
+		path = req.URL.String()
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil
+	})})
+	require.NoError(t, err)
+	exists, err := cli.IndexExists(context.Background(), "docs")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, "/docs", path)
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("index exists", func(t *testing.T) {
+		cli, rec := newTestClient(t, http.StatusOK, "")
+		exists, err := cli.IndexExists(ctx, "my docs")
+		require.NoError(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, http.MethodHead, rec.method)
+		assert.Equal(t, "/prefix/my%20docs", rec.path)
+		assert.Equal(t, "admin", rec.user)
+
+		cli, _ = newTestClient(t, http.StatusNotFound, "")
+		exists, err = cli.IndexExists(ctx, "docs")
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		cli, _ = newTestClient(t, http.StatusForbidden, "")
+		_, err = cli.IndexExists(ctx, "docs")
+		assert.Error(t, err)
+	})
+
+	t.Run("create index", func(t *testing.T) {
+		cli, rec := newTestClient(t, http.StatusOK, `{"acknowledged":true}`)
+		err := cli.CreateIndex(ctx, "docs", &CreateIndexRequest{
+			Settings: map[string]any{"index": map[string]any{"knn": true}},
+			Mappings: &Mappings{Properties: map[string]any{
+				"vector": KNNVectorProperty(3, &KNNMethod{Name: "hnsw", SpaceType: SpaceTypeCosine, Engine: EngineLucene}),
+			}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, http.MethodPut, rec.method)
+		assert.Equal(t, "/prefix/docs", rec.path)
+		assert.Equal(t, "application/json", rec.contentType)
+		assert.JSONEq(t, `{"settings":{"index":{"knn":true}},"mappings":{"properties":{"vector":{"type":"knn_vector","dimension":3,"method":{"name":"hnsw","space_type":"cosinesimil","engine":"lucene"}}}}}`, rec.body)
+	})
+
+	t.Run("bulk", func(t *testing.T) {
+		cli, rec := newTestClient(t, http.StatusOK, `{"took":3,"errors":true,"items":[`+
+			`{"index":{"_index":"docs","_id":"1","status":201,"result":"created"}},`+
+			`{"index":{"_index":"docs","_id":"2","status":400,"error":{"type":"mapper_parsing_exception","reason":"bad vector"}}}]}`)
+		resp, err := cli.Bulk(ctx, []*BulkDocument{
+			{Index: "docs", ID: "1", Source: map[string]any{"content": "a"}},
+			{Index: "docs", Source: map[string]any{"content": "b"}},
+		}, RefreshWaitFor)
+		require.NoError(t, err)
+		assert.Equal(t, http.MethodPost, rec.method)
+		assert.Equal(t, "/prefix/_bulk", rec.path)
+		assert.Equal(t, "refresh=wait_for", rec.query)
+		assert.Equal(t, "application/x-ndjson", rec.contentType)
+		assert.Equal(t, `{"index":{"_index":"docs","_id":"1"}}`+"\n"+`{"content":"a"}`+"\n"+
+			`{"index":{"_index":"docs"}}`+"\n"+`{"content":"b"}`+"\n", rec.body)
+		assert.True(t, resp.Errors)
+		require.Len(t, resp.Items, 2)
+		assert.Equal(t, "created", resp.Items[0]["index"].Result)
+		assert.Equal(t, "bad vector", resp.Items[1]["index"].Error.Reason)
+
+		_, err = cli.Bulk(ctx, []*BulkDocument{{Index: "docs", Source: func() {}}}, "")
+		assert.Error(t, err)
+	})
+
+	t.Run("search", func(t *testing.T) {
+		cli, rec := newTestClient(t, http.StatusOK, `{"took":1,"timed_out":false,"hits":{"total":{"value":1,"relation":"eq"},"max_score":0.9,`+
+			`"hits":[{"_index":"docs","_id":"1","_score":0.9,"_source":{"content":"a"}}]}}`)
+		resp, err := cli.Search(ctx, "docs", map[string]any{"size": 1}, "hybrid")
+		require.NoError(t, err)
+		assert.Equal(t, "/prefix/docs/_search", rec.path)
+		assert.Equal(t, "search_pipeline=hybrid", rec.query)
+		assert.JSONEq(t, `{"size":1}`, rec.body)
+		require.Len(t, resp.Hits.Hits, 1)
+		assert.Equal(t, "1", resp.Hits.Hits[0].ID)
+		assert.Equal(t, 0.9, *resp.Hits.Hits[0].Score)
+		assert.JSONEq(t, `{"content":"a"}`, string(resp.Hits.Hits[0].Source))
+
+		cli, _ = newTestClient(t, http.StatusOK, `not json`)
+		_, err = cli.Search(ctx, "docs", map[string]any{}, "")
+		assert.Error(t, err)
+
+		_, err = cli.Search(ctx, "docs", map[string]any{"bad": make(chan int)}, "")
+		assert.Error(t, err)
+	})
+
+	t.Run("put search pipeline", func(t *testing.T) {
+		cli, rec := newTestClient(t, http.StatusOK, `{"acknowledged":true}`)
+		err := cli.PutSearchPipeline(ctx, "hybrid", NormalizationPipeline(&NormalizationConfig{Weights: []float64{0.3, 0.7}}))
+		require.NoError(t, err)
+		assert.Equal(t, http.MethodPut, rec.method)
+		assert.Equal(t, "/prefix/_search/pipeline/hybrid", rec.path)
+		assert.JSONEq(t, `{"description":"hybrid search score normalization","phase_results_processors":[{"normalization-processor":{`+
+			`"normalization":{"technique":"min_max"},"combination":{"technique":"arithmetic_mean","parameters":{"weights":[0.3,0.7]}}}}]}`, rec.body)
+
+		b, err := json.Marshal(NormalizationPipeline(&NormalizationConfig{Normalization: "l2", Combination: "harmonic_mean"}))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"description":"hybrid search score normalization","phase_results_processors":[{"normalization-processor":{`+
+			`"normalization":{"technique":"l2"},"combination":{"technique":"harmonic_mean"}}}]}`, string(b))
+	})
+
+	t.Run("status error", func(t *testing.T) {
+		cli, _ := newTestClient(t, http.StatusBadRequest, `{"error":{"root_cause":[],"type":"resource_already_exists_exception","reason":"index [docs] already exists"},"status":400}`)
+		err := cli.CreateIndex(ctx, "docs", &CreateIndexRequest{})
+		var se *StatusError
+		require.True(t, errors.As(err, &se))
+		assert.Equal(t, http.StatusBadRequest, se.StatusCode)
+		assert.Equal(t, "resource_already_exists_exception", se.Type)
+		assert.Equal(t, "opensearch status code 400: resource_already_exists_exception: index [docs] already exists", se.Error())
+
+		cli, _ = newTestClient(t, http.StatusNotFound, `{"error":"no handler found","status":404}`)
+		err = cli.PutSearchPipeline(ctx, "p", &SearchPipeline{})
+		require.True(t, errors.As(err, &se))
+		assert.Equal(t, "opensearch status code 404: no handler found", se.Error())
+
+		cli, _ = newTestClient(t, http.StatusBadGateway, "bad gateway\n")
+		_, err = cli.Search(ctx, "docs", nil, "")
+		require.True(t, errors.As(err, &se))
+		assert.Equal(t, "bad gateway", se.Reason)
+	})
+
+	t.Run("transport error", func(t *testing.T) {
+		cli, err := NewClient(&Config{Transport: transportFunc(func(req *http.Request) (*http.Response, error) {
+			return nil, errors.New("connection refused")
+		})})
+		require.NoError(t, err)
+		_, err = cli.Bulk(ctx, nil, "")
+		assert.ErrorContains(t, err, "connection refused")
+	})
+}
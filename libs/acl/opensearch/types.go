@@ -0,0 +1,183 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package opensearch
+
+import "encoding/json"
+
+// SpaceType is the distance function of a knn_vector field.
+// see: https://opensearch.org/docs/latest/search-plugins/knn/approximate-knn/#spaces
+type SpaceType string
+
+const (
+	SpaceTypeL2           SpaceType = "l2"
+	SpaceTypeL1           SpaceType = "l1"
+	SpaceTypeLInf         SpaceType = "linf"
+	SpaceTypeCosine       SpaceType = "cosinesimil"
+	SpaceTypeInnerProduct SpaceType = "innerproduct"
+)
+
+// Engine is the library implementing the approximate knn search of a knn_vector field.
+type Engine string
+
+const (
+	EngineLucene Engine = "lucene"
+	EngineFaiss  Engine = "faiss"
+	EngineNmslib Engine = "nmslib"
+)
+
+// Refresh controls when the documents of a bulk request become searchable.
+type Refresh string
+
+const (
+	RefreshFalse   Refresh = "false"
+	RefreshTrue    Refresh = "true"
+	RefreshWaitFor Refresh = "wait_for"
+)
+
+// CreateIndexRequest is the body of the create index api.
+type CreateIndexRequest struct {
+	Settings map[string]any `json:"settings,omitempty"`
+	Mappings *Mappings      `json:"mappings,omitempty"`
+}
+
+type Mappings struct {
+	Properties map[string]any `json:"properties"`
+}
+
+// KNNMethod is the approximate knn method of a knn_vector field.
+type KNNMethod struct {
+	// Name of the method, e.g. hnsw.
+	Name      string    `json:"name"`
+	SpaceType SpaceType `json:"space_type,omitempty"`
+	Engine    Engine    `json:"engine,omitempty"`
+	// Parameters of the method, e.g. ef_construction and m of hnsw.
+	Parameters map[string]any `json:"parameters,omitempty"`
+}
+
+// KNNVectorProperty returns the mapping of a knn_vector field of dimension, method is optional.
+func KNNVectorProperty(dimension int, method *KNNMethod) map[string]any {
+	p := map[string]any{"type": "knn_vector", "dimension": dimension}
+	if method != nil {
+		p["method"] = method
+	}
+	return p
+}
+
+// BulkDocument is a document indexed by Client.Bulk, replacing the document of the same id.
+type BulkDocument struct {
+	Index string
+	// ID of the document, generated by OpenSearch if empty.
+	ID     string
+	Source any
+}
+
+type bulkMeta struct {
+	Index string `json:"_index"`
+	ID    string `json:"_id,omitempty"`
+}
+
+type BulkResponse struct {
+	Took   int64 `json:"took"`
+	Errors bool  `json:"errors"`
+	// Items are the results of the documents in request order, keyed by the action, e.g. "index".
+	Items []map[string]*BulkItem `json:"items"`
+}
+
+type BulkItem struct {
+	Index  string      `json:"_index"`
+	ID     string      `json:"_id"`
+	Status int         `json:"status"`
+	Result string      `json:"result,omitempty"`
+	Error  *ErrorCause `json:"error,omitempty"`
+}
+
+// ErrorCause is the error of a response or a bulk item.
+type ErrorCause struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+type SearchResponse struct {
+	Took     int64 `json:"took"`
+	TimedOut bool  `json:"timed_out"`
+	Hits     Hits  `json:"hits"`
+}
+
+type Hits struct {
+	Total    *TotalHits `json:"total,omitempty"`
+	MaxScore *float64   `json:"max_score"`
+	Hits     []*Hit     `json:"hits"`
+}
+
+type TotalHits struct {
+	Value    int64  `json:"value"`
+	Relation string `json:"relation"`
+}
+
+type Hit struct {
+	Index  string          `json:"_index"`
+	ID     string          `json:"_id"`
+	Score  *float64        `json:"_score"`
+	Source json.RawMessage `json:"_source,omitempty"`
+}
+
+// SearchPipeline is the body of the search pipeline api.
+// see: https://opensearch.org/docs/latest/search-plugins/search-pipelines/index/
+type SearchPipeline struct {
+	Description            string           `json:"description,omitempty"`
+	PhaseResultsProcessors []map[string]any `json:"phase_results_processors,omitempty"`
+}
+
+// NormalizationConfig configures the normalization processor combining the scores of the sub queries of a hybrid query.
+type NormalizationConfig struct {
+	// Normalization technique of sub query scores, "min_max" or "l2".
+	// Default "min_max".
+	Normalization string
+	// Combination technique of the normalized scores, "arithmetic_mean", "geometric_mean" or "harmonic_mean".
+	// Default "arithmetic_mean".
+	Combination string
+	// Weights of the sub queries in order, which should sum to 1.
+	// Default equal weights.
+	Weights []float64
+}
+
+// NormalizationPipeline returns a search pipeline of a normalization processor, which hybrid queries require.
+// see: https://opensearch.org/docs/latest/search-plugins/search-pipelines/normalization-processor/
+func NormalizationPipeline(config *NormalizationConfig) *SearchPipeline {
+	normalization, combination := config.Normalization, config.Combination
+	if normalization == "" {
+		normalization = "min_max"
+	}
+	if combination == "" {
+		combination = "arithmetic_mean"
+	}
+
+	comb := map[string]any{"technique": combination}
+	if len(config.Weights) > 0 {
+		comb["parameters"] = map[string]any{"weights": config.Weights}
+	}
+
+	return &SearchPipeline{
+		Description: "hybrid search score normalization",
+		PhaseResultsProcessors: []map[string]any{{
+			"normalization-processor": map[string]any{
+				"normalization": map[string]any{"technique": normalization},
+				"combination":   comb,
+			},
+		}},
+	}
+}
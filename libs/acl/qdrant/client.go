@@ -0,0 +1,227 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client is the subset of the qdrant api used by the qdrant indexer and retriever.
+// NewRESTClient implements it over the REST api, implement it with github.com/qdrant/go-client to use gRPC.
+type Client interface {
+	CollectionExists(ctx context.Context, collection string) (bool, error)
+	CreateCollection(ctx context.Context, collection string, req *CreateCollectionRequest) error
+	// UpsertPoints inserts points or overwrites the points of the same ids,
+	// it returns after the points are applied if wait is true, otherwise after they're received.
+	UpsertPoints(ctx context.Context, collection string, points []*Point, wait bool) error
+	DeletePoints(ctx context.Context, collection string, selector *PointsSelector, wait bool) error
+	// CountPoints returns the exact number of points matching filter, or of all points if filter is nil.
+	CountPoints(ctx context.Context, collection string, filter *Filter) (int64, error)
+	QueryPoints(ctx context.Context, collection string, req *QueryRequest) ([]*ScoredPoint, error)
+}
+
+type Config struct {
+	// BaseURL of the qdrant REST api, e.g. http://localhost:6333.
+	// Required.
+	BaseURL string
+	// APIKey sent in header api-key.
+	// Optional.
+	APIKey string
+	// HTTPClient sends requests.
+	// Optional, and the default value is a client with 30s timeout.
+	HTTPClient *http.Client
+}
+
+// RESTClient is a Client over the qdrant REST api.
+// see: https://api.qdrant.tech/api-reference
+type RESTClient struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+func NewRESTClient(config *Config) (*RESTClient, error) {
+	if config.BaseURL == "" {
+		return nil, fmt.Errorf("[NewRESTClient] base url not provided")
+	}
+
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	return &RESTClient{
+		baseURL:    strings.TrimRight(config.BaseURL, "/"),
+		apiKey:     config.APIKey,
+		httpClient: httpClient,
+	}, nil
+}
+
+func (c *RESTClient) CollectionExists(ctx context.Context, collection string) (bool, error) {
+	var result struct {
+		Exists bool `json:"exists"`
+	}
+	if err := c.do(ctx, http.MethodGet, collectionPath(collection, "exists"), nil, nil, &result); err != nil {
+		return false, fmt.Errorf("[CollectionExists] %w", err)
+	}
+	return result.Exists, nil
+}
+
+func (c *RESTClient) CreateCollection(ctx context.Context, collection string, req *CreateCollectionRequest) error {
+	if err := c.do(ctx, http.MethodPut, collectionPath(collection, ""), nil, req, nil); err != nil {
+		return fmt.Errorf("[CreateCollection] %w", err)
+	}
+	return nil
+}
+
+func (c *RESTClient) UpsertPoints(ctx context.Context, collection string, points []*Point, wait bool) error {
+	body := map[string]any{"points": points}
+	if err := c.do(ctx, http.MethodPut, collectionPath(collection, "points"), waitQuery(wait), body, nil); err != nil {
+		return fmt.Errorf("[UpsertPoints] %w", err)
+	}
+	return nil
+}
+
+func (c *RESTClient) DeletePoints(ctx context.Context, collection string, selector *PointsSelector, wait bool) error {
+	if err := c.do(ctx, http.MethodPost, collectionPath(collection, "points/delete"), waitQuery(wait), selector, nil); err != nil {
+		return fmt.Errorf("[DeletePoints] %w", err)
+	}
+	return nil
+}
+
+func (c *RESTClient) CountPoints(ctx context.Context, collection string, filter *Filter) (int64, error) {
+	body := map[string]any{"exact": true}
+	if filter != nil {
+		body["filter"] = filter
+	}
+
+	var result struct {
+		Count int64 `json:"count"`
+	}
+	if err := c.do(ctx, http.MethodPost, collectionPath(collection, "points/count"), nil, body, &result); err != nil {
+		return 0, fmt.Errorf("[CountPoints] %w", err)
+	}
+	return result.Count, nil
+}
+
+func (c *RESTClient) QueryPoints(ctx context.Context, collection string, req *QueryRequest) ([]*ScoredPoint, error) {
+	var result struct {
+		Points []*ScoredPoint `json:"points"`
+	}
+	if err := c.do(ctx, http.MethodPost, collectionPath(collection, "points/query"), nil, req, &result); err != nil {
+		return nil, fmt.Errorf("[QueryPoints] %w", err)
+	}
+	return result.Points, nil
+}
+
+// StatusError is returned for responses of non 2xx status.
+type StatusError struct {
+	StatusCode int
+	// Message is the error of the qdrant status, or the response body if it isn't json.
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("qdrant status code %d: %s", e.StatusCode, e.Message)
+}
+
+// do sends a json request, and decodes the result field of the response to result if it's not nil.
+func (c *RESTClient) do(ctx context.Context, method, path string, query url.Values, body, result any) error {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshal request failed, %w", err)
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return fmt.Errorf("create request failed, %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		req.Header.Set("api-key", c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed, %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response failed, %w", err)
+	}
+
+	var envelope struct {
+		Result json.RawMessage `json:"result"`
+		Status json.RawMessage `json:"status"`
+	}
+	decodeErr := json.Unmarshal(respBody, &envelope)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var status struct {
+			Error string `json:"error"`
+		}
+		if decodeErr == nil && json.Unmarshal(envelope.Status, &status) == nil && status.Error != "" {
+			return &StatusError{StatusCode: resp.StatusCode, Message: status.Error}
+		}
+		return &StatusError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(respBody))}
+	}
+
+	if result == nil {
+		return nil
+	}
+	if decodeErr != nil {
+		return fmt.Errorf("decode response failed, %w", decodeErr)
+	}
+	if err = json.Unmarshal(envelope.Result, result); err != nil {
+		return fmt.Errorf("decode result failed, %w", err)
+	}
+	return nil
+}
+
+func collectionPath(collection, sub string) string {
+	p := "/collections/" + url.PathEscape(collection)
+	if sub != "" {
+		p += "/" + sub
+	}
+	return p
+}
+
+func waitQuery(wait bool) url.Values {
+	return url.Values{"wait": []string{strconv.FormatBool(wait)}}
+}
@@ -0,0 +1,230 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recorded struct {
+	method string
+	path   string
+	query  string
+	apiKey string
+	body   map[string]any
+}
+
+func newTestClient(t *testing.T, status int, response string) (*RESTClient, *recorded) {
+	rec := &recorded{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec.method, rec.path, rec.query = r.Method, r.URL.EscapedPath(), r.URL.RawQuery
+		rec.apiKey = r.Header.Get("api-key")
+		b, _ := io.ReadAll(r.Body)
+		rec.body = nil
+		if len(b) > 0 {
+			require.NoError(t, json.Unmarshal(b, &rec.body))
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(srv.Close)
+
+	cli, err := NewRESTClient(&Config{BaseURL: srv.URL + "/", APIKey: "key"})
+	require.NoError(t, err)
+	return cli, rec
+}
+
+func TestNewRESTClient(t *testing.T) {
+	_, err := NewRESTClient(&Config{})
+	assert.Error(t, err)
+}
+
+func TestRESTClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("collection exists", func(t *testing.T) {
+		cli, rec := newTestClient(t, http.StatusOK, `{"result":{"exists":true},"status":"ok"}`)
+		exists, err := cli.CollectionExists(ctx, "my docs")
+		require.NoError(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, http.MethodGet, rec.method)
+		assert.Equal(t, "/collections/my%20docs/exists", rec.path)
+		assert.Equal(t, "key", rec.apiKey)
+	})
+
+	t.Run("create collection", func(t *testing.T) {
+		cli, rec := newTestClient(t, http.StatusOK, `{"result":true,"status":"ok"}`)
+		err := cli.CreateCollection(ctx, "docs", &CreateCollectionRequest{
+			Vectors:       VectorsConfig{"dense": {Size: 3, Distance: DistanceCosine}},
+			SparseVectors: map[string]*SparseVectorParams{"sparse": {Modifier: "idf"}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, http.MethodPut, rec.method)
+		assert.Equal(t, "/collections/docs", rec.path)
+		assert.Equal(t, map[string]any{
+			"vectors":        map[string]any{"dense": map[string]any{"size": 3.0, "distance": "Cosine"}},
+			"sparse_vectors": map[string]any{"sparse": map[string]any{"modifier": "idf"}},
+		}, rec.body)
+	})
+
+	t.Run("upsert points", func(t *testing.T) {
+		cli, rec := newTestClient(t, http.StatusOK, `{"result":{"status":"completed"},"status":"ok"}`)
+		sparse, err := NewSparseVector(map[int]float64{7: 0.5, 2: 1})
+		require.NoError(t, err)
+		err = cli.UpsertPoints(ctx, "docs", []*Point{{
+			ID:      uint64(1),
+			Vector:  Vectors{Dense: map[string][]float64{"dense": {0.1, 0.2}}, Sparse: map[string]*SparseVector{"sparse": sparse}},
+			Payload: map[string]any{"content": "hello"},
+		}}, true)
+		require.NoError(t, err)
+		assert.Equal(t, http.MethodPut, rec.method)
+		assert.Equal(t, "/collections/docs/points", rec.path)
+		assert.Equal(t, "wait=true", rec.query)
+		assert.Equal(t, map[string]any{"points": []any{map[string]any{
+			"id": 1.0,
+			"vector": map[string]any{
+				"dense":  []any{0.1, 0.2},
+				"sparse": map[string]any{"indices": []any{2.0, 7.0}, "values": []any{1.0, 0.5}},
+			},
+			"payload": map[string]any{"content": "hello"},
+		}}}, rec.body)
+	})
+
+	t.Run("delete points", func(t *testing.T) {
+		cli, rec := newTestClient(t, http.StatusOK, `{"result":{"status":"acknowledged"},"status":"ok"}`)
+		err := cli.DeletePoints(ctx, "docs", &PointsSelector{Filter: &Filter{Must: []*Condition{NewMatch("metadata.draft", false)}}}, false)
+		require.NoError(t, err)
+		assert.Equal(t, "/collections/docs/points/delete", rec.path)
+		assert.Equal(t, "wait=false", rec.query)
+		assert.Equal(t, map[string]any{"filter": map[string]any{"must": []any{
+			map[string]any{"key": "metadata.draft", "match": map[string]any{"value": false}},
+		}}}, rec.body)
+	})
+
+	t.Run("count points", func(t *testing.T) {
+		cli, rec := newTestClient(t, http.StatusOK, `{"result":{"count":3},"status":"ok"}`)
+		n, err := cli.CountPoints(ctx, "docs", &Filter{MustNot: []*Condition{NewHasID("a")}})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), n)
+		assert.Equal(t, map[string]any{
+			"exact":  true,
+			"filter": map[string]any{"must_not": []any{map[string]any{"has_id": []any{"a"}}}},
+		}, rec.body)
+	})
+
+	t.Run("query points", func(t *testing.T) {
+		cli, rec := newTestClient(t, http.StatusOK, `{"result":{"points":[
+			{"id":5,"score":0.9,"payload":{"content":"a"}},
+			{"id":"6ba7b811-9dad-11d1-80b4-00c04fd430c8","score":0.5}
+		]},"status":"ok"}`)
+		threshold := 0.1
+		points, err := cli.QueryPoints(ctx, "docs", &QueryRequest{
+			Prefetch: []*Prefetch{
+				{Query: []float64{1}, Using: "dense", Limit: 4},
+				{Query: &SparseVector{Indices: []uint32{1}, Values: []float64{1}}, Using: "sparse", Limit: 4},
+			},
+			Query:          &FusionQuery{Fusion: FusionRRF},
+			Filter:         &Filter{Must: []*Condition{NewFilterCondition(&Filter{Should: []*Condition{NewMatchAny("k", "a", "b")}})}},
+			Limit:          2,
+			ScoreThreshold: &threshold,
+			WithPayload:    true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "/collections/docs/points/query", rec.path)
+		assert.Equal(t, map[string]any{
+			"prefetch": []any{
+				map[string]any{"query": []any{1.0}, "using": "dense", "limit": 4.0},
+				map[string]any{"query": map[string]any{"indices": []any{1.0}, "values": []any{1.0}}, "using": "sparse", "limit": 4.0},
+			},
+			"query": map[string]any{"fusion": "rrf"},
+			"filter": map[string]any{"must": []any{map[string]any{"should": []any{
+				map[string]any{"key": "k", "match": map[string]any{"any": []any{"a", "b"}}},
+			}}}},
+			"limit":           2.0,
+			"score_threshold": 0.1,
+			"with_payload":    true,
+		}, rec.body)
+		assert.Equal(t, []*ScoredPoint{
+			{ID: uint64(5), Score: 0.9, Payload: map[string]any{"content": "a"}},
+			{ID: "6ba7b811-9dad-11d1-80b4-00c04fd430c8", Score: 0.5},
+		}, points)
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		cli, _ := newTestClient(t, http.StatusNotFound, `{"status":{"error":"Not found: Collection docs doesn't exist!"}}`)
+		_, err := cli.QueryPoints(ctx, "docs", &QueryRequest{})
+		var statusErr *StatusError
+		require.True(t, errors.As(err, &statusErr))
+		assert.Equal(t, http.StatusNotFound, statusErr.StatusCode)
+		assert.Equal(t, "Not found: Collection docs doesn't exist!", statusErr.Message)
+
+		cli, _ = newTestClient(t, http.StatusBadGateway, "bad gateway")
+		err = cli.UpsertPoints(ctx, "docs", nil, true)
+		require.True(t, errors.As(err, &statusErr))
+		assert.Equal(t, "bad gateway", statusErr.Message)
+	})
+
+	t.Run("invalid result", func(t *testing.T) {
+		cli, _ := newTestClient(t, http.StatusOK, `{"result":{"count":"x"}}`)
+		_, err := cli.CountPoints(ctx, "docs", nil)
+		assert.Error(t, err)
+
+		cli, _ = newTestClient(t, http.StatusOK, `{"result":{"points":[{"id":-1}]}}`)
+		_, err = cli.QueryPoints(ctx, "docs", &QueryRequest{})
+		assert.Error(t, err)
+	})
+}
+
+func TestVectorsMarshal(t *testing.T) {
+	b, err := json.Marshal(Vectors{Dense: map[string][]float64{"": {1, 2}}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `[1,2]`, string(b))
+
+	_, err = json.Marshal(Vectors{Dense: map[string][]float64{"": {1}}, Sparse: map[string]*SparseVector{"s": {}}})
+	assert.Error(t, err)
+
+	_, err = json.Marshal(Vectors{Dense: map[string][]float64{"v": {1}}, Sparse: map[string]*SparseVector{"v": {}}})
+	assert.Error(t, err)
+
+	b, err = json.Marshal(VectorsConfig{"": {Size: 2, Distance: DistanceDot}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"size":2,"distance":"Dot"}`, string(b))
+
+	_, err = json.Marshal(VectorsConfig{"": {}, "v": {}})
+	assert.Error(t, err)
+
+	_, err = NewSparseVector(map[int]float64{-1: 1})
+	assert.Error(t, err)
+}
+
+func TestPointID(t *testing.T) {
+	assert.Equal(t, uint64(42), PointID("42"))
+	assert.Equal(t, "6ba7b811-9dad-11d1-80b4-00c04fd430c8", PointID("6BA7B811-9DAD-11D1-80B4-00C04FD430C8"))
+	// uuid v5 of the URL namespace
+	assert.Equal(t, "3f622591-baa6-5888-8a4f-6b3813e16a44", PointID("doc-1"))
+	assert.NotEqual(t, PointID("doc-1"), PointID("doc-2"))
+	assert.IsType(t, "", PointID("-42"))
+}
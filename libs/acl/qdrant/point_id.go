@@ -0,0 +1,69 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// idNamespace is the UUID namespace of point ids derived from document ids, the URL namespace of RFC 4122.
+var idNamespace = [16]byte{0x6b, 0xa7, 0xb8, 0x11, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+
+// PointID converts a document id to a qdrant point id, which is an unsigned integer or a UUID.
+// Unsigned integers and UUIDs are kept, other ids are mapped to their UUID v5, so the same id is always the same point.
+func PointID(id string) any {
+	if n, err := strconv.ParseUint(id, 10, 64); err == nil {
+		return n
+	}
+	if isUUID(id) {
+		return strings.ToLower(id)
+	}
+	return uuidV5(id)
+}
+
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i, c := range s {
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func uuidV5(name string) string {
+	h := sha1.New()
+	h.Write(idNamespace[:])
+	h.Write([]byte(name))
+	sum := h.Sum(nil)
+
+	sum[6] = sum[6]&0x0f | 0x50
+	sum[8] = sum[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
@@ -0,0 +1,291 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qdrant
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Distance of dense vectors.
+type Distance string
+
+const (
+	DistanceCosine    Distance = "Cosine"
+	DistanceDot       Distance = "Dot"
+	DistanceEuclid    Distance = "Euclid"
+	DistanceManhattan Distance = "Manhattan"
+)
+
+type VectorParams struct {
+	Size     int      `json:"size"`
+	Distance Distance `json:"distance"`
+	OnDisk   *bool    `json:"on_disk,omitempty"`
+}
+
+// VectorsConfig dense vectors of a collection by name, the vector named "" is the unnamed default vector,
+// which can't be mixed with named ones.
+type VectorsConfig map[string]*VectorParams
+
+func (v VectorsConfig) MarshalJSON() ([]byte, error) {
+	if p, ok := v[""]; ok {
+		if len(v) > 1 {
+			return nil, fmt.Errorf("[VectorsConfig] unnamed vector mixed with named vectors")
+		}
+		return json.Marshal(p)
+	}
+	return json.Marshal(map[string]*VectorParams(v))
+}
+
+type SparseVectorParams struct {
+	// Modifier "idf" makes qdrant weight sparse vectors by inverse document frequency, e.g. for BM25.
+	Modifier string `json:"modifier,omitempty"`
+}
+
+type CreateCollectionRequest struct {
+	Vectors       VectorsConfig                  `json:"vectors,omitempty"`
+	SparseVectors map[string]*SparseVectorParams `json:"sparse_vectors,omitempty"`
+}
+
+// SparseVector non-zero values of a sparse vector by their indices.
+type SparseVector struct {
+	Indices []uint32  `json:"indices"`
+	Values  []float64 `json:"values"`
+}
+
+// NewSparseVector converts a sparse vector of index - value pairs, e.g. schema.Document.SparseVector(),
+// with indices in ascending order.
+func NewSparseVector(sparse map[int]float64) (*SparseVector, error) {
+	indices := make([]int, 0, len(sparse))
+	for idx := range sparse {
+		if idx < 0 || int64(idx) > int64(^uint32(0)) {
+			return nil, fmt.Errorf("[NewSparseVector] index out of range: %d", idx)
+		}
+		indices = append(indices, idx)
+	}
+	sort.Ints(indices)
+
+	v := &SparseVector{Indices: make([]uint32, len(indices)), Values: make([]float64, len(indices))}
+	for i, idx := range indices {
+		v.Indices[i] = uint32(idx)
+		v.Values[i] = sparse[idx]
+	}
+	return v, nil
+}
+
+// Vectors of a point, Dense[""] is the unnamed default vector.
+type Vectors struct {
+	Dense  map[string][]float64
+	Sparse map[string]*SparseVector
+}
+
+func (v Vectors) MarshalJSON() ([]byte, error) {
+	if dense, ok := v.Dense[""]; ok {
+		if len(v.Dense) > 1 || len(v.Sparse) > 0 {
+			return nil, fmt.Errorf("[Vectors] unnamed vector mixed with named vectors")
+		}
+		return json.Marshal(dense)
+	}
+
+	named := make(map[string]any, len(v.Dense)+len(v.Sparse))
+	for name, dense := range v.Dense {
+		named[name] = dense
+	}
+	for name, sparse := range v.Sparse {
+		if _, found := named[name]; found {
+			return nil, fmt.Errorf("[Vectors] duplicate vector name: %s", name)
+		}
+		named[name] = sparse
+	}
+	return json.Marshal(named)
+}
+
+type Point struct {
+	// ID an unsigned integer or a UUID string, see PointID.
+	ID      any            `json:"id"`
+	Vector  Vectors        `json:"vector"`
+	Payload map[string]any `json:"payload,omitempty"`
+}
+
+// PointsSelector selects points by ids or by filter.
+type PointsSelector struct {
+	Points []any   `json:"points,omitempty"`
+	Filter *Filter `json:"filter,omitempty"`
+}
+
+// Filter of points by payload, conditions of Must all match, at least one of Should matches, and none of MustNot matches.
+// see: https://qdrant.tech/documentation/concepts/filtering/
+type Filter struct {
+	Must    []*Condition `json:"must,omitempty"`
+	Should  []*Condition `json:"should,omitempty"`
+	MustNot []*Condition `json:"must_not,omitempty"`
+}
+
+// Condition is a field condition, a has_id condition, or a nested filter.
+type Condition struct {
+	Key     string    `json:"key,omitempty"`
+	Match   *Match    `json:"match,omitempty"`
+	Range   *Range    `json:"range,omitempty"`
+	IsEmpty *FieldKey `json:"is_empty,omitempty"`
+	IsNull  *FieldKey `json:"is_null,omitempty"`
+	HasID   []any     `json:"has_id,omitempty"`
+
+	*Filter
+}
+
+type Match struct {
+	// Value keyword, integer or bool to match exactly.
+	Value any `json:"value,omitempty"`
+	// Any matches any of the keywords or integers.
+	Any []any `json:"any,omitempty"`
+	// Except matches none of the keywords or integers.
+	Except []any `json:"except,omitempty"`
+	// Text full-text match of a text indexed field.
+	Text string `json:"text,omitempty"`
+}
+
+// MarshalJSON keeps zero values, e.g. false or 0, which omitempty would drop.
+func (m Match) MarshalJSON() ([]byte, error) {
+	type match Match
+	if m.Value == nil {
+		return json.Marshal(match(m))
+	}
+	return json.Marshal(struct {
+		Value any `json:"value"`
+	}{Value: m.Value})
+}
+
+type Range struct {
+	Gt  *float64 `json:"gt,omitempty"`
+	Gte *float64 `json:"gte,omitempty"`
+	Lt  *float64 `json:"lt,omitempty"`
+	Lte *float64 `json:"lte,omitempty"`
+}
+
+type FieldKey struct {
+	Key string `json:"key"`
+}
+
+// NewMatch matches key equal to value, a keyword, integer or bool.
+func NewMatch(key string, value any) *Condition {
+	return &Condition{Key: key, Match: &Match{Value: value}}
+}
+
+// NewMatchAny matches key equal to any of values, keywords or integers.
+func NewMatchAny(key string, values ...any) *Condition {
+	return &Condition{Key: key, Match: &Match{Any: values}}
+}
+
+// NewRange matches numeric key within r.
+func NewRange(key string, r *Range) *Condition {
+	return &Condition{Key: key, Range: r}
+}
+
+// NewIsEmpty matches points without key, or with key null or [].
+func NewIsEmpty(key string) *Condition {
+	return &Condition{IsEmpty: &FieldKey{Key: key}}
+}
+
+// NewHasID matches points of ids.
+func NewHasID(ids ...any) *Condition {
+	return &Condition{HasID: ids}
+}
+
+// NewFilterCondition nests f in a condition.
+func NewFilterCondition(f *Filter) *Condition {
+	return &Condition{Filter: f}
+}
+
+type SearchParams struct {
+	// HnswEf size of the candidate list of HNSW search, larger is more accurate and slower.
+	HnswEf *int `json:"hnsw_ef,omitempty"`
+	// Exact disables the index, searching all points.
+	Exact *bool `json:"exact,omitempty"`
+}
+
+// Fusion of the results of prefetch queries.
+type Fusion string
+
+const (
+	// FusionRRF reciprocal rank fusion.
+	FusionRRF Fusion = "rrf"
+	// FusionDBSF distribution-based score fusion.
+	FusionDBSF Fusion = "dbsf"
+)
+
+type FusionQuery struct {
+	Fusion Fusion `json:"fusion"`
+}
+
+// Prefetch is a sub query of QueryRequest, whose results are the candidates of the main query.
+type Prefetch struct {
+	// Query []float64 of a dense vector, or *SparseVector.
+	Query          any           `json:"query,omitempty"`
+	Using          string        `json:"using,omitempty"`
+	Filter         *Filter       `json:"filter,omitempty"`
+	Params         *SearchParams `json:"params,omitempty"`
+	Limit          int           `json:"limit,omitempty"`
+	ScoreThreshold *float64      `json:"score_threshold,omitempty"`
+}
+
+// QueryRequest of the universal query api.
+// see: https://qdrant.tech/documentation/concepts/hybrid-queries/
+type QueryRequest struct {
+	Prefetch []*Prefetch `json:"prefetch,omitempty"`
+	// Query []float64 of a dense vector, *SparseVector, or *FusionQuery fusing Prefetch.
+	Query          any           `json:"query,omitempty"`
+	Using          string        `json:"using,omitempty"`
+	Filter         *Filter       `json:"filter,omitempty"`
+	Params         *SearchParams `json:"params,omitempty"`
+	Limit          int           `json:"limit,omitempty"`
+	ScoreThreshold *float64      `json:"score_threshold,omitempty"`
+	WithPayload    bool          `json:"with_payload"`
+}
+
+type ScoredPoint struct {
+	// ID uint64 or UUID string.
+	ID      any
+	Score   float64
+	Payload map[string]any
+}
+
+func (p *ScoredPoint) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		ID      json.RawMessage `json:"id"`
+		Score   float64         `json:"score"`
+		Payload map[string]any  `json:"payload"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	p.Score, p.Payload = raw.Score, raw.Payload
+
+	var id string
+	if err := json.Unmarshal(raw.ID, &id); err == nil {
+		p.ID = id
+		return nil
+	}
+	n, err := strconv.ParseUint(string(raw.ID), 10, 64)
+	if err != nil {
+		return fmt.Errorf("[ScoredPoint] invalid point id: %s", raw.ID)
+	}
+	p.ID = n
+	return nil
+}
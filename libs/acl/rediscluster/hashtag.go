@@ -0,0 +1,32 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package rediscluster holds the redis cluster checks shared by the redis indexer and retriever.
+package rediscluster
+
+import "strings"
+
+// HasHashTag reports whether s contains a non-empty hash tag, e.g. "{eino_doc}:" or "{eino_doc}idx".
+// Keys and indexes used together on a cluster must share a hash tag to be in the same slot.
+// see: https://redis.io/docs/latest/operate/oss_and_stack/reference/cluster-spec/#hash-tags
+func HasHashTag(s string) bool {
+	start := strings.Index(s, "{")
+	if start < 0 {
+		return false
+	}
+	end := strings.Index(s[start+1:], "}")
+	return end > 0
+}
@@ -0,0 +1,32 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rediscluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasHashTag(t *testing.T) {
+	assert.True(t, HasHashTag("{eino_doc}:"))
+	assert.True(t, HasHashTag("{eino_doc}idx"))
+	assert.True(t, HasHashTag("doc:{a}"))
+	assert.False(t, HasHashTag("eino_doc:"))
+	assert.False(t, HasHashTag("{}idx"))
+	assert.False(t, HasHashTag("{eino_doc"))
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tokenusage estimates the token usage of embedders whose server doesn't report it.
+package tokenusage
+
+import (
+	"unicode"
+
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+// ExtraKeyEstimated is set to true in embedding.CallbackOutput.Extra
+// when TokenUsage is counted locally instead of being reported by the server.
+const ExtraKeyEstimated = "token_usage_estimated"
+
+// Estimate approximates the token count of a text: one token per CJK character,
+// and one token per four other letters or digits of a word, punctuation counted as one token each.
+func Estimate(text string) int {
+	n, word := 0, 0
+	flush := func() {
+		n += (word + 3) / 4
+		word = 0
+	}
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) ||
+			unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r):
+			flush()
+			n++
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word++
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			flush()
+			n++
+		}
+	}
+	flush()
+	return n
+}
+
+// Count sums the tokens of texts with counter, [Estimate] is used if counter is nil.
+func Count(counter func(text string) int, texts []string) *embedding.TokenUsage {
+	if counter == nil {
+		counter = Estimate
+	}
+	total := 0
+	for _, text := range texts {
+		total += counter(text)
+	}
+	return &embedding.TokenUsage{PromptTokens: total, TotalTokens: total}
+}
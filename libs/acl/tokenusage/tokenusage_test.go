@@ -0,0 +1,37 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tokenusage
+
+import (
+	"testing"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimate(t *testing.T) {
+	assert.Equal(t, 0, Estimate(""))
+	assert.Equal(t, 3, Estimate("the cat sat"))
+	assert.Equal(t, 4, Estimate("hello world"))
+	assert.Equal(t, 6, Estimate("embedding, ok?"))
+	assert.Equal(t, 4, Estimate("你好世界"))
+}
+
+func TestCount(t *testing.T) {
+	assert.Equal(t, &embedding.TokenUsage{PromptTokens: 3, TotalTokens: 3}, Count(nil, []string{"the cat", "你"}))
+	assert.Equal(t, &embedding.TokenUsage{PromptTokens: 10, TotalTokens: 10}, Count(func(string) int { return 5 }, []string{"a", "b"}))
+}